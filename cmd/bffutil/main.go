@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -287,6 +288,61 @@ func main() {
 				},
 			},
 		},
+		{
+			Name:      "apikeys:list",
+			Usage:     "list the api keys of an organization",
+			Action:    listAPIKeys,
+			ArgsUsage: "orgID",
+			Before:    connectDB,
+			After:     closeDB,
+		},
+		{
+			Name:   "apikeys:create",
+			Usage:  "create an api key for machine-to-machine access to the bff",
+			Action: createAPIKey,
+			Before: connectDB,
+			After:  closeDB,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "org",
+					Aliases:  []string{"o"},
+					Usage:    "specify the organization id to create the api key for",
+					Required: true,
+				},
+				&cli.StringSliceFlag{
+					Name:    "permission",
+					Aliases: []string{"p"},
+					Usage:   fmt.Sprintf("permissions to grant the api key (%s)", strings.Join(auth.APIKeyPermissions, ", ")),
+					Value:   cli.NewStringSlice(auth.ReadVASP),
+				},
+				&cli.StringFlag{
+					Name:    "description",
+					Aliases: []string{"d"},
+					Usage:   "describe what the api key is used for",
+				},
+				&cli.TimestampFlag{
+					Name:    "expires",
+					Aliases: []string{"e"},
+					Usage:   "timestamp when the api key expires, if omitted the key does not expire",
+					Layout:  time.RFC3339,
+				},
+			},
+		},
+		{
+			Name:      "apikeys:revoke",
+			Usage:     "revoke an api key so that it can no longer be used",
+			Action:    revokeAPIKey,
+			ArgsUsage: "clientID",
+			Before:    connectDB,
+			After:     closeDB,
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:    "force",
+					Aliases: []string{"f"},
+					Usage:   "do not prompt to confirm operation",
+				},
+			},
+		},
 		{
 			Name:   "appdata:sortorgs",
 			Usage:  "sort the organization list on each user's app_metadata",
@@ -1270,6 +1326,131 @@ func exportEmails(c *cli.Context) (err error) {
 	return nil
 }
 
+func listAPIKeys(c *cli.Context) (err error) {
+	if c.NArg() != 1 {
+		return cli.Exit("specify an orgID to list api keys for", 1)
+	}
+
+	var org *models.Organization
+	if org, err = GetOrg(c.Args().Get(0)); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	if len(org.ApiKeys) == 0 {
+		fmt.Println("organization has no api keys")
+		return nil
+	}
+
+	// Do not print the secret hashes of the keys
+	keys := make([]map[string]interface{}, 0, len(org.ApiKeys))
+	for _, key := range org.ApiKeys {
+		keys = append(keys, map[string]interface{}{
+			"client_id":   key.ClientId,
+			"description": key.Description,
+			"permissions": key.Permissions,
+			"created_by":  key.CreatedBy,
+			"expires":     key.Expires,
+			"last_used":   key.LastUsed,
+			"revoked":     key.Revoked,
+			"created":     key.Created,
+		})
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i]["created"].(string) < keys[j]["created"].(string)
+	})
+	return printJSON(keys)
+}
+
+func createAPIKey(c *cli.Context) (err error) {
+	var org *models.Organization
+	if org, err = GetOrg(c.String("org")); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	permissions := models.NormalizeAPIKeyPermissions(c.StringSlice("permission"))
+	if err = auth.ValidAPIKeyPermissions(permissions); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	var expires time.Time
+	if ts := c.Timestamp("expires"); ts != nil {
+		if !ts.After(time.Now()) {
+			return cli.Exit("api key expiration must be in the future", 1)
+		}
+		expires = *ts
+	}
+
+	var (
+		key    *models.APIKey
+		secret string
+	)
+	if key, secret, err = models.NewAPIKey(org.Id, c.String("description"), permissions, expires); err != nil {
+		return cli.Exit(err, 1)
+	}
+	key.CreatedBy = "bffutil"
+
+	if err = org.AddAPIKey(key); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	ctx, cancel := utils.WithDeadline(context.Background())
+	defer cancel()
+
+	if err = db.UpdateOrganization(ctx, org); err != nil {
+		return cli.Exit(fmt.Errorf("could not update organization: %w", err), 1)
+	}
+
+	fmt.Println("the client secret cannot be retrieved again, store it securely")
+	return printJSON(map[string]interface{}{
+		"client_id":     key.ClientId,
+		"client_secret": secret,
+		"permissions":   key.Permissions,
+		"expires":       key.Expires,
+	})
+}
+
+func revokeAPIKey(c *cli.Context) (err error) {
+	if c.NArg() != 1 {
+		return cli.Exit("specify the clientID of the api key to revoke", 1)
+	}
+	clientID := c.Args().Get(0)
+
+	var orgID uuid.UUID
+	if orgID, err = models.ParseAPIKeyClientID(clientID); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	var org *models.Organization
+	if org, err = GetOrg(orgID.String()); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	key := org.GetAPIKey(clientID)
+	if key == nil {
+		return cli.Exit("api key not found", 1)
+	}
+
+	if key.IsRevoked() {
+		fmt.Printf("api key was already revoked at %s\n", key.Revoked)
+		return nil
+	}
+
+	if !c.Bool("force") && !askForConfirmation(fmt.Sprintf("revoke api key %q of organization %q?", clientID, org.ResolveName())) {
+		return cli.Exit("canceled at request of user", 0)
+	}
+
+	key.Revoke()
+
+	ctx, cancel := utils.WithDeadline(context.Background())
+	defer cancel()
+
+	if err = db.UpdateOrganization(ctx, org); err != nil {
+		return cli.Exit(fmt.Errorf("could not update organization: %w", err), 1)
+	}
+	return nil
+}
+
 func sortAppdataOrgs(c *cli.Context) (err error) {
 	ctx, cancel := utils.WithDeadline(context.Background())
 	defer cancel()
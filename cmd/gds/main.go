@@ -7,6 +7,7 @@ import (
 	"log"
 	"net/url"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -21,6 +22,8 @@ import (
 	api "github.com/trisacrypto/trisa/pkg/trisa/gds/api/v1beta1"
 	models "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"gopkg.in/yaml.v2"
@@ -179,6 +182,16 @@ func main() {
 						Aliases: []string{"C", "categories", "cats"},
 						Usage:   "one or more categories to filter on",
 					},
+					&cli.IntFlag{
+						Name:    "page-size",
+						Aliases: []string{"s"},
+						Usage:   "specify the number of results per page",
+					},
+					&cli.StringFlag{
+						Name:    "page-token",
+						Aliases: []string{"p"},
+						Usage:   "specify the page token to fetch the next page of results",
+					},
 				},
 			},
 			{
@@ -412,6 +425,31 @@ func main() {
 					},
 				},
 			},
+			{
+				Name:     "admin:revoke",
+				Usage:    "revoke a certificate issued to a VASP",
+				Category: "admin",
+				Action:   adminRevokeCertificate,
+				Before:   initAdminClient,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "id",
+						Aliases: []string{"i"},
+						Usage:   "the uuid of the VASP to revoke the certificate for",
+					},
+					&cli.StringFlag{
+						Name:    "serial-number",
+						Aliases: []string{"s"},
+						Usage:   "the serial number of the certificate (default is the identity certificate)",
+					},
+					&cli.StringFlag{
+						Name:    "reason",
+						Aliases: []string{"r"},
+						Usage:   "the RFC 5280 CRL reason for the revocation",
+						Value:   "unspecified",
+					},
+				},
+			},
 			{
 				Name:     "admin:detail",
 				Usage:    "retrieve a VASP detail record by id",
@@ -896,12 +934,30 @@ func search(c *cli.Context) (err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	rep, err := client.Search(ctx, req)
+	// Pagination parameters are sent as metadata since the search request does not
+	// have pagination fields.
+	if pageSize := c.Int("page-size"); pageSize > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, gds.MetadataPageSize, strconv.Itoa(pageSize))
+	}
+
+	if pageToken := c.String("page-token"); pageToken != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, gds.MetadataPageToken, pageToken)
+	}
+
+	var header metadata.MD
+	rep, err := client.Search(ctx, req, grpc.Header(&header))
 	if err != nil {
 		return cli.Exit(err, 1)
 	}
 
-	return printJSON(rep)
+	if err = printJSON(rep); err != nil {
+		return err
+	}
+
+	if token := header.Get(gds.MetadataNextPageToken); len(token) > 0 {
+		fmt.Printf("next page token: %s\n", token[0])
+	}
+	return nil
 }
 
 // Check on verification and service status of a VASP
@@ -1107,6 +1163,24 @@ func adminListCertificates(c *cli.Context) (err error) {
 	return printJSON(rep)
 }
 
+func adminRevokeCertificate(c *cli.Context) (err error) {
+	ctx, cancel := profile.Context()
+	defer cancel()
+
+	req := &admin.RevokeCertificateRequest{
+		ID:           c.String("id"),
+		SerialNumber: c.String("serial-number"),
+		Reason:       c.String("reason"),
+	}
+
+	var rep *admin.RevokeCertificateReply
+	if rep, err = adminClient.RevokeCertificate(ctx, req); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	return printJSON(rep)
+}
+
 func adminRetrieveVASP(c *cli.Context) (err error) {
 	ctx, cancel := profile.Context()
 	defer cancel()
@@ -3,9 +3,7 @@ package main
 import (
 	"bytes"
 	"context"
-	"crypto/rand"
 	"crypto/x509"
-	"encoding/base64"
 	"encoding/pem"
 	"fmt"
 	"net"
@@ -18,7 +16,6 @@ import (
 	"github.com/trisacrypto/directory/pkg/gds/secrets"
 	"github.com/trisacrypto/directory/pkg/models/v1"
 	"github.com/trisacrypto/directory/pkg/utils"
-	"github.com/trisacrypto/directory/pkg/utils/whisper"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 	"github.com/trisacrypto/trisa/pkg/trust"
 
@@ -30,10 +27,6 @@ import (
 // Certificate Management
 //===========================================================================
 
-const (
-	whisperPasswordTemplate = "Below is the PKCS12 password which you must use to decrypt your new certificates:\n\n%s\n"
-)
-
 func notify(c *cli.Context) (err error) {
 	var (
 		nsent       int
@@ -86,7 +79,6 @@ func reissueCerts(c *cli.Context) (err error) {
 		certreq        *models.CertificateRequest
 		pkcs12password string
 		emailer        *emails.EmailManager
-		whisperLink    string
 		nsent          int
 	)
 
@@ -130,6 +122,19 @@ func reissueCerts(c *cli.Context) (err error) {
 		certreq.DnsNames = append(certreq.DnsNames, dnsNames...)
 	}
 
+	// Step 2c: issue the certificates for a CSR submitted by the VASP if specified
+	if path := c.String("csr"); path != "" {
+		var data []byte
+		if data, err = os.ReadFile(path); err != nil {
+			return cli.Exit(fmt.Errorf("could not read csr: %s", err), 1)
+		}
+
+		if err = models.ValidateCSR(data, certreq.CommonName, certreq.DnsNames); err != nil {
+			return cli.Exit(err, 1)
+		}
+		certreq.Csr = string(data)
+	}
+
 	// Override the certificate delivery webhook if specified
 	if webhook := c.String("webhook"); webhook != "" {
 		certreq.Webhook = webhook
@@ -138,7 +143,7 @@ func reissueCerts(c *cli.Context) (err error) {
 	// Override the email delivery preference
 	certreq.NoEmailDelivery = c.Bool("no-email")
 
-	// Step 2c: mark the certificate request as ready to submit for CertMan
+	// Step 2d: mark the certificate request as ready to submit for CertMan
 	if err = models.UpdateCertificateRequestStatus(
 		certreq,
 		models.CertificateRequestState_READY_TO_SUBMIT,
@@ -148,59 +153,59 @@ func reissueCerts(c *cli.Context) (err error) {
 		return cli.Exit(fmt.Errorf("could not mark certificate request ready to submit: %s", err), 1)
 	}
 
-	// Step 3: Create a PKCS12 password
-	var sm *secrets.SecretManager
-	if sm, err = secrets.New(conf.Secrets); err != nil {
-		return cli.Exit(fmt.Errorf("could not connect to secret manager: %s", err), 1)
-	}
-
-	secretType := "password"
-	pkcs12password = secrets.CreateToken(16)
-
-	if err = sm.With(certreq.Id).CreateSecret(ctx, secretType); err != nil {
-		return cli.Exit(fmt.Errorf("could not create password secret: %s", err), 1)
-	}
+	// Step 3: Create a PKCS12 password; certificates issued for a CSR do not contain a
+	// private key so no password is created or delivered.
+	if certreq.Csr == "" {
+		var sm *secrets.SecretManager
+		if sm, err = secrets.New(conf.Secrets); err != nil {
+			return cli.Exit(fmt.Errorf("could not connect to secret manager: %s", err), 1)
+		}
 
-	if err = sm.With(certreq.Id).AddSecretVersion(ctx, secretType, []byte(pkcs12password)); err != nil {
-		return cli.Exit(fmt.Errorf("could not create password version: %s", err), 1)
-	}
+		secretType := "password"
+		pkcs12password = secrets.CreateToken(16)
 
-	if !certreq.NoEmailDelivery {
-		// Create a Whisper link for the provided PKCS12 password.
-		if whisperLink, err = whisper.CreateSecretLink(fmt.Sprintf(whisperPasswordTemplate, pkcs12password), "", 3, weekFromNow()); err != nil {
-			return cli.Exit(err, 1)
+		if err = sm.With(certreq.Id).CreateSecret(ctx, secretType); err != nil {
+			return cli.Exit(fmt.Errorf("could not create password secret: %s", err), 1)
 		}
 
-		// Create the email manager.
-		if emailer, err = emails.New(conf.Email); err != nil {
-			return cli.Exit(err, 1)
+		if err = sm.With(certreq.Id).AddSecretVersion(ctx, secretType, []byte(pkcs12password)); err != nil {
+			return cli.Exit(fmt.Errorf("could not create password version: %s", err), 1)
 		}
 
-		// Send the notification email that certificate reissuance is forthcoming and provide whisper link to the PKCS12 password.
-		if nsent, err = emailer.SendReissuanceStarted(vasp, whisperLink); err != nil {
-			return cli.Exit(err, 1)
-		}
+		if !certreq.NoEmailDelivery {
+			// Create the email manager that creates the Whisper link to the PKCS12
+			// password from the secret manager when the email is rendered.
+			if emailer, err = emails.New(conf.Email); err != nil {
+				return cli.Exit(err, 1)
+			}
+			emailer.SetResolver(emails.NewResolver(db, sm))
 
-		fmt.Printf("successfully sent %d Whisper password notifications for PKCS12 password %q\n", nsent, pkcs12password)
-	}
+			// Send the notification email that certificate reissuance is forthcoming and provide whisper link to the PKCS12 password.
+			if nsent, err = emailer.SendReissuanceStarted(vasp, certreq.Id); err != nil {
+				return cli.Exit(err, 1)
+			}
 
-	if certreq.Webhook != "" {
-		// Create a courier client to deliver the pkcs12 password to the TRISA member
-		var client api.CourierClient
-		if client, err = api.New(certreq.Webhook); err != nil {
-			return cli.Exit(fmt.Errorf("could not create courier client: %s", err), 1)
+			fmt.Printf("successfully sent %d Whisper password notifications for PKCS12 password %q\n", nsent, pkcs12password)
 		}
 
-		// Deliver the pkcs12 password via the webhook
-		req := &api.StorePasswordRequest{
-			ID:       certreq.Id,
-			Password: pkcs12password,
-		}
-		if err = client.StoreCertificatePassword(ctx, req); err != nil {
-			return cli.Exit(fmt.Errorf("could not deliver pkcs12 password with webhook: %s", err), 1)
-		}
+		if certreq.Webhook != "" {
+			// Create a courier client to deliver the pkcs12 password to the TRISA member
+			var client api.CourierClient
+			if client, err = api.New(certreq.Webhook); err != nil {
+				return cli.Exit(fmt.Errorf("could not create courier client: %s", err), 1)
+			}
 
-		fmt.Printf("successfully sent PKCS12 password to webhook %q\n", certreq.Webhook)
+			// Deliver the pkcs12 password via the webhook
+			req := &api.StorePasswordRequest{
+				ID:       certreq.Id,
+				Password: pkcs12password,
+			}
+			if err = client.StoreCertificatePassword(ctx, req); err != nil {
+				return cli.Exit(fmt.Errorf("could not deliver pkcs12 password with webhook: %s", err), 1)
+			}
+
+			fmt.Printf("successfully sent PKCS12 password to webhook %q\n", certreq.Webhook)
+		}
 	}
 
 	// Save certificate request to database
@@ -279,6 +284,52 @@ func cancelCertificatRequest(c *cli.Context) (err error) {
 	return nil
 }
 
+func archiveCertReqs(c *cli.Context) (err error) {
+	dryrun := c.Bool("dryrun")
+	cutoff := time.Now().Add(-c.Duration("after"))
+
+	// Collect the finished certificate requests before modifying the database
+	ctx := context.Background()
+	archive := make([]*models.CertificateRequest, 0)
+	certreqs := db.ListCertReqs(ctx)
+	for certreqs.Next() {
+		var certreq *models.CertificateRequest
+		if certreq, err = certreqs.CertReq(); err != nil {
+			certreqs.Release()
+			return cli.Exit(err, 1)
+		}
+
+		if models.CertificateRequestArchivable(certreq, cutoff) {
+			archive = append(archive, certreq)
+		}
+	}
+
+	if err = certreqs.Error(); err != nil {
+		certreqs.Release()
+		return cli.Exit(err, 1)
+	}
+	certreqs.Release()
+
+	for _, certreq := range archive {
+		if dryrun {
+			fmt.Printf("certificate request %s for %s (%s) would be archived\n", certreq.Id, certreq.CommonName, certreq.Status)
+			continue
+		}
+
+		if err = db.ArchiveCertReq(ctx, certreq.Id); err != nil {
+			return cli.Exit(fmt.Errorf("could not archive certificate request %s: %w", certreq.Id, err), 1)
+		}
+	}
+
+	if dryrun {
+		fmt.Printf("%d certificate requests would be archived\n", len(archive))
+		return nil
+	}
+
+	fmt.Printf("%d certificate requests archived\n", len(archive))
+	return nil
+}
+
 func resendPassword(c *cli.Context) (err error) {
 	var (
 		vasp           *pb.VASP
@@ -287,7 +338,6 @@ func resendPassword(c *cli.Context) (err error) {
 		pkcs12password []byte
 		sm             *secrets.SecretManager
 		emailer        *emails.EmailManager
-		whisperLink    string
 		nsent          int
 	)
 
@@ -340,18 +390,15 @@ func resendPassword(c *cli.Context) (err error) {
 		}
 	}
 
-	// Create the Whisper link for the provided PKCS12 password.
-	if whisperLink, err = whisper.CreateSecretLink(fmt.Sprintf(whisperPasswordTemplate, string(pkcs12password)), "", 3, weekFromNow()); err != nil {
-		return cli.Exit(err, 1)
-	}
-
-	// Create the email manager.
+	// Create the email manager that creates the Whisper link to the PKCS12 password
+	// from the secret manager when the email is rendered.
 	if emailer, err = emails.New(conf.Email); err != nil {
 		return cli.Exit(err, 1)
 	}
+	emailer.SetResolver(emails.NewResolver(db, sm))
 
 	// Send the notification email that certificate reissuance is forthcoming and provide whisper link to the PKCS12 password.
-	if nsent, err = emailer.SendReissuanceStarted(vasp, whisperLink); err != nil {
+	if nsent, err = emailer.SendReissuanceStarted(vasp, certreqID); err != nil {
 		return cli.Exit(err, 1)
 	}
 
@@ -533,11 +580,11 @@ func verifyDomain(c *cli.Context) (err error) {
 	if token := c.Bool("token"); token {
 		// Generate a challenge token, print and return
 		// TODO: also generate whisper link to send to user
-		nonce := make([]byte, 32)
-		if _, err = rand.Read(nonce); err != nil {
+		var token string
+		if token, err = models.NewChallengeToken(); err != nil {
 			return cli.Exit(err, 1)
 		}
-		fmt.Printf("Challenge Token: TRISA-DOMAIN-VERIFICATION=%s\n", base64.RawURLEncoding.EncodeToString(nonce))
+		fmt.Printf("Challenge Token: %s%s\n", models.ChallengeTXTPrefix, token)
 		return nil
 	}
 
@@ -2,11 +2,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"text/tabwriter"
 	"time"
 
+	"github.com/kelseyhightower/envconfig"
+	bff "github.com/trisacrypto/directory/pkg/bff/models/v1"
+	"github.com/trisacrypto/directory/pkg/store"
+	storeconfig "github.com/trisacrypto/directory/pkg/store/config"
+	"github.com/trisacrypto/directory/pkg/store/leveldb"
+	"github.com/trisacrypto/directory/pkg/utils/backups"
+	"github.com/trisacrypto/directory/pkg/utils/logger"
 	"github.com/trisacrypto/directory/pkg/utils/wire"
 	"github.com/urfave/cli/v2"
 )
@@ -25,11 +33,14 @@ func dbUsage(c *cli.Context) (err error) {
 	}{
 		{wire.NamespaceVASPs, db.CountVASPs},
 		{wire.NamespaceCertReqs, db.CountCertReqs},
+		{wire.NamespaceCertReqArchive, db.CountArchivedCertReqs},
 		{wire.NamespaceCerts, db.CountCerts},
 		{wire.NamespaceAnnouncements, db.CountAnnouncementMonths},
 		{wire.NamespaceActivities, db.CountActivityMonth},
 		{wire.NamespaceOrganizations, db.CountOrganizations},
 		{wire.NamespaceContacts, db.CountContacts},
+		{wire.NamespaceJobs, db.CountJobs},
+		{wire.NamespaceEmails, db.CountEmails},
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
@@ -44,3 +55,225 @@ func dbUsage(c *cli.Context) (err error) {
 	w.Flush()
 	return nil
 }
+
+func dbCopy(c *cli.Context) (err error) {
+	// suppress zerolog output from the stores
+	logger.Discard()
+
+	copier := &store.Copier{}
+	if copier.Since, err = time.Parse(bff.MonthLayout, c.String("since")); err != nil {
+		return cli.Exit(fmt.Errorf("could not parse since month: %w", err), 1)
+	}
+
+	// Resume the copy from the checkpoint if it exists
+	path := c.String("checkpoint")
+	if copier.Checkpoint, err = store.LoadCopyCheckpoint(path); err != nil {
+		return cli.Exit(err, 1)
+	}
+	copier.OnCheckpoint = func(ckpt *store.CopyCheckpoint) error {
+		return ckpt.Save(path)
+	}
+
+	if len(copier.Checkpoint.Completed) > 0 || len(copier.Checkpoint.LastKey) > 0 {
+		fmt.Printf("resuming copy from checkpoint %s\n", path)
+	}
+
+	open := func(dsn string) (store.Store, error) {
+		return store.Open(storeconfig.StoreConfig{
+			URL:      dsn,
+			Insecure: c.Bool("insecure"),
+			CertPath: c.String("certs"),
+			PoolPath: c.String("pool"),
+		})
+	}
+
+	if copier.Src, err = open(c.String("src")); err != nil {
+		return cli.Exit(fmt.Errorf("could not open source store: %w", err), 1)
+	}
+	defer copier.Src.Close()
+
+	if copier.Dst, err = open(c.String("dst")); err != nil {
+		return cli.Exit(fmt.Errorf("could not open destination store: %w", err), 1)
+	}
+	defer copier.Dst.Close()
+
+	// The copy may take a long time, so no deadline is set on the context; the stores
+	// apply their own deadlines to individual requests.
+	ctx := context.Background()
+	if err = copier.Copy(ctx); err != nil {
+		return cli.Exit(fmt.Errorf("%w (rerun the command to resume from the checkpoint)", err), 1)
+	}
+
+	// Verify the counts of each namespace after the copy
+	verified := true
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Namespace\tCopied\tSource\tDestination\tStatus")
+	for _, count := range copier.Verify(ctx) {
+		copied := copier.Checkpoint.Copied[count.Namespace]
+		switch {
+		case count.Err != nil:
+			fmt.Fprintf(w, "%s\t%d\t-\t-\tcannot count: %s\n", count.Namespace, copied, count.Err)
+		case count.Verified():
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\tok\n", count.Namespace, copied, count.Src, count.Dst)
+		default:
+			verified = false
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\tmismatch\n", count.Namespace, copied, count.Src, count.Dst)
+		}
+	}
+	w.Flush()
+
+	if !verified {
+		return cli.Exit("object counts do not match, the destination may have had existing objects or the source was modified during the copy", 1)
+	}
+
+	// The copy is complete so the checkpoint is no longer needed
+	if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return cli.Exit(err, 1)
+	}
+	return nil
+}
+
+func dbRestore(c *cli.Context) (err error) {
+	// suppress zerolog output from the store
+	logger.Discard()
+
+	var manager *backups.Manager
+	if manager, err = openBackups(c); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	ctx := context.Background()
+	if c.Bool("list") {
+		var archives []*backups.Archive
+		if archives, err = manager.List(ctx); err != nil {
+			if errors.Is(err, backups.ErrNotSupported) {
+				return cli.Exit("the backup sink cannot be listed", 1)
+			}
+			return cli.Exit(err, 1)
+		}
+
+		if len(archives) == 0 {
+			fmt.Println("no archives found in the backup sink")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		if c.Bool("validate") {
+			fmt.Fprintln(w, "Archive\tTimestamp\tSize\tRecords\tStatus")
+		} else {
+			fmt.Fprintln(w, "Archive\tTimestamp\tSize")
+		}
+
+		for _, archive := range archives {
+			fmt.Fprintf(w, "%s\t%s\t%d", archive.Name, archive.Timestamp.Format(time.RFC3339), archive.Size)
+			if c.Bool("validate") {
+				if nrecords, err := validateArchive(ctx, manager, archive); err != nil {
+					fmt.Fprintf(w, "\t%d\tinvalid: %s", nrecords, err)
+				} else {
+					fmt.Fprintf(w, "\t%d\tok", nrecords)
+				}
+			}
+			fmt.Fprintln(w)
+		}
+		return w.Flush()
+	}
+
+	var archive *backups.Archive
+	if archive, err = manager.Find(ctx, c.String("archive")); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	if c.Bool("validate") {
+		var nrecords uint64
+		if nrecords, err = validateArchive(ctx, manager, archive); err != nil {
+			return cli.Exit(fmt.Errorf("%s is invalid: %w", archive.Name, err), 1)
+		}
+		fmt.Printf("%s is valid with %d records\n", archive.Name, nrecords)
+		return nil
+	}
+
+	path := c.String("db")
+	if path == "" {
+		return cli.Exit("specify the path to restore the database to with --db", 1)
+	}
+
+	archivePath, cleanup, err := downloadArchive(ctx, manager, archive)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	defer cleanup()
+
+	var nrecords uint64
+	if nrecords, err = leveldb.RestoreArchive(archivePath, path); err != nil {
+		return cli.Exit(err, 1)
+	}
+	fmt.Printf("restored %d records from %s to %s\n", nrecords, archive.Name, path)
+
+	if c.Bool("reindex") {
+		// The archived indices are removed by the restore, so opening the store rebuilds
+		// them from the restored VASP records and closing it checkpoints them so that
+		// the GDS does not have to reindex when it starts.
+		var ldb *leveldb.Store
+		if ldb, err = leveldb.Open(path); err != nil {
+			return cli.Exit(fmt.Errorf("could not open restored database: %w", err), 1)
+		}
+
+		if err = ldb.Close(); err != nil {
+			return cli.Exit(fmt.Errorf("could not reindex restored database: %w", err), 1)
+		}
+		fmt.Println("restored database reindexed")
+	}
+	return nil
+}
+
+// openBackups returns a manager for the GDS archives in the backup sink. The sink is
+// configured with the same environment variables as the GDS backup manager, e.g.
+// $GDS_BACKUP_SINK_URL, and is the backup storage directory if no sink url is set.
+func openBackups(c *cli.Context) (_ *backups.Manager, err error) {
+	var conf backups.SinkConfig
+	if err = envconfig.Process("gds_backup_sink", &conf); err != nil {
+		return nil, err
+	}
+
+	if sink := c.String("sink"); sink != "" {
+		conf.URL = sink
+	}
+
+	var sink backups.Sink
+	if sink, err = backups.Open(conf, c.String("backups")); err != nil {
+		return nil, err
+	}
+
+	var key []byte
+	if key, err = backups.ParseKey(c.String("key")); err != nil {
+		return nil, err
+	}
+	return backups.New(backups.GDSPrefix, sink, key, backups.Retention{}), nil
+}
+
+// validate the archive after downloading and decrypting it from the sink.
+func validateArchive(ctx context.Context, manager *backups.Manager, archive *backups.Archive) (_ uint64, err error) {
+	path, cleanup, err := downloadArchive(ctx, manager, archive)
+	if err != nil {
+		return 0, err
+	}
+	defer cleanup()
+	return leveldb.ValidateArchive(path)
+}
+
+// downloadArchive returns the path to the plaintext archive downloaded from the sink to
+// a temporary directory, decrypting encrypted archives with the backup encryption key.
+// Call cleanup when done with the archive to remove the temporary directory.
+func downloadArchive(ctx context.Context, manager *backups.Manager, archive *backups.Archive) (path string, cleanup func(), err error) {
+	var tmp string
+	if tmp, err = os.MkdirTemp("", "restore-*"); err != nil {
+		return "", nil, err
+	}
+	cleanup = func() { os.RemoveAll(tmp) }
+
+	if path, err = manager.Download(ctx, archive, tmp); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return path, cleanup, nil
+}
@@ -89,6 +89,101 @@ func main() {
 			After:    closeDB,
 			Flags:    []cli.Flag{},
 		},
+		{
+			Name:     "db:copy",
+			Usage:    "copy every object in every namespace from one store to another",
+			Category: "db",
+			Action:   dbCopy,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "src",
+					Aliases:  []string{"s"},
+					Usage:    "dsn of the store to copy objects from",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "dst",
+					Aliases:  []string{"d"},
+					Usage:    "dsn of the store to copy objects to",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:    "checkpoint",
+					Aliases: []string{"c"},
+					Usage:   "path to save copy progress to so that an interrupted copy can be resumed",
+					Value:   "dbcopy.checkpoint.json",
+				},
+				&cli.StringFlag{
+					Name:  "since",
+					Usage: "the first month (YYYY-MM) to copy announcement and activity months from",
+					Value: "2021-01",
+				},
+				&cli.BoolFlag{
+					Name:    "insecure",
+					Aliases: []string{"S"},
+					Usage:   "connect to trtl stores without mTLS",
+				},
+				&cli.StringFlag{
+					Name:  "certs",
+					Usage: "path to the mTLS certificates to connect to trtl stores",
+				},
+				&cli.StringFlag{
+					Name:  "pool",
+					Usage: "path to the mTLS cert pool to connect to trtl stores",
+				},
+			},
+		},
+		{
+			Name:     "db:restore",
+			Usage:    "list, validate, and restore backup archives of the leveldb store",
+			Category: "db",
+			Action:   dbRestore,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "backups",
+					Aliases: []string{"b"},
+					Usage:   "path to the backup storage directory if no backup sink is specified",
+					EnvVars: []string{"GDS_BACKUP_STORAGE"},
+				},
+				&cli.StringFlag{
+					Name:    "sink",
+					Aliases: []string{"s"},
+					Usage:   "url of the backup sink to restore archives from (file, s3, or http)",
+					EnvVars: []string{"GDS_BACKUP_SINK_URL"},
+				},
+				&cli.BoolFlag{
+					Name:    "list",
+					Aliases: []string{"l"},
+					Usage:   "list the available archives rather than restoring one",
+				},
+				&cli.StringFlag{
+					Name:    "archive",
+					Aliases: []string{"a"},
+					Usage:   "name of the archive to restore (default is the most recent archive)",
+				},
+				&cli.BoolFlag{
+					Name:    "validate",
+					Aliases: []string{"v"},
+					Usage:   "only validate the integrity of the archive(s) without restoring",
+				},
+				&cli.StringFlag{
+					Name:    "db",
+					Aliases: []string{"d"},
+					Usage:   "path to the fresh database directory to restore the archive into",
+				},
+				&cli.StringFlag{
+					Name:    "key",
+					Aliases: []string{"k"},
+					Usage:   "base64 encoded key to decrypt encrypted archives",
+					EnvVars: []string{"GDS_BACKUP_ENCRYPTION_KEY"},
+				},
+				&cli.BoolFlag{
+					Name:    "reindex",
+					Aliases: []string{"r"},
+					Usage:   "rebuild the store indices after the archive is restored",
+				},
+			},
+		},
 		{
 			Name:     "vasp:list",
 			Usage:    "list the VASPs in the current database by name, common name, and id",
@@ -362,6 +457,10 @@ func main() {
 					Aliases: []string{"E"},
 					Usage:   "do not deliver certificates by email",
 				},
+				&cli.StringFlag{
+					Name:  "csr",
+					Usage: "path to a PEM encoded CSR to issue the certs for instead of a private key",
+				},
 			},
 		},
 		{
@@ -491,6 +590,27 @@ func main() {
 			},
 		},
 
+		{
+			Name:     "certs:archive",
+			Usage:    "move finished certificate requests into the certificate request archive",
+			Category: "certs",
+			Action:   archiveCertReqs,
+			Before:   connectDB,
+			After:    closeDB,
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:    "dryrun",
+					Aliases: []string{"d"},
+					Usage:   "print the requests that would be archived without modifying the database",
+				},
+				&cli.DurationFlag{
+					Name:    "after",
+					Aliases: []string{"a"},
+					Usage:   "only archive requests that have not been modified for this duration",
+					Value:   720 * time.Hour,
+				},
+			},
+		},
 		{
 			Name:     "certs:acme",
 			Usage:    "verify a domain name via acme-dns challenge",
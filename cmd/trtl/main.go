@@ -2,24 +2,31 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/joho/godotenv"
+	"github.com/kelseyhightower/envconfig"
 	"github.com/rotationalio/honu"
 	opts "github.com/rotationalio/honu/options"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/opt"
 	"github.com/trisacrypto/directory/pkg"
 	profiles "github.com/trisacrypto/directory/pkg/gds/client"
+	ldbstore "github.com/trisacrypto/directory/pkg/store/leveldb"
 	"github.com/trisacrypto/directory/pkg/trtl"
 	"github.com/trisacrypto/directory/pkg/trtl/config"
 	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
 	"github.com/trisacrypto/directory/pkg/trtl/peers/v1"
+	"github.com/trisacrypto/directory/pkg/utils/backups"
 	"github.com/trisacrypto/directory/pkg/utils/wire"
 	"github.com/urfave/cli/v2"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -99,6 +106,52 @@ func main() {
 			Category:  "server",
 			Action:    migrate,
 		},
+		{
+			Name:     "restore",
+			Usage:    "list, validate, and restore trtl backup archives",
+			Category: "server",
+			Action:   restore,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "backups",
+					Aliases: []string{"b"},
+					Usage:   "path to the backup storage directory if no backup sink is specified",
+					EnvVars: []string{"TRTL_BACKUP_STORAGE"},
+				},
+				&cli.StringFlag{
+					Name:    "sink",
+					Aliases: []string{"s"},
+					Usage:   "url of the backup sink to restore archives from (file, s3, or http)",
+					EnvVars: []string{"TRTL_BACKUP_SINK_URL"},
+				},
+				&cli.BoolFlag{
+					Name:    "list",
+					Aliases: []string{"l"},
+					Usage:   "list the available archives rather than restoring one",
+				},
+				&cli.StringFlag{
+					Name:    "archive",
+					Aliases: []string{"a"},
+					Usage:   "name of the archive to restore (default is the most recent archive)",
+				},
+				&cli.BoolFlag{
+					Name:    "validate",
+					Aliases: []string{"v"},
+					Usage:   "only validate the integrity of the archive(s) without restoring",
+				},
+				&cli.StringFlag{
+					Name:    "db",
+					Aliases: []string{"d"},
+					Usage:   "path to the fresh database directory to restore the archive into",
+				},
+				&cli.StringFlag{
+					Name:    "key",
+					Aliases: []string{"k"},
+					Usage:   "base64 encoded key to decrypt encrypted archives",
+					EnvVars: []string{"TRTL_BACKUP_ENCRYPTION_KEY"},
+				},
+			},
+		},
 		{
 			Name:     "status",
 			Usage:    "check the status of the trtl database and replication service",
@@ -225,6 +278,35 @@ func main() {
 				},
 			},
 		},
+		{
+			Name:     "db:watch",
+			Usage:    "stream puts and deletes in a namespace as they happen until interrupted",
+			Category: "client",
+			Before:   initDBClient,
+			Action:   dbWatch,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "namespace",
+					Aliases: []string{"n"},
+					Usage:   "specify the namespace as a string (optional)",
+				},
+				&cli.StringFlag{
+					Name:    "prefix",
+					Aliases: []string{"p"},
+					Usage:   "specify a prefix of keys to watch (optional)",
+				},
+				&cli.BoolFlag{
+					Name:    "no-values",
+					Aliases: []string{"V"},
+					Usage:   "do not include values in the change events",
+				},
+				&cli.BoolFlag{
+					Name:    "b64",
+					Aliases: []string{"b", "b64decode"},
+					Usage:   "specify the prefix as a base64 encoded value which must be decoded",
+				},
+			},
+		},
 		{
 			Name:     "peers:add",
 			Usage:    "add peers to the network by pid",
@@ -519,6 +601,136 @@ func migrate(c *cli.Context) (err error) {
 	return nil
 }
 
+// restore a trtl backup archive into a fresh database directory. The GDS indices are
+// removed from the restored database so that the GDS trtl store rebuilds them from the
+// restored records when it connects.
+func restore(c *cli.Context) (err error) {
+	var manager *backups.Manager
+	if manager, err = openBackups(c); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	ctx := context.Background()
+	if c.Bool("list") {
+		var archives []*backups.Archive
+		if archives, err = manager.List(ctx); err != nil {
+			if errors.Is(err, backups.ErrNotSupported) {
+				return cli.Exit("the backup sink cannot be listed", 1)
+			}
+			return cli.Exit(err, 1)
+		}
+
+		if len(archives) == 0 {
+			fmt.Println("no archives found in the backup sink")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		if c.Bool("validate") {
+			fmt.Fprintln(w, "Archive\tTimestamp\tSize\tRecords\tStatus")
+		} else {
+			fmt.Fprintln(w, "Archive\tTimestamp\tSize")
+		}
+
+		for _, archive := range archives {
+			fmt.Fprintf(w, "%s\t%s\t%d", archive.Name, archive.Timestamp.Format(time.RFC3339), archive.Size)
+			if c.Bool("validate") {
+				if nrecords, err := validateArchive(ctx, manager, archive); err != nil {
+					fmt.Fprintf(w, "\t%d\tinvalid: %s", nrecords, err)
+				} else {
+					fmt.Fprintf(w, "\t%d\tok", nrecords)
+				}
+			}
+			fmt.Fprintln(w)
+		}
+		return w.Flush()
+	}
+
+	var archive *backups.Archive
+	if archive, err = manager.Find(ctx, c.String("archive")); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	if c.Bool("validate") {
+		var nrecords uint64
+		if nrecords, err = validateArchive(ctx, manager, archive); err != nil {
+			return cli.Exit(fmt.Errorf("%s is invalid: %w", archive.Name, err), 1)
+		}
+		fmt.Printf("%s is valid with %d records\n", archive.Name, nrecords)
+		return nil
+	}
+
+	path := c.String("db")
+	if path == "" {
+		return cli.Exit("specify the path to restore the database to with --db", 1)
+	}
+
+	archivePath, cleanup, err := downloadArchive(ctx, manager, archive)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	defer cleanup()
+
+	var nrecords uint64
+	if nrecords, err = ldbstore.RestoreArchive(archivePath, path); err != nil {
+		return cli.Exit(err, 1)
+	}
+	fmt.Printf("restored %d records from %s to %s\n", nrecords, archive.Name, path)
+	return nil
+}
+
+// openBackups returns a manager for the trtl archives in the backup sink. The sink is
+// configured with the same environment variables as the trtl backup manager, e.g.
+// $TRTL_BACKUP_SINK_URL, and is the backup storage directory if no sink url is set.
+func openBackups(c *cli.Context) (_ *backups.Manager, err error) {
+	var conf backups.SinkConfig
+	if err = envconfig.Process("trtl_backup_sink", &conf); err != nil {
+		return nil, err
+	}
+
+	if sink := c.String("sink"); sink != "" {
+		conf.URL = sink
+	}
+
+	var sink backups.Sink
+	if sink, err = backups.Open(conf, c.String("backups")); err != nil {
+		return nil, err
+	}
+
+	var key []byte
+	if key, err = backups.ParseKey(c.String("key")); err != nil {
+		return nil, err
+	}
+	return backups.New(backups.TrtlPrefix, sink, key, backups.Retention{}), nil
+}
+
+// validate the archive after downloading and decrypting it from the sink.
+func validateArchive(ctx context.Context, manager *backups.Manager, archive *backups.Archive) (_ uint64, err error) {
+	path, cleanup, err := downloadArchive(ctx, manager, archive)
+	if err != nil {
+		return 0, err
+	}
+	defer cleanup()
+	return ldbstore.ValidateArchive(path)
+}
+
+// downloadArchive returns the path to the plaintext archive downloaded from the sink to
+// a temporary directory, decrypting encrypted archives with the backup encryption key.
+// Call cleanup when done with the archive to remove the temporary directory.
+func downloadArchive(ctx context.Context, manager *backups.Manager, archive *backups.Archive) (path string, cleanup func(), err error) {
+	var tmp string
+	if tmp, err = os.MkdirTemp("", "restore-*"); err != nil {
+		return "", nil, err
+	}
+	cleanup = func() { os.RemoveAll(tmp) }
+
+	if path, err = manager.Download(ctx, archive, tmp); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return path, cleanup, nil
+}
+
 //===========================================================================
 // Initialization Functions
 //===========================================================================
@@ -724,6 +936,46 @@ func dbList(c *cli.Context) (err error) {
 	return nil
 }
 
+// dbWatch prints change events from the trtl database as JSON until interrupted.
+func dbWatch(c *cli.Context) (err error) {
+	// The watch is long running so it is not bound by the profile timeout.
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	req := &pb.WatchRequest{
+		Namespace: c.String("namespace"),
+		Options: &pb.Options{
+			IterNoValues: c.Bool("no-values"),
+		},
+	}
+
+	if prefix := c.String("prefix"); prefix != "" {
+		if req.Prefix, err = wire.DecodeKey(prefix, c.Bool("b64")); err != nil {
+			return cli.Exit(fmt.Errorf("could not decode prefix: %s", err), 1)
+		}
+	}
+
+	var stream pb.Trtl_WatchClient
+	if stream, err = dbClient.Watch(ctx, req); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	for {
+		var event *pb.WatchEvent
+		if event, err = stream.Recv(); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return cli.Exit(err, 1)
+		}
+
+		if err = printJSON(event); err != nil {
+			return cli.Exit(err, 1)
+		}
+		fmt.Println("")
+	}
+}
+
 // status prints the status of the trtl service.
 func status(c *cli.Context) (err error) {
 	ctx, cancel := profile.Context()
@@ -830,10 +1082,11 @@ func gossipMigrate(c *cli.Context) (err error) {
 // Utility Functions
 //===========================================================================
 
-var namespaces = [6]string{
+var namespaces = [7]string{
 	wire.NamespaceVASPs,
 	wire.NamespaceCerts,
 	wire.NamespaceCertReqs,
+	wire.NamespaceCertReqArchive,
 	wire.NamespaceContacts,
 	wire.NamespaceAnnouncements,
 	wire.NamespaceOrganizations,
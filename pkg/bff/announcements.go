@@ -104,6 +104,17 @@ func (s *Server) MakeAnnouncement(c *gin.Context) {
 		return
 	}
 
+	// Notify the organizations that are subscribed to announcements; the announcement
+	// has been posted so errors are logged rather than returned to the user.
+	if s.webhooks != nil {
+		ctx, cancel := utils.WithDeadline(context.Background())
+		defer cancel()
+
+		if _, err = s.webhooks.Broadcast(ctx, models.WebhookAnnouncementCreated, &api.WebhookEventData{Announcement: post}); err != nil {
+			sentry.Error(c).Err(err).Str("id", id).Msg("could not send announcement to webhooks")
+		}
+	}
+
 	// Return a 204 No Content to indicate the post happened successfully
 	log.Info().Str("id", id).Str("title", post.Title).Str("author", post.Author).Msg("network announcement added")
 	c.JSON(http.StatusNoContent, nil)
@@ -43,6 +43,18 @@ type BFFClient interface {
 	UpdateCollaboratorRoles(_ context.Context, id string, request *UpdateRolesParams) (*models.Collaborator, error)
 	DeleteCollaborator(_ context.Context, id string) error
 
+	// Webhook subscriptions
+	ListWebhooks(context.Context) (*ListWebhooksReply, error)
+	CreateWebhook(context.Context, *WebhookParams) (*Webhook, error)
+	UpdateWebhook(_ context.Context, id string, request *WebhookParams) (*Webhook, error)
+	DeleteWebhook(_ context.Context, id string) error
+	WebhookDeliveries(_ context.Context, id string) (*WebhookDeliveriesReply, error)
+
+	// Organization API keys
+	ListAPIKeys(context.Context) (*ListAPIKeysReply, error)
+	CreateAPIKey(context.Context, *APIKeyParams) (*APIKey, error)
+	RevokeAPIKey(_ context.Context, clientID string) error
+
 	MemberList(context.Context, *MemberPageInfo) (*MemberListReply, error)
 	MemberDetails(context.Context, *MemberDetailsParams) (*MemberDetailsReply, error)
 
@@ -52,6 +64,7 @@ type BFFClient interface {
 	ResetRegistrationForm(context.Context, *RegistrationFormParams) (*RegistrationForm, error)
 	SubmitRegistration(_ context.Context, network string) (*RegisterReply, error)
 	RegistrationStatus(context.Context) (*RegistrationStatus, error)
+	RegistrationJobStatus(_ context.Context, network, jobID string) (*JobStatus, error)
 
 	// Overview and announcements
 	Overview(context.Context) (*OverviewReply, error)
@@ -181,6 +194,101 @@ type ListCollaboratorsReply struct {
 	Collaborators []*models.Collaborator `json:"collaborators"`
 }
 
+// Webhook is a subscription of an organization to webhook events. The signing secret
+// is only returned when the webhook is created.
+type Webhook struct {
+	ID        string   `json:"id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	Active    bool     `json:"active"`
+	Secret    string   `json:"secret,omitempty"`
+	CreatedBy string   `json:"created_by,omitempty"`
+	Created   string   `json:"created"`
+	Modified  string   `json:"modified"`
+}
+
+// WebhookParams is used to create or update a webhook. If no events are specified the
+// webhook is subscribed to all events. When updating, empty fields are not changed.
+type WebhookParams struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Active *bool    `json:"active,omitempty"`
+}
+
+// ListWebhooksReply contains the webhooks of the organization.
+type ListWebhooksReply struct {
+	Webhooks []*Webhook `json:"webhooks"`
+}
+
+// WebhookDelivery describes an attempt to send an event to a webhook.
+type WebhookDelivery struct {
+	ID          string `json:"id"`
+	Event       string `json:"event"`
+	Status      string `json:"status"`
+	Attempts    uint32 `json:"attempts"`
+	StatusCode  int32  `json:"status_code,omitempty"`
+	LastError   string `json:"last_error,omitempty"`
+	NextAttempt string `json:"next_attempt,omitempty"`
+	Delivered   string `json:"delivered,omitempty"`
+	Created     string `json:"created"`
+}
+
+// WebhookDeliveriesReply contains the most recent deliveries to a webhook, newest first.
+type WebhookDeliveriesReply struct {
+	Deliveries []*WebhookDelivery `json:"deliveries"`
+}
+
+// WebhookEvent is the JSON payload that is posted to webhooks. The payload is signed
+// with the webhook secret in the X-TRISA-Signature header.
+type WebhookEvent struct {
+	ID      string            `json:"id"`
+	Event   string            `json:"event"`
+	OrgID   string            `json:"org_id"`
+	Created string            `json:"created"`
+	Data    *WebhookEventData `json:"data"`
+}
+
+// WebhookEventData describes what happened, the fields that are set depend on the event.
+type WebhookEventData struct {
+	Network        string               `json:"network,omitempty"`
+	VASPID         string               `json:"vasp_id,omitempty"`
+	Status         string               `json:"status,omitempty"`
+	PreviousStatus string               `json:"previous_status,omitempty"`
+	Contact        string               `json:"contact,omitempty"`
+	SerialNumber   string               `json:"serial_number,omitempty"`
+	ExpiresAt      string               `json:"expires_at,omitempty"`
+	DaysRemaining  int                  `json:"days_remaining,omitempty"`
+	Announcement   *models.Announcement `json:"announcement,omitempty"`
+}
+
+// APIKey allows machine clients to access the BFF on behalf of an organization. The
+// client secret is only returned when the key is created.
+type APIKey struct {
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	Permissions  []string `json:"permissions"`
+	CreatedBy    string   `json:"created_by,omitempty"`
+	Expires      string   `json:"expires,omitempty"`
+	LastUsed     string   `json:"last_used,omitempty"`
+	Revoked      string   `json:"revoked,omitempty"`
+	Created      string   `json:"created"`
+	Modified     string   `json:"modified"`
+}
+
+// APIKeyParams is used to create an API key. Expires is an optional RFC3339 timestamp,
+// if it is not specified then the key does not expire.
+type APIKeyParams struct {
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+	Expires     string   `json:"expires,omitempty"`
+}
+
+// ListAPIKeysReply contains the API keys of the organization, including revoked keys.
+type ListAPIKeysReply struct {
+	APIKeys []*APIKey `json:"api_keys"`
+}
+
 // LookupParams is converted into a GDS LookupRequest.
 type LookupParams struct {
 	ID         string `url:"uuid,omitempty" form:"uuid"`
@@ -284,15 +392,51 @@ type RegisterReply struct {
 	Status              string                 `json:"status"`
 	Message             string                 `json:"message"`
 	PKCS12Password      string                 `json:"pkcs12password"`
+	JobID               string                 `json:"job_id,omitempty"`
 	RefreshToken        bool                   `json:"refresh_token,omitempty"`
 }
 
+// JobStatus is the processing status of the job that processes a registration that was
+// submitted to a directory service. Status is one of "queued", "retrying", "completed",
+// or "failed".
+type JobStatus struct {
+	JobID       string `json:"job_id"`
+	VASPID      string `json:"vasp_id"`
+	Type        string `json:"type"`
+	Status      string `json:"status"`
+	Attempts    uint32 `json:"attempts"`
+	NextAttempt string `json:"next_attempt,omitempty"`
+	Created     string `json:"created"`
+	Modified    string `json:"modified"`
+	Finished    string `json:"finished,omitempty"`
+}
+
 // RegistrationStatus is returned on registration status requests. This will contain
 // RFC3339 formatted timestamps indicating when the registration was submitted for
-// testnet and mainnet.
+// testnet and mainnet and the domain challenges that must be completed before the
+// certificates for each registration are issued.
 type RegistrationStatus struct {
-	TestNetSubmitted string `json:"testnet_submitted,omitempty"`
-	MainNetSubmitted string `json:"mainnet_submitted,omitempty"`
+	TestNetSubmitted  string             `json:"testnet_submitted,omitempty"`
+	MainNetSubmitted  string             `json:"mainnet_submitted,omitempty"`
+	TestNetChallenges []*DomainChallenge `json:"testnet_challenges,omitempty"`
+	MainNetChallenges []*DomainChallenge `json:"mainnet_challenges,omitempty"`
+}
+
+// DomainChallenge describes how to prove control of a domain in a certificate request,
+// either by publishing the DNS value in a TXT record or by serving the token from the
+// HTTP path on the domain. Status is one of "pending", "valid", or "expired".
+type DomainChallenge struct {
+	Domain      string `json:"domain"`
+	Token       string `json:"token"`
+	Status      string `json:"status"`
+	DNSRecord   string `json:"dns_record"`
+	DNSValue    string `json:"dns_value"`
+	HTTPPath    string `json:"http_path"`
+	ValidatedBy string `json:"validated_by,omitempty"`
+	Attempts    uint32 `json:"attempts"`
+	Error       string `json:"error,omitempty"`
+	LastChecked string `json:"last_checked,omitempty"`
+	Validated   string `json:"validated,omitempty"`
 }
 
 // OverviewReply is returned on overview requests.
@@ -362,6 +362,156 @@ func (s *APIv1) DeleteCollaborator(ctx context.Context, id string) (err error) {
 	return nil
 }
 
+// List the webhooks of the user's organization.
+func (s *APIv1) ListWebhooks(ctx context.Context) (out *ListWebhooksReply, err error) {
+	// Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodGet, "/v1/webhooks", nil, nil); err != nil {
+		return nil, err
+	}
+
+	out = &ListWebhooksReply{}
+	if _, err = s.Do(req, out, true); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Create a webhook for the user's organization, the reply contains the signing secret.
+func (s *APIv1) CreateWebhook(ctx context.Context, request *WebhookParams) (webhook *Webhook, err error) {
+	// Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodPost, "/v1/webhooks", request, nil); err != nil {
+		return nil, err
+	}
+
+	webhook = &Webhook{}
+	if _, err = s.Do(req, webhook, true); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// Update the URL, events, or active state of a webhook.
+func (s *APIv1) UpdateWebhook(ctx context.Context, id string, request *WebhookParams) (webhook *Webhook, err error) {
+	// ID is required for the endpoint
+	if id == "" {
+		return nil, ErrIDRequired
+	}
+
+	// Construct the path from the request
+	path := fmt.Sprintf("/v1/webhooks/%s", id)
+
+	// Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodPatch, path, request, nil); err != nil {
+		return nil, err
+	}
+
+	webhook = &Webhook{}
+	if _, err = s.Do(req, webhook, true); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// Delete a webhook from the user's organization.
+func (s *APIv1) DeleteWebhook(ctx context.Context, id string) (err error) {
+	// ID is required for the endpoint
+	if id == "" {
+		return ErrIDRequired
+	}
+
+	// Construct the path from the request
+	path := fmt.Sprintf("/v1/webhooks/%s", id)
+
+	// Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodDelete, path, nil, nil); err != nil {
+		return err
+	}
+
+	if _, err = s.Do(req, nil, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+// List the most recent deliveries to a webhook.
+func (s *APIv1) WebhookDeliveries(ctx context.Context, id string) (out *WebhookDeliveriesReply, err error) {
+	// ID is required for the endpoint
+	if id == "" {
+		return nil, ErrIDRequired
+	}
+
+	// Construct the path from the request
+	path := fmt.Sprintf("/v1/webhooks/%s/deliveries", id)
+
+	// Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodGet, path, nil, nil); err != nil {
+		return nil, err
+	}
+
+	out = &WebhookDeliveriesReply{}
+	if _, err = s.Do(req, out, true); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// List the API keys of the user's organization.
+func (s *APIv1) ListAPIKeys(ctx context.Context) (out *ListAPIKeysReply, err error) {
+	// Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodGet, "/v1/apikeys", nil, nil); err != nil {
+		return nil, err
+	}
+
+	out = &ListAPIKeysReply{}
+	if _, err = s.Do(req, out, true); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Create an API key for the user's organization, the reply contains the client secret.
+func (s *APIv1) CreateAPIKey(ctx context.Context, request *APIKeyParams) (key *APIKey, err error) {
+	// Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodPost, "/v1/apikeys", request, nil); err != nil {
+		return nil, err
+	}
+
+	key = &APIKey{}
+	if _, err = s.Do(req, key, true); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Revoke an API key so that it can no longer be used to access the BFF.
+func (s *APIv1) RevokeAPIKey(ctx context.Context, clientID string) (err error) {
+	// Client ID is required for the endpoint
+	if clientID == "" {
+		return ErrIDRequired
+	}
+
+	// Construct the path from the request
+	path := fmt.Sprintf("/v1/apikeys/%s", clientID)
+
+	// Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodDelete, path, nil, nil); err != nil {
+		return err
+	}
+
+	if _, err = s.Do(req, nil, true); err != nil {
+		return err
+	}
+	return nil
+}
+
 // Load registration form data from the server to populate the front-end form.
 func (s *APIv1) LoadRegistrationForm(ctx context.Context, in *RegistrationFormParams) (form *RegistrationForm, err error) {
 	// Create the query params from the input
@@ -456,6 +606,36 @@ func (s *APIv1) SubmitRegistration(ctx context.Context, network string) (out *Re
 	return out, nil
 }
 
+// RegistrationJobStatus returns the status of the job that processes the registration
+// submitted to the specified network (testnet or mainnet).
+func (s *APIv1) RegistrationJobStatus(ctx context.Context, network, jobID string) (out *JobStatus, err error) {
+	// network is required for the endpoint
+	if network == "" {
+		return nil, ErrNetworkRequired
+	}
+
+	if jobID == "" {
+		return nil, ErrIDRequired
+	}
+
+	// Determine the path for the request
+	network = strings.ToLower(strings.TrimSpace(network))
+	path := fmt.Sprintf("/v1/register/%s/jobs/%s", network, jobID)
+
+	// Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodGet, path, nil, nil); err != nil {
+		return nil, err
+	}
+
+	// Execute the request and get a response
+	out = &JobStatus{}
+	if _, err = s.Do(req, out, true); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RegistrationStatus returns the status of the VASP registrations for the organization.
 func (s *APIv1) RegistrationStatus(ctx context.Context) (out *RegistrationStatus, err error) {
 	// Make the HTTP request
@@ -678,7 +858,12 @@ func (s *APIv1) NewRequest(ctx context.Context, method, path string, data interf
 		if token, err = s.creds.AccessToken(); err != nil {
 			return nil, err
 		}
-		req.Header.Add("Authorization", "Bearer "+token)
+
+		scheme := "Bearer"
+		if creds, ok := s.creds.(SchemeCredentials); ok {
+			scheme = creds.Scheme()
+		}
+		req.Header.Add("Authorization", scheme+" "+token)
 	}
 
 	// Add CSRF protection if it is available
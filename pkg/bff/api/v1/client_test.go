@@ -748,6 +748,44 @@ func TestRegistrationStatus(t *testing.T) {
 	require.Equal(t, fixture.MainNetSubmitted, out.MainNetSubmitted)
 }
 
+func TestRegistrationJobStatus(t *testing.T) {
+	fixture := &api.JobStatus{
+		JobID:    "b7c1a7b4-3f2a-4d8e-9a31-6c0c1f1f4b3e",
+		VASPID:   "8b2e9e78-baca-4c34-a382-8b285503c901",
+		Type:     "registration",
+		Status:   "completed",
+		Attempts: 1,
+		Created:  time.Now().Format(time.RFC3339),
+		Modified: time.Now().Format(time.RFC3339),
+		Finished: time.Now().Format(time.RFC3339),
+	}
+
+	// Create a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/v1/register/testnet/jobs/"+fixture.JobID, r.URL.Path)
+
+		w.Header().Add("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(fixture)
+	}))
+	defer ts.Close()
+
+	// Create a client that makes requests to the test server
+	client, err := api.New(ts.URL)
+	require.NoError(t, err)
+
+	_, err = client.RegistrationJobStatus(context.TODO(), "", fixture.JobID)
+	require.ErrorIs(t, err, api.ErrNetworkRequired)
+
+	_, err = client.RegistrationJobStatus(context.TODO(), "testnet", "")
+	require.ErrorIs(t, err, api.ErrIDRequired)
+
+	out, err := client.RegistrationJobStatus(context.TODO(), "TestNet", fixture.JobID)
+	require.NoError(t, err)
+	require.Equal(t, fixture, out)
+}
+
 func TestOverview(t *testing.T) {
 	fixture := &api.OverviewReply{
 		OrgID: "ba2202bf-635e-414e-a7bc-86f309dc95e0",
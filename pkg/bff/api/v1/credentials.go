@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"os"
 	"time"
@@ -16,11 +17,19 @@ type Credentials interface {
 	AccessToken() (string, error)
 }
 
+// SchemeCredentials are credentials that are not sent to the BFF as a bearer token,
+// the scheme is used in the Authorization header instead.
+type SchemeCredentials interface {
+	Credentials
+	Scheme() string
+}
+
 // Check to ensure that the different types of Credentials implement the interface.
 var (
-	_ Credentials = Token("")
-	_ Credentials = &LocalCredentials{}
-	_ Credentials = &Auth0Token{}
+	_ Credentials       = Token("")
+	_ Credentials       = &LocalCredentials{}
+	_ Credentials       = &Auth0Token{}
+	_ SchemeCredentials = &APIKeyCredentials{}
 )
 
 // A Token is just the JWT base64 encoded token string that can be obtained from the
@@ -121,3 +130,24 @@ func (t *Auth0Token) AccessToken() (_ string, err error) {
 
 	return t.Token, nil
 }
+
+// APIKeyCredentials authenticate machine clients using the client ID and secret of an
+// organization API key rather than an Auth0 access token.
+type APIKeyCredentials struct {
+	ClientID     string
+	ClientSecret string
+}
+
+// AccessToken implements the Credentials interface by returning the basic auth
+// encoding of the client ID and secret.
+func (k *APIKeyCredentials) AccessToken() (_ string, err error) {
+	if k.ClientID == "" || k.ClientSecret == "" {
+		return "", ErrInvalidCredentials
+	}
+	return base64.StdEncoding.EncodeToString([]byte(k.ClientID + ":" + k.ClientSecret)), nil
+}
+
+// Scheme implements the SchemeCredentials interface; API keys use basic auth.
+func (k *APIKeyCredentials) Scheme() string {
+	return "Basic"
+}
@@ -86,3 +86,36 @@ func TestCredentials(t *testing.T) {
 	local.Path = filepath.Join(t.TempDir(), "token.json")
 	require.NoError(t, local.Dump(), "could not dump local credentials back to tmp directory")
 }
+
+func TestAPIKeyCredentials(t *testing.T) {
+	// Ensure that API key credentials are sent to the server with basic auth.
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json; charset=utf-8")
+		if clientID, secret, ok := r.BasicAuth(); !ok || clientID != "clientid" || secret != "sk_secret" {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(&api.Reply{Success: false, Error: "invalid api key credentials"})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(&api.StatusReply{Status: "ok"})
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	creds := &api.APIKeyCredentials{ClientID: "clientid", ClientSecret: "sk_secret"}
+	client, err := api.New(ts.URL, api.WithClient(ts.Client()), api.WithCredentials(creds))
+	require.NoError(t, err, "unable to create an APIv1 client with api key credentials")
+
+	_, err = client.Status(ctx, &api.StatusParams{})
+	require.NoError(t, err, "expected to be able to make an authenticated request with api key credentials")
+
+	// Client secret is required
+	client, err = api.New(ts.URL, api.WithClient(ts.Client()), api.WithCredentials(&api.APIKeyCredentials{ClientID: "clientid"}))
+	require.NoError(t, err, "unable to create an APIv1 client with api key credentials")
+
+	_, err = client.Status(ctx, &api.StatusParams{})
+	require.ErrorIs(t, err, api.ErrInvalidCredentials, "expected missing client secret to be rejected")
+}
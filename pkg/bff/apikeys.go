@@ -0,0 +1,302 @@
+package bff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/bff/api/v1"
+	"github.com/trisacrypto/directory/pkg/bff/auth"
+	"github.com/trisacrypto/directory/pkg/bff/models/v1"
+	"github.com/trisacrypto/directory/pkg/utils"
+	"github.com/trisacrypto/directory/pkg/utils/sentry"
+)
+
+// Ensure the server can verify API keys in the authentication middleware.
+var _ auth.APIKeyVerifier = &Server{}
+
+// VerifyAPIKey implements the auth.APIKeyVerifier interface so that machine clients can
+// authenticate with the client ID and secret of an organization API key. The claims
+// returned contain the permissions of the key and the directory records of the
+// organization so that handlers can treat the request like a user request.
+func (s *Server) VerifyAPIKey(ctx context.Context, clientID, secret string) (_ *auth.Claims, err error) {
+	var orgID uuid.UUID
+	if orgID, err = models.ParseAPIKeyClientID(clientID); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := utils.WithDeadline(ctx)
+	defer cancel()
+
+	var org *models.Organization
+	if org, err = s.db.RetrieveOrganization(ctx, orgID); err != nil {
+		return nil, fmt.Errorf("could not retrieve organization of api key: %w", err)
+	}
+
+	key := org.GetAPIKey(clientID)
+	if key == nil {
+		return nil, models.ErrInvalidAPIKeyClientID
+	}
+
+	now := time.Now()
+	if err = key.Verify(secret, now); err != nil {
+		return nil, err
+	}
+
+	// Failing to record the last use of the key should not fail the request
+	if key.Used(now) {
+		if err = s.recordAPIKeyUsed(ctx, orgID, clientID, now); err != nil {
+			log.Warn().Err(err).Str("client_id", clientID).Msg("could not update api key last used timestamp")
+		}
+	}
+
+	claims := &auth.Claims{
+		Scope:       auth.ScopeAPIKey,
+		Permissions: key.Permissions,
+		OrgID:       org.Id,
+	}
+
+	if org.Testnet != nil {
+		claims.VASPs.TestNet = org.Testnet.Id
+	}
+
+	if org.Mainnet != nil {
+		claims.VASPs.MainNet = org.Mainnet.Id
+	}
+	return claims, nil
+}
+
+// Records the last use of the API key on the latest version of its organization rather
+// than writing back the organization that was read to verify the key, which could undo
+// changes made in the meantime such as the revocation of the key. Nothing is saved if
+// the key has since been revoked or deleted.
+func (s *Server) recordAPIKeyUsed(ctx context.Context, orgID uuid.UUID, clientID string, now time.Time) (err error) {
+	var org *models.Organization
+	if org, err = s.db.RetrieveOrganization(ctx, orgID); err != nil {
+		return err
+	}
+
+	key := org.GetAPIKey(clientID)
+	if key == nil || key.IsRevoked() || !key.Used(now) {
+		return nil
+	}
+	return s.db.UpdateOrganization(ctx, org)
+}
+
+// ListAPIKeys lists the API keys of the user's organization, including revoked keys.
+//
+// @Summary List API keys [read:organizations]
+// @Description Returns the API keys of the user's organization sorted by creation date.
+// @Tags apikeys
+// @Produce json
+// @Success 200 {object} api.ListAPIKeysReply
+// @Failure 401 {object} api.Reply
+// @Failure 500 {object} api.Reply
+// @Router /apikeys [get]
+func (s *Server) ListAPIKeys(c *gin.Context) {
+	var (
+		err error
+		org *models.Organization
+	)
+
+	// Fetch the organization from the claims
+	// NOTE: This method handles the error logging and response
+	if org, err = s.OrganizationFromClaims(c); err != nil {
+		return
+	}
+
+	out := &api.ListAPIKeysReply{
+		APIKeys: make([]*api.APIKey, 0, len(org.ApiKeys)),
+	}
+
+	for _, key := range org.ApiKeys {
+		out.APIKeys = append(out.APIKeys, apiKeyReply(key, ""))
+	}
+
+	// Enforce consistent ordering by creation timestamp
+	sort.Slice(out.APIKeys, func(i, j int) bool {
+		return out.APIKeys[i].Created < out.APIKeys[j].Created
+	})
+
+	c.JSON(http.StatusOK, out)
+}
+
+// CreateAPIKey creates an API key for the user's organization. The key can only be
+// granted permissions that the user has and that are allowed for API keys. The client
+// secret of the key is only returned in this response.
+//
+// @Summary Create API key [update:organizations]
+// @Description Create an API key for machine-to-machine access to the BFF.
+// @Tags apikeys
+// @Accept json
+// @Produce json
+// @Param params body api.APIKeyParams true "API key to create"
+// @Success 200 {object} api.APIKey
+// @Failure 400 {object} api.Reply "Invalid permissions or expiration"
+// @Failure 401 {object} api.Reply
+// @Failure 403 {object} api.Reply "Maximum number of API keys reached"
+// @Failure 500 {object} api.Reply
+// @Router /apikeys [post]
+func (s *Server) CreateAPIKey(c *gin.Context) {
+	var (
+		err     error
+		params  *api.APIKeyParams
+		claims  *auth.Claims
+		org     *models.Organization
+		user    *management.User
+		key     *models.APIKey
+		secret  string
+		expires time.Time
+	)
+
+	// Fetch the organization from the claims
+	// NOTE: This method handles the error logging and response
+	if org, err = s.OrganizationFromClaims(c); err != nil {
+		return
+	}
+
+	if claims, err = auth.GetClaims(c); err != nil {
+		sentry.Error(c).Err(err).Msg("could not retrieve claims to create api key")
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse("could not create api key"))
+		return
+	}
+
+	params = &api.APIKeyParams{}
+	if err = c.ShouldBind(params); err != nil {
+		sentry.Warn(c).Err(err).Msg("could not bind request")
+		c.JSON(http.StatusBadRequest, api.ErrorResponse(err))
+		return
+	}
+
+	permissions := models.NormalizeAPIKeyPermissions(params.Permissions)
+	if err = auth.ValidAPIKeyPermissions(permissions); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse(err))
+		return
+	}
+
+	// Users cannot grant API keys more access than they have themselves
+	if !claims.HasAllPermissions(permissions...) {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse("api key cannot have permissions that the user does not have"))
+		return
+	}
+
+	if params.Expires != "" {
+		if expires, err = time.Parse(time.RFC3339, params.Expires); err != nil || !expires.After(time.Now()) {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse("expires must be an RFC3339 timestamp in the future"))
+			return
+		}
+	}
+
+	if key, secret, err = models.NewAPIKey(org.Id, params.Description, permissions, expires); err != nil {
+		sentry.Error(c).Err(err).Msg("could not create api key")
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse("could not create api key"))
+		return
+	}
+
+	// Record the user who created the key if available
+	if user, err = auth.GetUserInfo(c); err == nil {
+		key.CreatedBy = user.GetEmail()
+	}
+
+	if err = org.AddAPIKey(key); err != nil {
+		switch {
+		case errors.Is(err, models.ErrMaxAPIKeys):
+			sentry.Warn(c).Err(err).Int("maximum", models.MaxAPIKeys).Msg("maximum number of api keys reached")
+			c.JSON(http.StatusForbidden, api.ErrorResponse(err))
+		case errors.Is(err, models.ErrNoAPIKeyPermissions):
+			c.JSON(http.StatusBadRequest, api.ErrorResponse(err))
+		default:
+			sentry.Error(c).Err(err).Msg("could not add api key to organization")
+			c.JSON(http.StatusInternalServerError, api.ErrorResponse("could not create api key"))
+		}
+		return
+	}
+
+	ctx, cancel := utils.WithDeadline(context.Background())
+	defer cancel()
+
+	if err = s.db.UpdateOrganization(ctx, org); err != nil {
+		sentry.Error(c).Err(err).Msg("could not save organization with new api key")
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse("could not create api key"))
+		return
+	}
+
+	c.JSON(http.StatusOK, apiKeyReply(key, secret))
+}
+
+// RevokeAPIKey revokes an API key of the user's organization so that it can no longer
+// be used to authenticate. Revoked keys remain on the organization for auditing.
+//
+// @Summary Revoke API key [update:organizations]
+// @Description Revoke an API key of the user's organization.
+// @Tags apikeys
+// @Produce json
+// @Param clientID path string true "API key client ID"
+// @Success 200 {object} api.Reply
+// @Failure 401 {object} api.Reply
+// @Failure 404 {object} api.Reply "API key not found"
+// @Failure 500 {object} api.Reply
+// @Router /apikeys/{clientID} [delete]
+func (s *Server) RevokeAPIKey(c *gin.Context) {
+	var (
+		err error
+		org *models.Organization
+	)
+
+	// Fetch the organization from the claims
+	// NOTE: This method handles the error logging and response
+	if org, err = s.OrganizationFromClaims(c); err != nil {
+		return
+	}
+
+	key := org.GetAPIKey(c.Param("clientID"))
+	if key == nil {
+		c.JSON(http.StatusNotFound, api.ErrorResponse("api key not found"))
+		return
+	}
+
+	// Revoking a key more than once is not an error
+	if !key.IsRevoked() {
+		key.Revoke()
+
+		ctx, cancel := utils.WithDeadline(context.Background())
+		defer cancel()
+
+		if err = s.db.UpdateOrganization(ctx, org); err != nil {
+			sentry.Error(c).Err(err).Msg("could not save organization with revoked api key")
+			c.JSON(http.StatusInternalServerError, api.ErrorResponse("could not revoke api key"))
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, api.Reply{Success: true})
+}
+
+// Returns the API representation of the key, the client secret is only included when
+// the key is created since only the hash of the secret is stored.
+func apiKeyReply(key *models.APIKey, secret string) *api.APIKey {
+	out := &api.APIKey{
+		ClientID:     key.ClientId,
+		ClientSecret: secret,
+		Description:  key.Description,
+		Permissions:  key.Permissions,
+		CreatedBy:    key.CreatedBy,
+		Expires:      key.Expires,
+		LastUsed:     key.LastUsed,
+		Revoked:      key.Revoked,
+		Created:      key.Created,
+		Modified:     key.Modified,
+	}
+
+	if out.Permissions == nil {
+		out.Permissions = make([]string, 0)
+	}
+	return out
+}
@@ -0,0 +1,155 @@
+package bff_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/trisacrypto/directory/pkg/bff/api/v1"
+	"github.com/trisacrypto/directory/pkg/bff/auth"
+	"github.com/trisacrypto/directory/pkg/bff/auth/authtest"
+	records "github.com/trisacrypto/directory/pkg/bff/models/v1"
+)
+
+func (s *bffTestSuite) TestAPIKeys() {
+	require := s.Require()
+	defer s.ResetDB()
+
+	// Create initial claims fixture
+	claims := &authtest.Claims{
+		Email:       "leopold.wentzel@gmail.com",
+		Permissions: []string{"read:nothing"},
+	}
+
+	params := &api.APIKeyParams{
+		Description: "compliance automation",
+		Permissions: []string{auth.ReadVASP},
+	}
+
+	// Endpoint requires CSRF protection
+	_, err := s.client.CreateAPIKey(context.TODO(), params)
+	s.requireError(err, http.StatusForbidden, "csrf verification failed for request", "expected error when request is not CSRF protected")
+	require.NoError(s.SetClientCSRFProtection(), "could not set csrf protection on client")
+
+	// Endpoint must be authenticated
+	_, err = s.client.CreateAPIKey(context.TODO(), params)
+	s.requireError(err, http.StatusUnauthorized, "this endpoint requires authentication", "expected error when user is not authenticated")
+
+	// Endpoints require the update:organizations and read:organizations permissions
+	require.NoError(s.SetClientCredentials(claims), "could not create token with incorrect permissions")
+	_, err = s.client.CreateAPIKey(context.TODO(), params)
+	s.requireError(err, http.StatusUnauthorized, "user does not have permission to perform this operation", "expected error when user is not authorized")
+	_, err = s.client.ListAPIKeys(context.TODO())
+	s.requireError(err, http.StatusUnauthorized, "user does not have permission to perform this operation", "expected error when user is not authorized")
+
+	// Create an organization in the database
+	org := &records.Organization{
+		Testnet: &records.DirectoryRecord{Submitted: time.Now().Format(time.RFC3339)},
+	}
+	_, err = s.DB().CreateOrganization(context.Background(), org)
+	require.NoError(err, "could not create organization in the database")
+
+	claims.OrgID = org.Id
+	claims.Permissions = []string{auth.ReadOrganizations, auth.UpdateOrganizations, auth.ReadVASP}
+	require.NoError(s.SetClientCredentials(claims), "could not create token with valid credentials")
+
+	reply, err := s.client.ListAPIKeys(context.TODO())
+	require.NoError(err, "could not list api keys")
+	require.Empty(reply.APIKeys, "expected no api keys")
+
+	// Should not be able to create keys with invalid permissions or expiration
+	_, err = s.client.CreateAPIKey(context.TODO(), &api.APIKeyParams{})
+	s.requireError(err, http.StatusBadRequest, records.ErrNoAPIKeyPermissions.Error(), "expected error when no permissions are specified")
+	_, err = s.client.CreateAPIKey(context.TODO(), &api.APIKeyParams{Permissions: []string{auth.UpdateVASP}})
+	s.requireError(err, http.StatusBadRequest, `permission cannot be assigned to an api key "update:vasp"`, "expected error when permission is not allowed")
+	_, err = s.client.CreateAPIKey(context.TODO(), &api.APIKeyParams{Permissions: []string{auth.ReadCollaborators}})
+	s.requireError(err, http.StatusBadRequest, "api key cannot have permissions that the user does not have", "expected error when user does not have the permission")
+	_, err = s.client.CreateAPIKey(context.TODO(), &api.APIKeyParams{Permissions: []string{auth.ReadVASP}, Expires: time.Now().Add(-time.Hour).Format(time.RFC3339)})
+	s.requireError(err, http.StatusBadRequest, "expires must be an RFC3339 timestamp in the future", "expected error when expiration is in the past")
+
+	// Successfully create an API key, the secret is only returned on creation
+	key, err := s.client.CreateAPIKey(context.TODO(), params)
+	require.NoError(err, "could not create api key")
+	require.NotEmpty(key.ClientID, "expected client ID to be set")
+	require.NotEmpty(key.ClientSecret, "expected client secret to be returned")
+	require.Equal(params.Description, key.Description)
+	require.Equal(params.Permissions, key.Permissions)
+	require.Empty(key.Expires, "expected key not to expire")
+
+	org, err = s.DB().RetrieveOrganization(context.Background(), org.UUID())
+	require.NoError(err, "could not retrieve organization from the database")
+	require.Len(org.ApiKeys, 1, "expected api key to be saved on the organization")
+	require.NotEqual(key.ClientSecret, org.ApiKeys[key.ClientID].SecretHash, "client secret should not be stored")
+
+	reply, err = s.client.ListAPIKeys(context.TODO())
+	require.NoError(err, "could not list api keys")
+	require.Len(reply.APIKeys, 1, "expected one api key")
+	require.Equal(key.ClientID, reply.APIKeys[0].ClientID)
+	require.Empty(reply.APIKeys[0].ClientSecret, "client secret should not be listed")
+
+	// The API key can be used to access endpoints with the permissions of the key
+	creds := &api.APIKeyCredentials{ClientID: key.ClientID, ClientSecret: key.ClientSecret}
+	s.client.(*api.APIv1).SetCredentials(creds)
+	status, err := s.client.RegistrationStatus(context.TODO())
+	require.NoError(err, "could not access endpoint with api key")
+	require.Equal(org.Testnet.Submitted, status.TestNetSubmitted)
+
+	org, err = s.DB().RetrieveOrganization(context.Background(), org.UUID())
+	require.NoError(err, "could not retrieve organization from the database")
+	require.NotEmpty(org.ApiKeys[key.ClientID].LastUsed, "expected last used timestamp to be recorded")
+
+	// The API key cannot be used for endpoints that require other permissions
+	_, err = s.client.ListAPIKeys(context.TODO())
+	s.requireError(err, http.StatusUnauthorized, "user does not have permission to perform this operation", "expected error when api key does not have the permission")
+
+	// Invalid credentials are rejected
+	s.client.(*api.APIv1).SetCredentials(&api.APIKeyCredentials{ClientID: key.ClientID, ClientSecret: "sk_wrong"})
+	_, err = s.client.RegistrationStatus(context.TODO())
+	s.requireError(err, http.StatusForbidden, "invalid api key credentials", "expected error when secret is incorrect")
+
+	s.client.(*api.APIv1).SetCredentials(&api.APIKeyCredentials{ClientID: "notaclientid", ClientSecret: key.ClientSecret})
+	_, err = s.client.RegistrationStatus(context.TODO())
+	s.requireError(err, http.StatusForbidden, "invalid api key credentials", "expected error when client ID is invalid")
+
+	// Revoke the API key
+	require.NoError(s.SetClientCredentials(claims), "could not create token with valid credentials")
+	err = s.client.RevokeAPIKey(context.TODO(), "missing")
+	s.requireError(err, http.StatusNotFound, "api key not found", "expected error when api key does not exist")
+	require.NoError(s.client.RevokeAPIKey(context.TODO(), key.ClientID), "could not revoke api key")
+	require.NoError(s.client.RevokeAPIKey(context.TODO(), key.ClientID), "revoking a key twice should not error")
+
+	reply, err = s.client.ListAPIKeys(context.TODO())
+	require.NoError(err, "could not list api keys")
+	require.Len(reply.APIKeys, 1, "expected revoked keys to be listed")
+	require.NotEmpty(reply.APIKeys[0].Revoked, "expected key to be revoked")
+
+	s.client.(*api.APIv1).SetCredentials(creds)
+	_, err = s.client.RegistrationStatus(context.TODO())
+	s.requireError(err, http.StatusForbidden, "invalid api key credentials", "expected error when api key is revoked")
+
+	// Expired keys cannot be used
+	require.NoError(s.SetClientCredentials(claims), "could not create token with valid credentials")
+	params.Expires = time.Now().Add(time.Hour).Format(time.RFC3339)
+	key, err = s.client.CreateAPIKey(context.TODO(), params)
+	require.NoError(err, "could not create api key")
+	require.Equal(params.Expires, key.Expires)
+
+	org, err = s.DB().RetrieveOrganization(context.Background(), org.UUID())
+	require.NoError(err, "could not retrieve organization from the database")
+	org.ApiKeys[key.ClientID].Expires = time.Now().Add(-time.Minute).Format(time.RFC3339)
+	require.NoError(s.DB().UpdateOrganization(context.Background(), org), "could not update organization")
+
+	s.client.(*api.APIv1).SetCredentials(&api.APIKeyCredentials{ClientID: key.ClientID, ClientSecret: key.ClientSecret})
+	_, err = s.client.RegistrationStatus(context.TODO())
+	s.requireError(err, http.StatusForbidden, "invalid api key credentials", "expected error when api key is expired")
+
+	// Limit the number of active API keys
+	require.NoError(s.SetClientCredentials(claims), "could not create token with valid credentials")
+	params.Expires = ""
+	for i := 1; i < records.MaxAPIKeys; i++ {
+		_, err = s.client.CreateAPIKey(context.TODO(), params)
+		require.NoError(err, "could not create api key")
+	}
+	_, err = s.client.CreateAPIKey(context.TODO(), params)
+	s.requireError(err, http.StatusForbidden, records.ErrMaxAPIKeys.Error(), "expected error when maximum number of api keys is reached")
+}
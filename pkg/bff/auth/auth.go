@@ -22,9 +22,11 @@ import (
 
 const (
 	ScopeAnonymous          = "anonymous"
+	ScopeAPIKey             = "apikey"
 	ContextUserInfo         = "auth0_user_info"
 	ContextBFFClaims        = "auth0_bff_claims"
 	ContextRegisteredClaims = "auth0_registered_claims"
+	ContextAPIKey           = "bff_api_key_client_id"
 )
 
 // AnonymousClaims are used to identify unauthenticated requests that have no permissions.
@@ -82,6 +84,29 @@ func (c Claims) IsAnonymous() bool {
 	return c.HasScope(ScopeAnonymous)
 }
 
+// IsAPIKey returns true if the claims were created from organization API key credentials
+// rather than from an Auth0 user token.
+func (c Claims) IsAPIKey() bool {
+	return c.HasScope(ScopeAPIKey)
+}
+
+// APIKeyVerifier verifies the client ID and secret of an organization API key and
+// returns the claims of the key. This allows machine clients to authenticate with the
+// BFF without an Auth0 user session.
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, clientID, secret string) (*Claims, error)
+}
+
+// WithAPIKeys configures the Authenticate middleware to accept organization API key
+// credentials in a basic authorization header as an alternative to Auth0 tokens.
+func WithAPIKeys(keys APIKeyVerifier) interface{} {
+	return apiKeysOption{keys}
+}
+
+type apiKeysOption struct {
+	keys APIKeyVerifier
+}
+
 // NewManagementClient creates a new Auth0 management client from the configuration.
 func NewManagementClient(conf config.AuthConfig) (manager *management.Management, err error) {
 	var options []management.Option
@@ -114,7 +139,9 @@ func NewClaims() validator.CustomClaims {
 // in the header of the request and will add the claims to the request context for
 // downstream processing. If no JWT token is present in the header, this middleware will
 // mark the request as unauthenticated but it does not perform any authorization. If the
-// JWT token is invalid this middleware will return a 403 Forbidden response.
+// JWT token is invalid this middleware will return a 403 Forbidden response. If the
+// WithAPIKeys option is specified, API key credentials provided with basic auth are
+// verified instead of a JWT token and the claims of the key are added to the context.
 func Authenticate(conf config.AuthConfig, options ...interface{}) (_ gin.HandlerFunc, err error) {
 	// Parse the issuer url to ensure it is correctly configured.
 	var issuerURL *url.URL
@@ -122,6 +149,18 @@ func Authenticate(conf config.AuthConfig, options ...interface{}) (_ gin.Handler
 		return nil, err
 	}
 
+	// Separate the API key verifier from the options passed to the JWKS provider.
+	var keys APIKeyVerifier
+	providerOptions := make([]interface{}, 0, len(options))
+	for _, option := range options {
+		if opt, ok := option.(apiKeysOption); ok {
+			keys = opt.keys
+			continue
+		}
+		providerOptions = append(providerOptions, option)
+	}
+	options = providerOptions
+
 	// If we're in testing mode and no other options have been provided, connect to the
 	// default authtest server to validate local, test credentials
 	if conf.Testing && len(options) == 0 {
@@ -159,6 +198,23 @@ func Authenticate(conf config.AuthConfig, options ...interface{}) (_ gin.Handler
 			claims interface{}
 		)
 
+		// If API key credentials are provided, authenticate the organization's API key
+		// rather than an Auth0 user. Registered claims are not set on the context since
+		// the request is not made on behalf of an Auth0 user.
+		if clientID, secret, ok := c.Request.BasicAuth(); ok && keys != nil {
+			var apiClaims *Claims
+			if apiClaims, err = keys.VerifyAPIKey(c.Request.Context(), clientID, secret); err != nil {
+				sentry.Warn(c).Err(err).Str("client_id", clientID).Msg("invalid api key credentials")
+				c.AbortWithStatusJSON(http.StatusForbidden, api.ErrorResponse(ErrInvalidAPIKey))
+				return
+			}
+
+			c.Set(ContextBFFClaims, apiClaims)
+			c.Set(ContextAPIKey, clientID)
+			c.Next()
+			return
+		}
+
 		if tks, err = jwtmiddleware.AuthHeaderTokenExtractor(c.Request); err != nil || tks == "" {
 			// The most common reason there is no token in the header is because it is
 			// not provided -- add an unauthenticated, anonymous user to the context.
@@ -281,6 +337,16 @@ func GetClaims(c *gin.Context) (*Claims, error) {
 	return claims.(*Claims), nil
 }
 
+// GetAPIKeyClientID returns the client ID of the API key that authenticated the request.
+// Returns an error if the request was not authenticated with an API key.
+func GetAPIKeyClientID(c *gin.Context) (string, error) {
+	clientID, exists := c.Get(ContextAPIKey)
+	if !exists {
+		return "", ErrNoAPIKey
+	}
+	return clientID.(string), nil
+}
+
 // GetRegisteredClaims fetches and parses the access token claims from the gin context.
 // Returns an error if no claims exist on the context rather than returning zero-valued
 // claims. Panics if the claims are an incorrect type, but should be recovered.
@@ -3,6 +3,7 @@ package auth_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -138,6 +139,88 @@ func TestAuthenticate(t *testing.T) {
 	require.Equal(t, "invalid authorization token", rep["error"])
 }
 
+func TestAuthenticateAPIKeys(t *testing.T) {
+	conf := config.AuthConfig{
+		Domain:   "example.auth0.com",
+		Audience: "http://localhost:3000",
+	}
+
+	keys := &mockAPIKeys{clientID: "clientid", secret: "sk_secret"}
+	authenticate, err := auth.Authenticate(conf, auth.WithAPIKeys(keys))
+	require.NoError(t, err, "could not create authenticate middleware with api keys")
+
+	// Create default handler
+	success := func(c *gin.Context) {
+		c.JSON(http.StatusOK, api.Reply{Success: true})
+	}
+
+	// Test valid api key credentials
+	c, srv, w := createTestContext(http.MethodGet, "/", nil, authenticate, success)
+	c.Request.SetBasicAuth("clientid", "sk_secret")
+	_, code, err := doRequest(srv, w, c)
+	require.NoError(t, err, "could not handle test request")
+	require.Equal(t, http.StatusOK, code)
+
+	claims, err := auth.GetClaims(c)
+	require.NoError(t, err, "expected api key claims on context")
+	require.True(t, claims.IsAPIKey(), "expected api key claims on context")
+	require.False(t, claims.IsAnonymous(), "api key claims should not be anonymous")
+	require.True(t, claims.HasPermission(auth.ReadVASP))
+	require.Equal(t, "2295c698-afdc-4aaf-9443-85a4515217e3", claims.OrgID)
+
+	clientID, err := auth.GetAPIKeyClientID(c)
+	require.NoError(t, err, "expected client id on context")
+	require.Equal(t, "clientid", clientID)
+
+	_, err = auth.GetRegisteredClaims(c)
+	require.ErrorIs(t, err, auth.ErrNoClaims, "api keys should not have registered claims")
+
+	// Test invalid api key credentials
+	c, srv, w = createTestContext(http.MethodGet, "/", nil, authenticate, success)
+	c.Request.SetBasicAuth("clientid", "sk_wrong")
+	rep, code, err := doRequest(srv, w, c)
+	require.NoError(t, err, "could not handle test request")
+	require.Equal(t, http.StatusForbidden, code)
+	require.Equal(t, "invalid api key credentials", rep["error"])
+
+	// Requests without credentials are still anonymous
+	c, srv, w = createTestContext(http.MethodGet, "/", nil, authenticate, success)
+	_, code, err = doRequest(srv, w, c)
+	require.NoError(t, err, "could not handle test request")
+	require.Equal(t, http.StatusOK, code)
+
+	claims, err = auth.GetClaims(c)
+	require.NoError(t, err, "expected anonymous claims on context")
+	require.True(t, claims.IsAnonymous(), "expected anonymous claims on context")
+
+	_, err = auth.GetAPIKeyClientID(c)
+	require.ErrorIs(t, err, auth.ErrNoAPIKey)
+}
+
+func TestValidAPIKeyPermissions(t *testing.T) {
+	require.NoError(t, auth.ValidAPIKeyPermissions(nil))
+	require.NoError(t, auth.ValidAPIKeyPermissions(auth.APIKeyPermissions))
+	require.ErrorIs(t, auth.ValidAPIKeyPermissions([]string{auth.ReadVASP, auth.UpdateVASP}), auth.ErrInvalidAPIKeyPermission)
+}
+
+// Verifies a single API key for testing the authentication middleware.
+type mockAPIKeys struct {
+	clientID string
+	secret   string
+}
+
+func (m *mockAPIKeys) VerifyAPIKey(_ context.Context, clientID, secret string) (*auth.Claims, error) {
+	if clientID != m.clientID || secret != m.secret {
+		return nil, errors.New("invalid credentials")
+	}
+
+	return &auth.Claims{
+		Scope:       auth.ScopeAPIKey,
+		Permissions: []string{auth.ReadVASP},
+		OrgID:       "2295c698-afdc-4aaf-9443-85a4515217e3",
+	}, nil
+}
+
 func TestAuthenticatePublicKeys(t *testing.T) {
 	// Creates a test server that serves well known jwks keys instead of the Auth0
 	// tenant - used to mock Auth0 (not a live test) but checks the happy path.
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/auth0/go-auth0/management"
 )
@@ -31,6 +32,26 @@ const (
 	CollaboratorRole = "Organization Collaborator"
 )
 
+// APIKeyPermissions are the permissions that may be assigned to an organization API key.
+// API keys are limited to read-only access since machine clients cannot satisfy the
+// double cookie CSRF protection on the endpoints that modify resources.
+var APIKeyPermissions = []string{ReadOrganizations, ReadCollaborators, ReadVASP}
+
+// ValidAPIKeyPermissions returns an error if any of the permissions cannot be assigned
+// to an organization API key.
+func ValidAPIKeyPermissions(permissions []string) error {
+permissionsLoop:
+	for _, permission := range permissions {
+		for _, allowed := range APIKeyPermissions {
+			if permission == allowed {
+				continue permissionsLoop
+			}
+		}
+		return fmt.Errorf("%w %q", ErrInvalidAPIKeyPermission, permission)
+	}
+	return nil
+}
+
 // UserProfile is a subset of the Auth0 user record that can be safely cached on the
 // BFF server.
 type UserProfile struct {
@@ -164,3 +164,15 @@ func (c *GDSClient) Summary(ctx context.Context, in *members.SummaryRequest, opt
 func (c *GDSClient) Details(ctx context.Context, in *members.DetailsRequest, opts ...grpc.CallOption) (*members.MemberDetails, error) {
 	return c.membersClient.client.Details(ctx, in, opts...)
 }
+
+func (c *GDSClient) JobStatus(ctx context.Context, in *members.JobStatusRequest, opts ...grpc.CallOption) (*members.JobStatusReply, error) {
+	return c.membersClient.client.JobStatus(ctx, in, opts...)
+}
+
+func (c *GDSClient) DomainChallenges(ctx context.Context, in *members.DomainChallengesRequest, opts ...grpc.CallOption) (*members.DomainChallengesReply, error) {
+	return c.membersClient.client.DomainChallenges(ctx, in, opts...)
+}
+
+func (c *GDSClient) SubmitCSR(ctx context.Context, in *members.SubmitCSRRequest, opts ...grpc.CallOption) (*members.SubmitCSRReply, error) {
+	return c.membersClient.client.SubmitCSR(ctx, in, opts...)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/trisacrypto/directory/pkg/store/config"
 	"github.com/trisacrypto/directory/pkg/utils/activity"
+	"github.com/trisacrypto/directory/pkg/utils/emails"
 	"github.com/trisacrypto/directory/pkg/utils/logger"
 	"github.com/trisacrypto/directory/pkg/utils/sentry"
 	"github.com/trisacrypto/trisa/pkg/trisa/mtls"
@@ -47,6 +48,7 @@ type Config struct {
 	Email        EmailConfig
 	Sentry       sentry.Config
 	Activity     activity.Config
+	Webhooks     WebhooksConfig
 	processed    bool
 }
 
@@ -91,10 +93,26 @@ type MTLSConfig struct {
 
 // EmailConfig defines how emails are sent from the BFF.
 type EmailConfig struct {
-	ServiceEmail   string `envconfig:"GDS_BFF_SERVICE_EMAIL" default:"TRISA Directory Service <admin@trisa.directory>"`
-	SendGridAPIKey string `envconfig:"SENDGRID_API_KEY" required:"false"`
-	Testing        bool   `split_words:"true" default:"false"`
-	Storage        string `split_words:"true" default:""`
+	ServiceEmail   string                 `envconfig:"GDS_BFF_SERVICE_EMAIL" default:"TRISA Directory Service <admin@trisa.directory>"`
+	SendGridAPIKey string                 `envconfig:"SENDGRID_API_KEY" required:"false"`
+	Testing        bool                   `split_words:"true" default:"false"`
+	Storage        string                 `split_words:"true" default:""`
+	Transport      emails.TransportConfig `split_words:"true"`                  // SMTP or Maildir transport, SendGrid is used if not configured
+	Templates      string                 `split_words:"true" required:"false"` // directory of email template overrides and localized templates
+	DefaultLocale  string                 `split_words:"true" default:"en"`     // locale of emails to recipients without a preferred locale
+}
+
+// WebhooksConfig defines how webhook events are detected and delivered to the webhook
+// subscriptions of organizations.
+type WebhooksConfig struct {
+	Enabled        bool          `split_words:"true" default:"false"`
+	Interval       time.Duration `split_words:"true" default:"1m"`    // how often changes are detected and deliveries are attempted
+	Timeout        time.Duration `split_words:"true" default:"10s"`   // timeout of a single delivery attempt
+	MaxAttempts    uint32        `split_words:"true" default:"8"`     // deliveries fail after this many attempts
+	Backoff        time.Duration `split_words:"true" default:"1m"`    // delay before the first retry, doubled for each attempt
+	MaxBackoff     time.Duration `split_words:"true" default:"6h"`    // maximum delay between retries
+	ExpiryWarnings []int         `split_words:"true" default:"30,7"`  // days before certificate expiration to send warnings
+	Insecure       bool          `split_words:"true" default:"false"` // allow plain http and internal webhook urls for testing
 }
 
 type CacheConfig struct {
@@ -169,6 +187,10 @@ func (c Config) Validate() (err error) {
 		return err
 	}
 
+	if err = c.Webhooks.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -276,9 +298,19 @@ func (c MTLSConfig) DialOption(endpoint string) (opt grpc.DialOption, err error)
 }
 
 func (c EmailConfig) Validate() error {
+	if c.DefaultLocale != "" {
+		if _, err := emails.NormalizeLocale(c.DefaultLocale); err != nil {
+			return fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+
 	if !c.Testing {
-		if c.SendGridAPIKey == "" || c.ServiceEmail == "" {
-			return errors.New("invalid configuration: sendgrid api key and service email are required")
+		if c.ServiceEmail == "" {
+			return errors.New("invalid configuration: service email is required")
+		}
+
+		if c.Transport.URL == "" && c.SendGridAPIKey == "" {
+			return errors.New("invalid configuration: sendgrid api key is required if no email transport is configured")
 		}
 
 		if c.Storage != "" {
@@ -288,6 +320,33 @@ func (c EmailConfig) Validate() error {
 	return nil
 }
 
+func (c WebhooksConfig) Validate() error {
+	if c.Enabled {
+		if c.Interval <= 0 {
+			return errors.New("invalid configuration: webhooks interval must be greater than 0")
+		}
+
+		if c.Timeout <= 0 {
+			return errors.New("invalid configuration: webhooks timeout must be greater than 0")
+		}
+
+		if c.MaxAttempts == 0 {
+			return errors.New("invalid configuration: webhooks max attempts must be greater than 0")
+		}
+
+		if c.Backoff <= 0 || c.MaxBackoff < c.Backoff {
+			return errors.New("invalid configuration: webhooks max backoff must be greater than or equal to the backoff")
+		}
+
+		for _, days := range c.ExpiryWarnings {
+			if days <= 0 {
+				return errors.New("invalid configuration: webhooks expiry warnings must be a positive number of days")
+			}
+		}
+	}
+	return nil
+}
+
 func (c CacheConfig) Validate() error {
 	if c.Enabled {
 		if c.Size == 0 {
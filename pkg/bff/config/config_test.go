@@ -62,6 +62,8 @@ var testEnv = map[string]string{
 	"SENDGRID_API_KEY":                      "foo1234",
 	"GDS_BFF_EMAIL_TESTING":                 "true",
 	"GDS_BFF_EMAIL_STORAGE":                 "fixtures/emails",
+	"GDS_BFF_EMAIL_TEMPLATES":               "/etc/trisa/emails",
+	"GDS_BFF_EMAIL_DEFAULT_LOCALE":          "ja",
 	"GDS_BFF_SENTRY_DSN":                    "https://something.ingest.sentry.io",
 	"GDS_BFF_SENTRY_ENVIRONMENT":            "test",
 	"GDS_BFF_SENTRY_RELEASE":                "1.4",
@@ -79,6 +81,14 @@ var testEnv = map[string]string{
 	"GDS_BFF_ACTIVITY_ENSIGN_ENDPOINT":      "api.ensign.world:443",
 	"GDS_BFF_ACTIVITY_ENSIGN_AUTH_URL":      "https://auth.ensign.world",
 	"GDS_BFF_ACTIVITY_ENSIGN_INSECURE":      "true",
+	"GDS_BFF_WEBHOOKS_ENABLED":              "true",
+	"GDS_BFF_WEBHOOKS_INTERVAL":             "30s",
+	"GDS_BFF_WEBHOOKS_TIMEOUT":              "5s",
+	"GDS_BFF_WEBHOOKS_MAX_ATTEMPTS":         "5",
+	"GDS_BFF_WEBHOOKS_BACKOFF":              "2m",
+	"GDS_BFF_WEBHOOKS_MAX_BACKOFF":          "1h",
+	"GDS_BFF_WEBHOOKS_EXPIRY_WARNINGS":      "60,30,1",
+	"GDS_BFF_WEBHOOKS_INSECURE":             "true",
 }
 
 func TestConfig(t *testing.T) {
@@ -149,6 +159,8 @@ func TestConfig(t *testing.T) {
 	require.Equal(t, testEnv["SENDGRID_API_KEY"], conf.Email.SendGridAPIKey)
 	require.True(t, conf.Email.Testing)
 	require.Equal(t, testEnv["GDS_BFF_EMAIL_STORAGE"], conf.Email.Storage)
+	require.Equal(t, testEnv["GDS_BFF_EMAIL_TEMPLATES"], conf.Email.Templates)
+	require.Equal(t, testEnv["GDS_BFF_EMAIL_DEFAULT_LOCALE"], conf.Email.DefaultLocale)
 	require.Equal(t, testEnv["GDS_BFF_SENTRY_DSN"], conf.Sentry.DSN)
 	require.Equal(t, testEnv["GDS_BFF_SENTRY_ENVIRONMENT"], conf.Sentry.Environment)
 	require.Equal(t, testEnv["GDS_BFF_SENTRY_RELEASE"], conf.Sentry.Release)
@@ -165,6 +177,14 @@ func TestConfig(t *testing.T) {
 	require.Equal(t, testEnv["GDS_BFF_ACTIVITY_ENSIGN_ENDPOINT"], conf.Activity.Ensign.Endpoint)
 	require.Equal(t, testEnv["GDS_BFF_ACTIVITY_ENSIGN_AUTH_URL"], conf.Activity.Ensign.AuthURL)
 	require.Equal(t, true, conf.Activity.Ensign.Insecure)
+	require.True(t, conf.Webhooks.Enabled)
+	require.Equal(t, 30*time.Second, conf.Webhooks.Interval)
+	require.Equal(t, 5*time.Second, conf.Webhooks.Timeout)
+	require.Equal(t, uint32(5), conf.Webhooks.MaxAttempts)
+	require.Equal(t, 2*time.Minute, conf.Webhooks.Backoff)
+	require.Equal(t, time.Hour, conf.Webhooks.MaxBackoff)
+	require.Equal(t, []int{60, 30, 1}, conf.Webhooks.ExpiryWarnings)
+	require.True(t, conf.Webhooks.Insecure)
 }
 
 func TestRequiredConfig(t *testing.T) {
@@ -313,13 +333,18 @@ func TestMembersConfigValidation(t *testing.T) {
 func TestEmailConfigValidation(t *testing.T) {
 	conf := config.EmailConfig{}
 	err := conf.Validate()
-	require.EqualError(t, err, "invalid configuration: sendgrid api key and service email are required")
+	require.EqualError(t, err, "invalid configuration: service email is required")
 
-	conf.SendGridAPIKey = "supersecretapikey"
+	conf.ServiceEmail = "service@example.com"
 	err = conf.Validate()
-	require.EqualError(t, err, "invalid configuration: sendgrid api key and service email are required")
+	require.EqualError(t, err, "invalid configuration: sendgrid api key is required if no email transport is configured")
 
-	conf.ServiceEmail = "service@example.com"
+	conf.Transport.URL = "maildir:///var/spool/trisa"
+	err = conf.Validate()
+	require.NoError(t, err, "expected valid configuration with a maildir transport")
+
+	conf.Transport.URL = ""
+	conf.SendGridAPIKey = "supersecretapikey"
 	conf.Storage = "fixtures/emails"
 	err = conf.Validate()
 	require.EqualError(t, err, "invalid configuration: email archiving is only supported in testing mode")
@@ -327,6 +352,14 @@ func TestEmailConfigValidation(t *testing.T) {
 	conf.Testing = true
 	err = conf.Validate()
 	require.NoError(t, err, "expected valid configuration")
+
+	conf.DefaultLocale = "japanese"
+	err = conf.Validate()
+	require.EqualError(t, err, `invalid configuration: invalid locale "japanese"`)
+
+	conf.DefaultLocale = "ja-JP"
+	err = conf.Validate()
+	require.NoError(t, err, "expected valid configuration with a regional default locale")
 }
 
 func TestCacheConfigValidation(t *testing.T) {
@@ -347,6 +380,37 @@ func TestCacheConfigValidation(t *testing.T) {
 	require.NoError(t, err, "expected valid configuration")
 }
 
+func TestWebhooksConfigValidation(t *testing.T) {
+	conf := config.WebhooksConfig{
+		Enabled:        true,
+		Timeout:        10 * time.Second,
+		MaxAttempts:    8,
+		Backoff:        time.Minute,
+		MaxBackoff:     time.Hour,
+		ExpiryWarnings: []int{30, 7},
+	}
+	require.EqualError(t, conf.Validate(), "invalid configuration: webhooks interval must be greater than 0")
+
+	conf.Interval = time.Minute
+	conf.MaxAttempts = 0
+	require.EqualError(t, conf.Validate(), "invalid configuration: webhooks max attempts must be greater than 0")
+
+	conf.MaxAttempts = 8
+	conf.MaxBackoff = time.Second
+	require.EqualError(t, conf.Validate(), "invalid configuration: webhooks max backoff must be greater than or equal to the backoff")
+
+	conf.MaxBackoff = time.Hour
+	conf.ExpiryWarnings = []int{30, 0}
+	require.EqualError(t, conf.Validate(), "invalid configuration: webhooks expiry warnings must be a positive number of days")
+
+	conf.ExpiryWarnings = []int{30, 7}
+	require.NoError(t, conf.Validate(), "expected valid configuration")
+
+	// Webhooks configuration is not validated if webhooks are disabled
+	conf = config.WebhooksConfig{}
+	require.NoError(t, conf.Validate(), "expected valid configuration")
+}
+
 // Returns the current environment for the specified keys, or if no keys are specified
 // then returns the current environment for all keys in testEnv.
 func curEnv(keys ...string) map[string]string {
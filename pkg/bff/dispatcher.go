@@ -0,0 +1,588 @@
+package bff
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/segmentio/ksuid"
+	"github.com/trisacrypto/directory/pkg"
+	"github.com/trisacrypto/directory/pkg/bff/api/v1"
+	"github.com/trisacrypto/directory/pkg/bff/config"
+	records "github.com/trisacrypto/directory/pkg/bff/models/v1"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/store"
+	"github.com/trisacrypto/directory/pkg/utils/netguard"
+	"github.com/trisacrypto/directory/pkg/utils/retry"
+	"github.com/trisacrypto/directory/pkg/utils/sentry"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Maximum length of a delivery error that is stored in the delivery log.
+const maxDeliveryError = 512
+
+// NewWebhookDispatcher creates a dispatcher that detects changes to the directory
+// records of organizations in the testnet and mainnet databases and delivers them to
+// the webhooks of the organization along with any other queued events.
+func NewWebhookDispatcher(conf config.WebhooksConfig, db store.OrganizationStore, testnet, mainnet store.DirectoryStore) *WebhookDispatcher {
+	d := &WebhookDispatcher{
+		conf:    conf,
+		db:      db,
+		testnet: testnet,
+		mainnet: mainnet,
+		client: &http.Client{
+			Timeout:   conf.Timeout,
+			Transport: webhookTransport(conf.Insecure),
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				// Redirects are not followed so that payloads are only sent to the URL
+				// that was registered by the organization.
+				return http.ErrUseLastResponse
+			},
+		},
+		policy: retry.Policy{
+			MaxAttempts: conf.MaxAttempts,
+			Backoff:     conf.Backoff,
+			MaxBackoff:  conf.MaxBackoff,
+		},
+	}
+	d.loop = retry.NewLoop("webhook dispatcher", conf.Interval, d.Process)
+	return d
+}
+
+// Returns the transport used to deliver webhooks, which refuses to connect to internal
+// addresses, e.g. the cloud metadata endpoint, unless insecure webhooks are allowed for
+// testing with local receivers.
+func webhookTransport(insecure bool) http.RoundTripper {
+	if insecure {
+		return http.DefaultTransport
+	}
+	return netguard.NewTransport()
+}
+
+// WebhookDispatcher periodically checks the organizations that have webhooks for
+// changes to their registrations and certificates, enqueueing an event for each change
+// and then delivering all due events with a signed POST request. Failed deliveries are
+// retried with an exponential backoff until the maximum number of attempts is reached.
+//
+// The embedded mutex is only held while organizations are saved, the delivering mutex
+// is held while webhooks are delivered so that events can be enqueued by broadcasts
+// without waiting for slow webhooks to respond.
+type WebhookDispatcher struct {
+	sync.Mutex
+	delivering sync.Mutex
+	conf       config.WebhooksConfig
+	db         store.OrganizationStore
+	testnet    store.DirectoryStore
+	mainnet    store.DirectoryStore
+	client     *http.Client
+	policy     retry.Policy
+	loop       *retry.Loop
+}
+
+// Describes a change that should be sent to the webhooks of an organization.
+type webhookEvent struct {
+	event string
+	data  *api.WebhookEventData
+}
+
+// Run starts the WebhookDispatcher as a go routine under the provided waitgroup. For
+// graceful shutdown, the caller must invoke the Stop method to signal the dispatcher
+// routine to stop and block on the waitgroup if provided.
+func (d *WebhookDispatcher) Run(wg *sync.WaitGroup) error {
+	return d.loop.Run(wg)
+}
+
+// Stop signals the WebhookDispatcher routine to shutdown.
+// Note: This does not wait for the dispatcher to stop and the caller should block on
+// the waitgroup passed to the Run method in order to implement a graceful shutdown.
+func (d *WebhookDispatcher) Stop() {
+	d.loop.Stop()
+}
+
+// Process performs one iteration of the dispatcher, detecting the changes to the
+// directory records of every organization with a webhook and attempting to deliver
+// every event that is due. Errors for individual organizations are logged rather than
+// returned so that one organization cannot block the webhooks of another.
+func (d *WebhookDispatcher) Process(ctx context.Context) (err error) {
+	d.delivering.Lock()
+	defer d.delivering.Unlock()
+
+	var orgs []*records.Organization
+	if orgs, err = d.organizations(ctx); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, org := range orgs {
+		if err = d.process(ctx, org, now); err != nil {
+			sentry.Error(ctx).Err(err).Str("org_id", org.Id).Msg("could not process organization webhooks")
+		}
+	}
+	return nil
+}
+
+// Broadcast enqueues an event for every organization that has a webhook subscribed to
+// the event, e.g. network announcements, returning the number of deliveries enqueued.
+// The dispatcher is notified and sends the deliveries the next time it runs; broadcasts
+// do not wait for webhooks that are currently being delivered.
+func (d *WebhookDispatcher) Broadcast(ctx context.Context, event string, data *api.WebhookEventData) (enqueued int, err error) {
+	var orgs []*records.Organization
+	if orgs, err = d.organizations(ctx); err != nil {
+		return 0, err
+	}
+
+	for _, org := range orgs {
+		original := proto.Clone(org).(*records.Organization)
+
+		var n int
+		if n, err = EnqueueWebhookEvent(org, event, data); err != nil {
+			return enqueued, err
+		}
+
+		if n > 0 {
+			if err = d.save(ctx, org, original); err != nil {
+				return enqueued, fmt.Errorf("could not save organization %s: %w", org.Id, err)
+			}
+			enqueued += n
+		}
+	}
+
+	if enqueued > 0 {
+		d.loop.Notify()
+	}
+	return enqueued, nil
+}
+
+// EnqueueWebhookEvent creates the payload of the event and adds it as a pending delivery
+// to the active webhooks of the organization that are subscribed to the event,
+// returning the number of deliveries that were enqueued.
+// Note: The caller is responsible for saving the organization record to the database.
+func EnqueueWebhookEvent(org *records.Organization, event string, data *api.WebhookEventData) (enqueued int, err error) {
+	var payload []byte
+	for _, hook := range org.Webhooks {
+		if !hook.Active || !hook.Subscribed(event) {
+			continue
+		}
+
+		// Only create the payload if at least one webhook is subscribed
+		if payload == nil {
+			if payload, err = json.Marshal(&api.WebhookEvent{
+				ID:      ksuid.New().String(),
+				Event:   event,
+				OrgID:   org.Id,
+				Created: time.Now().UTC().Format(time.RFC3339),
+				Data:    data,
+			}); err != nil {
+				return 0, fmt.Errorf("could not marshal webhook event: %w", err)
+			}
+		}
+
+		hook.Enqueue(event, payload)
+		enqueued++
+	}
+	return enqueued, nil
+}
+
+// Returns the organizations that have at least one webhook. The organizations are
+// collected before they are processed so that they are not updated while iterating.
+func (d *WebhookDispatcher) organizations(ctx context.Context) (orgs []*records.Organization, err error) {
+	iter := d.db.ListOrganizations(ctx)
+	defer iter.Release()
+
+	for iter.Next() {
+		var org *records.Organization
+		if org, err = iter.Organization(); err != nil {
+			sentry.Error(ctx).Err(err).Str("org_id", iter.ID()).Msg("could not parse organization from the database")
+			continue
+		}
+
+		if len(org.Webhooks) > 0 {
+			orgs = append(orgs, org)
+		}
+	}
+
+	if err = iter.Error(); err != nil {
+		return nil, fmt.Errorf("could not iterate over organizations: %w", err)
+	}
+	return orgs, nil
+}
+
+// Detect the changes to the organization's directory records, deliver the due events to
+// its webhooks, and save the organization if anything was modified.
+func (d *WebhookDispatcher) process(ctx context.Context, org *records.Organization, now time.Time) (err error) {
+	original := proto.Clone(org).(*records.Organization)
+
+	for _, network := range []string{config.TestNet, config.MainNet} {
+		var events []*webhookEvent
+		if events, err = d.detect(ctx, org, network, now); err != nil {
+			sentry.Warn(ctx).Err(err).Str("org_id", org.Id).Str("network", network).Msg("could not detect webhook events")
+			continue
+		}
+
+		for _, e := range events {
+			if _, err = EnqueueWebhookEvent(org, e.event, e.data); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, hook := range org.Webhooks {
+		if hook.Active {
+			for _, delivery := range hook.Deliveries {
+				if delivery.Due(now) {
+					d.deliver(ctx, org, hook, delivery)
+				}
+			}
+		}
+		hook.TrimDeliveries(records.MaxWebhookDeliveries)
+	}
+
+	if !proto.Equal(original, org) {
+		if err = d.save(ctx, org, original); err != nil {
+			return fmt.Errorf("could not save organization: %w", err)
+		}
+	}
+	return nil
+}
+
+// Save the webhook state and delivery logs modified by the dispatcher to the latest
+// version of the organization in the database. The organization that was read before
+// the webhooks were delivered is not written back since the BFF handlers may have
+// modified it in the meantime, e.g. by adding collaborators or deleting webhooks, and
+// the dispatcher lock does not prevent those changes. The lock is held while saving so
+// that deliveries enqueued by concurrent broadcasts are not overwritten.
+func (d *WebhookDispatcher) save(ctx context.Context, org, original *records.Organization) (err error) {
+	d.Lock()
+	defer d.Unlock()
+
+	var latest *records.Organization
+	if latest, err = d.db.RetrieveOrganization(ctx, org.UUID()); err != nil {
+		return err
+	}
+
+	mergeWebhookDeliveries(latest, org, original)
+	return d.db.UpdateOrganization(ctx, latest)
+}
+
+// Applies the changes made to the webhook state and delivery logs of the organization
+// since the original version was read to the latest version of the organization.
+// Attempted deliveries are updated and enqueued deliveries are appended, but webhooks
+// and deliveries that were removed from the latest version are not restored and all
+// other fields of the latest version are kept as is.
+func mergeWebhookDeliveries(latest, org, original *records.Organization) {
+	latest.WebhookState = org.WebhookState
+
+	existing := make(map[string]struct{})
+	for _, hook := range original.Webhooks {
+		for _, delivery := range hook.Deliveries {
+			existing[delivery.Id] = struct{}{}
+		}
+	}
+
+	for id, hook := range latest.Webhooks {
+		modified, ok := org.Webhooks[id]
+		if !ok {
+			continue
+		}
+
+		deliveries := make(map[string]*records.WebhookDelivery, len(modified.Deliveries))
+		for _, delivery := range modified.Deliveries {
+			deliveries[delivery.Id] = delivery
+		}
+
+		// Update the deliveries that are still in the latest delivery log
+		for i, delivery := range hook.Deliveries {
+			if update, ok := deliveries[delivery.Id]; ok {
+				hook.Deliveries[i] = update
+				delete(deliveries, delivery.Id)
+			}
+		}
+
+		// Append the deliveries that were enqueued, in the order they were enqueued
+		for _, delivery := range modified.Deliveries {
+			_, remaining := deliveries[delivery.Id]
+			_, removed := existing[delivery.Id]
+			if remaining && !removed {
+				hook.Deliveries = append(hook.Deliveries, delivery)
+			}
+		}
+		hook.TrimDeliveries(records.MaxWebhookDeliveries)
+	}
+}
+
+// Compares the directory record of the organization on the network with the state
+// observed the last time the dispatcher ran and returns the events for any changes.
+// The first time a record is observed only the state is recorded, since the previous
+// state of the record is unknown.
+func (d *WebhookDispatcher) detect(ctx context.Context, org *records.Organization, network string, now time.Time) (events []*webhookEvent, err error) {
+	var (
+		record *records.DirectoryRecord
+		db     store.DirectoryStore
+	)
+
+	switch network {
+	case config.TestNet:
+		record, db = org.Testnet, d.testnet
+	case config.MainNet:
+		record, db = org.Mainnet, d.mainnet
+	default:
+		return nil, fmt.Errorf("unknown network: %s", network)
+	}
+
+	// The organization is not registered on the network
+	if record == nil || record.Id == "" {
+		delete(org.WebhookState, network)
+		return nil, nil
+	}
+
+	var vasp *pb.VASP
+	if vasp, err = db.RetrieveVASP(ctx, record.Id); err != nil {
+		return nil, fmt.Errorf("could not retrieve VASP %s: %w", record.Id, err)
+	}
+
+	var state *records.WebhookState
+	if state, err = currentWebhookState(vasp); err != nil {
+		return nil, err
+	}
+
+	prev := org.WebhookState[network]
+	if prev != nil && prev.VaspId == state.VaspId {
+		events = diffWebhookState(network, prev, state)
+
+		// Carry over the expiration warnings that were sent for the same certificate
+		if prev.Certificate == state.Certificate {
+			state.ExpiryWarnings = prev.ExpiryWarnings
+		}
+	}
+
+	if warning := d.expiryWarning(network, vasp, state, now); warning != nil {
+		events = append(events, warning)
+	}
+
+	if prev == nil || !proto.Equal(prev, state) {
+		state.Modified = now.UTC().Format(time.RFC3339Nano)
+		if org.WebhookState == nil {
+			org.WebhookState = make(map[string]*records.WebhookState)
+		}
+		org.WebhookState[network] = state
+	}
+	return events, nil
+}
+
+// Returns the webhook state of the VASP record that is compared to detect changes.
+func currentWebhookState(vasp *pb.VASP) (state *records.WebhookState, err error) {
+	state = &records.WebhookState{
+		VaspId:             vasp.Id,
+		VerificationStatus: vasp.VerificationStatus.String(),
+		VerifiedContacts:   make(map[string]bool),
+	}
+
+	contacts, errs := models.ContactVerifications(vasp)
+	if errs != nil {
+		return nil, fmt.Errorf("could not retrieve contact verifications: %w", errs)
+	}
+
+	for kind, verified := range contacts {
+		if verified {
+			state.VerifiedContacts[kind] = true
+		}
+	}
+
+	if vasp.IdentityCertificate != nil {
+		state.Certificate = models.GetCertID(vasp.IdentityCertificate)
+	}
+
+	if state.CertificateRequest, err = models.GetLatestCertReqID(vasp); err != nil {
+		return nil, fmt.Errorf("could not retrieve latest certificate request: %w", err)
+	}
+	return state, nil
+}
+
+// Returns the events for the changes between the previous and current webhook state.
+func diffWebhookState(network string, prev, state *records.WebhookState) (events []*webhookEvent) {
+	if prev.VerificationStatus != state.VerificationStatus {
+		events = append(events, &webhookEvent{
+			event: records.WebhookRegistrationStatus,
+			data: &api.WebhookEventData{
+				Network:        network,
+				VASPID:         state.VaspId,
+				Status:         state.VerificationStatus,
+				PreviousStatus: prev.VerificationStatus,
+			},
+		})
+	}
+
+	// Sort the contacts so that events are sent in a consistent order
+	kinds := make([]string, 0, len(state.VerifiedContacts))
+	for kind := range state.VerifiedContacts {
+		if !prev.VerifiedContacts[kind] {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+
+	for _, kind := range kinds {
+		events = append(events, &webhookEvent{
+			event: records.WebhookContactVerified,
+			data: &api.WebhookEventData{
+				Network: network,
+				VASPID:  state.VaspId,
+				Contact: kind,
+			},
+		})
+	}
+
+	// A new certificate request for a VASP that already has a certificate means that
+	// the certificate is being reissued rather than issued for the first time.
+	if state.CertificateRequest != "" && state.CertificateRequest != prev.CertificateRequest && prev.Certificate != "" {
+		events = append(events, &webhookEvent{
+			event: records.WebhookReissuanceStarted,
+			data: &api.WebhookEventData{
+				Network:      network,
+				VASPID:       state.VaspId,
+				SerialNumber: prev.Certificate,
+			},
+		})
+	}
+
+	if state.Certificate != "" && state.Certificate != prev.Certificate {
+		events = append(events, &webhookEvent{
+			event: records.WebhookCertificateIssued,
+			data: &api.WebhookEventData{
+				Network:      network,
+				VASPID:       state.VaspId,
+				SerialNumber: state.Certificate,
+			},
+		})
+	}
+	return events
+}
+
+// Returns a warning event if the identity certificate of the VASP has crossed one of
+// the configured expiration warnings that has not already been sent. If several
+// warnings have been crossed, e.g. because the webhook was created shortly before the
+// certificate expires, only the most urgent warning is sent.
+func (d *WebhookDispatcher) expiryWarning(network string, vasp *pb.VASP, state *records.WebhookState, now time.Time) *webhookEvent {
+	if vasp.IdentityCertificate == nil || len(d.conf.ExpiryWarnings) == 0 {
+		return nil
+	}
+
+	expires, err := time.Parse(time.RFC3339, vasp.IdentityCertificate.NotAfter)
+	if err != nil || !expires.After(now) {
+		return nil
+	}
+
+	sent := make(map[string]struct{}, len(state.ExpiryWarnings))
+	for _, warning := range state.ExpiryWarnings {
+		sent[warning] = struct{}{}
+	}
+
+	urgent := 0
+	for _, days := range d.conf.ExpiryWarnings {
+		if expires.Sub(now) > time.Duration(days)*24*time.Hour {
+			continue
+		}
+
+		warning := strconv.Itoa(days)
+		if _, ok := sent[warning]; ok {
+			continue
+		}
+
+		state.ExpiryWarnings = append(state.ExpiryWarnings, warning)
+		if urgent == 0 || days < urgent {
+			urgent = days
+		}
+	}
+
+	if urgent == 0 {
+		return nil
+	}
+
+	return &webhookEvent{
+		event: records.WebhookCertificateExpiring,
+		data: &api.WebhookEventData{
+			Network:       network,
+			VASPID:        vasp.Id,
+			SerialNumber:  state.Certificate,
+			ExpiresAt:     vasp.IdentityCertificate.NotAfter,
+			DaysRemaining: int(expires.Sub(now).Hours() / 24),
+		},
+	}
+}
+
+// Attempt to deliver the event to the webhook and record the outcome on the delivery.
+// NOTE: the caller must hold the delivering lock to ensure an event is not delivered
+// concurrently.
+func (d *WebhookDispatcher) deliver(ctx context.Context, org *records.Organization, hook *records.Webhook, delivery *records.WebhookDelivery) {
+	now := time.Now()
+	status, err := d.post(ctx, hook, delivery, now)
+
+	delivery.Attempts++
+	delivery.StatusCode = int32(status)
+	delivery.Modified = now.UTC().Format(time.RFC3339Nano)
+
+	outcome, nextAttempt := d.policy.Attempt(delivery.Attempts, err, now)
+	switch outcome {
+	case retry.Succeeded:
+		delivery.Status = records.WebhookDeliveryState_DELIVERY_SUCCEEDED
+		delivery.LastError = ""
+		delivery.NextAttempt = ""
+		delivery.Delivered = now.Format(time.RFC3339)
+		log.Debug().Str("org_id", org.Id).Str("webhook", hook.Id).Str("delivery", delivery.Id).Str("event", delivery.Event).Uint32("attempts", delivery.Attempts).Msg("webhook event delivered")
+	case retry.Failed:
+		delivery.Status = records.WebhookDeliveryState_DELIVERY_FAILED
+		delivery.LastError = truncate(err.Error(), maxDeliveryError)
+		delivery.NextAttempt = ""
+		sentry.Warn(ctx).Err(err).Str("org_id", org.Id).Str("webhook", hook.Id).Str("delivery", delivery.Id).Int("attempts", int(delivery.Attempts)).Msg("webhook event could not be delivered")
+	case retry.Retrying:
+		delivery.Status = records.WebhookDeliveryState_DELIVERY_RETRYING
+		delivery.LastError = truncate(err.Error(), maxDeliveryError)
+		delivery.NextAttempt = nextAttempt
+		log.Debug().Err(err).Str("org_id", org.Id).Str("webhook", hook.Id).Str("delivery", delivery.Id).Int("attempts", int(delivery.Attempts)).Msg("webhook event delivery will be retried")
+	}
+}
+
+// Post the signed payload of the delivery to the webhook URL, returning the status code
+// of the response and an error if the webhook did not respond with a 2xx status.
+func (d *WebhookDispatcher) post(ctx context.Context, hook *records.Webhook, delivery *records.WebhookDelivery, now time.Time) (_ int, err error) {
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(ctx, http.MethodPost, hook.Url, bytes.NewReader(delivery.Payload)); err != nil {
+		return 0, fmt.Errorf("could not create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "GDS BFF/"+pkg.Version())
+	req.Header.Set(records.WebhookEventHeader, delivery.Event)
+	req.Header.Set(records.WebhookDeliveryHeader, delivery.Id)
+	req.Header.Set(records.WebhookSignatureHeader, hook.Sign(delivery.Payload, now))
+
+	var rep *http.Response
+	if rep, err = d.client.Do(req); err != nil {
+		return 0, err
+	}
+	defer rep.Body.Close()
+
+	// Drain the body so that the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(rep.Body, 4096))
+
+	if rep.StatusCode < 200 || rep.StatusCode >= 300 {
+		return rep.StatusCode, fmt.Errorf("webhook responded with status %s", rep.Status)
+	}
+	return rep.StatusCode, nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/auth0/go-auth0/management"
 	"github.com/rs/zerolog/log"
 	"github.com/sendgrid/rest"
-	"github.com/sendgrid/sendgrid-go"
 	sgmail "github.com/sendgrid/sendgrid-go/helpers/mail"
 	"github.com/trisacrypto/directory/pkg/bff/config"
 	"github.com/trisacrypto/directory/pkg/bff/models/v1"
@@ -28,10 +27,14 @@ func New(conf config.EmailConfig) (m *EmailManager, err error) {
 			Storage: conf.Storage,
 		}
 	} else {
-		if conf.SendGridAPIKey == "" {
-			return nil, errors.New("cannot create sendgrid client without API key")
+		if m.client, err = emails.Open(conf.Transport, conf.SendGridAPIKey); err != nil {
+			return nil, err
 		}
-		m.client = sendgrid.NewSendClient(conf.SendGridAPIKey)
+	}
+
+	// Load the template overrides and localized templates
+	if err = LoadTemplates(conf.Templates); err != nil {
+		return nil, err
 	}
 
 	// Parse the service email from the configuration
@@ -42,7 +45,8 @@ func New(conf config.EmailConfig) (m *EmailManager, err error) {
 	return m, nil
 }
 
-// EmailManager allows the BFF to send rich emails using the SendGrid service.
+// EmailManager allows the BFF to send rich emails using SendGrid or the configured
+// email transport.
 type EmailManager struct {
 	conf         config.EmailConfig
 	client       emails.EmailClient
@@ -80,7 +84,10 @@ func (m *EmailManager) SendUserInvite(user *management.User, inviter *management
 	}
 	ctx.InviterName = inviter.GetName()
 
-	msg, err := InviteUserEmail(m.serviceEmail.Name, m.serviceEmail.Address, ctx.UserName, ctx.UserEmail, ctx)
+	// Localize the invite for the user, falling back to the locale of the inviter since
+	// new users do not have a preferred locale until they have registered.
+	locales := []string{UserLocale(user), UserLocale(inviter), m.conf.DefaultLocale}
+	msg, err := InviteUserEmail(m.serviceEmail.Name, m.serviceEmail.Address, ctx.UserName, ctx.UserEmail, ctx, locales...)
 	if err != nil {
 		sentry.Error(nil).Err(err).Msg("could not create user invite email")
 		return err
@@ -92,3 +99,12 @@ func (m *EmailManager) SendUserInvite(user *management.User, inviter *management
 	}
 	return nil
 }
+
+// UserLocale returns the preferred locale of the Auth0 user from the locale in the user
+// metadata or an empty string if the user has not specified a preferred locale.
+func UserLocale(user *management.User) string {
+	if locale, ok := user.GetUserMetadata()["locale"].(string); ok {
+		return locale
+	}
+	return ""
+}
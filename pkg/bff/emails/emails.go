@@ -2,21 +2,60 @@ package emails
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 	"text/template"
 
 	"github.com/sendgrid/sendgrid-go/helpers/mail"
+	"github.com/trisacrypto/directory/pkg/utils/emails"
+	"github.com/trisacrypto/directory/pkg/utils/sentry"
 )
 
-// Compile all email templates into a top-level global variable.
-var templates map[string]*template.Template
+// Compile all email templates into top-level global variables. Subject templates are
+// only available as overrides.
+var (
+	templates map[string]*template.Template
+	subjects  map[string]*template.Template
+)
 
 func init() {
-	templates = make(map[string]*template.Template)
+	if err := LoadTemplates(""); err != nil {
+		panic(err)
+	}
+}
+
+// LoadTemplates compiles the email templates, replacing the compiled-in templates with
+// the template overrides in the specified directory and adding any localized templates
+// in its locale subdirectories (see emails.ReadTemplates). If dir is empty only the
+// compiled-in templates are loaded. This should be called once at startup.
+func LoadTemplates(dir string) (err error) {
+	var overrides map[string]string
+	if overrides, err = emails.ReadTemplates(dir); err != nil {
+		return err
+	}
+
+	compiled := make(map[string]*template.Template)
 	for _, name := range AssetNames() {
 		data := MustAssetString(name)
-		templates[name] = template.Must(template.New(name).Parse(data))
+		compiled[name] = template.Must(template.New(name).Parse(data))
+	}
+
+	compiledSubjects := make(map[string]*template.Template)
+	for name, data := range overrides {
+		if filepath.Ext(name) == emails.SubjectExt {
+			if compiledSubjects[name], err = template.New(name).Parse(strings.TrimSpace(data)); err != nil {
+				return fmt.Errorf("could not parse email template %s: %w", name, err)
+			}
+			continue
+		}
+
+		if compiled[name], err = template.New(name).Parse(data); err != nil {
+			return fmt.Errorf("could not parse email template %s: %w", name, err)
+		}
 	}
+
+	templates, subjects = compiled, compiledSubjects
+	return nil
 }
 
 //===========================================================================
@@ -53,15 +92,15 @@ func (d InviteUserData) Subject() string {
 
 // InviteUserEmail creates a new user invite email, ready for sending by rendering the
 // text and html templates with the supplied data then constructing a sendgrid email.
-func InviteUserEmail(sender, senderEmail, recipient, recipientEmail string, data InviteUserData) (msg *mail.SGMailV3, err error) {
+func InviteUserEmail(sender, senderEmail, recipient, recipientEmail string, data InviteUserData, locales ...string) (msg *mail.SGMailV3, err error) {
 	var text, html string
-	if text, html, err = Render("invite_user", data); err != nil {
+	if text, html, err = Render("invite_user", data, locales...); err != nil {
 		return nil, err
 	}
 
 	return mail.NewSingleEmail(
 		mail.NewEmail(sender, senderEmail),
-		data.Subject(),
+		Subject("invite_user", data.Subject(), data, locales...),
 		mail.NewEmail(recipient, recipientEmail),
 		text,
 		html,
@@ -73,27 +112,56 @@ func InviteUserEmail(sender, senderEmail, recipient, recipientEmail string, data
 //===========================================================================
 
 // Render returns the text and html executed templates for the specified name and data.
-// Ensure that the extension is not supplied to the render method.
-func Render(name string, data interface{}) (text, html string, err error) {
-	if text, err = render(name+".txt", data); err != nil {
+// Ensure that the extension is not supplied to the render method. If locales are
+// specified, the localized templates of the first locale in the fallback chain that has
+// them are rendered, otherwise the default templates are rendered.
+func Render(name string, data interface{}, locales ...string) (text, html string, err error) {
+	chain := emails.Locales(locales...)
+	if text, err = render(name+emails.TextExt, data, chain); err != nil {
 		return "", "", err
 	}
 
-	if html, err = render(name+".html", data); err != nil {
+	if html, err = render(name+emails.HTMLExt, data, chain); err != nil {
 		return "", "", err
 	}
 
 	return text, html, nil
 }
 
-func render(name string, data interface{}) (_ string, err error) {
+// Subject returns the subject of the email for the specified name, rendering the
+// localized subject template if one has been loaded for a locale in the fallback chain
+// or the subject override if one has been loaded. Otherwise the subject is returned.
+func Subject(name, subject string, data interface{}, locales ...string) string {
+	chain := append(emails.Locales(locales...), "")
+	for _, locale := range chain {
+		if t, ok := subjects[emails.LocalizedName(locale, name+emails.SubjectExt)]; ok {
+			buf := &strings.Builder{}
+			if err := t.Execute(buf, data); err != nil {
+				sentry.Warn(nil).Err(err).Str("template", t.Name()).Msg("could not render email subject template")
+				return subject
+			}
+			return buf.String()
+		}
+	}
+	return subject
+}
+
+func render(name string, data interface{}, chain []string) (_ string, err error) {
 	var (
 		ok bool
 		t  *template.Template
 	)
 
-	if t, ok = templates[name]; !ok {
-		return "", fmt.Errorf("could not find %q in templates", name)
+	for _, locale := range chain {
+		if t, ok = templates[emails.LocalizedName(locale, name)]; ok {
+			break
+		}
+	}
+
+	if t == nil {
+		if t, ok = templates[name]; !ok {
+			return "", fmt.Errorf("could not find %q in templates", name)
+		}
 	}
 
 	buf := &strings.Builder{}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/auth0/go-auth0/management"
 	sgmail "github.com/sendgrid/sendgrid-go/helpers/mail"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -89,6 +90,46 @@ func TestEmailBuilders(t *testing.T) {
 	generateMIME(t, mail, "invite_user_with_name_no_org.mime")
 }
 
+func TestLocalizedTemplates(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "es/invite_user.txt", "{{ .InviterName }} te ha invitado a colaborar en {{ .Organization }}")
+	writeTemplate(t, dir, "es/invite_user.html", "<p>{{ .InviterName }} te ha invitado a colaborar en {{ .Organization }}</p>")
+	writeTemplate(t, dir, "es/invite_user.subject", "{{ .InviterName }} te ha invitado a colaborar en {{ .Organization }}")
+
+	require.NoError(t, emails.LoadTemplates(dir))
+	t.Cleanup(func() { emails.LoadTemplates("") })
+
+	data := emails.InviteUserData{
+		UserEmail:    "tails@gottagofast.com",
+		InviterName:  "Sonic the Hedgehog",
+		InviterEmail: "sonic@gottagofast.com",
+		Organization: "Team Sonic",
+		InviteURL:    "https://gottagofast.com/invite",
+	}
+
+	msg, err := emails.InviteUserEmail("Sender", "sender@example.com", "", data.UserEmail, data, "", "es-MX", "en")
+	require.NoError(t, err, "failed to create localized user invite email")
+	require.Equal(t, "Sonic the Hedgehog te ha invitado a colaborar en Team Sonic", msg.Subject)
+	require.Equal(t, "Sonic the Hedgehog te ha invitado a colaborar en Team Sonic", msg.Content[0].Value)
+	require.Equal(t, "<p>Sonic the Hedgehog te ha invitado a colaborar en Team Sonic</p>", msg.Content[1].Value)
+
+	// Fall back to the default templates if the locale has no localized templates
+	msg, err = emails.InviteUserEmail("Sender", "sender@example.com", "", data.UserEmail, data, "ja", "en")
+	require.NoError(t, err, "failed to create user invite email")
+	require.Equal(t, data.Subject(), msg.Subject)
+
+	// The locale of the user is stored in the Auth0 user metadata
+	user := &management.User{UserMetadata: &map[string]interface{}{"locale": "es"}}
+	require.Equal(t, "es", emails.UserLocale(user))
+	require.Empty(t, emails.UserLocale(&management.User{}))
+}
+
+func writeTemplate(t *testing.T, dir, name, data string) {
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(data), 0644))
+}
+
 func (s *EmailTestSuite) TestUserInviteEmail() {
 	require := s.Require()
 	service, err := mail.ParseAddress(s.conf.ServiceEmail)
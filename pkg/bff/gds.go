@@ -15,6 +15,7 @@ import (
 	"github.com/trisacrypto/directory/pkg/bff/auth"
 	"github.com/trisacrypto/directory/pkg/bff/config"
 	records "github.com/trisacrypto/directory/pkg/bff/models/v1"
+	members "github.com/trisacrypto/directory/pkg/gds/members/v1alpha1"
 	"github.com/trisacrypto/directory/pkg/models/v1"
 	"github.com/trisacrypto/directory/pkg/store"
 	"github.com/trisacrypto/directory/pkg/utils"
@@ -22,7 +23,9 @@ import (
 	"github.com/trisacrypto/directory/pkg/utils/wire"
 	gds "github.com/trisacrypto/trisa/pkg/trisa/gds/api/v1beta1"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
@@ -704,15 +707,25 @@ func (s *Server) SubmitRegistration(c *gin.Context) {
 	ctx, cancel := utils.WithDeadline(context.Background())
 	defer cancel()
 
+	// The CSR is validated with the registration form and is sent to the directory
+	// service in the request metadata since the RegisterRequest has no CSR field. The
+	// ID of the registration job is returned by the directory in the response header.
+	var header metadata.MD
 	switch network {
 	case config.TestNet:
 		req.TrisaEndpoint = org.Registration.Testnet.Endpoint
 		req.CommonName = org.Registration.Testnet.CommonName
-		rep, err = s.testnetGDS.Register(ctx, req)
+		if csr := org.Registration.Testnet.Csr; csr != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, models.CSRMetadataKey, csr)
+		}
+		rep, err = s.testnetGDS.Register(ctx, req, grpc.Header(&header))
 	case config.MainNet:
 		req.TrisaEndpoint = org.Registration.Mainnet.Endpoint
 		req.CommonName = org.Registration.Mainnet.CommonName
-		rep, err = s.mainnetGDS.Register(ctx, req)
+		if csr := org.Registration.Mainnet.Csr; csr != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, models.CSRMetadataKey, csr)
+		}
+		rep, err = s.mainnetGDS.Register(ctx, req, grpc.Header(&header))
 	default:
 		c.JSON(http.StatusNotFound, api.ErrorResponse("network should be either testnet or mainnet"))
 		return
@@ -744,6 +757,10 @@ func (s *Server) SubmitRegistration(c *gin.Context) {
 		RefreshToken:        true,
 	}
 
+	if jobs := header.Get(models.JobMetadataKey); len(jobs) > 0 {
+		out.JobID = jobs[0]
+	}
+
 	if rep.Error != nil && rep.Error.Code != 0 {
 		if out.Error, err = wire.Rewire(rep.Error); err != nil {
 			sentry.Error(c).Err(err).Str("network", network).Msg("could not rewire response error struct")
@@ -1206,7 +1223,7 @@ func (s *Server) Attention(c *gin.Context) {
 // the user.
 //
 // @Summary Get current registration status for the user [read:vasp]
-// @Description Returns timestamps indicating when the user has submitted their TestNet and MainNet registrations.
+// @Description Returns timestamps indicating when the user has submitted their TestNet and MainNet registrations and the domain challenges that must be completed before certificates are issued.
 // @Tags registration
 // @Produce json
 // @Success 200 {object} api.RegistrationStatus
@@ -1231,9 +1248,159 @@ func (s *Server) RegistrationStatus(c *gin.Context) {
 	if org.Mainnet != nil && org.Mainnet.Submitted != "" {
 		out.MainNetSubmitted = org.Mainnet.Submitted
 	}
+
+	// Add the domain challenges of the registrations that have been created in the
+	// directory services. Challenges are omitted if the directory cannot be reached
+	// since the registration status is still available from the organization.
+	var testnetID, mainnetID string
+	if org.Testnet != nil {
+		testnetID = org.Testnet.Id
+	}
+	if org.Mainnet != nil {
+		mainnetID = org.Mainnet.Id
+	}
+
+	if testnetID != "" || mainnetID != "" {
+		rpc := func(ctx context.Context, client GlobalDirectoryClient, network string) (rep proto.Message, err error) {
+			req := &members.DomainChallengesRequest{}
+			switch network {
+			case config.TestNet:
+				req.VaspId = testnetID
+			case config.MainNet:
+				req.VaspId = mainnetID
+			default:
+				return nil, fmt.Errorf("unknown network: %s", network)
+			}
+
+			if req.VaspId == "" {
+				return nil, nil
+			}
+			return client.DomainChallenges(ctx, req)
+		}
+
+		results, errs := s.ParallelGDSRequests(sentry.RequestContext(c), rpc, false)
+		for i, network := range []string{config.TestNet, config.MainNet} {
+			if errs[i] != nil {
+				sentry.Warn(c).Err(errs[i]).Str("network", network).Msg("could not retrieve domain challenges")
+				continue
+			}
+
+			rep, ok := results[i].(*members.DomainChallengesReply)
+			if !ok || rep == nil {
+				continue
+			}
+
+			challenges := make([]*api.DomainChallenge, 0, len(rep.Challenges))
+			for _, challenge := range rep.Challenges {
+				challenges = append(challenges, &api.DomainChallenge{
+					Domain:      challenge.Domain,
+					Token:       challenge.Token,
+					Status:      challenge.Status,
+					DNSRecord:   challenge.DnsRecord,
+					DNSValue:    challenge.DnsValue,
+					HTTPPath:    challenge.HttpPath,
+					ValidatedBy: challenge.ValidatedBy,
+					Attempts:    challenge.Attempts,
+					Error:       challenge.Error,
+					LastChecked: challenge.LastChecked,
+					Validated:   challenge.Validated,
+				})
+			}
+
+			if len(challenges) == 0 {
+				continue
+			}
+
+			switch network {
+			case config.TestNet:
+				out.TestNetChallenges = challenges
+			case config.MainNet:
+				out.MainNetChallenges = challenges
+			}
+		}
+	}
 	c.JSON(http.StatusOK, out)
 }
 
+// RegistrationJobStatus returns the processing status of the registration job whose ID
+// was returned when the registration was submitted to the TestNet or MainNet directory.
+//
+// @Summary Get the status of a registration job [read:vasp]
+// @Description Returns the processing status of the job that processes the registration submitted to the TestNet or MainNet directory service.
+// @Tags registration
+// @Produce json
+// @Param directory path string true "Directory service the registration was submitted to (testnet or mainnet)"
+// @Param jobID path string true "ID of the registration job"
+// @Success 200 {object} api.JobStatus
+// @Failure 401 {object} api.Reply
+// @Failure 404 {object} api.Reply
+// @Failure 500 {object} api.Reply
+// @Router /register/{directory}/jobs/{jobID} [get]
+func (s *Server) RegistrationJobStatus(c *gin.Context) {
+	var err error
+	network := strings.ToLower(c.Param("network"))
+	if network != config.TestNet && network != config.MainNet {
+		c.JSON(http.StatusNotFound, api.ErrorResponse("network should be either testnet or mainnet"))
+		return
+	}
+
+	// Load the organization from the claims
+	// NOTE: this method will handle the error logging and response.
+	var org *records.Organization
+	if org, err = s.OrganizationFromClaims(c); err != nil {
+		return
+	}
+
+	// The job must belong to the VASP registered by the organization
+	var client GlobalDirectoryClient
+	req := &members.JobStatusRequest{JobId: c.Param("jobID")}
+	switch network {
+	case config.TestNet:
+		client = s.testnetGDS
+		if org.Testnet != nil {
+			req.VaspId = org.Testnet.Id
+		}
+	case config.MainNet:
+		client = s.mainnetGDS
+		if org.Mainnet != nil {
+			req.VaspId = org.Mainnet.Id
+		}
+	}
+
+	if req.VaspId == "" {
+		c.JSON(http.StatusNotFound, api.ErrorResponse(fmt.Errorf("registration has not been submitted to the %s", network)))
+		return
+	}
+
+	ctx, cancel := utils.WithDeadline(context.Background())
+	defer cancel()
+
+	var rep *members.JobStatusReply
+	if rep, err = client.JobStatus(ctx, req); err != nil {
+		serr, _ := status.FromError(err)
+		switch serr.Code() {
+		case codes.NotFound, codes.InvalidArgument:
+			c.JSON(http.StatusNotFound, api.ErrorResponse("registration job not found"))
+		default:
+			sentry.Error(c).Err(err).Str("code", serr.Code().String()).Str("network", network).Msg("could not retrieve registration job status")
+			c.JSON(http.StatusInternalServerError, api.ErrorResponse(fmt.Errorf("could not retrieve registration job status from %s", network)))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, &api.JobStatus{
+		JobID:       rep.JobId,
+		VASPID:      rep.VaspId,
+		Type:        rep.Type,
+		Status:      rep.Status,
+		Attempts:    rep.Attempts,
+		NextAttempt: rep.NextAttempt,
+		Created:     rep.Created,
+		Modified:    rep.Modified,
+		Finished:    rep.Finished,
+	})
+}
+
 // Checks if the user supplied registered directory is one of the known directories that
 // maps to either the testnet or to the mainnet (by domain).
 func validRegisteredDirectory(r string) bool {
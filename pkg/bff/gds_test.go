@@ -2,6 +2,12 @@ package bff_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"net/http"
@@ -15,12 +21,15 @@ import (
 	"github.com/trisacrypto/directory/pkg/bff/auth/authtest"
 	"github.com/trisacrypto/directory/pkg/bff/mock"
 	records "github.com/trisacrypto/directory/pkg/bff/models/v1"
+	members "github.com/trisacrypto/directory/pkg/gds/members/v1alpha1"
 	models "github.com/trisacrypto/directory/pkg/models/v1"
 	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
 	"github.com/trisacrypto/directory/pkg/utils/wire"
 	gds "github.com/trisacrypto/trisa/pkg/trisa/gds/api/v1beta1"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
@@ -730,6 +739,16 @@ func (s *bffTestSuite) TestSubmitRegistration() {
 	// Save the registration form fixture on the organization
 	org.Registration = &records.RegistrationForm{}
 	require.NoError(loadFixture("testdata/registration_form.pb.json", org.Registration), "could not load registration form from the fixtures")
+
+	// Submit a CSR with the testnet registration so that it is forwarded to the directory
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err, "could not generate private key")
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: org.Registration.Testnet.CommonName},
+		DNSNames: append([]string{org.Registration.Testnet.CommonName}, org.Registration.Testnet.DnsNames...),
+	}, key)
+	require.NoError(err, "could not create certificate signing request")
+	org.Registration.Testnet.Csr = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
 	require.NoError(s.DB().UpdateOrganization(context.Background(), org), "could not update organization with registration form")
 
 	// Test both the testnet and the mainnet registration
@@ -872,6 +891,25 @@ func (s *bffTestSuite) TestSubmitRegistration() {
 				return nil, status.Error(codes.InvalidArgument, "trixo questionnaire missing in request")
 			}
 
+			// Only the testnet registration has a CSR
+			md, _ := metadata.FromIncomingContext(ctx)
+			csrs := md.Get(models.CSRMetadataKey)
+			switch network {
+			case "testnet":
+				if len(csrs) != 1 || csrs[0] != org.Registration.Testnet.Csr {
+					return nil, status.Error(codes.InvalidArgument, "csr missing in request metadata")
+				}
+			case "mainnet":
+				if len(csrs) != 0 {
+					return nil, status.Error(codes.InvalidArgument, "unexpected csr in request metadata")
+				}
+			}
+
+			// Return the registration job in the header like the directory service
+			if err := grpc.SetHeader(ctx, metadata.Pairs(models.JobMetadataKey, network+"-job")); err != nil {
+				return nil, err
+			}
+
 			// Send the register reply back
 			return reply, nil
 		}
@@ -890,6 +928,7 @@ func (s *bffTestSuite) TestSubmitRegistration() {
 		require.Equal(rep.Status, "PENDING_REVIEW", "the verification status was not returned by the server")
 		require.Equal(rep.Message, "thank you for registering", "a message was not returned from the server")
 		require.Equal(rep.PKCS12Password, "supersecret", "a pkcs12 password was not returned from the server")
+		require.Equal(network+"-job", rep.JobID, "the registration job ID was not returned from the server")
 
 		// Test that a post to an incorrect network returns an error.
 		_, err = s.client.SubmitRegistration(context.TODO(), "notanetwork")
@@ -1579,4 +1618,124 @@ func (s *bffTestSuite) TestRegistrationStatus() {
 	require.NoError(err, "received error from registration status endpoint")
 	require.Equal(org.Testnet.Submitted, reply.TestNetSubmitted, "expected testnet timestamp to be returned")
 	require.Equal(org.Mainnet.Submitted, reply.MainNetSubmitted, "expected mainnet timestamp to be returned")
+	require.Empty(reply.TestNetChallenges, "expected no challenges when the registrations are not in the directory")
+	require.Empty(reply.MainNetChallenges, "expected no challenges when the registrations are not in the directory")
+
+	// Should return the domain challenges of the registrations in the directory and
+	// omit the challenges if the directory is unavailable
+	org.Testnet.Id = "7a96ca2c-2818-4106-932e-1bcfd743b04c"
+	org.Mainnet.Id = "9e069e01-8515-4d57-b9a5-e249f7ab4fca"
+	require.NoError(s.DB().UpdateOrganization(context.Background(), org), "could not update organization in the database")
+	require.NoError(s.testnet.members.UseFixture(mock.DomainChallengesRPC, "testdata/testnet/domain_challenges_reply.json"))
+	require.NoError(s.mainnet.members.UseError(mock.DomainChallengesRPC, codes.Unavailable, "mainnet is unavailable"))
+	defer s.testnet.members.Reset()
+	defer s.mainnet.members.Reset()
+
+	reply, err = s.client.RegistrationStatus(context.TODO())
+	require.NoError(err, "received error from registration status endpoint")
+	require.Equal(org.Testnet.Submitted, reply.TestNetSubmitted, "expected testnet timestamp to be returned")
+	require.Equal(org.Mainnet.Submitted, reply.MainNetSubmitted, "expected mainnet timestamp to be returned")
+	require.Empty(reply.MainNetChallenges, "expected no mainnet challenges when mainnet is unavailable")
+	require.Len(reply.TestNetChallenges, 2, "expected testnet challenges to be returned")
+
+	require.Equal(&api.DomainChallenge{
+		Domain:      "api.alice.vaspbot.net",
+		Token:       "Xv2hY0b4p0QmH1Jd1Z3Zq1y8kO8o9yZlH3Jd8v9uWqE",
+		Status:      "valid",
+		DNSRecord:   "_trisa-challenge.api.alice.vaspbot.net",
+		DNSValue:    "TRISA-DOMAIN-VERIFICATION=Xv2hY0b4p0QmH1Jd1Z3Zq1y8kO8o9yZlH3Jd8v9uWqE",
+		HTTPPath:    "/.well-known/trisa-challenge/Xv2hY0b4p0QmH1Jd1Z3Zq1y8kO8o9yZlH3Jd8v9uWqE",
+		ValidatedBy: "dns-01",
+		Attempts:    2,
+		LastChecked: "2022-10-13T14:32:05Z",
+		Validated:   "2022-10-13T14:32:05Z",
+	}, reply.TestNetChallenges[0])
+	require.Equal("pending", reply.TestNetChallenges[1].Status)
+	require.NotEmpty(reply.TestNetChallenges[1].Error)
+}
+
+func (s *bffTestSuite) TestRegistrationJobStatus() {
+	require := s.Require()
+	defer s.ResetDB()
+	defer s.testnet.members.Reset()
+	defer s.mainnet.members.Reset()
+
+	// Create an organization in the database that has only registered with testnet
+	org := &records.Organization{
+		Testnet: &records.DirectoryRecord{
+			Id:        "7a96ca2c-2818-4106-932e-1bcfd743b04c",
+			Submitted: time.Now().Format(time.RFC3339),
+		},
+	}
+	_, err := s.DB().CreateOrganization(context.Background(), org)
+	require.NoError(err, "could not create organization in the database")
+
+	// Create initial claims fixture
+	claims := &authtest.Claims{
+		Email:       "leopold.wentzel@gmail.com",
+		Permissions: []string{"read:nothing"},
+	}
+
+	// Endpoint must be authenticated
+	_, err = s.client.RegistrationJobStatus(context.TODO(), "testnet", "job")
+	s.requireError(err, http.StatusUnauthorized, "this endpoint requires authentication", "expected error when user is not authenticated")
+
+	// Endpoint requires the read:vasp permission
+	require.NoError(s.SetClientCredentials(claims), "could not create token with incorrect permissions")
+	_, err = s.client.RegistrationJobStatus(context.TODO(), "testnet", "job")
+	s.requireError(err, http.StatusUnauthorized, "user does not have permission to perform this operation", "expected error when user is not authorized")
+
+	claims.Permissions = []string{auth.ReadVASP}
+	claims.OrgID = org.Id
+	require.NoError(s.SetClientCredentials(claims), "could not create token with valid claims")
+
+	// The network must be valid
+	_, err = s.client.RegistrationJobStatus(context.TODO(), "notanetwork", "job")
+	s.requireError(err, http.StatusNotFound, "network should be either testnet or mainnet")
+
+	// The registration must have been submitted to the network
+	_, err = s.client.RegistrationJobStatus(context.TODO(), "mainnet", "job")
+	s.requireError(err, http.StatusNotFound, "registration has not been submitted to the mainnet")
+	require.Zero(s.mainnet.members.Calls[mock.JobStatusRPC], "expected no mainnet job status requests")
+
+	// Jobs that are not found for the VASP return a not found error
+	require.NoError(s.testnet.members.UseError(mock.JobStatusRPC, codes.NotFound, "requested job not found"))
+	_, err = s.client.RegistrationJobStatus(context.TODO(), "testnet", "job")
+	s.requireError(err, http.StatusNotFound, "registration job not found")
+
+	// Directory errors are returned as internal errors
+	require.NoError(s.testnet.members.UseError(mock.JobStatusRPC, codes.Unavailable, "testnet is unavailable"))
+	_, err = s.client.RegistrationJobStatus(context.TODO(), "testnet", "job")
+	s.requireError(err, http.StatusInternalServerError, "could not retrieve registration job status from testnet")
+
+	// The job status should be requested for the VASP of the organization
+	s.testnet.members.OnJobStatus = func(_ context.Context, in *members.JobStatusRequest) (*members.JobStatusReply, error) {
+		if in.JobId != "job" || in.VaspId != org.Testnet.Id {
+			return nil, status.Error(codes.NotFound, "requested job not found")
+		}
+
+		return &members.JobStatusReply{
+			JobId:    in.JobId,
+			VaspId:   in.VaspId,
+			Type:     "registration",
+			Status:   "completed",
+			Attempts: 1,
+			Created:  "2023-04-01T12:00:00Z",
+			Modified: "2023-04-01T12:01:00Z",
+			Finished: "2023-04-01T12:01:00Z",
+		}, nil
+	}
+
+	reply, err := s.client.RegistrationJobStatus(context.TODO(), "testnet", "job")
+	require.NoError(err, "could not retrieve registration job status")
+	require.Equal(&api.JobStatus{
+		JobID:    "job",
+		VASPID:   org.Testnet.Id,
+		Type:     "registration",
+		Status:   "completed",
+		Attempts: 1,
+		Created:  "2023-04-01T12:00:00Z",
+		Modified: "2023-04-01T12:01:00Z",
+		Finished: "2023-04-01T12:01:00Z",
+	}, reply)
 }
@@ -16,9 +16,12 @@ import (
 )
 
 const (
-	ListRPC    = "List"
-	SummaryRPC = "Summary"
-	DetailsRPC = "Details"
+	ListRPC             = "List"
+	SummaryRPC          = "Summary"
+	DetailsRPC          = "Details"
+	JobStatusRPC        = "JobStatus"
+	DomainChallengesRPC = "DomainChallenges"
+	SubmitCSRRPC        = "SubmitCSR"
 )
 
 func NewMembers(conf config.MembersConfig) (m *Members, err error) {
@@ -49,13 +52,16 @@ func NewMembers(conf config.MembersConfig) (m *Members, err error) {
 // NOTE: if the OnRPC function is not set, the test will panic
 type Members struct {
 	members.UnimplementedTRISAMembersServer
-	sock      *bufconn.GRPCListener
-	srv       *grpc.Server
-	client    members.TRISAMembersClient
-	Calls     map[string]int
-	OnList    func(context.Context, *members.ListRequest) (*members.ListReply, error)
-	OnSummary func(context.Context, *members.SummaryRequest) (*members.SummaryReply, error)
-	OnDetails func(context.Context, *members.DetailsRequest) (*members.MemberDetails, error)
+	sock               *bufconn.GRPCListener
+	srv                *grpc.Server
+	client             members.TRISAMembersClient
+	Calls              map[string]int
+	OnList             func(context.Context, *members.ListRequest) (*members.ListReply, error)
+	OnSummary          func(context.Context, *members.SummaryRequest) (*members.SummaryReply, error)
+	OnDetails          func(context.Context, *members.DetailsRequest) (*members.MemberDetails, error)
+	OnJobStatus        func(context.Context, *members.JobStatusRequest) (*members.JobStatusReply, error)
+	OnDomainChallenges func(context.Context, *members.DomainChallengesRequest) (*members.DomainChallengesReply, error)
+	OnSubmitCSR        func(context.Context, *members.SubmitCSRRequest) (*members.SubmitCSRReply, error)
 }
 
 func (g *Members) Client() (client members.TRISAMembersClient, err error) {
@@ -102,6 +108,10 @@ func (m *Members) Reset() {
 	// interfere with the operation of a current test.
 	m.OnList = nil
 	m.OnSummary = nil
+	m.OnDetails = nil
+	m.OnJobStatus = nil
+	m.OnDomainChallenges = nil
+	m.OnSubmitCSR = nil
 }
 
 // UseFixture allows you to specify a JSON fixture that is loaded from disk as the
@@ -144,6 +154,30 @@ func (m *Members) UseFixture(rpc, path string) (err error) {
 		m.OnDetails = func(context.Context, *members.DetailsRequest) (*members.MemberDetails, error) {
 			return out, nil
 		}
+	case JobStatusRPC:
+		out := &members.JobStatusReply{}
+		if err = jsonpb.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("could not unmarshal json into %T: %s", out, err)
+		}
+		m.OnJobStatus = func(context.Context, *members.JobStatusRequest) (*members.JobStatusReply, error) {
+			return out, nil
+		}
+	case DomainChallengesRPC:
+		out := &members.DomainChallengesReply{}
+		if err = jsonpb.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("could not unmarshal json into %T: %s", out, err)
+		}
+		m.OnDomainChallenges = func(context.Context, *members.DomainChallengesRequest) (*members.DomainChallengesReply, error) {
+			return out, nil
+		}
+	case SubmitCSRRPC:
+		out := &members.SubmitCSRReply{}
+		if err = jsonpb.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("could not unmarshal json into %T: %s", out, err)
+		}
+		m.OnSubmitCSR = func(context.Context, *members.SubmitCSRRequest) (*members.SubmitCSRReply, error) {
+			return out, nil
+		}
 	default:
 		return fmt.Errorf("unknown rpc %q", rpc)
 	}
@@ -165,6 +199,18 @@ func (m *Members) UseError(rpc string, code codes.Code, msg string) error {
 		m.OnDetails = func(context.Context, *members.DetailsRequest) (*members.MemberDetails, error) {
 			return nil, status.Error(code, msg)
 		}
+	case JobStatusRPC:
+		m.OnJobStatus = func(context.Context, *members.JobStatusRequest) (*members.JobStatusReply, error) {
+			return nil, status.Error(code, msg)
+		}
+	case DomainChallengesRPC:
+		m.OnDomainChallenges = func(context.Context, *members.DomainChallengesRequest) (*members.DomainChallengesReply, error) {
+			return nil, status.Error(code, msg)
+		}
+	case SubmitCSRRPC:
+		m.OnSubmitCSR = func(context.Context, *members.SubmitCSRRequest) (*members.SubmitCSRReply, error) {
+			return nil, status.Error(code, msg)
+		}
 	default:
 		return fmt.Errorf("unknown rpc %q", rpc)
 	}
@@ -185,3 +231,18 @@ func (m *Members) Details(ctx context.Context, in *members.DetailsRequest) (*mem
 	m.Calls[DetailsRPC]++
 	return m.OnDetails(ctx, in)
 }
+
+func (m *Members) JobStatus(ctx context.Context, in *members.JobStatusRequest) (*members.JobStatusReply, error) {
+	m.Calls[JobStatusRPC]++
+	return m.OnJobStatus(ctx, in)
+}
+
+func (m *Members) DomainChallenges(ctx context.Context, in *members.DomainChallengesRequest) (*members.DomainChallengesReply, error) {
+	m.Calls[DomainChallengesRPC]++
+	return m.OnDomainChallenges(ctx, in)
+}
+
+func (m *Members) SubmitCSR(ctx context.Context, in *members.SubmitCSRRequest) (*members.SubmitCSRReply, error) {
+	m.Calls[SubmitCSRRPC]++
+	return m.OnSubmitCSR(ctx, in)
+}
@@ -0,0 +1,245 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/ksuid"
+)
+
+const (
+	MaxAPIKeys = 10
+
+	// The last used timestamp of an API key is only updated at this resolution so that
+	// the organization record is not written on every request made with the key.
+	APIKeyLastUsedResolution = time.Minute
+)
+
+const (
+	apiKeySecretLength = 32
+	apiKeySecretPrefix = "sk_"
+	apiKeyOrgIDLength  = 32
+)
+
+// NewAPIKey creates an API key for the organization with the specified permissions,
+// returning the key record and the client secret. Only a hash of the secret is stored
+// on the key, so the secret must be given to the user when the key is created. If the
+// expiration time is zero then the key does not expire.
+func NewAPIKey(orgID, description string, permissions []string, expires time.Time) (key *APIKey, secret string, err error) {
+	var org uuid.UUID
+	if org, err = ParseOrgID(orgID); err != nil {
+		return nil, "", ErrInvalidOrgID
+	}
+
+	// The client ID is prefixed with the organization ID so that the organization of
+	// the key can be retrieved without searching all organizations when authenticating.
+	key = &APIKey{
+		ClientId:    hex.EncodeToString(org[:]) + ksuid.New().String(),
+		Description: strings.TrimSpace(description),
+		Permissions: NormalizeAPIKeyPermissions(permissions),
+	}
+
+	if secret, err = NewAPIKeySecret(); err != nil {
+		return nil, "", err
+	}
+	key.SecretHash = HashAPIKeySecret(secret)
+
+	if !expires.IsZero() {
+		key.Expires = expires.UTC().Format(time.RFC3339)
+	}
+
+	key.Created = time.Now().UTC().Format(time.RFC3339Nano)
+	key.Modified = key.Created
+	return key, secret, nil
+}
+
+// NewAPIKeySecret generates a cryptographically random client secret.
+func NewAPIKeySecret() (_ string, err error) {
+	secret := make([]byte, apiKeySecretLength)
+	if _, err = rand.Read(secret); err != nil {
+		return "", fmt.Errorf("could not generate api key secret: %w", err)
+	}
+	return apiKeySecretPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// HashAPIKeySecret returns the hex encoded SHA-256 hash of the secret. Because the
+// secrets are long random strings a slow password hash is not required.
+func HashAPIKeySecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseAPIKeyClientID returns the ID of the organization that the client ID belongs to.
+func ParseAPIKeyClientID(clientID string) (orgID uuid.UUID, err error) {
+	if len(clientID) <= apiKeyOrgIDLength {
+		return uuid.Nil, ErrInvalidAPIKeyClientID
+	}
+
+	var data []byte
+	if data, err = hex.DecodeString(clientID[:apiKeyOrgIDLength]); err != nil {
+		return uuid.Nil, ErrInvalidAPIKeyClientID
+	}
+
+	if orgID, err = uuid.FromBytes(data); err != nil {
+		return uuid.Nil, ErrInvalidAPIKeyClientID
+	}
+	return orgID, nil
+}
+
+// NormalizeAPIKeyPermissions returns the sorted, unique permissions. Note that the
+// permissions are not checked against the permissions an API key may be granted, the
+// caller must ensure that only allowed permissions are assigned to the key.
+func NormalizeAPIKeyPermissions(permissions []string) []string {
+	seen := make(map[string]struct{}, len(permissions))
+	normalized := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		permission = strings.ToLower(strings.TrimSpace(permission))
+		if _, ok := seen[permission]; ok || permission == "" {
+			continue
+		}
+		seen[permission] = struct{}{}
+		normalized = append(normalized, permission)
+	}
+
+	sort.Strings(normalized)
+	return normalized
+}
+
+// Validate the API key before it is stored.
+func (k *APIKey) Validate() (err error) {
+	if _, err = ParseAPIKeyClientID(k.ClientId); err != nil {
+		return err
+	}
+
+	if k.SecretHash == "" {
+		return ErrMissingAPIKeySecret
+	}
+
+	if len(k.Permissions) == 0 {
+		return ErrNoAPIKeyPermissions
+	}
+
+	if k.Expires != "" {
+		if _, err = time.Parse(time.RFC3339, k.Expires); err != nil {
+			return fmt.Errorf("could not parse api key expiration: %w", err)
+		}
+	}
+	return nil
+}
+
+// Verify that the secret matches the API key and that the key can be used at the
+// specified time. The secret is checked first so that the state of the key is not
+// disclosed to clients that do not have the secret.
+func (k *APIKey) Verify(secret string, now time.Time) error {
+	if subtle.ConstantTimeCompare([]byte(HashAPIKeySecret(secret)), []byte(k.SecretHash)) != 1 {
+		return ErrInvalidAPIKeySecret
+	}
+
+	if k.IsRevoked() {
+		return ErrAPIKeyRevoked
+	}
+
+	if k.IsExpired(now) {
+		return ErrAPIKeyExpired
+	}
+	return nil
+}
+
+// IsRevoked returns true if the API key has been revoked.
+func (k *APIKey) IsRevoked() bool {
+	return k.Revoked != ""
+}
+
+// IsExpired returns true if the API key has an expiration that is not after now.
+func (k *APIKey) IsExpired(now time.Time) bool {
+	if k.Expires == "" {
+		return false
+	}
+
+	expires, err := time.Parse(time.RFC3339, k.Expires)
+	return err != nil || !expires.After(now)
+}
+
+// Revoke the API key so that it can no longer be used to authenticate.
+// Note: The caller is responsible for saving the organization of the key.
+func (k *APIKey) Revoke() {
+	k.Revoked = time.Now().UTC().Format(time.RFC3339)
+	k.Modified = time.Now().UTC().Format(time.RFC3339Nano)
+}
+
+// Used records that the API key was used at the specified time and returns true if
+// the last used timestamp was updated. The timestamp is only updated if the previous
+// use was more than APIKeyLastUsedResolution ago.
+// Note: The caller is responsible for saving the organization of the key.
+func (k *APIKey) Used(now time.Time) bool {
+	if k.LastUsed != "" {
+		if last, err := time.Parse(time.RFC3339, k.LastUsed); err == nil && now.Sub(last) < APIKeyLastUsedResolution {
+			return false
+		}
+	}
+
+	k.LastUsed = now.UTC().Format(time.RFC3339)
+	return true
+}
+
+// Add a new API key to an organization record. The key is validated and must have been
+// created for the organization before it is added. Revoked keys are kept on the record
+// but do not count towards the maximum number of keys.
+// Note: The caller is responsible for saving the updated organization record to the
+// database.
+func (org *Organization) AddAPIKey(key *APIKey) (err error) {
+	if err = key.Validate(); err != nil {
+		return err
+	}
+
+	var orgID uuid.UUID
+	if orgID, err = ParseAPIKeyClientID(key.ClientId); err != nil {
+		return err
+	}
+
+	if orgID.String() != org.Id {
+		return ErrInvalidAPIKeyClientID
+	}
+
+	if _, ok := org.ApiKeys[key.ClientId]; ok {
+		return ErrAPIKeyExists
+	}
+
+	// Limit the number of usable API keys
+	active := 0
+	for _, existing := range org.ApiKeys {
+		if !existing.IsRevoked() {
+			active++
+		}
+	}
+
+	if active >= MaxAPIKeys {
+		return ErrMaxAPIKeys
+	}
+
+	if org.ApiKeys == nil {
+		org.ApiKeys = make(map[string]*APIKey)
+	}
+	org.ApiKeys[key.ClientId] = key
+	return nil
+}
+
+// Retrieve an API key by client ID. Returns nil if the key does not exist on the
+// organization.
+func (org *Organization) GetAPIKey(clientID string) *APIKey {
+	return org.ApiKeys[clientID]
+}
+
+// Delete an API key by client ID. Note that this will not return an error if the key
+// does not exist on the organization.
+func (org *Organization) DeleteAPIKey(clientID string) {
+	delete(org.ApiKeys, clientID)
+}
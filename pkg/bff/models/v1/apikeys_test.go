@@ -0,0 +1,119 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/bff/models/v1"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	orgID := uuid.New()
+	key, secret, err := models.NewAPIKey(orgID.String(), " compliance ", []string{"read:vasp", " Read:Organizations", "read:vasp"}, time.Time{})
+	require.NoError(t, err, "could not create api key")
+	require.True(t, strings.HasPrefix(secret, "sk_"), "expected client secret to be generated")
+	require.NotContains(t, key.SecretHash, secret, "expected only the hash of the secret to be stored")
+	require.Equal(t, models.HashAPIKeySecret(secret), key.SecretHash)
+	require.Equal(t, "compliance", key.Description)
+	require.Equal(t, []string{"read:organizations", "read:vasp"}, key.Permissions, "expected permissions to be normalized")
+	require.Empty(t, key.Expires, "expected key not to expire")
+	require.NotEmpty(t, key.Created)
+	require.NoError(t, key.Validate())
+
+	// The organization can be parsed from the client ID
+	parsed, err := models.ParseAPIKeyClientID(key.ClientId)
+	require.NoError(t, err, "could not parse client id")
+	require.Equal(t, orgID, parsed)
+
+	other, otherSecret, err := models.NewAPIKey(orgID.String(), "", []string{"read:vasp"}, time.Now().Add(time.Hour))
+	require.NoError(t, err, "could not create api key")
+	require.NotEqual(t, key.ClientId, other.ClientId, "expected client ids to be unique")
+	require.NotEqual(t, secret, otherSecret, "expected client secrets to be unique")
+	require.NotEmpty(t, other.Expires)
+
+	_, _, err = models.NewAPIKey("notanorgid", "", []string{"read:vasp"}, time.Time{})
+	require.ErrorIs(t, err, models.ErrInvalidOrgID)
+
+	for _, clientID := range []string{"", "notaclientid", strings.Repeat("z", 40)} {
+		_, err = models.ParseAPIKeyClientID(clientID)
+		require.ErrorIs(t, err, models.ErrInvalidAPIKeyClientID, "expected %q to be invalid", clientID)
+	}
+}
+
+func TestAPIKeyVerify(t *testing.T) {
+	key, secret, err := models.NewAPIKey(uuid.NewString(), "", []string{"read:vasp"}, time.Now().Add(time.Hour))
+	require.NoError(t, err, "could not create api key")
+
+	now := time.Now()
+	require.NoError(t, key.Verify(secret, now))
+	require.ErrorIs(t, key.Verify("sk_wrong", now), models.ErrInvalidAPIKeySecret)
+	require.ErrorIs(t, key.Verify(secret, now.Add(2*time.Hour)), models.ErrAPIKeyExpired)
+
+	key.Revoke()
+	require.True(t, key.IsRevoked())
+	require.ErrorIs(t, key.Verify(secret, now), models.ErrAPIKeyRevoked)
+
+	// The state of the key is not disclosed without the secret
+	require.ErrorIs(t, key.Verify("sk_wrong", now), models.ErrInvalidAPIKeySecret)
+
+	// The last used timestamp is only updated at the configured resolution
+	require.True(t, key.Used(now), "expected first use to be recorded")
+	require.False(t, key.Used(now.Add(time.Second)), "expected use within the resolution to be ignored")
+	require.True(t, key.Used(now.Add(models.APIKeyLastUsedResolution)), "expected use after the resolution to be recorded")
+}
+
+func TestAPIKeyValidate(t *testing.T) {
+	key, _, err := models.NewAPIKey(uuid.NewString(), "", []string{"read:vasp"}, time.Time{})
+	require.NoError(t, err, "could not create api key")
+
+	key.Expires = "tomorrow"
+	require.Error(t, key.Validate(), "expected invalid expiration to be rejected")
+
+	key.Expires = ""
+	key.Permissions = nil
+	require.ErrorIs(t, key.Validate(), models.ErrNoAPIKeyPermissions)
+
+	key.SecretHash = ""
+	require.ErrorIs(t, key.Validate(), models.ErrMissingAPIKeySecret)
+
+	key.ClientId = ""
+	require.ErrorIs(t, key.Validate(), models.ErrInvalidAPIKeyClientID)
+}
+
+func TestOrganizationAPIKeys(t *testing.T) {
+	org := &models.Organization{Id: uuid.NewString()}
+
+	// Keys created for another organization cannot be added
+	other, _, err := models.NewAPIKey(uuid.NewString(), "", []string{"read:vasp"}, time.Time{})
+	require.NoError(t, err, "could not create api key")
+	require.ErrorIs(t, org.AddAPIKey(other), models.ErrInvalidAPIKeyClientID)
+
+	key, _, err := models.NewAPIKey(org.Id, "", []string{"read:vasp"}, time.Time{})
+	require.NoError(t, err, "could not create api key")
+	require.NoError(t, org.AddAPIKey(key))
+	require.ErrorIs(t, org.AddAPIKey(key), models.ErrAPIKeyExists)
+	require.Equal(t, key, org.GetAPIKey(key.ClientId))
+	require.Nil(t, org.GetAPIKey("missing"))
+
+	// Limit the number of active keys
+	for len(org.ApiKeys) < models.MaxAPIKeys {
+		next, _, err := models.NewAPIKey(org.Id, "", []string{"read:vasp"}, time.Time{})
+		require.NoError(t, err, "could not create api key")
+		require.NoError(t, org.AddAPIKey(next))
+	}
+
+	next, _, err := models.NewAPIKey(org.Id, "", []string{"read:vasp"}, time.Time{})
+	require.NoError(t, err, "could not create api key")
+	require.ErrorIs(t, org.AddAPIKey(next), models.ErrMaxAPIKeys)
+
+	// Revoked keys do not count towards the limit
+	key.Revoke()
+	require.NoError(t, org.AddAPIKey(next))
+
+	org.DeleteAPIKey(key.ClientId)
+	require.Nil(t, org.GetAPIKey(key.ClientId))
+	require.Len(t, org.ApiKeys, models.MaxAPIKeys)
+}
@@ -42,6 +42,24 @@ var (
 	ErrInvalidPort             = errors.New("port must be a number between 1 and 65535")
 	ErrInvalidCommonName       = errors.New("common name cannot contain wildcard characters (*) and must not have a scheme or port")
 	ErrCommonNameMismatch      = errors.New("common name must match the endpoint host")
+	ErrMissingWebhookID        = errors.New("webhook record is missing an id")
+	ErrMissingWebhookSecret    = errors.New("webhook record is missing a signing secret")
+	ErrInvalidWebhookURL       = errors.New("webhook url must be an absolute url without credentials")
+	ErrInsecureWebhookURL      = errors.New("webhook url must use https")
+	ErrNonPublicWebhookURL     = errors.New("webhook url must resolve to a public internet address")
+	ErrUnresolvableWebhookURL  = errors.New("webhook url host could not be resolved")
+	ErrUnknownWebhookEvent     = errors.New("unknown webhook event")
+	ErrMaxWebhooks             = errors.New("maximum number of webhooks reached")
+	ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+	ErrWebhookSignatureExpired = errors.New("webhook signature timestamp is outside the allowed skew")
+	ErrInvalidAPIKeyClientID   = errors.New("api key client id is invalid")
+	ErrMissingAPIKeySecret     = errors.New("api key record is missing a secret hash")
+	ErrNoAPIKeyPermissions     = errors.New("api key requires at least one permission")
+	ErrMaxAPIKeys              = errors.New("maximum number of api keys reached")
+	ErrAPIKeyExists            = errors.New("api key already exists in organization")
+	ErrAPIKeyExpired           = errors.New("api key has expired")
+	ErrAPIKeyRevoked           = errors.New("api key has been revoked")
+	ErrInvalidAPIKeySecret     = errors.New("api key secret does not match")
 )
 
 type ValidationError struct {
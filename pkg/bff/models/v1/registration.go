@@ -74,10 +74,12 @@ const (
 	FieldTestNetCommonName = "testnet.common_name"
 	FieldTestNetEndpoint   = "testnet.endpoint"
 	FieldTestNetDNSNames   = "testnet.dns_names"
+	FieldTestNetCSR        = "testnet.csr"
 	FieldMainNet           = "mainnet"
 	FieldMainNetCommonName = "mainnet.common_name"
 	FieldMainNetEndpoint   = "mainnet.endpoint"
 	FieldMainNetDNSNames   = "mainnet.dns_names"
+	FieldMainNetCSR        = "mainnet.csr"
 
 	// Default fields
 	FieldState = "state"
@@ -549,7 +551,7 @@ func NetworkDetailsIsZero(details *NetworkDetails) bool {
 		return true
 	}
 
-	return details.Endpoint == "" && details.CommonName == "" && details.DnsNames == nil
+	return details.Endpoint == "" && details.CommonName == "" && details.DnsNames == nil && details.Csr == ""
 }
 
 // Validate a network details field.
@@ -608,6 +610,14 @@ func validateNetwork(details *NetworkDetails, fieldName string) error {
 		}
 	}
 
+	// Validate the CSR against the common name and DNS names if supplied
+	details.Csr = strings.TrimSpace(details.Csr)
+	if details.Csr != "" {
+		if err := models.ValidateCSR([]byte(details.Csr), details.CommonName, details.DnsNames); err != nil {
+			verr = append(verr, &ValidationError{Field: fieldName + ".csr", Err: err.Error()})
+		}
+	}
+
 	if len(verr) == 0 {
 		return nil
 	}
@@ -1,13 +1,20 @@
 package models_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
 	"os"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	. "github.com/trisacrypto/directory/pkg/bff/models/v1"
+	"github.com/trisacrypto/directory/pkg/models/v1"
 	ivms101 "github.com/trisacrypto/trisa/pkg/ivms101"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 	"google.golang.org/protobuf/proto"
@@ -533,6 +540,16 @@ func TestValidateTRISA(t *testing.T) {
 		CommonName: "main.travelrule.io",
 	}
 
+	// Create a certificate signing request for the testnet common name
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err, "could not generate private key")
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "test.travelrule.io"},
+		DNSNames: []string{"test.travelrule.io", "alt.travelrule.io"},
+	}, key)
+	require.NoError(t, err, "could not create certificate signing request")
+	csr := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+
 	testCases := []struct {
 		testnet *NetworkDetails
 		mainnet *NetworkDetails
@@ -586,6 +603,13 @@ func TestValidateTRISA(t *testing.T) {
 		{validNetwork, validNetwork, ValidationErrors{
 			{Field: FieldMainNetEndpoint, Err: ErrDuplicateEndpoint.Error()},
 		}},
+		{&NetworkDetails{Endpoint: "test.travelrule.io:443", CommonName: "test.travelrule.io", Csr: "foo"}, nil, ValidationErrors{
+			{Field: FieldTestNetCSR, Err: models.ErrInvalidCSR.Error()},
+		}},
+		{&NetworkDetails{Endpoint: "test.travelrule.io:443", CommonName: "test.travelrule.io", Csr: csr}, nil, ValidationErrors{
+			{Field: FieldTestNetCSR, Err: `certificate signing request contains dns names that are not registered: "alt.travelrule.io"`},
+		}},
+		{&NetworkDetails{Endpoint: "test.travelrule.io:443", CommonName: "test.travelrule.io", DnsNames: []string{"alt.travelrule.io"}, Csr: csr}, nil, nil},
 		{&NetworkDetails{Endpoint: "test.travelrule.io:443", CommonName: "test.travelrule.io"}, nil, nil},
 		{nil, validNetwork, nil},
 		{&NetworkDetails{Endpoint: "test.travelrule.io:443", CommonName: "test.travelrule.io"}, validNetwork, nil},
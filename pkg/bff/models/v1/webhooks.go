@@ -0,0 +1,333 @@
+package models
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"github.com/trisacrypto/directory/pkg/utils"
+	"github.com/trisacrypto/directory/pkg/utils/netguard"
+)
+
+const (
+	MaxWebhooks          = 10
+	MaxWebhookDeliveries = 100
+)
+
+// Headers that are set on every webhook delivery so that receivers can route and
+// verify the events sent by the BFF.
+const (
+	WebhookSignatureHeader = "X-TRISA-Signature"
+	WebhookEventHeader     = "X-TRISA-Event"
+	WebhookDeliveryHeader  = "X-TRISA-Delivery"
+)
+
+// Events that organizations can subscribe to with a webhook.
+const (
+	WebhookRegistrationStatus  = "registration.status_changed"
+	WebhookContactVerified     = "contact.verified"
+	WebhookCertificateIssued   = "certificate.issued"
+	WebhookCertificateExpiring = "certificate.expiring"
+	WebhookReissuanceStarted   = "certificate.reissuance_started"
+	WebhookAnnouncementCreated = "announcement.created"
+)
+
+// DefaultWebhookSkew is the recommended tolerance for the timestamp of a signature.
+const DefaultWebhookSkew = 5 * time.Minute
+
+const (
+	webhookSignatureVersion   = "v1"
+	webhookSignatureTimestamp = "t"
+	webhookSecretLength       = 32
+	webhookSecretPrefix       = "whsec_"
+)
+
+// WebhookEvents is the list of all events that can be subscribed to.
+var WebhookEvents = []string{
+	WebhookRegistrationStatus,
+	WebhookContactVerified,
+	WebhookCertificateIssued,
+	WebhookCertificateExpiring,
+	WebhookReissuanceStarted,
+	WebhookAnnouncementCreated,
+}
+
+// NewWebhook creates an active webhook that delivers the specified events to the URL
+// with a newly generated signing secret. If no events are specified then the webhook
+// is subscribed to all events. The webhook should be validated before it is stored.
+func NewWebhook(endpoint string, events []string) (hook *Webhook, err error) {
+	hook = &Webhook{
+		Id:     ksuid.New().String(),
+		Url:    endpoint,
+		Active: true,
+	}
+
+	if hook.Events, err = NormalizeWebhookEvents(events); err != nil {
+		return nil, err
+	}
+
+	if hook.Secret, err = NewWebhookSecret(); err != nil {
+		return nil, err
+	}
+
+	hook.Created = time.Now().UTC().Format(time.RFC3339Nano)
+	hook.Modified = hook.Created
+	return hook, nil
+}
+
+// NewWebhookSecret generates a cryptographically random secret to sign payloads with.
+func NewWebhookSecret() (_ string, err error) {
+	secret := make([]byte, webhookSecretLength)
+	if _, err = rand.Read(secret); err != nil {
+		return "", fmt.Errorf("could not generate webhook secret: %w", err)
+	}
+	return webhookSecretPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// NormalizeWebhookEvents returns the sorted, unique events or an error if any of the
+// events cannot be subscribed to.
+func NormalizeWebhookEvents(events []string) (_ []string, err error) {
+	seen := make(map[string]struct{}, len(events))
+	normalized := make([]string, 0, len(events))
+
+eventsLoop:
+	for _, event := range events {
+		event = strings.ToLower(strings.TrimSpace(event))
+		if _, ok := seen[event]; ok {
+			continue
+		}
+
+		for _, known := range WebhookEvents {
+			if event == known {
+				seen[event] = struct{}{}
+				normalized = append(normalized, event)
+				continue eventsLoop
+			}
+		}
+		return nil, fmt.Errorf("%w %q", ErrUnknownWebhookEvent, event)
+	}
+
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// Validate the webhook before it is stored, ensuring that the URL can receive
+// deliveries. Plain HTTP URLs and URLs that resolve to internal addresses, e.g. the
+// loopback interface, private networks, or the cloud metadata endpoint, are only
+// allowed if insecure is true, e.g. in testing.
+func (w *Webhook) Validate(insecure bool) (err error) {
+	if w.Id == "" {
+		return ErrMissingWebhookID
+	}
+
+	var u *url.URL
+	if u, err = url.Parse(w.Url); err != nil || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if !insecure {
+			return ErrInsecureWebhookURL
+		}
+	default:
+		return ErrInvalidWebhookURL
+	}
+
+	if u.User != nil {
+		return ErrInvalidWebhookURL
+	}
+
+	if _, err = NormalizeWebhookEvents(w.Events); err != nil {
+		return err
+	}
+
+	if w.Secret == "" {
+		return ErrMissingWebhookSecret
+	}
+
+	// The host is resolved last since it requires a DNS lookup; the addresses are
+	// checked again when webhooks are delivered in case the host records change.
+	if !insecure {
+		ctx, cancel := utils.WithDeadline(context.Background())
+		defer cancel()
+
+		if err = netguard.CheckHost(ctx, u.Hostname()); err != nil {
+			if errors.Is(err, netguard.ErrNonPublicAddress) {
+				return ErrNonPublicWebhookURL
+			}
+			return ErrUnresolvableWebhookURL
+		}
+	}
+	return nil
+}
+
+// Subscribed returns true if the webhook should receive the event. A webhook without
+// any events is subscribed to all events.
+func (w *Webhook) Subscribed(event string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Enqueue adds a pending delivery of the event payload to the webhook. The delivery is
+// sent the next time the webhook dispatcher runs.
+// Note: The caller is responsible for saving the organization of the webhook.
+func (w *Webhook) Enqueue(event string, payload []byte) *WebhookDelivery {
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	delivery := &WebhookDelivery{
+		Id:       ksuid.New().String(),
+		Event:    event,
+		Payload:  payload,
+		Status:   WebhookDeliveryState_DELIVERY_PENDING,
+		Created:  now,
+		Modified: now,
+	}
+
+	w.Deliveries = append(w.Deliveries, delivery)
+	return delivery
+}
+
+// TrimDeliveries removes the oldest completed deliveries from the delivery log so that
+// at most max deliveries are kept. Deliveries that may still be attempted are never
+// removed from the log.
+func (w *Webhook) TrimDeliveries(max int) {
+	remove := len(w.Deliveries) - max
+	if remove <= 0 {
+		return
+	}
+
+	deliveries := make([]*WebhookDelivery, 0, max)
+	for _, delivery := range w.Deliveries {
+		if remove > 0 && delivery.Completed() {
+			remove--
+			continue
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	w.Deliveries = deliveries
+}
+
+// Sign the payload with the webhook secret at the specified time, returning the value
+// of the signature header, e.g. t=1700000000,v1=5257a869e7... The signature is an
+// HMAC-SHA256 of the timestamp and the payload joined by a period.
+func (w *Webhook) Sign(payload []byte, ts time.Time) string {
+	timestamp := strconv.FormatInt(ts.Unix(), 10)
+	return fmt.Sprintf("%s=%s,%s=%s", webhookSignatureTimestamp, timestamp, webhookSignatureVersion, webhookSignature(w.Secret, timestamp, payload))
+}
+
+// VerifyWebhookSignature is used by webhook receivers to verify that the payload was
+// signed with the secret of the webhook within the allowed clock skew, preventing
+// forged or replayed deliveries.
+func VerifyWebhookSignature(secret, header string, payload []byte, skew time.Duration) (err error) {
+	var timestamp string
+	signatures := make([]string, 0, 1)
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case webhookSignatureTimestamp:
+			timestamp = value
+		case webhookSignatureVersion:
+			signatures = append(signatures, value)
+		}
+	}
+
+	if timestamp == "" || len(signatures) == 0 {
+		return ErrInvalidWebhookSignature
+	}
+
+	var ts int64
+	if ts, err = strconv.ParseInt(timestamp, 10, 64); err != nil {
+		return ErrInvalidWebhookSignature
+	}
+
+	if delta := time.Since(time.Unix(ts, 0)); delta > skew || delta < -skew {
+		return ErrWebhookSignatureExpired
+	}
+
+	expected := webhookSignature(secret, timestamp, payload)
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+	return ErrInvalidWebhookSignature
+}
+
+func webhookSignature(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Due returns true if the delivery should be attempted at the specified time.
+func (d *WebhookDelivery) Due(now time.Time) bool {
+	switch d.Status {
+	case WebhookDeliveryState_DELIVERY_PENDING:
+		return true
+	case WebhookDeliveryState_DELIVERY_RETRYING:
+		next, err := time.Parse(time.RFC3339, d.NextAttempt)
+		return err != nil || !next.After(now)
+	default:
+		return false
+	}
+}
+
+// Completed returns true if the delivery succeeded or will no longer be attempted.
+func (d *WebhookDelivery) Completed() bool {
+	return d.Status == WebhookDeliveryState_DELIVERY_SUCCEEDED || d.Status == WebhookDeliveryState_DELIVERY_FAILED
+}
+
+// Add a new webhook to an organization record. The given webhook record is validated
+// before being added to the organization.
+// Note: The caller is responsible for saving the updated organization record to the
+// database.
+func (org *Organization) AddWebhook(hook *Webhook, insecure bool) (err error) {
+	if err = hook.Validate(insecure); err != nil {
+		return err
+	}
+
+	// Limit the number of webhooks
+	if len(org.Webhooks) >= MaxWebhooks {
+		return ErrMaxWebhooks
+	}
+
+	if org.Webhooks == nil {
+		org.Webhooks = make(map[string]*Webhook)
+	}
+	org.Webhooks[hook.Id] = hook
+	return nil
+}
+
+// Retrieve a webhook by ID. Returns nil if the webhook does not exist on the
+// organization.
+func (org *Organization) GetWebhook(id string) *Webhook {
+	return org.Webhooks[id]
+}
+
+// Delete a webhook by ID. Note that this will not return an error if the webhook does
+// not exist on the organization.
+func (org *Organization) DeleteWebhook(id string) {
+	delete(org.Webhooks, id)
+}
@@ -103,6 +103,11 @@ func New(conf config.Config) (s *Server, err error) {
 					return nil, fmt.Errorf("could not create activity subscriber: %w", err)
 				}
 			}
+
+			// Initialize the webhook dispatcher if enabled
+			if s.conf.Webhooks.Enabled {
+				s.webhooks = NewWebhookDispatcher(s.conf.Webhooks, s.db, s.testnetDB, s.mainnetDB)
+			}
 		}
 
 		if s.email, err = emails.New(conf.Email); err != nil {
@@ -188,6 +193,7 @@ type Server struct {
 	email      *emails.EmailManager
 	users      cache.Cache
 	activity   *ActivitySubscriber
+	webhooks   *WebhookDispatcher
 	started    time.Time
 	healthy    bool
 	url        string
@@ -218,6 +224,13 @@ func (s *Server) Serve() (err error) {
 		}
 	}
 
+	// Start the webhook dispatcher
+	if s.webhooks != nil {
+		if err = s.webhooks.Run(&sync.WaitGroup{}); err != nil {
+			return fmt.Errorf("could not start webhook dispatcher: %w", err)
+		}
+	}
+
 	// Create a socket to listen on so that we can infer the final URL (e.g. if the
 	// BindAddr is 127.0.0.1:0 for testing, a random port will be assigned, manually
 	// creating the listener will allow us to determine which port).
@@ -263,6 +276,11 @@ func (s *Server) Shutdown() (err error) {
 		s.activity.Stop()
 	}
 
+	// Stop the webhook dispatcher
+	if s.webhooks != nil {
+		s.webhooks.Stop()
+	}
+
 	// Require shutdown in 30 seconds without blocking
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -310,8 +328,9 @@ func (s *Server) setupRoutes() (err error) {
 		bffTags       map[string]string
 	)
 
-	// Instantiate authentication middleware
-	if authenticator, err = auth.Authenticate(s.conf.Auth0); err != nil {
+	// Instantiate authentication middleware, organization API keys are accepted as an
+	// alternative to Auth0 tokens for machine-to-machine access.
+	if authenticator, err = auth.Authenticate(s.conf.Auth0, auth.WithAPIKeys(s)); err != nil {
 		return err
 	}
 
@@ -412,6 +431,25 @@ func (s *Server) setupRoutes() (err error) {
 			collaborators.DELETE("/:collabID", auth.DoubleCookie(), auth.Authorize(auth.UpdateCollaborators), s.DeleteCollaborator)
 		}
 
+		// Webhooks allow organizations to receive push notifications about changes to
+		// their registrations and certificates and about network announcements.
+		webhooks := v1.Group("/webhooks", s.WebhooksEnabled)
+		{
+			webhooks.GET("", auth.Authorize(auth.ReadOrganizations), s.ListWebhooks)
+			webhooks.POST("", auth.DoubleCookie(), auth.Authorize(auth.UpdateOrganizations), userinfo, s.CreateWebhook)
+			webhooks.PATCH("/:webhookID", auth.DoubleCookie(), auth.Authorize(auth.UpdateOrganizations), s.UpdateWebhook)
+			webhooks.DELETE("/:webhookID", auth.DoubleCookie(), auth.Authorize(auth.UpdateOrganizations), s.DeleteWebhook)
+			webhooks.GET("/:webhookID/deliveries", auth.Authorize(auth.ReadOrganizations), s.WebhookDeliveries)
+		}
+
+		// API keys allow machine clients to access the BFF on behalf of an organization.
+		apikeys := v1.Group("/apikeys")
+		{
+			apikeys.GET("", auth.Authorize(auth.ReadOrganizations), s.ListAPIKeys)
+			apikeys.POST("", auth.DoubleCookie(), auth.Authorize(auth.UpdateOrganizations), userinfo, s.CreateAPIKey)
+			apikeys.DELETE("/:clientID", auth.DoubleCookie(), auth.Authorize(auth.UpdateOrganizations), s.RevokeAPIKey)
+		}
+
 		// The register endpoint sends the VASP registration form to the GDS server to
 		// register the VASP as a GDS TestNet or MainNet member.
 		register := v1.Group("/register")
@@ -420,6 +458,7 @@ func (s *Server) setupRoutes() (err error) {
 			register.PUT("", auth.DoubleCookie(), auth.Authorize(auth.UpdateVASP), s.SaveRegisterForm)
 			register.DELETE("", auth.DoubleCookie(), auth.Authorize(auth.UpdateVASP), s.ResetRegisterForm)
 			register.POST("/:network", auth.DoubleCookie(), auth.Authorize(auth.UpdateVASP), userinfo, s.SubmitRegistration)
+			register.GET("/:network/jobs/:jobID", auth.Authorize(auth.ReadVASP), s.RegistrationJobStatus)
 		}
 
 		// Certificates is a resource to allow VASP members to perform certificate
@@ -482,6 +521,12 @@ func (s *Server) SetGDSClients(testnet, mainnet *GDSClient) {
 	s.mainnetGDS = mainnet
 }
 
+// SetWebhookDispatcher allows tests to set a webhook dispatcher that uses the mock
+// databases so that announcements are sent to webhooks.
+func (s *Server) SetWebhookDispatcher(webhooks *WebhookDispatcher) {
+	s.webhooks = webhooks
+}
+
 // SetDB allows tests to set a bufconn client to a mock trtl server.
 func (s *Server) SetDB(db store.Store) {
 	s.db = db
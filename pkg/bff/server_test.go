@@ -112,6 +112,16 @@ func (s *bffTestSuite) SetupSuite() {
 			Expiration: 1 * time.Minute,
 			Size:       100,
 		},
+		Webhooks: config.WebhooksConfig{
+			Enabled:        true,
+			Interval:       1 * time.Minute,
+			Timeout:        1 * time.Second,
+			MaxAttempts:    3,
+			Backoff:        1 * time.Minute,
+			MaxBackoff:     1 * time.Hour,
+			ExpiryWarnings: []int{30, 7},
+			Insecure:       true,
+		},
 	}.Mark()
 	require.NoError(err, "could not mark configuration")
 
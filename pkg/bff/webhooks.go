@@ -0,0 +1,345 @@
+package bff
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/gin-gonic/gin"
+	"github.com/trisacrypto/directory/pkg/bff/api/v1"
+	"github.com/trisacrypto/directory/pkg/bff/auth"
+	"github.com/trisacrypto/directory/pkg/bff/models/v1"
+	"github.com/trisacrypto/directory/pkg/utils"
+	"github.com/trisacrypto/directory/pkg/utils/sentry"
+)
+
+// WebhooksEnabled is a middleware that returns a 503 error if the webhook dispatcher is
+// not enabled so that organizations do not subscribe to events that are never sent.
+func (s *Server) WebhooksEnabled(c *gin.Context) {
+	if !s.conf.Webhooks.Enabled {
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, api.ErrorResponse("webhooks are not enabled"))
+		return
+	}
+	c.Next()
+}
+
+// ListWebhooks lists the webhook subscriptions of the user's organization.
+//
+// @Summary List webhooks [read:organizations]
+// @Description Returns the webhooks of the user's organization sorted by creation date.
+// @Tags webhooks
+// @Produce json
+// @Success 200 {object} api.ListWebhooksReply
+// @Failure 401 {object} api.Reply
+// @Failure 500 {object} api.Reply
+// @Failure 503 {object} api.Reply "Webhooks are not enabled"
+// @Router /webhooks [get]
+func (s *Server) ListWebhooks(c *gin.Context) {
+	var (
+		err error
+		org *models.Organization
+	)
+
+	// Fetch the organization from the claims
+	// NOTE: This method handles the error logging and response
+	if org, err = s.OrganizationFromClaims(c); err != nil {
+		return
+	}
+
+	out := &api.ListWebhooksReply{
+		Webhooks: make([]*api.Webhook, 0, len(org.Webhooks)),
+	}
+
+	for _, hook := range org.Webhooks {
+		out.Webhooks = append(out.Webhooks, webhookReply(hook, false))
+	}
+
+	// Enforce consistent ordering by creation timestamp
+	sort.Slice(out.Webhooks, func(i, j int) bool {
+		return out.Webhooks[i].Created < out.Webhooks[j].Created
+	})
+
+	c.JSON(http.StatusOK, out)
+}
+
+// CreateWebhook subscribes a new webhook endpoint to events about the user's
+// organization. The signing secret of the webhook is only returned in this response.
+//
+// @Summary Create webhook [update:organizations]
+// @Description Subscribe a webhook endpoint to organization events.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param params body api.WebhookParams true "Webhook to create"
+// @Success 200 {object} api.Webhook
+// @Failure 400 {object} api.Reply "Invalid webhook url or events"
+// @Failure 401 {object} api.Reply
+// @Failure 403 {object} api.Reply "Maximum number of webhooks reached"
+// @Failure 500 {object} api.Reply
+// @Failure 503 {object} api.Reply "Webhooks are not enabled"
+// @Router /webhooks [post]
+func (s *Server) CreateWebhook(c *gin.Context) {
+	var (
+		err    error
+		params *api.WebhookParams
+		hook   *models.Webhook
+		org    *models.Organization
+		user   *management.User
+	)
+
+	// Fetch the organization from the claims
+	// NOTE: This method handles the error logging and response
+	if org, err = s.OrganizationFromClaims(c); err != nil {
+		return
+	}
+
+	params = &api.WebhookParams{}
+	if err = c.ShouldBind(params); err != nil {
+		sentry.Warn(c).Err(err).Msg("could not bind request")
+		c.JSON(http.StatusBadRequest, api.ErrorResponse(err))
+		return
+	}
+
+	if hook, err = models.NewWebhook(params.URL, params.Events); err != nil {
+		if errors.Is(err, models.ErrUnknownWebhookEvent) {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse(err))
+			return
+		}
+
+		sentry.Error(c).Err(err).Msg("could not create webhook")
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse("could not create webhook"))
+		return
+	}
+
+	if params.Active != nil {
+		hook.Active = *params.Active
+	}
+
+	// Record the user who created the webhook if available
+	if user, err = auth.GetUserInfo(c); err == nil {
+		hook.CreatedBy = user.GetEmail()
+	}
+
+	if err = org.AddWebhook(hook, s.conf.Webhooks.Insecure); err != nil {
+		switch {
+		case errors.Is(err, models.ErrMaxWebhooks):
+			sentry.Warn(c).Err(err).Int("maximum", models.MaxWebhooks).Msg("maximum number of webhooks reached")
+			c.JSON(http.StatusForbidden, api.ErrorResponse(err))
+		default:
+			c.JSON(http.StatusBadRequest, api.ErrorResponse(err))
+		}
+		return
+	}
+
+	ctx, cancel := utils.WithDeadline(context.Background())
+	defer cancel()
+
+	if err = s.db.UpdateOrganization(ctx, org); err != nil {
+		sentry.Error(c).Err(err).Msg("could not save organization with new webhook")
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse("could not create webhook"))
+		return
+	}
+
+	c.JSON(http.StatusOK, webhookReply(hook, true))
+}
+
+// UpdateWebhook updates the URL, events, or active state of a webhook in the user's
+// organization. Fields that are not specified in the request are not changed.
+//
+// @Summary Update webhook [update:organizations]
+// @Description Update the URL, events, or active state of a webhook.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param params body api.WebhookParams true "Webhook fields to update"
+// @Success 200 {object} api.Webhook
+// @Failure 400 {object} api.Reply "Invalid webhook url or events"
+// @Failure 401 {object} api.Reply
+// @Failure 404 {object} api.Reply "Webhook not found"
+// @Failure 500 {object} api.Reply
+// @Failure 503 {object} api.Reply "Webhooks are not enabled"
+// @Router /webhooks/{id} [patch]
+func (s *Server) UpdateWebhook(c *gin.Context) {
+	var (
+		err    error
+		params *api.WebhookParams
+		org    *models.Organization
+	)
+
+	// Fetch the organization from the claims
+	// NOTE: This method handles the error logging and response
+	if org, err = s.OrganizationFromClaims(c); err != nil {
+		return
+	}
+
+	hook := org.GetWebhook(c.Param("webhookID"))
+	if hook == nil {
+		c.JSON(http.StatusNotFound, api.ErrorResponse("webhook not found"))
+		return
+	}
+
+	params = &api.WebhookParams{}
+	if err = c.ShouldBind(params); err != nil {
+		sentry.Warn(c).Err(err).Msg("could not bind request")
+		c.JSON(http.StatusBadRequest, api.ErrorResponse(err))
+		return
+	}
+
+	if params.URL != "" {
+		hook.Url = params.URL
+	}
+
+	if params.Events != nil {
+		if hook.Events, err = models.NormalizeWebhookEvents(params.Events); err != nil {
+			c.JSON(http.StatusBadRequest, api.ErrorResponse(err))
+			return
+		}
+	}
+
+	if params.Active != nil {
+		hook.Active = *params.Active
+	}
+
+	if err = hook.Validate(s.conf.Webhooks.Insecure); err != nil {
+		c.JSON(http.StatusBadRequest, api.ErrorResponse(err))
+		return
+	}
+	hook.Modified = time.Now().UTC().Format(time.RFC3339Nano)
+
+	ctx, cancel := utils.WithDeadline(context.Background())
+	defer cancel()
+
+	if err = s.db.UpdateOrganization(ctx, org); err != nil {
+		sentry.Error(c).Err(err).Msg("could not save organization with updated webhook")
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse("could not update webhook"))
+		return
+	}
+
+	c.JSON(http.StatusOK, webhookReply(hook, false))
+}
+
+// DeleteWebhook removes a webhook from the user's organization, pending deliveries to
+// the webhook are discarded.
+//
+// @Summary Delete webhook [update:organizations]
+// @Description Delete a webhook from the user's organization.
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} api.Reply
+// @Failure 401 {object} api.Reply
+// @Failure 404 {object} api.Reply "Webhook not found"
+// @Failure 500 {object} api.Reply
+// @Failure 503 {object} api.Reply "Webhooks are not enabled"
+// @Router /webhooks/{id} [delete]
+func (s *Server) DeleteWebhook(c *gin.Context) {
+	var (
+		err error
+		org *models.Organization
+	)
+
+	// Fetch the organization from the claims
+	// NOTE: This method handles the error logging and response
+	if org, err = s.OrganizationFromClaims(c); err != nil {
+		return
+	}
+
+	id := c.Param("webhookID")
+	if org.GetWebhook(id) == nil {
+		c.JSON(http.StatusNotFound, api.ErrorResponse("webhook not found"))
+		return
+	}
+	org.DeleteWebhook(id)
+
+	ctx, cancel := utils.WithDeadline(context.Background())
+	defer cancel()
+
+	if err = s.db.UpdateOrganization(ctx, org); err != nil {
+		sentry.Error(c).Err(err).Msg("could not save organization with deleted webhook")
+		c.JSON(http.StatusInternalServerError, api.ErrorResponse("could not delete webhook"))
+		return
+	}
+
+	c.JSON(http.StatusOK, api.Reply{Success: true})
+}
+
+// WebhookDeliveries returns the delivery log of a webhook in the user's organization.
+//
+// @Summary List webhook deliveries [read:organizations]
+// @Description Returns the most recent deliveries to the webhook, newest first.
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} api.WebhookDeliveriesReply
+// @Failure 401 {object} api.Reply
+// @Failure 404 {object} api.Reply "Webhook not found"
+// @Failure 500 {object} api.Reply
+// @Failure 503 {object} api.Reply "Webhooks are not enabled"
+// @Router /webhooks/{id}/deliveries [get]
+func (s *Server) WebhookDeliveries(c *gin.Context) {
+	var (
+		err error
+		org *models.Organization
+	)
+
+	// Fetch the organization from the claims
+	// NOTE: This method handles the error logging and response
+	if org, err = s.OrganizationFromClaims(c); err != nil {
+		return
+	}
+
+	hook := org.GetWebhook(c.Param("webhookID"))
+	if hook == nil {
+		c.JSON(http.StatusNotFound, api.ErrorResponse("webhook not found"))
+		return
+	}
+
+	out := &api.WebhookDeliveriesReply{
+		Deliveries: make([]*api.WebhookDelivery, 0, len(hook.Deliveries)),
+	}
+
+	// Deliveries are stored in the order they were created
+	for i := len(hook.Deliveries) - 1; i >= 0; i-- {
+		delivery := hook.Deliveries[i]
+		out.Deliveries = append(out.Deliveries, &api.WebhookDelivery{
+			ID:          delivery.Id,
+			Event:       delivery.Event,
+			Status:      delivery.Status.String(),
+			Attempts:    delivery.Attempts,
+			StatusCode:  delivery.StatusCode,
+			LastError:   delivery.LastError,
+			NextAttempt: delivery.NextAttempt,
+			Delivered:   delivery.Delivered,
+			Created:     delivery.Created,
+		})
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+// Returns the API representation of the webhook, the secret is only included if
+// specified so that it is not disclosed after the webhook is created.
+func webhookReply(hook *models.Webhook, secret bool) *api.Webhook {
+	out := &api.Webhook{
+		ID:        hook.Id,
+		URL:       hook.Url,
+		Events:    hook.Events,
+		Active:    hook.Active,
+		CreatedBy: hook.CreatedBy,
+		Created:   hook.Created,
+		Modified:  hook.Modified,
+	}
+
+	if out.Events == nil {
+		out.Events = make([]string, 0)
+	}
+
+	if secret {
+		out.Secret = hook.Secret
+	}
+	return out
+}
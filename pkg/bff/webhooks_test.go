@@ -0,0 +1,453 @@
+package bff_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/trisacrypto/directory/pkg/bff"
+	"github.com/trisacrypto/directory/pkg/bff/api/v1"
+	"github.com/trisacrypto/directory/pkg/bff/auth"
+	"github.com/trisacrypto/directory/pkg/bff/auth/authtest"
+	records "github.com/trisacrypto/directory/pkg/bff/models/v1"
+	models "github.com/trisacrypto/directory/pkg/models/v1"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+func (s *bffTestSuite) TestWebhooks() {
+	require := s.Require()
+	defer s.ResetDB()
+
+	// Create initial claims fixture
+	claims := &authtest.Claims{
+		Email:       "leopold.wentzel@gmail.com",
+		Permissions: []string{"read:nothing"},
+	}
+
+	params := &api.WebhookParams{
+		URL:    "https://example.com/hooks",
+		Events: []string{records.WebhookCertificateIssued},
+	}
+
+	// Endpoint requires CSRF protection
+	_, err := s.client.CreateWebhook(context.TODO(), params)
+	s.requireError(err, http.StatusForbidden, "csrf verification failed for request", "expected error when request is not CSRF protected")
+	require.NoError(s.SetClientCSRFProtection(), "could not set csrf protection on client")
+
+	// Endpoint must be authenticated
+	_, err = s.client.CreateWebhook(context.TODO(), params)
+	s.requireError(err, http.StatusUnauthorized, "this endpoint requires authentication", "expected error when user is not authenticated")
+
+	// Endpoints require the update:organizations and read:organizations permissions
+	require.NoError(s.SetClientCredentials(claims), "could not create token with incorrect permissions")
+	_, err = s.client.CreateWebhook(context.TODO(), params)
+	s.requireError(err, http.StatusUnauthorized, "user does not have permission to perform this operation", "expected error when user is not authorized")
+	_, err = s.client.ListWebhooks(context.TODO())
+	s.requireError(err, http.StatusUnauthorized, "user does not have permission to perform this operation", "expected error when user is not authorized")
+
+	// Claims must have an organization ID
+	claims.Permissions = []string{auth.ReadOrganizations, auth.UpdateOrganizations}
+	require.NoError(s.SetClientCredentials(claims), "could not create token with valid credentials")
+	_, err = s.client.CreateWebhook(context.TODO(), params)
+	s.requireError(err, http.StatusUnauthorized, "missing claims info, try logging out and logging back in", "expected error when user claims does not have an orgid")
+
+	// Create an organization in the database without any webhooks
+	org := &records.Organization{}
+	_, err = s.DB().CreateOrganization(context.Background(), org)
+	require.NoError(err, "could not create organization in the database")
+	claims.OrgID = org.Id
+	require.NoError(s.SetClientCredentials(claims), "could not create token with valid credentials")
+
+	reply, err := s.client.ListWebhooks(context.TODO())
+	require.NoError(err, "could not list webhooks")
+	require.Empty(reply.Webhooks, "expected no webhooks")
+
+	// Should not be able to create invalid webhooks
+	_, err = s.client.CreateWebhook(context.TODO(), &api.WebhookParams{URL: "ftp://example.com"})
+	s.requireError(err, http.StatusBadRequest, records.ErrInvalidWebhookURL.Error(), "expected error when webhook url is invalid")
+	_, err = s.client.CreateWebhook(context.TODO(), &api.WebhookParams{URL: "https://example.com/hooks", Events: []string{"vasp.deleted"}})
+	s.requireError(err, http.StatusBadRequest, `unknown webhook event "vasp.deleted"`, "expected error when webhook event is unknown")
+
+	// Successfully create a webhook, the secret is only returned on creation
+	webhook, err := s.client.CreateWebhook(context.TODO(), params)
+	require.NoError(err, "could not create webhook")
+	require.NotEmpty(webhook.ID, "expected webhook ID to be set")
+	require.NotEmpty(webhook.Secret, "expected webhook secret to be returned")
+	require.Equal(params.URL, webhook.URL)
+	require.Equal(params.Events, webhook.Events)
+	require.True(webhook.Active, "expected webhook to be active")
+
+	org, err = s.DB().RetrieveOrganization(context.Background(), org.UUID())
+	require.NoError(err, "could not retrieve organization from the database")
+	require.Len(org.Webhooks, 1, "expected webhook to be saved on the organization")
+	require.Equal(webhook.Secret, org.Webhooks[webhook.ID].Secret)
+
+	reply, err = s.client.ListWebhooks(context.TODO())
+	require.NoError(err, "could not list webhooks")
+	require.Len(reply.Webhooks, 1, "expected one webhook")
+	require.Equal(webhook.ID, reply.Webhooks[0].ID)
+	require.Empty(reply.Webhooks[0].Secret, "webhook secret should not be listed")
+
+	// Update the webhook
+	_, err = s.client.UpdateWebhook(context.TODO(), "missing", params)
+	s.requireError(err, http.StatusNotFound, "webhook not found", "expected error when webhook does not exist")
+
+	inactive := false
+	updated, err := s.client.UpdateWebhook(context.TODO(), webhook.ID, &api.WebhookParams{Events: []string{}, Active: &inactive})
+	require.NoError(err, "could not update webhook")
+	require.Equal(params.URL, updated.URL, "expected url to be unchanged")
+	require.Empty(updated.Events, "expected webhook to be subscribed to all events")
+	require.False(updated.Active, "expected webhook to be inactive")
+	require.Empty(updated.Secret, "webhook secret should not be returned")
+
+	_, err = s.client.UpdateWebhook(context.TODO(), webhook.ID, &api.WebhookParams{URL: "http://example.com/hooks?insecure=false"})
+	require.NoError(err, "http urls are allowed when webhooks are insecure")
+	_, err = s.client.UpdateWebhook(context.TODO(), webhook.ID, &api.WebhookParams{URL: "example.com"})
+	s.requireError(err, http.StatusBadRequest, records.ErrInvalidWebhookURL.Error(), "expected error when webhook url is invalid")
+
+	// No deliveries have been made to the webhook
+	deliveries, err := s.client.WebhookDeliveries(context.TODO(), webhook.ID)
+	require.NoError(err, "could not list webhook deliveries")
+	require.Empty(deliveries.Deliveries, "expected no deliveries")
+
+	_, err = s.client.WebhookDeliveries(context.TODO(), "missing")
+	s.requireError(err, http.StatusNotFound, "webhook not found", "expected error when webhook does not exist")
+
+	// Delete the webhook
+	err = s.client.DeleteWebhook(context.TODO(), "missing")
+	s.requireError(err, http.StatusNotFound, "webhook not found", "expected error when webhook does not exist")
+
+	require.NoError(s.client.DeleteWebhook(context.TODO(), webhook.ID), "could not delete webhook")
+	org, err = s.DB().RetrieveOrganization(context.Background(), org.UUID())
+	require.NoError(err, "could not retrieve organization from the database")
+	require.Empty(org.Webhooks, "expected webhook to be deleted")
+
+	// Limit the number of webhooks
+	for i := 0; i < records.MaxWebhooks; i++ {
+		_, err = s.client.CreateWebhook(context.TODO(), params)
+		require.NoError(err, "could not create webhook")
+	}
+	_, err = s.client.CreateWebhook(context.TODO(), params)
+	s.requireError(err, http.StatusForbidden, records.ErrMaxWebhooks.Error(), "expected error when maximum number of webhooks is reached")
+}
+
+func (s *bffTestSuite) TestWebhookDispatcher() {
+	require := s.Require()
+	ctx := context.Background()
+	s.ResetTestNetDB()
+	defer s.ResetDB()
+	defer s.ResetTestNetDB()
+	defer s.bff.SetWebhookDispatcher(nil)
+
+	// Start a webhook receiver that records the events it receives
+	receiver := &webhookReceiver{status: http.StatusOK}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	// Create a submitted testnet registration
+	vasp := &pb.VASP{}
+	require.NoError(loadFixture(filepath.Join("testdata", "testnet", "vasp.json"), vasp), "could not load testnet VASP fixture")
+	vasp.VerificationStatus = pb.VerificationState_SUBMITTED
+	vasp.IdentityCertificate = nil
+	_, err := s.TestNetDB().CreateVASP(ctx, vasp)
+	require.NoError(err, "could not create testnet VASP")
+
+	// Create an organization for the registration with a webhook
+	org := &records.Organization{
+		Testnet: &records.DirectoryRecord{Id: vasp.Id},
+	}
+	hook, err := records.NewWebhook(srv.URL, nil)
+	require.NoError(err, "could not create webhook")
+	require.NoError(org.AddWebhook(hook, true), "could not add webhook to organization")
+	_, err = s.DB().CreateOrganization(ctx, org)
+	require.NoError(err, "could not create organization in the database")
+
+	conf := s.bff.GetConf().Webhooks
+	dispatcher := bff.NewWebhookDispatcher(conf, s.DB(), s.TestNetDB(), s.MainNetDB())
+
+	// The first time the registration is observed no events are sent
+	require.NoError(dispatcher.Process(ctx), "could not process webhooks")
+	require.Empty(receiver.Events(), "expected no events to be sent")
+	org, err = s.DB().RetrieveOrganization(ctx, org.UUID())
+	require.NoError(err, "could not retrieve organization")
+	require.Contains(org.WebhookState, "testnet", "expected the testnet state to be recorded")
+	require.Equal("SUBMITTED", org.WebhookState["testnet"].VerificationStatus)
+
+	// Verify the registration and issue a certificate that expires in 10 days
+	contact := vasp.Contacts.Administrative
+	require.NoError(models.SetContactVerification(contact, "", true), "could not verify contact")
+	vasp.VerificationStatus = pb.VerificationState_VERIFIED
+	vasp.IdentityCertificate = &pb.Certificate{
+		SerialNumber: []byte{0x0c, 0x2d},
+		NotAfter:     time.Now().AddDate(0, 0, 10).Format(time.RFC3339),
+	}
+	require.NoError(models.AppendCertReqID(vasp, "8a3c6d5e-7f2b-4c1a-9e0d-3b5f7a9c1e2d"), "could not add certificate request")
+	require.NoError(s.TestNetDB().UpdateVASP(ctx, vasp), "could not update testnet VASP")
+
+	require.NoError(dispatcher.Process(ctx), "could not process webhooks")
+	events := receiver.Events()
+	require.Len(events, 4, "expected an event for each change")
+	for _, event := range events {
+		require.NoError(records.VerifyWebhookSignature(hook.Secret, event.signature, event.body, records.DefaultWebhookSkew), "could not verify webhook signature")
+		require.Equal(org.Id, event.payload.OrgID)
+		require.Equal(event.payload.Event, event.event, "expected event header to match the payload")
+		require.NotEmpty(event.delivery)
+		require.Equal("testnet", event.payload.Data.Network)
+		require.Equal(vasp.Id, event.payload.Data.VASPID)
+	}
+
+	require.Equal(records.WebhookRegistrationStatus, events[0].event)
+	require.Equal("VERIFIED", events[0].payload.Data.Status)
+	require.Equal("SUBMITTED", events[0].payload.Data.PreviousStatus)
+	require.Equal(records.WebhookContactVerified, events[1].event)
+	require.Equal("administrative", events[1].payload.Data.Contact)
+	require.Equal(records.WebhookCertificateIssued, events[2].event)
+	require.Equal("0C2D", events[2].payload.Data.SerialNumber)
+	require.Equal(records.WebhookCertificateExpiring, events[3].event)
+	require.Equal(9, events[3].payload.Data.DaysRemaining)
+
+	// Nothing is sent if nothing has changed
+	require.NoError(dispatcher.Process(ctx), "could not process webhooks")
+	require.Len(receiver.Events(), 4, "expected no new events")
+
+	// A new certificate request for a VASP with a certificate starts reissuance
+	require.NoError(models.AppendCertReqID(vasp, "f3a1b2c4-5d6e-4f70-8a9b-0c1d2e3f4a5b"), "could not add certificate request")
+	require.NoError(s.TestNetDB().UpdateVASP(ctx, vasp), "could not update testnet VASP")
+
+	// Changes made to the organization while webhooks are delivered should not be lost
+	receiver.OnReceive(func() {
+		latest, err := s.DB().RetrieveOrganization(ctx, org.UUID())
+		require.NoError(err, "could not retrieve organization")
+		latest.Name = "Concurrent Update"
+		require.NoError(s.DB().UpdateOrganization(ctx, latest), "could not update organization")
+	})
+	require.NoError(dispatcher.Process(ctx), "could not process webhooks")
+	receiver.OnReceive(nil)
+
+	events = receiver.Events()
+	require.Len(events, 5, "expected a reissuance event")
+	require.Equal(records.WebhookReissuanceStarted, events[4].event)
+	require.Equal("0C2D", events[4].payload.Data.SerialNumber)
+
+	org, err = s.DB().RetrieveOrganization(ctx, org.UUID())
+	require.NoError(err, "could not retrieve organization")
+	require.Equal("Concurrent Update", org.Name, "expected the concurrent update to be kept")
+	require.Equal("f3a1b2c4-5d6e-4f70-8a9b-0c1d2e3f4a5b", org.WebhookState["testnet"].CertificateRequest, "expected the webhook state to be saved")
+	require.Len(org.Webhooks[hook.Id].Deliveries, 5, "expected the reissuance delivery to be saved")
+	require.Equal(records.WebhookDeliveryState_DELIVERY_SUCCEEDED, org.Webhooks[hook.Id].Deliveries[4].Status)
+
+	// Announcements are sent to webhooks when they are posted
+	s.bff.SetWebhookDispatcher(dispatcher)
+	require.NoError(s.SetClientCSRFProtection(), "could not set csrf protection on client")
+	claims := &authtest.Claims{
+		Email:       "leopold.wentzel@gmail.com",
+		OrgID:       org.Id,
+		Permissions: []string{auth.CreateAnnouncements, auth.ReadOrganizations},
+	}
+	require.NoError(s.SetClientCredentials(claims), "could not create token with valid credentials")
+	post := &records.Announcement{Title: "Scheduled maintenance", Body: "The directory will be down for maintenance."}
+	require.NoError(s.client.MakeAnnouncement(context.TODO(), post), "could not make announcement")
+
+	// Failed deliveries are retried after the backoff
+	receiver.SetStatus(http.StatusInternalServerError)
+	require.NoError(dispatcher.Process(ctx), "could not process webhooks")
+	require.Len(receiver.Events(), 6, "expected an attempt to deliver the announcement")
+
+	deliveries, err := s.client.WebhookDeliveries(context.TODO(), hook.Id)
+	require.NoError(err, "could not list webhook deliveries")
+	require.Len(deliveries.Deliveries, 6, "expected all deliveries in the log")
+	delivery := deliveries.Deliveries[0]
+	require.Equal(records.WebhookAnnouncementCreated, delivery.Event)
+	require.Equal("DELIVERY_RETRYING", delivery.Status)
+	require.Equal(uint32(1), delivery.Attempts)
+	require.Equal(int32(http.StatusInternalServerError), delivery.StatusCode)
+	require.Equal("webhook responded with status 500 Internal Server Error", delivery.LastError)
+	require.NotEmpty(delivery.NextAttempt)
+	require.Equal("DELIVERY_SUCCEEDED", deliveries.Deliveries[1].Status)
+
+	// Retries are not attempted before they are due
+	require.NoError(dispatcher.Process(ctx), "could not process webhooks")
+	require.Len(receiver.Events(), 6, "expected no attempts before the retry is due")
+
+	org, err = s.DB().RetrieveOrganization(ctx, org.UUID())
+	require.NoError(err, "could not retrieve organization")
+	retry := org.Webhooks[hook.Id].Deliveries[5]
+	retry.NextAttempt = time.Now().Add(-time.Minute).Format(time.RFC3339)
+	require.NoError(s.DB().UpdateOrganization(ctx, org), "could not update organization")
+
+	receiver.SetStatus(http.StatusNoContent)
+	require.NoError(dispatcher.Process(ctx), "could not process webhooks")
+	events = receiver.Events()
+	require.Len(events, 7, "expected the announcement to be retried")
+	require.Equal(records.WebhookAnnouncementCreated, events[6].event)
+	require.Equal(post.Title, events[6].payload.Data.Announcement.Title)
+	require.Equal(retry.Id, events[6].delivery)
+
+	deliveries, err = s.client.WebhookDeliveries(context.TODO(), hook.Id)
+	require.NoError(err, "could not list webhook deliveries")
+	require.Equal("DELIVERY_SUCCEEDED", deliveries.Deliveries[0].Status)
+	require.Equal(uint32(2), deliveries.Deliveries[0].Attempts)
+	require.Empty(deliveries.Deliveries[0].LastError)
+
+	// Deliveries fail after the maximum number of attempts
+	conf.MaxAttempts = 1
+	dispatcher = bff.NewWebhookDispatcher(conf, s.DB(), s.TestNetDB(), s.MainNetDB())
+	receiver.SetStatus(http.StatusNotFound)
+	enqueued, err := dispatcher.Broadcast(ctx, records.WebhookAnnouncementCreated, &api.WebhookEventData{Announcement: post})
+	require.NoError(err, "could not broadcast announcement")
+	require.Equal(1, enqueued)
+	require.NoError(dispatcher.Process(ctx), "could not process webhooks")
+
+	deliveries, err = s.client.WebhookDeliveries(context.TODO(), hook.Id)
+	require.NoError(err, "could not list webhook deliveries")
+	require.Equal("DELIVERY_FAILED", deliveries.Deliveries[0].Status)
+	require.Empty(deliveries.Deliveries[0].NextAttempt)
+
+	// Inactive webhooks do not receive events
+	org, err = s.DB().RetrieveOrganization(ctx, org.UUID())
+	require.NoError(err, "could not retrieve organization")
+	org.Webhooks[hook.Id].Active = false
+	require.NoError(s.DB().UpdateOrganization(ctx, org), "could not update organization")
+	enqueued, err = dispatcher.Broadcast(ctx, records.WebhookAnnouncementCreated, &api.WebhookEventData{Announcement: post})
+	require.NoError(err, "could not broadcast announcement")
+	require.Zero(enqueued, "expected no deliveries to inactive webhooks")
+}
+
+func (s *bffTestSuite) TestWebhookBroadcast() {
+	require := s.Require()
+	ctx := context.Background()
+	defer s.ResetDB()
+
+	receiver := &webhookReceiver{status: http.StatusOK}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	org := &records.Organization{}
+	hook, err := records.NewWebhook(srv.URL, []string{records.WebhookAnnouncementCreated})
+	require.NoError(err, "could not create webhook")
+	require.NoError(org.AddWebhook(hook, true), "could not add webhook to organization")
+	_, err = s.DB().CreateOrganization(ctx, org)
+	require.NoError(err, "could not create organization in the database")
+
+	conf := s.bff.GetConf().Webhooks
+	dispatcher := bff.NewWebhookDispatcher(conf, s.DB(), s.TestNetDB(), s.MainNetDB())
+	first := &records.Announcement{Title: "First announcement"}
+	enqueued, err := dispatcher.Broadcast(ctx, records.WebhookAnnouncementCreated, &api.WebhookEventData{Announcement: first})
+	require.NoError(err, "could not broadcast announcement")
+	require.Equal(1, enqueued)
+
+	// Broadcasts should not wait for the webhooks that are being delivered
+	var broadcastErr error
+	second := &records.Announcement{Title: "Second announcement"}
+	receiver.OnReceive(func() {
+		done := make(chan error, 1)
+		go func() {
+			_, err := dispatcher.Broadcast(ctx, records.WebhookAnnouncementCreated, &api.WebhookEventData{Announcement: second})
+			done <- err
+		}()
+
+		select {
+		case broadcastErr = <-done:
+		case <-time.After(500 * time.Millisecond):
+			broadcastErr = errors.New("broadcast was blocked by the webhook delivery")
+		}
+	})
+	require.NoError(dispatcher.Process(ctx), "could not process webhooks")
+	receiver.OnReceive(nil)
+	require.NoError(broadcastErr)
+	require.Len(receiver.Events(), 1, "expected the first announcement to be delivered")
+
+	// The announcement broadcast during the delivery is sent the next time
+	require.NoError(dispatcher.Process(ctx), "could not process webhooks")
+	events := receiver.Events()
+	require.Len(events, 2, "expected the second announcement to be delivered")
+	require.Equal(first.Title, events[0].payload.Data.Announcement.Title)
+	require.Equal(second.Title, events[1].payload.Data.Announcement.Title)
+
+	// Unless insecure webhooks are allowed, webhooks are not delivered to internal addresses
+	conf.Insecure = false
+	dispatcher = bff.NewWebhookDispatcher(conf, s.DB(), s.TestNetDB(), s.MainNetDB())
+	enqueued, err = dispatcher.Broadcast(ctx, records.WebhookAnnouncementCreated, &api.WebhookEventData{Announcement: first})
+	require.NoError(err, "could not broadcast announcement")
+	require.Equal(1, enqueued)
+	require.NoError(dispatcher.Process(ctx), "could not process webhooks")
+	require.Len(receiver.Events(), 2, "expected no delivery to the loopback receiver")
+
+	org, err = s.DB().RetrieveOrganization(ctx, org.UUID())
+	require.NoError(err, "could not retrieve organization")
+	deliveries := org.Webhooks[hook.Id].Deliveries
+	require.Len(deliveries, 3)
+	require.Equal(records.WebhookDeliveryState_DELIVERY_RETRYING, deliveries[2].Status)
+	require.Contains(deliveries[2].LastError, "not a public internet address")
+}
+
+// Records the webhook events that are received and responds with the configured status.
+type webhookReceiver struct {
+	sync.Mutex
+	status    int
+	events    []*receivedWebhook
+	onReceive func()
+}
+
+type receivedWebhook struct {
+	event     string
+	delivery  string
+	signature string
+	body      []byte
+	payload   *api.WebhookEvent
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.Lock()
+	defer r.Unlock()
+
+	event := &receivedWebhook{
+		event:     req.Header.Get(records.WebhookEventHeader),
+		delivery:  req.Header.Get(records.WebhookDeliveryHeader),
+		signature: req.Header.Get(records.WebhookSignatureHeader),
+		payload:   &api.WebhookEvent{},
+	}
+
+	var err error
+	if event.body, err = io.ReadAll(req.Body); err == nil {
+		err = json.Unmarshal(event.body, event.payload)
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if r.onReceive != nil {
+		r.onReceive()
+	}
+
+	r.events = append(r.events, event)
+	w.WriteHeader(r.status)
+}
+
+func (r *webhookReceiver) SetStatus(status int) {
+	r.Lock()
+	defer r.Unlock()
+	r.status = status
+}
+
+func (r *webhookReceiver) Events() []*receivedWebhook {
+	r.Lock()
+	defer r.Unlock()
+	return append([]*receivedWebhook(nil), r.events...)
+}
+
+// OnReceive sets a callback that is called whenever the receiver receives an event,
+// e.g. to modify the database while the dispatcher is delivering webhooks.
+func (r *webhookReceiver) OnReceive(callback func()) {
+	r.Lock()
+	defer r.Unlock()
+	r.onReceive = callback
+}
@@ -23,12 +23,18 @@ import (
 
 	"github.com/trisacrypto/directory/pkg"
 	admin "github.com/trisacrypto/directory/pkg/gds/admin/v2"
+	"github.com/trisacrypto/directory/pkg/gds/certman"
 	"github.com/trisacrypto/directory/pkg/gds/config"
 	"github.com/trisacrypto/directory/pkg/gds/secrets"
 	"github.com/trisacrypto/directory/pkg/gds/tokens"
 	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/sectigo"
 	"github.com/trisacrypto/directory/pkg/store"
+	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
+	"github.com/trisacrypto/directory/pkg/store/iterator"
+	"github.com/trisacrypto/directory/pkg/store/txn"
 	"github.com/trisacrypto/directory/pkg/utils"
+	"github.com/trisacrypto/directory/pkg/utils/emails"
 	"github.com/trisacrypto/directory/pkg/utils/logger"
 	"github.com/trisacrypto/directory/pkg/utils/sentry"
 	"github.com/trisacrypto/directory/pkg/utils/wire"
@@ -227,6 +233,7 @@ func (s *Admin) setupRoutes() (err error) {
 			vasps.GET("/:vaspID/review", s.ReviewToken)
 			vasps.POST("/:vaspID/review", csrf, s.Review)
 			vasps.POST("/:vaspID/resend", csrf, s.Resend)
+			vasps.POST("/:vaspID/revoke", csrf, s.RevokeCertificate)
 
 			contacts := vasps.Group("/:vaspID/contacts")
 			{
@@ -242,6 +249,20 @@ func (s *Admin) setupRoutes() (err error) {
 				notes.DELETE("/:noteID", csrf, s.DeleteReviewNote)
 			}
 		}
+
+		// Certificate request archive routes must be authenticated
+		certreqs := v2.Group("/certreqs", authorize)
+		{
+			certreqs.GET("/archive", s.ListArchivedCertReqs)
+			certreqs.GET("/archive/:certreqID", s.RetrieveArchivedCertReq)
+		}
+
+		// Email outbox routes must be authenticated
+		emails := v2.Group("/emails", authorize)
+		{
+			emails.GET("", s.ListEmails)
+			emails.POST("/:emailID/retry", csrf, s.RetryEmail)
+		}
 	}
 
 	// NotFound and NotAllowed requests
@@ -527,30 +548,32 @@ func (s *Admin) Summary(c *gin.Context) {
 	}
 	iter.Release()
 
-	// Loop over certificate requests next
-	iter2 := s.db.ListCertReqs(ctx)
-	for iter2.Next() {
-		// Fetch CertificateRequest from the database
-		var certreq *models.CertificateRequest
-		var err error
-		if certreq, err = iter2.CertReq(); err != nil {
-			sentry.Error(c).Err(err).Msg("could not parse CertificateRequest from database")
-			continue
-		}
+	// Loop over the active and archived certificate requests next
+	for _, list := range []func(context.Context) iterator.CertificateRequestIterator{s.db.ListCertReqs, s.db.ListArchivedCertReqs} {
+		iter2 := list(ctx)
+		for iter2.Next() {
+			// Fetch CertificateRequest from the database
+			var certreq *models.CertificateRequest
+			var err error
+			if certreq, err = iter2.CertReq(); err != nil {
+				sentry.Error(c).Err(err).Msg("could not parse CertificateRequest from database")
+				continue
+			}
 
-		out.CertReqs[certreq.Status.String()]++
-		if certreq.Status == models.CertificateRequestState_COMPLETED {
-			out.CertificatesIssued++
+			out.CertReqs[certreq.Status.String()]++
+			if certreq.Status == models.CertificateRequestState_COMPLETED {
+				out.CertificatesIssued++
+			}
 		}
-	}
 
-	if err := iter2.Error(); err != nil {
+		if err := iter2.Error(); err != nil {
+			iter2.Release()
+			sentry.Error(c).Err(err).Msg("could not iterate over certreqs in store")
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse(err))
+			return
+		}
 		iter2.Release()
-		sentry.Error(c).Err(err).Msg("could not iterate over certreqs in store")
-		c.JSON(http.StatusInternalServerError, admin.ErrorResponse(err))
-		return
 	}
-	iter2.Release()
 
 	// Successful request, return the VASP list JSON data
 	c.JSON(http.StatusOK, out)
@@ -974,7 +997,7 @@ func (s *Admin) RetrieveVASP(c *gin.Context) {
 
 	// Prepare VASP detail response (both retrieve and update use this method)
 	// NOTE: VASP is modified in this step, must not save VASP after this!
-	if out, err = s.prepareVASPDetail(vasp, logctx); err != nil {
+	if out, err = s.prepareVASPDetail(ctx, vasp, logctx); err != nil {
 		// NOTE: logging occurs in prepareVASPDetail
 		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not create VASP detail"))
 		return
@@ -984,7 +1007,7 @@ func (s *Admin) RetrieveVASP(c *gin.Context) {
 	c.JSON(http.StatusOK, out)
 }
 
-func (s *Admin) prepareVASPDetail(vasp *pb.VASP, logctx *sentry.Logger) (out *admin.RetrieveVASPReply, err error) {
+func (s *Admin) prepareVASPDetail(ctx context.Context, vasp *pb.VASP, logctx *sentry.Logger) (out *admin.RetrieveVASPReply, err error) {
 	// Create the response to send back
 	out = &admin.RetrieveVASPReply{
 		Traveler:         models.IsTraveler(vasp),
@@ -1034,6 +1057,72 @@ func (s *Admin) prepareVASPDetail(vasp *pb.VASP, logctx *sentry.Logger) (out *ad
 		}
 	}
 
+	// Add the endpoint health check record and history to the response
+	if record, err := models.GetHealthCheck(vasp); err != nil {
+		logctx.Warn().Err(err).Msg("could not get health check record for VASP detail")
+	} else if record != nil {
+		if out.HealthCheck, err = wire.Rewire(record); err != nil {
+			logctx.Warn().Err(err).Msg("could not rewire health check record for VASP detail")
+			out.HealthCheck = nil
+		}
+	}
+
+	// Add any likely duplicate registrations of the VASP to the response
+	if matches, err := models.GetDuplicates(vasp); err != nil {
+		logctx.Warn().Err(err).Msg("could not get duplicate matches for VASP detail")
+	} else if len(matches) > 0 {
+		out.Duplicates = make([]map[string]interface{}, 0, len(matches))
+		for _, match := range matches {
+			var data map[string]interface{}
+			if data, err = wire.Rewire(match); err != nil {
+				logctx.Warn().Err(err).Msg("could not rewire duplicate match for VASP detail")
+				continue
+			}
+			out.Duplicates = append(out.Duplicates, data)
+		}
+	}
+
+	// Add the active certificates and the rotation overlap to the response; the details
+	// of the certificates are omitted since they are on the VASP record.
+	if active, err := models.GetActiveCertificates(vasp, time.Now()); err != nil {
+		logctx.Warn().Err(err).Msg("could not get active certificates for VASP detail")
+	} else if len(active) > 0 {
+		out.RotationOverlap = s.svc.conf.CertMan.RotationOverlap.String()
+		out.ActiveCertificates = make([]map[string]interface{}, 0, len(active))
+		for _, cert := range active {
+			var data map[string]interface{}
+			if data, err = wire.Rewire(cert); err != nil {
+				logctx.Warn().Err(err).Msg("could not rewire active certificate for VASP detail")
+				continue
+			}
+			delete(data, "details")
+			out.ActiveCertificates = append(out.ActiveCertificates, data)
+		}
+	}
+
+	// Add the domain challenges of the latest certificate request to the response so
+	// that reviewers can see if the VASP has proven control of its domains.
+	if certreqID, err := models.GetLatestCertReqID(vasp); err != nil {
+		logctx.Warn().Err(err).Msg("could not get latest certificate request for VASP detail")
+	} else if certreqID != "" {
+		var certreq *models.CertificateRequest
+		if certreq, err = s.db.RetrieveCertReq(ctx, certreqID); err != nil {
+			if !errors.Is(err, storeerrors.ErrEntityNotFound) {
+				logctx.Warn().Err(err).Str("certreq", certreqID).Msg("could not retrieve certificate request for VASP detail")
+			}
+		} else if len(certreq.Challenges) > 0 {
+			out.DomainChallenges = make([]map[string]interface{}, 0, len(certreq.Challenges))
+			for _, challenge := range certreq.Challenges {
+				var data map[string]interface{}
+				if data, err = wire.Rewire(challenge); err != nil {
+					logctx.Warn().Err(err).Msg("could not rewire domain challenge for VASP detail")
+					continue
+				}
+				out.DomainChallenges = append(out.DomainChallenges, data)
+			}
+		}
+	}
+
 	// Remove extra data from the VASP
 	// Must be done after verified contacts is computed
 	// WARNING: This is safe because nothing is saved back to the database!
@@ -1126,6 +1215,16 @@ func (s *Admin) UpdateVASP(c *gin.Context) {
 		return
 	}
 
+	// The VASP and any certificate requests changed by the update are committed in a
+	// single transaction so that the certificate requests are not changed on error.
+	var tx txn.Txn
+	if tx, err = s.db.Begin(ctx); err != nil {
+		logctx.Error().Err(err).Msg("could not begin update transaction")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP"))
+		return
+	}
+	defer tx.Rollback()
+
 	// Apply changes and record if anything has changed. Note that all update methods
 	// may change the VASP and must return if they created a modification requiring the
 	// VASP to be saved back to the database.
@@ -1168,13 +1267,9 @@ func (s *Admin) UpdateVASP(c *gin.Context) {
 		nChanges++
 	}
 
-	// Update common name and trisa endpoint - this will also update any certificate requests.
-	// NOTE: if updated is true and any failure occurs after this point, the certificate requests
-	// will be in an inconsistent state. Transactions would be very nice here, but instead this
-	// function is performed as late as possible to minimize the chance of other errors.
-	// The log messages that follow this line of code may be at a higher level than we might
-	// expect so that we can debug the case where the database moves to an inconsistent state.
-	if updated, code, err = s.updateVASPEndpoint(vasp, in.CommonName, in.TRISAEndpoint, claims.Email, logctx); err != nil {
+	// Update common name and trisa endpoint - this will also update any certificate
+	// requests, which are only saved when the transaction is committed.
+	if updated, code, err = s.updateVASPEndpoint(ctx, tx, vasp, in.CommonName, in.TRISAEndpoint, claims.Email, logctx); err != nil {
 		// NOTE: logging happens in the update helper function
 		c.JSON(code, admin.ErrorResponse(err))
 		return
@@ -1202,27 +1297,29 @@ func (s *Admin) UpdateVASP(c *gin.Context) {
 	}
 
 	// Add a record to the audit log
-	// NOTE: if validation, adding a record to the audit log, or saving the VASP back to
-	// disk errors, we could end up in an inconsistent state where we have changes to
-	// the certificate request that do not appear in the VASP audit log.
 	if err = models.UpdateVerificationStatus(vasp, vasp.VerificationStatus, "VASP record updated by admin", claims.Email); err != nil {
 		logctx.Error().Err(err).Msg("could not add audit log entry by updating the verification status")
 		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP audit log"))
 		return
 	}
 
-	// Since updates have occurred, save the changes
-	// TODO: transactions would be super nice here so we could rollback any certificate request changes
-	if err = s.db.UpdateVASP(ctx, vasp); err != nil {
+	// Since updates have occurred, save the changes along with the certificate requests
+	if err = tx.UpdateVASP(vasp); err != nil {
 		logctx.Error().Err(err).Msg("could not save VASP after update")
 		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP"))
 		return
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		logctx.Error().Err(err).Msg("could not commit VASP update")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP"))
+		return
+	}
+
 	// Create the response to send back, ensuring extra fields are removed.
 	// Prepare VASP detail response (both retrieve and update use this method)
 	// NOTE: VASP is modified in this step, must not save VASP after this!
-	if out, err = s.prepareVASPDetail(vasp, logctx); err != nil {
+	if out, err = s.prepareVASPDetail(ctx, vasp, logctx); err != nil {
 		// NOTE: logging occurs in prepareVASPDetail
 		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not create VASP detail"))
 		return
@@ -1315,7 +1412,7 @@ func (s *Admin) updateVASPTRIXO(vasp *pb.VASP, data map[string]interface{}, logc
 	return true, http.StatusOK, nil
 }
 
-func (s *Admin) updateVASPEndpoint(vasp *pb.VASP, commonName, endpoint, source string, logctx *sentry.Logger) (_ bool, _ int, err error) {
+func (s *Admin) updateVASPEndpoint(ctx context.Context, tx txn.Txn, vasp *pb.VASP, commonName, endpoint, source string, logctx *sentry.Logger) (_ bool, _ int, err error) {
 	if commonName == "" && endpoint == "" {
 		return false, http.StatusOK, nil
 	}
@@ -1357,9 +1454,6 @@ func (s *Admin) updateVASPEndpoint(vasp *pb.VASP, commonName, endpoint, source s
 		return false, http.StatusInternalServerError, errors.New("could not update certificate request with common name")
 	}
 
-	ctx, cancel := utils.WithDeadline(context.Background())
-	defer cancel()
-
 	// Loop through all of the certificate requests and check if they can be updated
 	ncertreqs := 0
 	for _, certreqID := range certreqs {
@@ -1382,8 +1476,8 @@ func (s *Admin) updateVASPEndpoint(vasp *pb.VASP, commonName, endpoint, source s
 			continue
 		}
 
-		// Store the certificate request back to disk
-		if err = s.db.UpdateCertReq(ctx, certreq); err != nil {
+		// Store the certificate request when the transaction is committed
+		if err = tx.UpdateCertReq(certreq); err != nil {
 			logctx.Error().Err(err).Str("certreq_id", certreqID).Msg("could not update certificate request for VASP")
 			continue
 		}
@@ -1397,9 +1491,6 @@ func (s *Admin) updateVASPEndpoint(vasp *pb.VASP, commonName, endpoint, source s
 		return false, http.StatusInternalServerError, errors.New("could not update certificate request with common name")
 	}
 
-	// NOTE: from this point on it's possible that we have an unsaved VASP that has had
-	// modifications to its certificate requests. If the VASP is not saved after this
-	// method, it could lead to an inconsistency that needs to be repaired manually.
 	return true, http.StatusOK, nil
 }
 
@@ -1504,6 +1595,7 @@ func (s *Admin) ListCertificates(c *gin.Context) {
 			IssuedAt:     cert.Details.NotBefore,
 			ExpiresAt:    cert.Details.NotAfter,
 			Status:       cert.Status.String(),
+			RevokedOn:    cert.RevokedOn,
 		}
 		if entry.Details, err = wire.Rewire(cert.Details); err != nil {
 			sentry.Error(c).Err(err).Str("cert_id", id).Msg("could not serialize certificate details")
@@ -1517,6 +1609,330 @@ func (s *Admin) ListCertificates(c *gin.Context) {
 	c.JSON(http.StatusOK, out)
 }
 
+// ListArchivedCertReqs returns a paginated list of the finished certificate requests
+// that have been moved to the archive by the certificate manager, optionally filtered
+// by the VASP the requests were made for.
+func (s *Admin) ListArchivedCertReqs(c *gin.Context) {
+	var (
+		err error
+		in  *admin.ListArchivedCertReqsParams
+		out *admin.ListArchivedCertReqsReply
+	)
+
+	in = new(admin.ListArchivedCertReqsParams)
+	if err = c.ShouldBindQuery(&in); err != nil {
+		sentry.Warn(c).Err(err).Msg("could not bind request with query params")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
+		return
+	}
+
+	// Set pagination defaults if not specified in query
+	if in.Page <= 0 {
+		in.Page = 1
+	}
+	if in.PageSize <= 0 {
+		in.PageSize = 100
+	}
+
+	// Determine pagination index range (indexed by 1)
+	minIndex := (in.Page - 1) * in.PageSize
+	maxIndex := minIndex + in.PageSize
+
+	out = &admin.ListArchivedCertReqsReply{
+		CertReqs: make([]admin.CertReqSnippet, 0),
+		Page:     in.Page,
+		PageSize: in.PageSize,
+	}
+
+	ctx, cancel := utils.WithDeadline(context.Background())
+	defer cancel()
+
+	iter := s.db.ListArchivedCertReqs(ctx)
+	defer iter.Release()
+	for iter.Next() {
+		var certreq *models.CertificateRequest
+		if certreq, err = iter.CertReq(); err != nil {
+			sentry.Error(c).Err(err).Msg("could not parse archived certificate request from database")
+			continue
+		}
+
+		if in.VASP != "" && certreq.Vasp != in.VASP {
+			continue
+		}
+
+		if out.Count >= minIndex && out.Count < maxIndex {
+			out.CertReqs = append(out.CertReqs, admin.CertReqSnippet{
+				ID:          certreq.Id,
+				VASP:        certreq.Vasp,
+				CommonName:  certreq.CommonName,
+				Status:      certreq.Status.String(),
+				Certificate: certreq.Certificate,
+				Created:     certreq.Created,
+				Modified:    certreq.Modified,
+			})
+		}
+		out.Count++
+	}
+
+	if err = iter.Error(); err != nil {
+		sentry.Error(c).Err(err).Msg("could not iterate over archived certreqs in store")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+// RetrieveArchivedCertReq returns the details of an archived certificate request. The
+// request parameters are omitted from the reply since they may contain secrets.
+func (s *Admin) RetrieveArchivedCertReq(c *gin.Context) {
+	var (
+		err     error
+		certreq *models.CertificateRequest
+		out     *admin.RetrieveArchivedCertReqReply
+	)
+
+	certreqID := c.Param("certreqID")
+
+	ctx, cancel := utils.WithDeadline(context.Background())
+	defer cancel()
+
+	if certreq, err = s.db.RetrieveArchivedCertReq(ctx, certreqID); err != nil {
+		if errors.Is(err, storeerrors.ErrEntityNotFound) {
+			c.JSON(http.StatusNotFound, admin.ErrorResponse("could not find archived certificate request by ID"))
+			return
+		}
+		sentry.Error(c).Err(err).Str("certreq_id", certreqID).Msg("could not retrieve archived certificate request")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not retrieve archived certificate request"))
+		return
+	}
+
+	certreq.Params = nil
+	out = &admin.RetrieveArchivedCertReqReply{}
+	if out.CertReq, err = wire.Rewire(certreq); err != nil {
+		sentry.Error(c).Err(err).Str("certreq_id", certreqID).Msg("could not serialize archived certificate request")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not serialize archived certificate request"))
+		return
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+// ListEmails returns a paginated list of the emails in the outbox and their delivery
+// status, optionally filtered by status (e.g. dead_letter) and by the VASP the emails
+// were sent about, so that admins can find emails that could not be delivered.
+func (s *Admin) ListEmails(c *gin.Context) {
+	var (
+		err    error
+		in     *admin.ListEmailsParams
+		out    *admin.ListEmailsReply
+		status models.EmailState
+	)
+
+	in = new(admin.ListEmailsParams)
+	if err = c.ShouldBindQuery(&in); err != nil {
+		sentry.Warn(c).Err(err).Msg("could not bind request with query params")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
+		return
+	}
+
+	if in.Status != "" {
+		if status, err = models.ParseEmailState(in.Status); err != nil {
+			c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
+			return
+		}
+	}
+
+	// Set pagination defaults if not specified in query
+	if in.Page <= 0 {
+		in.Page = 1
+	}
+	if in.PageSize <= 0 {
+		in.PageSize = 100
+	}
+
+	// Determine pagination index range (indexed by 1)
+	minIndex := (in.Page - 1) * in.PageSize
+	maxIndex := minIndex + in.PageSize
+
+	out = &admin.ListEmailsReply{
+		Emails:   make([]admin.EmailSnippet, 0),
+		Page:     in.Page,
+		PageSize: in.PageSize,
+	}
+
+	ctx, cancel := utils.WithDeadline(context.Background())
+	defer cancel()
+
+	for _, email := range s.db.ListEmails(ctx) {
+		if in.Status != "" && email.Status != status {
+			continue
+		}
+
+		if in.VASP != "" && email.Vasp != in.VASP {
+			continue
+		}
+
+		if out.Count >= minIndex && out.Count < maxIndex {
+			out.Emails = append(out.Emails, emailSnippet(email))
+		}
+		out.Count++
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+// RetryEmail requeues an email in the outbox that has not been delivered, e.g. one in
+// the dead letter state, and immediately attempts to deliver it again.
+func (s *Admin) RetryEmail(c *gin.Context) {
+	var (
+		err   error
+		email *models.Email
+	)
+
+	if s.svc.outbox == nil || !s.svc.conf.Outbox.Enabled {
+		c.JSON(http.StatusServiceUnavailable, admin.ErrorResponse("email outbox is not enabled"))
+		return
+	}
+
+	emailID := c.Param("emailID")
+
+	ctx, cancel := utils.WithDeadline(context.Background())
+	defer cancel()
+
+	if email, err = s.svc.outbox.Retry(ctx, emailID); err != nil {
+		switch {
+		case errors.Is(err, storeerrors.ErrEntityNotFound):
+			c.JSON(http.StatusNotFound, admin.ErrorResponse("could not find email by ID"))
+		case errors.Is(err, models.ErrEmailDelivered):
+			c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
+		default:
+			sentry.Error(c).Err(err).Str("email_id", emailID).Msg("could not retry email")
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not retry email"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, &admin.RetryEmailReply{Email: emailSnippet(email)})
+}
+
+// Helper to create a summary of the delivery status of an outbox email.
+func emailSnippet(email *models.Email) admin.EmailSnippet {
+	return admin.EmailSnippet{
+		ID:          email.Id,
+		VASP:        email.Vasp,
+		Reason:      email.Reason,
+		Subject:     email.Subject,
+		Recipient:   email.Recipient,
+		Status:      email.Status.String(),
+		Attempts:    email.Attempts,
+		LastError:   email.LastError,
+		NextAttempt: email.NextAttempt,
+		Created:     email.Created,
+		Delivered:   email.Delivered,
+	}
+}
+
+// RevokeCertificate revokes a certificate issued to the VASP with the certificate
+// authority via the certificate manager, which also marks the identity certificate of
+// the VASP as revoked so that the revocation is reported to counterparties.
+func (s *Admin) RevokeCertificate(c *gin.Context) {
+	var (
+		err    error
+		in     *admin.RevokeCertificateRequest
+		out    *admin.RevokeCertificateReply
+		reason sectigo.CRLReason
+		cert   *models.Certificate
+		vasp   *pb.VASP
+		vaspID string
+	)
+
+	// Get vaspID from the URL
+	vaspID = c.Param("vaspID")
+
+	// Parse incoming JSON data from the client request
+	in = new(admin.RevokeCertificateRequest)
+	if err := c.ShouldBind(&in); err != nil {
+		sentry.Warn(c).Err(err).Msg("could not bind request")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
+		return
+	}
+
+	// Validate revoke request
+	if in.ID != "" && in.ID != vaspID {
+		sentry.Warn(c).Str("id", in.ID).Str("vasp_id", vaspID).Msg("mismatched request ID and URL")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the request ID does not match the URL endpoint"))
+		return
+	}
+
+	if reason, err = sectigo.RevokeReasonCode(in.Reason); err != nil {
+		sentry.Warn(c).Err(err).Str("reason", in.Reason).Msg("invalid CRL reason")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(fmt.Errorf("%q is not a valid CRL reason", in.Reason)))
+		return
+	}
+
+	// Retrieve user claims for access to provided user info
+	var claims *tokens.Claims
+	if claims, err = s.getClaims(c); err != nil {
+		sentry.Error(c).Err(err).Msg("could not retrieve user claims")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("unable to retrieve user info"))
+		return
+	}
+
+	ctx, cancel := utils.WithDeadline(context.Background())
+	defer cancel()
+
+	if cert, err = s.svc.certman.RevokeCertificate(ctx, vaspID, strings.ToUpper(in.SerialNumber), reason, claims.Email); err != nil {
+		switch {
+		case errors.Is(err, storeerrors.ErrEntityNotFound):
+			sentry.Warn(c).Err(err).Str("id", vaspID).Msg("could not retrieve vasp")
+			c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
+		case errors.Is(err, certman.ErrCertificateNotFound), errors.Is(err, certman.ErrNoCertificate):
+			sentry.Warn(c).Err(err).Str("id", vaspID).Str("serial_number", in.SerialNumber).Msg("could not find certificate to revoke")
+			c.JSON(http.StatusNotFound, admin.ErrorResponse(err))
+		case errors.Is(err, certman.ErrAlreadyRevoked):
+			sentry.Warn(c).Err(err).Str("id", vaspID).Str("serial_number", in.SerialNumber).Msg("certificate already revoked")
+			c.JSON(http.StatusConflict, admin.ErrorResponse(err))
+		case errors.Is(err, certman.ErrDisabled):
+			sentry.Warn(c).Err(err).Msg("cannot revoke certificates when certman is disabled")
+			c.JSON(http.StatusServiceUnavailable, admin.ErrorResponse(err))
+		default:
+			sentry.Error(c).Err(err).Str("id", vaspID).Str("serial_number", in.SerialNumber).Msg("could not revoke certificate")
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not revoke certificate"))
+		}
+		return
+	}
+
+	// Retrieve the VASP to report the updated verification status
+	if vasp, err = s.db.RetrieveVASP(ctx, vaspID); err != nil {
+		sentry.Error(c).Err(err).Str("id", vaspID).Msg("could not retrieve vasp after revocation")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not retrieve VASP record by ID"))
+		return
+	}
+
+	out = &admin.RevokeCertificateReply{
+		Certificate: admin.Certificate{
+			SerialNumber: cert.Id,
+			Status:       cert.Status.String(),
+			RevokedOn:    cert.RevokedOn,
+		},
+		VerificationStatus: vasp.VerificationStatus.String(),
+		Message:            fmt.Sprintf("certificate %s revoked: %s", cert.Id, reason),
+	}
+
+	if cert.Details != nil {
+		out.Certificate.IssuedAt = cert.Details.NotBefore
+		out.Certificate.ExpiresAt = cert.Details.NotAfter
+		if out.Certificate.Details, err = wire.Rewire(cert.Details); err != nil {
+			sentry.Error(c).Err(err).Str("cert_id", cert.Id).Msg("could not serialize certificate details")
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not serialize certificate details"))
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
 // ReplaceContact completely replaces a contact on a VASP with a new contact.
 func (s *Admin) ReplaceContact(c *gin.Context) {
 	var (
@@ -1579,6 +1995,21 @@ func (s *Admin) ReplaceContact(c *gin.Context) {
 		return
 	}
 
+	// The preferred locale of the contact is stored in the GDS extra data rather than
+	// the TRISA contact record so it must be removed before the contact is unmarshaled.
+	var locale string
+	value, updateLocale := in.Contact["locale"]
+	if updateLocale {
+		delete(in.Contact, "locale")
+		if locale, _ = value.(string); locale != "" {
+			if locale, err = emails.NormalizeLocale(locale); err != nil {
+				sentry.Warn(c).Err(err).Msg("invalid contact locale")
+				c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
+				return
+			}
+		}
+	}
+
 	// Remarshal the JSON contact data
 	update := &pb.Contact{}
 	if err = wire.Unwire(in.Contact, update); err != nil {
@@ -1620,6 +2051,14 @@ func (s *Admin) ReplaceContact(c *gin.Context) {
 		}
 	}
 
+	if updateLocale {
+		if err = models.SetContactLocale(contact, locale); err != nil {
+			sentry.Error(c).Err(err).Msg("could not set contact locale")
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update locale for the indicated contact"))
+			return
+		}
+	}
+
 	// New VASP record must be valid
 	if err = models.ValidateVASP(vasp, true); err != nil {
 		sentry.Warn(c).Err(err).Msg("invalid VASP record after update")
@@ -1643,6 +2082,7 @@ func (s *Admin) ReplaceContact(c *gin.Context) {
 			EmailLog: make([]*models.EmailLogEntry, 0),
 		}
 		cmodel.Token, cmodel.Verified, _ = models.GetContactVerification(contact)
+		cmodel.Locale, _ = models.GetContactLocale(contact)
 
 		if err = s.svc.email.SendVerifyContact(vasp, cmodel); err != nil {
 			sentry.Error(c).Err(err).Msg("could not send verification email")
@@ -2108,39 +2548,56 @@ func (s *Admin) Review(c *gin.Context) {
 		return
 	}
 
+	// The VASP and its certificate requests are committed in a single transaction
+	var tx txn.Txn
+	if tx, err = s.db.Begin(ctx); err != nil {
+		sentry.Error(c).Err(err).Msg("could not begin review transaction")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP record"))
+		return
+	}
+	defer tx.Rollback()
+
 	// Accept or reject the request
 	out = &admin.ReviewReply{}
 	logctx := sentry.With(c).Str("vaspID", vasp.Id)
 
 	if in.Accept {
-		if out.Message, err = s.acceptRegistration(vasp, claims, logctx); err != nil {
+		if out.Message, err = s.acceptRegistration(tx, vasp, claims, logctx); err != nil {
 			sentry.Error(c).Err(err).Msg("could not accept VASP registration")
 			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("unable to accept VASP registration request"))
 			return
 		}
 	} else {
-		if out.Message, err = s.rejectRegistration(vasp, in.RejectReason, claims, logctx); err != nil {
+		if out.Message, err = s.rejectRegistration(tx, vasp, in.RejectReason, claims, logctx); err != nil {
 			sentry.Error(c).Err(err).Msg("could not reject VASP registration")
 			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("unable to reject VASP registration request"))
 			return
 		}
 	}
 
-	// Persist the VASP record to the database
-	if err = s.db.UpdateVASP(ctx, vasp); err != nil {
+	// Persist the VASP record and its certificate requests to the database
+	if err = tx.UpdateVASP(vasp); err != nil {
 		sentry.Error(c).Err(err).Msg("error updating VASP record")
 		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP record"))
 		return
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		sentry.Error(c).Err(err).Msg("could not commit VASP review")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP record"))
+		return
+	}
+
 	name, _ := vasp.Name()
 	out.Status = vasp.VerificationStatus.String()
 	log.Info().Str("vasp", vasp.Id).Str("name", name).Bool("accepted", in.Accept).Msg("registration reviewed")
 	c.JSON(http.StatusOK, out)
 }
 
-// Accept the VASP registration and begin the certificate issuance process.
-func (s *Admin) acceptRegistration(vasp *pb.VASP, claims *tokens.Claims, logctx *sentry.Logger) (msg string, err error) {
+// Accept the VASP registration and begin the certificate issuance process. The changes
+// to the certificate requests are added to the transaction but the VASP is not, the
+// caller must add the VASP to the transaction and commit it.
+func (s *Admin) acceptRegistration(tx txn.Txn, vasp *pb.VASP, claims *tokens.Claims, logctx *sentry.Logger) (msg string, err error) {
 	ctx, cancel := utils.WithDeadline(context.Background())
 	defer cancel()
 
@@ -2152,9 +2609,6 @@ func (s *Admin) acceptRegistration(vasp *pb.VASP, claims *tokens.Claims, logctx
 	if err := models.UpdateVerificationStatus(vasp, pb.VerificationState_REVIEWED, "registration request received", claims.Email); err != nil {
 		return "", err
 	}
-	if err = s.db.UpdateVASP(ctx, vasp); err != nil {
-		return "", err
-	}
 
 	// Mark any initialized certificate requests for this VASP as ready to submit
 	// NOTE: there should only be one certificate request per VASP, but no errors occur
@@ -2185,7 +2639,7 @@ func (s *Admin) acceptRegistration(vasp *pb.VASP, claims *tokens.Claims, logctx
 			if err = models.UpdateCertificateRequestStatus(careq, models.CertificateRequestState_READY_TO_SUBMIT, "registration request received", claims.Email); err != nil {
 				return "", err
 			}
-			if err = s.db.UpdateCertReq(ctx, careq); err != nil {
+			if err = tx.UpdateCertReq(careq); err != nil {
 				return "", err
 			}
 			ncertreqs++
@@ -2209,8 +2663,10 @@ func (s *Admin) acceptRegistration(vasp *pb.VASP, claims *tokens.Claims, logctx
 	return fmt.Sprintf("registration request for %s has been approved and a Sectigo certificate will be requested", name), nil
 }
 
-// Reject the VASP registration and notify the contacts of the result.
-func (s *Admin) rejectRegistration(vasp *pb.VASP, reason string, claims *tokens.Claims, logctx *sentry.Logger) (msg string, err error) {
+// Reject the VASP registration and notify the contacts of the result. The deleted
+// certificate requests are added to the transaction but the VASP is not, the caller
+// must add the VASP to the transaction and commit it.
+func (s *Admin) rejectRegistration(tx txn.Txn, vasp *pb.VASP, reason string, claims *tokens.Claims, logctx *sentry.Logger) (msg string, err error) {
 	ctx, cancel := utils.WithDeadline(context.Background())
 	defer cancel()
 
@@ -2221,9 +2677,6 @@ func (s *Admin) rejectRegistration(vasp *pb.VASP, reason string, claims *tokens.
 	if err := models.UpdateVerificationStatus(vasp, pb.VerificationState_REJECTED, "registration rejected", claims.Email); err != nil {
 		return "", err
 	}
-	if err = s.db.UpdateVASP(ctx, vasp); err != nil {
-		return "", err
-	}
 
 	// Delete all pending certificate requests
 	var (
@@ -2249,7 +2702,7 @@ func (s *Admin) rejectRegistration(vasp *pb.VASP, reason string, claims *tokens.
 		}
 
 		// Delete the certificate request
-		if err = s.db.DeleteCertReq(ctx, careq.Id); err != nil {
+		if err = tx.DeleteCertReq(careq.Id); err != nil {
 			logctx.Error().Err(err).Str("id", careq.Id).Msg("could not delete certificate request")
 			continue
 		}
@@ -2257,8 +2710,6 @@ func (s *Admin) rejectRegistration(vasp *pb.VASP, reason string, claims *tokens.
 		// Delete the VASP reference to the certificate request
 		if err = models.DeleteCertReqID(vasp, careq.Id); err != nil {
 			logctx.Error().Err(err).Str("certreq", careq.Id).Msg("could not delete certificate request ID from VASP")
-		} else if err = s.db.UpdateVASP(ctx, vasp); err != nil {
-			logctx.Error().Err(err).Msg("could not update VASP with deleted certificate request ID")
 		}
 		ncertreqs++
 	}
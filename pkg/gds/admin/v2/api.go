@@ -26,6 +26,11 @@ type DirectoryAdministrationClient interface {
 	UpdateVASP(ctx context.Context, in *UpdateVASPRequest) (out *UpdateVASPReply, err error)
 	DeleteVASP(ctx context.Context, id string) (out *Reply, err error)
 	ListCertificates(ctx context.Context, vaspID string) (out *ListCertificatesReply, err error)
+	RevokeCertificate(ctx context.Context, in *RevokeCertificateRequest) (out *RevokeCertificateReply, err error)
+	ListArchivedCertReqs(ctx context.Context, params *ListArchivedCertReqsParams) (out *ListArchivedCertReqsReply, err error)
+	RetrieveArchivedCertReq(ctx context.Context, id string) (out *RetrieveArchivedCertReqReply, err error)
+	ListEmails(ctx context.Context, params *ListEmailsParams) (out *ListEmailsReply, err error)
+	RetryEmail(ctx context.Context, id string) (out *RetryEmailReply, err error)
 	ReplaceContact(ctx context.Context, in *ReplaceContactRequest) (out *Reply, err error)
 	DeleteContact(ctx context.Context, vaspID string, kind string) (out *Reply, err error)
 	CreateReviewNote(ctx context.Context, in *ModifyReviewNoteRequest) (out *ReviewNote, err error)
@@ -155,12 +160,17 @@ type VASPSnippet struct {
 // trisacrypto/trisa library to ensure they have all of the requried data that is
 // returned. Go developers should unmarshal the data into a *pb.VASP struct.
 type RetrieveVASPReply struct {
-	Name             string                   `json:"name"`
-	VASP             map[string]interface{}   `json:"vasp"`
-	VerifiedContacts map[string]string        `json:"verified_contacts"`
-	Traveler         bool                     `json:"traveler"`
-	AuditLog         []map[string]interface{} `json:"audit_log"`
-	EmailLog         []map[string]interface{} `json:"email_log"`
+	Name               string                   `json:"name"`
+	VASP               map[string]interface{}   `json:"vasp"`
+	VerifiedContacts   map[string]string        `json:"verified_contacts"`
+	Traveler           bool                     `json:"traveler"`
+	AuditLog           []map[string]interface{} `json:"audit_log"`
+	EmailLog           []map[string]interface{} `json:"email_log"`
+	HealthCheck        map[string]interface{}   `json:"health_check,omitempty"`
+	Duplicates         []map[string]interface{} `json:"duplicates,omitempty"`
+	DomainChallenges   []map[string]interface{} `json:"domain_challenges,omitempty"`
+	ActiveCertificates []map[string]interface{} `json:"active_certificates,omitempty"`
+	RotationOverlap    string                   `json:"rotation_overlap,omitempty"`
 }
 
 // UpdateVASPRequest allows the admin to PATCH a VASP record depending on the state
@@ -211,6 +221,7 @@ type Certificate struct {
 	IssuedAt     string                 `json:"issued_at"`
 	ExpiresAt    string                 `json:"expires_at"`
 	Status       string                 `json:"status"`
+	RevokedOn    string                 `json:"revoked_on,omitempty"`
 	Details      map[string]interface{} `json:"details"`
 }
 
@@ -220,6 +231,103 @@ type ListCertificatesReply struct {
 	Certificates []Certificate `json:"certificates"`
 }
 
+// RevokeCertificateRequest revokes a certificate issued to a VASP with the certificate
+// authority. If the identity certificate of the VASP is revoked then the revocation is
+// reported to counterparties by Lookup and Verification.
+type RevokeCertificateRequest struct {
+	// The ID of the VASP the certificate was issued to (optional - is part of the URL)
+	ID string `json:"vasp_id,omitempty"`
+
+	// The serial number of the certificate to revoke; if omitted the current identity
+	// certificate of the VASP is revoked.
+	SerialNumber string `json:"serial_number,omitempty"`
+
+	// The RFC 5280 CRL reason for the revocation, e.g. "key compromise" or "cessation
+	// of operation". If omitted the reason is "unspecified".
+	Reason string `json:"reason,omitempty"`
+}
+
+// RevokeCertificateReply returns the revoked certificate and the verification status of
+// the VASP after the revocation.
+type RevokeCertificateReply struct {
+	Certificate        Certificate `json:"certificate"`
+	VerificationStatus string      `json:"verification_status"`
+	Message            string      `json:"message"`
+}
+
+// ListArchivedCertReqsParams is a request-like struct that passes query params to the
+// ListArchivedCertReqs GET request. All query params are optional.
+type ListArchivedCertReqsParams struct {
+	VASP     string `url:"vasp,omitempty" form:"vasp"`                         // only return requests made for the VASP with this ID
+	Page     int    `url:"page,omitempty" form:"page" default:"1"`             // defaults to page 1 if not included
+	PageSize int    `url:"page_size,omitempty" form:"page_size" default:"100"` // defaults to 100 if not included
+}
+
+// ListArchivedCertReqsReply contains a summary of the certificate requests that have
+// been archived along with standard pagination information.
+type ListArchivedCertReqsReply struct {
+	CertReqs []CertReqSnippet `json:"certreqs"`
+	Count    int              `json:"count"`
+	Page     int              `json:"page"`
+	PageSize int              `json:"page_size"`
+}
+
+// CertReqSnippet provides summary information about a certificate request.
+type CertReqSnippet struct {
+	ID          string `json:"id"`
+	VASP        string `json:"vasp"`
+	CommonName  string `json:"common_name"`
+	Status      string `json:"status"`
+	Certificate string `json:"certificate,omitempty"`
+	Created     string `json:"created,omitempty"`
+	Modified    string `json:"modified,omitempty"`
+}
+
+// RetrieveArchivedCertReqReply returns an archived models.CertificateRequest that has
+// been marshaled by protojson. Go developers should unmarshal the data into a
+// *models.CertificateRequest struct.
+type RetrieveArchivedCertReqReply struct {
+	CertReq map[string]interface{} `json:"certreq"`
+}
+
+// ListEmailsParams is a request-like struct that passes query params to the ListEmails
+// GET request. All query params are optional.
+type ListEmailsParams struct {
+	Status   string `url:"status,omitempty" form:"status"`                     // only return emails in the specified delivery status, e.g. dead_letter
+	VASP     string `url:"vasp,omitempty" form:"vasp"`                         // only return emails sent about the VASP with this ID
+	Page     int    `url:"page,omitempty" form:"page" default:"1"`             // defaults to page 1 if not included
+	PageSize int    `url:"page_size,omitempty" form:"page_size" default:"100"` // defaults to 100 if not included
+}
+
+// ListEmailsReply contains a summary of the emails in the outbox along with standard
+// pagination information.
+type ListEmailsReply struct {
+	Emails   []EmailSnippet `json:"emails"`
+	Count    int            `json:"count"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"page_size"`
+}
+
+// EmailSnippet provides summary information about the delivery of an outbox email.
+type EmailSnippet struct {
+	ID          string `json:"id"`
+	VASP        string `json:"vasp,omitempty"`
+	Reason      string `json:"reason"`
+	Subject     string `json:"subject"`
+	Recipient   string `json:"recipient"`
+	Status      string `json:"status"`
+	Attempts    uint32 `json:"attempts"`
+	LastError   string `json:"last_error,omitempty"`
+	NextAttempt string `json:"next_attempt,omitempty"`
+	Created     string `json:"created,omitempty"`
+	Delivered   string `json:"delivered,omitempty"`
+}
+
+// RetryEmailReply returns the delivery status of the email after it was retried.
+type RetryEmailReply struct {
+	Email EmailSnippet `json:"email"`
+}
+
 //===========================================================================
 // Contact management RPCs
 //===========================================================================
@@ -234,7 +342,9 @@ type ReplaceContactRequest struct {
 	Kind string `json:"kind"`
 
 	// The new contact to replace the existing contact with, must be marshalled into a
-	// gds.models.v1beta1.Contact protocol buffer.
+	// gds.models.v1beta1.Contact protocol buffer. The contact may also include a
+	// "locale" field with the preferred locale of the contact (e.g. ja or es-MX) that is
+	// used to localize the emails sent to the contact.
 	Contact map[string]interface{} `json:"contact,omitempty"`
 }
 
@@ -679,6 +679,230 @@ func TestListCertificates(t *testing.T) {
 	require.Equal(t, fixture, out)
 }
 
+func TestListArchivedCertReqs(t *testing.T) {
+	fixture := &admin.ListArchivedCertReqsReply{
+		CertReqs: []admin.CertReqSnippet{
+			{
+				ID:          "b2ea4ba1-c5df-4d42-9c6c-9e5a1f3b1d52",
+				VASP:        "af367d27-b0e7-48b5-8987-e48a0712a826",
+				CommonName:  "trisa.alice.us",
+				Status:      models.CertificateRequestState_COMPLETED.String(),
+				Certificate: "ABC83132333435363738",
+				Created:     "2021-08-15T12:32:41Z",
+				Modified:    "2021-08-16T09:12:03Z",
+			},
+			{
+				ID:         "e1a1cbd8-2bd8-4b1b-bb6b-8b3e1a37d3c4",
+				VASP:       "af367d27-b0e7-48b5-8987-e48a0712a826",
+				CommonName: "trisa.alice.us",
+				Status:     models.CertificateRequestState_CR_REJECTED.String(),
+				Created:    "2021-07-01T10:00:00Z",
+				Modified:   "2021-07-02T10:00:00Z",
+			},
+		},
+		Page:     2,
+		PageSize: 10,
+		Count:    12,
+	}
+
+	params := &admin.ListArchivedCertReqsParams{
+		VASP:     "af367d27-b0e7-48b5-8987-e48a0712a826",
+		Page:     2,
+		PageSize: 10,
+	}
+
+	// Create a Test Server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/v2/certreqs/archive", r.URL.Path)
+		require.Equal(t, "page=2&page_size=10&vasp=af367d27-b0e7-48b5-8987-e48a0712a826", r.URL.RawQuery)
+
+		w.Header().Add("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(fixture)
+	}))
+	defer ts.Close()
+
+	// Create a Client that makes requests to the test server
+	client, err := admin.New(ts.URL, nil)
+	require.NoError(t, err)
+
+	out, err := client.ListArchivedCertReqs(context.TODO(), params)
+	require.NoError(t, err)
+	require.Equal(t, fixture, out)
+}
+
+func TestRetrieveArchivedCertReq(t *testing.T) {
+	id := "b2ea4ba1-c5df-4d42-9c6c-9e5a1f3b1d52"
+	fixture := &admin.RetrieveArchivedCertReqReply{
+		CertReq: map[string]interface{}{
+			"id":          id,
+			"vasp":        "af367d27-b0e7-48b5-8987-e48a0712a826",
+			"common_name": "trisa.alice.us",
+			"status":      "COMPLETED",
+		},
+	}
+
+	// Create a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/v2/certreqs/archive/b2ea4ba1-c5df-4d42-9c6c-9e5a1f3b1d52", r.URL.Path)
+
+		w.Header().Add("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(fixture)
+	}))
+	defer ts.Close()
+
+	// Create a Client that makes requests to the test server
+	client, err := admin.New(ts.URL, nil)
+	require.NoError(t, err)
+
+	// Ensure a certificate request ID is required
+	_, err = client.RetrieveArchivedCertReq(context.TODO(), "")
+	require.EqualError(t, err, "request requires a valid ID to determine endpoint")
+
+	out, err := client.RetrieveArchivedCertReq(context.TODO(), id)
+	require.NoError(t, err)
+	require.Equal(t, fixture, out)
+}
+
+func TestListEmails(t *testing.T) {
+	fixture := &admin.ListEmailsReply{
+		Emails: []admin.EmailSnippet{
+			{
+				ID:        "a3f9c4e2-5b1d-4c8e-9f2a-7d6b3e1c0a94",
+				VASP:      "af367d27-b0e7-48b5-8987-e48a0712a826",
+				Reason:    "verify_contact",
+				Subject:   "TRISA: Please verify your email address",
+				Recipient: "adam@example.com",
+				Status:    models.EmailState_EMAIL_DEAD_LETTER.String(),
+				Attempts:  8,
+				LastError: "could not connect to smtp server",
+				Created:   "2021-08-15T12:32:41Z",
+			},
+		},
+		Page:     1,
+		PageSize: 10,
+		Count:    1,
+	}
+
+	params := &admin.ListEmailsParams{
+		Status:   "dead_letter",
+		VASP:     "af367d27-b0e7-48b5-8987-e48a0712a826",
+		Page:     1,
+		PageSize: 10,
+	}
+
+	// Create a Test Server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/v2/emails", r.URL.Path)
+		require.Equal(t, "page=1&page_size=10&status=dead_letter&vasp=af367d27-b0e7-48b5-8987-e48a0712a826", r.URL.RawQuery)
+
+		w.Header().Add("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(fixture)
+	}))
+	defer ts.Close()
+
+	// Create a Client that makes requests to the test server
+	client, err := admin.New(ts.URL, nil)
+	require.NoError(t, err)
+
+	out, err := client.ListEmails(context.TODO(), params)
+	require.NoError(t, err)
+	require.Equal(t, fixture, out)
+}
+
+func TestRetryEmail(t *testing.T) {
+	id := "a3f9c4e2-5b1d-4c8e-9f2a-7d6b3e1c0a94"
+	fixture := &admin.RetryEmailReply{
+		Email: admin.EmailSnippet{
+			ID:        id,
+			VASP:      "af367d27-b0e7-48b5-8987-e48a0712a826",
+			Reason:    "verify_contact",
+			Subject:   "TRISA: Please verify your email address",
+			Recipient: "adam@example.com",
+			Status:    models.EmailState_EMAIL_DELIVERED.String(),
+			Attempts:  1,
+			Created:   "2021-08-15T12:32:41Z",
+			Delivered: "2021-08-16T09:12:03Z",
+		},
+	}
+
+	// Create a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/v2/emails/a3f9c4e2-5b1d-4c8e-9f2a-7d6b3e1c0a94/retry", r.URL.Path)
+
+		w.Header().Add("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(fixture)
+	}))
+	defer ts.Close()
+
+	// Create a Client that makes requests to the test server
+	client, err := admin.New(ts.URL, nil)
+	require.NoError(t, err)
+
+	// Ensure an email ID is required
+	_, err = client.RetryEmail(context.TODO(), "")
+	require.EqualError(t, err, "request requires a valid ID to determine endpoint")
+
+	out, err := client.RetryEmail(context.TODO(), id)
+	require.NoError(t, err)
+	require.Equal(t, fixture, out)
+}
+
+func TestRevokeCertificate(t *testing.T) {
+	req := &admin.RevokeCertificateRequest{
+		ID:           "83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5",
+		SerialNumber: "ABC83132333435363738",
+		Reason:       "key compromise",
+	}
+
+	fixture := &admin.RevokeCertificateReply{
+		Certificate: admin.Certificate{
+			SerialNumber: "ABC83132333435363738",
+			Status:       models.CertificateState_REVOKED.String(),
+			RevokedOn:    time.Now().Format(time.RFC3339),
+		},
+		VerificationStatus: "REJECTED",
+		Message:            "certificate ABC83132333435363738 revoked: key compromise",
+	}
+
+	// Create a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/v2/vasps/83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5/revoke", r.URL.Path)
+
+		in := new(admin.RevokeCertificateRequest)
+		err := json.NewDecoder(r.Body).Decode(in)
+		require.NoError(t, err)
+		require.Equal(t, req, in)
+
+		w.Header().Add("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(fixture)
+	}))
+	defer ts.Close()
+
+	// Create a Client that makes requests to the test server
+	client, err := admin.New(ts.URL, nil)
+	require.NoError(t, err)
+
+	// Ensure a VASP ID is required to revoke a certificate
+	_, err = client.RevokeCertificate(context.TODO(), &admin.RevokeCertificateRequest{})
+	require.EqualError(t, err, "request requires a valid ID to determine endpoint")
+
+	// Correctly formatted request
+	out, err := client.RevokeCertificate(context.TODO(), req)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.Equal(t, fixture, out)
+}
+
 func TestCreateReviewNote(t *testing.T) {
 	req := &admin.ModifyReviewNoteRequest{
 		VASP: "83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5",
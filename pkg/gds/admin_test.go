@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,14 +17,18 @@ import (
 	jwt "github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/gds"
 	admin "github.com/trisacrypto/directory/pkg/gds/admin/v2"
+	"github.com/trisacrypto/directory/pkg/gds/config"
 	"github.com/trisacrypto/directory/pkg/gds/emails"
 	"github.com/trisacrypto/directory/pkg/gds/fixtures"
 	"github.com/trisacrypto/directory/pkg/gds/tokens"
 	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/sectigo"
 	"github.com/trisacrypto/directory/pkg/utils/emails/mock"
 	"github.com/trisacrypto/directory/pkg/utils/wire"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/protobuf/proto"
 )
 
 // httpRequest is a helper struct to make it easier to organize all the different
@@ -707,40 +712,46 @@ func (s *gdsTestSuite) TestRetrieveVASP() {
 		},
 		EmailLog: []map[string]interface{}{
 			{
-				"reason":    "verify_contact",
-				"subject":   "TRISA: Please verify your email address",
-				"timestamp": "2021-06-17T01:24:08Z",
-				"recipient": hotel.Contacts.Legal.Email,
+				"message_id": "",
+				"reason":     "verify_contact",
+				"subject":    "TRISA: Please verify your email address",
+				"timestamp":  "2021-06-17T01:24:08Z",
+				"recipient":  hotel.Contacts.Legal.Email,
 			},
 			{
-				"reason":    "verify_contact",
-				"subject":   "TRISA: Please verify your email address",
-				"timestamp": "2021-06-26T15:53:51Z",
-				"recipient": hotel.Contacts.Technical.Email,
+				"message_id": "",
+				"reason":     "verify_contact",
+				"subject":    "TRISA: Please verify your email address",
+				"timestamp":  "2021-06-26T15:53:51Z",
+				"recipient":  hotel.Contacts.Technical.Email,
 			},
 			{
-				"reason":    "deliver_certs",
-				"subject":   "Welcome to the TRISA network!",
-				"timestamp": "2021-08-19T15:47:59Z",
-				"recipient": hotel.Contacts.Legal.Email,
+				"message_id": "",
+				"reason":     "deliver_certs",
+				"subject":    "Welcome to the TRISA network!",
+				"timestamp":  "2021-08-19T15:47:59Z",
+				"recipient":  hotel.Contacts.Legal.Email,
 			},
 			{
-				"reason":    "reissuance_reminder",
-				"subject":   "TRISA Identity Certificate Expiration",
-				"timestamp": "2021-09-03T07:06:22Z",
-				"recipient": hotel.Contacts.Legal.Email,
+				"message_id": "",
+				"reason":     "reissuance_reminder",
+				"subject":    "TRISA Identity Certificate Expiration",
+				"timestamp":  "2021-09-03T07:06:22Z",
+				"recipient":  hotel.Contacts.Legal.Email,
 			},
 			{
-				"reason":    "deliver_certs",
-				"subject":   "Welcome to the TRISA network!",
-				"timestamp": "2021-09-12T11:41:09Z",
-				"recipient": hotel.Contacts.Technical.Email,
+				"message_id": "",
+				"reason":     "deliver_certs",
+				"subject":    "Welcome to the TRISA network!",
+				"timestamp":  "2021-09-12T11:41:09Z",
+				"recipient":  hotel.Contacts.Technical.Email,
 			},
 			{
-				"reason":    "reissuance_reminder",
-				"subject":   "TRISA Identity Certificate Expiration",
-				"timestamp": "2021-10-08T12:45:17Z",
-				"recipient": hotel.Contacts.Technical.Email,
+				"message_id": "",
+				"reason":     "reissuance_reminder",
+				"subject":    "TRISA Identity Certificate Expiration",
+				"timestamp":  "2021-10-08T12:45:17Z",
+				"recipient":  hotel.Contacts.Technical.Email,
 			},
 		},
 	}
@@ -781,6 +792,104 @@ func (s *gdsTestSuite) TestRetrieveVASP() {
 	require.Equal(expected, actual)
 }
 
+func (s *gdsTestSuite) TestRetrieveVASPDomainChallenges() {
+	s.LoadFullFixtures()
+	defer s.ResetFixtures()
+	require := s.Require()
+	a := s.svc.GetAdmin()
+	ctx := context.Background()
+
+	// Create domain challenges on the latest certificate request of the echo VASP
+	echo, err := s.fixtures.GetVASP("echo")
+	require.NoError(err)
+	certreqID, err := models.GetLatestCertReqID(echo)
+	require.NoError(err)
+	require.NotEmpty(certreqID, "echo fixture has no certificate requests")
+
+	db := s.svc.GetStore()
+	certreq, err := db.RetrieveCertReq(ctx, certreqID)
+	require.NoError(err)
+	require.NoError(models.CreateDomainChallenges(certreq))
+	certreq.Challenges[0].Status = models.DomainChallengeState_CHALLENGE_VALID
+	certreq.Challenges[0].ValidatedBy = models.ChallengeHTTP01
+	require.NoError(db.UpdateCertReq(ctx, certreq))
+
+	// The domain challenges should be returned with the VASP detail
+	request := &httpRequest{
+		method: http.MethodGet,
+		path:   "/v2/vasps/" + echo.Id,
+		params: map[string]string{
+			"vaspID": echo.Id,
+		},
+	}
+	actual := &admin.RetrieveVASPReply{}
+	c, w := s.makeRequest(request)
+	rep := s.doRequest(a.RetrieveVASP, c, w, actual)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Len(actual.DomainChallenges, len(certreq.Challenges))
+
+	for i, challenge := range certreq.Challenges {
+		require.Equal(challenge.Domain, actual.DomainChallenges[i]["domain"])
+		require.Equal(challenge.Token, actual.DomainChallenges[i]["token"])
+	}
+	require.Equal(models.DomainChallengeState_CHALLENGE_VALID.String(), actual.DomainChallenges[0]["status"])
+	require.Equal(models.ChallengeHTTP01, actual.DomainChallenges[0]["validated_by"])
+	require.Equal(models.DomainChallengeState_CHALLENGE_PENDING.String(), actual.DomainChallenges[1]["status"])
+}
+
+func (s *gdsTestSuite) TestRetrieveVASPActiveCertificates() {
+	s.LoadFullFixtures()
+	defer s.ResetFixtures()
+	require := s.Require()
+	a := s.svc.GetAdmin()
+	ctx := context.Background()
+
+	// Rotate the identity certificate of the echo VASP
+	db := s.svc.GetStore()
+	fixture, err := s.fixtures.GetVASP("echo")
+	require.NoError(err)
+	echo, err := db.RetrieveVASP(ctx, fixture.Id)
+	require.NoError(err)
+
+	now := time.Now()
+	echo.IdentityCertificate = &pb.Certificate{
+		SerialNumber: []byte{0x01},
+		NotBefore:    now.AddDate(-1, 0, 0).Format(time.RFC3339),
+		NotAfter:     now.AddDate(0, 6, 0).Format(time.RFC3339),
+	}
+	echo.SigningCertificates = nil
+	next := &pb.Certificate{
+		SerialNumber: []byte{0x02},
+		NotBefore:    now.Format(time.RFC3339),
+		NotAfter:     now.AddDate(1, 0, 0).Format(time.RFC3339),
+	}
+	require.NoError(models.RotateCertificate(echo, models.CertificateUsageIdentity, next, s.svc.GetConf().CertMan.RotationOverlap))
+	require.NoError(db.UpdateVASP(ctx, echo))
+
+	// Both certificates should be returned with the VASP detail
+	request := &httpRequest{
+		method: http.MethodGet,
+		path:   "/v2/vasps/" + echo.Id,
+		params: map[string]string{
+			"vaspID": echo.Id,
+		},
+	}
+	actual := &admin.RetrieveVASPReply{}
+	c, w := s.makeRequest(request)
+	rep := s.doRequest(a.RetrieveVASP, c, w, actual)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal(s.svc.GetConf().CertMan.RotationOverlap.String(), actual.RotationOverlap)
+	require.Len(actual.ActiveCertificates, 2)
+
+	require.Equal("01", actual.ActiveCertificates[0]["id"])
+	require.Equal(models.CertificateUsageIdentity, actual.ActiveCertificates[0]["usage"])
+	require.Equal(false, actual.ActiveCertificates[0]["primary"], "previous certificate should not be primary")
+	require.Equal("02", actual.ActiveCertificates[1]["id"])
+	require.Equal(true, actual.ActiveCertificates[1]["primary"])
+	require.Equal(next.NotAfter, actual.ActiveCertificates[1]["not_after"])
+	require.NotContains(actual.ActiveCertificates[1], "details")
+}
+
 func (s *gdsTestSuite) TestUpdateVASP() {
 	require := s.Require()
 	s.LoadSmallFixtures()
@@ -999,6 +1108,378 @@ func (s *gdsTestSuite) TestListCertificates() {
 	require.ElementsMatch(certificates, actual.Certificates)
 }
 
+// Test that the archived certificate request endpoints return the certificate requests
+// that have been moved to the archive.
+func (s *gdsTestSuite) TestArchivedCertReqs() {
+	s.LoadFullFixtures()
+	defer s.ResetFixtures()
+
+	require := s.Require()
+	a := s.svc.GetAdmin()
+	ctx := context.Background()
+
+	xray, err := s.fixtures.GetCertReq("xray")
+	require.NoError(err, "could not get xray cert request")
+	quebec, err := s.fixtures.GetCertReq("quebec")
+	require.NoError(err, "could not get quebec cert request")
+	require.NotEqual(xray.Vasp, quebec.Vasp, "fixtures should be for different VASPs")
+
+	// No certificate requests have been archived
+	request := &httpRequest{
+		method: http.MethodGet,
+		path:   "/v2/certreqs/archive",
+		claims: &tokens.Claims{
+			Email: "admin@example.com",
+		},
+	}
+	list := &admin.ListArchivedCertReqsReply{}
+	c, w := s.makeRequest(request)
+	rep := s.doRequest(a.ListArchivedCertReqs, c, w, list)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Empty(list.CertReqs)
+	require.Equal(0, list.Count)
+	require.Equal(1, list.Page)
+	require.Equal(100, list.PageSize)
+
+	// Archive the certificate requests
+	db := s.svc.GetStore()
+	require.NoError(db.ArchiveCertReq(ctx, xray.Id))
+	require.NoError(db.ArchiveCertReq(ctx, quebec.Id))
+
+	// Archived certificate requests should still be included in the summary
+	summary := &admin.SummaryReply{}
+	c, w = s.makeRequest(&httpRequest{method: http.MethodGet, path: "/v2/summary"})
+	rep = s.doRequest(a.Summary, c, w, summary)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal(3, summary.CertificatesIssued)
+	require.Equal(3, summary.CertReqs[models.CertificateRequestState_INITIALIZED.String()])
+
+	list = &admin.ListArchivedCertReqsReply{}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.ListArchivedCertReqs, c, w, list)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Len(list.CertReqs, 2)
+	require.Equal(2, list.Count)
+
+	// Filter the archived certificate requests by VASP
+	request.path = "/v2/certreqs/archive?vasp=" + xray.Vasp
+	list = &admin.ListArchivedCertReqsReply{}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.ListArchivedCertReqs, c, w, list)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal(1, list.Count)
+	require.Equal([]admin.CertReqSnippet{
+		{
+			ID:          xray.Id,
+			VASP:        xray.Vasp,
+			CommonName:  xray.CommonName,
+			Status:      xray.Status.String(),
+			Certificate: xray.Certificate,
+			Created:     xray.Created,
+			Modified:    xray.Modified,
+		},
+	}, list.CertReqs)
+
+	// Pages beyond the count should be empty
+	request.path = "/v2/certreqs/archive?page=2&page_size=1"
+	list = &admin.ListArchivedCertReqsReply{}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.ListArchivedCertReqs, c, w, list)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Len(list.CertReqs, 1)
+	require.Equal(2, list.Count)
+
+	request.path = "/v2/certreqs/archive?page=3&page_size=1"
+	list = &admin.ListArchivedCertReqsReply{}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.ListArchivedCertReqs, c, w, list)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Empty(list.CertReqs)
+
+	// Attempt to retrieve a certificate request that has not been archived
+	request.path = "/v2/certreqs/archive/invalid"
+	request.params = map[string]string{"certreqID": "invalid"}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RetrieveArchivedCertReq, c, w, nil)
+	s.APIError(http.StatusNotFound, "could not find archived certificate request by ID", rep)
+
+	// Retrieve the archived certificate request without its params
+	request.path = "/v2/certreqs/archive/" + xray.Id
+	request.params["certreqID"] = xray.Id
+	actual := &admin.RetrieveArchivedCertReqReply{}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RetrieveArchivedCertReq, c, w, actual)
+	require.Equal(http.StatusOK, rep.StatusCode)
+
+	expected := proto.Clone(xray).(*models.CertificateRequest)
+	expected.Params = nil
+	data, err := wire.Rewire(expected)
+	require.NoError(err)
+	require.Equal(data, actual.CertReq)
+	require.Empty(actual.CertReq["params"], "params should not be returned since they may contain secrets")
+}
+
+// Test that admins can list the emails in the outbox and retry dead letters.
+func (s *gdsTestSuite) TestEmailOutbox() {
+	require := s.Require()
+	request := &httpRequest{
+		method: http.MethodPost,
+		path:   "/v2/emails/invalid/retry",
+		params: map[string]string{"emailID": "invalid"},
+		claims: &tokens.Claims{
+			Email: "admin@example.com",
+		},
+	}
+
+	// Emails cannot be retried if the outbox is disabled
+	s.LoadEmptyFixtures()
+	c, w := s.makeRequest(request)
+	rep := s.doRequest(s.svc.GetAdmin().RetryEmail, c, w, nil)
+	s.APIError(http.StatusServiceUnavailable, "email outbox is not enabled", rep)
+
+	conf := gds.MockConfig()
+	conf.Outbox = config.OutboxConfig{Enabled: true, Interval: time.Minute, MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Hour}
+	s.SetConfig(conf)
+	defer s.ResetConfig()
+	s.LoadEmptyFixtures()
+	defer mock.PurgeEmails()
+
+	a := s.svc.GetAdmin()
+	db := s.svc.GetStore()
+	ctx := context.Background()
+
+	// Create a delivered email and a dead letter for different VASPs
+	msg, err := emails.NewDraft("reject_registration", "Alice", "alice@example.com", emails.RejectRegistrationData{Name: "Alice", Reason: "testing the outbox"})
+	require.NoError(err)
+	data, err := msg.Marshal()
+	require.NoError(err)
+
+	delivered, err := models.NewEmail(string(admin.ResendReview), msg.Subject, "alice@example.com", "b5841869-105f-411c-8722-4045aad72717", data)
+	require.NoError(err)
+	delivered.Status = models.EmailState_EMAIL_DELIVERED
+	delivered.Attempts = 1
+	_, err = db.CreateEmail(ctx, delivered)
+	require.NoError(err)
+
+	deadLetter, err := models.NewEmail(string(admin.ResendVerifyContact), msg.Subject, "alice@example.com", "d9da630e-41aa-11ed-a18a-acde48001122", data)
+	require.NoError(err)
+	deadLetter.Status = models.EmailState_EMAIL_DEAD_LETTER
+	deadLetter.Attempts = 3
+	deadLetter.LastError = "connection refused"
+	deadLetter.NextAttempt = ""
+	_, err = db.CreateEmail(ctx, deadLetter)
+	require.NoError(err)
+
+	// List all of the emails in the outbox
+	list := &admin.ListEmailsReply{}
+	c, w = s.makeRequest(&httpRequest{method: http.MethodGet, path: "/v2/emails"})
+	rep = s.doRequest(a.ListEmails, c, w, list)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Len(list.Emails, 2)
+	require.Equal(2, list.Count)
+	require.Equal(1, list.Page)
+	require.Equal(100, list.PageSize)
+
+	// Filter the emails by status
+	list = &admin.ListEmailsReply{}
+	c, w = s.makeRequest(&httpRequest{method: http.MethodGet, path: "/v2/emails?status=dead_letter"})
+	rep = s.doRequest(a.ListEmails, c, w, list)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal(1, list.Count)
+	require.Equal(admin.EmailSnippet{
+		ID:        deadLetter.Id,
+		VASP:      deadLetter.Vasp,
+		Reason:    deadLetter.Reason,
+		Subject:   deadLetter.Subject,
+		Recipient: deadLetter.Recipient,
+		Status:    models.EmailState_EMAIL_DEAD_LETTER.String(),
+		Attempts:  3,
+		LastError: "connection refused",
+		Created:   deadLetter.Created,
+	}, list.Emails[0])
+
+	// Filter the emails by VASP
+	list = &admin.ListEmailsReply{}
+	c, w = s.makeRequest(&httpRequest{method: http.MethodGet, path: "/v2/emails?vasp=" + delivered.Vasp})
+	rep = s.doRequest(a.ListEmails, c, w, list)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal(1, list.Count)
+	require.Equal(delivered.Id, list.Emails[0].ID)
+
+	// Invalid statuses are rejected
+	c, w = s.makeRequest(&httpRequest{method: http.MethodGet, path: "/v2/emails?status=lost"})
+	rep = s.doRequest(a.ListEmails, c, w, nil)
+	s.APIError(http.StatusBadRequest, `unknown email state "EMAIL_LOST"`, rep)
+
+	// Attempt to retry an email that does not exist
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RetryEmail, c, w, nil)
+	s.APIError(http.StatusNotFound, "could not find email by ID", rep)
+
+	// Delivered emails cannot be retried
+	request.path = "/v2/emails/" + delivered.Id + "/retry"
+	request.params["emailID"] = delivered.Id
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RetryEmail, c, w, nil)
+	s.APIError(http.StatusBadRequest, models.ErrEmailDelivered.Error(), rep)
+
+	// Retry the dead letter, which should be delivered by the mock email client
+	mock.PurgeEmails()
+	request.path = "/v2/emails/" + deadLetter.Id + "/retry"
+	request.params["emailID"] = deadLetter.Id
+	reply := &admin.RetryEmailReply{}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RetryEmail, c, w, reply)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal(deadLetter.Id, reply.Email.ID)
+	require.Equal(models.EmailState_EMAIL_DELIVERED.String(), reply.Email.Status)
+	require.Equal(uint32(1), reply.Email.Attempts)
+	require.Empty(reply.Email.LastError)
+	require.NotEmpty(reply.Email.Delivered)
+	require.Len(mock.Emails, 1)
+
+	email, err := db.RetrieveEmail(ctx, deadLetter.Id)
+	require.NoError(err)
+	require.Equal(models.EmailState_EMAIL_DELIVERED, email.Status)
+}
+
+// Test the RevokeCertificate endpoint
+func (s *gdsTestSuite) TestRevokeCertificate() {
+	s.LoadFullFixtures()
+	defer s.ResetFixtures()
+
+	require := s.Require()
+	a := s.svc.GetAdmin()
+	db := s.svc.GetStore()
+
+	hotel, err := s.fixtures.GetVASP("hotel")
+	require.NoError(err, "could not get hotel VASP")
+	uniform, err := s.fixtures.GetCert("uniform")
+	require.NoError(err, "could not get uniform certificate")
+	zulu, err := s.fixtures.GetCert("zulu")
+	require.NoError(err, "could not get zulu certificate")
+
+	// Attempt to revoke a certificate for a VASP that doesn't exist
+	request := &httpRequest{
+		method: http.MethodPost,
+		path:   "/v2/vasps/invalid/revoke",
+		in:     &admin.RevokeCertificateRequest{},
+		params: map[string]string{
+			"vaspID": "invalid",
+		},
+		claims: &tokens.Claims{
+			Email: "admin@example.com",
+		},
+	}
+	c, w := s.makeRequest(request)
+	rep := s.doRequest(a.RevokeCertificate, c, w, nil)
+	s.APIError(http.StatusNotFound, "could not retrieve VASP record by ID", rep)
+
+	// Mismatched VASP ID in the request
+	request.path = "/v2/vasps/" + hotel.Id + "/revoke"
+	request.params["vaspID"] = hotel.Id
+	request.in = &admin.RevokeCertificateRequest{ID: "invalid"}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RevokeCertificate, c, w, nil)
+	s.APIError(http.StatusBadRequest, "the request ID does not match the URL endpoint", rep)
+
+	// Invalid CRL reason
+	request.in = &admin.RevokeCertificateRequest{Reason: "because"}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RevokeCertificate, c, w, nil)
+	s.APIError(http.StatusBadRequest, `"because" is not a valid CRL reason`, rep)
+
+	// Certificate that does not belong to the VASP
+	request.in = &admin.RevokeCertificateRequest{SerialNumber: "ABCDEF", Reason: "superseded"}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RevokeCertificate, c, w, nil)
+	s.APIError(http.StatusNotFound, "certificate not found for VASP", rep)
+
+	// Certificate that has already been revoked
+	request.in = &admin.RevokeCertificateRequest{SerialNumber: zulu.Id, Reason: "superseded"}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RevokeCertificate, c, w, nil)
+	s.APIError(http.StatusConflict, "certificate has already been revoked", rep)
+
+	// Revoking a certificate that is not the identity certificate does not change the
+	// verification status of the VASP; serial numbers are not case sensitive.
+	request.in = &admin.RevokeCertificateRequest{SerialNumber: strings.ToLower(uniform.Id), Reason: "superseded"}
+	actual := &admin.RevokeCertificateReply{}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RevokeCertificate, c, w, actual)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal(uniform.Id, actual.Certificate.SerialNumber)
+	require.Equal(models.CertificateState_REVOKED.String(), actual.Certificate.Status)
+	require.NotEmpty(actual.Certificate.RevokedOn)
+	require.Equal(pb.VerificationState_VERIFIED.String(), actual.VerificationStatus)
+
+	cert, err := db.RetrieveCert(context.Background(), uniform.Id)
+	require.NoError(err, "could not retrieve certificate")
+	require.Equal(models.CertificateState_REVOKED, cert.Status)
+	require.Equal(int32(sectigo.CRLRSuperseded), cert.RevocationReason)
+	require.True(cert.Details.Revoked)
+
+	// Revoking the identity certificate of the VASP keeps the VASP verified so that the
+	// revocation is reported to counterparties
+	request.in = &admin.RevokeCertificateRequest{Reason: "key compromise"}
+	actual = &admin.RevokeCertificateReply{}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RevokeCertificate, c, w, actual)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal(models.GetCertID(hotel.IdentityCertificate), actual.Certificate.SerialNumber)
+	require.Equal(pb.VerificationState_VERIFIED.String(), actual.VerificationStatus)
+
+	vasp, err := db.RetrieveVASP(context.Background(), hotel.Id)
+	require.NoError(err, "could not retrieve VASP")
+	require.Equal(pb.VerificationState_VERIFIED, vasp.VerificationStatus)
+	require.True(vasp.IdentityCertificate.Revoked)
+	revokedOn, err := models.GetRevokedOn(vasp)
+	require.NoError(err, "could not get revoked on timestamp")
+	require.Equal(actual.Certificate.RevokedOn, revokedOn)
+
+	ids, err := models.GetCertIDs(vasp)
+	require.NoError(err, "could not get certificate IDs")
+	require.Contains(ids, actual.Certificate.SerialNumber)
+
+	cert, err = db.RetrieveCert(context.Background(), actual.Certificate.SerialNumber)
+	require.NoError(err, "could not retrieve identity certificate record")
+	require.Equal(models.CertificateState_REVOKED, cert.Status)
+	require.Equal(int32(sectigo.CRLRKeyCompromise), cert.RevocationReason)
+
+	auditLog, err := models.GetAuditLog(vasp)
+	require.NoError(err, "could not get audit log")
+	entry := auditLog[len(auditLog)-1]
+	require.Equal(pb.VerificationState_VERIFIED, entry.PreviousState)
+	require.Equal(pb.VerificationState_VERIFIED, entry.CurrentState)
+	require.Equal("identity certificate revoked: key compromise", entry.Description)
+	require.Equal("admin@example.com", entry.Source)
+
+	// If the VASP record was not updated after the certificate was revoked, revoking the
+	// certificate again should resume the revocation.
+	vasp.IdentityCertificate.Revoked = false
+	require.NoError(models.SetRevokedOn(vasp, ""))
+	require.NoError(db.UpdateVASP(context.Background(), vasp))
+
+	request.in = &admin.RevokeCertificateRequest{SerialNumber: actual.Certificate.SerialNumber}
+	resumed := &admin.RevokeCertificateReply{}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RevokeCertificate, c, w, resumed)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal(actual.Certificate.RevokedOn, resumed.Certificate.RevokedOn)
+
+	vasp, err = db.RetrieveVASP(context.Background(), hotel.Id)
+	require.NoError(err, "could not retrieve VASP")
+	require.True(vasp.IdentityCertificate.Revoked)
+	revokedOn, err = models.GetRevokedOn(vasp)
+	require.NoError(err, "could not get revoked on timestamp")
+	require.Equal(actual.Certificate.RevokedOn, revokedOn)
+
+	// Once the revocation has been applied the certificate cannot be revoked again
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RevokeCertificate, c, w, nil)
+	require.Equal(http.StatusConflict, rep.StatusCode)
+}
+
 // Test the ReplaceContact endpoint
 func (s *gdsTestSuite) TestReplaceContact() {
 	s.T().Skip("requires fix to replace contact method")
@@ -1174,6 +1655,28 @@ func (s *gdsTestSuite) TestReplaceContact() {
 		},
 	}
 	emails.CheckEmails(s.T(), messages)
+
+	// Set the preferred locale of the contact to localize the emails sent to it
+	contactRequest["locale"] = "es_MX"
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.ReplaceContact, c, w, nil)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	vasp, err = s.svc.GetStore().RetrieveVASP(context.Background(), charlieID)
+	require.NoError(err, "could not retrieve VASP record")
+	locale, err := models.GetContactLocale(vasp.Contacts.Technical)
+	require.NoError(err, "could not retrieve contact locale")
+	require.Equal("es-mx", locale)
+
+	// The verification token should be preserved when the locale is updated
+	updatedToken, _, err := models.GetContactVerification(vasp.Contacts.Technical)
+	require.NoError(err, "could not retrieve contact verification")
+	require.Equal(token, updatedToken)
+
+	// Invalid locales should be rejected
+	contactRequest["locale"] = "klingon"
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.ReplaceContact, c, w, nil)
+	s.APIError(http.StatusBadRequest, `invalid locale "klingon"`, rep)
 }
 
 // Test the DeleteContact endpoint
@@ -1,16 +1,16 @@
 package gds
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
-	"sort"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/trisacrypto/directory/pkg/store"
+	"github.com/trisacrypto/directory/pkg/utils/backups"
 	"github.com/trisacrypto/directory/pkg/utils/sentry"
 )
 
@@ -58,8 +58,9 @@ func (s *Service) BackupManager(stop <-chan bool) {
 }
 
 // Backup performs a backup on behalf of the service. BackupManager calls this function
-// at a periodic interval to take a snapshot of the store to disk and to keep only the
-// configured number of archives.
+// at a periodic interval to take a snapshot of the store, upload it (encrypted if a key
+// is configured) to the backup sink, and to prune old archives from the sink according
+// to the configured retention policy.
 func (s *Service) Backup(path string) (err error) {
 	// Begin the backup process
 	start := time.Now()
@@ -75,8 +76,22 @@ func (s *Service) Backup(path string) (err error) {
 		}
 	}
 
+	var manager *backups.Manager
+	if manager, err = s.backupManager(path); err != nil {
+		log.WithLevel(zerolog.FatalLevel).Err(err).Msg("could not create backup sink")
+		return err
+	}
+
+	// Stage the archive in the backup directory before it is uploaded to the sink
+	var staging string
+	if staging, err = os.MkdirTemp(path, ".staging-"); err != nil {
+		log.WithLevel(zerolog.FatalLevel).Err(err).Msg("could not create backup staging directory")
+		return err
+	}
+	defer os.RemoveAll(staging)
+
 	// Conduct the backup, logging errors if needed
-	if err = s.db.(store.Backup).Backup(path); err != nil {
+	if err = s.db.(store.Backup).Backup(staging); err != nil {
 		// Do not continue if there was a backup error; all code in the rest of the
 		// loop should expect that the backup was successful.
 		// NOTE: using WithLevel and Fatal does not Exit the program like log.Fatal()
@@ -85,29 +100,49 @@ func (s *Service) Backup(path string) (err error) {
 		return err
 	}
 
-	log.Info().Dur("duration", time.Since(start)).Msg("backup complete")
-
-	// Remove any previous backups that may be in the directory
-	// NOTE: this requires the backup to write filenames as gdsdb-200601021504.*
+	// Upload the staged archive to the backup sink
 	var archives []string
-	if archives, err = listArchives(path); err != nil {
-		sentry.Error(nil).Err(err).Msg("could not list backup directory")
+	if archives, err = backups.Staged(staging, backups.GDSPrefix); err != nil {
+		sentry.Error(nil).Err(err).Msg("could not list backup staging directory")
 		return err
 	}
 
-	if len(archives) > s.conf.Backup.Keep {
-		var removed int
-		for _, archive := range archives[:len(archives)-s.conf.Backup.Keep] {
-			log.Debug().Str("archive", archive).Msg("deleting archive")
-			if err = os.Remove(archive); err == nil {
-				removed++
-			}
+	ctx := context.Background()
+	for _, archive := range archives {
+		if _, err = manager.Upload(ctx, archive); err != nil {
+			log.WithLevel(zerolog.FatalLevel).Err(err).Str("archive", archive).Msg("could not upload backup archive")
+			return err
 		}
-		log.Debug().Int("kept", s.conf.Backup.Keep).Int("removed", removed).Msg("backup directory cleaned up")
 	}
+
+	log.Info().Dur("duration", time.Since(start)).Msg("backup complete")
+
+	// Remove any previous backups from the sink that are not retained
+	var removed int
+	if removed, err = manager.Prune(ctx); err != nil {
+		sentry.Error(nil).Err(err).Msg("could not prune backup archives")
+		return err
+	}
+
+	log.Debug().Int("kept", s.conf.Backup.Keep).Int("removed", removed).Msg("backup directory cleaned up")
 	return nil
 }
 
+// create the backup manager for the configured sink, using the backup directory as the
+// sink if no other sink is configured.
+func (s *Service) backupManager(path string) (_ *backups.Manager, err error) {
+	var sink backups.Sink
+	if sink, err = backups.Open(s.conf.Backup.Sink, path); err != nil {
+		return nil, err
+	}
+
+	var key []byte
+	if key, err = backups.ParseKey(s.conf.Backup.EncryptionKey); err != nil {
+		return nil, err
+	}
+	return backups.New(backups.GDSPrefix, sink, key, s.conf.Backup.Retention()), nil
+}
+
 // get the configured backup directory storage or return an error
 func (s *Service) getBackupStorage() (path string, err error) {
 	if s.conf.Backup.Storage == "" {
@@ -132,15 +167,3 @@ func (s *Service) getBackupStorage() (path string, err error) {
 	}
 	return path, nil
 }
-
-// list all backup archives ordered by date ascending using string sorting that depends
-// on the backup archive format gdsdb-YYYYmmddHHMM.
-func listArchives(path string) (paths []string, err error) {
-	if paths, err = filepath.Glob(filepath.Join(path, "gdsdb-[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9].*")); err != nil {
-		return nil, err
-	}
-
-	// Sort the paths by timestamp ascending
-	sort.Strings(paths)
-	return paths, nil
-}
@@ -0,0 +1,60 @@
+package certman
+
+import (
+	"fmt"
+
+	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/sectigo"
+)
+
+// CertificateAuthority issues and revokes identity certificates for the certificate
+// manager. Certificate requests are submitted to the CA as a batch whose status is
+// polled until the certificates are ready to be downloaded. Batch statuses use the
+// Sectigo batch status values since they are stored on the certificate request.
+type CertificateAuthority interface {
+	// Balance returns the ID of an authority of the CA that has an available balance
+	// and the number of certificates that the authority can still issue.
+	Balance() (authority, available int, err error)
+
+	// Submit the certificate request to the authority, returning the created batch.
+	Submit(r *models.CertificateRequest, authority int, batchName string) (*Batch, error)
+
+	// Status returns the current processing status of the batch of the request.
+	Status(r *models.CertificateRequest) (*Batch, error)
+
+	// Download the certificates of the request into the directory, returning the path
+	// to the zip archive of the certificates.
+	Download(r *models.CertificateRequest, dir string) (path string, err error)
+
+	// Revoke the certificate with the specified serial number.
+	Revoke(certID string, reason sectigo.CRLReason) error
+}
+
+// Batch describes a certificate request that has been submitted to a certificate
+// authority and the processing status of the certificates in the batch.
+type Batch struct {
+	BatchID      int
+	BatchName    string
+	Status       string
+	OrderNumber  int
+	CreationDate string
+	Profile      string
+	RejectReason string
+	Active       int
+	Failed       int
+	Success      int
+}
+
+// NewCertificateAuthority returns the certificate authority specified by the
+// configuration. The cert directory is used by CAs that store issued certificates.
+func NewCertificateAuthority(conf config.CertManConfig, certDir string) (CertificateAuthority, error) {
+	switch conf.Authority {
+	case config.AuthoritySectigo:
+		return NewSectigoAuthority(conf.Sectigo)
+	case config.AuthorityLocal:
+		return NewLocalAuthority(conf.LocalCA, certDir)
+	default:
+		return nil, fmt.Errorf("unknown certificate authority %q", conf.Authority)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -23,9 +24,10 @@ import (
 	"github.com/trisacrypto/directory/pkg/sectigo"
 	"github.com/trisacrypto/directory/pkg/sectigo/mock"
 	"github.com/trisacrypto/directory/pkg/store"
+	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
+	"github.com/trisacrypto/directory/pkg/store/txn"
 	"github.com/trisacrypto/directory/pkg/utils"
 	"github.com/trisacrypto/directory/pkg/utils/sentry"
-	"github.com/trisacrypto/directory/pkg/utils/whisper"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 	"github.com/trisacrypto/trisa/pkg/trust"
 )
@@ -52,20 +54,24 @@ func New(conf config.CertManConfig, db store.Store, secret *secrets.SecretManage
 		return nil, errors.New("secret manager is required for cert manager")
 	}
 
-	if conf.Sectigo.Testing() {
+	if conf.Authority == config.AuthoritySectigo && conf.Sectigo.Testing() {
 		if err = mock.Start(conf.Sectigo.Profile); err != nil {
 			return nil, err
 		}
 	}
 
-	if cm.certs, err = sectigo.New(conf.Sectigo); err != nil {
+	if cm.certDir, err = cm.getCertStorage(); err != nil {
 		return nil, err
 	}
 
-	if cm.certDir, err = cm.getCertStorage(); err != nil {
+	if cm.ca, err = NewCertificateAuthority(conf, cm.certDir); err != nil {
 		return nil, err
 	}
 
+	if conf.DomainValidation.Enabled {
+		cm.domains = NewDomainValidator()
+	}
+
 	return cm, nil
 }
 
@@ -76,7 +82,8 @@ type CertificateManager struct {
 	conf    config.CertManConfig
 	db      store.Store
 	secret  *secrets.SecretManager
-	certs   *sectigo.Sectigo
+	ca      CertificateAuthority
+	domains *DomainValidator
 	email   *emails.EmailManager
 	certDir string
 	stop    chan struct{}
@@ -128,9 +135,8 @@ func (c *CertificateManager) Stop() {
 // is done processing it downloads the certs and emails them to the technical contacts.
 // If the certificate processing fails for any reason, it sends an error message to
 // the TRISA admins since this will prevent the integrator from joining the network.
-//
-// TODO: move completed certificate requests to archive so that the CertManger routine
-// isn't continuously handling a growing number of requests over time.
+// Finished certificate requests are moved to the archive after the configured duration
+// so that the CertManager routine isn't handling a growing number of requests over time.
 func (c *CertificateManager) CertManager() {
 	// Tickers are created in the go routine to prevent backpressure if the individual
 	// handler routines take longer than the ticker intervals.
@@ -160,12 +166,14 @@ func (c *CertificateManager) CertManager() {
 
 // HandleCertificateRequests performs one iteration through the certificate requests in
 // the database and handles each sequentially, progressing them by modifying the status
-// fields in the database. Note that this method logs errors instead of returning them
-// to the caller.
+// fields in the database. Finished requests that have not been modified within the
+// archive duration are moved to the archive once all requests have been handled. Note
+// that this method logs errors instead of returning them to the caller.
 func (c *CertificateManager) HandleCertificateRequests() {
 	// Retrieve all certificate requests from the database
 	var (
 		nrequests int
+		archive   []string
 		wg        sync.WaitGroup
 		err       error
 	)
@@ -186,6 +194,11 @@ func (c *CertificateManager) HandleCertificateRequests() {
 
 		logctx := sentry.With(nil).Str("id", req.Id).Str("common_name", req.CommonName)
 
+		// Archive finished requests after the iteration is complete
+		if c.conf.ArchiveAfter > 0 && models.CertificateRequestArchivable(req, time.Now().Add(-c.conf.ArchiveAfter)) {
+			archive = append(archive, req.Id)
+		}
+
 		switch req.Status {
 		case models.CertificateRequestState_READY_TO_SUBMIT:
 			wg.Add(1)
@@ -210,8 +223,19 @@ func (c *CertificateManager) HandleCertificateRequests() {
 					}
 					if err = c.db.UpdateCertReq(ctx, req); err != nil {
 						logctx.Error().Err(err).Msg("could not save updated certificate request")
-						return
 					}
+					return
+				}
+
+				// Verify that the VASP controls the domains of the certificate request
+				var ready bool
+				if ready, err = c.validateDomains(ctx, req); err != nil {
+					logctx.Error().Err(err).Msg("could not validate certificate request domains")
+					return
+				}
+
+				if !ready {
+					logctx.Info().Str("status", req.Status.String()).Msg("certificate request domains have not been validated")
 				} else if err = c.submitCertificateRequest(req, vasp); err != nil {
 					// If certificate submission requests fail we want immediate notification
 					// so this is a CRITICAL severity that should alert us immediately.
@@ -246,14 +270,37 @@ func (c *CertificateManager) HandleCertificateRequests() {
 		return
 	}
 
+	// Move the finished certificate requests to the archive
+	narchived := 0
+	for _, id := range archive {
+		if err = c.db.ArchiveCertReq(ctx, id); err != nil {
+			sentry.Error(nil).Err(err).Str("id", id).Msg("cert-manager could not archive certificate request")
+			continue
+		}
+		narchived++
+	}
+
 	// Conclude certificate handling successfully
-	log.Debug().Int("requests", nrequests).Msg("cert-manager check complete")
+	log.Debug().Int("requests", nrequests).Int("archived", narchived).Msg("cert-manager check complete")
 }
 
 func (c *CertificateManager) submitCertificateRequest(r *models.CertificateRequest, vasp *pb.VASP) (err error) {
 	ctx, cancel := utils.WithDeadline(context.Background())
 	defer cancel()
 
+	// Reject certificate requests whose CSR no longer matches the request, e.g. if the
+	// common name was changed after the CSR was submitted, since Sectigo would issue a
+	// certificate for names that the VASP did not request.
+	if err = models.ValidateCertificateRequestCSR(r); err != nil {
+		if serr := models.UpdateCertificateRequestStatus(r, models.CertificateRequestState_CR_REJECTED, fmt.Sprintf("invalid certificate signing request: %s", err), "automated"); serr != nil {
+			return fmt.Errorf("could not update certificate request status: %s", serr)
+		}
+		if serr := c.db.UpdateCertReq(ctx, r); serr != nil {
+			return fmt.Errorf("could not save rejected certificate request: %s", serr)
+		}
+		return fmt.Errorf("invalid certificate signing request: %w", err)
+	}
+
 	// Step 0: mark the VASP status as issuing certificates
 	if err := models.UpdateVerificationStatus(vasp, pb.VerificationState_ISSUING_CERTIFICATE, "issuing certificate", "automated"); err != nil {
 		return err
@@ -264,70 +311,45 @@ func (c *CertificateManager) submitCertificateRequest(r *models.CertificateReque
 
 	// Step 1: find an authority with an available balance
 	var authority int
-	if authority, err = c.findCertAuthority(); err != nil {
+	if authority, _, err = c.ca.Balance(); err != nil {
 		return err
 	}
 
-	// Step 2: get the password
-	secretType := "password"
-	pkcs12Password, err := c.secret.With(r.Id).GetLatestVersion(context.Background(), secretType)
-	if err != nil {
-		return fmt.Errorf("could not retrieve pkcs12password: %s", err)
+	// Step 2: get the password; certificates issued from a CSR do not have a private key
+	// so no password is required to encrypt them.
+	if r.Csr == "" {
+		secretType := "password"
+		pkcs12Password, err := c.secret.With(r.Id).GetLatestVersion(context.Background(), secretType)
+		if err != nil {
+			return fmt.Errorf("could not retrieve pkcs12password: %s", err)
+		}
+		models.UpdateCertificateRequestParams(r, sectigo.ParamPassword, string(pkcs12Password))
 	}
 
 	// Allow multiple DNS names to be specified in addition to the common name
 	// This will overwrite whatever is in the params ensuring the latest common name and
-	// dns names are submitted to Sectigo if there were intermediate changes to the req.
+	// dns names are submitted to the CA if there were intermediate changes to the req.
 	dnsNames := []string{r.CommonName}
 	dnsNames = append(dnsNames, r.DnsNames...)
 	models.UpdateCertificateRequestParams(r, sectigo.ParamDNSNames, strings.Join(dnsNames, "\n"))
 	models.UpdateCertificateRequestParams(r, sectigo.ParamCommonName, r.CommonName)
-	models.UpdateCertificateRequestParams(r, sectigo.ParamPassword, string(pkcs12Password))
 
-	// Step 3: submit the certificate
-	var (
-		rep    *sectigo.BatchResponse
-		params map[string]string
-	)
-
-	// Construct the required parameters for the Sectigo request.
-	profile := c.certs.Profile()
+	// Step 3: submit the certificate request to the certificate authority
+	var batch *Batch
 	batchName := fmt.Sprintf("%s-certreq-%s", c.conf.DirectoryID, r.Id)
-
-	if params, err = models.GetCertificateRequestParams(r, profile); err != nil {
-		return fmt.Errorf("could not retrieve certificate request parameters for profile %q: %s", profile, err)
-	}
-
-	// Execute the certificate request to Sectigo.
-	if rep, err = c.certs.CreateSingleCertBatch(authority, batchName, params); err != nil {
-		// Although the error may be logged again by the calling function, log the error
-		// here as well to provide debugging information about why the Sectigo request failed.
-		dict := sentry.Dict()
-		for key, value := range params {
-			// NOTE: Do not log any passwords or secrets!
-			if key == "pkcs12Password" {
-				value = strings.Repeat("*", len(value))
-			}
-			dict.Str(key, value)
-		}
-		sentry.Error(nil).Err(err).
-			Int("authority", authority).
-			Str("batch_name", batchName).
-			Dict("params", dict).
-			Str("profile", profile).
-			Msg("create single cert batch failed")
-		return fmt.Errorf("could not create single certificate batch: %s", err)
+	if batch, err = c.ca.Submit(r, authority, batchName); err != nil {
+		return fmt.Errorf("could not create certificate batch: %s", err)
 	}
 
 	// Step 4: update the certificate request with the batch details
 	r.AuthorityId = int64(authority)
-	r.BatchId = int64(rep.BatchID)
-	r.BatchName = rep.BatchName
-	r.BatchStatus = rep.Status
-	r.OrderNumber = int64(rep.OrderNumber)
-	r.CreationDate = rep.CreationDate
-	r.Profile = rep.Profile
-	r.RejectReason = rep.RejectReason
+	r.BatchId = int64(batch.BatchID)
+	r.BatchName = batch.BatchName
+	r.BatchStatus = batch.Status
+	r.OrderNumber = int64(batch.OrderNumber)
+	r.CreationDate = batch.CreationDate
+	r.Profile = batch.Profile
+	r.RejectReason = batch.RejectReason
 
 	// Mark the certificate request as processing so downstream status checks occur
 	if err = models.UpdateCertificateRequestStatus(r, models.CertificateRequestState_PROCESSING, "certificate submitted", "automated"); err != nil {
@@ -345,43 +367,29 @@ func (c *CertificateManager) checkCertificateRequest(r *models.CertificateReques
 		return errors.New("missing batch ID - cannot retrieve status")
 	}
 
-	// Step 1: refresh batch info from the sectigo service
-	var info *sectigo.BatchResponse
-	if info, err = c.certs.BatchDetail(int(r.BatchId)); err != nil {
-		return fmt.Errorf("could not fetch batch info for id %d: %s", r.BatchId, err)
-	}
-
-	// Step 1b: update certificate request with fetched info
-	r.BatchStatus = info.Status
-	r.RejectReason = info.RejectReason
-
-	// Step 1c: check if the batch is in an unhandled state, and if so, refresh batch status
-	if info.Status == sectigo.BatchStatusCollected || info.Status == "" {
-		sentry.Warn(nil).Int64("batch_id", r.BatchId).Str("batch_status", info.Status).Msg("unknown batch info status, refreshing batch status directly")
-		if r.BatchStatus, err = c.certs.BatchStatus(int(r.BatchId)); err != nil {
-			return fmt.Errorf("could not fetch batch status for id %d: %s", r.BatchId, err)
-		}
+	// Step 1: refresh the batch info from the certificate authority
+	var batch *Batch
+	if batch, err = c.ca.Status(r); err != nil {
+		return err
 	}
 
-	// Step 2: get the processing info for the batch
-	var proc *sectigo.ProcessingInfoResponse
-	if proc, err = c.certs.ProcessingInfo(int(r.BatchId)); err != nil {
-		return fmt.Errorf("could not fetch batch processing info for id %d: %s", r.BatchId, err)
-	}
+	// Step 2: update certificate request with fetched info
+	r.BatchStatus = batch.Status
+	r.RejectReason = batch.RejectReason
 
 	log.Info().
 		Str("status", r.BatchStatus).
 		Str("reject", r.RejectReason).
-		Int("active", proc.Active).
-		Int("failed", proc.Failed).
-		Int("success", proc.Success).
+		Int("active", batch.Active).
+		Int("failed", batch.Failed).
+		Int("success", batch.Success).
 		Msg("batch processing status")
 
 	ctx, cancel := utils.WithDeadline(context.Background())
 	defer cancel()
 
 	// Step 3: check active - if there is still an active batch then delay
-	if proc.Active > 0 {
+	if batch.Active > 0 {
 		if err = models.UpdateCertificateRequestStatus(r, models.CertificateRequestState_PROCESSING, "awaiting batch processing", "automated"); err != nil {
 			return fmt.Errorf("could not update certificate request status: %s", err)
 		}
@@ -392,15 +400,15 @@ func (c *CertificateManager) checkCertificateRequest(r *models.CertificateReques
 	}
 
 	// Step 4: check failures -- determine if certificate request has been rejected
-	if proc.Failed > 0 {
+	if batch.Failed > 0 {
 		logctx := sentry.With(nil).
 			Int("batch_id", int(r.BatchId)).
-			Int("failed", proc.Failed).
-			Int("success", proc.Success).
+			Int("failed", batch.Failed).
+			Int("success", batch.Success).
 			Str("status", r.BatchStatus).
 			Str("name", r.BatchName)
 
-		if proc.Success > 0 || r.BatchStatus == sectigo.BatchStatusReadyForDownload {
+		if batch.Success > 0 || r.BatchStatus == sectigo.BatchStatusReadyForDownload {
 			// This may mean that some certificates can be downloaded, so just log
 			// errors and continue with download processing
 			logctx.Warn().Msg("certificate request mixed success/failure")
@@ -429,12 +437,12 @@ func (c *CertificateManager) checkCertificateRequest(r *models.CertificateReques
 	}
 
 	// Step 5: Check to make sure we can download certificates
-	if proc.Success == 0 || r.BatchStatus != sectigo.BatchStatusReadyForDownload {
-		// We should not be in this state, it should have been handled in Step 1c or 4
-		// so this is a developer error on our part, or a change in the Sectigo API
+	if batch.Success == 0 || r.BatchStatus != sectigo.BatchStatusReadyForDownload {
+		// We should not be in this state, it should have been handled in Step 1 or 4
+		// so this is a developer error on our part, or a change in the CA API
 		// NOTE: using WithLevel and Fatal does not Exit the program like log.Fatal()
 		// this ensures that we issue a CRITICAL severity without stopping the server.
-		sentry.Fatal(nil).Int64("batch_id", r.BatchId).Int("success", proc.Success).Str("batch_status", r.BatchStatus).Msg("unhandled sectigo state")
+		sentry.Fatal(nil).Int64("batch_id", r.BatchId).Int("success", batch.Success).Str("batch_status", r.BatchStatus).Msg("unhandled sectigo state")
 		if err = models.UpdateCertificateRequestStatus(r, models.CertificateRequestState_PROCESSING, "unhandled sectigo state", "automated"); err != nil {
 			return fmt.Errorf("could not update certificate request status: %s", err)
 		}
@@ -475,26 +483,6 @@ func (c *CertificateManager) checkCertificateRequest(r *models.CertificateReques
 	return nil
 }
 
-// finds the first authority with an available balance greater than 0.
-func (c *CertificateManager) findCertAuthority() (id int, err error) {
-	var authorities []*sectigo.AuthorityResponse
-	if authorities, err = c.certs.UserAuthorities(); err != nil {
-		return 0, fmt.Errorf("could not fetch user authorities: %s", err)
-	}
-
-	for _, authority := range authorities {
-		var balance int
-		if balance, err = c.certs.AuthorityAvailableBalance(authority.ID); err != nil {
-			sentry.Error(c).Err(err).Int("authority", authority.ID).Msg("could not fetch authority balance")
-		}
-		if balance > 0 {
-			return authority.ID, nil
-		}
-	}
-
-	return 0, fmt.Errorf("could not find authority with available balance out of %d available authorities", len(authorities))
-}
-
 // a go routine that downloads the certificate in the background, then sends the certs
 // as an attachment to the technical contact if available.
 func (c *CertificateManager) downloadCertificateRequest(r *models.CertificateRequest, vasp *pb.VASP) {
@@ -506,7 +494,7 @@ func (c *CertificateManager) downloadCertificateRequest(r *models.CertificateReq
 	)
 
 	// Download the certificates as a zip file to the cert storage directory
-	if path, err = c.certs.Download(int(r.BatchId), c.certDir); err != nil {
+	if path, err = c.ca.Download(r, c.certDir); err != nil {
 		sentry.Error(c).Err(err).Int("batch", int(r.BatchId)).Msg("could not download certificates")
 		return
 	}
@@ -545,16 +533,39 @@ func (c *CertificateManager) downloadCertificateRequest(r *models.CertificateReq
 
 	log.Info().Str(path, path).Msg("certificates written to secret manager")
 
-	// Retrieve the latest secret version for the password
-	secretType = "password"
-	pkcs12password, err := c.secret.With(r.Id).GetLatestVersion(sctx, secretType)
-	if err != nil {
-		sentry.Error(nil).Err(err).Msg("could not retrieve password from secret manager to extract public key")
-		return
+	// Retrieve the latest secret version for the password; certificates issued from a
+	// CSR only contain the public certificate chain and are not encrypted.
+	var pkcs12password []byte
+	if r.Csr == "" {
+		secretType = "password"
+		if pkcs12password, err = c.secret.With(r.Id).GetLatestVersion(sctx, secretType); err != nil {
+			sentry.Error(nil).Err(err).Msg("could not retrieve password from secret manager to extract public key")
+			return
+		}
 	}
 
-	if vasp.IdentityCertificate, err = extractCertificate(path, string(pkcs12password)); err != nil {
+	var identity *pb.Certificate
+	if identity, err = extractCertificate(path, string(pkcs12password), r.Csr); err != nil {
 		sentry.Error(nil).Err(err).Msg("could not extract certificate")
+
+		// A certificate that was not issued for the submitted CSR must not be delivered.
+		if errors.Is(err, errCSRMismatch) {
+			if err = models.UpdateCertificateRequestStatus(r, models.CertificateRequestState_CR_ERRORED, "issued certificate does not match the certificate signing request", "automated"); err != nil {
+				sentry.Error(nil).Err(err).Msg("could not update certificate request status")
+				return
+			}
+			if err = c.db.UpdateCertReq(ctx, r); err != nil {
+				sentry.Error(nil).Err(err).Msg("could not save updated cert request")
+			}
+		}
+		return
+	}
+
+	// The new certificate becomes the identity certificate of the VASP; any previous
+	// identity certificate remains active for the rotation overlap so that peers that
+	// cached it can continue to connect while they pre-trust the new certificate.
+	if err = models.RotateCertificate(vasp, models.CertificateUsageIdentity, identity, c.conf.RotationOverlap); err != nil {
+		sentry.Error(nil).Err(err).Msg("could not rotate identity certificate")
 		return
 	}
 
@@ -578,6 +589,12 @@ func (c *CertificateManager) downloadCertificateRequest(r *models.CertificateReq
 		return
 	}
 
+	// The new identity certificate replaces any previously revoked certificate
+	if err = models.SetRevokedOn(vasp, ""); err != nil {
+		sentry.Error(nil).Err(err).Msg("could not clear revocation on VASP")
+		return
+	}
+
 	// Update the VASP status as verified/certificate issued
 	if err := models.UpdateVerificationStatus(vasp, pb.VerificationState_VERIFIED, "certificate issued", "automated"); err != nil {
 		sentry.Error(nil).Err(err).Msg("could not update VASP verification status")
@@ -599,7 +616,7 @@ func (c *CertificateManager) downloadCertificateRequest(r *models.CertificateReq
 	// If the user has not specifically turned off email delivery or if webhook
 	// delivery failed, send the certificates via email.
 	if !r.NoEmailDelivery || deliveryErr != nil {
-		if _, err = c.email.SendDeliverCertificates(vasp, path); err != nil {
+		if _, err = c.email.SendDeliverCertificates(vasp, r.Id, filepath.Base(path), r.Csr != ""); err != nil {
 			// If there is an error delivering emails, return here so we don't mark as completed
 			sentry.Error(nil).Err(err).Msg("could not deliver certificates to technical contact")
 			return
@@ -627,9 +644,14 @@ func (c *CertificateManager) downloadCertificateRequest(r *models.CertificateReq
 		Msg("certificates extracted and delivered")
 }
 
-func extractCertificate(path, pkcs12password string) (pub *pb.Certificate, err error) {
+var errCSRMismatch = errors.New("issued certificate does not match the certificate signing request")
+
+// Extracts the public certificate from the downloaded certificates. If a CSR was
+// submitted, the certificates are not encrypted and the public key of the certificate
+// must match the public key of the CSR.
+func extractCertificate(path, pkcs12password, csr string) (pub *pb.Certificate, err error) {
 	var archive *trust.Serializer
-	if archive, err = trust.NewSerializer(true, pkcs12password, trust.CompressionZIP); err != nil {
+	if archive, err = trust.NewSerializer(csr == "", pkcs12password, trust.CompressionZIP); err != nil {
 		return nil, err
 	}
 
@@ -643,6 +665,16 @@ func extractCertificate(path, pkcs12password string) (pub *pb.Certificate, err e
 		return nil, err
 	}
 
+	if csr != "" {
+		var match bool
+		if match, err = models.MatchesCSR(cert, []byte(csr)); err != nil {
+			return nil, err
+		}
+		if !match {
+			return nil, errCSRMismatch
+		}
+	}
+
 	pub = &pb.Certificate{
 		Version:            int64(cert.Version),
 		SerialNumber:       cert.SerialNumber.Bytes(),
@@ -728,9 +760,12 @@ func (c *CertificateManager) getCertStorage() (path string, err error) {
 }
 
 // HandleCertificateReissuance iterates through each VASP in the database and checks
-// if their identity certificate will be expiring soon, sending a reminder email at
-// the 30 and 7 day checkpoints if so, and reissuing the identity certificate 10 days before
-// expiration.
+// if their identity certificate will be expiring soon, performing the stages of the
+// configured reissuance schedule (e.g. sending reminder emails, reissuing the identity
+// certificate, and marking the certificate as expired) as the expiration approaches.
+// Reminder stages are performed at most once per identity certificate; stages that
+// reissue or expire the certificate are retried on every run until their action
+// succeeds, after which their email is sent at most once.
 func (c *CertificateManager) HandleCertificateReissuance() {
 	var (
 		err            error
@@ -740,6 +775,9 @@ func (c *CertificateManager) HandleCertificateReissuance() {
 	ctx, cancel := utils.WithDeadline(context.Background())
 	defer cancel()
 
+	// The reissuance schedule for the configured Sectigo profile
+	schedule := c.conf.Schedule()
+
 	// Iterate through the VASPs in the database.
 	vasps := c.db.ListVASPs(ctx)
 	defer vasps.Release()
@@ -776,69 +814,45 @@ vaspsLoop:
 			continue vaspsLoop
 		}
 
-		// Calculate the reissuance date, 10 days before expiration
-		reissuanceDate := expirationDate.Add(-time.Hour * 240)
-		timeBeforeExpiration := time.Until(expirationDate)
-
-		// NOTE: This computation returns fractional days rather than rounding up or down to the nearest day.
-		switch daysBeforeExpiration := timeBeforeExpiration.Hours() / 24; {
+		// Calculate the reissuance date from the reissue stage of the schedule
+		reissuanceDate := expirationDate.Add(-schedule.ReissuanceOffset())
 
-		// If a certificate has expired, update the certificate record.
-		case daysBeforeExpiration <= 0:
-			var cert *models.Certificate
-			if cert, err = c.db.RetrieveCert(ctx, models.GetCertID(identityCert)); err != nil {
-				sentry.Error(nil).Err(err).Str("vasp_id", vasp.Id).Msg("could not retrieve expired certificate record")
-				continue vaspsLoop
-			}
-			cert.Status = models.CertificateState_EXPIRED
-			if err = c.db.UpdateCert(ctx, cert); err != nil {
-				sentry.Error(nil).Err(err).Str("vasp_id", vasp.Id).Msg("could not update expired certificate record status")
+		// Perform the stages of the schedule that are due and have not already fired
+		// for the current identity certificate of the VASP.
+		for _, stage := range schedule.Current(time.Until(expirationDate)) {
+			var fired bool
+			if fired, err = models.ReissuanceStageFired(vasp, stage.ID()); err != nil {
+				sentry.Error(nil).Err(err).Str("vasp_id", vasp.Id).Str("stage", stage.ID()).Msg("could not check if reissuance stage has fired")
+				continue
 			}
 
-		// Seven days before expiration, send a cert reissuance reminder to VASP.
-		// NOTE: the SendContactReissuanceReminder will not send emails more than
-		// once to a contact.
-		case daysBeforeExpiration <= 7:
-			if err = c.email.SendContactReissuanceReminder(vasp, 7, reissuanceDate); err != nil {
-				sentry.Error(nil).Err(err).Str("vasp_id", vasp.Id).Msg("error sending seven day reissuance reminder")
-				continue vaspsLoop
+			if fired {
+				continue
 			}
 
-		// Ten days before expiration, reissue the VASP's identity certificate, send the email with the created pkcs12
-		// password and send the whisper link, as well as notifying the TRISA admin that reissuance has started.
-		case daysBeforeExpiration <= 10:
-			// Check if the reissuance process has already started for this VASP
-			if started, err := c.reissuanceInProgress(vasp); err != nil {
-				sentry.Error(nil).Err(err).Str("vasp_id", vasp.Id).Msg("could not check vasp reissuance status")
-				continue vaspsLoop
-			} else if started {
-				log.Info().Str("vasp_id", vasp.Id).Msg("vasp reissuance is already in progress")
-				continue vaspsLoop
+			// The action of the stage must succeed before the stage is recorded so that
+			// a failed reissuance or expiration is retried on the next run.
+			if err = c.handleReissuanceAction(ctx, vasp, stage); err != nil {
+				sentry.Error(nil).Err(err).Str("vasp_id", vasp.Id).Str("stage", stage.ID()).Msg("could not perform reissuance stage")
+				continue
 			}
 
-			// Start the reissuance process for this VASP by creating a new certificate request
-			if err = c.reissueIdentityCertificates(vasp); err != nil {
-				sentry.Error(nil).Err(err).Str("vasp_id", vasp.Id).Msg("could not start reissuance process")
-				continue vaspsLoop
-			}
-			if _, err = c.email.SendReissuanceAdminNotification(vasp, 10, reissuanceDate); err != nil {
-				sentry.Error(nil).Err(err).Str("vasp_id", vasp.Id).Msg("error sending admin reissuance notification")
-				// The VASP and certreq records have already been updated in
-				// reissueIdentityCertificates, so we can continue to the next VASP if
-				// we failed to send the email.
-				continue vaspsLoop
+			// The stage is recorded and saved before its email is sent so that contacts
+			// are notified at most once, even if the certificate manager stops while the
+			// email is sent.
+			if err = models.RecordReissuanceStage(vasp, stage.ID()); err != nil {
+				sentry.Error(nil).Err(err).Str("vasp_id", vasp.Id).Str("stage", stage.ID()).Msg("could not record reissuance stage")
+				continue
 			}
 
-		// Thirty days before expiration, send the reissuance reminder to the VASP and the TRISA admin.
-		case daysBeforeExpiration <= 30:
-			// If the reminder fails do not stop processing and attempt to send reminder to admins
-			if err = c.email.SendContactReissuanceReminder(vasp, 30, reissuanceDate); err != nil {
-				sentry.Error(nil).Err(err).Str("vasp_id", vasp.Id).Msg("error sending thirty day reissuance reminder")
+			if err = c.db.UpdateVASP(ctx, vasp); err != nil {
+				sentry.Error(nil).Err(err).Str("vasp_id", vasp.Id).Str("stage", stage.ID()).Msg("could not save reissuance stage")
+				continue vaspsLoop
 			}
 
-			if _, err = c.email.SendExpiresAdminNotification(vasp, 30, reissuanceDate); err != nil {
-				sentry.Error(nil).Err(err).Str("vasp_id", vasp.Id).Msg("error sending admin reissuance reminder")
-				continue vaspsLoop
+			if err = c.sendReissuanceStageEmail(vasp, stage, reissuanceDate); err != nil {
+				sentry.Error(nil).Err(err).Str("vasp_id", vasp.Id).Str("stage", stage.ID()).Msg("could not send reissuance stage email")
+				continue
 			}
 		}
 
@@ -865,6 +879,71 @@ vaspsLoop:
 	}
 }
 
+// Helper function for HandleCertificateReissuance that performs the action of a stage
+// of the reissuance schedule; reminder stages have no action.
+func (c *CertificateManager) handleReissuanceAction(ctx context.Context, vasp *pb.VASP, stage config.ReissuanceStage) (err error) {
+	switch stage.Action {
+	case config.ActionRemind:
+	case config.ActionReissue:
+		// Check if the reissuance process has already started for this VASP
+		var started bool
+		if started, err = c.reissuanceInProgress(vasp); err != nil {
+			return fmt.Errorf("could not check vasp reissuance status: %w", err)
+		}
+
+		if started {
+			log.Info().Str("vasp_id", vasp.Id).Msg("vasp reissuance is already in progress")
+			return nil
+		}
+
+		// Start the reissuance process for this VASP by creating a new certificate request
+		if err = c.reissueIdentityCertificates(vasp); err != nil {
+			return fmt.Errorf("could not start reissuance process: %w", err)
+		}
+	case config.ActionExpire:
+		// If a certificate has expired, update the certificate record. Identity
+		// certificates issued before certificate records were stored have no record.
+		var cert *models.Certificate
+		if cert, err = c.db.RetrieveCert(ctx, models.GetCertID(vasp.IdentityCertificate)); err != nil {
+			if errors.Is(err, storeerrors.ErrEntityNotFound) {
+				log.Warn().Str("vasp_id", vasp.Id).Msg("expired identity certificate does not have a certificate record")
+				return nil
+			}
+			return fmt.Errorf("could not retrieve expired certificate record: %w", err)
+		}
+
+		cert.Status = models.CertificateState_EXPIRED
+		if err = c.db.UpdateCert(ctx, cert); err != nil {
+			return fmt.Errorf("could not update expired certificate record status: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown reissuance stage action %q", stage.Action)
+	}
+	return nil
+}
+
+// Helper function for HandleCertificateReissuance that sends the email of a stage of
+// the reissuance schedule to its audience.
+func (c *CertificateManager) sendReissuanceStageEmail(vasp *pb.VASP, stage config.ReissuanceStage, reissuanceDate time.Time) (err error) {
+	// NOTE: SendContactReissuanceReminder will not send emails more than once to a contact.
+	switch stage.Template {
+	case "":
+	case config.TemplateReissuanceReminder:
+		err = c.email.SendContactReissuanceReminder(vasp, stage.Days(), reissuanceDate)
+	case config.TemplateExpiresAdminNotification:
+		_, err = c.email.SendExpiresAdminNotification(vasp, stage.Days(), reissuanceDate)
+	case config.TemplateReissuanceAdminNotification:
+		_, err = c.email.SendReissuanceAdminNotification(vasp, stage.Days(), reissuanceDate)
+	default:
+		return fmt.Errorf("unknown reissuance stage template %q", stage.Template)
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not send %s email to %s: %w", stage.Template, stage.Audience, err)
+	}
+	return nil
+}
+
 // Helper to check if the reissuance process has already started for a VASP.
 func (c *CertificateManager) reissuanceInProgress(vasp *pb.VASP) (_ bool, err error) {
 	// Get the latest certificate request ID for the VASP.
@@ -893,10 +972,7 @@ func (c *CertificateManager) reissuanceInProgress(vasp *pb.VASP) (_ bool, err er
 // Helper function for HandleCertificateReissuance that reissues identity certificates
 // for the given vasp.
 func (c *CertificateManager) reissueIdentityCertificates(vasp *pb.VASP) (err error) {
-	var (
-		certreq     *models.CertificateRequest
-		whisperLink string
-	)
+	var certreq *models.CertificateRequest
 
 	// Create a new certificate request.
 	if certreq, err = models.NewCertificateRequest(vasp); err != nil {
@@ -913,13 +989,77 @@ func (c *CertificateManager) reissueIdentityCertificates(vasp *pb.VASP) (err err
 		return fmt.Errorf("error updating certificate request for vasp %s: %w", vasp.Id, err)
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// If the VASP submitted a CSR for the reissuance, issue the certificates for the new
+	// public key so that the private key never leaves the VASP; no password is needed.
+	// Previous CSRs are never reused so that the key pair is rotated on every reissuance.
+	if certreq.Csr, certreq.DnsNames, err = c.reissuanceCSR(vasp); err != nil {
+		return fmt.Errorf("error retrieving certificate signing request for vasp %s: %w", vasp.Id, err)
+	}
+
+	if certreq.Csr == "" {
+		if err = c.createReissuancePassword(ctx, vasp, certreq); err != nil {
+			return err
+		}
+	}
+
+	// The certificate request and the VASP are saved in a single transaction so that
+	// the VASP never references a certificate request that does not exist.
+	var tx txn.Txn
+	if tx, err = c.db.Begin(ctx); err != nil {
+		return fmt.Errorf("error beginning transaction for vasp %s: %w", vasp.Id, err)
+	}
+	defer tx.Rollback()
+
+	if err = tx.UpdateCertReq(certreq); err != nil {
+		return fmt.Errorf("error updating certificate request for vasp %s: %w", vasp.Id, err)
+	}
+
+	// Save the certificate request on the VASP.
+	if err = models.AppendCertReqID(vasp, certreq.Id); err != nil {
+		return fmt.Errorf("error appending certificate request to vasp %s: %w", vasp.Id, err)
+	}
+
+	if err = tx.UpdateVASP(vasp); err != nil {
+		return fmt.Errorf("error updating vasp %s in the certman store: %w", vasp.Id, err)
+	}
+
+	// Commit the certificate request and VASP to the datastore.
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error saving certificate request for vasp %s: %w", vasp.Id, err)
+	}
+	return nil
+}
+
+// Returns the CSR submitted by the VASP for the reissuance along with the DNS names of
+// the CSR and clears it from the VASP so that it is only used once. A CSR that is no
+// longer valid for the VASP, e.g. because its endpoint changed, is discarded so that
+// the certificates are reissued with a new key pair instead.
+func (c *CertificateManager) reissuanceCSR(vasp *pb.VASP) (csr string, dnsNames []string, err error) {
+	if csr, err = models.GetReissuanceCSR(vasp); err != nil || csr == "" {
+		return "", nil, err
+	}
+
+	if err = models.SetReissuanceCSR(vasp, ""); err != nil {
+		return "", nil, err
+	}
+
+	if dnsNames, err = models.ValidateVASPCSR([]byte(csr), vasp); err != nil {
+		sentry.Warn(nil).Err(err).Str("vasp_id", vasp.Id).Msg("discarding invalid reissuance certificate signing request")
+		return "", nil, nil
+	}
+	return csr, dnsNames, nil
+}
+
+// Generates a new PKCS12 password for the reissued certificates and delivers it to the
+// VASP via webhook or as a whisper link in the reissuance started email. The whisper
+// link is created from the stored password when the email is rendered.
+func (c *CertificateManager) createReissuancePassword(ctx context.Context, vasp *pb.VASP, certreq *models.CertificateRequest) (err error) {
 	// Generate a new PKCS12 password
 	secretType := "password"
 	pkcs12password := secrets.CreateToken(16)
-	whisperPasswordTemplate := "Below is the PKCS12 password which you must use to decrypt your new certificates:\n\n%s\n"
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
 
 	// Create a new secret using the secret manager.
 	if err = c.secret.With(certreq.Id).CreateSecret(ctx, secretType); err != nil {
@@ -940,29 +1080,10 @@ func (c *CertificateManager) reissueIdentityCertificates(vasp *pb.VASP) (err err
 	// If the user has not specifically turned off email delivery or if there was an
 	// error in webhook delivery, send the pkcs12 password in a whisper via email.
 	if !certreq.NoEmailDelivery || deliveryErr != nil {
-		if whisperLink, err = whisper.CreateSecretLink(fmt.Sprintf(whisperPasswordTemplate, pkcs12password), "", 3, time.Now().AddDate(0, 0, 7)); err != nil {
-			return fmt.Errorf("error creating whisper link for vasp %s: %w", vasp.Id, err)
-		}
-
-		if _, err = c.email.SendReissuanceStarted(vasp, whisperLink); err != nil {
+		if _, err = c.email.SendReissuanceStarted(vasp, certreq.Id); err != nil {
 			return fmt.Errorf("error sending reissuance started email for vasp %s: %w", vasp.Id, err)
 		}
 	}
-
-	// Update the certificate request in the datastore.
-	if err = c.db.UpdateCertReq(ctx, certreq); err != nil {
-		return fmt.Errorf("error updating certificate request for vasp %s: %w", vasp.Id, err)
-	}
-
-	// Save the certificate request on the VASP.
-	if err = models.AppendCertReqID(vasp, certreq.Id); err != nil {
-		return fmt.Errorf("error appending certificate request to vasp %s: %w", vasp.Id, err)
-	}
-
-	// Update the VASP information in the datastore.
-	if err = c.db.UpdateVASP(ctx, vasp); err != nil {
-		return fmt.Errorf("error updating vasp %s in the certman store: %w", vasp.Id, err)
-	}
 	return nil
 }
 
@@ -2,6 +2,12 @@ package certman_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -39,12 +45,14 @@ var (
 // is isolated from GDS.
 type certTestSuite struct {
 	suite.Suite
-	fixtures *fixtures.Library
-	conf     config.Config
-	db       store.Store
-	secret   *secrets.SecretManager
-	certman  *certman.CertificateManager
-	courier  *httptest.Server
+	fixtures  *fixtures.Library
+	conf      config.Config
+	schedules config.ReissuanceSchedules
+	domains   config.DomainValidationConfig
+	db        store.Store
+	secret    *secrets.SecretManager
+	certman   *certman.CertificateManager
+	courier   *httptest.Server
 }
 
 func TestCertManLevelDB(t *testing.T) {
@@ -218,6 +226,152 @@ func (s *certTestSuite) TestCertManager() {
 	require.Equal("automated", certReq.AuditLog[5].Source)
 }
 
+// Test that the certificate manager issues certificates for a CSR submitted by the
+// VASP and only delivers the public certificate chain.
+func (s *certTestSuite) TestCertManagerCSR() {
+	s.setupCertManager(sectigo.ProfileCipherTraceEE, fixtures.Full)
+	defer s.teardownCertManager()
+	require := s.Require()
+	ctx := context.Background()
+
+	echoVASP, err := s.fixtures.GetVASP("echo")
+	require.NoError(err, "could not get echo VASP")
+	quebecCertReq, err := s.fixtures.GetCertReq("quebec")
+	require.NoError(err, "could not get quebec certreq")
+
+	// Ensure that the email logs are cleared before the test
+	require.NoError(fixtures.ClearContactEmailLogs(echoVASP), "could not clear contact email logs")
+	require.NoError(s.db.UpdateVASP(ctx, echoVASP), "could not update echo VASP")
+
+	// Add a CSR to the certificate request; no password secret is created
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err, "could not generate private key")
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: quebecCertReq.CommonName},
+		DNSNames: append([]string{quebecCertReq.CommonName}, quebecCertReq.DnsNames...),
+	}, key)
+	require.NoError(err, "could not create certificate signing request")
+	quebecCertReq.Csr = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+	require.NoError(s.db.UpdateCertReq(ctx, quebecCertReq), "could not update quebec certreq")
+
+	// Let the certificate manager submit the CSR to Sectigo
+	s.certman.HandleCertificateRequests()
+	certReq, err := s.db.RetrieveCertReq(ctx, quebecCertReq.Id)
+	require.NoError(err)
+	require.Equal(models.CertificateRequestState_PROCESSING, certReq.Status)
+	require.Equal(int64(mock.CSRBatchID), certReq.BatchId)
+	require.NotContains(certReq.Params, sectigo.ParamPassword, "no password should be sent to sectigo")
+
+	// Let the certificate manager download the certificates
+	sent := time.Now()
+	s.certman.HandleCertificateRequests()
+
+	// The certificate should be issued for the public key of the CSR
+	v, err := s.db.RetrieveVASP(ctx, echoVASP.Id)
+	require.NoError(err)
+	require.Equal(pb.VerificationState_VERIFIED, v.VerificationStatus)
+	require.NotNil(v.IdentityCertificate)
+	block, _ := pem.Decode(v.IdentityCertificate.Data)
+	require.NotNil(block, "could not decode identity certificate")
+	leaf, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(err, "could not parse identity certificate")
+	match, err := models.MatchesCSR(leaf, []byte(quebecCertReq.Csr))
+	require.NoError(err)
+	require.True(match, "identity certificate was not issued for the CSR")
+
+	certReq, err = s.db.RetrieveCertReq(ctx, quebecCertReq.Id)
+	require.NoError(err)
+	require.Equal(models.CertificateRequestState_COMPLETED, certReq.Status)
+
+	messages := []*emails.EmailMeta{
+		{
+			Contact:   v.Contacts.Legal,
+			To:        v.Contacts.Legal.Email,
+			From:      s.conf.Email.ServiceEmail,
+			Subject:   emails.DeliverCertsRE,
+			Reason:    "deliver_certs",
+			Timestamp: sent,
+		},
+	}
+	emails.CheckEmails(s.T(), messages)
+}
+
+// Test that certificate requests with a CSR that does not match the request are rejected.
+func (s *certTestSuite) TestCertManagerInvalidCSR() {
+	s.setupCertManager(sectigo.ProfileCipherTraceEE, fixtures.Full)
+	defer s.teardownCertManager()
+	require := s.Require()
+	ctx := context.Background()
+
+	quebecCertReq, err := s.fixtures.GetCertReq("quebec")
+	require.NoError(err, "could not get quebec certreq")
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err, "could not generate private key")
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "other.example.com"}}, key)
+	require.NoError(err, "could not create certificate signing request")
+	quebecCertReq.Csr = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+	require.NoError(s.db.UpdateCertReq(ctx, quebecCertReq), "could not update quebec certreq")
+
+	s.certman.HandleCertificateRequests()
+	certReq, err := s.db.RetrieveCertReq(ctx, quebecCertReq.Id)
+	require.NoError(err)
+	require.Equal(models.CertificateRequestState_CR_REJECTED, certReq.Status)
+	require.Zero(certReq.BatchId, "certificate request should not have been submitted")
+}
+
+// Test that the certificate manager moves finished certificate requests to the archive.
+func (s *certTestSuite) TestCertManagerArchive() {
+	s.setupCertManager(sectigo.ProfileCipherTraceEE, fixtures.Full)
+	defer s.teardownCertManager()
+	require := s.Require()
+	ctx := context.Background()
+
+	// Create a certificate manager that archives finished requests almost immediately
+	conf := s.conf.CertMan
+	conf.ArchiveAfter = time.Millisecond
+	email, err := emails.New(s.conf.Email)
+	require.NoError(err, "could not create email manager")
+	email.SetResolver(emails.NewResolver(s.db, s.secret))
+	service, err := certman.New(conf, s.db, s.secret, email)
+	require.NoError(err, "could not create certificate manager")
+	s.certman = service.(*certman.CertificateManager)
+
+	fixture, err := s.fixtures.GetCertReq("quebec")
+	require.NoError(err, "could not get quebec certreq")
+	quebecCertReq, err := s.db.RetrieveCertReq(ctx, fixture.Id)
+	require.NoError(err, "could not retrieve quebec certreq")
+	quebecCertReq.Status = models.CertificateRequestState_COMPLETED
+	require.NoError(s.db.UpdateCertReq(ctx, quebecCertReq), "could not update quebec certreq")
+	time.Sleep(5 * time.Millisecond)
+
+	s.certman.HandleCertificateRequests()
+
+	// Only unfinished certificate requests should remain in the active namespace
+	iter := s.db.ListCertReqs(ctx)
+	for iter.Next() {
+		req, err := iter.CertReq()
+		require.NoError(err)
+		require.NotEqual(quebecCertReq.Id, req.Id, "completed certificate request was not archived")
+		require.False(models.CertificateRequestArchivable(req, time.Now()), "finished certificate request %s was not archived", req.Id)
+	}
+	require.NoError(iter.Error())
+	iter.Release()
+
+	// The archived certificate request should still be retrievable
+	archived, err := s.db.RetrieveArchivedCertReq(ctx, quebecCertReq.Id)
+	require.NoError(err, "could not retrieve archived certreq")
+	require.Equal(models.CertificateRequestState_COMPLETED, archived.Status)
+
+	certReq, err := s.db.RetrieveCertReq(ctx, quebecCertReq.Id)
+	require.NoError(err, "archived certreq should be retrievable by id")
+	require.True(proto.Equal(archived, certReq))
+
+	count, err := s.db.CountArchivedCertReqs(ctx)
+	require.NoError(err)
+	require.GreaterOrEqual(count, uint64(1))
+}
+
 func setupCertWebhook(s *certTestSuite, certreq *models.CertificateRequest) {
 	require := s.Require()
 	ctx := context.Background()
@@ -469,6 +623,141 @@ func (s *certTestSuite) TestCertManagerExpiration() {
 	emails.CheckEmails(s.T(), []*emails.EmailMeta{})
 }
 
+func (s *certTestSuite) TestCertManagerReissuanceSchedule() {
+	// Configure a schedule that only notifies the admins two weeks before expiration
+	s.schedules = config.ReissuanceSchedules{
+		sectigo.ProfileCipherTraceEE: {
+			{Offset: 14 * 24 * time.Hour, Audience: config.AudienceAdmins, Template: config.TemplateExpiresAdminNotification, Action: config.ActionRemind},
+		},
+	}
+	defer func() { s.schedules = nil }()
+
+	s.setupCertManager(sectigo.ProfileCipherTraceEE, fixtures.Small)
+	defer s.teardownCertManager()
+	defer s.fixtures.LoadReferenceFixtures()
+	require := s.Require()
+
+	charlieVASP, err := s.fixtures.GetVASP("charliebank")
+	require.NoError(err, "could not get charlie VASP")
+	charlieVASP = s.setupVASP(charlieVASP)
+
+	hotelVASP, err := s.fixtures.GetVASP("hotel")
+	require.NoError(err, "could not get hotel VASP")
+	hotelVASP = s.setupVASP(hotelVASP)
+
+	// No stages are due for charlieVASP at 29 days, the admins should be notified
+	// about hotelVASP at 13 days, and no other stages of the default schedule fire.
+	s.updateVaspIdentityCert(charlieVASP, 29)
+	s.updateVaspIdentityCert(hotelVASP, 13)
+	callTime := time.Now()
+	s.certman.HandleCertificateReissuance()
+
+	// Run the loop again to ensure that the stage does not fire twice
+	s.certman.HandleCertificateReissuance()
+
+	hotel, err := s.db.RetrieveVASP(context.Background(), hotelVASP.Id)
+	require.NoError(err)
+
+	stage := s.schedules[sectigo.ProfileCipherTraceEE][0].ID()
+	fired, err := models.ReissuanceStageFired(hotel, stage)
+	require.NoError(err)
+	require.True(fired, "expected the reissuance stage to be recorded on the VASP")
+
+	messages := []*emails.EmailMeta{
+		{
+			To:        s.conf.Email.AdminEmail,
+			From:      s.conf.Email.ServiceEmail,
+			Subject:   emails.ExpiresAdminNotificationRE,
+			Reason:    "expires_admin_notification",
+			Timestamp: callTime,
+		},
+	}
+	emails.CheckEmails(s.T(), messages)
+}
+
+// Test that a reissue stage that fails is not recorded so that it is retried on the
+// next run of the certificate manager.
+func (s *certTestSuite) TestCertManagerReissuanceRetry() {
+	s.schedules = config.ReissuanceSchedules{
+		sectigo.ProfileCipherTraceEE: {
+			{Offset: 10 * 24 * time.Hour, Action: config.ActionReissue},
+		},
+	}
+	defer func() { s.schedules = nil }()
+
+	s.setupCertManager(sectigo.ProfileCipherTraceEE, fixtures.Small)
+	defer s.teardownCertManager()
+	defer s.fixtures.LoadReferenceFixtures()
+	require := s.Require()
+	ctx := context.Background()
+
+	charlieVASP, err := s.fixtures.GetVASP("charliebank")
+	require.NoError(err, "could not get charlie VASP")
+	charlieVASP = s.setupVASP(charlieVASP)
+
+	// Ensure the other VASPs in the fixtures.Small set are not reissued
+	for _, name := range []string{"delta", "hotel"} {
+		vasp, err := s.fixtures.GetVASP(name)
+		require.NoError(err)
+		vasp.VerificationStatus = pb.VerificationState_REJECTED
+		require.NoError(s.db.UpdateVASP(ctx, vasp))
+	}
+
+	// Submit a CSR so that the reissuance does not require a password
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err, "could not generate private key")
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: charlieVASP.CommonName},
+		DNSNames: []string{charlieVASP.CommonName},
+	}, key)
+	require.NoError(err, "could not create certificate signing request")
+	csr := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+	require.NoError(models.SetReissuanceCSR(charlieVASP, csr))
+
+	// The reissue fails because the latest certificate request cannot be retrieved
+	missingID := "c2b0ab2c-2b10-4b0e-9c4d-3f5f0f1d7f3a"
+	require.NoError(models.AppendCertReqID(charlieVASP, missingID))
+	s.updateVaspIdentityCert(charlieVASP, 8)
+	s.certman.HandleCertificateReissuance()
+
+	stage := s.schedules[sectigo.ProfileCipherTraceEE][0].ID()
+	v, err := s.db.RetrieveVASP(ctx, charlieVASP.Id)
+	require.NoError(err)
+	fired, err := models.ReissuanceStageFired(v, stage)
+	require.NoError(err)
+	require.False(fired, "a failed reissue stage should not be recorded on the VASP")
+
+	latestID, err := models.GetLatestCertReqID(v)
+	require.NoError(err)
+	require.Equal(missingID, latestID, "no certificate request should be created when the reissue fails")
+
+	pending, err := models.GetReissuanceCSR(v)
+	require.NoError(err)
+	require.Equal(csr, pending, "the reissuance CSR should be kept for the retry")
+
+	// Once the failure is resolved the next run should perform the reissue
+	require.NoError(s.db.UpdateCertReq(ctx, &models.CertificateRequest{
+		Id:     missingID,
+		Vasp:   charlieVASP.Id,
+		Status: models.CertificateRequestState_COMPLETED,
+	}))
+	s.certman.HandleCertificateReissuance()
+
+	v, err = s.db.RetrieveVASP(ctx, charlieVASP.Id)
+	require.NoError(err)
+	fired, err = models.ReissuanceStageFired(v, stage)
+	require.NoError(err)
+	require.True(fired, "expected the reissue stage to be recorded once it succeeds")
+
+	latestID, err = models.GetLatestCertReqID(v)
+	require.NoError(err)
+	require.NotEqual(missingID, latestID, "expected a new certificate request to be created")
+	certReq, err := s.db.RetrieveCertReq(ctx, latestID)
+	require.NoError(err)
+	require.Equal(models.CertificateRequestState_READY_TO_SUBMIT, certReq.Status)
+	require.Equal(csr, certReq.Csr)
+}
+
 func (s *certTestSuite) TestCertManagerReissuance() {
 	require := s.Require()
 
@@ -604,6 +893,62 @@ func (s *certTestSuite) TestCertManagerReissuance() {
 	emails.CheckEmails(s.T(), messages)
 }
 
+// Test that certificates are reissued for the CSR submitted by the VASP for the
+// reissuance and that the CSR is only used once.
+func (s *certTestSuite) TestCertManagerReissuanceCSR() {
+	require := s.Require()
+
+	s.setupCertManager(sectigo.ProfileCipherTraceEE, fixtures.Small)
+	defer s.teardownCertManager()
+	defer s.fixtures.LoadReferenceFixtures()
+
+	charlieVASP, err := s.fixtures.GetVASP("charliebank")
+	require.NoError(err, "could not get charlie VASP")
+	charlieVASP = s.setupVASP(charlieVASP)
+
+	// Ensure the other VASPs in the fixtures.Small set are not reissued
+	for _, name := range []string{"delta", "hotel"} {
+		vasp, err := s.fixtures.GetVASP(name)
+		require.NoError(err)
+		vasp.VerificationStatus = pb.VerificationState_REJECTED
+		require.NoError(s.db.UpdateVASP(context.Background(), vasp))
+	}
+
+	// Submit a CSR for a new key pair for the reissuance
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err, "could not generate private key")
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: charlieVASP.CommonName},
+		DNSNames: []string{charlieVASP.CommonName},
+	}, key)
+	require.NoError(err, "could not create certificate signing request")
+	csr := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+
+	require.NoError(models.SetReissuanceCSR(charlieVASP, csr))
+	s.updateVaspIdentityCert(charlieVASP, 8)
+	s.certman.HandleCertificateReissuance()
+
+	// The certificate request should be created for the submitted CSR
+	v, err := s.db.RetrieveVASP(context.Background(), charlieVASP.Id)
+	require.NoError(err)
+	certReqID, err := models.GetLatestCertReqID(v)
+	require.NoError(err)
+	certReq, err := s.db.RetrieveCertReq(context.Background(), certReqID)
+	require.NoError(err)
+	require.Equal(models.CertificateRequestState_READY_TO_SUBMIT, certReq.Status)
+	require.Equal(csr, certReq.Csr)
+	require.NoError(models.ValidateCertificateRequestCSR(certReq))
+
+	// No password should be created for certificates issued from a CSR
+	_, err = s.secret.With(certReq.Id).GetLatestVersion(context.Background(), "password")
+	require.Error(err, "no password should be created for a CSR")
+
+	// The CSR should be cleared from the VASP so that it is not used again
+	pending, err := models.GetReissuanceCSR(v)
+	require.NoError(err)
+	require.Empty(pending, "reissuance CSR should only be used once")
+}
+
 func setupVASPWebhook(s *certTestSuite, vasp *pb.VASP) {
 	require := s.Require()
 	vasp.CertificateWebhook = s.courier.URL
@@ -1265,6 +1610,8 @@ func (s *certTestSuite) setupCertManager(profile string, fType fixtures.FixtureT
 	s.conf.CertMan.Storage = certPath
 	s.conf.CertMan.RequestInterval = time.Millisecond
 	s.conf.CertMan.Sectigo.Profile = profile
+	s.conf.CertMan.Schedules = s.schedules
+	s.conf.CertMan.DomainValidation = s.domains
 
 	// Initialize the configured store
 	switch s.fixtures.StoreType() {
@@ -1288,6 +1635,7 @@ func (s *certTestSuite) setupCertManager(profile string, fType fixtures.FixtureT
 	// Initialize the email manager
 	email, err := emails.New(s.conf.Email)
 	require.NoError(err, "could not create email manager")
+	email.SetResolver(emails.NewResolver(s.db, s.secret))
 
 	// Initialize the courier server
 	s.resetCourierHandler()
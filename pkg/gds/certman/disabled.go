@@ -1,9 +1,12 @@
 package certman
 
 import (
+	"context"
 	"sync"
 
 	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/sectigo"
 )
 
 // Disabled implements the certman.Service interface but is essentially a no-op that
@@ -34,3 +37,8 @@ func (d *Disabled) HandleCertificateRequests() {
 func (d *Disabled) HandleCertificateReissuance() {
 	log.Trace().Msg("certman is disabled: cannot handle certificate reissuance")
 }
+
+func (d *Disabled) RevokeCertificate(context.Context, string, string, sectigo.CRLReason, string) (*models.Certificate, error) {
+	log.Trace().Msg("certman is disabled: cannot revoke certificates")
+	return nil, ErrDisabled
+}
@@ -1,12 +1,14 @@
 package certman_test
 
 import (
+	"context"
 	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"github.com/trisacrypto/directory/pkg/gds/certman"
 	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/sectigo"
 )
 
 func TestDisabled(t *testing.T) {
@@ -27,4 +29,7 @@ func TestDisabled(t *testing.T) {
 	require.NotPanics(t, service.CertManager, "cert manager should not panic")
 	require.NotPanics(t, service.HandleCertificateRequests, "handle certificate requests should not panic")
 	require.NotPanics(t, service.HandleCertificateReissuance, "handle certificate reissuance should not panic")
+
+	_, err = service.RevokeCertificate(context.Background(), "vasp", "", sectigo.CRLRUnspecified, "test")
+	require.ErrorIs(t, err, certman.ErrDisabled, "expected revoke certificate to return an error")
 }
@@ -0,0 +1,221 @@
+package certman
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
+	"github.com/trisacrypto/directory/pkg/utils/netguard"
+	"github.com/trisacrypto/directory/pkg/utils/sentry"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+// Resolver looks up the TXT records of a domain, it is implemented by net.Resolver and
+// allows tests to stub out DNS queries.
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// DomainValidator checks ACME-style domain challenges to verify that the VASP controls
+// the domains of a certificate request before it is submitted to the CA. A challenge is
+// valid if the challenge value is published in a TXT record at the challenge subdomain
+// (dns-01) or if the token is served from the challenge path over HTTP (http-01).
+type DomainValidator struct {
+	Resolver Resolver
+	Client   *http.Client
+}
+
+// The maximum number of redirects followed when fetching an http-01 challenge.
+const maxChallengeRedirects = 5
+
+// NewDomainValidator returns a validator that uses the default DNS resolver. The HTTP
+// client only connects to public addresses so that the domain of a certificate request
+// cannot be used to make requests to internal services, and only follows a limited
+// number of redirects to the standard HTTP and HTTPS ports.
+func NewDomainValidator() *DomainValidator {
+	return &DomainValidator{
+		Resolver: net.DefaultResolver,
+		Client: &http.Client{
+			Transport:     netguard.NewTransport(),
+			CheckRedirect: checkChallengeRedirect,
+			Timeout:       10 * time.Second,
+		},
+	}
+}
+
+func checkChallengeRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxChallengeRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxChallengeRedirects)
+	}
+
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return fmt.Errorf("cannot redirect to %s scheme", req.URL.Scheme)
+	}
+
+	if port := req.URL.Port(); port != "" && port != "80" && port != "443" {
+		return fmt.Errorf("cannot redirect to port %s", port)
+	}
+	return nil
+}
+
+// Validate the challenge using DNS-01 then HTTP-01, returning the type of challenge
+// that validated the domain or an error describing why both challenges failed.
+func (v *DomainValidator) Validate(ctx context.Context, challenge *models.DomainChallenge) (_ string, err error) {
+	var dnsErr, httpErr error
+	if dnsErr = v.validateDNS(ctx, challenge); dnsErr == nil {
+		return models.ChallengeDNS01, nil
+	}
+
+	if httpErr = v.validateHTTP(ctx, challenge); httpErr == nil {
+		return models.ChallengeHTTP01, nil
+	}
+
+	return "", fmt.Errorf("%s: %s; %s: %s", models.ChallengeDNS01, dnsErr, models.ChallengeHTTP01, httpErr)
+}
+
+func (v *DomainValidator) validateDNS(ctx context.Context, challenge *models.DomainChallenge) (err error) {
+	var records []string
+	if records, err = v.Resolver.LookupTXT(ctx, challenge.DNSRecord()); err != nil {
+		return fmt.Errorf("could not lookup TXT record %s: %w", challenge.DNSRecord(), err)
+	}
+
+	for _, record := range records {
+		if strings.TrimSpace(record) == challenge.DNSValue() {
+			return nil
+		}
+	}
+	return fmt.Errorf("%d TXT records did not match challenge", len(records))
+}
+
+func (v *DomainValidator) validateHTTP(ctx context.Context, challenge *models.DomainChallenge) (err error) {
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(ctx, http.MethodGet, "http://"+challenge.Domain+challenge.HTTPPath(), nil); err != nil {
+		return err
+	}
+
+	var rep *http.Response
+	if rep, err = v.Client.Do(req); err != nil {
+		return fmt.Errorf("could not fetch challenge: %w", err)
+	}
+	defer rep.Body.Close()
+
+	if rep.StatusCode != http.StatusOK {
+		return fmt.Errorf("challenge request returned status %d", rep.StatusCode)
+	}
+
+	// The token is small so limit how much of the body is read from the domain
+	var body []byte
+	if body, err = io.ReadAll(io.LimitReader(rep.Body, 1024)); err != nil {
+		return fmt.Errorf("could not read challenge: %w", err)
+	}
+
+	if strings.TrimSpace(string(body)) != challenge.Token {
+		return errors.New("challenge response did not match token")
+	}
+	return nil
+}
+
+// SetDomainValidator allows tests to stub out the DNS and HTTP checks of domain
+// challenges.
+func (c *CertificateManager) SetDomainValidator(v *DomainValidator) {
+	c.domains = v
+}
+
+// validateDomains creates challenges for the domains of the certificate request, reusing
+// the challenges of previous requests of the VASP, and checks the pending challenges, saving the challenge state on the request. Returns
+// true if all challenges are valid and the request can be submitted. If the challenges
+// are not validated before the timeout the request is rejected. If domain validation
+// is not enabled then the request is always ready to be submitted.
+func (c *CertificateManager) validateDomains(ctx context.Context, r *models.CertificateRequest) (_ bool, err error) {
+	if !c.conf.DomainValidation.Enabled {
+		return true, nil
+	}
+
+	// Reuse the challenges of previous requests so that reissued certificates do not
+	// require the VASP to publish new tokens for domains it has already validated.
+	if len(r.Challenges) == 0 {
+		if err = c.reuseDomainChallenges(ctx, r); err != nil {
+			return false, err
+		}
+	}
+
+	if err = models.CreateDomainChallenges(r); err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	expired := false
+	for _, challenge := range r.Challenges {
+		if challenge.Status != models.DomainChallengeState_CHALLENGE_PENDING {
+			continue
+		}
+
+		challenge.Attempts++
+		challenge.LastChecked = now.Format(time.RFC3339)
+
+		var method string
+		if method, err = c.domains.Validate(ctx, challenge); err == nil {
+			challenge.Status = models.DomainChallengeState_CHALLENGE_VALID
+			challenge.ValidatedBy = method
+			challenge.Validated = now.Format(time.RFC3339)
+			challenge.Error = ""
+			continue
+		}
+		challenge.Error = err.Error()
+
+		var created time.Time
+		if created, err = time.Parse(time.RFC3339, challenge.Created); err != nil || now.Sub(created) > c.conf.DomainValidation.Timeout {
+			challenge.Status = models.DomainChallengeState_CHALLENGE_EXPIRED
+			expired = true
+		}
+	}
+
+	if expired {
+		sentry.Warn(ctx).Str("id", r.Id).Str("common_name", r.CommonName).Msg("domain challenges expired before validation")
+		if err = models.UpdateCertificateRequestStatus(r, models.CertificateRequestState_CR_REJECTED, "domain validation failed", "automated"); err != nil {
+			return false, err
+		}
+	}
+
+	if err = c.db.UpdateCertReq(ctx, r); err != nil {
+		return false, fmt.Errorf("could not save domain challenges: %w", err)
+	}
+	return !expired && models.DomainChallengesValid(r), nil
+}
+
+// reuseDomainChallenges adds the challenges of the most recent previous certificate
+// requests of the VASP to the certificate request.
+func (c *CertificateManager) reuseDomainChallenges(ctx context.Context, r *models.CertificateRequest) (err error) {
+	var vasp *pb.VASP
+	if vasp, err = c.db.RetrieveVASP(ctx, r.Vasp); err != nil {
+		return fmt.Errorf("could not retrieve vasp to reuse domain challenges: %w", err)
+	}
+
+	var certReqIDs []string
+	if certReqIDs, err = models.GetCertReqIDs(vasp); err != nil {
+		return err
+	}
+
+	for i := len(certReqIDs) - 1; i >= 0; i-- {
+		if certReqIDs[i] == r.Id {
+			continue
+		}
+
+		var previous *models.CertificateRequest
+		if previous, err = c.db.RetrieveCertReq(ctx, certReqIDs[i]); err != nil {
+			if errors.Is(err, storeerrors.ErrEntityNotFound) {
+				continue
+			}
+			return fmt.Errorf("could not retrieve certificate request to reuse domain challenges: %w", err)
+		}
+		models.ReuseDomainChallenges(r, previous, c.conf.DomainValidation.Reuse)
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+package certman_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trisacrypto/directory/pkg/gds/certman"
+	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/gds/fixtures"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/sectigo"
+	"github.com/trisacrypto/directory/pkg/utils/netguard"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+// Test that certificate requests are only submitted once the VASP has proven control
+// of all of the domains in the request.
+func (s *certTestSuite) TestCertManagerDomainValidation() {
+	s.domains = config.DomainValidationConfig{Enabled: true, Timeout: time.Hour}
+	defer func() { s.domains = config.DomainValidationConfig{} }()
+
+	s.setupCertManager(sectigo.ProfileCipherTraceEE, fixtures.Full)
+	defer s.teardownCertManager()
+	require := s.Require()
+	ctx := context.Background()
+
+	challenges := newChallengeServer()
+	defer challenges.Close()
+	s.certman.SetDomainValidator(challenges.Validator())
+
+	quebecCertReq, err := s.fixtures.GetCertReq("quebec")
+	require.NoError(err, "could not get quebec certreq")
+
+	sm := s.secret.With(quebecCertReq.Id)
+	require.NoError(sm.CreateSecret(ctx, "password"))
+	require.NoError(sm.AddSecretVersion(ctx, "password", []byte("qDhAwnfMjgDEzzUC")))
+
+	// Challenges are created for the domains but the request is not submitted
+	s.certman.HandleCertificateRequests()
+	certReq, err := s.db.RetrieveCertReq(ctx, quebecCertReq.Id)
+	require.NoError(err)
+	require.Equal(models.CertificateRequestState_READY_TO_SUBMIT, certReq.Status)
+	require.Zero(certReq.BatchId, "certificate request should not have been submitted")
+	require.Len(certReq.Challenges, 2)
+
+	for i, domain := range []string{"trisa.echo.io", "echo.tauceti.io"} {
+		challenge := certReq.Challenges[i]
+		require.Equal(domain, challenge.Domain)
+		require.Equal(models.DomainChallengeState_CHALLENGE_PENDING, challenge.Status)
+		require.NotEmpty(challenge.Token)
+		require.Equal(uint32(1), challenge.Attempts)
+		require.NotEmpty(challenge.LastChecked)
+		require.NotEmpty(challenge.Error)
+	}
+
+	// Complete one challenge with DNS and the other over HTTP
+	challenges.PublishTXT(certReq.Challenges[0].DNSRecord(), certReq.Challenges[0].DNSValue())
+	challenges.ServeToken(certReq.Challenges[1].HTTPPath(), certReq.Challenges[1].Token)
+
+	s.certman.HandleCertificateRequests()
+	certReq, err = s.db.RetrieveCertReq(ctx, quebecCertReq.Id)
+	require.NoError(err)
+	require.Equal(models.CertificateRequestState_PROCESSING, certReq.Status)
+	require.Greater(int(certReq.BatchId), 0)
+	require.True(models.DomainChallengesValid(certReq))
+
+	require.Equal(models.ChallengeDNS01, certReq.Challenges[0].ValidatedBy)
+	require.Equal(models.ChallengeHTTP01, certReq.Challenges[1].ValidatedBy)
+	for _, challenge := range certReq.Challenges {
+		require.Equal(uint32(2), challenge.Attempts)
+		require.NotEmpty(challenge.Validated)
+		require.Empty(challenge.Error)
+	}
+}
+
+// Test that certificate requests are rejected if the domains are not validated before
+// the challenges expire.
+func (s *certTestSuite) TestCertManagerDomainValidationExpired() {
+	s.domains = config.DomainValidationConfig{Enabled: true, Timeout: time.Hour}
+	defer func() { s.domains = config.DomainValidationConfig{} }()
+
+	s.setupCertManager(sectigo.ProfileCipherTraceEE, fixtures.Full)
+	defer s.teardownCertManager()
+	require := s.Require()
+	ctx := context.Background()
+
+	challenges := newChallengeServer()
+	defer challenges.Close()
+	s.certman.SetDomainValidator(challenges.Validator())
+
+	quebecCertReq, err := s.fixtures.GetCertReq("quebec")
+	require.NoError(err, "could not get quebec certreq")
+
+	// Create challenges that were issued before the timeout
+	certReq, err := s.db.RetrieveCertReq(ctx, quebecCertReq.Id)
+	require.NoError(err)
+	require.NoError(models.CreateDomainChallenges(certReq))
+	for _, challenge := range certReq.Challenges {
+		challenge.Created = time.Now().Add(-2 * time.Hour).Format(time.RFC3339)
+	}
+	require.NoError(s.db.UpdateCertReq(ctx, certReq))
+
+	// Validated challenges do not expire
+	challenges.PublishTXT(certReq.Challenges[0].DNSRecord(), certReq.Challenges[0].DNSValue())
+
+	s.certman.HandleCertificateRequests()
+	certReq, err = s.db.RetrieveCertReq(ctx, quebecCertReq.Id)
+	require.NoError(err)
+	require.Equal(models.CertificateRequestState_CR_REJECTED, certReq.Status)
+	require.Zero(certReq.BatchId, "certificate request should not have been submitted")
+	require.Equal(models.DomainChallengeState_CHALLENGE_VALID, certReq.Challenges[0].Status)
+	require.Equal(models.DomainChallengeState_CHALLENGE_EXPIRED, certReq.Challenges[1].Status)
+	require.NotEmpty(certReq.Challenges[1].Error)
+}
+
+// challengeServer stands in for the DNS records and web servers of the domains in a
+// certificate request so that challenges can be completed in tests.
+type challengeServer struct {
+	sync.RWMutex
+	records map[string][]string
+	tokens  map[string]string
+	srv     *httptest.Server
+}
+
+func newChallengeServer() *challengeServer {
+	c := &challengeServer{
+		records: make(map[string][]string),
+		tokens:  make(map[string]string),
+	}
+	c.srv = httptest.NewServer(http.HandlerFunc(c.serveHTTP))
+	return c
+}
+
+// Validator returns a domain validator that resolves TXT records from the published
+// records and sends all HTTP requests to the test server regardless of the domain.
+func (c *challengeServer) Validator() *certman.DomainValidator {
+	addr := c.srv.Listener.Addr().String()
+	dialer := &net.Dialer{}
+	return &certman.DomainValidator{
+		Resolver: c,
+		Client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+					return dialer.DialContext(ctx, network, addr)
+				},
+			},
+		},
+	}
+}
+
+func (c *challengeServer) PublishTXT(name, value string) {
+	c.Lock()
+	defer c.Unlock()
+	c.records[name] = append(c.records[name], value)
+}
+
+func (c *challengeServer) ServeToken(path, token string) {
+	c.Lock()
+	defer c.Unlock()
+	c.tokens[path] = token
+}
+
+func (c *challengeServer) LookupTXT(_ context.Context, name string) ([]string, error) {
+	c.RLock()
+	defer c.RUnlock()
+	if records, ok := c.records[name]; ok {
+		return records, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+func (c *challengeServer) Close() {
+	c.srv.Close()
+}
+
+func (c *challengeServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	c.RLock()
+	defer c.RUnlock()
+	if token, ok := c.tokens[r.URL.Path]; ok && strings.HasPrefix(r.URL.Path, models.ChallengeHTTPPrefix) {
+		w.Write([]byte(token))
+		return
+	}
+	http.NotFound(w, r)
+}
+
+// Test that automated reissuance reuses the domain challenges of previous certificate
+// requests so that the VASP does not have to publish a new token for every reissue.
+func (s *certTestSuite) TestCertManagerDomainValidationReissuance() {
+	s.domains = config.DomainValidationConfig{Enabled: true, Timeout: time.Hour, Reuse: 24 * time.Hour}
+	s.schedules = config.ReissuanceSchedules{
+		sectigo.ProfileCipherTraceEE: {
+			{Offset: 10 * 24 * time.Hour, Action: config.ActionReissue},
+		},
+	}
+	defer func() {
+		s.domains = config.DomainValidationConfig{}
+		s.schedules = nil
+	}()
+
+	s.setupCertManager(sectigo.ProfileCipherTraceEE, fixtures.Small)
+	defer s.teardownCertManager()
+	defer s.fixtures.LoadReferenceFixtures()
+	require := s.Require()
+	ctx := context.Background()
+
+	challenges := newChallengeServer()
+	defer challenges.Close()
+	s.certman.SetDomainValidator(challenges.Validator())
+
+	charlieVASP, err := s.fixtures.GetVASP("charliebank")
+	require.NoError(err, "could not get charlie VASP")
+	charlieVASP = s.setupVASP(charlieVASP)
+
+	// Ensure the other VASPs in the fixtures.Small set are not reissued
+	for _, name := range []string{"delta", "hotel"} {
+		vasp, err := s.fixtures.GetVASP(name)
+		require.NoError(err)
+		vasp.VerificationStatus = pb.VerificationState_REJECTED
+		require.NoError(s.db.UpdateVASP(ctx, vasp))
+	}
+
+	// The domain was validated for the original certificate request longer ago than
+	// the reuse duration but the VASP still publishes the TXT record of the challenge.
+	previous := &models.CertificateRequest{
+		Id:         "7d8c3b9e-5c1a-4f0e-8d2b-9a6f4e1c2b3d",
+		Vasp:       charlieVASP.Id,
+		CommonName: charlieVASP.CommonName,
+		Status:     models.CertificateRequestState_COMPLETED,
+		Challenges: []*models.DomainChallenge{
+			{
+				Domain:      strings.ToLower(charlieVASP.CommonName),
+				Token:       "charlie-challenge-token",
+				Status:      models.DomainChallengeState_CHALLENGE_VALID,
+				ValidatedBy: models.ChallengeDNS01,
+				Validated:   time.Now().AddDate(0, 0, -60).Format(time.RFC3339),
+			},
+		},
+	}
+	require.NoError(s.db.UpdateCertReq(ctx, previous))
+	require.NoError(models.AppendCertReqID(charlieVASP, previous.Id))
+	challenges.PublishTXT(previous.Challenges[0].DNSRecord(), previous.Challenges[0].DNSValue())
+
+	// Reissue the certificates from a CSR
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err, "could not generate private key")
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: charlieVASP.CommonName},
+		DNSNames: []string{charlieVASP.CommonName},
+	}, key)
+	require.NoError(err, "could not create certificate signing request")
+	require.NoError(models.SetReissuanceCSR(charlieVASP, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))))
+	s.updateVaspIdentityCert(charlieVASP, 8)
+	s.certman.HandleCertificateReissuance()
+
+	v, err := s.db.RetrieveVASP(ctx, charlieVASP.Id)
+	require.NoError(err)
+	certReqID, err := models.GetLatestCertReqID(v)
+	require.NoError(err)
+	require.NotEqual(previous.Id, certReqID, "expected a certificate request to be created for the reissuance")
+
+	// The reissued request is validated with the previous token and submitted
+	s.certman.HandleCertificateRequests()
+	certReq, err := s.db.RetrieveCertReq(ctx, certReqID)
+	require.NoError(err)
+	require.Equal(models.CertificateRequestState_PROCESSING, certReq.Status)
+	require.Greater(int(certReq.BatchId), 0)
+	require.Len(certReq.Challenges, 1)
+
+	challenge := certReq.Challenges[0]
+	require.Equal(previous.Challenges[0].Token, challenge.Token, "expected the previous challenge token to be reused")
+	require.Equal(models.DomainChallengeState_CHALLENGE_VALID, challenge.Status)
+	require.Equal(models.ChallengeDNS01, challenge.ValidatedBy)
+	require.Equal(uint32(1), challenge.Attempts)
+}
+
+// Test that the default domain validator does not fetch http-01 challenges from
+// loopback or private addresses.
+func (s *certTestSuite) TestDomainValidatorNonPublic() {
+	require := s.Require()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("token"))
+	}))
+	defer srv.Close()
+
+	challenge := &models.DomainChallenge{Domain: srv.Listener.Addr().String(), Token: "token"}
+	_, err := certman.NewDomainValidator().Validate(context.Background(), challenge)
+	require.Error(err)
+	require.Contains(err.Error(), netguard.ErrNonPublicAddress.Error())
+}
@@ -1,6 +1,12 @@
 package certman
 
-import "sync"
+import (
+	"context"
+	"sync"
+
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/sectigo"
+)
 
 // Service defines the CertMan go routine interface for outside users to interact with
 // the certificate manager directly.
@@ -10,4 +16,5 @@ type Service interface {
 	CertManager()
 	HandleCertificateRequests()
 	HandleCertificateReissuance()
+	RevokeCertificate(ctx context.Context, vaspID, certID string, reason sectigo.CRLReason, source string) (*models.Certificate, error)
 }
@@ -0,0 +1,320 @@
+package certman
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/sectigo"
+	"github.com/trisacrypto/trisa/pkg/trust"
+)
+
+// LocalAuthority is a self-managed certificate authority that signs certificates with
+// CA key material on disk, e.g. created by the certs init command. Certificates are
+// issued as soon as a request is submitted and are stored in the cert directory until
+// they are downloaded. The local CA does not publish revocation lists, revocations are
+// appended to a log in the cert directory so that operators can distribute them.
+type LocalAuthority struct {
+	cacrt    *x509.Certificate
+	cakey    interface{}
+	validity time.Duration
+	certDir  string
+	revokes  sync.Mutex
+}
+
+// The maximum number of random batch IDs tried before the local CA gives up on
+// finding an unused certificate file in the cert directory.
+const maxBatchAttempts = 16
+
+// The file in the cert directory that revocations are appended to.
+const localRevocations = "local-revocations.jsonl"
+
+// A revocation in the revocation log of the local CA.
+type localRevocation struct {
+	CertID    string            `json:"cert_id"`
+	Reason    sectigo.CRLReason `json:"reason"`
+	RevokedOn string            `json:"revoked_on"`
+}
+
+// Compile time interface implementation check.
+var _ CertificateAuthority = &LocalAuthority{}
+
+func NewLocalAuthority(conf config.LocalCAConfig, certDir string) (ca *LocalAuthority, err error) {
+	var sz *trust.Serializer
+	if sz, err = trust.NewSerializer(false); err != nil {
+		return nil, err
+	}
+
+	var provider *trust.Provider
+	if provider, err = sz.ReadFile(conf.Certs); err != nil {
+		return nil, fmt.Errorf("could not read local CA certs: %w", err)
+	}
+
+	var keypair tls.Certificate
+	if keypair, err = provider.GetKeyPair(); err != nil {
+		return nil, fmt.Errorf("local CA certs must contain the CA private key: %w", err)
+	}
+
+	ca = &LocalAuthority{
+		cakey:    keypair.PrivateKey,
+		validity: conf.Validity,
+		certDir:  certDir,
+	}
+
+	if ca.cacrt, err = x509.ParseCertificate(keypair.Certificate[0]); err != nil {
+		return nil, err
+	}
+
+	if !ca.cacrt.IsCA {
+		return nil, errors.New("local CA certificate is not a certificate authority")
+	}
+	return ca, nil
+}
+
+// Balance is unlimited since the local CA signs its own certificates.
+func (l *LocalAuthority) Balance() (authority, available int, err error) {
+	return 0, math.MaxInt32, nil
+}
+
+// Submit issues the certificates for the request immediately and stores the zip file of
+// the certificates in the cert directory. If the request has a CSR the certificate is
+// issued for the public key of the CSR, otherwise a key pair is generated and the
+// certificates are encrypted with the PKCS12 password of the request.
+func (l *LocalAuthority) Submit(r *models.CertificateRequest, _ int, batchName string) (_ *Batch, err error) {
+	batch := &Batch{
+		BatchName:    batchName,
+		Status:       sectigo.BatchStatusReadyForDownload,
+		CreationDate: time.Now().Format(time.RFC3339),
+		Profile:      config.AuthorityLocal,
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber(),
+		Subject: pkix.Name{
+			CommonName: r.CommonName,
+		},
+		NotBefore:   time.Now(),
+		NotAfter:    time.Now().Add(l.validity),
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		DNSNames:    dnsNames(r),
+	}
+
+	// Add the optional subject parameters of the request to the certificate
+	for param, field := range map[string]*[]string{
+		sectigo.ParamOrganizationName:    &template.Subject.Organization,
+		sectigo.ParamLocalityName:        &template.Subject.Locality,
+		sectigo.ParamStateOrProvinceName: &template.Subject.Province,
+		sectigo.ParamCountryName:         &template.Subject.Country,
+	} {
+		if value := r.Params[param]; value != "" {
+			*field = []string{value}
+		}
+	}
+
+	var (
+		pub  interface{}
+		priv *rsa.PrivateKey
+	)
+
+	if r.Csr != "" {
+		var csr *x509.CertificateRequest
+		if csr, err = models.ParseCSR([]byte(r.Csr)); err != nil {
+			return nil, fmt.Errorf("could not parse certificate signing request: %w", err)
+		}
+		pub = csr.PublicKey
+	} else {
+		if r.Params[sectigo.ParamPassword] == "" {
+			return nil, errors.New("a pkcs12 password is required to issue certificates without a csr")
+		}
+
+		if priv, err = rsa.GenerateKey(rand.Reader, 4096); err != nil {
+			return nil, fmt.Errorf("could not create private key: %w", err)
+		}
+		pub = &priv.PublicKey
+	}
+
+	var signed []byte
+	if signed, err = x509.CreateCertificate(rand.Reader, template, l.cacrt, pub, l.cakey); err != nil {
+		return nil, fmt.Errorf("could not sign certificate: %w", err)
+	}
+
+	// Encode the certificate chain and the private key, if generated, as PEM blocks
+	var chain []byte
+	for _, der := range [][]byte{signed, l.cacrt.Raw} {
+		var cert *x509.Certificate
+		if cert, err = x509.ParseCertificate(der); err != nil {
+			return nil, err
+		}
+
+		var block []byte
+		if block, err = trust.PEMEncodeCertificate(cert); err != nil {
+			return nil, err
+		}
+		chain = append(chain, block...)
+	}
+
+	if priv != nil {
+		var block []byte
+		if block, err = trust.PEMEncodePrivateKey(priv); err != nil {
+			return nil, err
+		}
+		chain = append(chain, block...)
+	}
+
+	var provider *trust.Provider
+	if provider, err = trust.New(chain); err != nil {
+		return nil, err
+	}
+
+	// Certificates with a private key are encrypted in the same format as Sectigo
+	var sz *trust.Serializer
+	if priv != nil {
+		sz, err = trust.NewSerializer(true, r.Params[sectigo.ParamPassword], trust.CompressionZIP)
+	} else {
+		sz, err = trust.NewSerializer(false, "", trust.CompressionZIP)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	if data, err = sz.Compress(provider); err != nil {
+		return nil, err
+	}
+
+	if batch.BatchID, err = l.write(data); err != nil {
+		return nil, fmt.Errorf("could not write issued certificates: %w", err)
+	}
+
+	batch.Success = 1
+	return batch, nil
+}
+
+// Status reports that the certificates are ready for download if they have been issued.
+func (l *LocalAuthority) Status(r *models.CertificateRequest) (_ *Batch, err error) {
+	batch := &Batch{
+		BatchID:      int(r.BatchId),
+		BatchName:    r.BatchName,
+		CreationDate: r.CreationDate,
+		Profile:      config.AuthorityLocal,
+	}
+
+	if _, err = os.Stat(l.path(batch.BatchID)); err != nil {
+		if os.IsNotExist(err) {
+			batch.Status = sectigo.BatchStatusFailed
+			batch.Failed = 1
+			return batch, nil
+		}
+		return nil, err
+	}
+
+	batch.Status = sectigo.BatchStatusReadyForDownload
+	batch.Success = 1
+	return batch, nil
+}
+
+// Download returns the path to the certificates issued for the request. The
+// certificates are already stored in the cert directory so they are not copied.
+func (l *LocalAuthority) Download(r *models.CertificateRequest, dir string) (path string, err error) {
+	path = l.path(int(r.BatchId))
+	if _, err = os.Stat(path); err != nil {
+		return "", fmt.Errorf("could not find certificates for batch %d: %w", r.BatchId, err)
+	}
+	return path, nil
+}
+
+// Revoke appends the revocation to the revocation log in the cert directory since the
+// local CA does not publish revocation lists.
+func (l *LocalAuthority) Revoke(certID string, reason sectigo.CRLReason) (err error) {
+	var record []byte
+	if record, err = json.Marshal(&localRevocation{
+		CertID:    certID,
+		Reason:    reason,
+		RevokedOn: time.Now().Format(time.RFC3339),
+	}); err != nil {
+		return err
+	}
+
+	l.revokes.Lock()
+	defer l.revokes.Unlock()
+
+	var f *os.File
+	if f, err = os.OpenFile(filepath.Join(l.certDir, localRevocations), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600); err != nil {
+		return fmt.Errorf("could not open revocation log: %w", err)
+	}
+
+	if _, err = f.Write(append(record, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("could not record revocation: %w", err)
+	}
+	return f.Close()
+}
+
+// Writes the certificates to a new file with a random batch ID. The file is created
+// exclusively so that certificates issued before a restart are never overwritten.
+func (l *LocalAuthority) write(data []byte) (batchID int, err error) {
+	for i := 0; i < maxBatchAttempts; i++ {
+		var n *big.Int
+		if n, err = rand.Int(rand.Reader, big.NewInt(math.MaxInt32)); err != nil {
+			return 0, err
+		}
+		batchID = int(n.Int64()) + 1
+
+		var f *os.File
+		if f, err = os.OpenFile(l.path(batchID), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600); err != nil {
+			if os.IsExist(err) {
+				continue
+			}
+			return 0, err
+		}
+
+		if _, err = f.Write(data); err != nil {
+			f.Close()
+			os.Remove(l.path(batchID))
+			return 0, err
+		}
+
+		if err = f.Close(); err != nil {
+			os.Remove(l.path(batchID))
+			return 0, err
+		}
+		return batchID, nil
+	}
+	return 0, errors.New("could not find an unused batch ID")
+}
+
+func (l *LocalAuthority) path(batchID int) string {
+	return filepath.Join(l.certDir, fmt.Sprintf("local-%d.zip", batchID))
+}
+
+// Returns the common name and any additional DNS names of the request.
+func dnsNames(r *models.CertificateRequest) []string {
+	names := []string{r.CommonName}
+	for _, name := range r.DnsNames {
+		if name = strings.TrimSpace(name); name != "" && name != r.CommonName {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func serialNumber() *big.Int {
+	sn := make([]byte, 16)
+	rand.Read(sn)
+	return new(big.Int).SetBytes(sn)
+}
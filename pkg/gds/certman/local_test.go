@@ -0,0 +1,220 @@
+package certman_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/gds/certman"
+	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/sectigo"
+	"github.com/trisacrypto/trisa/pkg/trust"
+)
+
+func TestLocalAuthority(t *testing.T) {
+	dir := t.TempDir()
+	conf := config.CertManConfig{
+		Authority: config.AuthorityLocal,
+		LocalCA: config.LocalCAConfig{
+			Certs:    filepath.Join(dir, "missing.gz"),
+			Validity: 24 * time.Hour,
+		},
+	}
+
+	// The CA certs must exist on disk
+	_, err := certman.NewCertificateAuthority(conf, dir)
+	require.Error(t, err, "expected an error when the CA certs do not exist")
+
+	// The local authority is selected from the configuration
+	var cacrt *x509.Certificate
+	conf.LocalCA.Certs, cacrt = createLocalCA(t, dir)
+	ca, err := certman.NewCertificateAuthority(conf, dir)
+	require.NoError(t, err, "could not create local certificate authority")
+	require.IsType(t, &certman.LocalAuthority{}, ca)
+
+	_, available, err := ca.Balance()
+	require.NoError(t, err)
+	require.Greater(t, available, 0)
+
+	// A password is required to issue certificates without a CSR
+	req := &models.CertificateRequest{
+		Id:         "b5841869-105f-411c-8722-4045aad72717",
+		CommonName: "trisa.example.com",
+		DnsNames:   []string{"api.example.com"},
+		Params: map[string]string{
+			sectigo.ParamOrganizationName: "Example VASP",
+		},
+	}
+	_, err = ca.Submit(req, 0, "test-certreq")
+	require.EqualError(t, err, "a pkcs12 password is required to issue certificates without a csr")
+
+	// Certificates issued without a CSR are encrypted with the password
+	req.Params[sectigo.ParamPassword] = "supersecretsquirrel"
+	batch, err := ca.Submit(req, 0, "test-certreq")
+	require.NoError(t, err, "could not issue certificates")
+	require.Equal(t, sectigo.BatchStatusReadyForDownload, batch.Status)
+	require.Equal(t, "test-certreq", batch.BatchName)
+	require.Equal(t, 1, batch.Success)
+
+	req.BatchId = int64(batch.BatchID)
+	status, err := ca.Status(req)
+	require.NoError(t, err)
+	require.Equal(t, sectigo.BatchStatusReadyForDownload, status.Status)
+	require.Equal(t, 1, status.Success)
+
+	path, err := ca.Download(req, dir)
+	require.NoError(t, err, "could not download certificates")
+
+	sz, err := trust.NewSerializer(true, "supersecretsquirrel", trust.CompressionZIP)
+	require.NoError(t, err)
+	provider, err := sz.ReadFile(path)
+	require.NoError(t, err, "could not decrypt issued certificates")
+	require.True(t, provider.IsPrivate(), "expected the private key to be included")
+
+	leaf, err := provider.GetLeafCertificate()
+	require.NoError(t, err)
+	require.NoError(t, leaf.CheckSignatureFrom(cacrt), "certificate was not signed by the local CA")
+	require.Equal(t, "trisa.example.com", leaf.Subject.CommonName)
+	require.Equal(t, []string{"Example VASP"}, leaf.Subject.Organization)
+	require.Equal(t, []string{"trisa.example.com", "api.example.com"}, leaf.DNSNames)
+	require.WithinDuration(t, time.Now().Add(24*time.Hour), leaf.NotAfter, time.Minute)
+
+	// Certificates issued from a CSR are only the public chain for the CSR public key
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: req.CommonName},
+		DNSNames: []string{req.CommonName},
+	}, key)
+	require.NoError(t, err)
+
+	csrReq := &models.CertificateRequest{
+		Id:         "2a5b7e8c-0c8f-4b5e-9d3c-0b8f6c6f1e2d",
+		CommonName: req.CommonName,
+		Csr:        string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})),
+	}
+	batch, err = ca.Submit(csrReq, 0, "test-csr")
+	require.NoError(t, err, "could not issue certificates for csr")
+	require.NotEqual(t, int(req.BatchId), batch.BatchID, "expected a unique batch ID")
+
+	csrReq.BatchId = int64(batch.BatchID)
+	path, err = ca.Download(csrReq, dir)
+	require.NoError(t, err)
+
+	sz, err = trust.NewSerializer(false, "", trust.CompressionZIP)
+	require.NoError(t, err)
+	provider, err = sz.ReadFile(path)
+	require.NoError(t, err)
+	require.False(t, provider.IsPrivate(), "no private key should be issued for a csr")
+
+	leaf, err = provider.GetLeafCertificate()
+	require.NoError(t, err)
+	match, err := models.MatchesCSR(leaf, []byte(csrReq.Csr))
+	require.NoError(t, err)
+	require.True(t, match, "certificate was not issued for the csr")
+
+	// Batches that were not issued by the CA have failed
+	status, err = ca.Status(&models.CertificateRequest{BatchId: 42})
+	require.NoError(t, err)
+	require.Equal(t, sectigo.BatchStatusFailed, status.Status)
+	require.Equal(t, 1, status.Failed)
+}
+
+func TestLocalAuthorityRestart(t *testing.T) {
+	dir := t.TempDir()
+	conf := config.LocalCAConfig{Validity: 24 * time.Hour}
+	conf.Certs, _ = createLocalCA(t, dir)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "trisa.example.com"},
+	}, key)
+	require.NoError(t, err)
+	req := &models.CertificateRequest{
+		CommonName: "trisa.example.com",
+		Csr:        string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})),
+	}
+
+	// Certificates issued before a restart must not be overwritten after the restart
+	paths := make(map[int]string)
+	for i := 0; i < 2; i++ {
+		ca, err := certman.NewLocalAuthority(conf, dir)
+		require.NoError(t, err)
+
+		for j := 0; j < 4; j++ {
+			batch, err := ca.Submit(req, 0, "test-restart")
+			require.NoError(t, err)
+			require.NotContains(t, paths, batch.BatchID, "expected a unique batch ID")
+
+			path, err := ca.Download(&models.CertificateRequest{BatchId: int64(batch.BatchID)}, dir)
+			require.NoError(t, err)
+			paths[batch.BatchID] = path
+		}
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NotEmpty(t, data)
+	}
+
+	// Revocations are recorded in the cert directory
+	ca, err := certman.NewLocalAuthority(conf, dir)
+	require.NoError(t, err)
+	require.NoError(t, ca.Revoke("42", sectigo.CRLRKeyCompromise))
+	require.NoError(t, ca.Revoke("43", sectigo.CRLRSuperseded))
+
+	data, err := os.ReadFile(filepath.Join(dir, "local-revocations.jsonl"))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], `"cert_id":"42"`)
+	require.Contains(t, lines[1], `"cert_id":"43"`)
+}
+
+// Creates CA certs in the directory and returns the path to the certs and the CA cert.
+func createLocalCA(t *testing.T, dir string) (string, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1942),
+		Subject:               pkix.Name{CommonName: "Local TRISA CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	signed, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cacrt, err := x509.ParseCertificate(signed)
+	require.NoError(t, err)
+
+	chain, err := trust.PEMEncodeCertificate(cacrt)
+	require.NoError(t, err)
+	block, err := trust.PEMEncodePrivateKey(key)
+	require.NoError(t, err)
+
+	provider, err := trust.New(append(chain, block...))
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "ca.gz")
+	sz, err := trust.NewSerializer(false)
+	require.NoError(t, err)
+	require.NoError(t, sz.WriteFile(provider, path))
+	return path, cacrt
+}
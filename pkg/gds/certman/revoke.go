@@ -0,0 +1,180 @@
+package certman
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/sectigo"
+	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+var (
+	ErrDisabled            = errors.New("certificate manager is disabled")
+	ErrNoCertificate       = errors.New("VASP does not have an identity certificate")
+	ErrCertificateNotFound = errors.New("certificate not found for VASP")
+	ErrAlreadyRevoked      = errors.New("certificate has already been revoked")
+)
+
+// RevokeCertificate revokes a certificate issued to the VASP with the certificate
+// authority using the RFC 5280 reason code, then marks the certificate record as
+// revoked. If certID is empty the current identity certificate of the VASP is revoked.
+// When the identity certificate is revoked the VASP remains listed in the directory so
+// that Lookup and Verification can report the revocation and RevokedOn timestamp to
+// counterparties; the revocation is recorded in the audit log of the VASP.
+//
+// The certificate authority and the certificate record are updated before the VASP, so
+// if the VASP cannot be updated the revocation can be resumed by calling this method
+// again: a revoked certificate whose revocation has not been applied to the VASP is not
+// revoked with the certificate authority a second time.
+func (c *CertificateManager) RevokeCertificate(ctx context.Context, vaspID, certID string, reason sectigo.CRLReason, source string) (cert *models.Certificate, err error) {
+	var vasp *pb.VASP
+	if vasp, err = c.db.RetrieveVASP(ctx, vaspID); err != nil {
+		return nil, err
+	}
+
+	if certID == "" {
+		if vasp.IdentityCertificate == nil || len(vasp.IdentityCertificate.SerialNumber) == 0 {
+			return nil, ErrNoCertificate
+		}
+		certID = models.GetCertID(vasp.IdentityCertificate)
+	}
+
+	if cert, err = c.retrieveVASPCert(ctx, vasp, certID); err != nil {
+		return nil, err
+	}
+
+	if cert.Status == models.CertificateState_REVOKED {
+		var pending bool
+		if pending, err = revocationPending(vasp, cert.Id); err != nil {
+			return nil, err
+		}
+
+		if !pending {
+			return nil, ErrAlreadyRevoked
+		}
+
+		// Resume a previous revocation that did not update the VASP record
+		reason = sectigo.CRLReason(cert.RevocationReason)
+		log.Warn().Str("vasp", vasp.Id).Str("serial_number", cert.Id).Msg("resuming interrupted certificate revocation")
+	} else {
+		// Revoke the certificate with the certificate authority
+		if err = c.ca.Revoke(cert.Id, reason); err != nil {
+			return nil, fmt.Errorf("could not revoke certificate with the certificate authority: %w", err)
+		}
+
+		// Mark the certificate record as revoked
+		cert.Status = models.CertificateState_REVOKED
+		cert.RevokedOn = time.Now().Format(time.RFC3339)
+		cert.RevocationReason = int32(reason)
+		if cert.Details != nil {
+			cert.Details.Revoked = true
+		}
+
+		if err = c.db.UpdateCert(ctx, cert); err != nil {
+			return nil, fmt.Errorf("could not update certificate record: %w", err)
+		}
+	}
+
+	// Mark the copies of the certificate on the VASP as revoked
+	serial := cert.Id
+	for _, signing := range vasp.SigningCertificates {
+		if models.GetCertID(signing) == serial {
+			signing.Revoked = true
+		}
+	}
+
+	if err = models.RevokeActiveCertificate(vasp, serial); err != nil {
+		return nil, err
+	}
+
+	if vasp.IdentityCertificate != nil && models.GetCertID(vasp.IdentityCertificate) == serial {
+		vasp.IdentityCertificate.Revoked = true
+		if err = models.SetRevokedOn(vasp, cert.RevokedOn); err != nil {
+			return nil, err
+		}
+
+		// Record the revocation without changing the verification status so that the
+		// VASP is still returned with its revoked identity certificate by Lookup.
+		entry := &models.AuditLogEntry{
+			Timestamp:     time.Now().Format(time.RFC3339),
+			PreviousState: vasp.VerificationStatus,
+			CurrentState:  vasp.VerificationStatus,
+			Description:   fmt.Sprintf("identity certificate revoked: %s", reason),
+			Source:        source,
+		}
+		if err = models.AppendAuditLog(vasp, entry); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = c.db.UpdateVASP(ctx, vasp); err != nil {
+		return nil, fmt.Errorf("certificate revoked but could not update VASP record, retry the revocation to resume: %w", err)
+	}
+
+	log.Info().
+		Str("vasp", vasp.Id).
+		Str("serial_number", serial).
+		Str("reason", reason.String()).
+		Str("source", source).
+		Msg("certificate revoked")
+	return cert, nil
+}
+
+// revocationPending returns true if the VASP record still has an unrevoked copy of the
+// certificate, e.g. because a previous revocation could not update the VASP.
+func revocationPending(vasp *pb.VASP, serial string) (_ bool, err error) {
+	for _, signing := range vasp.SigningCertificates {
+		if models.GetCertID(signing) == serial && !signing.Revoked {
+			return true, nil
+		}
+	}
+
+	if vasp.IdentityCertificate != nil && models.GetCertID(vasp.IdentityCertificate) == serial {
+		if !vasp.IdentityCertificate.Revoked {
+			return true, nil
+		}
+
+		var revokedOn string
+		if revokedOn, err = models.GetRevokedOn(vasp); err != nil {
+			return false, err
+		}
+		return revokedOn == "", nil
+	}
+	return false, nil
+}
+
+// retrieveVASPCert returns the certificate record for the certificate ID, ensuring that
+// the certificate belongs to the VASP. Identity certificates issued before certificate
+// records were stored do not have a record, so one is created from the VASP.
+func (c *CertificateManager) retrieveVASPCert(ctx context.Context, vasp *pb.VASP, certID string) (cert *models.Certificate, err error) {
+	if cert, err = c.db.RetrieveCert(ctx, certID); err != nil {
+		if !errors.Is(err, storeerrors.ErrEntityNotFound) {
+			return nil, err
+		}
+
+		if vasp.IdentityCertificate == nil || models.GetCertID(vasp.IdentityCertificate) != certID {
+			return nil, ErrCertificateNotFound
+		}
+
+		cert = &models.Certificate{
+			Id:      certID,
+			Vasp:    vasp.Id,
+			Status:  models.CertificateState_ISSUED,
+			Details: vasp.IdentityCertificate,
+		}
+		if err = models.AppendCertID(vasp, certID); err != nil {
+			return nil, err
+		}
+		return cert, nil
+	}
+
+	if cert.Vasp != "" && cert.Vasp != vasp.Id {
+		return nil, ErrCertificateNotFound
+	}
+	return cert, nil
+}
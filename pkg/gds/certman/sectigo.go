@@ -0,0 +1,171 @@
+package certman
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/sectigo"
+	"github.com/trisacrypto/directory/pkg/utils/sentry"
+)
+
+// SectigoAuthority issues certificates using the Sectigo IoT Manager API.
+type SectigoAuthority struct {
+	api *sectigo.Sectigo
+}
+
+// Compile time interface implementation check.
+var _ CertificateAuthority = &SectigoAuthority{}
+
+func NewSectigoAuthority(conf sectigo.Config) (_ *SectigoAuthority, err error) {
+	ca := &SectigoAuthority{}
+	if ca.api, err = sectigo.New(conf); err != nil {
+		return nil, err
+	}
+	return ca, nil
+}
+
+// Balance finds the first authority with an available balance greater than 0.
+func (s *SectigoAuthority) Balance() (authority, available int, err error) {
+	var authorities []*sectigo.AuthorityResponse
+	if authorities, err = s.api.UserAuthorities(); err != nil {
+		return 0, 0, fmt.Errorf("could not fetch user authorities: %s", err)
+	}
+
+	for _, authority := range authorities {
+		var balance int
+		if balance, err = s.api.AuthorityAvailableBalance(authority.ID); err != nil {
+			sentry.Error(nil).Err(err).Int("authority", authority.ID).Msg("could not fetch authority balance")
+		}
+		if balance > 0 {
+			return authority.ID, balance, nil
+		}
+	}
+
+	return 0, 0, fmt.Errorf("could not find authority with available balance out of %d available authorities", len(authorities))
+}
+
+// Submit creates a batch for the certificate request with the parameters required by
+// the configured Sectigo profile, uploading the CSR if the VASP submitted one so that
+// the private key is never generated by the CA.
+func (s *SectigoAuthority) Submit(r *models.CertificateRequest, authority int, batchName string) (_ *Batch, err error) {
+	var (
+		rep    *sectigo.BatchResponse
+		params map[string]string
+	)
+
+	profile := s.api.Profile()
+	if params, err = models.GetCertificateRequestParams(r, profile); err != nil {
+		return nil, fmt.Errorf("could not retrieve certificate request parameters for profile %q: %s", profile, err)
+	}
+
+	if r.Csr != "" {
+		var profileID int
+		if profileID, err = s.profileID(); err != nil {
+			return nil, err
+		}
+		rep, err = s.api.UploadCSRBatch(profileID, fmt.Sprintf("%s.csr", batchName), []byte(r.Csr), params)
+	} else {
+		rep, err = s.api.CreateSingleCertBatch(authority, batchName, params)
+	}
+
+	if err != nil {
+		// Although the error may be logged again by the calling function, log the error
+		// here as well to provide debugging information about why the Sectigo request failed.
+		dict := sentry.Dict()
+		for key, value := range params {
+			// NOTE: Do not log any passwords or secrets!
+			if key == sectigo.ParamPassword {
+				value = strings.Repeat("*", len(value))
+			}
+			dict.Str(key, value)
+		}
+		sentry.Error(nil).Err(err).
+			Int("authority", authority).
+			Str("batch_name", batchName).
+			Dict("params", dict).
+			Str("profile", profile).
+			Bool("csr", r.Csr != "").
+			Msg("create certificate batch failed")
+		return nil, err
+	}
+
+	return &Batch{
+		BatchID:      rep.BatchID,
+		BatchName:    rep.BatchName,
+		Status:       rep.Status,
+		OrderNumber:  rep.OrderNumber,
+		CreationDate: rep.CreationDate,
+		Profile:      rep.Profile,
+		RejectReason: rep.RejectReason,
+	}, nil
+}
+
+// Status refreshes the batch info and processing info of the batch from Sectigo.
+func (s *SectigoAuthority) Status(r *models.CertificateRequest) (batch *Batch, err error) {
+	var info *sectigo.BatchResponse
+	if info, err = s.api.BatchDetail(int(r.BatchId)); err != nil {
+		return nil, fmt.Errorf("could not fetch batch info for id %d: %s", r.BatchId, err)
+	}
+
+	batch = &Batch{
+		BatchID:      info.BatchID,
+		BatchName:    info.BatchName,
+		Status:       info.Status,
+		OrderNumber:  info.OrderNumber,
+		CreationDate: info.CreationDate,
+		Profile:      info.Profile,
+		RejectReason: info.RejectReason,
+	}
+
+	// Check if the batch is in an unhandled state, and if so, refresh batch status
+	if info.Status == sectigo.BatchStatusCollected || info.Status == "" {
+		sentry.Warn(nil).Int64("batch_id", r.BatchId).Str("batch_status", info.Status).Msg("unknown batch info status, refreshing batch status directly")
+		if batch.Status, err = s.api.BatchStatus(int(r.BatchId)); err != nil {
+			return nil, fmt.Errorf("could not fetch batch status for id %d: %s", r.BatchId, err)
+		}
+	}
+
+	var proc *sectigo.ProcessingInfoResponse
+	if proc, err = s.api.ProcessingInfo(int(r.BatchId)); err != nil {
+		return nil, fmt.Errorf("could not fetch batch processing info for id %d: %s", r.BatchId, err)
+	}
+
+	batch.Active = proc.Active
+	batch.Failed = proc.Failed
+	batch.Success = proc.Success
+	return batch, nil
+}
+
+// Download the certificates of the batch as a zip file to the directory.
+func (s *SectigoAuthority) Download(r *models.CertificateRequest, dir string) (string, error) {
+	return s.api.Download(int(r.BatchId), dir)
+}
+
+// Revoke the certificate with the configured Sectigo profile.
+func (s *SectigoAuthority) Revoke(certID string, reason sectigo.CRLReason) (err error) {
+	var profileID int
+	if profileID, err = s.profileID(); err != nil {
+		return err
+	}
+	return s.api.RevokeCertificate(profileID, int(reason), certID)
+}
+
+// profileID returns the numeric ID of the configured Sectigo profile, which is required
+// to upload CSRs and to revoke certificates issued with that profile.
+func (s *SectigoAuthority) profileID() (int, error) {
+	profile := s.api.Profile()
+	switch profile {
+	case sectigo.ProfileCipherTraceEE:
+		profile = sectigo.ProfileIDCipherTraceEE
+	case sectigo.ProfileCipherTraceEndEntityCertificate:
+		profile = sectigo.ProfileIDCipherTraceEndEntityCertificate
+	}
+
+	id, err := strconv.Atoi(profile)
+	if err != nil {
+		return 0, fmt.Errorf("could not determine profile ID for profile %q", s.api.Profile())
+	}
+	return id, nil
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/trisacrypto/directory/pkg/sectigo"
 	"github.com/trisacrypto/directory/pkg/store/config"
 	"github.com/trisacrypto/directory/pkg/utils/activity"
+	"github.com/trisacrypto/directory/pkg/utils/backups"
+	"github.com/trisacrypto/directory/pkg/utils/emails"
 	"github.com/trisacrypto/directory/pkg/utils/logger"
 	"github.com/trisacrypto/directory/pkg/utils/sentry"
 )
@@ -31,6 +33,10 @@ type Config struct {
 	Email       EmailConfig
 	CertMan     CertManConfig
 	Backup      BackupConfig
+	Health      HealthConfig
+	Duplicates  DuplicatesConfig
+	Jobs        JobsConfig
+	Outbox      OutboxConfig
 	Secrets     SecretsConfig
 	Sentry      sentry.Config
 	Activity    activity.Config
@@ -72,30 +78,125 @@ type MembersConfig struct {
 }
 
 type EmailConfig struct {
-	ServiceEmail         string `envconfig:"GDS_SERVICE_EMAIL" default:"TRISA Directory Service <admin@trisa.directory>"`
-	AdminEmail           string `envconfig:"GDS_ADMIN_EMAIL" default:"TRISA Admins <admin@travelrule.io>"`
-	SendGridAPIKey       string `envconfig:"SENDGRID_API_KEY" required:"false"`
-	DirectoryID          string `envconfig:"GDS_DIRECTORY_ID" default:"trisa.directory"`
-	VerifyContactBaseURL string `envconfig:"GDS_VERIFY_CONTACT_URL" default:"https://trisa.directory/verify"`
-	AdminReviewBaseURL   string `envconfig:"GDS_ADMIN_REVIEW_URL" default:"https://admin.trisa.directory/vasps/"`
-	Testing              bool   `split_words:"true" default:"false"`
-	Storage              string `split_words:"true" default:""`
+	ServiceEmail         string                 `envconfig:"GDS_SERVICE_EMAIL" default:"TRISA Directory Service <admin@trisa.directory>"`
+	AdminEmail           string                 `envconfig:"GDS_ADMIN_EMAIL" default:"TRISA Admins <admin@travelrule.io>"`
+	SendGridAPIKey       string                 `envconfig:"SENDGRID_API_KEY" required:"false"`
+	DirectoryID          string                 `envconfig:"GDS_DIRECTORY_ID" default:"trisa.directory"`
+	VerifyContactBaseURL string                 `envconfig:"GDS_VERIFY_CONTACT_URL" default:"https://trisa.directory/verify"`
+	AdminReviewBaseURL   string                 `envconfig:"GDS_ADMIN_REVIEW_URL" default:"https://admin.trisa.directory/vasps/"`
+	Testing              bool                   `split_words:"true" default:"false"`
+	Storage              string                 `split_words:"true" default:""`
+	Transport            emails.TransportConfig `split_words:"true"`                  // SMTP or Maildir transport, SendGrid is used if not configured
+	Templates            string                 `split_words:"true" required:"false"` // directory of email template overrides and localized templates
+	DefaultLocale        string                 `split_words:"true" default:"en"`     // locale of emails to recipients without a preferred locale
 }
 
 type CertManConfig struct {
-	Enabled            bool          `split_words:"true" default:"true"`
-	RequestInterval    time.Duration `split_words:"true" default:"10m"`
-	ReissuanceInterval time.Duration `split_words:"true" default:"24h"`
-	Storage            string        `split_words:"true" required:"false"`
-	DirectoryID        string        `envconfig:"GDS_DIRECTORY_ID" default:"trisa.directory"`
+	Enabled            bool                `split_words:"true" default:"true"`
+	RequestInterval    time.Duration       `split_words:"true" default:"10m"`
+	ReissuanceInterval time.Duration       `split_words:"true" default:"24h"`
+	ArchiveAfter       time.Duration       `split_words:"true" default:"720h"`   // finished requests are archived after this duration, 0 disables archiving
+	RotationOverlap    time.Duration       `split_words:"true" default:"720h"`   // previous certificates remain active for this duration after reissuance
+	Schedules          ReissuanceSchedules `split_words:"true" required:"false"` // JSON map of Sectigo profile to reissuance schedule
+	Storage            string              `split_words:"true" required:"false"`
+	DirectoryID        string              `envconfig:"GDS_DIRECTORY_ID" default:"trisa.directory"`
+	Authority          string              `split_words:"true" default:"sectigo"` // the certificate authority that issues certificates, sectigo or local
 	Sectigo            sectigo.Config
+	LocalCA            LocalCAConfig          `split_words:"true"`
+	DomainValidation   DomainValidationConfig `split_words:"true"`
+}
+
+// Certificate authorities that the certificate manager can issue certificates with.
+const (
+	AuthoritySectigo = "sectigo"
+	AuthorityLocal   = "local"
+)
+
+// LocalCAConfig configures a self-managed certificate authority that signs certificates
+// with CA key material on disk (e.g. created by the certs init command) for private
+// TRISA networks and for offline testing.
+type LocalCAConfig struct {
+	Certs    string        `required:"false"`                 // path to the CA certificate and private key
+	Validity time.Duration `required:"false" default:"9504h"` // how long issued certificates are valid for
+}
+
+// DomainValidationConfig configures the ACME-style domain control validation that
+// certificate requests must pass before they are submitted to the certificate authority.
+type DomainValidationConfig struct {
+	Enabled bool          `split_words:"true" default:"false"`
+	Timeout time.Duration `split_words:"true" default:"168h"` // requests are rejected if their domains are not validated within this duration
+	Reuse   time.Duration `split_words:"true" default:"720h"` // challenges validated within this duration are reused by later requests of the VASP
 }
 
 type BackupConfig struct {
-	Enabled  bool          `split_words:"true" default:"false"`
-	Interval time.Duration `split_words:"true" default:"24h"`
-	Storage  string        `split_words:"true" required:"false"`
-	Keep     int           `split_words:"true" default:"1"`
+	Enabled       bool               `split_words:"true" default:"false"`
+	Interval      time.Duration      `split_words:"true" default:"24h"`
+	Storage       string             `split_words:"true" required:"false"`
+	Keep          int                `split_words:"true" default:"1"`
+	KeepDaily     int                `split_words:"true" default:"0"`
+	KeepWeekly    int                `split_words:"true" default:"0"`
+	KeepMonthly   int                `split_words:"true" default:"0"`
+	EncryptionKey string             `split_words:"true" required:"false"`
+	Sink          backups.SinkConfig `split_words:"true"`
+}
+
+// HealthConfig configures the monitor that periodically checks the health of the TRISA
+// endpoints of verified VASPs using the directory service's mTLS certificates. Health
+// check records are saved when the service status of a VASP changes and otherwise at
+// most every save interval; a zero save interval saves the record on every check.
+type HealthConfig struct {
+	Enabled         bool          `split_words:"true" default:"false"`
+	Interval        time.Duration `split_words:"true" default:"1h"`
+	Timeout         time.Duration `split_words:"true" default:"30s"`
+	Concurrency     int           `split_words:"true" default:"8"`
+	History         int           `split_words:"true" default:"24"`
+	DangerThreshold uint32        `split_words:"true" default:"3"`
+	SaveInterval    time.Duration `split_words:"true" default:"6h"`
+	Insecure        bool          `split_words:"true" default:"false"`
+	Certs           string        `split_words:"true"`
+	CertPool        string        `split_words:"true"`
+}
+
+// DuplicatesConfig configures the detection of repeat registrations of the same legal
+// entity. Registrations that are likely duplicates of an existing VASP are either
+// flagged for the admins to consider during review or rejected outright.
+type DuplicatesConfig struct {
+	Enabled   bool    `split_words:"true" default:"true"`
+	Action    string  `split_words:"true" default:"flag"`
+	Threshold float64 `split_words:"true" default:"0.8"`
+}
+
+// Actions that can be taken when a registration is a likely duplicate.
+const (
+	DuplicatesFlag   = "flag"
+	DuplicatesReject = "reject"
+)
+
+// JobsConfig configures the durable queue that processes the side effects of
+// registrations, such as sending verification emails, in the background. If the queue
+// is disabled then jobs are processed once when they are submitted and are not retried.
+type JobsConfig struct {
+	Enabled     bool          `split_words:"true" default:"true"`
+	Interval    time.Duration `split_words:"true" default:"30s"`
+	MaxAttempts uint32        `split_words:"true" default:"8"`
+	Backoff     time.Duration `split_words:"true" default:"30s"`
+	MaxBackoff  time.Duration `split_words:"true" default:"1h"`
+	Retention   time.Duration `split_words:"true" default:"168h"`
+}
+
+// OutboxConfig configures the durable outbox that records every email sent by the
+// directory service, delivers it in the background and retries failed deliveries. Emails that cannot be delivered
+// within the maximum number of attempts are moved to the dead letter state until they
+// are retried by an admin or deleted after the dead letter retention period. If the
+// outbox is disabled emails are sent synchronously.
+type OutboxConfig struct {
+	Enabled     bool          `split_words:"true" default:"true"`
+	Interval    time.Duration `split_words:"true" default:"1m"`
+	MaxAttempts uint32        `split_words:"true" default:"8"`
+	Backoff     time.Duration `split_words:"true" default:"1m"`
+	MaxBackoff  time.Duration `split_words:"true" default:"6h"`
+	Retention   time.Duration `split_words:"true" default:"720h"` // delivered emails are deleted after this duration, 0 keeps them
+	DeadLetters time.Duration `split_words:"true" default:"720h"` // dead letters are deleted after this duration, 0 keeps them
 }
 
 type SecretsConfig struct {
@@ -170,6 +271,22 @@ func (c Config) Validate() (err error) {
 		return err
 	}
 
+	if err = c.Backup.Validate(); err != nil {
+		return err
+	}
+
+	if err = c.Health.Validate(); err != nil {
+		return err
+	}
+
+	if err = c.Duplicates.Validate(); err != nil {
+		return err
+	}
+
+	if err = c.Jobs.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -225,13 +342,23 @@ func (c MembersConfig) Validate() error {
 }
 
 func (c EmailConfig) Validate() error {
+	if c.DefaultLocale != "" {
+		if _, err := emails.NormalizeLocale(c.DefaultLocale); err != nil {
+			return fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+
 	if c.AdminReviewBaseURL != "" && !strings.HasSuffix(c.AdminReviewBaseURL, "/") {
 		return errors.New("invalid configuration: admin review base URL must end in a /")
 	}
 
 	if !c.Testing {
-		if c.SendGridAPIKey == "" || c.ServiceEmail == "" {
-			return errors.New("invalid configuration: sendgrid api key and service email are required")
+		if c.ServiceEmail == "" {
+			return errors.New("invalid configuration: service email is required")
+		}
+
+		if c.Transport.URL == "" && c.SendGridAPIKey == "" {
+			return errors.New("invalid configuration: sendgrid api key is required if no email transport is configured")
 		}
 
 		if c.Storage != "" {
@@ -247,5 +374,146 @@ func (c CertManConfig) Validate() (err error) {
 		return err
 	}
 
+	switch c.Authority {
+	case AuthoritySectigo:
+	case AuthorityLocal:
+		if c.LocalCA.Certs == "" {
+			return errors.New("invalid configuration: local certificate authority requires the path to the CA certs")
+		}
+
+		if c.LocalCA.Validity <= 0 {
+			return errors.New("invalid configuration: local certificate authority validity must be greater than zero")
+		}
+	default:
+		return fmt.Errorf("invalid configuration: %q is not a valid certificate authority, specify %q or %q", c.Authority, AuthoritySectigo, AuthorityLocal)
+	}
+
+	if c.ArchiveAfter < 0 {
+		return errors.New("invalid configuration: certman archive after cannot be negative")
+	}
+
+	if c.RotationOverlap < 0 {
+		return errors.New("invalid configuration: certman rotation overlap cannot be negative")
+	}
+
+	if c.DomainValidation.Enabled && c.DomainValidation.Timeout <= 0 {
+		return errors.New("invalid configuration: domain validation timeout must be greater than zero")
+	}
+
+	if c.DomainValidation.Reuse < 0 {
+		return errors.New("invalid configuration: domain validation reuse cannot be negative")
+	}
+
+	if err = c.Schedules.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Schedule returns the reissuance schedule for the configured Sectigo profile or the
+// default schedule if no schedule is configured for the profile.
+func (c CertManConfig) Schedule() ReissuanceSchedule {
+	if schedule, ok := c.Schedules[c.Sectigo.Profile]; ok {
+		return schedule
+	}
+	return DefaultReissuanceSchedule
+}
+
+func (c BackupConfig) Validate() (err error) {
+	if err = c.Sink.Validate(); err != nil {
+		return err
+	}
+
+	if _, err = backups.ParseKey(c.EncryptionKey); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if c.Keep < 0 || c.KeepDaily < 0 || c.KeepWeekly < 0 || c.KeepMonthly < 0 {
+		return errors.New("invalid configuration: backup retention cannot be negative")
+	}
+	return nil
+}
+
+// Retention returns the retention policy of the backup archives.
+func (c BackupConfig) Retention() backups.Retention {
+	return backups.Retention{
+		Keep:    c.Keep,
+		Daily:   c.KeepDaily,
+		Weekly:  c.KeepWeekly,
+		Monthly: c.KeepMonthly,
+	}
+}
+
+func (c HealthConfig) Validate() error {
+	if c.Enabled {
+		if c.Interval <= 0 || c.Timeout <= 0 {
+			return errors.New("invalid configuration: health check interval and timeout must be greater than zero")
+		}
+
+		if c.Concurrency < 1 {
+			return errors.New("invalid configuration: health check concurrency must be at least 1")
+		}
+
+		if c.SaveInterval < 0 {
+			return errors.New("invalid configuration: health check save interval cannot be negative")
+		}
+
+		// If the insecure flag isn't set then we must have certs to connect to peers.
+		if !c.Insecure && (c.Certs == "" || c.CertPool == "") {
+			return errors.New("invalid configuration: health checks over mTLS require the path to certs and the cert pool")
+		}
+	}
+	return nil
+}
+
+func (c DuplicatesConfig) Validate() error {
+	if c.Enabled {
+		if c.Action != DuplicatesFlag && c.Action != DuplicatesReject {
+			return fmt.Errorf("invalid configuration: %q is not a valid duplicates action, specify %q or %q", c.Action, DuplicatesFlag, DuplicatesReject)
+		}
+
+		if c.Threshold <= 0 || c.Threshold > 1 {
+			return errors.New("invalid configuration: duplicates threshold must be greater than zero and at most 1")
+		}
+	}
+	return nil
+}
+
+func (c JobsConfig) Validate() error {
+	if c.Enabled {
+		if c.Interval <= 0 {
+			return errors.New("invalid configuration: jobs interval must be greater than zero")
+		}
+
+		if c.MaxAttempts < 1 {
+			return errors.New("invalid configuration: jobs max attempts must be at least 1")
+		}
+
+		if c.Backoff <= 0 || c.MaxBackoff < c.Backoff {
+			return errors.New("invalid configuration: jobs backoff must be greater than zero and at most the max backoff")
+		}
+	}
+	return nil
+}
+
+func (c OutboxConfig) Validate() error {
+	if c.Enabled {
+		if c.Interval <= 0 {
+			return errors.New("invalid configuration: outbox interval must be greater than zero")
+		}
+
+		if c.MaxAttempts < 1 {
+			return errors.New("invalid configuration: outbox max attempts must be at least 1")
+		}
+
+		if c.Backoff <= 0 || c.MaxBackoff < c.Backoff {
+			return errors.New("invalid configuration: outbox backoff must be greater than zero and at most the max backoff")
+		}
+
+		if c.Retention < 0 || c.DeadLetters < 0 {
+			return errors.New("invalid configuration: outbox retention cannot be negative")
+		}
+	}
 	return nil
 }
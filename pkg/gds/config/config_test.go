@@ -10,6 +10,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/sectigo"
 )
 
 var testEnv = map[string]string{
@@ -50,15 +51,43 @@ var testEnv = map[string]string{
 	"GDS_VERIFY_CONTACT_URL":                   "http://localhost:3000/verify",
 	"GDS_ADMIN_REVIEW_URL":                     "http://localhost:3001/vasps/",
 	"GDS_EMAIL_TESTING":                        "true",
+	"GDS_EMAIL_TRANSPORT_URL":                  "smtp://localhost:1025",
+	"GDS_EMAIL_TEMPLATES":                      "/etc/trisa/emails",
+	"GDS_EMAIL_DEFAULT_LOCALE":                 "ja",
 	"GDS_EMAIL_STORAGE":                        "fixtures/emails",
 	"GDS_CERTMAN_ENABLED":                      "false",
 	"GDS_CERTMAN_REQUEST_INTERVAL":             "60s",
 	"GDS_CERTMAN_REISSUANCE_INTERVAL":          "90s",
+	"GDS_CERTMAN_ARCHIVE_AFTER":                "168h",
+	"GDS_CERTMAN_ROTATION_OVERLAP":             "336h",
+	"GDS_CERTMAN_SCHEDULES":                    `{"17": [{"offset": "336h", "audience": "contacts", "template": "reissuance_reminder", "action": "remind"}, {"offset": "0s", "action": "expire"}]}`,
 	"GDS_CERTMAN_STORAGE":                      "fixtures/certs",
+	"GDS_CERTMAN_AUTHORITY":                    "local",
+	"GDS_CERTMAN_LOCAL_CA_CERTS":               "fixtures/certs/ca.gz",
+	"GDS_CERTMAN_LOCAL_CA_VALIDITY":            "720h",
+	"GDS_CERTMAN_DOMAIN_VALIDATION_ENABLED":    "true",
+	"GDS_CERTMAN_DOMAIN_VALIDATION_TIMEOUT":    "72h",
+	"GDS_CERTMAN_DOMAIN_VALIDATION_REUSE":      "240h",
 	"GDS_BACKUP_ENABLED":                       "true",
 	"GDS_BACKUP_INTERVAL":                      "36h",
 	"GDS_BACKUP_STORAGE":                       "fixtures/backups",
 	"GDS_BACKUP_KEEP":                          "7",
+	"GDS_BACKUP_KEEP_WEEKLY":                   "4",
+	"GDS_BACKUP_ENCRYPTION_KEY":                "3q2+796tvu/erb7v3q2+796tvu/erb7v3q2+796tvu8=",
+	"GDS_BACKUP_SINK_URL":                      "s3://backups/gds",
+	"GDS_BACKUP_SINK_ENDPOINT":                 "localhost:9000",
+	"GDS_BACKUP_SINK_ACCESS_KEY_ID":            "minio",
+	"GDS_HEALTH_ENABLED":                       "true",
+	"GDS_HEALTH_INTERVAL":                      "30m",
+	"GDS_HEALTH_HISTORY":                       "48",
+	"GDS_HEALTH_INSECURE":                      "true",
+	"GDS_DUPLICATES_ACTION":                    "reject",
+	"GDS_DUPLICATES_THRESHOLD":                 "0.9",
+	"GDS_JOBS_INTERVAL":                        "1m",
+	"GDS_JOBS_MAX_ATTEMPTS":                    "5",
+	"GDS_OUTBOX_MAX_ATTEMPTS":                  "10",
+	"GDS_OUTBOX_MAX_BACKOFF":                   "12h",
+	"GDS_OUTBOX_DEAD_LETTERS":                  "336h",
 	"GOOGLE_APPLICATION_CREDENTIALS":           "test.json",
 	"GOOGLE_PROJECT_NAME":                      "test",
 	"GDS_SECRETS_TESTING":                      "true",
@@ -136,16 +165,60 @@ func TestConfig(t *testing.T) {
 	require.Equal(t, testEnv["GDS_ADMIN_REVIEW_URL"], conf.Email.AdminReviewBaseURL)
 	require.Equal(t, testEnv["GDS_EMAIL_STORAGE"], conf.Email.Storage)
 	require.True(t, conf.Email.Testing)
+	require.Equal(t, testEnv["GDS_EMAIL_TRANSPORT_URL"], conf.Email.Transport.URL)
+	require.Equal(t, testEnv["GDS_EMAIL_TEMPLATES"], conf.Email.Templates)
+	require.Equal(t, testEnv["GDS_EMAIL_DEFAULT_LOCALE"], conf.Email.DefaultLocale)
 	require.Equal(t, testEnv["GDS_DIRECTORY_ID"], conf.Email.DirectoryID)
 	require.False(t, conf.CertMan.Enabled)
 	require.Equal(t, 1*time.Minute, conf.CertMan.RequestInterval)
 	require.Equal(t, 90*time.Second, conf.CertMan.ReissuanceInterval)
+	require.Equal(t, 168*time.Hour, conf.CertMan.ArchiveAfter)
+	require.Equal(t, 336*time.Hour, conf.CertMan.RotationOverlap)
+	require.Equal(t, config.ReissuanceSchedule{
+		{Offset: 336 * time.Hour, Audience: config.AudienceContacts, Template: config.TemplateReissuanceReminder, Action: config.ActionRemind},
+		{Offset: 0, Action: config.ActionExpire},
+	}, conf.CertMan.Schedule())
 	require.Equal(t, testEnv["GDS_CERTMAN_STORAGE"], conf.CertMan.Storage)
+	require.Equal(t, config.AuthorityLocal, conf.CertMan.Authority)
+	require.Equal(t, testEnv["GDS_CERTMAN_LOCAL_CA_CERTS"], conf.CertMan.LocalCA.Certs)
+	require.Equal(t, 720*time.Hour, conf.CertMan.LocalCA.Validity)
+	require.True(t, conf.CertMan.DomainValidation.Enabled)
+	require.Equal(t, 72*time.Hour, conf.CertMan.DomainValidation.Timeout)
+	require.Equal(t, 240*time.Hour, conf.CertMan.DomainValidation.Reuse)
 	require.Equal(t, testEnv["GDS_DIRECTORY_ID"], conf.CertMan.DirectoryID)
 	require.Equal(t, true, conf.Backup.Enabled)
 	require.Equal(t, 36*time.Hour, conf.Backup.Interval)
 	require.Equal(t, testEnv["GDS_BACKUP_STORAGE"], conf.Backup.Storage)
 	require.Equal(t, 7, conf.Backup.Keep)
+	require.Equal(t, 4, conf.Backup.KeepWeekly)
+	require.Equal(t, testEnv["GDS_BACKUP_ENCRYPTION_KEY"], conf.Backup.EncryptionKey)
+	require.Equal(t, testEnv["GDS_BACKUP_SINK_URL"], conf.Backup.Sink.URL)
+	require.Equal(t, testEnv["GDS_BACKUP_SINK_ENDPOINT"], conf.Backup.Sink.Endpoint)
+	require.Equal(t, testEnv["GDS_BACKUP_SINK_ACCESS_KEY_ID"], conf.Backup.Sink.AccessKeyID)
+	require.True(t, conf.Health.Enabled)
+	require.Equal(t, 30*time.Minute, conf.Health.Interval)
+	require.Equal(t, 30*time.Second, conf.Health.Timeout)
+	require.Equal(t, 8, conf.Health.Concurrency)
+	require.Equal(t, 48, conf.Health.History)
+	require.Equal(t, uint32(3), conf.Health.DangerThreshold)
+	require.Equal(t, 6*time.Hour, conf.Health.SaveInterval)
+	require.True(t, conf.Health.Insecure)
+	require.True(t, conf.Duplicates.Enabled)
+	require.Equal(t, config.DuplicatesReject, conf.Duplicates.Action)
+	require.Equal(t, 0.9, conf.Duplicates.Threshold)
+	require.True(t, conf.Jobs.Enabled)
+	require.Equal(t, time.Minute, conf.Jobs.Interval)
+	require.Equal(t, uint32(5), conf.Jobs.MaxAttempts)
+	require.Equal(t, 30*time.Second, conf.Jobs.Backoff)
+	require.Equal(t, time.Hour, conf.Jobs.MaxBackoff)
+	require.Equal(t, 168*time.Hour, conf.Jobs.Retention)
+	require.True(t, conf.Outbox.Enabled)
+	require.Equal(t, time.Minute, conf.Outbox.Interval)
+	require.Equal(t, uint32(10), conf.Outbox.MaxAttempts)
+	require.Equal(t, time.Minute, conf.Outbox.Backoff)
+	require.Equal(t, 12*time.Hour, conf.Outbox.MaxBackoff)
+	require.Equal(t, 720*time.Hour, conf.Outbox.Retention)
+	require.Equal(t, 336*time.Hour, conf.Outbox.DeadLetters)
 	require.Equal(t, testEnv["GOOGLE_APPLICATION_CREDENTIALS"], conf.Secrets.Credentials)
 	require.Equal(t, testEnv["GOOGLE_PROJECT_NAME"], conf.Secrets.Project)
 	require.Equal(t, testEnv["GDS_SENTRY_DSN"], conf.Sentry.DSN)
@@ -261,13 +334,18 @@ func TestEmailConfigValidation(t *testing.T) {
 
 	conf.AdminReviewBaseURL += "/"
 	err = conf.Validate()
-	require.EqualError(t, err, "invalid configuration: sendgrid api key and service email are required")
+	require.EqualError(t, err, "invalid configuration: service email is required")
 
-	conf.SendGridAPIKey = "supersecretapikey"
+	conf.ServiceEmail = "service@example.com"
 	err = conf.Validate()
-	require.EqualError(t, err, "invalid configuration: sendgrid api key and service email are required")
+	require.EqualError(t, err, "invalid configuration: sendgrid api key is required if no email transport is configured")
 
-	conf.ServiceEmail = "service@example.com"
+	conf.Transport.URL = "smtp://mail.example.com:587"
+	err = conf.Validate()
+	require.NoError(t, err, "expected valid configuration with an smtp transport")
+
+	conf.Transport.URL = ""
+	conf.SendGridAPIKey = "supersecretapikey"
 	conf.Storage = "fixtures/emails"
 	err = conf.Validate()
 	require.EqualError(t, err, "invalid configuration: email archiving is only supported in testing mode")
@@ -279,6 +357,14 @@ func TestEmailConfigValidation(t *testing.T) {
 	conf.Testing = true
 	err = conf.Validate()
 	require.NoError(t, err, "expected valid configuration in testing mode")
+
+	conf.DefaultLocale = "japanese"
+	err = conf.Validate()
+	require.EqualError(t, err, `invalid configuration: invalid locale "japanese"`)
+
+	conf.DefaultLocale = "ja-JP"
+	err = conf.Validate()
+	require.NoError(t, err, "expected valid configuration with a regional default locale")
 }
 
 func TestAdminConfigValidation(t *testing.T) {
@@ -332,6 +418,205 @@ func TestMembersConfigValidation(t *testing.T) {
 	require.EqualError(t, err, "invalid configuration: serving mTLS requires the path to certs and the cert pool")
 }
 
+func TestHealthConfigValidation(t *testing.T) {
+	conf := config.HealthConfig{
+		Enabled:     false,
+		Interval:    time.Hour,
+		Timeout:     30 * time.Second,
+		Concurrency: 8,
+	}
+
+	// If not enabled, no other configuration is required.
+	require.NoError(t, conf.Validate())
+
+	// If enabled, the certs and cert pool are required unless insecure.
+	conf.Enabled = true
+	require.EqualError(t, conf.Validate(), "invalid configuration: health checks over mTLS require the path to certs and the cert pool")
+
+	conf.Insecure = true
+	require.NoError(t, conf.Validate())
+
+	// The interval, timeout, and concurrency must be valid
+	conf.Timeout = 0
+	require.EqualError(t, conf.Validate(), "invalid configuration: health check interval and timeout must be greater than zero")
+
+	conf.Timeout = 30 * time.Second
+	conf.Concurrency = 0
+	require.EqualError(t, conf.Validate(), "invalid configuration: health check concurrency must be at least 1")
+}
+
+func TestDuplicatesConfigValidation(t *testing.T) {
+	conf := config.DuplicatesConfig{
+		Enabled:   false,
+		Action:    "unknown",
+		Threshold: 0.8,
+	}
+
+	// If not enabled, no other configuration is required.
+	require.NoError(t, conf.Validate())
+
+	// If enabled, the action must be flag or reject
+	conf.Enabled = true
+	require.EqualError(t, conf.Validate(), `invalid configuration: "unknown" is not a valid duplicates action, specify "flag" or "reject"`)
+
+	conf.Action = config.DuplicatesFlag
+	require.NoError(t, conf.Validate())
+
+	// The threshold must be a valid score
+	conf.Threshold = 0
+	require.EqualError(t, conf.Validate(), "invalid configuration: duplicates threshold must be greater than zero and at most 1")
+
+	conf.Threshold = 1.2
+	require.EqualError(t, conf.Validate(), "invalid configuration: duplicates threshold must be greater than zero and at most 1")
+}
+
+func TestJobsConfigValidation(t *testing.T) {
+	conf := config.JobsConfig{
+		Enabled:     false,
+		Interval:    0,
+		MaxAttempts: 8,
+		Backoff:     30 * time.Second,
+		MaxBackoff:  time.Hour,
+	}
+
+	// If not enabled, no other configuration is required.
+	require.NoError(t, conf.Validate())
+
+	// If enabled, the interval must be set
+	conf.Enabled = true
+	require.EqualError(t, conf.Validate(), "invalid configuration: jobs interval must be greater than zero")
+
+	conf.Interval = 30 * time.Second
+	require.NoError(t, conf.Validate())
+
+	conf.MaxAttempts = 0
+	require.EqualError(t, conf.Validate(), "invalid configuration: jobs max attempts must be at least 1")
+
+	// The backoff must not exceed the max backoff
+	conf.MaxAttempts = 8
+	conf.MaxBackoff = time.Second
+	require.EqualError(t, conf.Validate(), "invalid configuration: jobs backoff must be greater than zero and at most the max backoff")
+
+	conf.Backoff = 0
+	require.EqualError(t, conf.Validate(), "invalid configuration: jobs backoff must be greater than zero and at most the max backoff")
+}
+
+func TestOutboxConfigValidation(t *testing.T) {
+	conf := config.OutboxConfig{
+		Enabled:     false,
+		Interval:    0,
+		MaxAttempts: 8,
+		Backoff:     time.Minute,
+		MaxBackoff:  6 * time.Hour,
+	}
+
+	// If not enabled, no other configuration is required.
+	require.NoError(t, conf.Validate())
+
+	// If enabled, the interval must be set
+	conf.Enabled = true
+	require.EqualError(t, conf.Validate(), "invalid configuration: outbox interval must be greater than zero")
+
+	conf.Interval = time.Minute
+	require.NoError(t, conf.Validate())
+
+	conf.MaxAttempts = 0
+	require.EqualError(t, conf.Validate(), "invalid configuration: outbox max attempts must be at least 1")
+
+	// The backoff must not exceed the max backoff
+	conf.MaxAttempts = 8
+	conf.MaxBackoff = time.Second
+	require.EqualError(t, conf.Validate(), "invalid configuration: outbox backoff must be greater than zero and at most the max backoff")
+
+	// Dead letters cannot be kept for a negative duration
+	conf.MaxBackoff = 6 * time.Hour
+	conf.DeadLetters = -time.Hour
+	require.EqualError(t, conf.Validate(), "invalid configuration: outbox retention cannot be negative")
+}
+
+func TestCertManAuthorityValidation(t *testing.T) {
+	conf := config.CertManConfig{
+		Authority: "unknown",
+		Sectigo:   sectigo.Config{Profile: sectigo.ProfileCipherTraceEE, Environment: "testing"},
+	}
+	require.EqualError(t, conf.Validate(), `invalid configuration: "unknown" is not a valid certificate authority, specify "sectigo" or "local"`)
+
+	conf.Authority = config.AuthoritySectigo
+	require.NoError(t, conf.Validate())
+
+	// The local authority requires the CA certs and a validity period
+	conf.Authority = config.AuthorityLocal
+	require.EqualError(t, conf.Validate(), "invalid configuration: local certificate authority requires the path to the CA certs")
+
+	conf.LocalCA.Certs = "fixtures/certs/ca.gz"
+	require.EqualError(t, conf.Validate(), "invalid configuration: local certificate authority validity must be greater than zero")
+
+	conf.LocalCA.Validity = 24 * time.Hour
+	require.NoError(t, conf.Validate())
+
+	// Domain validation requires a timeout when enabled
+	conf.DomainValidation.Enabled = true
+	require.EqualError(t, conf.Validate(), "invalid configuration: domain validation timeout must be greater than zero")
+
+	conf.DomainValidation.Timeout = time.Hour
+	require.NoError(t, conf.Validate())
+
+	conf.DomainValidation.Reuse = -time.Hour
+	require.EqualError(t, conf.Validate(), "invalid configuration: domain validation reuse cannot be negative")
+
+	conf.DomainValidation.Reuse = 0
+	require.NoError(t, conf.Validate())
+}
+
+func TestReissuanceScheduleValidation(t *testing.T) {
+	conf := config.CertManConfig{}
+	conf.Sectigo.Profile = "17"
+
+	// If no schedules are configured, the default schedule is used
+	require.NoError(t, conf.Schedules.Validate())
+	require.Equal(t, config.DefaultReissuanceSchedule, conf.Schedule())
+	require.NoError(t, config.DefaultReissuanceSchedule.Validate())
+
+	// Schedules must be for a valid Sectigo profile
+	conf.Schedules = config.ReissuanceSchedules{"unknown": config.DefaultReissuanceSchedule}
+	require.EqualError(t, conf.Schedules.Validate(), `invalid configuration: "unknown" is not a valid Sectigo profile for a reissuance schedule`)
+
+	// Stages must have a known action
+	schedule := config.ReissuanceSchedule{{Offset: time.Hour, Action: "unknown"}}
+	require.EqualError(t, schedule.Validate(), `invalid configuration: unknown reissuance stage action "unknown"`)
+
+	// Reminders must have a template that can be sent to the audience
+	schedule = config.ReissuanceSchedule{{Offset: time.Hour, Audience: config.AudienceContacts, Action: config.ActionRemind}}
+	require.EqualError(t, schedule.Validate(), "invalid configuration: reissuance reminder stages require an email template")
+
+	schedule[0].Template = config.TemplateExpiresAdminNotification
+	require.EqualError(t, schedule.Validate(), `invalid configuration: template "expires_admin_notification" cannot be sent to reissuance stage audience "contacts"`)
+
+	schedule[0].Audience = "unknown"
+	require.EqualError(t, schedule.Validate(), `invalid configuration: unknown reissuance stage audience "unknown"`)
+
+	// Offsets cannot be negative
+	schedule = config.ReissuanceSchedule{{Offset: -time.Hour, Action: config.ActionExpire}}
+	require.EqualError(t, schedule.Validate(), "invalid configuration: reissuance stage offset cannot be negative")
+
+	// Stages must be ordered and unique
+	schedule = config.ReissuanceSchedule{{Offset: 0, Action: config.ActionExpire}, {Offset: time.Hour, Action: config.ActionReissue}}
+	require.EqualError(t, schedule.Validate(), "invalid configuration: reissuance stages must be ordered from the largest to the smallest offset")
+
+	schedule = config.ReissuanceSchedule{{Offset: 0, Action: config.ActionExpire}, {Offset: 0, Action: config.ActionExpire}}
+	require.EqualError(t, schedule.Validate(), `invalid configuration: duplicate reissuance stage "expire:::0s"`)
+
+	// Only the reminders with the smallest offset that has been reached are due but
+	// reissue and expire stages that have been reached are never skipped
+	schedule = config.DefaultReissuanceSchedule
+	require.Empty(t, schedule.Current(31*24*time.Hour))
+	require.Equal(t, schedule[:2], schedule.Current(29*24*time.Hour))
+	require.Equal(t, schedule[2:3], schedule.Current(9*24*time.Hour))
+	require.Equal(t, schedule[2:4], schedule.Current(7*24*time.Hour))
+	require.Equal(t, config.ReissuanceSchedule{schedule[2], schedule[4]}, schedule.Current(-time.Hour))
+	require.Equal(t, 240*time.Hour, schedule.ReissuanceOffset())
+}
+
 // Returns the current environment for the specified keys, or if no keys are specified
 // then returns the current environment for all keys in testEnv.
 func curEnv(keys ...string) map[string]string {
@@ -0,0 +1,212 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/trisacrypto/directory/pkg/sectigo"
+)
+
+// Audiences, actions, and email templates of the stages of a reissuance schedule.
+const (
+	AudienceContacts = "contacts"
+	AudienceAdmins   = "admins"
+
+	ActionRemind  = "remind"
+	ActionReissue = "reissue"
+	ActionExpire  = "expire"
+
+	TemplateReissuanceReminder          = "reissuance_reminder"
+	TemplateExpiresAdminNotification    = "expires_admin_notification"
+	TemplateReissuanceAdminNotification = "reissuance_admin_notification"
+)
+
+// The email templates that can be sent to each audience by a reissuance stage.
+var audienceTemplates = map[string]map[string]struct{}{
+	AudienceContacts: {
+		TemplateReissuanceReminder: {},
+	},
+	AudienceAdmins: {
+		TemplateExpiresAdminNotification:    {},
+		TemplateReissuanceAdminNotification: {},
+	},
+}
+
+const day = 24 * time.Hour
+
+// DefaultReissuanceSchedule is used for any Sectigo profile that does not have a
+// schedule configured. Reminders are sent to the contacts and admins 30 days before the
+// identity certificate expires, certificates are reissued 10 days before expiration,
+// a final reminder is sent to the contacts 7 days before expiration, and the
+// certificate record is marked as expired once the certificate has expired.
+var DefaultReissuanceSchedule = ReissuanceSchedule{
+	{Offset: 30 * day, Audience: AudienceContacts, Template: TemplateReissuanceReminder, Action: ActionRemind},
+	{Offset: 30 * day, Audience: AudienceAdmins, Template: TemplateExpiresAdminNotification, Action: ActionRemind},
+	{Offset: 10 * day, Audience: AudienceAdmins, Template: TemplateReissuanceAdminNotification, Action: ActionReissue},
+	{Offset: 7 * day, Audience: AudienceContacts, Template: TemplateReissuanceReminder, Action: ActionRemind},
+	{Offset: 0, Action: ActionExpire},
+}
+
+// ReissuanceStage is a step in the lifecycle of an identity certificate that is
+// performed by the certificate manager when the certificate is within the offset of
+// its expiration date. The action of the stage is performed first, then if a template
+// is specified, the email is sent to the audience of the stage.
+type ReissuanceStage struct {
+	Offset   time.Duration `json:"offset"`
+	Audience string        `json:"audience,omitempty"`
+	Template string        `json:"template,omitempty"`
+	Action   string        `json:"action"`
+}
+
+// ID uniquely identifies the stage in a schedule so that the certificate manager can
+// record that the stage has fired for a certificate.
+func (s ReissuanceStage) ID() string {
+	return fmt.Sprintf("%s:%s:%s:%s", s.Action, s.Audience, s.Template, s.Offset)
+}
+
+// Days returns the offset of the stage in whole days.
+func (s ReissuanceStage) Days() int {
+	return int(s.Offset / day)
+}
+
+// UnmarshalJSON allows the offset of the stage to be specified as a duration string,
+// e.g. "720h", rather than as an integer number of nanoseconds.
+func (s *ReissuanceStage) UnmarshalJSON(data []byte) (err error) {
+	type alias ReissuanceStage
+	stage := &struct {
+		*alias
+		Offset string `json:"offset"`
+	}{alias: (*alias)(s)}
+
+	if err = json.Unmarshal(data, stage); err != nil {
+		return err
+	}
+
+	if s.Offset, err = time.ParseDuration(stage.Offset); err != nil {
+		return fmt.Errorf("could not parse reissuance stage offset: %w", err)
+	}
+	return nil
+}
+
+// Validate that the stage has a known action and that any email template can be sent
+// to the audience of the stage.
+func (s ReissuanceStage) Validate() error {
+	if s.Offset < 0 {
+		return errors.New("invalid configuration: reissuance stage offset cannot be negative")
+	}
+
+	switch s.Action {
+	case ActionRemind:
+		if s.Template == "" {
+			return errors.New("invalid configuration: reissuance reminder stages require an email template")
+		}
+	case ActionReissue, ActionExpire:
+	default:
+		return fmt.Errorf("invalid configuration: unknown reissuance stage action %q", s.Action)
+	}
+
+	if s.Template != "" || s.Audience != "" {
+		templates, ok := audienceTemplates[s.Audience]
+		if !ok {
+			return fmt.Errorf("invalid configuration: unknown reissuance stage audience %q", s.Audience)
+		}
+
+		if _, ok := templates[s.Template]; !ok {
+			return fmt.Errorf("invalid configuration: template %q cannot be sent to reissuance stage audience %q", s.Template, s.Audience)
+		}
+	}
+	return nil
+}
+
+// ReissuanceSchedule is a list of stages ordered from the largest to the smallest
+// offset before the expiration of the identity certificate.
+type ReissuanceSchedule []ReissuanceStage
+
+// Validate the stages of the schedule and that the stages are ordered and unique.
+func (s ReissuanceSchedule) Validate() (err error) {
+	seen := make(map[string]struct{}, len(s))
+	for i, stage := range s {
+		if err = stage.Validate(); err != nil {
+			return err
+		}
+
+		if i > 0 && stage.Offset > s[i-1].Offset {
+			return errors.New("invalid configuration: reissuance stages must be ordered from the largest to the smallest offset")
+		}
+
+		if _, ok := seen[stage.ID()]; ok {
+			return fmt.Errorf("invalid configuration: duplicate reissuance stage %q", stage.ID())
+		}
+		seen[stage.ID()] = struct{}{}
+	}
+	return nil
+}
+
+// Current returns the stages that are due when the certificate expires in the specified
+// duration. Only the reminders with the smallest offset that has been reached are due
+// so that reminders that were missed, e.g. because the certificate manager was not
+// running, are skipped rather than all being sent at once. Reissue and expire stages
+// are never skipped; every one that has been reached is due until it has fired.
+func (s ReissuanceSchedule) Current(expiresIn time.Duration) (stages ReissuanceSchedule) {
+	// Stages are ordered by offset so the last stage reached has the smallest offset
+	current := time.Duration(-1)
+	for _, stage := range s {
+		if expiresIn <= stage.Offset {
+			current = stage.Offset
+		}
+	}
+
+	for _, stage := range s {
+		if expiresIn > stage.Offset {
+			continue
+		}
+
+		if stage.Action != ActionRemind || stage.Offset == current {
+			stages = append(stages, stage)
+		}
+	}
+	return stages
+}
+
+// ReissuanceOffset returns the offset of the first reissue stage in the schedule, which
+// is used to compute the reissuance date included in emails. If the schedule does not
+// reissue certificates, zero is returned so that the reissuance date is the expiration.
+func (s ReissuanceSchedule) ReissuanceOffset() time.Duration {
+	for _, stage := range s {
+		if stage.Action == ActionReissue {
+			return stage.Offset
+		}
+	}
+	return 0
+}
+
+// ReissuanceSchedules maps Sectigo profile names to the reissuance schedule used for
+// the certificates issued with that profile, e.g. so that TestNet and MainNet can use
+// different schedules. The schedules are decoded from a JSON object in the environment.
+type ReissuanceSchedules map[string]ReissuanceSchedule
+
+// Decode implements envconfig.Decoder to parse the schedules from JSON.
+func (s *ReissuanceSchedules) Decode(value string) error {
+	schedules := make(ReissuanceSchedules)
+	if err := json.Unmarshal([]byte(value), &schedules); err != nil {
+		return fmt.Errorf("could not parse reissuance schedules: %w", err)
+	}
+	*s = schedules
+	return nil
+}
+
+// Validate that each schedule is for a known Sectigo profile and is valid.
+func (s ReissuanceSchedules) Validate() (err error) {
+	for profile, schedule := range s {
+		if _, ok := sectigo.Profiles[profile]; !ok {
+			return fmt.Errorf("invalid configuration: %q is not a valid Sectigo profile for a reissuance schedule", profile)
+		}
+
+		if err = schedule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Package duplicates detects repeat registrations of the same legal entity in the
+directory service. A fingerprint of the IVMS101 legal person, common name, and TRISA
+endpoint of a registering VASP is compared to the fingerprints of the existing VASP
+records and any records that are likely registrations of the same legal entity are
+returned so that the registration can be rejected or flagged for review.
+*/
+package duplicates
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/store"
+	"github.com/trisacrypto/directory/pkg/utils/sentry"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+// New creates a duplicate detector that compares VASPs to the records in the store.
+func New(conf config.DuplicatesConfig, db store.Store) *Detector {
+	return &Detector{conf: conf, db: db}
+}
+
+// Detector finds existing VASP records that are likely registrations of the same legal
+// entity as a VASP by comparing the fingerprints of the records.
+type Detector struct {
+	conf config.DuplicatesConfig
+	db   store.Store
+}
+
+// Reject returns true if registrations that have likely duplicates should be rejected
+// rather than flagged for review.
+func (d *Detector) Reject() bool {
+	return d.conf.Enabled && d.conf.Action == config.DuplicatesReject
+}
+
+// Check returns the existing VASP records whose fingerprints match the fingerprint of
+// the VASP with a score of at least the configured threshold, ordered by descending
+// score. The VASP itself is never matched, so Check can be used for existing records.
+// Rejected and errored records are not matched so that an entity whose earlier
+// registration failed can register again. If duplicate detection is disabled no
+// matches are returned.
+func (d *Detector) Check(ctx context.Context, vasp *pb.VASP) (matches []*models.DuplicateMatch, err error) {
+	if !d.conf.Enabled {
+		return nil, nil
+	}
+
+	fingerprint := NewFingerprint(vasp)
+	detectedOn := time.Now().Format(time.RFC3339)
+
+	iter := d.db.ListVASPs(ctx)
+	defer iter.Release()
+	for iter.Next() {
+		var other *pb.VASP
+		if other, err = iter.VASP(); err != nil {
+			sentry.Warn(ctx).Err(err).Msg("could not parse VASP from database")
+			continue
+		}
+
+		if vasp.Id != "" && other.Id == vasp.Id {
+			continue
+		}
+
+		switch other.VerificationStatus {
+		case pb.VerificationState_REJECTED, pb.VerificationState_ERRORED:
+			continue
+		}
+
+		score, reasons := fingerprint.Compare(NewFingerprint(other))
+		if score < d.conf.Threshold {
+			continue
+		}
+
+		matches = append(matches, &models.DuplicateMatch{
+			VaspId:     other.Id,
+			CommonName: other.CommonName,
+			Reasons:    reasons,
+			Score:      score,
+			DetectedOn: detectedOn,
+		})
+	}
+
+	if err = iter.Error(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	return matches, nil
+}
@@ -0,0 +1,156 @@
+package duplicates_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/gds/duplicates"
+	"github.com/trisacrypto/directory/pkg/store"
+	storeconfig "github.com/trisacrypto/directory/pkg/store/config"
+	"github.com/trisacrypto/trisa/pkg/ivms101"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+func TestFingerprint(t *testing.T) {
+	vasp := makeVASP("Example Exchange, Ltd.", "GB", "trisa.example.co.uk", "trisa.example.co.uk:443")
+	vasp.Entity.NationalIdentification = &ivms101.NationalIdentification{
+		NationalIdentifier:     "5493 0017 2Z0B HTMM Y584",
+		NationalIdentifierType: ivms101.NationalIdentifierLEIX,
+	}
+
+	fingerprint := duplicates.NewFingerprint(vasp)
+	require.Equal(t, "549300172Z0BHTMMY584", fingerprint.LEI)
+	require.Empty(t, fingerprint.NationalIdentifier)
+	require.Equal(t, []string{"exampleexchange"}, fingerprint.LegalNames)
+	require.Equal(t, "GB", fingerprint.Country)
+	require.Equal(t, "example.co.uk", fingerprint.Domain)
+	require.Equal(t, "trisa.example.co.uk", fingerprint.Endpoint)
+
+	// A fingerprint should match itself conclusively
+	score, reasons := fingerprint.Compare(fingerprint)
+	require.Equal(t, 1.0, score)
+	require.Equal(t, []string{duplicates.ReasonLEI, duplicates.ReasonLegalName, duplicates.ReasonCommonName, duplicates.ReasonEndpoint}, reasons)
+
+	testCases := []struct {
+		name    string
+		other   *pb.VASP
+		score   float64
+		reasons []string
+	}{
+		{"no match", makeVASP("Other Exchange", "US", "trisa.other.io", "trisa.other.io:443"), 0, nil},
+		{"legal name", makeVASP("EXAMPLE EXCHANGE LIMITED", "US", "trisa.other.io", "trisa.other.io:443"), 0.5, []string{duplicates.ReasonLegalName}},
+		{"legal name and country", makeVASP("Example-Exchange", "United Kingdom", "trisa.other.io", "trisa.other.io:443"), 0.8, []string{duplicates.ReasonLegalName}},
+		{"common name", makeVASP("Other Exchange", "US", "testnet.example.co.uk", "trisa.other.io:443"), 0.4, []string{duplicates.ReasonCommonName}},
+		{"endpoint", makeVASP("Other Exchange", "US", "trisa.other.io", "trisa.example.co.uk:4000"), 0.5, []string{duplicates.ReasonEndpoint}},
+	}
+
+	for _, tc := range testCases {
+		score, reasons := fingerprint.Compare(duplicates.NewFingerprint(tc.other))
+		require.InDelta(t, tc.score, score, 1e-9, tc.name)
+		require.Equal(t, tc.reasons, reasons, tc.name)
+	}
+
+	// National identifiers must match the type and country of issue
+	vasp.Entity.NationalIdentification = &ivms101.NationalIdentification{
+		NationalIdentifier:     "HRB 12345",
+		NationalIdentifierType: ivms101.NationalIdentifierRAID,
+		CountryOfIssue:         "DE",
+	}
+	other := makeVASP("Other Exchange", "DE", "trisa.other.io", "trisa.other.io:443")
+	other.Entity.NationalIdentification = &ivms101.NationalIdentification{
+		NationalIdentifier:     "hrb12345",
+		NationalIdentifierType: ivms101.NationalIdentifierRAID,
+		CountryOfIssue:         "DE",
+	}
+
+	score, reasons = duplicates.NewFingerprint(vasp).Compare(duplicates.NewFingerprint(other))
+	require.Equal(t, 1.0, score)
+	require.Equal(t, []string{duplicates.ReasonNationalIdentifier}, reasons)
+
+	other.Entity.NationalIdentification.CountryOfIssue = "AT"
+	score, reasons = duplicates.NewFingerprint(vasp).Compare(duplicates.NewFingerprint(other))
+	require.Zero(t, score)
+	require.Empty(t, reasons)
+}
+
+func TestDetector(t *testing.T) {
+	db, err := store.Open(storeconfig.StoreConfig{URL: "leveldb:///" + t.TempDir()})
+	require.NoError(t, err, "could not open leveldb store")
+	defer db.Close()
+
+	ctx := context.Background()
+	original := makeVASP("Example Exchange Ltd", "GB", "trisa.example.com", "trisa.example.com:443")
+	original.Id, err = db.CreateVASP(ctx, original)
+	require.NoError(t, err)
+
+	similar := makeVASP("Example Exchange", "GB", "trisa.other.io", "trisa.other.io:443")
+	similar.Id, err = db.CreateVASP(ctx, similar)
+	require.NoError(t, err)
+
+	_, err = db.CreateVASP(ctx, makeVASP("Other Exchange", "US", "api.example.com", "api.example.com:443"))
+	require.NoError(t, err)
+
+	conf := config.DuplicatesConfig{Enabled: true, Action: config.DuplicatesFlag, Threshold: 0.8}
+	detector := duplicates.New(conf, db)
+	require.False(t, detector.Reject())
+
+	// A repeat registration should match both records with the most likely first
+	repeat := makeVASP("Example Exchange Limited", "GB", "testnet.example.com", "trisa.example.com:443")
+	matches, err := detector.Check(ctx, repeat)
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	require.Equal(t, original.Id, matches[0].VaspId)
+	require.Equal(t, "trisa.example.com", matches[0].CommonName)
+	require.Equal(t, 1.0, matches[0].Score)
+	require.Equal(t, []string{duplicates.ReasonLegalName, duplicates.ReasonCommonName, duplicates.ReasonEndpoint}, matches[0].Reasons)
+	require.NotEmpty(t, matches[0].DetectedOn)
+	require.Equal(t, similar.Id, matches[1].VaspId)
+	require.Equal(t, 0.8, matches[1].Score)
+
+	// An existing record should not match itself
+	matches, err = detector.Check(ctx, original)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	require.Equal(t, similar.Id, matches[0].VaspId)
+
+	// A registration of a different legal entity should not match
+	matches, err = detector.Check(ctx, makeVASP("Another Exchange", "FR", "trisa.another.fr", "trisa.another.fr:443"))
+	require.NoError(t, err)
+	require.Empty(t, matches)
+
+	// Rejected and errored records should not be matched
+	similar.VerificationStatus = pb.VerificationState_REJECTED
+	require.NoError(t, db.UpdateVASP(ctx, similar))
+	original.VerificationStatus = pb.VerificationState_ERRORED
+	require.NoError(t, db.UpdateVASP(ctx, original))
+	matches, err = detector.Check(ctx, repeat)
+	require.NoError(t, err)
+	require.Empty(t, matches)
+
+	// If disabled no matches should be returned
+	detector = duplicates.New(config.DuplicatesConfig{Enabled: false, Action: config.DuplicatesReject}, db)
+	require.False(t, detector.Reject())
+	matches, err = detector.Check(ctx, repeat)
+	require.NoError(t, err)
+	require.Empty(t, matches)
+
+	conf.Action = config.DuplicatesReject
+	require.True(t, duplicates.New(conf, db).Reject())
+}
+
+func makeVASP(name, country, commonName, endpoint string) *pb.VASP {
+	return &pb.VASP{
+		CommonName:    commonName,
+		TrisaEndpoint: endpoint,
+		Entity: &ivms101.LegalPerson{
+			Name: &ivms101.LegalPersonName{
+				NameIdentifiers: []*ivms101.LegalPersonNameId{
+					{LegalPersonName: name, LegalPersonNameIdentifierType: ivms101.LegalPersonLegal},
+				},
+			},
+			CountryOfRegistration: country,
+		},
+	}
+}
@@ -0,0 +1,198 @@
+package duplicates
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/trisacrypto/directory/pkg/store/index"
+	"github.com/trisacrypto/trisa/pkg/ivms101"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"golang.org/x/net/publicsuffix"
+)
+
+// Reasons are the fingerprint fields that can match between two VASP records.
+const (
+	ReasonLEI                = "lei"
+	ReasonNationalIdentifier = "national_identifier"
+	ReasonLegalName          = "legal_name"
+	ReasonCommonName         = "common_name"
+	ReasonEndpoint           = "endpoint"
+)
+
+// Weights of each matching field that are summed to compute the likelihood that two
+// records are registrations of the same legal entity. Identifiers issued to the legal
+// entity are conclusive, whereas names and domains are only suggestive. A legal name
+// match is weighted more heavily if the country of registration also matches.
+const (
+	weightLEI                = 1.0
+	weightNationalIdentifier = 1.0
+	weightLegalNameCountry   = 0.8
+	weightLegalName          = 0.5
+	weightEndpoint           = 0.5
+	weightCommonName         = 0.4
+)
+
+// Corporate designations that are removed from the end of legal names so that, e.g.
+// "Example Exchange Ltd" and "Example Exchange Limited" have the same fingerprint.
+var designations = map[string]struct{}{
+	"ab": {}, "ag": {}, "as": {}, "bv": {}, "co": {}, "company": {}, "corp": {},
+	"corporation": {}, "gmbh": {}, "inc": {}, "incorporated": {}, "kk": {}, "limited": {},
+	"llc": {}, "llp": {}, "lp": {}, "ltd": {}, "nv": {}, "oy": {}, "plc": {}, "pte": {},
+	"pty": {}, "sa": {}, "sarl": {}, "sas": {}, "spa": {}, "srl": {},
+}
+
+// Fingerprint contains the normalized fields of a VASP record that identify the legal
+// entity that registered it. Two records with matching fingerprint fields are likely
+// registrations of the same legal entity, even if their names differ slightly.
+type Fingerprint struct {
+	LEI                string   // the normalized legal entity identifier, if any
+	NationalIdentifier string   // the normalized national identifier, type, and country of issue
+	LegalNames         []string // the normalized names of the legal person
+	Country            string   // the ISO 3166-1 alpha-2 country of registration
+	Domain             string   // the registered domain of the common name
+	Endpoint           string   // the host of the TRISA endpoint
+}
+
+// NewFingerprint computes the fingerprint of the VASP record.
+func NewFingerprint(vasp *pb.VASP) *Fingerprint {
+	f := &Fingerprint{
+		Domain:   domain(vasp.CommonName),
+		Endpoint: host(vasp.TrisaEndpoint),
+	}
+
+	if entity := vasp.Entity; entity != nil {
+		f.Country = index.NormalizeCountry(entity.CountryOfRegistration)
+
+		if nid := entity.NationalIdentification; nid != nil && nid.NationalIdentifier != "" {
+			identifier := normalizeIdentifier(nid.NationalIdentifier)
+			if nid.NationalIdentifierType == ivms101.NationalIdentifierLEIX {
+				f.LEI = identifier
+			} else if identifier != "" {
+				f.NationalIdentifier = strings.Join([]string{nid.NationalIdentifierType.String(), index.NormalizeCountry(nid.CountryOfIssue), identifier}, ":")
+			}
+		}
+
+		if entity.Name != nil {
+			names := make(map[string]struct{})
+			for _, name := range entity.Name.NameIdentifiers {
+				names[normalizeName(name.LegalPersonName)] = struct{}{}
+			}
+			for _, name := range entity.Name.LocalNameIdentifiers {
+				names[normalizeName(name.LegalPersonName)] = struct{}{}
+			}
+			for _, name := range entity.Name.PhoneticNameIdentifiers {
+				names[normalizeName(name.LegalPersonName)] = struct{}{}
+			}
+			delete(names, "")
+
+			f.LegalNames = make([]string, 0, len(names))
+			for name := range names {
+				f.LegalNames = append(f.LegalNames, name)
+			}
+			sort.Strings(f.LegalNames)
+		}
+	}
+	return f
+}
+
+// Compare the fingerprint to another fingerprint, returning the likelihood that the
+// fingerprints identify the same legal entity between 0 and 1 and the fields that
+// matched. A score of zero means that no fields matched.
+func (f *Fingerprint) Compare(o *Fingerprint) (score float64, reasons []string) {
+	if f.LEI != "" && f.LEI == o.LEI {
+		score += weightLEI
+		reasons = append(reasons, ReasonLEI)
+	}
+
+	if f.NationalIdentifier != "" && f.NationalIdentifier == o.NationalIdentifier {
+		score += weightNationalIdentifier
+		reasons = append(reasons, ReasonNationalIdentifier)
+	}
+
+	if f.matchLegalName(o) {
+		if f.Country != "" && f.Country == o.Country {
+			score += weightLegalNameCountry
+		} else {
+			score += weightLegalName
+		}
+		reasons = append(reasons, ReasonLegalName)
+	}
+
+	if f.Domain != "" && f.Domain == o.Domain {
+		score += weightCommonName
+		reasons = append(reasons, ReasonCommonName)
+	}
+
+	if f.Endpoint != "" && f.Endpoint == o.Endpoint {
+		score += weightEndpoint
+		reasons = append(reasons, ReasonEndpoint)
+	}
+
+	if score > 1 {
+		score = 1
+	}
+	return score, reasons
+}
+
+// Returns true if any of the legal names of the fingerprints are the same.
+func (f *Fingerprint) matchLegalName(o *Fingerprint) bool {
+	for _, name := range f.LegalNames {
+		for _, other := range o.LegalNames {
+			if name == other {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Normalizes a legal name by lower casing it, removing punctuation and whitespace, and
+// removing any trailing corporate designations.
+func normalizeName(name string) string {
+	tokens := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for len(tokens) > 1 {
+		if _, ok := designations[tokens[len(tokens)-1]]; !ok {
+			break
+		}
+		tokens = tokens[:len(tokens)-1]
+	}
+	return strings.Join(tokens, "")
+}
+
+// Normalizes an identifier by upper casing it and removing punctuation and whitespace.
+func normalizeIdentifier(id string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, id)
+}
+
+// Returns the registered domain of the common name (e.g. example.co.uk for
+// trisa.example.co.uk) so that different subdomains of the same domain match.
+func domain(commonName string) string {
+	commonName = strings.TrimPrefix(index.Normalize(commonName), "*.")
+	if commonName == "" {
+		return ""
+	}
+
+	if registered, err := publicsuffix.EffectiveTLDPlusOne(commonName); err == nil {
+		return registered
+	}
+	return commonName
+}
+
+// Returns the normalized host of the endpoint without the port.
+func host(endpoint string) string {
+	endpoint = index.Normalize(endpoint)
+	if h, _, err := net.SplitHostPort(endpoint); err == nil {
+		return h
+	}
+	return endpoint
+}
@@ -1,7 +1,7 @@
 // Code generated by go-bindata. DO NOT EDIT.
 // sources:
-// deliver_certs.html (1.983kB)
-// deliver_certs.txt (1.585kB)
+// deliver_certs.html (2.456kB)
+// deliver_certs.txt (2.032kB)
 // expires_admin_notification.html (1.199kB)
 // expires_admin_notification.txt (850B)
 // reissuance_admin_notification.html (931B)
@@ -83,7 +83,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _deliver_certsHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x55\x4f\x6f\xdb\xc6\x13\xbd\xf3\x53\xbc\x9f\x7f\x87\xb6\x80\x2d\x22\x39\x1a\x2c\xd1\x34\xe9\x1f\x23\x45\x1a\x58\x42\x8b\x1e\xc7\xbb\x23\x71\xeb\xe5\xce\x76\x76\x29\x45\x09\xfc\xdd\x8b\x25\x29\x59\x12\x9c\xde\x48\xee\xce\x9b\x37\x33\xef\x0d\x9b\xd8\xfe\xca\xde\x0b\xbe\x7c\xc1\xe2\x03\xf5\x8c\xa7\xa7\xeb\xa6\x8e\x6d\x55\x35\xb1\xad\x80\xbf\x64\x50\xac\xee\xef\x96\x6f\x10\x38\xef\x44\x1f\xa1\xbc\x71\x29\x2b\x65\x27\x01\x1d\x25\x3c\x30\x07\x50\x8c\x2a\x5b\xb6\xf8\x56\x14\xca\x87\xd7\xef\xfe\x37\x41\x88\x6e\x28\xb8\xcf\x63\x50\x85\xe7\xb0\x8d\x52\xc8\x6c\xe1\x2c\x87\xec\xf2\x1e\x86\x35\xbb\xb5\x33\x94\x39\x61\x4b\xde\x59\xca\x2e\x6c\xb0\x2f\x28\x3d\xf7\x0f\xac\xa9\x73\x11\x59\x20\xb9\xe3\x99\x5c\x85\xc3\x19\xb6\x8e\x90\x3b\x9e\x49\xff\xe2\xe5\x81\x3c\xde\x39\x65\x93\x45\xf7\x0b\xbc\xc9\x99\x4c\xc7\xb6\x20\xe4\xce\x25\x70\x4f\xce\x83\x94\xf1\xf1\xfd\xdb\xe5\xab\xd7\x15\xc0\xc1\xe8\x3e\x16\x5e\x67\x74\x52\x89\xa0\x5c\xb8\xc0\x50\x80\xeb\xa3\xe7\x9e\x43\x3e\x49\x18\x55\xb2\x18\xf1\x18\x52\xa1\xdd\xaf\x7e\x5b\x56\xc0\xce\xe5\x6e\xe6\x7b\x68\xe3\x81\x6f\x16\xf0\x27\xd3\x51\xd8\x30\x56\x4a\x5b\xf6\xb8\x1f\x3c\xc3\x48\x1f\xbd\xa3\x60\x18\x2e\xac\x45\xfb\xb1\x77\x8b\xea\x38\x9d\x55\xc7\x88\xea\x7a\xd2\x3d\x2c\x67\x72\x3e\x41\xd6\x53\xa3\xec\xa1\x5e\x70\xc8\xba\x1f\xab\xa3\x84\xb5\x78\x2f\xbb\x74\x3b\x63\x0c\xbe\x8c\xb8\xf1\xae\x6d\x52\x56\x09\x9b\xf6\xee\xdd\x6d\x53\xcf\xcf\xa3\x26\xfe\xb8\x7b\x87\xa7\xa7\xa6\xf6\xee\xf2\xea\xfd\x28\x03\x56\xb6\xcf\xdd\xbd\x08\x7e\xbe\x72\xbc\xf1\x15\xb0\xdf\x4f\xe4\x71\x01\x72\x7a\xf4\x95\xe8\xb7\xd2\xf7\x12\x50\xf4\x7b\x11\x3c\x9d\xcc\xc2\x7e\x29\x74\xc9\xea\xc8\xe3\xc3\x50\x86\x71\x11\x3c\x9d\x4d\x47\x5f\x09\xff\x29\xd8\x28\x2e\xe4\x8b\xc8\xc3\xe7\x63\x54\x53\x97\x5e\xcf\x9e\x5a\x09\x2c\x8f\x02\x9b\x86\x75\xaa\xb1\x6b\x34\xdc\xb7\x45\x60\x3b\xe7\x3d\x02\xb3\x05\xcd\xc2\x44\xa4\x94\x76\xa2\xb6\xa9\xb9\x6f\x17\xb8\x5b\x4f\x02\x76\xa9\xc2\xa8\xc0\xb5\xd3\x94\x4f\xe1\x0a\xfe\x37\x5b\x86\xb2\x61\x57\xcc\x49\xeb\xcc\x7a\xe6\xe0\xeb\xf1\xce\x21\x55\x41\x39\xba\xe0\x90\x6e\x12\xfd\x8e\x12\xac\x4b\xd1\xd3\x9e\x2d\xec\xa0\x45\xde\xe5\xfe\xd9\x3e\x88\x2a\x86\x53\x9a\xc9\x71\xe2\x49\x7a\x21\x0c\xe4\xfd\xbe\x02\x94\x6f\x5c\x4a\xc3\x85\xb5\x5e\x60\xf1\xf6\xfd\x69\xc9\xcf\x1c\x46\xbb\x4e\xf6\x2d\x6d\x2a\x76\xa7\x0a\x90\xc0\x37\xd9\xf5\x8c\x3f\x3b\x97\x22\x2b\xbc\x0b\x8f\x88\xa5\x72\x3b\x31\x3d\x58\xfd\xc4\x42\xe7\xc3\x28\x69\x87\xf0\xd9\xc5\x78\x69\x7c\x09\xe3\xa1\x91\xbe\xa7\x60\x0b\x34\x5f\x1f\xb7\xc0\x90\x18\x8d\x11\xcb\xad\x44\x0e\x29\xf9\xa6\x1e\xdf\x2a\x14\xcb\x59\x4e\x46\xdd\x43\x59\x71\x01\x0d\xa1\x53\x5e\x7f\x7f\xd5\xe5\x1c\xd3\x6d\x5d\x67\x75\x89\x16\x96\xb7\xf5\xdf\xe2\x82\x0b\x9b\x9b\xf1\x4b\x1d\x1f\x4d\x7a\xf5\xba\x76\xc1\xf2\xa7\x45\x97\x7b\xff\x7f\x32\xa5\xaf\xe5\x06\x05\x7b\x93\x68\x3b\x5e\xee\xf8\xe6\x94\xe8\x55\x3b\x9a\x5f\xcc\x50\xb6\xd2\x38\x91\xa6\xa6\x76\x31\xd6\x3a\x17\xfc\xb3\x28\x7a\xd1\xb3\xad\x52\x0a\x74\x21\xf3\x46\xa7\x65\x3b\xae\xab\xe7\x9d\x36\xaf\xac\x6b\x44\xcf\x94\x18\x89\x19\x32\x68\x85\xf3\x54\xa0\xfc\x5f\x15\x5e\xb5\xc7\xe7\x91\x54\x91\x48\xe9\x61\x47\xdb\xa2\x90\x22\x8e\x7f\x06\x4e\x05\x69\xd2\x03\x7a\xda\xc3\x48\xc8\x64\x32\x86\x04\xca\xc5\x7d\x87\x04\x65\x94\x59\x6e\xd3\x10\xa3\x68\xfe\x41\x65\x22\x41\x7e\xe1\xe4\xaa\x7d\xf1\x73\x49\x0b\x51\x94\x5e\x17\xc0\xf1\x57\x54\x3a\xf6\x32\xeb\x9b\x52\x74\x8a\x64\x78\x91\x3c\x99\xc7\x85\x91\xfe\xaa\x5d\x96\x47\x94\x85\x1d\xd8\x4f\x85\x7c\x9c\xda\x62\xa5\x02\x82\x64\x28\x47\xbf\x9f\x77\xb0\xdf\x9f\xff\x68\xce\xd5\xf7\x23\xa7\x8c\x7b\xde\x90\xda\x74\xdd\x3c\x28\xea\x51\x93\x2f\xfe\xba\xb0\x64\xdd\x3a\xc3\x58\x31\xf5\x13\xca\xbf\x01\x00\x00\xff\xff\x44\x02\x3d\xcd\xbf\x07\x00\x00")
+var _deliver_certsHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x84\x56\x4d\x6f\xe4\x36\x0c\xbd\xfb\x57\xb0\xe9\xa1\x2d\x90\x8c\xb1\x7b\x4c\x5d\xa3\xdb\x6c\x3f\x82\x2d\x76\x83\x24\x68\xd1\x23\x47\xe2\x8c\xd5\xc8\xa2\x4a\xc9\x93\xf5\x06\xf3\xdf\x0b\xfa\x63\xbe\x92\xb4\x37\x8f\x24\x3e\x91\x8f\x8f\x4f\x53\xc5\xfa\x37\xf2\x9e\xe1\xe9\x09\x16\x1f\xb1\x25\xd8\x6e\xcf\xab\x32\xd6\x45\x51\xc5\xba\x00\xf8\x8b\x3b\x81\xfb\xdb\xeb\xbb\x77\x10\x28\x3f\xb2\x3c\x80\xd0\xda\xa5\x2c\x98\x1d\x07\x68\x30\xc1\x92\x28\x00\xc6\x28\xbc\x21\x0b\xdf\xb2\x80\xd0\xfc\xf3\xbb\xaf\x46\x08\x96\x35\x06\xf7\x65\x08\x2a\x60\x1f\xb6\x16\x0c\x99\x2c\x38\x4b\x21\xbb\xdc\x83\x21\xc9\x6e\xe5\x0c\x66\x4a\xb0\x41\xef\x2c\x66\x17\xd6\xd0\x2b\x4a\x4b\xed\x92\x24\x35\x2e\x42\x66\xe0\xdc\xd0\x94\x5c\x01\xf3\x1e\x6c\x1c\x42\x6e\x68\x5c\x87\x5f\x3d\x2f\xd1\xc3\x7b\x27\x64\x32\x4b\xbf\x80\x77\x39\xa3\x69\xc8\x2a\x42\x6e\x5c\x02\x6a\xd1\x79\x40\xa1\x02\x94\x06\xb7\x82\xc5\x4d\xb7\xf4\xce\x7c\x0a\xbe\x87\xed\x56\xc1\xe2\xb0\x70\x9c\x9c\x4b\xa9\x23\x0b\x2b\x96\x31\xb9\x83\x4d\x48\x6e\x1d\x5c\x58\x17\x00\x42\xff\x74\x94\xf2\xd3\x13\x90\x4f\x4a\xef\xcd\x87\xab\xbb\x37\x6f\x81\x82\x91\x3e\x6a\xe9\x87\xa0\x7a\x2c\x58\xd8\x6e\x21\x69\x76\x98\x15\x1a\x0c\x06\x70\x6d\xf4\xd4\x52\xc8\x05\x1c\x94\x17\x85\x33\x1b\xf6\xd0\x25\x25\xa9\xbd\xff\xfd\x0e\x1e\x5d\x6e\x26\x6e\xe6\x96\xcd\xdc\x64\x06\xfa\x6c\x1a\x0c\x6b\x82\x7b\xc1\x0d\x79\xb8\xed\xbc\x16\x6e\xb8\x8d\xde\x61\x30\x04\x2e\xac\x58\xda\xa1\x53\x8b\x62\xa7\x85\x7b\x65\x41\x5c\x8b\xd2\x83\xa5\x8c\xce\x27\xe0\xd5\x58\xb9\x9d\xd9\x05\x0a\x59\x7a\xe5\x12\x30\xc1\x8a\xbd\xe7\xc7\x74\x39\x61\x74\x5e\x05\x55\x79\x57\x57\x29\x0b\x87\x75\x7d\xfd\xfe\xb2\x2a\xa7\xef\x41\x81\x7f\x5c\xbf\x87\xed\xb6\x2a\xbd\x3b\x3d\x7a\x3b\x88\x8e\x84\xec\xbe\x97\x27\xc1\xfb\x23\xbb\x13\xaf\x80\x7d\x3a\x10\xe3\x09\xc8\xe1\xd6\x2b\xd1\x57\xdc\xb6\x1c\x40\xa7\xe5\x24\x78\xdc\x99\xc6\xe8\xa5\xd0\x3b\x12\x87\x1e\x3e\x76\xda\x8e\x93\xe0\x71\x6f\xdc\x7a\x25\xfc\xe7\x60\x23\xbb\x90\x4f\x22\xe7\xe5\x5d\x54\x55\x2a\xd7\xc5\x4b\x62\x9e\xc6\x5a\xbb\x89\xf3\x20\x1c\xa9\xba\xa2\xb6\xb6\x0c\x81\x33\x18\x0e\x19\x5d\x00\xd4\xc6\x6f\x30\x13\x3c\x50\x5f\x95\xd4\xd6\x80\xc1\x0e\x5d\x0e\x9c\x0b\xd8\x6b\x79\x01\x57\xdc\x2e\x5d\xa0\x41\xa2\x07\xb8\x60\x1a\x45\x1a\xa4\x99\x1b\x3a\x04\x1c\x65\xfe\x88\x09\xba\x34\x4e\xa5\x11\xc2\x4c\x05\xbc\x3a\x56\xf3\x50\x7d\xff\x0c\x4b\x7d\x25\xd0\x86\x64\x74\x97\xd4\xa0\x2a\x66\xbe\x56\x0b\x7f\xd1\x15\xe0\x8e\x64\xe3\x0c\x01\xcb\xb3\xcc\xb1\xcb\x0d\x8b\xcb\xfd\x34\x0c\xfb\x49\x9e\xb9\x64\xb0\x34\x10\xf0\x2c\xe1\x74\x3e\xf0\xa9\x33\xfc\xe8\xbc\x87\x40\x64\x01\x61\xb2\x80\x88\x29\x3d\xb2\xd8\x81\xd2\x05\x5c\xaf\x46\x3f\x72\x69\x1a\xf1\x95\x93\x94\x8f\x72\xe9\xb9\xfb\x66\x43\x20\x64\xc8\xa9\xd7\xe2\x2a\x93\x1c\x19\xf2\xf9\x70\x66\xbe\x4a\x51\xc6\xcb\x0a\xd8\x5d\xb7\x27\xdc\xba\x14\x3d\xf6\x64\xc1\x76\xa2\xbc\xea\xf9\x43\x34\x88\xc2\x86\x52\x9a\x92\xa3\x44\xe3\x6c\x87\xd0\xa1\xf7\xfd\x60\x6f\x17\x93\x0f\x1e\x97\xfd\x2c\x8b\xab\x0f\x87\x25\xef\x73\x18\xdc\x77\xec\xbb\xd2\xa4\xee\x8d\x05\x00\x07\xba\xc8\xae\x25\xf8\xb3\x71\x29\x92\x80\x77\xe1\x01\xa2\x56\x6e\xc7\x4c\x67\xe7\x3e\xf0\xa8\xe3\x66\xe8\xb5\x5d\xf8\xe2\x62\x3c\xd5\x38\x87\xb1\xcb\xdc\xb6\x2a\x64\xef\x02\x9d\xef\x8c\xb6\x4b\x04\x95\x61\x4b\x35\x47\x0a\x29\xf9\xaa\x1c\x7e\x15\x00\x4a\x19\x25\x23\x6e\xa9\x2f\x56\x80\x0a\xa1\x11\x5a\xfd\x70\xd6\xe4\x1c\xd3\x65\x59\x66\x71\x09\x17\x96\x36\xe5\xdf\xec\x54\xa9\x17\xc3\x4a\x19\x1f\x4c\x7a\xf3\xb6\x74\xc1\xd2\xe7\x45\x93\x5b\xff\x35\x1a\xe5\x55\x4f\x60\xb0\x17\x09\x37\xc3\xe1\x86\x2e\x0e\x13\x3d\xab\x07\x77\x65\xd3\xa9\xf1\x0f\x1d\xa9\x4a\xac\x17\x45\x35\xe9\x70\x78\x2a\xe6\xd2\x7f\x61\x81\x96\xe5\xc8\xc0\xb5\x54\x17\x32\xad\x65\x7c\x45\x77\x03\x78\xf4\xa4\x9f\x43\xf4\x84\x89\x20\x11\x01\x77\x52\xc0\xf1\xa5\x80\xf9\xbf\x6a\x3d\xab\x77\xdf\x43\x7a\x2a\x16\x65\xb3\xc1\x8d\x6a\x45\x65\x32\x8c\xab\xe3\x30\x2a\x03\x5a\xec\x47\x77\x31\x19\xba\x04\x98\x0b\xd8\x5f\xa0\x4d\xcd\x7c\x99\xba\x18\x59\xf2\x8f\xc2\x63\x12\xe8\x17\x8e\xcf\xea\x17\x97\xf5\x5a\x60\x01\x65\x5d\x01\x87\xff\x18\xca\xdd\xcb\x59\x5f\x68\xd1\x29\xa2\xa1\x45\xf2\x68\x1e\x16\x86\xdb\xb3\xfa\x4e\x3f\xd5\xa8\x42\x20\x3f\x16\x72\x33\xd2\x62\xb9\x80\xc1\x11\x85\xa2\xef\xa7\xe7\xce\xf7\xc7\xff\x20\x8e\x75\xf8\x13\xa5\x0c\xb7\xb4\x46\xb1\xe9\xbc\x5a\x0a\x94\xf5\xff\xbb\xcf\x3d\x61\x3b\xa2\xfc\x3b\x00\xba\xfb\x77\x44\x98\x09\x00\x00")
 
 func deliver_certsHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -98,12 +98,12 @@ func deliver_certsHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "deliver_certs.html", size: 1983, mode: os.FileMode(0644), modTime: time.Unix(1721689071, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x51, 0xfc, 0x54, 0x6f, 0xcb, 0x9b, 0xeb, 0x65, 0xcb, 0xa, 0xd4, 0x6b, 0x64, 0xfb, 0xde, 0x72, 0xba, 0xc1, 0xe5, 0xec, 0xfb, 0xa4, 0x1, 0xcb, 0x87, 0x9c, 0x97, 0x25, 0xef, 0x60, 0xb8, 0x3f}}
+	info := bindataFileInfo{name: "deliver_certs.html", size: 2456, mode: os.FileMode(0644), modTime: time.Unix(1721689071, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3a, 0x28, 0xdb, 0x4d, 0xf, 0xcf, 0xb2, 0xbe, 0x18, 0x9d, 0x26, 0xcd, 0xa6, 0x62, 0xea, 0x20, 0xd0, 0x3e, 0xd9, 0xb, 0xc8, 0x7f, 0xf8, 0x2f, 0x8b, 0x80, 0xd4, 0xd, 0x97, 0x0, 0x35, 0x58}}
 	return a, nil
 }
 
-var _deliver_certsTxt = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x54\x4d\x6f\xdc\x46\x0c\xbd\xeb\x57\xb0\xe8\x21\x2d\xe0\x95\x91\x1c\x7d\x6a\x6a\xf7\xc3\x48\x91\x06\xde\x45\x8b\xde\x42\xcf\x70\x57\xac\x47\xc3\x29\x49\x69\xa3\x04\xfe\xef\xc5\x48\xbb\xf1\xae\xe3\xa3\xc8\x27\xf2\x0d\xdf\x23\x7f\xa7\x94\x04\xbe\x7c\x81\xf6\x3d\xf6\x04\x8f\x8f\x17\x4d\xf3\x8f\x0c\x0a\x9b\xbb\xdb\xf5\x5b\xc8\xe4\x7b\xd1\x07\x50\xda\xb1\xb9\xa2\xb3\x64\xe8\xd0\xe0\x9e\x28\x03\x96\xa2\x32\x52\x84\x1f\x44\x41\xe9\xf8\xf9\xe3\x77\x30\x97\x10\xdd\x61\xe6\xcf\xcf\x7e\xda\x29\x66\xa7\x08\x1c\x29\x3b\xfb\x04\x81\xd4\x79\xcb\x01\x9d\x0c\x46\x4c\x1c\xd1\x39\xef\x60\xaa\x35\x7a\xea\xef\x49\xad\xe3\x02\x2e\x20\xde\xd1\x91\xda\x21\x03\x23\x23\x78\x47\x87\xe8\x6f\x49\xee\x31\xc1\x0d\x2b\x05\x17\x9d\x5a\x78\xeb\x8e\xa1\xa3\x58\xff\xf7\x8e\x0d\xa8\x47\x4e\x80\x4a\xf0\xe1\xdd\xf5\xfa\xf5\x1b\xa0\x1c\x74\x2a\x95\xd3\x19\x15\xab\x78\xf4\xca\x03\x02\x66\xe0\xbe\x24\xea\x29\xfb\x49\xbb\xa2\xe2\x12\x24\xc1\x60\x95\x72\xbf\xf9\x63\x0d\x7b\xf6\xee\xc0\xf4\x38\xbe\x23\x57\x17\xa0\x4f\xa1\xc3\xbc\x23\xd8\x28\x8e\x94\xe0\x6e\x48\x04\x41\xfa\x92\x18\x73\x20\xe0\xbc\x15\xed\xe7\x99\xb5\x4d\xb3\xe9\x08\x8a\x72\x8f\x3a\x41\x24\x47\x4e\x06\xb2\x5d\x46\x13\x8f\x6f\x04\xca\xae\xd3\xfc\x22\x34\xd8\x4a\x4a\xb2\xb7\xab\xa6\xb9\xbd\xb9\x9a\x95\xfd\xeb\xf6\x06\x1e\x1f\x9b\xbb\x59\x43\x52\x8a\x4f\xe3\x59\x00\x4f\x99\xaf\x89\xfa\xc3\x9f\x27\xfa\x2d\xc0\xd3\x48\x45\x5c\x4b\xdf\x4b\x86\x6a\x9d\x05\xb0\x04\x0e\x56\x6a\xd6\xa4\x8c\x09\xde\x0f\xf5\xf5\x0b\x60\x09\x2d\x91\x0a\xf9\x25\xc7\x22\x9c\x7d\xc9\x1e\xbf\x6a\xa6\xd9\x08\x44\x9a\x95\x59\xde\x7b\x2a\xce\xc5\xac\xca\x9e\x53\x82\x4c\x14\x01\x8f\x5a\x16\x34\xdb\x8b\xc6\x16\x6e\xb7\x8b\xdc\x6c\xb3\x5e\x5b\x56\xf3\xd3\x1a\xb5\xc2\xab\x91\x40\x29\x30\x55\x13\xe3\xd6\x49\xcf\x9c\x3e\x77\x79\x75\xec\x51\xab\x3c\xeb\xb2\x18\x64\x8f\x06\x91\xad\x24\x9c\x28\x42\x1c\xb4\x5a\xa1\xa2\xcf\xb6\xa6\xa8\x04\x32\x3b\x10\x23\xa3\x45\xb0\x9c\x07\x4c\x69\x02\xa5\x15\x9b\x0d\xcf\x4c\xf8\x02\x83\xeb\x77\x2f\x33\x98\x6d\xbd\xd8\xbc\xce\xa6\xae\x05\x82\x64\x5a\x39\xf7\x04\x7f\x77\x6c\x85\x14\x12\xe7\x07\x28\x4a\x81\xe2\xc2\xf2\xb8\x10\xed\xd9\xc0\x6b\xa7\x21\x7f\xe6\x52\x9e\x6f\x85\xe4\x39\x19\xa4\xef\x31\xc7\x5a\x8f\x2e\xbe\xae\xc8\x60\x04\x1f\xa5\x50\x36\x4b\x1f\xab\x19\x23\x59\x50\xbe\xaf\xeb\x9e\x61\x36\xad\x84\xa1\xee\xd0\xe1\x28\x90\xd2\x55\xd3\x74\xee\xc5\xae\x2e\x2f\x5d\xd9\xb0\x8d\x34\x5e\xfe\x2b\x9c\x39\xef\x56\x73\xe4\xb2\x3c\x04\x7b\xfd\xe6\x92\x73\xa4\x4f\x6d\xe7\x7d\xfa\x1e\x43\x9d\x65\x45\x60\x8e\x2b\xc3\x71\x06\x77\xb4\x3a\xe5\xda\x34\xbf\x8a\x42\x2f\x7a\xb6\x55\xf5\x05\x9c\x9d\x76\xba\x9c\x99\x79\x5d\x9f\x36\xfa\xb0\xb2\x17\x50\x12\xa1\x11\x8c\x6c\xec\x2f\x50\x47\x87\x6f\x69\xcf\xe2\xd6\x61\x74\x38\x56\x6d\x27\xf8\x6f\x20\xab\xf8\x83\x63\x7b\x9c\x20\x48\x76\x0c\x0e\x83\xd5\x22\x36\x94\x22\xea\x3f\xa9\x2c\x85\x31\xb5\x2c\x20\x0a\x75\x04\x15\x23\xcb\xe0\xd6\x09\xc3\x03\xd4\xdb\x91\x29\xc1\xdc\x72\x55\x89\x5a\xc1\x40\xad\xd5\x6c\x1b\xa4\x6f\xe1\xc3\xc2\x3b\x0a\x64\x71\x50\x2a\x69\x3a\x9c\x8a\x34\x9d\xdf\xc0\xb6\x69\x7e\x26\x73\xb8\xa3\x1d\x6a\xb4\x8b\xe6\xe5\x13\x0a\x6b\xd2\x91\x03\xc1\x86\xb0\x6f\xfe\x0f\x00\x00\xff\xff\x6a\xe7\xb1\x52\x31\x06\x00\x00")
+var _deliver_certsTxt = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x84\x55\x4d\x6f\xe3\x46\x0c\xbd\xeb\x57\xb0\xe8\x61\x5b\x20\x56\xb0\x7b\x4c\x2f\xdd\x26\xfd\x08\xb6\xd8\x0d\xe2\xa0\x45\x6f\x4b\xcf\xd0\x16\x9b\xd1\x50\xe5\x8c\xe4\xd5\x1a\xfe\xef\x05\x35\x72\x62\xc7\x01\xf6\x28\x92\xc3\x8f\xc7\xc7\xa7\x3f\x28\x04\x81\xdd\x0e\xea\x8f\xd8\x12\xec\xf7\x17\x55\xf5\x8f\xf4\x0a\x0f\xf7\xb7\xcb\xf7\x10\x29\x6f\x45\x1f\x41\x69\xc3\x29\x2b\x66\x96\x08\x0d\x26\x58\x11\x45\xc0\xae\x53\x19\xc8\xc3\x0f\xa2\xa0\x74\xf8\xfc\xf1\x3b\x98\x52\x88\x6e\x30\xf2\xd7\x17\x8f\x36\x8a\x31\x93\x07\xf6\x14\x33\xe7\x11\x1c\x69\xe6\x35\x3b\xcc\x94\x60\xc0\xc0\x1e\x33\xc7\x0d\x8c\x96\xa3\xa5\x76\x45\x9a\x1a\xee\x20\x0b\x48\x6e\xe8\xd0\xda\xec\x81\x81\x11\x72\x43\xb3\xf5\xf7\x20\x2b\x0c\x70\xc3\x4a\x2e\x8b\x8e\x35\xbc\xcf\x19\x5d\x43\xde\xde\xe7\x86\x13\x50\x8b\x1c\x00\x95\x6c\x6c\x5e\x43\x7d\xd7\xaf\x02\xbb\x4f\x31\x8c\xb0\xdf\x5b\xaa\x6e\x32\x9c\x36\xc6\x29\xf5\xe4\x61\x2d\x5a\x1a\x3b\x72\x42\xe2\x4d\xb4\x8e\x95\xfe\xeb\x29\xe5\xdd\x0e\x28\x24\x03\xf3\xee\xc3\xf5\xf2\xed\x3b\xa0\xe8\x74\xec\x6c\xe8\xe3\x94\x16\x16\x3d\xec\xf7\x90\xac\x33\xcc\x96\x18\x1c\x46\xe0\xb6\x0b\xd4\x52\xcc\x47\x83\x75\x2a\x59\x9c\x04\xe8\x93\x95\x6a\x1f\xfe\x5c\xc2\x96\x73\x33\x63\x72\x58\xd4\x01\x95\x2c\x40\x5f\x5c\x83\x71\x43\xf0\xa0\x38\x50\x80\xfb\x3e\x10\x38\x69\xbb\xc0\x18\x1d\x01\xc7\xb5\x68\x3b\x6d\xa7\xae\xaa\x07\x9b\x5b\xb9\x45\x1d\xc1\x53\x46\x0e\x09\x64\x5d\x66\xf5\x07\x34\x81\x62\xd6\x71\xc2\x0e\x13\xac\x25\x04\xd9\xa6\xab\xaa\xba\xbd\xb9\x9a\x38\xf4\xd7\xed\x0d\xec\xf7\xd5\xfd\xc4\x16\x52\xf2\xcf\x8b\x28\x01\xcf\x9e\x27\x87\x3d\xf8\x74\xc4\x94\x12\x78\x6c\xb1\x88\x6b\x69\x5b\x89\x60\x24\x2d\x01\xc5\x30\x93\xb6\x5a\x92\x32\x06\xf8\xd8\xdb\xf4\x25\xa0\x98\x8a\xc5\x42\x7e\x8d\xbe\x13\x8e\xb9\x78\x0f\x5f\xe6\xa9\xce\x79\xb0\xd8\xef\x27\x44\xf0\x40\x9e\x13\x2e\x78\x81\x28\x19\x9c\xc4\x8c\x1c\x01\x0d\xb8\x01\x33\xc1\x23\x8d\x80\xd1\x4f\x00\x59\xc4\xd3\xe2\x6b\xb8\x96\x76\xc5\x91\xa6\x8d\x1e\x25\x03\xd7\x58\x8a\x69\x93\xb9\xa1\x93\x4c\x13\x27\xb6\x98\xa0\x4f\x85\xbe\x4e\xc9\x7c\xdf\xe2\xdf\x4f\x67\x99\xec\xf8\x22\x0d\xa4\xe5\x04\x53\x83\xb6\x9b\xa7\xa2\xaf\x9f\x0e\x2c\x49\x07\x76\x04\xa2\x67\x5d\x63\x9f\x1b\x51\xce\x63\x5d\xed\x76\x8b\x42\xf7\x09\x33\x01\x4f\xd3\xcc\x67\x5d\xa6\x0b\x33\xc1\x96\x43\x80\x48\xe4\x01\x61\x3e\x8f\x0e\x53\xda\x8a\xfa\x1a\x6e\xd7\xe5\x44\x39\x4d\x15\xd7\xac\x29\x9f\xd4\x1d\xa5\x7f\x33\x10\x28\x39\x26\x13\x1e\x5c\x67\xd2\x13\x75\x9a\xaa\xbc\x39\xd4\xb0\x2c\x2f\xaa\x3c\xc3\xea\x39\x75\x01\x47\xf2\xe0\x7b\x35\xfc\x2c\xfa\x38\x97\x1d\x9d\xa3\x94\xe6\xc6\x28\x51\xa1\x7e\x8c\x3d\x86\x30\x82\xd2\x62\x96\x85\xb3\x39\x4f\x3b\xb8\xfe\xf0\x7a\x07\x93\x14\x95\xdd\x1a\x36\x26\x65\x08\x12\x69\x91\xb9\x25\xf8\xbb\xe1\xd4\x91\x42\xe0\xf8\x08\x9d\x92\x23\x5f\xba\x3c\x88\x58\x5d\x1d\x03\x6e\x95\xfa\xf8\x95\xbb\xee\x25\x5f\x25\x96\x05\x4a\xdb\x1a\x3b\x03\x47\xba\x78\x12\x9b\x3e\x11\x7c\x96\x8e\x62\x4a\xe1\x33\x18\x2c\x94\x9c\xf2\x8a\x3c\x70\x84\xe9\xfc\xc5\xf5\xa6\x46\xb3\x90\x93\xd2\x55\x55\x35\x39\x77\xe9\xea\xf2\x32\x2b\x27\xac\x3d\x0d\x97\xff\x0a\x1b\x0b\x17\x93\xe5\xb2\x7b\x74\xe9\xed\xbb\x4b\x8e\x9e\xbe\xd4\x4d\x6e\xc3\xf7\xe8\x0c\x4b\x8b\xc0\xe8\x17\x09\x87\x29\xb8\xa1\xc5\x71\xaf\x85\x4e\x93\x2c\x56\xd5\x6f\xa2\xd0\x8a\x9e\x48\x95\x0d\xc3\x31\xd3\x46\xcb\x5f\xe2\x05\x89\x67\x1d\xbc\x80\x2e\x10\x26\x82\x81\x13\xe7\x57\xa6\xc0\x0c\xe7\x13\x4c\x7b\x36\x5c\x1a\x1c\x6c\xcd\x23\x4c\xf7\xc4\x12\x67\xf2\xb6\x38\x96\xab\x77\x19\xfa\x64\x49\x52\xdf\x75\xa2\xf9\x67\x95\x92\x18\x43\xcd\x02\xa2\x60\x68\x58\x8c\x14\x0c\x97\x01\xdd\xa3\x1d\x7b\x8c\x14\x60\x2a\xb9\xb0\x46\x53\x87\x8e\xea\x64\xde\xda\x49\x5b\xc3\x5d\xe9\x7b\x96\x18\xa5\x2e\x8c\xb3\xfe\x86\xf1\xf4\x17\x56\x57\xd5\x2f\x94\x32\xdc\xd3\x06\xd5\xa7\x8b\xea\x1b\x67\xfc\x40\xd8\x56\xff\x0f\x00\x5e\xc9\x59\x42\xf0\x07\x00\x00")
 
 func deliver_certsTxtBytes() ([]byte, error) {
 	return bindataRead(
@@ -118,8 +118,8 @@ func deliver_certsTxt() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "deliver_certs.txt", size: 1585, mode: os.FileMode(0644), modTime: time.Unix(1721689071, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x6e, 0x37, 0x14, 0xb8, 0xfc, 0xfa, 0xff, 0xbd, 0xc9, 0xc4, 0x79, 0xe2, 0x1a, 0x76, 0xe0, 0xf6, 0x3a, 0x56, 0xcc, 0x55, 0xa8, 0x27, 0x5a, 0xa7, 0xb0, 0x1a, 0xea, 0x1e, 0xd, 0xdc, 0x68, 0x51}}
+	info := bindataFileInfo{name: "deliver_certs.txt", size: 2032, mode: os.FileMode(0644), modTime: time.Unix(1721689071, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x35, 0x9a, 0x0, 0x47, 0xb6, 0xa6, 0xa7, 0xf9, 0x4c, 0x38, 0x43, 0xdc, 0x42, 0x50, 0x1e, 0x2d, 0xf6, 0xdb, 0x5c, 0x84, 0xc1, 0xfb, 0x3f, 0x8c, 0xbf, 0x8b, 0x31, 0xf5, 0x83, 0xe2, 0x4f, 0x19}}
 	return a, nil
 }
 
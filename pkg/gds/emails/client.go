@@ -1,6 +1,7 @@
 package emails
 
 import (
+	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -13,11 +14,11 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/sendgrid/rest"
-	"github.com/sendgrid/sendgrid-go"
 	sgmail "github.com/sendgrid/sendgrid-go/helpers/mail"
 	"github.com/trisacrypto/directory/pkg/gds/admin/v2"
 	"github.com/trisacrypto/directory/pkg/gds/config"
 	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/utils"
 	"github.com/trisacrypto/directory/pkg/utils/emails"
 	"github.com/trisacrypto/directory/pkg/utils/emails/mock"
 	"github.com/trisacrypto/directory/pkg/utils/sentry"
@@ -35,10 +36,14 @@ func New(conf config.EmailConfig) (m *EmailManager, err error) {
 			Storage: conf.Storage,
 		}
 	} else {
-		if conf.SendGridAPIKey == "" {
-			return nil, errors.New("cannot create sendgrid client without API key")
+		if m.client, err = emails.Open(conf.Transport, conf.SendGridAPIKey); err != nil {
+			return nil, err
 		}
-		m.client = sendgrid.NewSendClient(conf.SendGridAPIKey)
+	}
+
+	// Load the template overrides and localized templates
+	if err = LoadTemplates(conf.Templates); err != nil {
+		return nil, err
 	}
 
 	// Warn if email configuration isn't complete and will produce partial emails.
@@ -61,12 +66,15 @@ func New(conf config.EmailConfig) (m *EmailManager, err error) {
 	return m, nil
 }
 
-// EmailManager allows the server to send rich emails using the SendGrid service.
+// EmailManager allows the server to send rich emails using SendGrid or the configured
+// email transport.
 type EmailManager struct {
 	conf         config.EmailConfig
 	client       emails.EmailClient
 	serviceEmail *mail.Address
 	adminsEmail  *mail.Address
+	outbox       *Outbox
+	secrets      Resolver
 }
 
 func (m *EmailManager) Send(message *sgmail.SGMailV3) (err error) {
@@ -82,6 +90,39 @@ func (m *EmailManager) Send(message *sgmail.SGMailV3) (err error) {
 	return nil
 }
 
+// Send the draft through the outbox if it is enabled, returning the ID of the outbox
+// email so that it can be referenced by the email log. If the outbox is not enabled,
+// the draft is rendered and sent directly and no message ID is returned.
+func (m *EmailManager) send(draft *Draft, reason, vaspID string) (_ string, err error) {
+	ctx, cancel := utils.WithDeadline(context.Background())
+	defer cancel()
+
+	if m.outbox != nil {
+		return m.outbox.Send(ctx, draft, reason, vaspID)
+	}
+
+	var msg *sgmail.SGMailV3
+	if msg, err = m.Render(ctx, draft); err != nil {
+		return "", err
+	}
+	return "", m.Send(msg)
+}
+
+// Returns the locale fallback chain for a recipient with the preferred locale, which
+// falls back to the default locale if the recipient has no preferred locale.
+func (m *EmailManager) locales(preferred string) []string {
+	return []string{preferred, m.conf.DefaultLocale}
+}
+
+// Returns the locale fallback chain for the VASP contact.
+func (m *EmailManager) contactLocales(contact *pb.Contact) []string {
+	locale, err := models.GetContactLocale(contact)
+	if err != nil {
+		sentry.Warn(nil).Err(err).Str("contact", contact.Email).Msg("could not retrieve contact locale")
+	}
+	return m.locales(locale)
+}
+
 // SendVerifyContacts creates a verification token for each contact in the VASP contact
 // list and sends them the verification email with instructions on how to verify their
 // email address. Caller must update the VASP record on the data store after calling
@@ -101,6 +142,11 @@ func (m *EmailManager) SendVerifyContacts(vasp *pb.VASP, contacts map[string]*mo
 		}
 
 		if !card.Verified {
+			// Use the preferred locale on the VASP contact if the contact has none
+			if card.Locale == "" {
+				card.Locale, _ = models.GetContactLocale(contact)
+			}
+
 			if err := m.SendVerifyContact(vasp, card); err != nil {
 				nErrors++
 				sentry.Error(nil).Err(err).Str("vasp", vasp.Id).Str("contact", kind).Msg("failed to send verify contact email")
@@ -137,10 +183,10 @@ func (m *EmailManager) SendVerifyContact(vasp *pb.VASP, contact *models.Contact)
 		sentry.Error(nil).Err(err).Str("vasp", vasp.Id).Str("contact", contact.Email).Msg("verification email being sent to a verified contact")
 	}
 
-	msg, err := VerifyContactEmail(
-		m.serviceEmail.Name, m.serviceEmail.Address,
+	msg, err := NewDraft(
+		"verify_contact",
 		contact.Name, contact.Email,
-		ctx,
+		ctx, m.locales(contact.Locale)...,
 	)
 
 	if err != nil {
@@ -148,26 +194,50 @@ func (m *EmailManager) SendVerifyContact(vasp *pb.VASP, contact *models.Contact)
 		return err
 	}
 
-	if err = m.Send(msg); err != nil {
+	var messageID string
+	if messageID, err = m.send(msg, string(admin.ResendVerifyContact), vasp.Id); err != nil {
 		sentry.Error(nil).Err(err).Msg("could not send verify contact email")
 		return err
 	}
 
-	contact.AppendEmailLog(string(admin.ResendVerifyContact), msg.Subject)
+	contact.AppendEmailLogMessage(string(admin.ResendVerifyContact), msg.Subject, messageID)
 	return nil
 }
 
 // SendReviewRequest is a shortcut for iComply verification in which we simply send
 // an email to the TRISA admins and have them manually verify registrations.
 func (m *EmailManager) SendReviewRequest(vasp *pb.VASP) (sent int, err error) {
-	// Create the template context with the admin verification token
-	ctx := ReviewRequestData{
+	var ctx ReviewRequestData
+	if ctx, err = m.reviewRequestData(vasp); err != nil {
+		return 0, err
+	}
+
+	msg, err := NewDraft(
+		"review_request",
+		m.adminsEmail.Name, m.adminsEmail.Address,
+		ctx, m.locales("")...,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = m.send(msg, string(admin.ResendReview), vasp.Id); err != nil {
+		return 0, err
+	}
+
+	return 1, nil
+}
+
+// Creates the review request template context with the admin verification token and
+// the redacted registration of the VASP.
+func (m *EmailManager) reviewRequestData(vasp *pb.VASP) (ctx ReviewRequestData, err error) {
+	ctx = ReviewRequestData{
 		VID:                 vasp.Id,
 		RegisteredDirectory: m.conf.DirectoryID,
 		BaseURL:             m.conf.AdminReviewBaseURL,
 	}
 	if ctx.Token, err = models.GetAdminVerificationToken(vasp); err != nil {
-		return 0, err
+		return ctx, err
 	}
 
 	// Remove sensitive data so it's not sent in the form.
@@ -192,7 +262,7 @@ func (m *EmailManager) SendReviewRequest(vasp *pb.VASP) (sent int, err error) {
 
 	var data []byte
 	if data, err = jsonpb.Marshal(clone); err != nil {
-		return 0, err
+		return ctx, err
 	}
 
 	// Convert JSON to YAML to make it more human readable
@@ -204,21 +274,7 @@ func (m *EmailManager) SendReviewRequest(vasp *pb.VASP) (sent int, err error) {
 
 	// Attach the JSON data as an attachment
 	ctx.Attachment = data
-
-	msg, err := ReviewRequestEmail(
-		m.serviceEmail.Name, m.serviceEmail.Address,
-		m.adminsEmail.Name, m.adminsEmail.Address,
-		ctx,
-	)
-	if err != nil {
-		return 0, err
-	}
-
-	if err = m.Send(msg); err != nil {
-		return 0, err
-	}
-
-	return 1, nil
+	return ctx, nil
 }
 
 // SendRejectRegistration sends a notification to all VASP contacts that their
@@ -246,24 +302,25 @@ func (m *EmailManager) SendRejectRegistration(vasp *pb.VASP, reason string) (sen
 		var kind string
 		contact, kind = iter.Value()
 		ctx.Name = contact.Name
-		msg, err := RejectRegistrationEmail(
-			m.serviceEmail.Name, m.serviceEmail.Address,
+		msg, err := NewDraft(
+			"reject_registration",
 			contact.Name, contact.Email,
-			ctx,
+			ctx, m.contactLocales(contact)...,
 		)
 		if err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("could not create reject registration email for %s contact: %s", kind, err))
 			continue
 		}
 
-		if err = m.Send(msg); err != nil {
+		var messageID string
+		if messageID, err = m.send(msg, string(admin.ResendRejection), vasp.Id); err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("could not send reject registration email for %s contact: %s", kind, err))
 			continue
 		}
 
 		sent++
 
-		if err = models.AppendEmailLog(contact, string(admin.ResendRejection), msg.Subject); err != nil {
+		if err = models.AppendEmailLogMessage(contact, string(admin.ResendRejection), msg.Subject, messageID); err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("could not log reject registration email for %s contact: %s", kind, err))
 			continue
 		}
@@ -283,9 +340,12 @@ func (m *EmailManager) SendRejectRegistration(vasp *pb.VASP, reason string) (sen
 // SendDeliverCertificates sends the PKCS12 encrypted certificate files to the VASP
 // contacts as an attachment, completing the certificate issuance process. This method
 // only sends the certificate attachment to one email (to limit the delivery of a secure
-// email), ranking the contact emails by priority. Caller must update the VASP record on
-// the data store after calling this function.
-func (m *EmailManager) SendDeliverCertificates(vasp *pb.VASP, path string) (sent int, err error) {
+// email), ranking the contact emails by priority. The certificates are looked up from
+// the certificate request with the resolver when the email is rendered and attached
+// with the filename. If publicOnly is true, the attached certificates were issued from
+// a CSR submitted by the VASP and only contain the public certificate chain. Caller
+// must update the VASP record on the data store after calling this function.
+func (m *EmailManager) SendDeliverCertificates(vasp *pb.VASP, certreqID, filename string, publicOnly bool) (sent int, err error) {
 	var errs *multierror.Error
 	ctx := DeliverCertsData{
 		VID:                 vasp.Id,
@@ -293,6 +353,9 @@ func (m *EmailManager) SendDeliverCertificates(vasp *pb.VASP, path string) (sent
 		SerialNumber:        hex.EncodeToString(vasp.IdentityCertificate.SerialNumber),
 		Endpoint:            vasp.TrisaEndpoint,
 		RegisteredDirectory: m.conf.DirectoryID,
+		PublicOnly:          publicOnly,
+		CertRequest:         certreqID,
+		Filename:            filename,
 	}
 
 	ctx.Organization, _ = vasp.Name()
@@ -309,10 +372,10 @@ func (m *EmailManager) SendDeliverCertificates(vasp *pb.VASP, path string) (sent
 		var kind string
 		contact, kind = iter.Value()
 		ctx.Name = contact.Name
-		msg, err := DeliverCertsEmail(
-			m.serviceEmail.Name, m.serviceEmail.Address,
+		msg, err := NewDraft(
+			"deliver_certs",
 			contact.Name, contact.Email,
-			path, ctx,
+			ctx, m.contactLocales(contact)...,
 		)
 
 		if err != nil {
@@ -320,14 +383,15 @@ func (m *EmailManager) SendDeliverCertificates(vasp *pb.VASP, path string) (sent
 			continue
 		}
 
-		if err = m.Send(msg); err != nil {
+		var messageID string
+		if messageID, err = m.send(msg, string(admin.ResendDeliverCerts), vasp.Id); err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("could not send deliver certificates email for %s contact: %s", kind, err))
 			continue
 		}
 
 		sent++
 
-		if err = models.AppendEmailLog(contact, string(admin.ResendDeliverCerts), msg.Subject); err != nil {
+		if err = models.AppendEmailLogMessage(contact, string(admin.ResendDeliverCerts), msg.Subject, messageID); err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("could not log deliver certificates email for %s contact: %s", kind, err))
 			continue
 		}
@@ -385,21 +449,22 @@ func (m *EmailManager) SendExpiresAdminNotification(vasp *pb.VASP, timeWindow in
 		ctx.Expiration, _ = time.Parse(time.RFC3339, vasp.IdentityCertificate.NotAfter)
 	}
 
-	msg, err := ExpiresAdminNotificationEmail(
-		m.serviceEmail.Name, m.serviceEmail.Address,
+	msg, err := NewDraft(
+		"expires_admin_notification",
 		m.adminsEmail.Name, m.adminsEmail.Address,
-		ctx,
+		ctx, m.locales("")...,
 	)
 	if err != nil {
 		return 0, err
 	}
 
-	if err = m.Send(msg); err != nil {
+	var messageID string
+	if messageID, err = m.send(msg, string(admin.ReissuanceReminder), vasp.Id); err != nil {
 		return 0, err
 	}
 	sent++
 
-	if err = models.AppendAdminEmailLog(vasp, string(admin.ReissuanceReminder), msg.Subject); err != nil {
+	if err = models.AppendAdminEmailLogMessage(vasp, string(admin.ReissuanceReminder), msg.Subject, messageID); err != nil {
 		return 0, err
 	}
 
@@ -458,21 +523,22 @@ func (m *EmailManager) SendContactReissuanceReminder(vasp *pb.VASP, timeWindow i
 
 		// Create the reissuance reminder email.
 		ctx.Name = contact.Name
-		msg, err := ReissuanceReminderEmail(
-			m.serviceEmail.Name, m.serviceEmail.Address,
+		msg, err := NewDraft(
+			"reissuance_reminder",
 			contact.Name, contact.Email,
-			ctx,
+			ctx, m.contactLocales(contact)...,
 		)
 		if err != nil {
 			sentry.Error(nil).Err(err).Str("vasp_id", vasp.Id).Str("contact", contact.Name).Msg("could not create reissuance reminder email")
 			continue
 		}
 
-		if err = m.Send(msg); err != nil {
+		var messageID string
+		if messageID, err = m.send(msg, reissuanceReminder, vasp.Id); err != nil {
 			sentry.Error(nil).Err(err).Str("vasp_id", vasp.Id).Str("contact", contact.Name).Msg("error sending reissuance reminder email")
 			continue
 		}
-		if err = models.AppendEmailLog(contact, reissuanceReminder, msg.Subject); err != nil {
+		if err = models.AppendEmailLogMessage(contact, reissuanceReminder, msg.Subject, messageID); err != nil {
 			sentry.Error(nil).Err(err).Str("vasp_id", vasp.Id).Str("contact", contact.Name).Msg("error appending to email log")
 		}
 	}
@@ -543,10 +609,10 @@ func (m *EmailManager) SendReissuanceReminder(vasp *pb.VASP, reissueDate time.Ti
 		contact, kind := iter.Value()
 		ctx.Name = contact.Name
 
-		msg, err := ReissuanceReminderEmail(
-			m.serviceEmail.Name, m.serviceEmail.Address,
+		msg, err := NewDraft(
+			"reissuance_reminder",
 			contact.Name, contact.Email,
-			ctx,
+			ctx, m.contactLocales(contact)...,
 		)
 
 		if err != nil {
@@ -554,14 +620,15 @@ func (m *EmailManager) SendReissuanceReminder(vasp *pb.VASP, reissueDate time.Ti
 			continue
 		}
 
-		if err = m.Send(msg); err != nil {
+		var messageID string
+		if messageID, err = m.send(msg, string(admin.ReissuanceReminder), vasp.Id); err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("could not send reissuance reminder email for %s contact: %s", kind, err))
 			continue
 		}
 
 		sent++
 
-		if err = models.AppendEmailLog(contact, string(admin.ReissuanceReminder), msg.Subject); err != nil {
+		if err = models.AppendEmailLogMessage(contact, string(admin.ReissuanceReminder), msg.Subject, messageID); err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("could not log reissuance reminder email for %s contact: %s", kind, err))
 			continue
 		}
@@ -578,17 +645,18 @@ func (m *EmailManager) SendReissuanceReminder(vasp *pb.VASP, reissueDate time.Ti
 	return sent, errs.ErrorOrNil()
 }
 
-// SendReissuanceStarted sends the PKCS12 password via a secure one time link. This
-// method only sends the PKCS12 password to one email (to limit the delivery of secure
-// emails), ranking the contact emails by priority.
-func (m *EmailManager) SendReissuanceStarted(vasp *pb.VASP, whisperLink string) (sent int, err error) {
+// SendReissuanceStarted sends the PKCS12 password of the certificate request via a
+// secure one time link that is created with the resolver when the email is rendered.
+// This method only sends the PKCS12 password to one email (to limit the delivery of
+// secure emails), ranking the contact emails by priority.
+func (m *EmailManager) SendReissuanceStarted(vasp *pb.VASP, certreqID string) (sent int, err error) {
 	var errs *multierror.Error
 	ctx := ReissuanceStartedData{
 		VID:                 vasp.Id,
 		CommonName:          vasp.CommonName,
 		Endpoint:            vasp.TrisaEndpoint,
 		RegisteredDirectory: m.conf.DirectoryID,
-		WhisperURL:          whisperLink,
+		CertRequest:         certreqID,
 	}
 
 	ctx.Organization, _ = vasp.Name()
@@ -604,10 +672,10 @@ func (m *EmailManager) SendReissuanceStarted(vasp *pb.VASP, whisperLink string)
 		contact, kind := iter.Value()
 		ctx.Name = contact.Name
 
-		msg, err := ReissuanceStartedEmail(
-			m.serviceEmail.Name, m.serviceEmail.Address,
+		msg, err := NewDraft(
+			"reissuance_started",
 			contact.Name, contact.Email,
-			ctx,
+			ctx, m.contactLocales(contact)...,
 		)
 
 		if err != nil {
@@ -615,14 +683,15 @@ func (m *EmailManager) SendReissuanceStarted(vasp *pb.VASP, whisperLink string)
 			continue
 		}
 
-		if err = m.Send(msg); err != nil {
+		var messageID string
+		if messageID, err = m.send(msg, string(admin.ReissuanceStarted), vasp.Id); err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("could not send reissuance started email for %s contact: %s", kind, err))
 			continue
 		}
 
 		sent++
 
-		if err = models.AppendEmailLog(contact, string(admin.ReissuanceStarted), msg.Subject); err != nil {
+		if err = models.AppendEmailLogMessage(contact, string(admin.ReissuanceStarted), msg.Subject, messageID); err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("could not log reissuance started email for %s contact: %s", kind, err))
 			continue
 		}
@@ -685,21 +754,22 @@ func (m *EmailManager) SendReissuanceAdminNotification(vasp *pb.VASP, timeWindow
 	}
 
 	// Create reissuance admin notifications email.
-	msg, err := ReissuanceAdminNotificationEmail(
-		m.serviceEmail.Name, m.serviceEmail.Address,
+	msg, err := NewDraft(
+		"reissuance_admin_notification",
 		m.adminsEmail.Name, m.adminsEmail.Address,
-		ctx,
+		ctx, m.locales("")...,
 	)
 	if err != nil {
 		return 0, err
 	}
 
-	if err = m.Send(msg); err != nil {
+	var messageID string
+	if messageID, err = m.send(msg, string(admin.ReissuanceStarted), vasp.Id); err != nil {
 		return 0, err
 	}
 	sent++
 
-	if err = models.AppendAdminEmailLog(vasp, string(admin.ReissuanceStarted), msg.Subject); err != nil {
+	if err = models.AppendAdminEmailLogMessage(vasp, string(admin.ReissuanceStarted), msg.Subject, messageID); err != nil {
 		return sent, err
 	}
 
@@ -1,6 +1,8 @@
 package emails_test
 
 import (
+	"context"
+	"errors"
 	"net/mail"
 	"os"
 	"testing"
@@ -51,6 +53,7 @@ func TestClientSend(t *testing.T) {
 	// This test sends emails from the serviceEmail using SendGrid to the adminsEmail
 	email, err := emails.New(conf)
 	require.NoError(t, err)
+	email.SetResolver(&stubResolver{payload: []byte("notrealcertificates"), link: "https://whisper.dev/supersecret"})
 
 	recipient, err := mail.ParseAddress(conf.AdminEmail)
 	require.NoError(t, err)
@@ -158,7 +161,7 @@ func TestClientSend(t *testing.T) {
 	t.Run("DeliverCertificates", func(t *testing.T) {
 		defer resetLogs(t)
 
-		sent, err := email.SendDeliverCertificates(vasp, "testdata/foo.zip")
+		sent, err := email.SendDeliverCertificates(vasp, "b0a7a2d4-1b3c-4d5e-8f60-718293a4b5c6", "test.example.com.zip", false)
 		require.NoError(t, err)
 		require.Equal(t, 1, sent)
 
@@ -199,7 +202,7 @@ func TestClientSend(t *testing.T) {
 	t.Run("ReissuanceStarted", func(t *testing.T) {
 		defer resetLogs(t)
 
-		sent, err := email.SendReissuanceStarted(vasp, "https://whisper.dev/supersecret")
+		sent, err := email.SendReissuanceStarted(vasp, "b0a7a2d4-1b3c-4d5e-8f60-718293a4b5c6")
 		require.NoError(t, err)
 		require.Equal(t, 1, sent)
 
@@ -311,3 +314,28 @@ func makeClientFixtures(t *testing.T, recipient *mail.Address) (vasp *pb.VASP, c
 
 	return vasp, contacts
 }
+
+// stubResolver returns fixed secrets and records the certificate requests looked up.
+type stubResolver struct {
+	vasp    *pb.VASP
+	payload []byte
+	link    string
+	lookups []string
+}
+
+func (r *stubResolver) RetrieveVASP(_ context.Context, id string) (*pb.VASP, error) {
+	if r.vasp == nil || r.vasp.Id != id {
+		return nil, errors.New("vasp not found")
+	}
+	return r.vasp, nil
+}
+
+func (r *stubResolver) CertificatePayload(_ context.Context, certreqID string) ([]byte, error) {
+	r.lookups = append(r.lookups, certreqID)
+	return r.payload, nil
+}
+
+func (r *stubResolver) PasswordLink(_ context.Context, certreqID string) (string, error) {
+	r.lookups = append(r.lookups, certreqID)
+	return r.link, nil
+}
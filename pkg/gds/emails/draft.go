@@ -0,0 +1,179 @@
+package emails
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	sgmail "github.com/sendgrid/sendgrid-go/helpers/mail"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+// Default subjects of the email templates that can be rendered from a draft.
+var draftSubjects = map[string]string{
+	"verify_contact":                VerifyContactRE,
+	"review_request":                ReviewRequestRE,
+	"reject_registration":           RejectRegistrationRE,
+	"deliver_certs":                 DeliverCertsRE,
+	"expires_admin_notification":    ExpiresAdminNotificationRE,
+	"reissuance_reminder":           ReissuanceReminderRE,
+	"reissuance_started":            ReissuanceStartedRE,
+	"reissuance_admin_notification": ReissuanceAdminNotificationRE,
+}
+
+// Draft is an email that has not been rendered yet. Drafts are stored in the outbox
+// instead of the rendered message so that secrets and attachments, e.g. the issued
+// certificates or the whisper link to the PKCS12 password, are never stored; they are
+// looked up by the Resolver from the references in the template data when the draft is
+// rendered at send time. Contact verification tokens are stored with the draft since
+// they are already stored on the contact records.
+type Draft struct {
+	Template string          `json:"template"`
+	Subject  string          `json:"subject"`
+	Name     string          `json:"name"`
+	Email    string          `json:"email"`
+	Locales  []string        `json:"locales,omitempty"`
+	Data     json.RawMessage `json:"data"`
+
+	// The template data including secrets when the draft is rendered immediately
+	data interface{}
+}
+
+// NewDraft creates a draft to send the email template to the recipient with the
+// template data, which must be the data type of the template.
+func NewDraft(template, name, email string, data interface{}, locales ...string) (draft *Draft, err error) {
+	defaultSubject, ok := draftSubjects[template]
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q", template)
+	}
+
+	draft = &Draft{
+		Template: template,
+		Subject:  Subject(template, defaultSubject, data, locales...),
+		Name:     name,
+		Email:    email,
+		Locales:  locales,
+		data:     data,
+	}
+
+	if draft.Data, err = json.Marshal(data); err != nil {
+		return nil, fmt.Errorf("could not marshal %s email data: %w", template, err)
+	}
+	return draft, nil
+}
+
+// ParseDraft parses a draft that was stored in the outbox.
+func ParseDraft(data []byte) (draft *Draft, err error) {
+	draft = &Draft{}
+	if err = json.Unmarshal(data, draft); err != nil {
+		return nil, fmt.Errorf("could not parse email draft: %w", err)
+	}
+	return draft, nil
+}
+
+// Marshal the draft for storage in the outbox without the secrets of the template data.
+func (d *Draft) Marshal() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+// Render the draft into a message that can be sent by the email client, looking up any
+// secrets and attachments that are not in the template data with the resolver.
+func (m *EmailManager) Render(ctx context.Context, d *Draft) (msg *sgmail.SGMailV3, err error) {
+	sender, senderEmail := m.serviceEmail.Name, m.serviceEmail.Address
+	switch d.Template {
+	case "verify_contact":
+		var data VerifyContactData
+		if data, err = decode[VerifyContactData](d); err != nil {
+			return nil, err
+		}
+		return VerifyContactEmail(sender, senderEmail, d.Name, d.Email, data, d.Locales...)
+
+	case "review_request":
+		var data ReviewRequestData
+		if data, err = decode[ReviewRequestData](d); err != nil {
+			return nil, err
+		}
+
+		if data.Token == "" {
+			var vasp *pb.VASP
+			if vasp, err = m.resolver().RetrieveVASP(ctx, data.VID); err != nil {
+				return nil, fmt.Errorf("could not retrieve vasp for review request: %w", err)
+			}
+
+			if data, err = m.reviewRequestData(vasp); err != nil {
+				return nil, err
+			}
+		}
+		return ReviewRequestEmail(sender, senderEmail, d.Name, d.Email, data, d.Locales...)
+
+	case "reject_registration":
+		var data RejectRegistrationData
+		if data, err = decode[RejectRegistrationData](d); err != nil {
+			return nil, err
+		}
+		return RejectRegistrationEmail(sender, senderEmail, d.Name, d.Email, data, d.Locales...)
+
+	case "deliver_certs":
+		var data DeliverCertsData
+		if data, err = decode[DeliverCertsData](d); err != nil {
+			return nil, err
+		}
+
+		if len(data.Attachment) == 0 {
+			if data.Attachment, err = m.resolver().CertificatePayload(ctx, data.CertRequest); err != nil {
+				return nil, fmt.Errorf("could not retrieve certificates to deliver: %w", err)
+			}
+		}
+		return DeliverCertsEmail(sender, senderEmail, d.Name, d.Email, data, d.Locales...)
+
+	case "expires_admin_notification":
+		var data ExpiresAdminNotificationData
+		if data, err = decode[ExpiresAdminNotificationData](d); err != nil {
+			return nil, err
+		}
+		return ExpiresAdminNotificationEmail(sender, senderEmail, d.Name, d.Email, data, d.Locales...)
+
+	case "reissuance_reminder":
+		var data ReissuanceReminderData
+		if data, err = decode[ReissuanceReminderData](d); err != nil {
+			return nil, err
+		}
+		return ReissuanceReminderEmail(sender, senderEmail, d.Name, d.Email, data, d.Locales...)
+
+	case "reissuance_started":
+		var data ReissuanceStartedData
+		if data, err = decode[ReissuanceStartedData](d); err != nil {
+			return nil, err
+		}
+
+		if data.WhisperURL == "" {
+			if data.WhisperURL, err = m.resolver().PasswordLink(ctx, data.CertRequest); err != nil {
+				return nil, fmt.Errorf("could not create pkcs12 password link: %w", err)
+			}
+		}
+		return ReissuanceStartedEmail(sender, senderEmail, d.Name, d.Email, data, d.Locales...)
+
+	case "reissuance_admin_notification":
+		var data ReissuanceAdminNotificationData
+		if data, err = decode[ReissuanceAdminNotificationData](d); err != nil {
+			return nil, err
+		}
+		return ReissuanceAdminNotificationEmail(sender, senderEmail, d.Name, d.Email, data, d.Locales...)
+
+	default:
+		return nil, fmt.Errorf("unknown email template %q", d.Template)
+	}
+}
+
+// Decodes the template data of the draft, using the data the draft was created with if
+// the draft was not stored so that the secrets of the template data are kept.
+func decode[T any](d *Draft) (data T, err error) {
+	if v, ok := d.data.(T); ok {
+		return v, nil
+	}
+
+	if err = json.Unmarshal(d.Data, &data); err != nil {
+		return data, fmt.Errorf("could not parse %s email data: %w", d.Template, err)
+	}
+	return data, nil
+}
@@ -2,27 +2,66 @@ package emails
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"html/template"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	texttemplate "text/template"
 	"time"
 
 	"github.com/sendgrid/sendgrid-go/helpers/mail"
+	"github.com/trisacrypto/directory/pkg/utils/emails"
 	"github.com/trisacrypto/directory/pkg/utils/sentry"
 )
 
-// Compile all email templates into a top-level global variable.
-var templates map[string]*template.Template
+// Compile all email templates into top-level global variables. Subject templates are
+// only available as overrides and are rendered as text rather than html.
+var (
+	templates map[string]*template.Template
+	subjects  map[string]*texttemplate.Template
+)
 
 func init() {
-	templates = make(map[string]*template.Template)
+	if err := LoadTemplates(""); err != nil {
+		panic(err)
+	}
+}
+
+// LoadTemplates compiles the email templates, replacing the compiled-in templates with
+// the template overrides in the specified directory and adding any localized templates
+// in its locale subdirectories (see emails.ReadTemplates). If dir is empty only the
+// compiled-in templates are loaded. This should be called once at startup.
+func LoadTemplates(dir string) (err error) {
+	var overrides map[string]string
+	if overrides, err = emails.ReadTemplates(dir); err != nil {
+		return err
+	}
+
+	compiled := make(map[string]*template.Template)
 	for _, name := range AssetNames() {
 		data := MustAssetString(name)
-		templates[name] = template.Must(template.New(name).Parse(data))
+		compiled[name] = template.Must(template.New(name).Parse(data))
 	}
+
+	compiledSubjects := make(map[string]*texttemplate.Template)
+	for name, data := range overrides {
+		if filepath.Ext(name) == emails.SubjectExt {
+			if compiledSubjects[name], err = texttemplate.New(name).Parse(strings.TrimSpace(data)); err != nil {
+				return fmt.Errorf("could not parse email template %s: %w", name, err)
+			}
+			continue
+		}
+
+		if compiled[name], err = template.New(name).Parse(data); err != nil {
+			return fmt.Errorf("could not parse email template %s: %w", name, err)
+		}
+	}
+
+	templates, subjects = compiled, compiledSubjects
+	return nil
 }
 
 //===========================================================================
@@ -75,13 +114,15 @@ func (d VerifyContactData) VerifyContactURLUnencoded() template.HTML {
 	return template.HTML(url.String())
 }
 
-// ReviewRequestData to complete review request email templates.
+// ReviewRequestData to complete review request email templates. The token and the
+// registration are not stored with outbox drafts and are looked up from the VASP record
+// when the email is rendered.
 type ReviewRequestData struct {
 	VID                 string // The ID of the VASP/Registration
-	Token               string // The unique token needed to review the registration
-	Request             string // The review request data as a nicely formatted JSON or YAML string
+	Token               string `json:"-"` // The unique token needed to review the registration
+	Request             string `json:"-"` // The review request data as a nicely formatted JSON or YAML string
 	RegisteredDirectory string // The directory name for the review request
-	Attachment          []byte // Data to attach to the email
+	Attachment          []byte `json:"-"` // Data to attach to the email
 	BaseURL             string // The URL of the admin review endpoint to build the AdminReviewURL
 }
 
@@ -115,7 +156,9 @@ type RejectRegistrationData struct {
 	Reason              string // A description of why the registration request was rejected
 }
 
-// DeliverCertsData to complete deliver certs email templates.
+// DeliverCertsData to complete deliver certs email templates. The certificates are not
+// stored with outbox drafts and are looked up from the certificate request when the
+// email is rendered.
 type DeliverCertsData struct {
 	Name                string // Used to address the email
 	VID                 string // The ID of the VASP/Registration
@@ -124,6 +167,10 @@ type DeliverCertsData struct {
 	SerialNumber        string // The serial number of the certificate
 	Endpoint            string // The expected endpoint for the TRISA service
 	RegisteredDirectory string // The directory name for the certificates being issued
+	PublicOnly          bool   // The certificates were issued from a CSR and do not include a private key
+	CertRequest         string // The ID of the certificate request the certificates were issued for
+	Filename            string // The filename of the attached certificates
+	Attachment          []byte `json:"-"` // The zipped certificates to attach to the email
 }
 
 // ExpiresAdminNotificationData to complete expires admin notification email templates.
@@ -204,7 +251,8 @@ func (d ReissuanceReminderData) ReissueDate() string {
 	return d.Reissuance.Format(DateFormat)
 }
 
-// ReissuanceStartedData to complete reissue reminder email templates.
+// ReissuanceStartedData to complete reissue reminder email templates. The whisper link
+// is not stored with outbox drafts and is created when the email is rendered.
 type ReissuanceStartedData struct {
 	Name                string // Used to address the email
 	VID                 string // The ID of the VASP/Registration
@@ -212,7 +260,8 @@ type ReissuanceStartedData struct {
 	CommonName          string // The common name assigned to the cert
 	Endpoint            string // The expected endpoint for the TRISA service
 	RegisteredDirectory string // The directory name for the certificates being issued
-	WhisperURL          string // Secure one-time whisper link for password retrieval
+	CertRequest         string // The ID of the certificate request the password was created for
+	WhisperURL          string `json:"-"` // Secure one-time whisper link for password retrieval
 }
 
 // ReissuanceAdminNotificationData to complete reissuance admin notification email templates.
@@ -270,15 +319,15 @@ func (d ReissuanceAdminNotificationData) ReissueDate() string {
 
 // VerifyContactEmail creates a new verify contact email, ready for sending by rendering
 // the text and html templates with the supplied data then constructing a sendgrid email.
-func VerifyContactEmail(sender, senderEmail, recipient, recipientEmail string, data VerifyContactData) (message *mail.SGMailV3, err error) {
+func VerifyContactEmail(sender, senderEmail, recipient, recipientEmail string, data VerifyContactData, locales ...string) (message *mail.SGMailV3, err error) {
 	var text, html string
-	if text, html, err = Render("verify_contact", data); err != nil {
+	if text, html, err = Render("verify_contact", data, locales...); err != nil {
 		return nil, err
 	}
 
 	return mail.NewSingleEmail(
 		mail.NewEmail(sender, senderEmail),
-		VerifyContactRE,
+		Subject("verify_contact", VerifyContactRE, data, locales...),
 		mail.NewEmail(recipient, recipientEmail),
 		text,
 		html,
@@ -287,15 +336,15 @@ func VerifyContactEmail(sender, senderEmail, recipient, recipientEmail string, d
 
 // ReviewRequestEmail creates a new review request email, ready for sending by rendering
 // the text and html templates with the supplied data then constructing a sendgrid email.
-func ReviewRequestEmail(sender, senderEmail, recipient, recipientEmail string, data ReviewRequestData) (message *mail.SGMailV3, err error) {
+func ReviewRequestEmail(sender, senderEmail, recipient, recipientEmail string, data ReviewRequestData, locales ...string) (message *mail.SGMailV3, err error) {
 	var text, html string
-	if text, html, err = Render("review_request", data); err != nil {
+	if text, html, err = Render("review_request", data, locales...); err != nil {
 		return nil, err
 	}
 
 	message = mail.NewSingleEmail(
 		mail.NewEmail(sender, senderEmail),
-		ReviewRequestRE,
+		Subject("review_request", ReviewRequestRE, data, locales...),
 		mail.NewEmail(recipient, recipientEmail),
 		text,
 		html,
@@ -311,15 +360,15 @@ func ReviewRequestEmail(sender, senderEmail, recipient, recipientEmail string, d
 // RejectRegistrationEmail creates a new reject registration email, ready for sending by
 // rendering the text and html templates with the supplied data then constructing a
 // sendgrid email.
-func RejectRegistrationEmail(sender, senderEmail, recipient, recipientEmail string, data RejectRegistrationData) (message *mail.SGMailV3, err error) {
+func RejectRegistrationEmail(sender, senderEmail, recipient, recipientEmail string, data RejectRegistrationData, locales ...string) (message *mail.SGMailV3, err error) {
 	var text, html string
-	if text, html, err = Render("reject_registration", data); err != nil {
+	if text, html, err = Render("reject_registration", data, locales...); err != nil {
 		return nil, err
 	}
 
 	return mail.NewSingleEmail(
 		mail.NewEmail(sender, senderEmail),
-		RejectRegistrationRE,
+		Subject("reject_registration", RejectRegistrationRE, data, locales...),
 		mail.NewEmail(recipient, recipientEmail),
 		text,
 		html,
@@ -327,41 +376,41 @@ func RejectRegistrationEmail(sender, senderEmail, recipient, recipientEmail stri
 }
 
 // DeliverCertsEmail creates a new deliver certs email, ready for sending by rendering
-// the text and html templates with the supplied data, loading the attachment from disk
-// then constructing a sendgrid email.
-func DeliverCertsEmail(sender, senderEmail, recipient, recipientEmail, attachmentPath string, data DeliverCertsData) (message *mail.SGMailV3, err error) {
+// the text and html templates with the supplied data, attaching the certificates then
+// constructing a sendgrid email.
+func DeliverCertsEmail(sender, senderEmail, recipient, recipientEmail string, data DeliverCertsData, locales ...string) (message *mail.SGMailV3, err error) {
 	var text, html string
-	if text, html, err = Render("deliver_certs", data); err != nil {
+	if text, html, err = Render("deliver_certs", data, locales...); err != nil {
 		return nil, err
 	}
 
 	message = mail.NewSingleEmail(
 		mail.NewEmail(sender, senderEmail),
-		DeliverCertsRE,
+		Subject("deliver_certs", DeliverCertsRE, data, locales...),
 		mail.NewEmail(recipient, recipientEmail),
 		text,
 		html,
 	)
 
-	// Add attachment from a file on disk.
-	if err = LoadAttachment(message, attachmentPath); err != nil {
-		return nil, err
+	if len(data.Attachment) == 0 {
+		return nil, errors.New("no certificates to attach to the email")
 	}
+	AttachZip(message, data.Attachment, data.Filename)
 
 	return message, nil
 }
 
 // ExpiresAdminNotificationEmail creates a new certs expired admin notification email,
 // ready for sending by rendering the text and html templates with the supplied data.
-func ExpiresAdminNotificationEmail(sender, senderEmail, recipient, recipientEmail string, data ExpiresAdminNotificationData) (message *mail.SGMailV3, err error) {
+func ExpiresAdminNotificationEmail(sender, senderEmail, recipient, recipientEmail string, data ExpiresAdminNotificationData, locales ...string) (message *mail.SGMailV3, err error) {
 	var text, html string
-	if text, html, err = Render("expires_admin_notification", data); err != nil {
+	if text, html, err = Render("expires_admin_notification", data, locales...); err != nil {
 		return nil, err
 	}
 
 	message = mail.NewSingleEmail(
 		mail.NewEmail(sender, senderEmail),
-		ExpiresAdminNotificationRE,
+		Subject("expires_admin_notification", ExpiresAdminNotificationRE, data, locales...),
 		mail.NewEmail(recipient, recipientEmail),
 		text,
 		html,
@@ -372,15 +421,15 @@ func ExpiresAdminNotificationEmail(sender, senderEmail, recipient, recipientEmai
 
 // ReissuanceReminderEmail creates a new reissuance reminder email, ready for sending by
 // rendering the text and html templates with the supplied data.
-func ReissuanceReminderEmail(sender, senderEmail, recipient, recipientEmail string, data ReissuanceReminderData) (message *mail.SGMailV3, err error) {
+func ReissuanceReminderEmail(sender, senderEmail, recipient, recipientEmail string, data ReissuanceReminderData, locales ...string) (message *mail.SGMailV3, err error) {
 	var text, html string
-	if text, html, err = Render("reissuance_reminder", data); err != nil {
+	if text, html, err = Render("reissuance_reminder", data, locales...); err != nil {
 		return nil, err
 	}
 
 	message = mail.NewSingleEmail(
 		mail.NewEmail(sender, senderEmail),
-		ReissuanceReminderRE,
+		Subject("reissuance_reminder", ReissuanceReminderRE, data, locales...),
 		mail.NewEmail(recipient, recipientEmail),
 		text,
 		html,
@@ -391,15 +440,15 @@ func ReissuanceReminderEmail(sender, senderEmail, recipient, recipientEmail stri
 
 // ReissuanceStartedEmail creates a new reissuance started email, ready for sending by
 // rendering the text and html templates with the supplied data.
-func ReissuanceStartedEmail(sender, senderEmail, recipient, recipientEmail string, data ReissuanceStartedData) (message *mail.SGMailV3, err error) {
+func ReissuanceStartedEmail(sender, senderEmail, recipient, recipientEmail string, data ReissuanceStartedData, locales ...string) (message *mail.SGMailV3, err error) {
 	var text, html string
-	if text, html, err = Render("reissuance_started", data); err != nil {
+	if text, html, err = Render("reissuance_started", data, locales...); err != nil {
 		return nil, err
 	}
 
 	message = mail.NewSingleEmail(
 		mail.NewEmail(sender, senderEmail),
-		ReissuanceStartedRE,
+		Subject("reissuance_started", ReissuanceStartedRE, data, locales...),
 		mail.NewEmail(recipient, recipientEmail),
 		text,
 		html,
@@ -410,15 +459,15 @@ func ReissuanceStartedEmail(sender, senderEmail, recipient, recipientEmail strin
 
 // ReissuanceAdminNotificationEmail creates a new certs reissuance admin notification email,
 // ready for sending by rendering the text and html templates with the supplied data.
-func ReissuanceAdminNotificationEmail(sender, senderEmail, recipient, recipientEmail string, data ReissuanceAdminNotificationData) (message *mail.SGMailV3, err error) {
+func ReissuanceAdminNotificationEmail(sender, senderEmail, recipient, recipientEmail string, data ReissuanceAdminNotificationData, locales ...string) (message *mail.SGMailV3, err error) {
 	var text, html string
-	if text, html, err = Render("reissuance_admin_notification", data); err != nil {
+	if text, html, err = Render("reissuance_admin_notification", data, locales...); err != nil {
 		return nil, err
 	}
 
 	message = mail.NewSingleEmail(
 		mail.NewEmail(sender, senderEmail),
-		ReissuanceAdminNotificationRE,
+		Subject("reissuance_admin_notification", ReissuanceAdminNotificationRE, data, locales...),
 		mail.NewEmail(recipient, recipientEmail),
 		text,
 		html,
@@ -432,27 +481,56 @@ func ReissuanceAdminNotificationEmail(sender, senderEmail, recipient, recipientE
 //===========================================================================
 
 // Render returns the text and html executed templates for the specified name and data.
-// Ensure that the extension is not supplied to the render method.
-func Render(name string, data interface{}) (text, html string, err error) {
-	if text, err = render(name+".txt", data); err != nil {
+// Ensure that the extension is not supplied to the render method. If locales are
+// specified, the localized templates of the first locale in the fallback chain that has
+// them are rendered, otherwise the default templates are rendered.
+func Render(name string, data interface{}, locales ...string) (text, html string, err error) {
+	chain := emails.Locales(locales...)
+	if text, err = render(name+emails.TextExt, data, chain); err != nil {
 		return "", "", err
 	}
 
-	if html, err = render(name+".html", data); err != nil {
+	if html, err = render(name+emails.HTMLExt, data, chain); err != nil {
 		return "", "", err
 	}
 
 	return text, html, nil
 }
 
-func render(name string, data interface{}) (_ string, err error) {
+// Subject returns the subject of the email for the specified name, rendering the
+// localized subject template if one has been loaded for a locale in the fallback chain
+// or the subject override if one has been loaded. Otherwise the subject is returned.
+func Subject(name, subject string, data interface{}, locales ...string) string {
+	chain := append(emails.Locales(locales...), "")
+	for _, locale := range chain {
+		if t, ok := subjects[emails.LocalizedName(locale, name+emails.SubjectExt)]; ok {
+			buf := &strings.Builder{}
+			if err := t.Execute(buf, data); err != nil {
+				sentry.Warn(nil).Err(err).Str("template", t.Name()).Msg("could not render email subject template")
+				return subject
+			}
+			return buf.String()
+		}
+	}
+	return subject
+}
+
+func render(name string, data interface{}, chain []string) (_ string, err error) {
 	var (
 		ok bool
 		t  *template.Template
 	)
 
-	if t, ok = templates[name]; !ok {
-		return "", fmt.Errorf("could not find %q in templates", name)
+	for _, locale := range chain {
+		if t, ok = templates[emails.LocalizedName(locale, name)]; ok {
+			break
+		}
+	}
+
+	if t == nil {
+		if t, ok = templates[name]; !ok {
+			return "", fmt.Errorf("could not find %q in templates", name)
+		}
 	}
 
 	buf := &strings.Builder{}
@@ -482,6 +560,16 @@ func LoadAttachment(message *mail.SGMailV3, attachmentPath string) (err error) {
 	return nil
 }
 
+// AttachZip encodes the zip file data and attaches it to the email.
+func AttachZip(message *mail.SGMailV3, data []byte, filename string) {
+	attach := mail.NewAttachment()
+	attach.SetContent(base64.StdEncoding.EncodeToString(data))
+	attach.SetType("application/zip")
+	attach.SetFilename(filename)
+	attach.SetDisposition("attachment")
+	message.AddAttachment(attach)
+}
+
 // AttachJSON by marshaling the specified data into human-readable data and encode and
 // attach it to the email as a file.
 func AttachJSON(message *mail.SGMailV3, data []byte, filename string) (err error) {
@@ -72,8 +72,10 @@ func TestEmailBuilders(t *testing.T) {
 	require.Equal(t, emails.RejectRegistrationRE, mail.Subject)
 	generateMIME(t, mail, "reject-registration.mim")
 
-	dcdata := emails.DeliverCertsData{Name: recipient, VID: "42", Organization: "Acme, Inc", CommonName: "example.com", SerialNumber: "1234abcdef56789", Endpoint: "trisa.example.com:443"}
-	mail, err = emails.DeliverCertsEmail(sender, senderEmail, recipient, recipientEmail, "testdata/foo.zip", dcdata)
+	dcdata := emails.DeliverCertsData{Name: recipient, VID: "42", Organization: "Acme, Inc", CommonName: "example.com", SerialNumber: "1234abcdef56789", Endpoint: "trisa.example.com:443", Filename: "foo.zip"}
+	dcdata.Attachment, err = os.ReadFile("testdata/foo.zip")
+	require.NoError(t, err)
+	mail, err = emails.DeliverCertsEmail(sender, senderEmail, recipient, recipientEmail, dcdata)
 	require.NoError(t, err)
 	require.Equal(t, emails.DeliverCertsRE, mail.Subject)
 	generateMIME(t, mail, "deliver-certs.mim")
@@ -106,6 +108,63 @@ func TestEmailBuilders(t *testing.T) {
 	generateMIME(t, mail, "reissuance-admin-notification.mim")
 }
 
+func TestLocalizedTemplates(t *testing.T) {
+	// Create template overrides with a branded html template and localized templates
+	dir := t.TempDir()
+	writeTemplate(t, dir, "verify_contact.html", `<p>Acme Network: {{ .VID }}</p>`)
+	writeTemplate(t, dir, "ja/verify_contact.txt", "{{ .Name }} 様、メールアドレスを確認してください。")
+	writeTemplate(t, dir, "ja/verify_contact.html", "<p>{{ .Name }} 様、メールアドレスを確認してください。</p>")
+	writeTemplate(t, dir, "ja/verify_contact.subject", "TRISA: メールアドレスの確認 ({{ .VID }})")
+	writeTemplate(t, dir, "es/deliver_certs.subject", "¡Bienvenido a la red TRISA!")
+
+	require.NoError(t, emails.LoadTemplates(dir))
+	t.Cleanup(func() { emails.LoadTemplates("") })
+
+	data := emails.VerifyContactData{Name: "Yamada", VID: "42", Token: "1234defg4321", BaseURL: "https://trisa.directory/verify"}
+
+	// The localized templates should be rendered for a regional locale of the language
+	msg, err := emails.VerifyContactEmail("Sender", "sender@example.com", "Yamada", "yamada@example.com", data, "ja-JP", "en")
+	require.NoError(t, err)
+	require.Equal(t, "TRISA: メールアドレスの確認 (42)", msg.Subject)
+	require.Equal(t, "Yamada 様、メールアドレスを確認してください。", msg.Content[0].Value)
+	require.Equal(t, "<p>Yamada 様、メールアドレスを確認してください。</p>", msg.Content[1].Value)
+
+	// Locales without localized templates should fall back to the default templates,
+	// which are replaced by the overrides in the root of the template directory.
+	msg, err = emails.VerifyContactEmail("Sender", "sender@example.com", "Kim", "kim@example.com", data, "ko", "en")
+	require.NoError(t, err)
+	require.Equal(t, emails.VerifyContactRE, msg.Subject)
+	require.Contains(t, msg.Content[0].Value, "Hello Yamada")
+	require.Equal(t, "<p>Acme Network: 42</p>", msg.Content[1].Value)
+
+	// The default locale is used if the recipient does not have a preferred locale
+	msg, err = emails.VerifyContactEmail("Sender", "sender@example.com", "Kim", "kim@example.com", data, "", "ja")
+	require.NoError(t, err)
+	require.Equal(t, "TRISA: メールアドレスの確認 (42)", msg.Subject)
+
+	// Subjects can be localized without localizing the email content
+	require.Equal(t, "¡Bienvenido a la red TRISA!", emails.Subject("deliver_certs", emails.DeliverCertsRE, nil, "es-MX"))
+	require.Equal(t, emails.DeliverCertsRE, emails.Subject("deliver_certs", emails.DeliverCertsRE, nil))
+
+	// Invalid templates cannot be loaded
+	writeTemplate(t, dir, "ko/verify_contact.txt", "{{ .Name ")
+	require.ErrorContains(t, emails.LoadTemplates(dir), "could not parse email template ko/verify_contact.txt")
+	require.Error(t, emails.LoadTemplates(filepath.Join(dir, "missing")))
+
+	// The compiled-in templates are restored when loaded without a directory
+	require.NoError(t, emails.LoadTemplates(""))
+	msg, err = emails.VerifyContactEmail("Sender", "sender@example.com", "Yamada", "yamada@example.com", data, "ja")
+	require.NoError(t, err)
+	require.Equal(t, emails.VerifyContactRE, msg.Subject)
+	require.NotContains(t, msg.Content[1].Value, "Acme Network")
+}
+
+func writeTemplate(t *testing.T, dir, name, data string) {
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(data), 0644))
+}
+
 func TestVerifyContactURL(t *testing.T) {
 	data := emails.VerifyContactData{
 		Name:  "Darlene Ulmsted",
@@ -333,8 +392,10 @@ func (suite *EmailTestSuite) TestSendDeliverCertsEmail() {
 	email, err := emails.New(suite.conf)
 	require.NoError(err)
 
-	data := emails.DeliverCertsData{Name: recipient.Name, VID: "42", Organization: "Acme, Inc.", CommonName: "example.com", SerialNumber: "1234abcdef56789", Endpoint: "trisa.example.com:443"}
-	msg, err := emails.DeliverCertsEmail(sender.Name, sender.Address, recipient.Name, recipient.Address, "testdata/foo.zip", data)
+	data := emails.DeliverCertsData{Name: recipient.Name, VID: "42", Organization: "Acme, Inc.", CommonName: "example.com", SerialNumber: "1234abcdef56789", Endpoint: "trisa.example.com:443", Filename: "foo.zip"}
+	data.Attachment, err = os.ReadFile("testdata/foo.zip")
+	require.NoError(err)
+	msg, err := emails.DeliverCertsEmail(sender.Name, sender.Address, recipient.Name, recipient.Address, data)
 	require.NoError(err)
 	require.NoError(email.Send(msg))
 	require.Len(mock.Emails, 1)
@@ -0,0 +1,206 @@
+package emails
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	sgmail "github.com/sendgrid/sendgrid-go/helpers/mail"
+	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/store"
+	"github.com/trisacrypto/directory/pkg/utils/retry"
+	"github.com/trisacrypto/directory/pkg/utils/sentry"
+)
+
+// NewOutbox creates an outbox that records the emails sent by the email manager in the
+// store. If the outbox is enabled, the email manager sends its emails through the
+// outbox so that they are delivered in the background and failed deliveries are retried
+// with an exponential backoff.
+func NewOutbox(conf config.OutboxConfig, db store.EmailStore, manager *EmailManager) *Outbox {
+	o := &Outbox{
+		conf:    conf,
+		db:      db,
+		manager: manager,
+		policy: retry.Policy{
+			MaxAttempts: conf.MaxAttempts,
+			Backoff:     conf.Backoff,
+			MaxBackoff:  conf.MaxBackoff,
+		},
+	}
+	o.loop = retry.NewLoop("email outbox", conf.Interval, o.Process)
+
+	if conf.Enabled {
+		manager.outbox = o
+	}
+	return o
+}
+
+// Outbox persists the emails sent by the directory service and delivers them in a go
+// routine that is notified when emails are sent and that periodically checks the outbox
+// until each email is delivered or the maximum number of attempts is reached, at which
+// point the email is moved to the dead letter state so that it can be inspected and
+// retried by an admin.
+type Outbox struct {
+	sync.Mutex
+	conf    config.OutboxConfig
+	db      store.EmailStore
+	manager *EmailManager
+	policy  retry.Policy
+	loop    *retry.Loop
+}
+
+// Run starts the Outbox as a go routine under the provided waitgroup. For graceful
+// shutdown, the caller must invoke the Stop method to signal the Outbox routine to stop
+// and block on the waitgroup if provided.
+func (o *Outbox) Run(wg *sync.WaitGroup) error {
+	if !o.conf.Enabled {
+		log.Warn().Msg("email outbox is disabled: emails will be sent synchronously and not retried")
+		return nil
+	}
+	return o.loop.Run(wg)
+}
+
+// Stop signals the Outbox routine to shutdown.
+// Note: This does not wait for the Outbox to stop and the caller should block on the
+// waitgroup passed to the Run method in order to implement a graceful shutdown.
+func (o *Outbox) Stop() {
+	o.loop.Stop()
+}
+
+// Send records the draft in the outbox and notifies the outbox routine to deliver it,
+// returning the ID of the outbox email so that email logs can reference its delivery
+// status. Delivery happens in the background so that callers are not blocked by a slow
+// or unavailable email service; an error is only returned if the email could not be
+// recorded in the outbox. The draft is rendered when the email is delivered so that its
+// secrets and attachments are not stored in the outbox.
+func (o *Outbox) Send(ctx context.Context, draft *Draft, reason, vaspID string) (_ string, err error) {
+	var data []byte
+	if data, err = draft.Marshal(); err != nil {
+		return "", fmt.Errorf("could not marshal email draft: %w", err)
+	}
+
+	var email *models.Email
+	if email, err = models.NewEmail(reason, draft.Subject, draft.Email, vaspID, data); err != nil {
+		return "", err
+	}
+
+	if _, err = o.db.CreateEmail(ctx, email); err != nil {
+		return "", fmt.Errorf("could not create outbox email: %w", err)
+	}
+
+	o.loop.Notify()
+	return email.Id, nil
+}
+
+// Retry an email that has not been delivered, e.g. a dead letter, with a fresh set of
+// attempts. Delivery is attempted immediately and the updated email is returned.
+func (o *Outbox) Retry(ctx context.Context, id string) (email *models.Email, err error) {
+	o.Lock()
+	defer o.Unlock()
+
+	if email, err = o.db.RetrieveEmail(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err = email.Requeue(); err != nil {
+		return nil, err
+	}
+
+	if err = o.deliver(ctx, email); err != nil {
+		return nil, err
+	}
+	return email, nil
+}
+
+// Process performs one iteration through the outbox, attempting to deliver every email
+// that is due and deleting delivered emails and dead letters that are older than their
+// retention periods. Errors delivering individual emails are recorded on the email
+// rather than returned.
+func (o *Outbox) Process(ctx context.Context) (err error) {
+	o.Lock()
+	defer o.Unlock()
+
+	now := time.Now()
+	delivered, purged := 0, 0
+	for _, email := range o.db.ListEmails(ctx) {
+		if email.IsFinished() {
+			if o.expired(email, now) {
+				if err = o.db.DeleteEmail(ctx, email.Id); err != nil {
+					sentry.Error(ctx).Err(err).Str("email", email.Id).Msg("could not delete expired outbox email")
+					continue
+				}
+				purged++
+			}
+			continue
+		}
+
+		if !email.Due(now) {
+			continue
+		}
+
+		if err = o.deliver(ctx, email); err != nil {
+			sentry.Error(ctx).Err(err).Str("email", email.Id).Msg("could not save outbox email")
+			continue
+		}
+
+		if email.Status == models.EmailState_EMAIL_DELIVERED {
+			delivered++
+		}
+	}
+
+	if delivered > 0 || purged > 0 {
+		log.Debug().Int("delivered", delivered).Int("purged", purged).Dur("duration", time.Since(now)).Msg("email outbox processed")
+	}
+	return nil
+}
+
+// Returns true if the delivered email or dead letter is older than its retention period.
+// Dead letters expire from the time they were moved to the dead letter state.
+func (o *Outbox) expired(email *models.Email, now time.Time) bool {
+	if email.Status == models.EmailState_EMAIL_DEAD_LETTER {
+		return retry.Expired(email.Modified, o.conf.DeadLetters, now)
+	}
+	return retry.Expired(email.Delivered, o.conf.Retention, now)
+}
+
+// Attempt to render and deliver the email draft with the email client and save the
+// outcome. The draft is cleared once the email is delivered; dead letters keep their
+// draft so that they can be retried by an admin.
+// NOTE: the caller must hold the lock to ensure an email is not delivered concurrently.
+func (o *Outbox) deliver(ctx context.Context, email *models.Email) error {
+	draft, err := ParseDraft(email.Draft)
+	if err == nil {
+		var msg *sgmail.SGMailV3
+		if msg, err = o.manager.Render(ctx, draft); err == nil {
+			err = o.manager.Send(msg)
+		}
+	}
+
+	now := time.Now()
+	email.Attempts++
+	outcome, nextAttempt := o.policy.Attempt(email.Attempts, err, now)
+	switch outcome {
+	case retry.Succeeded:
+		email.Status = models.EmailState_EMAIL_DELIVERED
+		email.LastError = ""
+		email.NextAttempt = ""
+		email.Delivered = now.Format(time.RFC3339)
+		email.Draft = nil
+		log.Debug().Str("email", email.Id).Str("reason", email.Reason).Uint32("attempts", email.Attempts).Msg("email delivered")
+	case retry.Failed:
+		email.Status = models.EmailState_EMAIL_DEAD_LETTER
+		email.LastError = err.Error()
+		email.NextAttempt = ""
+		sentry.Error(ctx).Err(err).Str("email", email.Id).Str("reason", email.Reason).Str("vasp", email.Vasp).Int("attempts", int(email.Attempts)).Msg("email could not be delivered and was moved to the dead letter state")
+	case retry.Retrying:
+		email.Status = models.EmailState_EMAIL_RETRYING
+		email.LastError = err.Error()
+		email.NextAttempt = nextAttempt
+		sentry.Warn(ctx).Err(err).Str("email", email.Id).Str("reason", email.Reason).Str("vasp", email.Vasp).Int("attempts", int(email.Attempts)).Msg("email delivery will be retried")
+	}
+
+	return o.db.UpdateEmail(ctx, email)
+}
@@ -0,0 +1,271 @@
+package emails_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net/mail"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/gds/admin/v2"
+	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/gds/emails"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/store"
+	storeconfig "github.com/trisacrypto/directory/pkg/store/config"
+	emailutils "github.com/trisacrypto/directory/pkg/utils/emails"
+	"github.com/trisacrypto/directory/pkg/utils/logger"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+func TestOutbox(t *testing.T) {
+	logger.Discard()
+	defer logger.ResetLogger()
+
+	// Emails are spooled into a maildir, removing the tmp directory breaks delivery
+	spool := t.TempDir()
+	manager := newMaildirManager(t, spool)
+	db := openStore(t)
+	conf := config.OutboxConfig{
+		Enabled:     true,
+		Interval:    time.Minute,
+		MaxAttempts: 3,
+		Backoff:     time.Minute,
+		MaxBackoff:  90 * time.Second,
+		Retention:   time.Hour,
+		DeadLetters: time.Hour,
+	}
+	outbox := emails.NewOutbox(conf, db, manager)
+	ctx := context.Background()
+
+	// A sent email should be queued in the outbox rather than delivered immediately
+	data := emails.RejectRegistrationData{Name: "Alice", VID: "b5841869-105f-411c-8722-4045aad72717", Reason: "testing the outbox"}
+	msg, err := emails.NewDraft("reject_registration", "Alice", "alice@example.com", data)
+	require.NoError(t, err)
+	id, err := outbox.Send(ctx, msg, string(admin.ResendRejection), "b5841869-105f-411c-8722-4045aad72717")
+	require.NoError(t, err)
+	queued := retrieveEmail(t, db, id)
+	require.Equal(t, models.EmailState_EMAIL_QUEUED, queued.Status)
+	require.Zero(t, queued.Attempts)
+	require.NotEmpty(t, queued.Draft)
+
+	// Processing the outbox should deliver the queued email
+	require.NoError(t, outbox.Process(ctx))
+	delivered := retrieveEmail(t, db, id)
+	require.Equal(t, models.EmailState_EMAIL_DELIVERED, delivered.Status)
+	require.Equal(t, uint32(1), delivered.Attempts)
+	require.Equal(t, "alice@example.com", delivered.Recipient)
+	require.Equal(t, emails.RejectRegistrationRE, delivered.Subject)
+	require.NotEmpty(t, delivered.Delivered)
+	require.Empty(t, delivered.Draft, "the draft should be cleared once the email is delivered")
+	requireSpooled(t, spool, 1)
+
+	// An email that cannot be delivered should be retried after the backoff
+	require.NoError(t, os.RemoveAll(filepath.Join(spool, "tmp")))
+	id, err = outbox.Send(ctx, msg, string(admin.ResendRejection), "b5841869-105f-411c-8722-4045aad72717")
+	require.NoError(t, err)
+	require.NoError(t, outbox.Process(ctx), "failed deliveries should not return an error")
+	email := retrieveEmail(t, db, id)
+	require.Equal(t, models.EmailState_EMAIL_RETRYING, email.Status)
+	require.Equal(t, uint32(1), email.Attempts)
+	require.Contains(t, email.LastError, "could not spool email")
+	requireNextAttempt(t, email, time.Minute)
+
+	// Emails should not be delivered again until they are due
+	require.NoError(t, outbox.Process(ctx))
+	require.Equal(t, uint32(1), retrieveEmail(t, db, id).Attempts)
+
+	// The backoff should double up to the max backoff
+	makeDue(t, db, id)
+	require.NoError(t, outbox.Process(ctx))
+	email = retrieveEmail(t, db, id)
+	require.Equal(t, models.EmailState_EMAIL_RETRYING, email.Status)
+	require.Equal(t, uint32(2), email.Attempts)
+	requireNextAttempt(t, email, 90*time.Second)
+
+	// The email should be moved to the dead letter state after the max attempts
+	makeDue(t, db, id)
+	require.NoError(t, outbox.Process(ctx))
+	email = retrieveEmail(t, db, id)
+	require.Equal(t, models.EmailState_EMAIL_DEAD_LETTER, email.Status)
+	require.Equal(t, uint32(3), email.Attempts)
+	require.Empty(t, email.NextAttempt)
+	require.NotEmpty(t, email.Draft, "dead letters should keep their draft so they can be retried")
+
+	// Dead letters are not delivered or deleted by the outbox before they expire
+	makeDue(t, db, id)
+	require.NoError(t, outbox.Process(ctx))
+	require.Equal(t, uint32(3), retrieveEmail(t, db, id).Attempts)
+
+	// Once delivery is possible again, dead letters can be retried
+	require.NoError(t, os.MkdirAll(filepath.Join(spool, "tmp"), 0700))
+	email, err = outbox.Retry(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, models.EmailState_EMAIL_DELIVERED, email.Status)
+	require.Equal(t, uint32(1), email.Attempts)
+	require.Empty(t, email.LastError)
+	require.Equal(t, models.EmailState_EMAIL_DELIVERED, retrieveEmail(t, db, id).Status)
+	requireSpooled(t, spool, 2)
+
+	// Delivered emails cannot be retried
+	_, err = outbox.Retry(ctx, id)
+	require.ErrorIs(t, err, models.ErrEmailDelivered)
+
+	// Delivered emails should be deleted after the retention period
+	delivered.Delivered = time.Now().Add(-2 * time.Hour).Format(time.RFC3339)
+	require.NoError(t, db.UpdateEmail(ctx, delivered))
+	require.NoError(t, outbox.Process(ctx))
+	_, err = db.RetrieveEmail(ctx, delivered.Id)
+	require.Error(t, err, "expected expired email to be deleted")
+	retrieveEmail(t, db, id)
+	requireSpooled(t, spool, 2)
+
+	// Dead letters should be deleted after the dead letter retention period
+	dead, err := models.NewEmail(string(admin.ResendRejection), emails.RejectRegistrationRE, "alice@example.com", "b5841869-105f-411c-8722-4045aad72717", queued.Draft)
+	require.NoError(t, err)
+	dead.Status = models.EmailState_EMAIL_DEAD_LETTER
+	_, err = db.CreateEmail(ctx, dead)
+	require.NoError(t, err)
+	require.NoError(t, outbox.Process(ctx))
+	retrieveEmail(t, db, dead.Id)
+
+	conf.DeadLetters = time.Nanosecond
+	require.NoError(t, emails.NewOutbox(conf, db, manager).Process(ctx))
+	_, err = db.RetrieveEmail(ctx, dead.Id)
+	require.Error(t, err, "expected expired dead letter to be deleted")
+	retrieveEmail(t, db, id)
+	requireSpooled(t, spool, 2)
+}
+
+func TestOutboxSecrets(t *testing.T) {
+	logger.Discard()
+	defer logger.ResetLogger()
+
+	spool := t.TempDir()
+	manager := newMaildirManager(t, spool)
+	db := openStore(t)
+	conf := config.OutboxConfig{Enabled: true, Interval: time.Minute, MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Hour}
+	outbox := emails.NewOutbox(conf, db, manager)
+	ctx := context.Background()
+
+	vasp, _ := makeClientFixtures(t, &mail.Address{Name: "Alice", Address: "alice@example.com"})
+	certreqID := "b0a7a2d4-1b3c-4d5e-8f60-718293a4b5c6"
+
+	// Secrets cannot be looked up without a resolver so the delivery is retried
+	sent, err := manager.SendReissuanceStarted(vasp, certreqID)
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+	require.NoError(t, outbox.Process(ctx))
+	queue := db.ListEmails(ctx)
+	require.Len(t, queue, 1)
+	require.Equal(t, models.EmailState_EMAIL_RETRYING, queue[0].Status)
+	require.Contains(t, queue[0].LastError, emails.ErrNoResolver.Error())
+
+	// The whisper link and the certificates are not stored in the outbox
+	resolver := &stubResolver{payload: []byte("supersecretcertificates"), link: "https://whisper.dev/supersecret"}
+	manager.SetResolver(resolver)
+	sent, err = manager.SendDeliverCertificates(vasp, certreqID, "test.example.com.zip", false)
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+
+	queue = db.ListEmails(ctx)
+	require.Len(t, queue, 2)
+	for _, email := range queue {
+		require.Contains(t, string(email.Draft), certreqID)
+		require.NotContains(t, string(email.Draft), resolver.link)
+		require.NotContains(t, string(email.Draft), base64.StdEncoding.EncodeToString(resolver.payload))
+		makeDue(t, db, email.Id)
+	}
+
+	// The secrets are looked up when the drafts are rendered and delivered
+	require.NoError(t, outbox.Process(ctx))
+	for _, email := range db.ListEmails(ctx) {
+		require.Equal(t, models.EmailState_EMAIL_DELIVERED, email.Status)
+		require.Empty(t, email.Draft)
+	}
+	require.Equal(t, []string{certreqID, certreqID}, resolver.lookups)
+	requireSpooled(t, spool, 2)
+}
+
+func TestOutboxEmailLog(t *testing.T) {
+	logger.Discard()
+	defer logger.ResetLogger()
+
+	spool := t.TempDir()
+	manager := newMaildirManager(t, spool)
+	db := openStore(t)
+
+	vasp := &pb.VASP{Id: "b5841869-105f-411c-8722-4045aad72717"}
+	contact := &models.Contact{Email: "alice@example.com", Name: "Alice", Token: "12345"}
+
+	// If the outbox is disabled, emails are sent directly without a message ID
+	emails.NewOutbox(config.OutboxConfig{}, db, manager)
+	require.NoError(t, manager.SendVerifyContact(vasp, contact))
+	require.Len(t, contact.EmailLog, 1)
+	require.Empty(t, contact.EmailLog[0].MessageId)
+	require.Empty(t, db.ListEmails(context.Background()))
+
+	// If the outbox is enabled, the email log references the outbox email
+	conf := config.OutboxConfig{Enabled: true, Interval: time.Minute, MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Hour}
+	outbox := emails.NewOutbox(conf, db, manager)
+	require.NoError(t, manager.SendVerifyContact(vasp, contact))
+	require.Len(t, contact.EmailLog, 2)
+	require.NotEmpty(t, contact.EmailLog[1].MessageId)
+	require.NoError(t, outbox.Process(context.Background()))
+
+	email := retrieveEmail(t, db, contact.EmailLog[1].MessageId)
+	require.Equal(t, models.EmailState_EMAIL_DELIVERED, email.Status)
+	require.Equal(t, string(admin.ResendVerifyContact), email.Reason)
+	require.Equal(t, vasp.Id, email.Vasp)
+	require.Equal(t, contact.Email, email.Recipient)
+	requireSpooled(t, spool, 2)
+}
+
+func newMaildirManager(t *testing.T, spool string) *emails.EmailManager {
+	conf := config.EmailConfig{
+		ServiceEmail:         "GDS <service@gds.dev>",
+		AdminEmail:           "GDS Admin <admin@gds.dev>",
+		VerifyContactBaseURL: "http://localhost:3000/verify",
+		AdminReviewBaseURL:   "http://localhost:3001/vasps/",
+		Transport:            emailutils.TransportConfig{URL: (&url.URL{Scheme: "maildir", Path: spool}).String()},
+	}
+
+	manager, err := emails.New(conf)
+	require.NoError(t, err, "could not create maildir email manager")
+	return manager
+}
+
+func openStore(t *testing.T) store.Store {
+	db, err := store.Open(storeconfig.StoreConfig{URL: "leveldb:///" + t.TempDir()})
+	require.NoError(t, err, "could not open leveldb store")
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func retrieveEmail(t *testing.T, db store.Store, id string) *models.Email {
+	email, err := db.RetrieveEmail(context.Background(), id)
+	require.NoError(t, err)
+	return email
+}
+
+func makeDue(t *testing.T, db store.Store, id string) {
+	email := retrieveEmail(t, db, id)
+	email.NextAttempt = time.Now().Add(-time.Second).Format(time.RFC3339)
+	require.NoError(t, db.UpdateEmail(context.Background(), email))
+}
+
+func requireNextAttempt(t *testing.T, email *models.Email, backoff time.Duration) {
+	next, err := time.Parse(time.RFC3339, email.NextAttempt)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(backoff), next, 5*time.Second)
+}
+
+func requireSpooled(t *testing.T, spool string, n int) {
+	spooled, err := os.ReadDir(filepath.Join(spool, "new"))
+	require.NoError(t, err)
+	require.Len(t, spooled, n)
+}
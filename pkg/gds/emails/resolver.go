@@ -0,0 +1,89 @@
+package emails
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/trisacrypto/directory/pkg/gds/secrets"
+	"github.com/trisacrypto/directory/pkg/store"
+	"github.com/trisacrypto/directory/pkg/utils/whisper"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+// Resolver looks up the secrets and attachments of an email when it is rendered so that
+// they do not have to be stored with the draft in the email outbox.
+type Resolver interface {
+	// RetrieveVASP returns the VASP record that a review request is rendered from.
+	RetrieveVASP(ctx context.Context, id string) (*pb.VASP, error)
+
+	// CertificatePayload returns the zipped certificates issued for the request.
+	CertificatePayload(ctx context.Context, certreqID string) ([]byte, error)
+
+	// PasswordLink returns a one-time whisper link to the PKCS12 password of the request.
+	PasswordLink(ctx context.Context, certreqID string) (string, error)
+}
+
+// ErrNoResolver is returned when an email cannot be rendered because it requires a
+// secret and the email manager was not configured with a resolver.
+var ErrNoResolver = errors.New("email manager cannot look up email secrets without a resolver")
+
+const passwordLinkTemplate = "Below is the PKCS12 password which you must use to decrypt your new certificates:\n\n%s\n"
+
+// NewResolver returns a resolver that retrieves VASP records from the store and the
+// certificates and PKCS12 passwords of certificate requests from the secret manager.
+func NewResolver(db store.DirectoryStore, secret *secrets.SecretManager) Resolver {
+	return &storeResolver{db: db, secret: secret}
+}
+
+type storeResolver struct {
+	db     store.DirectoryStore
+	secret *secrets.SecretManager
+}
+
+func (r *storeResolver) RetrieveVASP(ctx context.Context, id string) (*pb.VASP, error) {
+	return r.db.RetrieveVASP(ctx, id)
+}
+
+func (r *storeResolver) CertificatePayload(ctx context.Context, certreqID string) ([]byte, error) {
+	return r.secret.With(certreqID).GetLatestVersion(ctx, "cert")
+}
+
+func (r *storeResolver) PasswordLink(ctx context.Context, certreqID string) (link string, err error) {
+	var password []byte
+	if password, err = r.secret.With(certreqID).GetLatestVersion(ctx, "password"); err != nil {
+		return "", err
+	}
+
+	if link, err = whisper.CreateSecretLink(fmt.Sprintf(passwordLinkTemplate, password), "", 3, time.Now().AddDate(0, 0, 7)); err != nil {
+		return "", err
+	}
+	return link, nil
+}
+
+// SetResolver configures how the email manager looks up email secrets and attachments.
+func (m *EmailManager) SetResolver(resolver Resolver) {
+	m.secrets = resolver
+}
+
+func (m *EmailManager) resolver() Resolver {
+	if m.secrets == nil {
+		return noResolver{}
+	}
+	return m.secrets
+}
+
+type noResolver struct{}
+
+func (noResolver) RetrieveVASP(context.Context, string) (*pb.VASP, error) {
+	return nil, ErrNoResolver
+}
+
+func (noResolver) CertificatePayload(context.Context, string) ([]byte, error) {
+	return nil, ErrNoResolver
+}
+
+func (noResolver) PasswordLink(context.Context, string) (string, error) {
+	return "", ErrNoResolver
+}
@@ -6,16 +6,19 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/trisacrypto/directory/pkg"
 	admin "github.com/trisacrypto/directory/pkg/gds/admin/v2"
 	"github.com/trisacrypto/directory/pkg/gds/config"
+	members "github.com/trisacrypto/directory/pkg/gds/members/v1alpha1"
 	"github.com/trisacrypto/directory/pkg/gds/secrets"
 	"github.com/trisacrypto/directory/pkg/models/v1"
 	"github.com/trisacrypto/directory/pkg/store"
 	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
+	"github.com/trisacrypto/directory/pkg/store/txn"
 	"github.com/trisacrypto/directory/pkg/utils"
 	activity "github.com/trisacrypto/directory/pkg/utils/activity"
 	"github.com/trisacrypto/directory/pkg/utils/sentry"
@@ -23,7 +26,9 @@ import (
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 // NewGDS creates a new GDS server derived from a parent Service.
@@ -40,6 +45,12 @@ func NewGDS(svc *Service) (gds *GDS, err error) {
 		grpc.ChainStreamInterceptor(svc.StreamInterceptors()...),
 	)
 	api.RegisterTRISADirectoryServer(gds.srv, gds)
+
+	// Register the handlers of the jobs that process the side effects of GDS requests;
+	// the job queue is not created in maintenance mode.
+	if svc.jobs != nil {
+		svc.jobs.Handle(models.JobRegistration, gds.processRegistration)
+	}
 	return gds, nil
 }
 
@@ -156,6 +167,15 @@ func (s *GDS) Register(ctx context.Context, in *api.RegisterRequest) (out *api.R
 		}
 	}
 
+	// Certificates are issued for the CSR in the request metadata, if any, so that the
+	// private key of the VASP is never sent to the directory service.
+	var csr string
+	var dnsNames []string
+	if csr, dnsNames, err = registrationCSR(ctx, vasp); err != nil {
+		sentry.Warn(ctx).Err(err).Str("common_name", vasp.CommonName).Msg("invalid certificate signing request")
+		return nil, status.Errorf(codes.InvalidArgument, "invalid certificate signing request: %s", err)
+	}
+
 	// Validate partial VASP record to ensure that it can be registered.
 	if err = models.ValidateVASP(vasp, true); err != nil {
 		sentry.Warn(ctx).Err(err).Msg("invalid or incomplete VASP registration")
@@ -189,47 +209,71 @@ func (s *GDS) Register(ctx context.Context, in *api.RegisterRequest) (out *api.R
 		return nil, status.Error(codes.Aborted, "could not add new entry to VASP audit log")
 	}
 
-	// TODO: create legal entity hash to detect a repeat registration without ID
+	// Detect repeat registrations of the same legal entity, which are either rejected or
+	// flagged on the VASP record so that the admins can consider them during review.
+	var matches []*models.DuplicateMatch
+	if matches, err = s.svc.duplicates.Check(ctx, vasp); err != nil {
+		// Registration can continue without duplicate detection, the name index will
+		// still prevent registrations with the same common name.
+		sentry.Error(ctx).Err(err).Msg("could not check registration for duplicates")
+	}
+
+	if len(matches) > 0 {
+		if s.svc.duplicates.Reject() {
+			sentry.Warn(ctx).Str("common_name", vasp.CommonName).Str("duplicate", matches[0].VaspId).Str("reasons", strings.Join(matches[0].Reasons, ",")).Msg("duplicate registration rejected")
+			return nil, status.Error(codes.AlreadyExists, "could not complete registration, the legal entity appears to already be registered; please contact the TRISA admins")
+		}
+
+		if err = models.SetDuplicates(vasp, matches); err != nil {
+			sentry.Error(ctx).Err(err).Msg("could not flag duplicates on VASP record")
+			return nil, status.Error(codes.Internal, "internal error with registration, please contact admins")
+		}
+		sentry.Warn(ctx).Str("common_name", vasp.CommonName).Int("matches", len(matches)).Msg("registration flagged as a likely duplicate")
+	}
+
+	// The VASP, its contacts, and its certificate request are written in a single
+	// transaction so that a failure does not leave a partial registration behind.
+	var tx txn.Txn
+	if tx, err = s.db.Begin(ctx); err != nil {
+		sentry.Error(ctx).Err(err).Msg("could not begin registration transaction")
+		return nil, status.Error(codes.Internal, "internal error with registration, please contact admins")
+	}
+	defer tx.Rollback()
+
 	// TODO: add signature to leveldb indices
-	// TODO: check already exists and uniqueness constraints
-	if vasp.Id, err = s.db.CreateVASP(ctx, vasp); err != nil {
-		// Assuming uniqueness is the primary constraint here
-		// TODO: better database error checking or handling
+	// NOTE: the uniqueness constraints are checked when the transaction is committed
+	if vasp.Id, err = tx.CreateVASP(vasp); err != nil {
 		sentry.Warn(ctx).Err(err).Msg("could not register VASP in database")
 		return nil, status.Error(codes.AlreadyExists, "could not complete registration, uniqueness constraints violated")
 	}
 
-	// Log successful registration
-	vaspName, _ := vasp.Name()
-	log.Info().Str("name", vaspName).Str("id", vasp.Id).Msg("registered VASP")
-
-	// Begin verification process by sending emails to all contacts in the VASP record.
-	// TODO: add to processing queue to return sooner/parallelize work
-	// Create the verification tokens and save the VASP back to the database
+	// Create the verification tokens for the contacts; the verification emails are
+	// sent by the registration job once the registration has been committed.
 	contacts := make(map[string]*models.Contact)
-	sent := 0
 	iter := models.NewContactIterator(vasp.Contacts, models.SkipNoEmail())
 	for iter.Next() {
 		vaspContact, kind := iter.Value()
 
 		// If there does not exist a model contact associated with the vasp contact's email then create one.
 		var contact *models.Contact
-		contact, err = s.db.RetrieveContact(ctx, vaspContact.Email)
-		if err != nil {
-			if errors.Is(err, storeerrors.ErrEntityNotFound) {
+		if contact, err = s.db.RetrieveContact(ctx, vaspContact.Email); err != nil {
+			if !errors.Is(err, storeerrors.ErrEntityNotFound) {
+				sentry.Warn(ctx).Err(err).Msg("could not register contact in database")
+				return nil, status.Error(codes.AlreadyExists, "could not complete registration")
+			}
+
+			// The same email may be specified on multiple contacts in the request.
+			if contact = contacts[models.NormalizeEmail(vaspContact.Email)]; contact == nil {
 				contact = &models.Contact{
 					Email: vaspContact.Email,
 					Name:  vaspContact.Name,
 					Vasps: []string{vasp.CommonName},
 					Token: secrets.CreateToken(48),
 				}
-				if _, err = s.db.CreateContact(ctx, contact); err != nil {
+				if _, err = tx.CreateContact(contact); err != nil {
 					sentry.Error(ctx).Err(err).Str("contact", vaspContact.Email).Str("vasp", vasp.Id).Msg("could not create contact")
 					return nil, status.Error(codes.Aborted, "could not create contact")
 				}
-			} else {
-				sentry.Warn(ctx).Err(err).Msg("could not register contact in database")
-				return nil, status.Error(codes.AlreadyExists, "could not complete registration")
 			}
 		}
 
@@ -240,50 +284,11 @@ func (s *GDS) Register(ctx context.Context, in *api.RegisterRequest) (out *api.R
 			return nil, status.Error(codes.Aborted, "could not send contact verification emails")
 		}
 
-		// Prevent sending duplicate verification emails, e.g. if the same email is
-		// specified on multiple contacts in the register request.
-		if _, ok := contacts[contact.Email]; ok {
-			log.Debug().Str("email", contact.Email).Str("kind", kind).Msg("ignoring duplicate email on VASP")
-			continue
-		}
-
-		if !contact.Verified {
-			// Begin verification process by sending email to the contact created from the VASP record.
-			// TODO: add to processing queue to return sooner/parallelize work
-			if err = s.svc.email.SendVerifyContact(vasp, contact); err != nil {
-				// If there is an error sending contact verification emails, alert admins who
-				// can resend emails later, do not abort processing the registration.
-				sentry.Error(ctx).Err(err).Str("vasp", vasp.Id).Int("sent", sent).Msg("could not send verify contacts emails")
-			} else {
-				// Log successful contact verification emails sent
-				log.Info().Msg("contact email verification sent")
-				sent++
-
-				// Update the model contact record to update the email log
-				if err = s.db.UpdateContact(ctx, contact); err != nil {
-					sentry.Error(ctx).Err(err).Str("contact", contact.Email).Msg("could not update email logs on contact")
-					return nil, status.Error(codes.Aborted, "could not send contact verification emails")
-				}
-				models.AppendEmailLog(vaspContact, string(admin.ResendVerifyContact), "verify_contact")
-			}
-		} else if vasp.VerificationStatus == pb.VerificationState_SUBMITTED {
-			// If one of the contacts is already verified then short circuit the
-			// verification step and begin the review step by sending a review email to
-			// TRISA admins. This should only be done once for this VASP to avoid
-			// sending the admins duplicate emails.
-			if err = s.beginReview(ctx, vasp, contact.Email); err != nil {
-				sentry.Error(ctx).Err(err).Str("vasp", vasp.Id).Msg("could not begin registration for contact with verified email")
-				return nil, err
-			}
-		}
-
-		// Avoid double processing this contact
-		contacts[contact.Email] = contact
+		contacts[models.NormalizeEmail(contact.Email)] = contact
 	}
 
 	// Create PKCS12 password along with certificate request.
 	var certRequest *models.CertificateRequest
-	password := secrets.CreateToken(16)
 	if certRequest, err = models.NewCertificateRequest(vasp); err != nil {
 		sentry.Error(ctx).Err(err).Str("vasp", vasp.Id).Msg("could not create certificate request")
 		return nil, status.Error(codes.Internal, "internal error with registration, please contact admins")
@@ -294,19 +299,29 @@ func (s *GDS) Register(ctx context.Context, in *api.RegisterRequest) (out *api.R
 		return nil, status.Error(codes.Internal, "internal error with registration, please contact admins")
 	}
 
-	// Make a new secret of type "password"
-	secretType := "password"
-	if err = s.svc.secret.With(certRequest.Id).CreateSecret(ctx, secretType); err != nil {
-		sentry.Error(ctx).Err(err).Str("vasp", vasp.Id).Msg("could not create new secret for pkcs12 password")
-		return nil, status.Error(codes.Internal, "internal error with registration, please contact admins")
-	}
-	if err = s.svc.secret.With(certRequest.Id).AddSecretVersion(ctx, secretType, []byte(password)); err != nil {
-		sentry.Error(ctx).Err(err).Str("vasp", vasp.Id).Msg("unable to add secret version for pkcs12 password")
-		return nil, status.Error(codes.Internal, "internal error with registration, please contact admins")
+	// Certificates issued from a CSR do not contain a private key so no password is needed.
+	var password string
+	if csr != "" {
+		certRequest.Csr = csr
+		certRequest.DnsNames = dnsNames
+	} else {
+		// Make a new secret of type "password"; unlike the other side effects of the
+		// registration the secret is created inline rather than by the registration job
+		// since the password must be stored before it is returned to the VASP.
+		password = secrets.CreateToken(16)
+		secretType := "password"
+		if err = s.svc.secret.With(certRequest.Id).CreateSecret(ctx, secretType); err != nil {
+			sentry.Error(ctx).Err(err).Str("vasp", vasp.Id).Msg("could not create new secret for pkcs12 password")
+			return nil, status.Error(codes.Internal, "internal error with registration, please contact admins")
+		}
+		if err = s.svc.secret.With(certRequest.Id).AddSecretVersion(ctx, secretType, []byte(password)); err != nil {
+			sentry.Error(ctx).Err(err).Str("vasp", vasp.Id).Msg("unable to add secret version for pkcs12 password")
+			return nil, status.Error(codes.Internal, "internal error with registration, please contact admins")
+		}
 	}
 
 	// Create certificate request in the database.
-	if err = s.db.UpdateCertReq(ctx, certRequest); err != nil {
+	if err = tx.UpdateCertReq(certRequest); err != nil {
 		sentry.Error(ctx).Err(err).Str("vasp", vasp.Id).Msg("could not save certificate request")
 		return nil, status.Error(codes.Internal, "internal error with registration, please contact admins")
 	}
@@ -317,12 +332,60 @@ func (s *GDS) Register(ctx context.Context, in *api.RegisterRequest) (out *api.R
 		return nil, status.Error(codes.Internal, "internal error with registration, please contact admins")
 	}
 
-	// Store VASP with updated certificate requests
-	if err = s.db.UpdateVASP(ctx, vasp); err != nil {
-		sentry.Error(ctx).Err(err).Str("vasp", vasp.Id).Msg("could not update vasp with certificate request ID")
+	// The side effects of the registration, e.g. sending the verification emails, are
+	// processed by a job created with the registration so that they are retried if they
+	// fail, even if the directory service is restarted in the meantime.
+	var job *models.Job
+	if job, err = models.NewJob(models.JobRegistration, vasp.Id); err != nil {
+		sentry.Error(ctx).Err(err).Str("vasp", vasp.Id).Msg("could not create registration job")
+		return nil, status.Error(codes.Internal, "internal error with registration, please contact admins")
+	}
+
+	if _, err = tx.CreateJob(job); err != nil {
+		sentry.Error(ctx).Err(err).Str("vasp", vasp.Id).Msg("could not save registration job")
+		return nil, status.Error(codes.Internal, "internal error with registration, please contact admins")
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		// The password secret is not part of the transaction so it must be cleaned up.
+		if password != "" {
+			if serr := s.svc.secret.With(certRequest.Id).DeleteSecret(ctx, "password"); serr != nil {
+				sentry.Error(ctx).Err(serr).Str("certreq", certRequest.Id).Msg("could not delete pkcs12 password of failed registration")
+			}
+		}
+
+		if errors.Is(err, storeerrors.ErrDuplicateEntity) {
+			sentry.Warn(ctx).Err(err).Msg("could not register VASP in database")
+			return nil, status.Errorf(codes.AlreadyExists, "could not complete registration, a VASP with common name %q is already registered", vasp.CommonName)
+		}
+		sentry.Error(ctx).Err(err).Str("vasp", vasp.Id).Msg("could not commit registration")
 		return nil, status.Error(codes.Internal, "internal error with registration, please contact admins")
 	}
 
+	// Log successful registration
+	vaspName, _ := vasp.Name()
+	log.Info().Str("name", vaspName).Str("id", vasp.Id).Msg("registered VASP")
+
+	// Return the ID of the registration job in the header so that the status of the job
+	// can be polled; the RegisterReply does not have a field for the job.
+	if err = grpc.SetHeader(ctx, metadata.Pairs(models.JobMetadataKey, job.Id)); err != nil {
+		log.Debug().Err(err).Str("job", job.Id).Msg("could not set registration job header")
+	}
+
+	if err = s.svc.jobs.Submit(ctx, job); err != nil {
+		// The job is in the database so it will be retried when the queue next runs.
+		sentry.Error(ctx).Err(err).Str("vasp", vasp.Id).Str("job", job.Id).Msg("could not submit registration job")
+	}
+
+	// If the job was processed synchronously then the registration may have progressed.
+	if job.IsFinished() {
+		if processed, err := s.db.RetrieveVASP(ctx, vasp.Id); err != nil {
+			sentry.Warn(ctx).Err(err).Str("vasp", vasp.Id).Msg("could not retrieve processed registration")
+		} else {
+			vasp = processed
+		}
+	}
+
 	out = &api.RegisterReply{
 		Id:                  vasp.Id,
 		RegisteredDirectory: vasp.RegisteredDirectory,
@@ -331,9 +394,153 @@ func (s *GDS) Register(ctx context.Context, in *api.RegisterRequest) (out *api.R
 		Message:             "a verification code has been sent to the contact email, please check spam folder if it has not arrived; pkcs12 password attached, this is the only time it will be available -- do not lose!",
 		Pkcs12Password:      password,
 	}
+
+	if csr != "" {
+		out.Message = "a verification code has been sent to the contact email, please check spam folder if it has not arrived; certificates will be issued for the submitted certificate signing request"
+	}
 	return out, nil
 }
 
+// registrationCSR returns the PEM encoded CSR submitted in the metadata of a register
+// request along with any DNS names requested in addition to the common name. The
+// subject of the CSR must be the common name of the VASP and the only alternative name
+// allowed in addition to the common name is the host of the TRISA endpoint of the VASP.
+// If no CSR was submitted an empty string is returned.
+func registrationCSR(ctx context.Context, vasp *pb.VASP) (_ string, dnsNames []string, err error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil, nil
+	}
+
+	values := md.Get(models.CSRMetadataKey)
+	switch len(values) {
+	case 0:
+		return "", nil, nil
+	case 1:
+	default:
+		return "", nil, errors.New("only one certificate signing request may be submitted")
+	}
+
+	if dnsNames, err = models.ValidateVASPCSR([]byte(values[0]), vasp); err != nil {
+		return "", nil, err
+	}
+	return values[0], dnsNames, nil
+}
+
+// Steps of a registration job that are recorded so that they are not repeated if the
+// job is retried. Verify contact steps are suffixed with the normalized contact email.
+const (
+	stepVerifyContact = "verify_contact:"
+	stepReview        = "review"
+)
+
+// processRegistration handles registration jobs by sending verification emails to the
+// unverified contacts of the VASP or, if one of the contacts has already been verified,
+// by beginning the review of the registration. If any email cannot be sent an error is
+// returned so that the job is retried for the contacts that have not been sent one.
+func (s *GDS) processRegistration(ctx context.Context, job *models.Job) (err error) {
+	var vasp *pb.VASP
+	if vasp, err = s.db.RetrieveVASP(ctx, job.Vasp); err != nil {
+		return fmt.Errorf("could not retrieve VASP: %w", err)
+	}
+
+	var verifiedEmail string
+	failed := 0
+	seen := make(map[string]struct{})
+	iter := models.NewContactIterator(vasp.Contacts, models.SkipNoEmail())
+	for iter.Next() {
+		vaspContact, kind := iter.Value()
+
+		// Prevent sending duplicate verification emails, e.g. if the same email is
+		// specified on multiple contacts in the register request.
+		email := models.NormalizeEmail(vaspContact.Email)
+		if _, ok := seen[email]; ok {
+			log.Debug().Str("email", vaspContact.Email).Str("kind", kind).Msg("ignoring duplicate email on VASP")
+			continue
+		}
+		seen[email] = struct{}{}
+
+		var contact *models.Contact
+		if contact, err = s.db.RetrieveContact(ctx, vaspContact.Email); err != nil {
+			return fmt.Errorf("could not retrieve %s contact: %w", kind, err)
+		}
+
+		if contact.Verified {
+			// If one of the contacts is already verified then short circuit the
+			// verification step and begin the review step. This should only be done
+			// once for this VASP to avoid sending the admins duplicate emails.
+			if verifiedEmail == "" {
+				verifiedEmail = contact.Email
+			}
+			continue
+		}
+
+		step := stepVerifyContact + email
+		if job.StepCompleted(step) {
+			continue
+		}
+
+		if err = s.svc.email.SendVerifyContact(vasp, contact); err != nil {
+			// Do not stop sending emails to the other contacts, the job will be retried.
+			sentry.Error(ctx).Err(err).Str("vasp", vasp.Id).Str("contact", kind).Msg("could not send verify contact email")
+			failed++
+			continue
+		}
+
+		// Log successful contact verification emails sent
+		log.Info().Msg("contact email verification sent")
+		models.AppendEmailLog(vaspContact, string(admin.ResendVerifyContact), "verify_contact")
+
+		if err = s.completeRegistrationStep(ctx, job, step, vasp, contact); err != nil {
+			return fmt.Errorf("could not save email logs: %w", err)
+		}
+	}
+
+	// Send the review request to the admins unless the registration has progressed since
+	// the job was created, e.g. because a contact has verified their email address.
+	if verifiedEmail != "" && !job.StepCompleted(stepReview) && vasp.VerificationStatus < pb.VerificationState_EMAIL_VERIFIED {
+		if err = s.beginReview(ctx, vasp, verifiedEmail); err != nil {
+			return fmt.Errorf("could not begin review: %w", err)
+		}
+
+		if err = s.completeRegistrationStep(ctx, job, stepReview, vasp); err != nil {
+			return fmt.Errorf("could not save verification status: %w", err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("could not send %d verify contact emails", failed)
+	}
+	return nil
+}
+
+// Saves the VASP and contacts that were modified by a step of a registration job in a
+// transaction and then records the step on the job.
+func (s *GDS) completeRegistrationStep(ctx context.Context, job *models.Job, step string, vasp *pb.VASP, contacts ...*models.Contact) (err error) {
+	var tx txn.Txn
+	if tx, err = s.db.Begin(ctx); err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, contact := range contacts {
+		if err = tx.UpdateContact(contact); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.UpdateVASP(vasp); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	job.CompleteStep(step)
+	return s.db.UpdateJob(ctx, job)
+}
+
 // beginReview starts the registration review process by sending an email to the TRISA admins.
 // This method does not update the passed vasp, the caller should be aware that they will need to
 // update the vasp record. contactEmail will be used to update the vasp's verification status. The
@@ -433,19 +640,58 @@ func (s *GDS) Lookup(ctx context.Context, in *api.LookupRequest) (out *api.Looku
 	// Ignore errors on name lookup
 	out.Name, _ = vasp.Name()
 
-	// TODO: how do we determine which signing certificate to send?
-	// Currently sending the last certificate in the array so that to update a
-	// signing certificate, a new cert just has to be appended to the slice.
-	if len(vasp.SigningCertificates) > 0 {
-		out.SigningCertificate = vasp.SigningCertificates[len(vasp.SigningCertificates)-1]
+	// The primary signing certificate is the last certificate in the array that has not
+	// been revoked so that to rotate a signing certificate, a new cert just has to be
+	// appended to the slice.
+	for i := len(vasp.SigningCertificates) - 1; i >= 0; i-- {
+		if !vasp.SigningCertificates[i].Revoked {
+			out.SigningCertificate = vasp.SigningCertificates[i]
+			break
+		}
+	}
+
+	// The reply only has fields for the primary certificates, so all of the active
+	// certificates, including certificates in their rotation overlap, are returned in
+	// the response headers so that peers can pre-trust the new certificates.
+	if err = s.sendActiveCertificates(ctx, vasp); err != nil {
+		log.Debug().Err(err).Str("id", vasp.Id).Msg("could not set active certificates header")
 	}
 
 	log.Info().Str("id", vasp.Id).Str("common_name", vasp.CommonName).Msg("VASP lookup succeeded")
 	return out, nil
 }
 
+// sendActiveCertificates sets the active certificates of the VASP and the rotation
+// overlap in the response header. Each certificate is a binary header value containing
+// a protocol buffer encoded members ActiveCertificate.
+func (s *GDS) sendActiveCertificates(ctx context.Context, vasp *pb.VASP) (err error) {
+	var certs []*members.ActiveCertificate
+	if certs, err = GetMemberCertificates(vasp, time.Now()); err != nil {
+		return err
+	}
+
+	md := metadata.Pairs(models.RotationOverlapMetadataKey, s.svc.conf.CertMan.RotationOverlap.String())
+	for _, cert := range certs {
+		var data []byte
+		if data, err = proto.Marshal(cert); err != nil {
+			return err
+		}
+		md.Append(models.ActiveCertificatesMetadataKey, string(data))
+	}
+	return grpc.SetHeader(ctx, md)
+}
+
 // Search for VASP entity records by name or by country in order to perform more detailed
-// Lookup requests. The search process is purposefully simplistic at the moment.
+// Lookup requests. Names are matched exactly or by prefix and are also used as full
+// text queries over the names, websites, addresses, and TRIXO questionnaires of VASPs,
+// tolerating small typos. The results are ordered by relevance and filtered by the
+// country and category if specified.
+//
+// The response is paginated; since the TRISA search messages do not have pagination
+// fields, the page_size and page_token are sent as request metadata. If there are more
+// results than the page size, the next_page_token is returned in the response header
+// and can be used to fetch the next page so long as the query is not modified. Results
+// with the same relevance are ordered by ID so that the order of pages is stable.
 func (s *GDS) Search(ctx context.Context, in *api.SearchRequest) (out *api.SearchReply, err error) {
 	// send search request activity to network activity handler
 	activity.Search().Add()
@@ -453,9 +699,15 @@ func (s *GDS) Search(ctx context.Context, in *api.SearchRequest) (out *api.Searc
 		ctx, _ = utils.WithDeadline(ctx)
 	}
 
+	var cursor *models.PageCursor
+	if cursor, err = searchCursor(ctx, in); err != nil {
+		return nil, err
+	}
+
 	// Create search query to send to database
 	query := make(map[string]interface{})
 	query["name"] = in.Name
+	query["text"] = in.Name
 	query["website"] = in.Website
 	query["country"] = in.Country
 
@@ -491,12 +743,20 @@ func (s *GDS) Search(ctx context.Context, in *api.SearchRequest) (out *api.Searc
 		})
 	}
 
+	// Return the page of results and the next page token if there are more results
+	out.Results = paginateSearch(out.Results, cursor)
+	if err = sendNextPageToken(ctx, cursor); err != nil {
+		sentry.Error(ctx).Err(err).Msg("could not send next page token on vasp search")
+		return nil, status.Error(codes.Internal, "could not create next page token")
+	}
+
 	log.Info().
 		Strs("name", in.Name).
 		Strs("websites", in.Website).
 		Strs("country", in.Country).
 		Strs("categories", categories).
 		Int("results", len(out.Results)).
+		Bool("next_page", cursor.NextVasp != "").
 		Msg("search succeeded")
 	return out, nil
 }
@@ -535,7 +795,6 @@ func (s *GDS) Verification(ctx context.Context, in *api.VerificationRequest) (ou
 		return nil, status.Error(codes.InvalidArgument, "please supply ID and registered directory or common name for verification")
 	}
 
-	// TODO: also return RevokedOn, which needs to be stored on the VASP
 	out = &api.VerificationReply{
 		VerificationStatus: vasp.VerificationStatus,
 		ServiceStatus:      vasp.ServiceStatus,
@@ -543,6 +802,11 @@ func (s *GDS) Verification(ctx context.Context, in *api.VerificationRequest) (ou
 		FirstListed:        vasp.FirstListed,
 		LastUpdated:        vasp.LastUpdated,
 	}
+
+	if out.RevokedOn, err = models.GetRevokedOn(vasp); err != nil {
+		sentry.Error(ctx).Err(err).Str("id", vasp.Id).Msg("could not retrieve revocation from VASP extra")
+		return nil, status.Error(codes.Internal, "could not retrieve VASP verification status")
+	}
 	log.Info().Str("id", vasp.Id).Str("common_name", vasp.CommonName).Msg("verification status check")
 	return out, nil
 }
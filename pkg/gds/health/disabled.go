@@ -0,0 +1,30 @@
+package health
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Disabled implements the health.Service interface but is essentially a no-op that
+// warns that the health check monitor is disabled. This allows outsider users to
+// interact with the monitor without having to check if it's enabled.
+type Disabled struct{}
+
+// Compile time interface implementation check.
+var _ Service = &Disabled{}
+
+func (d *Disabled) Run(*sync.WaitGroup) error {
+	log.Warn().Msg("health check monitor is disabled")
+	return nil
+}
+
+func (d *Disabled) Stop() {
+	log.Debug().Msg("stopping disabled health check monitor")
+}
+
+func (d *Disabled) HealthCheck(context.Context) error {
+	log.Trace().Msg("health check monitor is disabled: cannot check endpoints")
+	return nil
+}
@@ -0,0 +1,280 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/gds/health"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/store"
+	storeconfig "github.com/trisacrypto/directory/pkg/store/config"
+	"github.com/trisacrypto/directory/pkg/utils/bufconn"
+	"github.com/trisacrypto/directory/pkg/utils/logger"
+	api "github.com/trisacrypto/trisa/pkg/trisa/api/v1beta1"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/grpc"
+)
+
+func TestHealthCheck(t *testing.T) {
+	logger.Discard()
+	defer logger.ResetLogger()
+
+	// Start the TRISA peers that the monitor will check; the endpoints are IP literals
+	// so that they are not resolved before being passed to the bufconn dialer.
+	notBefore := time.Now().Add(2 * time.Hour).Format(time.RFC3339)
+	peers := newPeers(t)
+	peers.Add("127.0.0.1:4001", &peer{state: &api.ServiceState{Status: api.ServiceState_HEALTHY}})
+	peers.Add("127.0.0.1:4002", &peer{state: &api.ServiceState{Status: api.ServiceState_MAINTENANCE, NotBefore: notBefore}})
+	peers.Add("127.0.0.1:4003", nil)
+
+	conf := config.HealthConfig{
+		Enabled:         true,
+		Interval:        time.Minute,
+		Timeout:         5 * time.Second,
+		Concurrency:     2,
+		History:         2,
+		DangerThreshold: 3,
+		SaveInterval:    time.Hour,
+		Insecure:        true,
+	}
+
+	db, err := store.Open(storeconfig.StoreConfig{URL: "leveldb:///" + t.TempDir()})
+	require.NoError(t, err, "could not open leveldb store")
+	defer db.Close()
+
+	svc, err := health.New(conf, db, grpc.WithContextDialer(peers.Dialer))
+	require.NoError(t, err, "could not create health check monitor")
+	require.IsType(t, &health.Monitor{}, svc)
+
+	ctx := context.Background()
+	healthy := createVASP(t, db, "healthy.example.com", "127.0.0.1:4001", pb.VerificationState_VERIFIED)
+	maintenance := createVASP(t, db, "maintenance.example.com", "127.0.0.1:4002", pb.VerificationState_VERIFIED)
+	unimplemented := createVASP(t, db, "unimplemented.example.com", "127.0.0.1:4003", pb.VerificationState_VERIFIED)
+	offline := createVASP(t, db, "offline.example.com", "127.0.0.1:4004", pb.VerificationState_VERIFIED)
+	pending := createVASP(t, db, "pending.example.com", "127.0.0.1:4001", pb.VerificationState_PENDING_REVIEW)
+
+	// Run the health checks several times to escalate the offline VASP
+	for i := 0; i < 3; i++ {
+		require.NoError(t, svc.HealthCheck(ctx), "could not run health checks")
+	}
+
+	// A healthy peer should be checked every iteration but the record is only saved
+	// when the service status of the VASP changes or the save interval has elapsed
+	record := getHealthCheck(t, db, healthy, pb.ServiceState_HEALTHY)
+	require.Zero(t, record.Attempts)
+	require.NotEmpty(t, record.LastCheckedAt)
+	require.Equal(t, record.LastCheckedAt, record.LastHealthyAt)
+	require.Len(t, record.History, 1, "expected the record to only be saved when the status changes")
+	require.Equal(t, 3, peers.Calls("127.0.0.1:4001"), "expected only verified VASPs to be checked")
+
+	// A peer that asks not to be checked again should not be checked until not before
+	record = getHealthCheck(t, db, maintenance, pb.ServiceState_MAINTENANCE)
+	require.Equal(t, notBefore, record.NotBefore)
+	require.Empty(t, record.LastHealthyAt)
+	require.Len(t, record.History, 1)
+	require.Equal(t, 1, peers.Calls("127.0.0.1:4002"), "expected not before to be respected")
+
+	// A peer that does not implement the health service is reachable and healthy
+	record = getHealthCheck(t, db, unimplemented, pb.ServiceState_HEALTHY)
+	require.Zero(t, record.Attempts)
+	require.Empty(t, record.History[0].Error)
+
+	// An unreachable peer should escalate to danger after the threshold; the history
+	// is trimmed when the escalation is saved
+	record = getHealthCheck(t, db, offline, pb.ServiceState_DANGER)
+	require.Equal(t, uint32(3), record.Attempts)
+	require.Empty(t, record.LastHealthyAt)
+	require.Equal(t, pb.ServiceState_UNHEALTHY, record.History[0].Status)
+	require.Equal(t, pb.ServiceState_DANGER, record.History[1].Status)
+	require.NotEmpty(t, record.History[1].Error)
+
+	// VASPs that are not verified are not checked
+	vasp, err := db.RetrieveVASP(ctx, pending)
+	require.NoError(t, err)
+	require.Equal(t, pb.ServiceState_UNKNOWN, vasp.ServiceStatus)
+	record, err = models.GetHealthCheck(vasp)
+	require.NoError(t, err)
+	require.Nil(t, record)
+
+	// Once the offline VASP recovers its attempts should be reset
+	peers.Add("127.0.0.1:4004", &peer{state: &api.ServiceState{Status: api.ServiceState_UNKNOWN}})
+	require.NoError(t, svc.HealthCheck(ctx), "could not run health checks")
+	record = getHealthCheck(t, db, offline, pb.ServiceState_HEALTHY)
+	require.Zero(t, record.Attempts)
+	require.NotEmpty(t, record.LastHealthyAt)
+}
+
+func TestHealthCheckSaveInterval(t *testing.T) {
+	logger.Discard()
+	defer logger.ResetLogger()
+
+	peers := newPeers(t)
+	peers.Add("127.0.0.1:4001", &peer{state: &api.ServiceState{Status: api.ServiceState_HEALTHY}})
+
+	db, err := store.Open(storeconfig.StoreConfig{URL: "leveldb:///" + t.TempDir()})
+	require.NoError(t, err, "could not open leveldb store")
+	defer db.Close()
+
+	// With no save interval the record should be saved on every check
+	conf := config.HealthConfig{Enabled: true, Interval: time.Minute, Timeout: 5 * time.Second, Concurrency: 1, History: 3, Insecure: true}
+	svc, err := health.New(conf, db, grpc.WithContextDialer(peers.Dialer))
+	require.NoError(t, err, "could not create health check monitor")
+
+	healthy := createVASP(t, db, "healthy.example.com", "127.0.0.1:4001", pb.VerificationState_VERIFIED)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, svc.HealthCheck(context.Background()), "could not run health checks")
+	}
+
+	record := getHealthCheck(t, db, healthy, pb.ServiceState_HEALTHY)
+	require.Len(t, record.History, 3, "expected the record to be saved on every check")
+	require.Equal(t, record.History[2].Timestamp, record.LastCheckedAt)
+}
+
+func TestMonitorRun(t *testing.T) {
+	logger.Discard()
+	defer logger.ResetLogger()
+
+	db, err := store.Open(storeconfig.StoreConfig{URL: "leveldb:///" + t.TempDir()})
+	require.NoError(t, err, "could not open leveldb store")
+	defer db.Close()
+
+	conf := config.HealthConfig{Enabled: true, Interval: time.Hour, Timeout: time.Second, Concurrency: 1, History: 1, Insecure: true}
+	svc, err := health.New(conf, db)
+	require.NoError(t, err, "could not create health check monitor")
+
+	// Should be able to run and stop the monitor gracefully
+	var wg sync.WaitGroup
+	require.NoError(t, svc.Run(&wg), "could not run the monitor")
+	require.Error(t, svc.Run(&wg), "should not be able to run the monitor twice")
+	svc.Stop()
+	wg.Wait()
+
+	// Stopping the monitor again should not panic and the monitor should be able to restart
+	require.NotPanics(t, svc.Stop, "stop should not panic")
+	require.NoError(t, svc.Run(&wg), "could not restart the monitor")
+	svc.Stop()
+	wg.Wait()
+}
+
+func TestDisabled(t *testing.T) {
+	// Should be able to create a new disabled monitor with no dependencies
+	service, err := health.New(config.HealthConfig{Enabled: false}, nil)
+	require.NoError(t, err, "could not create a disabled health service with no config")
+	require.IsType(t, &health.Disabled{}, service, "expected the service to be disabled")
+
+	// Should be able to run and shutdown the service without blocking
+	var wg sync.WaitGroup
+	err = service.Run(&wg)
+	require.NoError(t, err, "could not run the disabled service")
+
+	// If the test times out it means that disabled isn't properly managing the wait group
+	require.NotPanics(t, service.Stop, "stop should not panic")
+	wg.Wait()
+
+	require.NoError(t, service.HealthCheck(context.Background()), "health check should be a no-op")
+}
+
+func createVASP(t *testing.T, db store.Store, commonName, endpoint string, state pb.VerificationState) string {
+	vasp := &pb.VASP{
+		CommonName:         commonName,
+		TrisaEndpoint:      endpoint,
+		VerificationStatus: state,
+	}
+
+	id, err := db.CreateVASP(context.Background(), vasp)
+	require.NoError(t, err, "could not create VASP")
+	return id
+}
+
+func getHealthCheck(t *testing.T, db store.Store, vaspID string, expected pb.ServiceState) *models.HealthCheckRecord {
+	vasp, err := db.RetrieveVASP(context.Background(), vaspID)
+	require.NoError(t, err, "could not retrieve VASP")
+	require.Equal(t, expected, vasp.ServiceStatus, "unexpected service status for %s", vasp.CommonName)
+
+	record, err := models.GetHealthCheck(vasp)
+	require.NoError(t, err, "could not get health check record")
+	require.NotNil(t, record, "expected a health check record")
+	require.Equal(t, expected, record.Status)
+	return record
+}
+
+// peers maps endpoints to bufconn listeners; endpoints without a listener are offline.
+type peers struct {
+	sync.Mutex
+	t         *testing.T
+	listeners map[string]*bufconn.GRPCListener
+	servers   map[string]*peer
+}
+
+func newPeers(t *testing.T) *peers {
+	return &peers{
+		t:         t,
+		listeners: make(map[string]*bufconn.GRPCListener),
+		servers:   make(map[string]*peer),
+	}
+}
+
+// Add a peer at the endpoint; if the peer is nil the endpoint does not implement the
+// TRISA health service.
+func (p *peers) Add(endpoint string, handler *peer) {
+	p.Lock()
+	defer p.Unlock()
+
+	srv := grpc.NewServer()
+	if handler != nil {
+		api.RegisterTRISAHealthServer(srv, handler)
+		p.servers[endpoint] = handler
+	}
+
+	sock := bufconn.New(endpoint)
+	go srv.Serve(sock.Listener)
+	p.listeners[endpoint] = sock
+
+	p.t.Cleanup(func() {
+		srv.Stop()
+		sock.Release()
+	})
+}
+
+func (p *peers) Calls(endpoint string) int {
+	p.Lock()
+	defer p.Unlock()
+	return p.servers[endpoint].Calls()
+}
+
+func (p *peers) Dialer(ctx context.Context, addr string) (net.Conn, error) {
+	p.Lock()
+	sock, ok := p.listeners[addr]
+	p.Unlock()
+
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	return sock.Dialer(ctx, addr)
+}
+
+type peer struct {
+	api.UnimplementedTRISAHealthServer
+	sync.Mutex
+	state *api.ServiceState
+	calls int
+}
+
+func (p *peer) Status(context.Context, *api.HealthCheck) (*api.ServiceState, error) {
+	p.Lock()
+	defer p.Unlock()
+	p.calls++
+	return p.state, nil
+}
+
+func (p *peer) Calls() int {
+	p.Lock()
+	defer p.Unlock()
+	return p.calls
+}
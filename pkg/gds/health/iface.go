@@ -0,0 +1,14 @@
+package health
+
+import (
+	"context"
+	"sync"
+)
+
+// Service defines the health check monitor go routine interface for outside users to
+// interact with the monitor directly.
+type Service interface {
+	Run(*sync.WaitGroup) error
+	Stop()
+	HealthCheck(context.Context) error
+}
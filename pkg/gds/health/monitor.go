@@ -0,0 +1,366 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/store"
+	"github.com/trisacrypto/directory/pkg/utils/sentry"
+	api "github.com/trisacrypto/trisa/pkg/trisa/api/v1beta1"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"github.com/trisacrypto/trisa/pkg/trisa/mtls"
+	"github.com/trisacrypto/trisa/pkg/trust"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// New creates a health check monitor that uses the directory service's mTLS
+// certificates to connect to the TRISA endpoints of verified VASPs. The dial options
+// are added to every connection and are primarily used to connect to test peers.
+func New(conf config.HealthConfig, db store.Store, opts ...grpc.DialOption) (_ Service, err error) {
+	// If not enabled return the disabled stub.
+	if !conf.Enabled {
+		return &Disabled{}, nil
+	}
+
+	monitor := &Monitor{
+		conf:    conf,
+		db:      db,
+		opts:    opts,
+		records: make(map[string]*models.HealthCheckRecord),
+		saved:   make(map[string]time.Time),
+	}
+
+	if !conf.Insecure {
+		var sz *trust.Serializer
+		if sz, err = trust.NewSerializer(false); err != nil {
+			return nil, err
+		}
+
+		if monitor.certs, err = sz.ReadFile(conf.Certs); err != nil {
+			return nil, fmt.Errorf("could not load health check certs and private key: %s", err)
+		}
+
+		if monitor.pool, err = sz.ReadPoolFile(conf.CertPool); err != nil {
+			return nil, fmt.Errorf("could not load health check public cert pool: %s", err)
+		}
+	}
+
+	return monitor, nil
+}
+
+// Monitor is a go routine that periodically calls the TRISA health check on the
+// endpoints of verified VASPs and records the service status of the VASP along with a
+// history of the latency of the checks so that the status can be reported to
+// counterparties and administrators.
+//
+// To avoid rewriting (and replicating) every VASP on every check, the health check
+// record is saved on the VASP when its service status changes and otherwise at most
+// every save interval so that the last check and latency history do not go stale; the
+// most recent record of each VASP is kept in memory between saves.
+type Monitor struct {
+	sync.Mutex
+	conf    config.HealthConfig
+	db      store.Store
+	certs   *trust.Provider
+	pool    trust.ProviderPool
+	opts    []grpc.DialOption
+	running sync.Mutex
+	stop    chan struct{}
+	records map[string]*models.HealthCheckRecord
+	saved   map[string]time.Time
+}
+
+// Compile time interface implementation check.
+var _ Service = &Monitor{}
+
+// Run starts the Monitor as a go routine under the provided waitgroup. For graceful
+// shutdown, the caller must invoke the Stop method to signal the Monitor routine to
+// stop and block on the waitgroup if provided.
+func (m *Monitor) Run(wg *sync.WaitGroup) error {
+	m.running.Lock()
+	defer m.running.Unlock()
+
+	if m.stop != nil {
+		return errors.New("health check monitor is already running")
+	}
+
+	if wg != nil {
+		wg.Add(1)
+	}
+
+	// The go routine only reads its own stop channel so that the field can be reset by
+	// Stop without racing the routine as it shuts down.
+	m.stop = make(chan struct{})
+	go func(stop <-chan struct{}) {
+		m.monitor(stop)
+		if wg != nil {
+			wg.Done()
+		}
+	}(m.stop)
+	return nil
+}
+
+// Stop signals the Monitor routine to shutdown. Stop is safe to call more than once
+// and if the Monitor is not running.
+// Note: This does not wait for the Monitor to stop and the caller should block on the
+// waitgroup passed to the Run method in order to implement a graceful shutdown.
+func (m *Monitor) Stop() {
+	m.running.Lock()
+	defer m.running.Unlock()
+
+	if m.stop != nil {
+		close(m.stop)
+		m.stop = nil
+	}
+}
+
+func (m *Monitor) monitor(stop <-chan struct{}) {
+	ticker := time.NewTicker(m.conf.Interval)
+	defer ticker.Stop()
+	log.Info().Dur("interval", m.conf.Interval).Int("concurrency", m.conf.Concurrency).Msg("health check monitor started")
+
+	// Check the endpoints on startup so that statuses are available before the first tick
+	ctx, cancel := m.context()
+	m.HealthCheck(ctx)
+	cancel()
+
+	for {
+		select {
+		case <-stop:
+			log.Info().Msg("health check monitor received stop signal")
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := m.context()
+		m.HealthCheck(ctx)
+		cancel()
+	}
+}
+
+// HealthCheck performs one iteration through the VASPs in the database and checks the
+// health of the TRISA endpoint of every verified VASP that is due for a check. Errors
+// checking individual VASPs are logged rather than returned.
+func (m *Monitor) HealthCheck(ctx context.Context) (err error) {
+	start := time.Now()
+	targets := make([]*pb.VASP, 0)
+
+	iter := m.db.ListVASPs(ctx)
+	for iter.Next() {
+		var vasp *pb.VASP
+		if vasp, err = iter.VASP(); err != nil {
+			sentry.Error(ctx).Err(err).Msg("could not parse VASP from database")
+			continue
+		}
+
+		if vasp.VerificationStatus != pb.VerificationState_VERIFIED || vasp.TrisaEndpoint == "" {
+			continue
+		}
+
+		if due, err := m.due(vasp, start); err != nil {
+			sentry.Warn(ctx).Err(err).Str("vasp", vasp.Id).Msg("could not determine when VASP is due for a health check")
+		} else if !due {
+			continue
+		}
+		targets = append(targets, vasp)
+	}
+
+	if err = iter.Error(); err != nil {
+		iter.Release()
+		sentry.Error(ctx).Err(err).Msg("could not iterate over VASPs")
+		return err
+	}
+	iter.Release()
+
+	// Check the endpoints concurrently with at most the configured number of checks
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, m.conf.Concurrency)
+	for _, vasp := range targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(vasp *pb.VASP) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := m.Check(ctx, vasp); err != nil {
+				sentry.Error(ctx).Err(err).Str("vasp", vasp.Id).Msg("could not record health check")
+			}
+		}(vasp)
+	}
+	wg.Wait()
+
+	log.Info().Int("checked", len(targets)).Dur("duration", time.Since(start)).Msg("health check complete")
+	return nil
+}
+
+// Check the health of the TRISA endpoint of the VASP and record the result on the VASP
+// if the service status of the VASP has changed or the record is due to be saved.
+func (m *Monitor) Check(ctx context.Context, vasp *pb.VASP) (err error) {
+	var record *models.HealthCheckRecord
+	if record, err = m.record(vasp); err != nil {
+		return err
+	}
+
+	// Send the previous attempts to the peer as described by the TRISA protocol
+	req := &api.HealthCheck{
+		Attempts:      record.Attempts,
+		LastCheckedAt: record.LastCheckedAt,
+	}
+
+	started := time.Now()
+	rep, err := m.probe(ctx, vasp.TrisaEndpoint, req)
+	entry := &models.HealthCheckEntry{
+		Timestamp: started.Format(time.RFC3339),
+		LatencyMs: time.Since(started).Milliseconds(),
+	}
+
+	switch {
+	case err == nil:
+		// The peer reports its own status; a peer that responds is at least healthy
+		entry.Status = pb.ServiceState(rep.Status)
+		if entry.Status == pb.ServiceState_UNKNOWN {
+			entry.Status = pb.ServiceState_HEALTHY
+		}
+		record.Attempts = 0
+		record.NotBefore = rep.NotBefore
+		record.NotAfter = rep.NotAfter
+	case status.Code(err) == codes.Unimplemented:
+		// The TRISAHealth service is optional, but the endpoint is reachable over mTLS
+		entry.Status = pb.ServiceState_HEALTHY
+		record.Attempts = 0
+		record.NotBefore, record.NotAfter = "", ""
+	default:
+		// Repeated failures escalate the status so that administrators are alerted
+		entry.Error = err.Error()
+		record.Attempts++
+		record.NotBefore, record.NotAfter = "", ""
+		if m.conf.DangerThreshold > 0 && record.Attempts >= m.conf.DangerThreshold {
+			entry.Status = pb.ServiceState_DANGER
+		} else {
+			entry.Status = pb.ServiceState_UNHEALTHY
+		}
+	}
+
+	record.Status = entry.Status
+	record.LastCheckedAt = entry.Timestamp
+	if entry.Status == pb.ServiceState_HEALTHY {
+		record.LastHealthyAt = entry.Timestamp
+	}
+
+	// Only the configured number of most recent results are kept in the history
+	record.History = append(record.History, entry)
+	if n := max(m.conf.History, 0); len(record.History) > n {
+		record.History = record.History[len(record.History)-n:]
+	}
+
+	m.Lock()
+	m.records[vasp.Id] = record
+	saved, ok := m.saved[vasp.Id]
+	m.Unlock()
+
+	// Save the record if the service status has changed or if it has not been saved
+	// since the monitor started or within the save interval
+	changed := vasp.ServiceStatus != entry.Status
+	if changed || !ok || started.Sub(saved) >= m.conf.SaveInterval {
+		// Reload the VASP so that changes made during the health check are not overwritten
+		if vasp, err = m.db.RetrieveVASP(ctx, vasp.Id); err != nil {
+			return err
+		}
+
+		if err = models.SetHealthCheck(vasp, record); err != nil {
+			return err
+		}
+
+		if err = m.db.UpdateVASP(ctx, vasp); err != nil {
+			return err
+		}
+
+		m.Lock()
+		m.saved[vasp.Id] = started
+		m.Unlock()
+	}
+
+	log.Debug().
+		Str("vasp", vasp.Id).
+		Str("endpoint", vasp.TrisaEndpoint).
+		Str("status", entry.Status.String()).
+		Int64("latency_ms", entry.LatencyMs).
+		Bool("changed", changed).
+		Msg("health check recorded")
+	return nil
+}
+
+// Returns a copy of the most recent health check record of the VASP, loading the record
+// from the VASP if it has not been checked since the monitor started.
+func (m *Monitor) record(vasp *pb.VASP) (record *models.HealthCheckRecord, err error) {
+	m.Lock()
+	cached, ok := m.records[vasp.Id]
+	m.Unlock()
+
+	if ok {
+		return proto.Clone(cached).(*models.HealthCheckRecord), nil
+	}
+
+	if record, err = models.GetHealthCheck(vasp); err != nil {
+		return nil, err
+	}
+
+	if record == nil {
+		record = &models.HealthCheckRecord{}
+	}
+	return record, nil
+}
+
+// Calls the TRISA health check on the endpoint using the directory service's mTLS
+// certificates unless the monitor is configured to be insecure.
+func (m *Monitor) probe(ctx context.Context, endpoint string, req *api.HealthCheck) (_ *api.ServiceState, err error) {
+	opts := make([]grpc.DialOption, 0, len(m.opts)+1)
+	if m.conf.Insecure {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		var creds grpc.DialOption
+		if creds, err = mtls.ClientCreds(endpoint, m.certs, m.pool); err != nil {
+			return nil, err
+		}
+		opts = append(opts, creds)
+	}
+	opts = append(opts, m.opts...)
+
+	var cc *grpc.ClientConn
+	if cc, err = grpc.NewClient(endpoint, opts...); err != nil {
+		return nil, err
+	}
+	defer cc.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, m.conf.Timeout)
+	defer cancel()
+	return api.NewTRISAHealthClient(cc).Status(ctx, req)
+}
+
+// A VASP is due for a health check unless the VASP asked not to be checked until later.
+func (m *Monitor) due(vasp *pb.VASP, now time.Time) (_ bool, err error) {
+	var record *models.HealthCheckRecord
+	if record, err = m.record(vasp); err != nil || record.NotBefore == "" {
+		return true, err
+	}
+
+	var notBefore time.Time
+	if notBefore, err = time.Parse(time.RFC3339, record.NotBefore); err != nil {
+		return true, err
+	}
+	return !now.Before(notBefore), nil
+}
+
+// The context for an iteration of health checks must complete before the next tick.
+func (m *Monitor) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), m.conf.Interval)
+}
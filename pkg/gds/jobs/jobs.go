@@ -0,0 +1,174 @@
+/*
+Package jobs implements a durable queue that processes the side effects of requests to
+the directory service, such as sending verification emails for a registration, in the
+background so that the request can return as soon as its records have been committed.
+Jobs are persisted in the store so that they survive restarts and are retried with an
+exponential backoff until they complete or the maximum number of attempts is reached.
+*/
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/store"
+	"github.com/trisacrypto/directory/pkg/utils/retry"
+	"github.com/trisacrypto/directory/pkg/utils/sentry"
+)
+
+// Handler processes a job of a specific type. If the handler returns an error the job
+// is retried, so handlers must use the completed steps of the job to avoid repeating
+// work, e.g. sending an email twice, that was completed by a previous attempt.
+type Handler func(ctx context.Context, job *models.Job) error
+
+// New creates a job queue that processes the jobs in the store.
+func New(conf config.JobsConfig, db store.Store) *Queue {
+	q := &Queue{
+		conf:     conf,
+		db:       db,
+		handlers: make(map[string]Handler),
+		policy: retry.Policy{
+			MaxAttempts: conf.MaxAttempts,
+			Backoff:     conf.Backoff,
+			MaxBackoff:  conf.MaxBackoff,
+		},
+	}
+	q.loop = retry.NewLoop("job queue", conf.Interval, q.Process)
+	return q
+}
+
+// Queue processes the jobs in the store that are due with the handler registered for
+// the type of the job. If the queue is enabled, jobs are processed by a go routine that
+// is notified when jobs are submitted and that periodically retries failed jobs. If the
+// queue is disabled, jobs are processed once when they are submitted and not retried.
+type Queue struct {
+	sync.Mutex
+	conf     config.JobsConfig
+	db       store.Store
+	handlers map[string]Handler
+	policy   retry.Policy
+	loop     *retry.Loop
+}
+
+// Handle registers the handler for the specified job type, replacing any previously
+// registered handler. Handlers must be registered before the queue is run.
+func (q *Queue) Handle(jobType string, handler Handler) {
+	q.handlers[jobType] = handler
+}
+
+// Run starts the Queue as a go routine under the provided waitgroup. For graceful
+// shutdown, the caller must invoke the Stop method to signal the Queue routine to stop
+// and block on the waitgroup if provided.
+func (q *Queue) Run(wg *sync.WaitGroup) error {
+	if !q.conf.Enabled {
+		log.Warn().Msg("job queue is disabled: jobs will be processed synchronously")
+		return nil
+	}
+
+	return q.loop.Run(wg)
+}
+
+// Stop signals the Queue routine to shutdown.
+// Note: This does not wait for the Queue to stop and the caller should block on the
+// waitgroup passed to the Run method in order to implement a graceful shutdown.
+func (q *Queue) Stop() {
+	q.loop.Stop()
+}
+
+// Submit a job that has been created in the store for processing. If the queue is
+// enabled the job is processed in the background, otherwise the job is processed
+// before Submit returns and the outcome is recorded on the job. An error is only
+// returned if the outcome could not be saved.
+func (q *Queue) Submit(ctx context.Context, job *models.Job) error {
+	if q.conf.Enabled {
+		q.loop.Notify()
+		return nil
+	}
+
+	q.Lock()
+	defer q.Unlock()
+	return q.execute(ctx, job)
+}
+
+// Process performs one iteration through the jobs in the store, executing every job
+// that is due and deleting finished jobs that are older than the retention period.
+// Errors executing individual jobs are logged rather than returned.
+func (q *Queue) Process(ctx context.Context) (err error) {
+	q.Lock()
+	defer q.Unlock()
+
+	now := time.Now()
+	processed, purged := 0, 0
+	for _, job := range q.db.ListJobs(ctx) {
+		if job.IsFinished() {
+			if retry.Expired(job.Finished, q.conf.Retention, now) {
+				if err = q.db.DeleteJob(ctx, job.Id); err != nil {
+					sentry.Error(ctx).Err(err).Str("job", job.Id).Msg("could not delete expired job")
+					continue
+				}
+				purged++
+			}
+			continue
+		}
+
+		if !job.Due(now) {
+			continue
+		}
+
+		if err = q.execute(ctx, job); err != nil {
+			sentry.Error(ctx).Err(err).Str("job", job.Id).Msg("could not save job")
+			continue
+		}
+		processed++
+	}
+
+	if processed > 0 || purged > 0 {
+		log.Debug().Int("processed", processed).Int("purged", purged).Dur("duration", time.Since(now)).Msg("job queue processed")
+	}
+	return nil
+}
+
+// Execute the job with the handler registered for its type and save the outcome.
+// NOTE: the caller must hold the lock to ensure that a job is not executed concurrently.
+func (q *Queue) execute(ctx context.Context, job *models.Job) error {
+	var err error
+	if handler, ok := q.handlers[job.Type]; ok {
+		err = handler(ctx, job)
+	} else {
+		err = fmt.Errorf("no handler registered for %q jobs", job.Type)
+	}
+
+	now := time.Now()
+	job.Attempts++
+	outcome, nextAttempt := q.policy.Attempt(job.Attempts, err, now)
+
+	// Jobs are not retried if the queue is disabled since there is no loop to retry them
+	if outcome == retry.Retrying && !q.conf.Enabled {
+		outcome, nextAttempt = retry.Failed, ""
+	}
+
+	job.NextAttempt = nextAttempt
+	switch outcome {
+	case retry.Succeeded:
+		job.Status = models.JobState_JOB_COMPLETED
+		job.LastError = ""
+		job.Finished = now.Format(time.RFC3339)
+		log.Debug().Str("job", job.Id).Str("type", job.Type).Uint32("attempts", job.Attempts).Msg("job completed")
+	case retry.Failed:
+		job.Status = models.JobState_JOB_FAILED
+		job.LastError = err.Error()
+		job.Finished = now.Format(time.RFC3339)
+		sentry.Error(ctx).Err(err).Str("job", job.Id).Str("type", job.Type).Str("vasp", job.Vasp).Int("attempts", int(job.Attempts)).Msg("job failed")
+	case retry.Retrying:
+		job.Status = models.JobState_JOB_RETRYING
+		job.LastError = err.Error()
+		sentry.Warn(ctx).Err(err).Str("job", job.Id).Str("type", job.Type).Str("vasp", job.Vasp).Int("attempts", int(job.Attempts)).Msg("job will be retried")
+	}
+
+	return q.db.UpdateJob(ctx, job)
+}
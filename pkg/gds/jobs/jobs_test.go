@@ -0,0 +1,213 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/gds/jobs"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/store"
+	storeconfig "github.com/trisacrypto/directory/pkg/store/config"
+	"github.com/trisacrypto/directory/pkg/utils/logger"
+)
+
+func TestProcess(t *testing.T) {
+	logger.Discard()
+	defer logger.ResetLogger()
+
+	db := openStore(t)
+	conf := config.JobsConfig{
+		Enabled:     true,
+		Interval:    time.Minute,
+		MaxAttempts: 3,
+		Backoff:     time.Minute,
+		MaxBackoff:  90 * time.Second,
+		Retention:   time.Hour,
+	}
+
+	// The handler fails until the final attempt, completing one step per attempt
+	calls := 0
+	queue := jobs.New(conf, db)
+	queue.Handle(models.JobRegistration, func(ctx context.Context, job *models.Job) error {
+		calls++
+		if !job.StepCompleted("first") {
+			job.CompleteStep("first")
+			return errors.New("first step is slow")
+		}
+		if job.Attempts < 2 {
+			return errors.New("second step is slow")
+		}
+		job.CompleteStep("second")
+		return nil
+	})
+
+	ctx := context.Background()
+	job := createJob(t, db, models.JobRegistration)
+	unknown := createJob(t, db, "unknown")
+
+	// The first attempt should be retried after the backoff
+	require.NoError(t, queue.Process(ctx))
+	job = retrieveJob(t, db, job.Id)
+	require.Equal(t, models.JobState_JOB_RETRYING, job.Status)
+	require.Equal(t, uint32(1), job.Attempts)
+	require.Equal(t, "first step is slow", job.LastError)
+	require.Equal(t, []string{"first"}, job.CompletedSteps)
+	requireNextAttempt(t, job, time.Minute)
+
+	// A job without a handler should be retried until it fails
+	unknown = retrieveJob(t, db, unknown.Id)
+	require.Equal(t, models.JobState_JOB_RETRYING, unknown.Status)
+	require.Equal(t, `no handler registered for "unknown" jobs`, unknown.LastError)
+
+	// Jobs should not be processed again until they are due
+	require.NoError(t, queue.Process(ctx))
+	require.Equal(t, 1, calls)
+
+	// The second attempt should double the backoff up to the max backoff
+	makeDue(t, db, job.Id)
+	require.NoError(t, queue.Process(ctx))
+	job = retrieveJob(t, db, job.Id)
+	require.Equal(t, models.JobState_JOB_RETRYING, job.Status)
+	require.Equal(t, uint32(2), job.Attempts)
+	require.Equal(t, "second step is slow", job.LastError)
+	requireNextAttempt(t, job, 90*time.Second)
+
+	// The third attempt should complete the job
+	makeDue(t, db, job.Id)
+	require.NoError(t, queue.Process(ctx))
+	job = retrieveJob(t, db, job.Id)
+	require.Equal(t, models.JobState_JOB_COMPLETED, job.Status)
+	require.Equal(t, uint32(3), job.Attempts)
+	require.Empty(t, job.LastError)
+	require.Empty(t, job.NextAttempt)
+	require.NotEmpty(t, job.Finished)
+	require.Equal(t, []string{"first", "second"}, job.CompletedSteps)
+	require.Equal(t, 3, calls)
+
+	// The job without a handler should fail after the max attempts
+	makeDue(t, db, unknown.Id)
+	require.NoError(t, queue.Process(ctx))
+	makeDue(t, db, unknown.Id)
+	require.NoError(t, queue.Process(ctx))
+	unknown = retrieveJob(t, db, unknown.Id)
+	require.Equal(t, models.JobState_JOB_FAILED, unknown.Status)
+	require.Equal(t, uint32(3), unknown.Attempts)
+	require.NotEmpty(t, unknown.Finished)
+
+	// Finished jobs should be deleted after the retention period
+	job.Finished = time.Now().Add(-2 * time.Hour).Format(time.RFC3339)
+	require.NoError(t, db.UpdateJob(ctx, job))
+	require.NoError(t, queue.Process(ctx))
+	_, err := db.RetrieveJob(ctx, job.Id)
+	require.Error(t, err, "expected expired job to be deleted")
+	retrieveJob(t, db, unknown.Id)
+	require.Equal(t, 3, calls)
+}
+
+func TestSubmit(t *testing.T) {
+	logger.Discard()
+	defer logger.ResetLogger()
+
+	db := openStore(t)
+	conf := config.JobsConfig{
+		Enabled:     false,
+		Interval:    time.Minute,
+		MaxAttempts: 3,
+		Backoff:     time.Minute,
+		MaxBackoff:  time.Hour,
+	}
+
+	processed := make(chan string, 2)
+	handler := func(ctx context.Context, job *models.Job) error {
+		processed <- job.Id
+		if job.Vasp == "fail" {
+			return errors.New("could not process job")
+		}
+		return nil
+	}
+
+	// If the queue is disabled, jobs are processed when submitted and not retried
+	ctx := context.Background()
+	queue := jobs.New(conf, db)
+	queue.Handle(models.JobRegistration, handler)
+	require.NoError(t, queue.Run(nil))
+
+	job := createJob(t, db, models.JobRegistration)
+	require.NoError(t, queue.Submit(ctx, job))
+	require.Equal(t, job.Id, <-processed)
+	require.Equal(t, models.JobState_JOB_COMPLETED, job.Status)
+	require.Equal(t, models.JobState_JOB_COMPLETED, retrieveJob(t, db, job.Id).Status)
+
+	job, err := models.NewJob(models.JobRegistration, "fail")
+	require.NoError(t, err)
+	_, err = db.CreateJob(ctx, job)
+	require.NoError(t, err)
+	require.NoError(t, queue.Submit(ctx, job))
+	require.Equal(t, job.Id, <-processed)
+	require.Equal(t, models.JobState_JOB_FAILED, job.Status)
+	require.Equal(t, "could not process job", retrieveJob(t, db, job.Id).LastError)
+
+	// If the queue is enabled, submitted jobs are processed in the background
+	conf.Enabled = true
+	queue = jobs.New(conf, db)
+	queue.Handle(models.JobRegistration, handler)
+
+	var wg sync.WaitGroup
+	require.NoError(t, queue.Run(&wg))
+	defer func() {
+		queue.Stop()
+		wg.Wait()
+	}()
+
+	job = createJob(t, db, models.JobRegistration)
+	require.NoError(t, queue.Submit(ctx, job))
+
+	select {
+	case id := <-processed:
+		require.Equal(t, job.Id, id)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "submitted job was not processed")
+	}
+
+	require.Eventually(t, func() bool {
+		return retrieveJob(t, db, job.Id).Status == models.JobState_JOB_COMPLETED
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func openStore(t *testing.T) store.Store {
+	db, err := store.Open(storeconfig.StoreConfig{URL: "leveldb:///" + t.TempDir()})
+	require.NoError(t, err, "could not open leveldb store")
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func createJob(t *testing.T, db store.Store, jobType string) *models.Job {
+	job, err := models.NewJob(jobType, "b5841869-105f-411c-8722-4045aad72717")
+	require.NoError(t, err)
+	_, err = db.CreateJob(context.Background(), job)
+	require.NoError(t, err)
+	return job
+}
+
+func retrieveJob(t *testing.T, db store.Store, id string) *models.Job {
+	job, err := db.RetrieveJob(context.Background(), id)
+	require.NoError(t, err)
+	return job
+}
+
+func makeDue(t *testing.T, db store.Store, id string) {
+	job := retrieveJob(t, db, id)
+	job.NextAttempt = time.Now().Add(-time.Second).Format(time.RFC3339)
+	require.NoError(t, db.UpdateJob(context.Background(), job))
+}
+
+func requireNextAttempt(t *testing.T, job *models.Job, backoff time.Duration) {
+	next, err := time.Parse(time.RFC3339, job.NextAttempt)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(backoff), next, 2*time.Second)
+}
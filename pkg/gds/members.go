@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -13,6 +14,8 @@ import (
 	api "github.com/trisacrypto/directory/pkg/gds/members/v1alpha1"
 	"github.com/trisacrypto/directory/pkg/models/v1"
 	"github.com/trisacrypto/directory/pkg/store"
+	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
+	"github.com/trisacrypto/directory/pkg/utils/interceptors"
 	"github.com/trisacrypto/directory/pkg/utils/sentry"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 	"github.com/trisacrypto/trisa/pkg/trisa/mtls"
@@ -378,9 +381,177 @@ func (s *Members) Details(ctx context.Context, in *api.DetailsRequest) (out *api
 			Phone: vasp.Contacts.Legal.Phone,
 		}
 	}
+
+	// Add the active certificates so that peers can pre-trust rotated certificates
+	if out.Certificates, err = GetMemberCertificates(vasp, time.Now()); err != nil {
+		sentry.Error(ctx).Err(err).Str("vasp_id", vasp.Id).Msg("could not retrieve active certificates from VASP record")
+		return nil, status.Error(codes.Internal, "could not retrieve member details")
+	}
+	out.RotationOverlap = s.svc.conf.CertMan.RotationOverlap.String()
 	return out, nil
 }
 
+// JobStatus returns the processing status of a job, e.g. the registration job whose ID
+// is returned in the header of the Register RPC. Only the status of the job is returned
+// since the steps and errors of the job may contain details about the VASP's contacts.
+func (s *Members) JobStatus(ctx context.Context, in *api.JobStatusRequest) (out *api.JobStatusReply, err error) {
+	if in.JobId == "" || in.VaspId == "" {
+		return nil, status.Error(codes.InvalidArgument, "job id and vasp id are required")
+	}
+
+	// The job must belong to the specified VASP so that job IDs alone cannot be used to
+	// discover the status of other registrations.
+	var job *models.Job
+	if job, err = s.db.RetrieveJob(ctx, in.JobId); err != nil || job.Vasp != in.VaspId {
+		if err != nil && !errors.Is(err, storeerrors.ErrEntityNotFound) {
+			sentry.Error(ctx).Err(err).Str("job", in.JobId).Msg("could not retrieve job")
+			return nil, status.Error(codes.Internal, "could not retrieve job status")
+		}
+		return nil, status.Error(codes.NotFound, "requested job not found")
+	}
+
+	return &api.JobStatusReply{
+		JobId:       job.Id,
+		VaspId:      job.Vasp,
+		Type:        job.Type,
+		Status:      strings.ToLower(strings.TrimPrefix(job.Status.String(), "JOB_")),
+		Attempts:    job.Attempts,
+		NextAttempt: job.NextAttempt,
+		Created:     job.Created,
+		Modified:    job.Modified,
+		Finished:    job.Finished,
+	}, nil
+}
+
+// DomainChallenges returns the domain control validation challenges of the latest
+// certificate request of the VASP so that the VASP can complete the challenges before
+// its certificates are issued. The challenge tokens are not secret since they must be
+// published in DNS or served from the domain to complete the challenge.
+func (s *Members) DomainChallenges(ctx context.Context, in *api.DomainChallengesRequest) (out *api.DomainChallengesReply, err error) {
+	if in.VaspId == "" {
+		return nil, status.Error(codes.InvalidArgument, "vasp id is required")
+	}
+
+	var vasp *pb.VASP
+	if vasp, err = s.db.RetrieveVASP(ctx, in.VaspId); err != nil {
+		sentry.Warn(ctx).Err(err).Str("vasp_id", in.VaspId).Msg("VASP not found")
+		return nil, status.Error(codes.NotFound, "requested VASP not found")
+	}
+
+	out = &api.DomainChallengesReply{VaspId: vasp.Id}
+	if out.CertificateRequest, err = models.GetLatestCertReqID(vasp); err != nil {
+		sentry.Error(ctx).Err(err).Str("vasp_id", vasp.Id).Msg("could not retrieve certificate request ID from VASP record")
+		return nil, status.Error(codes.Internal, "could not retrieve domain challenges")
+	}
+
+	if out.CertificateRequest == "" {
+		return out, nil
+	}
+
+	// Finished certificate requests may have been archived and no longer need challenges
+	var certreq *models.CertificateRequest
+	if certreq, err = s.db.RetrieveCertReq(ctx, out.CertificateRequest); err != nil {
+		if errors.Is(err, storeerrors.ErrEntityNotFound) {
+			return out, nil
+		}
+		sentry.Error(ctx).Err(err).Str("certreq", out.CertificateRequest).Msg("could not retrieve certificate request")
+		return nil, status.Error(codes.Internal, "could not retrieve domain challenges")
+	}
+
+	for _, challenge := range certreq.Challenges {
+		out.Challenges = append(out.Challenges, &api.DomainChallenge{
+			Domain:      challenge.Domain,
+			Token:       challenge.Token,
+			Status:      strings.ToLower(strings.TrimPrefix(challenge.Status.String(), "CHALLENGE_")),
+			DnsRecord:   challenge.DNSRecord(),
+			DnsValue:    challenge.DNSValue(),
+			HttpPath:    challenge.HTTPPath(),
+			ValidatedBy: challenge.ValidatedBy,
+			Attempts:    challenge.Attempts,
+			Error:       challenge.Error,
+			LastChecked: challenge.LastChecked,
+			Validated:   challenge.Validated,
+		})
+	}
+	return out, nil
+}
+
+// SubmitCSR stores a certificate signing request for a new key pair on the VASP record
+// so that the next certificate reissuance issues the certificates for the new public
+// key. The request must be made with the current identity certificates of the VASP
+// so that other members cannot replace the key pair of the VASP; requests without a
+// peer certificate are rejected even if the members server is insecure.
+func (s *Members) SubmitCSR(ctx context.Context, in *api.SubmitCSRRequest) (out *api.SubmitCSRReply, err error) {
+	if in.VaspId == "" {
+		return nil, status.Error(codes.InvalidArgument, "vasp id is required")
+	}
+
+	if in.Csr == "" {
+		return nil, status.Error(codes.InvalidArgument, "certificate signing request is required")
+	}
+
+	var vasp *pb.VASP
+	if vasp, err = s.db.RetrieveVASP(ctx, in.VaspId); err != nil {
+		sentry.Warn(ctx).Err(err).Str("vasp_id", in.VaspId).Msg("VASP not found")
+		return nil, status.Error(codes.NotFound, "requested VASP not found")
+	}
+
+	var peer *interceptors.PeerInfo
+	if peer, err = interceptors.PeerFromTLS(ctx); err != nil || peer == nil || peer.Name == nil {
+		sentry.Warn(ctx).Err(err).Str("vasp_id", vasp.Id).Msg("could not identify peer from mTLS certificates")
+		return nil, status.Error(codes.Unauthenticated, "mTLS identity certificates are required")
+	}
+
+	if !strings.EqualFold(peer.Name.CommonName, vasp.CommonName) {
+		sentry.Warn(ctx).Str("vasp_id", vasp.Id).Str("peer", peer.Name.CommonName).Msg("peer attempted to submit a CSR for another VASP")
+		return nil, status.Error(codes.PermissionDenied, "certificate signing requests can only be submitted by the VASP")
+	}
+
+	if vasp.VerificationStatus != pb.VerificationState_VERIFIED || vasp.IdentityCertificate == nil {
+		return nil, status.Error(codes.FailedPrecondition, "certificates can only be reissued for verified VASPs")
+	}
+
+	out = &api.SubmitCSRReply{VaspId: vasp.Id, CommonName: vasp.CommonName}
+	if out.DnsNames, err = models.ValidateVASPCSR([]byte(in.Csr), vasp); err != nil {
+		sentry.Warn(ctx).Err(err).Str("vasp_id", vasp.Id).Msg("invalid certificate signing request")
+		return nil, status.Errorf(codes.InvalidArgument, "invalid certificate signing request: %s", err)
+	}
+
+	if err = models.SetReissuanceCSR(vasp, in.Csr); err != nil {
+		sentry.Error(ctx).Err(err).Str("vasp_id", vasp.Id).Msg("could not set reissuance CSR on VASP record")
+		return nil, status.Error(codes.Internal, "could not submit certificate signing request")
+	}
+
+	if err = s.db.UpdateVASP(ctx, vasp); err != nil {
+		sentry.Error(ctx).Err(err).Str("vasp_id", vasp.Id).Msg("could not update VASP record")
+		return nil, status.Error(codes.Internal, "could not submit certificate signing request")
+	}
+
+	log.Info().Str("vasp_id", vasp.Id).Msg("certificate signing request submitted for reissuance")
+	return out, nil
+}
+
+// GetMemberCertificates is a helper function to construct the active certificates of a
+// VASP member that peers should trust at the specified time.
+func GetMemberCertificates(vasp *pb.VASP, now time.Time) (_ []*api.ActiveCertificate, err error) {
+	var active []*models.ActiveCertificate
+	if active, err = models.GetActiveCertificates(vasp, now); err != nil {
+		return nil, err
+	}
+
+	certs := make([]*api.ActiveCertificate, 0, len(active))
+	for _, cert := range active {
+		certs = append(certs, &api.ActiveCertificate{
+			Usage:       cert.Usage,
+			Primary:     cert.Primary,
+			NotBefore:   cert.NotBefore,
+			NotAfter:    cert.NotAfter,
+			Certificate: cert.Details,
+		})
+	}
+	return certs, nil
+}
+
 // GetVASPMember is a helper function to construct a VASPMember from a VASP record.
 func GetVASPMember(vasp *pb.VASP) *api.VASPMember {
 	var err error
@@ -397,6 +568,7 @@ func GetVASPMember(vasp *pb.VASP) *api.VASPMember {
 		Status:              vasp.VerificationStatus,
 		FirstListed:         vasp.FirstListed,
 		LastUpdated:         vasp.LastUpdated,
+		ServiceStatus:       vasp.ServiceStatus,
 	}
 
 	// Try to add the name information
@@ -409,5 +581,13 @@ func GetVASPMember(vasp *pb.VASP) *api.VASPMember {
 		info.Country = vasp.Entity.CountryOfRegistration
 	}
 
+	// Add the time of the last health check of the TRISA endpoint if available
+	var health *models.HealthCheckRecord
+	if health, err = models.GetHealthCheck(vasp); err != nil {
+		sentry.Error(nil).Err(err).Str("vasp_id", vasp.Id).Msg("could not retrieve health check from VASP record")
+	} else if health != nil {
+		info.LastHealthCheck = health.LastCheckedAt
+	}
+
 	return info
 }
@@ -19,9 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TRISAMembers_List_FullMethodName    = "/gds.members.v1alpha1.TRISAMembers/List"
-	TRISAMembers_Summary_FullMethodName = "/gds.members.v1alpha1.TRISAMembers/Summary"
-	TRISAMembers_Details_FullMethodName = "/gds.members.v1alpha1.TRISAMembers/Details"
+	TRISAMembers_List_FullMethodName             = "/gds.members.v1alpha1.TRISAMembers/List"
+	TRISAMembers_Summary_FullMethodName          = "/gds.members.v1alpha1.TRISAMembers/Summary"
+	TRISAMembers_Details_FullMethodName          = "/gds.members.v1alpha1.TRISAMembers/Details"
+	TRISAMembers_JobStatus_FullMethodName        = "/gds.members.v1alpha1.TRISAMembers/JobStatus"
+	TRISAMembers_DomainChallenges_FullMethodName = "/gds.members.v1alpha1.TRISAMembers/DomainChallenges"
+	TRISAMembers_SubmitCSR_FullMethodName        = "/gds.members.v1alpha1.TRISAMembers/SubmitCSR"
 )
 
 // TRISAMembersClient is the client API for TRISAMembers service.
@@ -41,6 +44,12 @@ type TRISAMembersClient interface {
 	Summary(ctx context.Context, in *SummaryRequest, opts ...grpc.CallOption) (*SummaryReply, error)
 	// Get details for a VASP member in the Directory Service.
 	Details(ctx context.Context, in *DetailsRequest, opts ...grpc.CallOption) (*MemberDetails, error)
+	// Get the status of the job that processes a registration in the Directory Service.
+	JobStatus(ctx context.Context, in *JobStatusRequest, opts ...grpc.CallOption) (*JobStatusReply, error)
+	// Get the domain control validation challenges of the latest certificate request.
+	DomainChallenges(ctx context.Context, in *DomainChallengesRequest, opts ...grpc.CallOption) (*DomainChallengesReply, error)
+	// Submit a certificate signing request for the next reissuance of the certificates.
+	SubmitCSR(ctx context.Context, in *SubmitCSRRequest, opts ...grpc.CallOption) (*SubmitCSRReply, error)
 }
 
 type tRISAMembersClient struct {
@@ -81,6 +90,36 @@ func (c *tRISAMembersClient) Details(ctx context.Context, in *DetailsRequest, op
 	return out, nil
 }
 
+func (c *tRISAMembersClient) JobStatus(ctx context.Context, in *JobStatusRequest, opts ...grpc.CallOption) (*JobStatusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JobStatusReply)
+	err := c.cc.Invoke(ctx, TRISAMembers_JobStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tRISAMembersClient) DomainChallenges(ctx context.Context, in *DomainChallengesRequest, opts ...grpc.CallOption) (*DomainChallengesReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DomainChallengesReply)
+	err := c.cc.Invoke(ctx, TRISAMembers_DomainChallenges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tRISAMembersClient) SubmitCSR(ctx context.Context, in *SubmitCSRRequest, opts ...grpc.CallOption) (*SubmitCSRReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitCSRReply)
+	err := c.cc.Invoke(ctx, TRISAMembers_SubmitCSR_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TRISAMembersServer is the server API for TRISAMembers service.
 // All implementations must embed UnimplementedTRISAMembersServer
 // for forward compatibility.
@@ -98,6 +137,12 @@ type TRISAMembersServer interface {
 	Summary(context.Context, *SummaryRequest) (*SummaryReply, error)
 	// Get details for a VASP member in the Directory Service.
 	Details(context.Context, *DetailsRequest) (*MemberDetails, error)
+	// Get the status of the job that processes a registration in the Directory Service.
+	JobStatus(context.Context, *JobStatusRequest) (*JobStatusReply, error)
+	// Get the domain control validation challenges of the latest certificate request.
+	DomainChallenges(context.Context, *DomainChallengesRequest) (*DomainChallengesReply, error)
+	// Submit a certificate signing request for the next reissuance of the certificates.
+	SubmitCSR(context.Context, *SubmitCSRRequest) (*SubmitCSRReply, error)
 	mustEmbedUnimplementedTRISAMembersServer()
 }
 
@@ -117,6 +162,15 @@ func (UnimplementedTRISAMembersServer) Summary(context.Context, *SummaryRequest)
 func (UnimplementedTRISAMembersServer) Details(context.Context, *DetailsRequest) (*MemberDetails, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Details not implemented")
 }
+func (UnimplementedTRISAMembersServer) JobStatus(context.Context, *JobStatusRequest) (*JobStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JobStatus not implemented")
+}
+func (UnimplementedTRISAMembersServer) DomainChallenges(context.Context, *DomainChallengesRequest) (*DomainChallengesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DomainChallenges not implemented")
+}
+func (UnimplementedTRISAMembersServer) SubmitCSR(context.Context, *SubmitCSRRequest) (*SubmitCSRReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitCSR not implemented")
+}
 func (UnimplementedTRISAMembersServer) mustEmbedUnimplementedTRISAMembersServer() {}
 func (UnimplementedTRISAMembersServer) testEmbeddedByValue()                      {}
 
@@ -192,6 +246,60 @@ func _TRISAMembers_Details_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TRISAMembers_JobStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TRISAMembersServer).JobStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TRISAMembers_JobStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TRISAMembersServer).JobStatus(ctx, req.(*JobStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TRISAMembers_DomainChallenges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DomainChallengesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TRISAMembersServer).DomainChallenges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TRISAMembers_DomainChallenges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TRISAMembersServer).DomainChallenges(ctx, req.(*DomainChallengesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TRISAMembers_SubmitCSR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitCSRRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TRISAMembersServer).SubmitCSR(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TRISAMembers_SubmitCSR_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TRISAMembersServer).SubmitCSR(ctx, req.(*SubmitCSRRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TRISAMembers_ServiceDesc is the grpc.ServiceDesc for TRISAMembers service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -211,6 +319,18 @@ var TRISAMembers_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Details",
 			Handler:    _TRISAMembers_Details_Handler,
 		},
+		{
+			MethodName: "JobStatus",
+			Handler:    _TRISAMembers_JobStatus_Handler,
+		},
+		{
+			MethodName: "DomainChallenges",
+			Handler:    _TRISAMembers_DomainChallenges_Handler,
+		},
+		{
+			MethodName: "SubmitCSR",
+			Handler:    _TRISAMembers_SubmitCSR_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "gds/members/v1alpha1/members.proto",
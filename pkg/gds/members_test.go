@@ -2,10 +2,22 @@ package gds_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"time"
 
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 	members "github.com/trisacrypto/directory/pkg/gds/members/v1alpha1"
+	"github.com/trisacrypto/directory/pkg/models/v1"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -165,6 +177,17 @@ func (s *gdsTestSuite) TestMembersDetails() {
 	require.True(proto.Equal(charlie.Entity, out.LegalPerson), "VASP legal person mismatch")
 	require.True(proto.Equal(charlie.Trixo, out.Trixo), "VASP trixo form mismatch")
 
+	// Check that the active certificates and rotation overlap are returned
+	active, err := models.GetActiveCertificates(charlie, time.Now())
+	require.NoError(err)
+	require.Len(out.Certificates, len(active), "active certificates mismatch")
+	for i, cert := range active {
+		require.Equal(cert.Usage, out.Certificates[i].Usage)
+		require.Equal(cert.Primary, out.Certificates[i].Primary)
+		require.True(proto.Equal(cert.Details, out.Certificates[i].Certificate), "active certificate mismatch")
+	}
+	require.Equal(s.svc.GetConf().CertMan.RotationOverlap.String(), out.RotationOverlap)
+
 	// Check contacts return is correct
 	contacts := []struct{
 		expected *pb.Contact
@@ -199,3 +222,196 @@ func (s *gdsTestSuite) TestMembersDetails() {
 
 	require.Greater(nContacts, 0, "charlie fixture has no contact data")
 }
+
+func (s *gdsTestSuite) TestMembersJobStatus() {
+	s.LoadFullFixtures()
+	s.SetupMembers()
+	require := s.Require()
+	ctx := context.Background()
+
+	// Create a registration job for the charlie VASP
+	charlie, err := s.fixtures.GetVASP("charliebank")
+	require.NoError(err, "could not get charliebank VASP")
+	job, err := models.NewJob(models.JobRegistration, charlie.Id)
+	require.NoError(err)
+	job.CompleteStep("verify_contact:technical@example.com")
+	job.LastError = "could not send 1 verify contact emails"
+	_, err = s.svc.GetStore().CreateJob(ctx, job)
+	require.NoError(err)
+	defer s.svc.GetStore().DeleteJob(ctx, job.Id)
+
+	// Start the gRPC client.
+	require.NoError(s.grpc.Connect(ctx))
+	defer s.grpc.Close()
+	client := members.NewTRISAMembersClient(s.grpc.Conn)
+	require.NotNil(client)
+
+	// Both the job and the VASP must be specified
+	_, err = client.JobStatus(ctx, &members.JobStatusRequest{JobId: job.Id})
+	s.StatusError(err, codes.InvalidArgument, "job id and vasp id are required")
+
+	// Test with a non-existent job
+	_, err = client.JobStatus(ctx, &members.JobStatusRequest{JobId: "invalid", VaspId: charlie.Id})
+	s.StatusError(err, codes.NotFound, "requested job not found")
+
+	// The job should not be found for a different VASP
+	_, err = client.JobStatus(ctx, &members.JobStatusRequest{JobId: job.Id, VaspId: "b5841869-105f-411c-8722-4045aad72717"})
+	s.StatusError(err, codes.NotFound, "requested job not found")
+
+	// Test with a valid job
+	out, err := client.JobStatus(ctx, &members.JobStatusRequest{JobId: job.Id, VaspId: charlie.Id})
+	require.NoError(err, "job status request failed")
+	expected := &members.JobStatusReply{
+		JobId:       job.Id,
+		VaspId:      charlie.Id,
+		Type:        models.JobRegistration,
+		Status:      "queued",
+		NextAttempt: job.NextAttempt,
+		Created:     job.Created,
+		Modified:    job.Modified,
+	}
+	require.True(proto.Equal(expected, out), "job status mismatch")
+}
+
+func (s *gdsTestSuite) TestMembersDomainChallenges() {
+	s.LoadFullFixtures()
+	s.SetupMembers()
+	defer s.ResetFixtures()
+	require := s.Require()
+	ctx := context.Background()
+
+	// Create domain challenges on the latest certificate request of the echo VASP
+	echo, err := s.fixtures.GetVASP("echo")
+	require.NoError(err, "could not get echo VASP")
+	certreqID, err := models.GetLatestCertReqID(echo)
+	require.NoError(err)
+	require.NotEmpty(certreqID, "echo fixture has no certificate requests")
+
+	db := s.svc.GetStore()
+	certreq, err := db.RetrieveCertReq(ctx, certreqID)
+	require.NoError(err)
+	require.NoError(models.CreateDomainChallenges(certreq))
+	certreq.Challenges[0].Status = models.DomainChallengeState_CHALLENGE_VALID
+	certreq.Challenges[0].ValidatedBy = models.ChallengeDNS01
+	certreq.Challenges[0].Attempts = 1
+	require.NoError(db.UpdateCertReq(ctx, certreq))
+
+	// Start the gRPC client.
+	require.NoError(s.grpc.Connect(ctx))
+	defer s.grpc.Close()
+	client := members.NewTRISAMembersClient(s.grpc.Conn)
+	require.NotNil(client)
+
+	// The VASP must be specified
+	_, err = client.DomainChallenges(ctx, &members.DomainChallengesRequest{})
+	s.StatusError(err, codes.InvalidArgument, "vasp id is required")
+
+	// Test with a non-existent VASP
+	_, err = client.DomainChallenges(ctx, &members.DomainChallengesRequest{VaspId: "b5841869-105f-411c-8722-4045aad72717"})
+	s.StatusError(err, codes.NotFound, "requested VASP not found")
+
+	// Test with a valid VASP
+	out, err := client.DomainChallenges(ctx, &members.DomainChallengesRequest{VaspId: echo.Id})
+	require.NoError(err, "domain challenges request failed")
+	require.Equal(echo.Id, out.VaspId)
+	require.Equal(certreqID, out.CertificateRequest)
+	require.Len(out.Challenges, len(certreq.Challenges))
+
+	for i, challenge := range certreq.Challenges {
+		require.Equal(challenge.Domain, out.Challenges[i].Domain)
+		require.Equal(challenge.Token, out.Challenges[i].Token)
+		require.Equal(challenge.DNSRecord(), out.Challenges[i].DnsRecord)
+		require.Equal(challenge.DNSValue(), out.Challenges[i].DnsValue)
+		require.Equal(challenge.HTTPPath(), out.Challenges[i].HttpPath)
+	}
+
+	require.Equal("valid", out.Challenges[0].Status)
+	require.Equal(models.ChallengeDNS01, out.Challenges[0].ValidatedBy)
+	require.Equal(uint32(1), out.Challenges[0].Attempts)
+	require.Equal("pending", out.Challenges[1].Status)
+}
+
+func (s *gdsTestSuite) TestMembersSubmitCSR() {
+	s.LoadFullFixtures()
+	s.SetupMembers()
+	defer s.ResetFixtures()
+	require := s.Require()
+	ctx := context.Background()
+
+	echo, err := s.fixtures.GetVASP("echo")
+	require.NoError(err, "could not get echo VASP")
+	require.NotEqual(pb.VerificationState_VERIFIED, echo.VerificationStatus, "echo fixture should not be verified")
+	endpoint, _, err := net.SplitHostPort(echo.TrisaEndpoint)
+	require.NoError(err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err, "could not generate private key")
+
+	// Start the gRPC client.
+	require.NoError(s.grpc.Connect(ctx))
+	defer s.grpc.Close()
+	client := members.NewTRISAMembersClient(s.grpc.Conn)
+	require.NotNil(client)
+
+	// The VASP and the CSR must be specified
+	_, err = client.SubmitCSR(ctx, &members.SubmitCSRRequest{Csr: createCSR(s.T(), key, echo.CommonName)})
+	s.StatusError(err, codes.InvalidArgument, "vasp id is required")
+
+	_, err = client.SubmitCSR(ctx, &members.SubmitCSRRequest{VaspId: echo.Id})
+	s.StatusError(err, codes.InvalidArgument, "certificate signing request is required")
+
+	// Test with a non-existent VASP
+	_, err = client.SubmitCSR(ctx, &members.SubmitCSRRequest{VaspId: "b5841869-105f-411c-8722-4045aad72717", Csr: createCSR(s.T(), key, echo.CommonName)})
+	s.StatusError(err, codes.NotFound, "requested VASP not found")
+
+	// The request must be made with the identity certificates of the VASP even though
+	// the members server is insecure in tests
+	_, err = client.SubmitCSR(ctx, &members.SubmitCSRRequest{VaspId: echo.Id, Csr: createCSR(s.T(), key, echo.CommonName)})
+	s.StatusError(err, codes.Unauthenticated, "mTLS identity certificates are required")
+
+	// Other members cannot submit a CSR for the VASP
+	svc := s.svc.GetMembers()
+	_, err = svc.SubmitCSR(peerContext("attacker.example.com"), &members.SubmitCSRRequest{VaspId: echo.Id, Csr: createCSR(s.T(), key, echo.CommonName)})
+	s.StatusError(err, codes.PermissionDenied, "certificate signing requests can only be submitted by the VASP")
+
+	// Certificates can only be reissued for verified VASPs
+	peerCtx := peerContext(echo.CommonName)
+	_, err = svc.SubmitCSR(peerCtx, &members.SubmitCSRRequest{VaspId: echo.Id, Csr: createCSR(s.T(), key, echo.CommonName)})
+	s.StatusError(err, codes.FailedPrecondition, "certificates can only be reissued for verified VASPs")
+
+	db := s.svc.GetStore()
+	echo.VerificationStatus = pb.VerificationState_VERIFIED
+	echo.IdentityCertificate = &pb.Certificate{SerialNumber: []byte("echo"), NotAfter: time.Now().AddDate(0, 0, 7).Format(time.RFC3339)}
+	require.NoError(db.UpdateVASP(ctx, echo))
+
+	// The CSR must be for the names of the VASP
+	_, err = svc.SubmitCSR(peerCtx, &members.SubmitCSRRequest{VaspId: echo.Id, Csr: createCSR(s.T(), key, echo.CommonName, "attacker.example.com")})
+	require.Error(err, "a CSR with unregistered dns names should be rejected")
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	// A valid CSR is stored for the next reissuance
+	csr := createCSR(s.T(), key, echo.CommonName, endpoint)
+	out, err := svc.SubmitCSR(peerCtx, &members.SubmitCSRRequest{VaspId: echo.Id, Csr: csr})
+	require.NoError(err, "submit csr request failed")
+	require.Equal(echo.Id, out.VaspId)
+	require.Equal(echo.CommonName, out.CommonName)
+
+	v, err := db.RetrieveVASP(ctx, echo.Id)
+	require.NoError(err)
+	pending, err := models.GetReissuanceCSR(v)
+	require.NoError(err)
+	require.Equal(csr, pending)
+}
+
+// peerContext returns a context for calling the members service directly as a peer that
+// connected with mTLS certificates issued to the common name.
+func peerContext(commonName string) context.Context {
+	chain := []*x509.Certificate{
+		{Subject: pkix.Name{CommonName: commonName}},
+		{Subject: pkix.Name{CommonName: "TRISA Test CA"}},
+	}
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr:     &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 4000},
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{chain}}},
+	})
+}
@@ -9,7 +9,10 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/trisacrypto/directory/pkg/gds/certman"
 	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/gds/duplicates"
 	"github.com/trisacrypto/directory/pkg/gds/emails"
+	"github.com/trisacrypto/directory/pkg/gds/health"
+	"github.com/trisacrypto/directory/pkg/gds/jobs"
 	"github.com/trisacrypto/directory/pkg/gds/secrets"
 	"github.com/trisacrypto/directory/pkg/gds/tokens"
 	"github.com/trisacrypto/directory/pkg/sectigo"
@@ -55,6 +58,11 @@ func NewMock(conf config.Config, trtlConn *grpc.ClientConn) (s *Service, err err
 		}
 	}
 
+	// The job queue must be created before the GDS server registers its job handlers.
+	svc.jobs = jobs.New(conf.Jobs, svc.db)
+	svc.outbox = emails.NewOutbox(conf.Outbox, svc.db, svc.email)
+	svc.email.SetResolver(emails.NewResolver(svc.db, svc.secret))
+
 	if svc.gds, err = NewGDS(svc); err != nil {
 		return nil, err
 	}
@@ -83,6 +91,12 @@ func NewMock(conf config.Config, trtlConn *grpc.ClientConn) (s *Service, err err
 		return nil, err
 	}
 
+	if svc.health, err = health.New(conf.Health, svc.db); err != nil {
+		return nil, err
+	}
+
+	svc.duplicates = duplicates.New(conf.Duplicates, svc.db)
+
 	return svc, nil
 }
 
@@ -134,7 +148,9 @@ func MockConfig() config.Config {
 			Enabled:            true,
 			RequestInterval:    24 * time.Hour,
 			ReissuanceInterval: 24 * time.Hour,
+			RotationOverlap:    30 * 24 * time.Hour,
 			Storage:            "testdata/certs",
+			Authority:          config.AuthoritySectigo,
 			Sectigo: sectigo.Config{
 				Username:    "foo",
 				Password:    "supersecretsquirrel",
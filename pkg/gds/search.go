@@ -0,0 +1,138 @@
+package gds
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/utils/sentry"
+	api "github.com/trisacrypto/trisa/pkg/trisa/gds/api/v1beta1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// The TRISA SearchRequest and SearchReply messages do not have pagination fields, so
+// search pagination parameters are sent as gRPC request metadata and the next page
+// token is returned in the response header metadata.
+const (
+	MetadataPageSize      = "page_size"
+	MetadataPageToken     = "page_token"
+	MetadataNextPageToken = "next_page_token"
+)
+
+// searchCursor loads the page cursor for a search request from the incoming metadata.
+// If a page size is not specified, the search is not paginated so that clients that do
+// not send pagination metadata receive all of the results. If a page token is provided,
+// the page size and search query must not have changed since the token was issued.
+func searchCursor(ctx context.Context, in *api.SearchRequest) (cursor *models.PageCursor, err error) {
+	var pageSize int32
+	var pageToken string
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get(MetadataPageSize); len(vals) > 0 && vals[0] != "" {
+			var size int64
+			if size, err = strconv.ParseInt(vals[0], 10, 32); err != nil || size < 0 {
+				log.Debug().Str("page_size", vals[0]).Msg("invalid search request: could not parse page size")
+				return nil, status.Error(codes.InvalidArgument, "invalid page size")
+			}
+
+			pageSize = int32(size)
+		}
+
+		if vals := md.Get(MetadataPageToken); len(vals) > 0 {
+			pageToken = vals[0]
+		}
+	}
+
+	var query string
+	if query, err = searchDigest(in); err != nil {
+		sentry.Error(ctx).Err(err).Msg("could not compute search query digest")
+		return nil, status.Error(codes.Internal, "could not process search request")
+	}
+
+	// If a page cursor is provided, load it - otherwise create a cursor for iteration
+	cursor = &models.PageCursor{}
+	if pageToken != "" {
+		if err = cursor.Load(pageToken); err != nil {
+			sentry.Warn(ctx).Err(err).Msg("invalid page token on search request")
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+
+		// Validate the request has not changed
+		if cursor.PageSize != pageSize {
+			log.Debug().Int32("cursor", cursor.PageSize).Int32("opts", pageSize).Msg("invalid search request: mismatched page size")
+			return nil, status.Error(codes.InvalidArgument, "page size cannot change between requests")
+		}
+
+		if cursor.Query != query {
+			log.Debug().Msg("invalid search request: mismatched query")
+			return nil, status.Error(codes.InvalidArgument, "search query cannot change between requests")
+		}
+	} else {
+		// Update the cursor with the input request
+		cursor.PageSize = pageSize
+		cursor.Query = query
+	}
+	return cursor, nil
+}
+
+// paginateSearch returns the page of results specified by the cursor and updates the
+// cursor to point to the first result of the next page if there are more results. The
+// results must be in a deterministic order for the page token to be valid. If the
+// cursor does not have a page size then all of the results are returned.
+func paginateSearch(results []*api.SearchReply_Result, cursor *models.PageCursor) []*api.SearchReply_Result {
+	// If necessary, seek to the result specified by the cursor; if the result is no
+	// longer in the search results then there are no more results to return.
+	// NOTE: the next vasp must be reset after seeking so the last page doesn't loop.
+	start := 0
+	if cursor.NextVasp != "" {
+		start = len(results)
+		for i, result := range results {
+			if result.Id == cursor.NextVasp {
+				start = i
+				break
+			}
+		}
+		cursor.NextVasp = ""
+	}
+
+	end := start + int(cursor.PageSize)
+	if cursor.PageSize > 0 && end < len(results) {
+		cursor.NextVasp = results[end].Id
+	} else {
+		end = len(results)
+	}
+	return results[start:end]
+}
+
+// sendNextPageToken sets the next page token in the response header if there is a
+// next page of results.
+func sendNextPageToken(ctx context.Context, cursor *models.PageCursor) (err error) {
+	if cursor.NextVasp == "" {
+		return nil
+	}
+
+	var token string
+	if token, err = cursor.Dump(); err != nil {
+		return err
+	}
+	return grpc.SetHeader(ctx, metadata.Pairs(MetadataNextPageToken, token))
+}
+
+// searchDigest returns a hash of the search request so that page tokens are only valid
+// for the query they were created for.
+func searchDigest(in *api.SearchRequest) (_ string, err error) {
+	var data []byte
+	if data, err = (proto.MarshalOptions{Deterministic: true}).Marshal(in); err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:16]), nil
+}
@@ -10,7 +10,10 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/trisacrypto/directory/pkg/gds/certman"
 	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/gds/duplicates"
 	"github.com/trisacrypto/directory/pkg/gds/emails"
+	"github.com/trisacrypto/directory/pkg/gds/health"
+	"github.com/trisacrypto/directory/pkg/gds/jobs"
 	"github.com/trisacrypto/directory/pkg/gds/secrets"
 	"github.com/trisacrypto/directory/pkg/store"
 	"github.com/trisacrypto/directory/pkg/utils/activity"
@@ -90,16 +93,34 @@ func New(conf config.Config) (s *Service, err error) {
 		return nil, err
 	}
 
+	// Create the email outbox that retries failed deliveries
+	s.outbox = emails.NewOutbox(conf.Outbox, s.db, s.email)
+
 	// Create secret manager and connect to backend vault service
 	if s.secret, err = secrets.New(conf.Secrets); err != nil {
 		return nil, err
 	}
 
+	// Look up email secrets and attachments when emails are rendered so that they are
+	// not stored in the email outbox
+	s.email.SetResolver(emails.NewResolver(s.db, s.secret))
+
 	// Create the certificate manager
 	if s.certman, err = certman.New(conf.CertMan, s.db, s.secret, s.email); err != nil {
 		return nil, err
 	}
 
+	// Create the endpoint health check monitor
+	if s.health, err = health.New(conf.Health, s.db); err != nil {
+		return nil, err
+	}
+
+	// Create the duplicate registration detector
+	s.duplicates = duplicates.New(conf.Duplicates, s.db)
+
+	// Create the job queue that processes the side effects of registrations
+	s.jobs = jobs.New(conf.Jobs, s.db)
+
 	// Start the activity publisher
 	if err = activity.Start(conf.Activity); err != nil {
 		return nil, err
@@ -124,19 +145,23 @@ func New(conf config.Config) (s *Service, err error) {
 // Service defines the entirety of the TRISA Global Directory Service including the GDS
 // server that handles TRISA requests, the Admin server that handles administrative
 // interactions, as well as the smaller routines and managers to handle email, secrets,
-// backups, and certificates.
+// backups, certificates, endpoint health checks, and background jobs.
 // E.g. this is the parent service that coordinates all subservices.
 type Service struct {
-	db      store.Store
-	gds     *GDS
-	admin   *Admin
-	members *Members
-	conf    config.Config
-	certman certman.Service
-	email   *emails.EmailManager
-	secret  *secrets.SecretManager
-	wg      sync.WaitGroup
-	echan   chan error
+	db         store.Store
+	gds        *GDS
+	admin      *Admin
+	members    *Members
+	conf       config.Config
+	certman    certman.Service
+	health     health.Service
+	duplicates *duplicates.Detector
+	jobs       *jobs.Queue
+	email      *emails.EmailManager
+	outbox     *emails.Outbox
+	secret     *secrets.SecretManager
+	wg         sync.WaitGroup
+	echan      chan error
 }
 
 // Serve GRPC requests on the specified addresses and all internal servers.
@@ -163,6 +188,15 @@ func (s *Service) Serve() (err error) {
 		s.wg = sync.WaitGroup{}
 		s.certman.Run(&s.wg)
 
+		// Start the endpoint health check monitor go routine process
+		s.health.Run(&s.wg)
+
+		// Start the job queue go routine process
+		s.jobs.Run(&s.wg)
+
+		// Start the email outbox go routine process
+		s.outbox.Run(&s.wg)
+
 		// Start the backup manager go routine process
 		// TODO: Refactor to use the wait group and shutdown gracefully
 		go s.BackupManager(nil)
@@ -208,6 +242,15 @@ func (s *Service) Shutdown() (err error) {
 		// Stop the certificate manager
 		s.certman.Stop()
 
+		// Stop the endpoint health check monitor
+		s.health.Stop()
+
+		// Stop the job queue
+		s.jobs.Stop()
+
+		// Stop the email outbox
+		s.outbox.Stop()
+
 		// Wait for all go routines to finish
 		s.wg.Wait()
 
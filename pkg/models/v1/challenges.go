@@ -0,0 +1,163 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Domain challenge types that can be used to validate control of a domain.
+const (
+	ChallengeHTTP01 = "http-01"
+	ChallengeDNS01  = "dns-01"
+)
+
+// Domain challenges are completed by publishing the challenge value in a TXT record at
+// the challenge subdomain of the domain or by serving the token from the challenge path
+// of the domain over HTTP.
+const (
+	ChallengeDNSPrefix  = "_trisa-challenge"
+	ChallengeTXTPrefix  = "TRISA-DOMAIN-VERIFICATION="
+	ChallengeHTTPPrefix = "/.well-known/trisa-challenge/"
+)
+
+// NewChallengeToken returns a random token that can be used to create a domain challenge.
+func NewChallengeToken() (string, error) {
+	nonce := make([]byte, 32)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(nonce), nil
+}
+
+// ChallengeDomains returns the unique domains of the certificate request that must be
+// validated before the certificate request can be submitted to the CA.
+func ChallengeDomains(r *CertificateRequest) []string {
+	seen := make(map[string]struct{})
+	domains := make([]string, 0, len(r.DnsNames)+1)
+	for _, name := range append([]string{r.CommonName}, r.DnsNames...) {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			domains = append(domains, name)
+		}
+	}
+	return domains
+}
+
+// CreateDomainChallenges adds a pending challenge for every domain of the certificate
+// request that does not already have a challenge. Existing challenges are not modified
+// so that the VASP does not have to publish a new token when the request is retried.
+func CreateDomainChallenges(r *CertificateRequest) (err error) {
+	existing := make(map[string]struct{}, len(r.Challenges))
+	for _, challenge := range r.Challenges {
+		existing[challenge.Domain] = struct{}{}
+	}
+
+	domains := ChallengeDomains(r)
+	if len(domains) == 0 {
+		return errors.New("certificate request has no domains to validate")
+	}
+
+	for _, domain := range domains {
+		if _, ok := existing[domain]; ok {
+			continue
+		}
+
+		challenge := &DomainChallenge{
+			Domain:  domain,
+			Status:  DomainChallengeState_CHALLENGE_PENDING,
+			Created: time.Now().Format(time.RFC3339),
+		}
+
+		if challenge.Token, err = NewChallengeToken(); err != nil {
+			return fmt.Errorf("could not create challenge token: %w", err)
+		}
+		r.Challenges = append(r.Challenges, challenge)
+	}
+	return nil
+}
+
+// ReuseDomainChallenges adds the challenges of a previous certificate request of the
+// same VASP for domains of the certificate request that do not already have a challenge
+// so that the VASP does not have to publish a new token for every request, e.g. when
+// certificates are automatically reissued. Challenges validated within the reuse
+// duration remain valid, otherwise the token is reused but must be validated again.
+func ReuseDomainChallenges(r, previous *CertificateRequest, reuse time.Duration) {
+	existing := make(map[string]struct{}, len(r.Challenges))
+	for _, challenge := range r.Challenges {
+		existing[challenge.Domain] = struct{}{}
+	}
+
+	domains := make(map[string]struct{})
+	for _, domain := range ChallengeDomains(r) {
+		domains[domain] = struct{}{}
+	}
+
+	now := time.Now()
+	for _, prev := range previous.Challenges {
+		if _, ok := domains[prev.Domain]; !ok {
+			continue
+		}
+
+		if _, ok := existing[prev.Domain]; ok || prev.Token == "" {
+			continue
+		}
+
+		challenge := &DomainChallenge{
+			Domain:  prev.Domain,
+			Token:   prev.Token,
+			Status:  DomainChallengeState_CHALLENGE_PENDING,
+			Created: now.Format(time.RFC3339),
+		}
+
+		if prev.Status == DomainChallengeState_CHALLENGE_VALID {
+			if validated, err := time.Parse(time.RFC3339, prev.Validated); err == nil && now.Sub(validated) < reuse {
+				challenge.Status = prev.Status
+				challenge.ValidatedBy = prev.ValidatedBy
+				challenge.Validated = prev.Validated
+			}
+		}
+
+		existing[challenge.Domain] = struct{}{}
+		r.Challenges = append(r.Challenges, challenge)
+	}
+}
+
+// DomainChallengesValid returns true if the certificate request has challenges and all
+// of the challenges have been validated.
+func DomainChallengesValid(r *CertificateRequest) bool {
+	if len(r.Challenges) == 0 {
+		return false
+	}
+
+	for _, challenge := range r.Challenges {
+		if challenge.Status != DomainChallengeState_CHALLENGE_VALID {
+			return false
+		}
+	}
+	return true
+}
+
+// DNSRecord returns the name of the TXT record that must contain the challenge value.
+func (c *DomainChallenge) DNSRecord() string {
+	return ChallengeDNSPrefix + "." + c.Domain
+}
+
+// DNSValue returns the value of the TXT record that completes the challenge.
+func (c *DomainChallenge) DNSValue() string {
+	return ChallengeTXTPrefix + c.Token
+}
+
+// HTTPPath returns the path on the domain that must serve the token to complete the
+// challenge.
+func (c *DomainChallenge) HTTPPath() string {
+	return ChallengeHTTPPrefix + c.Token
+}
@@ -0,0 +1,99 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	. "github.com/trisacrypto/directory/pkg/models/v1"
+)
+
+func TestChallengeDomains(t *testing.T) {
+	req := &CertificateRequest{
+		CommonName: "trisa.example.com",
+		DnsNames:   []string{"trisa.example.com", " API.example.com ", "", "api.example.com"},
+	}
+	require.Equal(t, []string{"trisa.example.com", "api.example.com"}, ChallengeDomains(req))
+
+	require.Empty(t, ChallengeDomains(&CertificateRequest{}))
+}
+
+func TestCreateDomainChallenges(t *testing.T) {
+	// Challenges cannot be created without domains
+	req := &CertificateRequest{}
+	require.EqualError(t, CreateDomainChallenges(req), "certificate request has no domains to validate")
+	require.False(t, DomainChallengesValid(req), "expected no challenges to be invalid")
+
+	// A pending challenge is created for each domain
+	req.CommonName = "trisa.example.com"
+	req.DnsNames = []string{"api.example.com"}
+	require.NoError(t, CreateDomainChallenges(req))
+	require.Len(t, req.Challenges, 2)
+
+	for i, domain := range []string{"trisa.example.com", "api.example.com"} {
+		challenge := req.Challenges[i]
+		require.Equal(t, domain, challenge.Domain)
+		require.Equal(t, DomainChallengeState_CHALLENGE_PENDING, challenge.Status)
+		require.Len(t, challenge.Token, 43)
+		require.NotEmpty(t, challenge.Created)
+		require.Equal(t, "_trisa-challenge."+domain, challenge.DNSRecord())
+		require.Equal(t, "TRISA-DOMAIN-VERIFICATION="+challenge.Token, challenge.DNSValue())
+		require.Equal(t, "/.well-known/trisa-challenge/"+challenge.Token, challenge.HTTPPath())
+	}
+	require.NotEqual(t, req.Challenges[0].Token, req.Challenges[1].Token, "expected unique challenge tokens")
+	require.False(t, DomainChallengesValid(req))
+
+	// Existing challenges are not modified when new domains are added
+	token := req.Challenges[0].Token
+	req.Challenges[0].Status = DomainChallengeState_CHALLENGE_VALID
+	req.Challenges[1].Status = DomainChallengeState_CHALLENGE_VALID
+	require.True(t, DomainChallengesValid(req))
+
+	req.DnsNames = append(req.DnsNames, "vasp.example.com")
+	require.NoError(t, CreateDomainChallenges(req))
+	require.Len(t, req.Challenges, 3)
+	require.Equal(t, token, req.Challenges[0].Token)
+	require.Equal(t, "vasp.example.com", req.Challenges[2].Domain)
+	require.False(t, DomainChallengesValid(req))
+}
+
+func TestReuseDomainChallenges(t *testing.T) {
+	validated := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	stale := time.Now().Add(-48 * time.Hour).Format(time.RFC3339)
+	previous := &CertificateRequest{
+		Challenges: []*DomainChallenge{
+			{Domain: "trisa.example.com", Token: "recent", Status: DomainChallengeState_CHALLENGE_VALID, ValidatedBy: ChallengeDNS01, Validated: validated, Attempts: 3},
+			{Domain: "api.example.com", Token: "stale", Status: DomainChallengeState_CHALLENGE_VALID, ValidatedBy: ChallengeHTTP01, Validated: stale},
+			{Domain: "old.example.com", Token: "old", Status: DomainChallengeState_CHALLENGE_VALID, Validated: validated},
+		},
+	}
+
+	req := &CertificateRequest{CommonName: "trisa.example.com", DnsNames: []string{"api.example.com", "vasp.example.com"}}
+	ReuseDomainChallenges(req, previous, 24*time.Hour)
+	require.Len(t, req.Challenges, 2, "only challenges for domains of the request should be reused")
+
+	// Challenges validated within the reuse duration remain valid
+	require.Equal(t, "trisa.example.com", req.Challenges[0].Domain)
+	require.Equal(t, "recent", req.Challenges[0].Token)
+	require.Equal(t, DomainChallengeState_CHALLENGE_VALID, req.Challenges[0].Status)
+	require.Equal(t, ChallengeDNS01, req.Challenges[0].ValidatedBy)
+	require.Equal(t, validated, req.Challenges[0].Validated)
+	require.Zero(t, req.Challenges[0].Attempts)
+
+	// Older challenges reuse the token but must be validated again
+	require.Equal(t, "api.example.com", req.Challenges[1].Domain)
+	require.Equal(t, "stale", req.Challenges[1].Token)
+	require.Equal(t, DomainChallengeState_CHALLENGE_PENDING, req.Challenges[1].Status)
+	require.Empty(t, req.Challenges[1].Validated)
+	require.NotEmpty(t, req.Challenges[1].Created)
+
+	// Existing challenges are not replaced and remaining domains get new challenges
+	ReuseDomainChallenges(req, &CertificateRequest{Challenges: []*DomainChallenge{{Domain: "trisa.example.com", Token: "other"}}}, 24*time.Hour)
+	require.Len(t, req.Challenges, 2)
+	require.Equal(t, "recent", req.Challenges[0].Token)
+
+	require.NoError(t, CreateDomainChallenges(req))
+	require.Len(t, req.Challenges, 3)
+	require.Equal(t, "vasp.example.com", req.Challenges[2].Domain)
+	require.False(t, DomainChallengesValid(req))
+}
@@ -270,6 +270,41 @@ func SetContactVerification(contact *pb.Contact, token string, verified bool) (e
 	return nil
 }
 
+// GetContactLocale returns the preferred locale of the contact used to localize emails,
+// returning an empty string if the contact has no preferred locale.
+func GetContactLocale(contact *pb.Contact) (_ string, err error) {
+	if contact == nil || contact.Extra == nil {
+		return "", nil
+	}
+
+	extra := &GDSContactExtraData{}
+	if err = contact.Extra.UnmarshalTo(extra); err != nil {
+		return "", err
+	}
+	return extra.GetLocale(), nil
+}
+
+// SetContactLocale sets the preferred locale of the contact used to localize emails.
+func SetContactLocale(contact *pb.Contact, locale string) (err error) {
+	if contact == nil || contact.IsZero() {
+		return errors.New("cannot set locale on nil contact")
+	}
+
+	// Unmarshal previous extra data.
+	extra := &GDSContactExtraData{}
+	if contact.Extra != nil {
+		if err = contact.Extra.UnmarshalTo(extra); err != nil {
+			return fmt.Errorf("could not deserialize previous extra: %s", err)
+		}
+	}
+
+	extra.Locale = locale
+	if contact.Extra, err = anypb.New(extra); err != nil {
+		return err
+	}
+	return nil
+}
+
 // VerifiedContacts returns a map of contact type to email address for all verified
 // contacts, omitting any contacts that are not verified or do not exist.
 func VerifiedContacts(vasp *pb.VASP) (contacts map[string]string) {
@@ -315,6 +350,12 @@ func GetEmailLog(contact *pb.Contact) (_ []*EmailLogEntry, err error) {
 
 // Create and add a new entry to the EmailLog on the extra data on the Contact record.
 func AppendEmailLog(contact *pb.Contact, reason, subject string) (err error) {
+	return AppendEmailLogMessage(contact, reason, subject, "")
+}
+
+// AppendEmailLogMessage adds a new entry to the EmailLog on the extra data on the
+// Contact record that is linked to the outbox email that delivers the message.
+func AppendEmailLogMessage(contact *pb.Contact, reason, subject, messageID string) (err error) {
 	// Contact must be non-nil.
 	if contact == nil || contact.IsZero() {
 		return errors.New("cannot append entry to nil contact")
@@ -336,6 +377,7 @@ func AppendEmailLog(contact *pb.Contact, reason, subject string) (err error) {
 		Reason:    reason,
 		Subject:   subject,
 		Recipient: contact.Email,
+		MessageId: messageID,
 	}
 	extra.EmailLog = append(extra.EmailLog, entry)
 
@@ -0,0 +1,160 @@
+package models
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/trisacrypto/directory/pkg/utils"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"github.com/trisacrypto/trisa/pkg/trust"
+)
+
+// CSRMetadataKey is the gRPC metadata key used to submit a PEM encoded certificate
+// signing request along with a TRISA RegisterRequest, which does not have a field for
+// the CSR. The -bin suffix ensures that the multi-line PEM data is base64 encoded.
+const CSRMetadataKey = "trisa-csr-bin"
+
+// Errors returned when validating a certificate signing request.
+var (
+	ErrInvalidCSR         = errors.New("could not parse PEM encoded certificate signing request")
+	ErrCSRPrivateKey      = errors.New("certificate signing request must not contain a private key")
+	ErrCSRSignature       = errors.New("certificate signing request signature is invalid")
+	ErrCSRCommonName      = errors.New("certificate signing request common name does not match the registered common name")
+	ErrCSRDNSNames        = errors.New("certificate signing request contains dns names that are not registered")
+	ErrCSRPublicKeyNotSet = errors.New("certificate signing request does not contain a public key")
+)
+
+// ParseCSR parses a PEM encoded certificate signing request and verifies its signature
+// to ensure that the requester holds the private key of the public key in the request.
+// Any PEM data that contains a private key is rejected so that VASPs are not encouraged
+// to send their private keys to the directory service.
+func ParseCSR(data []byte) (csr *x509.CertificateRequest, err error) {
+	// Check every block in the data for private keys before parsing the CSR.
+	rest := bytes.TrimSpace(data)
+	for len(rest) > 0 {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+
+		if strings.Contains(block.Type, "PRIVATE KEY") {
+			return nil, ErrCSRPrivateKey
+		}
+	}
+
+	if csr, err = trust.PEMDecodeCSR(bytes.TrimSpace(data)); err != nil {
+		return nil, ErrInvalidCSR
+	}
+
+	if csr.PublicKey == nil {
+		return nil, ErrCSRPublicKeyNotSet
+	}
+
+	if err = csr.CheckSignature(); err != nil {
+		return nil, ErrCSRSignature
+	}
+	return csr, nil
+}
+
+// ValidateCSR parses the PEM encoded certificate signing request and ensures that the
+// subject common name matches the common name of the VASP and that any DNS names in
+// the request are either the common name or one of the additional dns names.
+func ValidateCSR(data []byte, commonName string, dnsNames []string) (err error) {
+	var csr *x509.CertificateRequest
+	if csr, err = ParseCSR(data); err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(csr.Subject.CommonName, commonName) {
+		return fmt.Errorf("%w: %q", ErrCSRCommonName, csr.Subject.CommonName)
+	}
+
+	allowed := make(map[string]struct{}, len(dnsNames)+1)
+	allowed[strings.ToLower(commonName)] = struct{}{}
+	for _, name := range dnsNames {
+		allowed[strings.ToLower(name)] = struct{}{}
+	}
+
+	for _, name := range csr.DNSNames {
+		if _, ok := allowed[strings.ToLower(name)]; !ok {
+			return fmt.Errorf("%w: %q", ErrCSRDNSNames, name)
+		}
+	}
+
+	if len(csr.IPAddresses) > 0 || len(csr.EmailAddresses) > 0 || len(csr.URIs) > 0 {
+		return fmt.Errorf("%w: only dns names are allowed as alternative names", ErrCSRDNSNames)
+	}
+	return nil
+}
+
+// ValidateVASPCSR validates a PEM encoded certificate signing request submitted by the
+// VASP against its registered common name and returns the DNS names requested in
+// addition to the common name. The only additional name that may be requested is the
+// host of the TRISA endpoint of the VASP.
+func ValidateVASPCSR(data []byte, vasp *pb.VASP) (dnsNames []string, err error) {
+	var csr *x509.CertificateRequest
+	if csr, err = ParseCSR(data); err != nil {
+		return nil, err
+	}
+
+	var endpoint string
+	if endpoint, _, err = net.SplitHostPort(vasp.TrisaEndpoint); err != nil {
+		return nil, fmt.Errorf("could not parse host from endpoint: %w", err)
+	}
+
+	for _, name := range csr.DNSNames {
+		if strings.EqualFold(name, vasp.CommonName) {
+			continue
+		}
+
+		if !strings.EqualFold(name, endpoint) {
+			return nil, fmt.Errorf("%w: %q is not the common name or endpoint of the VASP", ErrCSRDNSNames, name)
+		}
+
+		if err = utils.ValidateCommonName(name); err != nil {
+			return nil, fmt.Errorf("invalid dns name %q: %w", name, err)
+		}
+		dnsNames = append(dnsNames, name)
+	}
+
+	if err = ValidateCSR(data, vasp.CommonName, dnsNames); err != nil {
+		return nil, err
+	}
+	return dnsNames, nil
+}
+
+// ValidateCertificateRequestCSR validates the CSR on the certificate request, if any,
+// against the common name and dns names of the request.
+func ValidateCertificateRequestCSR(request *CertificateRequest) error {
+	if request.Csr == "" {
+		return nil
+	}
+	return ValidateCSR([]byte(request.Csr), request.CommonName, request.DnsNames)
+}
+
+// MatchesCSR returns true if the public key of the certificate is the public key of the
+// PEM encoded certificate signing request, e.g. to ensure that the certificate issued
+// by the CA was issued for the request submitted by the VASP.
+func MatchesCSR(cert *x509.Certificate, data []byte) (_ bool, err error) {
+	var csr *x509.CertificateRequest
+	if csr, err = ParseCSR(data); err != nil {
+		return false, err
+	}
+
+	var (
+		certKey []byte
+		csrKey  []byte
+	)
+	if certKey, err = x509.MarshalPKIXPublicKey(cert.PublicKey); err != nil {
+		return false, err
+	}
+	if csrKey, err = x509.MarshalPKIXPublicKey(csr.PublicKey); err != nil {
+		return false, err
+	}
+	return bytes.Equal(certKey, csrKey), nil
+}
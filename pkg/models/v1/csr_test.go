@@ -0,0 +1,148 @@
+package models_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"github.com/trisacrypto/trisa/pkg/trust"
+)
+
+func TestValidateCSR(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err, "could not generate private key")
+
+	csr := createCSR(t, key, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "trisa.example.com"},
+		DNSNames: []string{"trisa.example.com", "api.example.com"},
+	})
+
+	// A valid CSR should match the common name and dns names
+	require.NoError(t, models.ValidateCSR(csr, "trisa.example.com", []string{"api.example.com"}))
+	require.NoError(t, models.ValidateCSR(csr, "TRISA.example.com", []string{"API.example.com"}))
+
+	// The common name must match
+	require.ErrorIs(t, models.ValidateCSR(csr, "other.example.com", []string{"api.example.com"}), models.ErrCSRCommonName)
+
+	// The dns names must be registered
+	require.ErrorIs(t, models.ValidateCSR(csr, "trisa.example.com", nil), models.ErrCSRDNSNames)
+
+	// IP addresses cannot be requested
+	ipcsr := createCSR(t, key, &x509.CertificateRequest{
+		Subject:     pkix.Name{CommonName: "trisa.example.com"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+	})
+	require.ErrorIs(t, models.ValidateCSR(ipcsr, "trisa.example.com", nil), models.ErrCSRDNSNames)
+
+	// Invalid PEM data should not be parsed
+	require.ErrorIs(t, models.ValidateCSR([]byte("foo"), "trisa.example.com", nil), models.ErrInvalidCSR)
+	require.ErrorIs(t, models.ValidateCSR(nil, "trisa.example.com", nil), models.ErrInvalidCSR)
+
+	// A CSR with a private key should be rejected
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	withKey := append(bytes.Clone(csr), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})...)
+	require.ErrorIs(t, models.ValidateCSR(withKey, "trisa.example.com", []string{"api.example.com"}), models.ErrCSRPrivateKey)
+
+	// A CSR with an invalid signature should be rejected
+	block, _ := pem.Decode(csr)
+	block.Bytes[len(block.Bytes)-1] ^= 0xff
+	require.ErrorIs(t, models.ValidateCSR(pem.EncodeToMemory(block), "trisa.example.com", []string{"api.example.com"}), models.ErrCSRSignature)
+
+	// Certificate requests without a CSR are valid
+	require.NoError(t, models.ValidateCertificateRequestCSR(&models.CertificateRequest{CommonName: "trisa.example.com"}))
+	require.NoError(t, models.ValidateCertificateRequestCSR(&models.CertificateRequest{CommonName: "trisa.example.com", DnsNames: []string{"api.example.com"}, Csr: string(csr)}))
+	require.Error(t, models.ValidateCertificateRequestCSR(&models.CertificateRequest{CommonName: "trisa.example.com", Csr: string(csr)}))
+}
+
+func TestValidateVASPCSR(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err, "could not generate private key")
+
+	vasp := &pb.VASP{CommonName: "trisa.example.com", TrisaEndpoint: "example.com:443"}
+
+	// The host of the endpoint may be requested in addition to the common name
+	csr := createCSR(t, key, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "trisa.example.com"},
+		DNSNames: []string{"trisa.example.com", "example.com"},
+	})
+	dnsNames, err := models.ValidateVASPCSR(csr, vasp)
+	require.NoError(t, err)
+	require.Equal(t, []string{"example.com"}, dnsNames)
+
+	// Any other dns names are rejected
+	csr = createCSR(t, key, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "trisa.example.com"},
+		DNSNames: []string{"trisa.example.com", "attacker.example.com"},
+	})
+	_, err = models.ValidateVASPCSR(csr, vasp)
+	require.ErrorIs(t, err, models.ErrCSRDNSNames)
+
+	// The common name must match the VASP
+	csr = createCSR(t, key, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "example.com"},
+	})
+	_, err = models.ValidateVASPCSR(csr, vasp)
+	require.ErrorIs(t, err, models.ErrCSRCommonName)
+}
+
+func TestMatchesCSR(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err, "could not generate private key")
+	csr := createCSR(t, key, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "trisa.example.com"}})
+
+	// A certificate issued for the CSR public key should match
+	cert := createCert(t, &key.PublicKey)
+	match, err := models.MatchesCSR(cert, csr)
+	require.NoError(t, err)
+	require.True(t, match)
+
+	// A certificate issued for a different key should not match
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err, "could not generate private key")
+	match, err = models.MatchesCSR(createCert(t, &other.PublicKey), csr)
+	require.NoError(t, err)
+	require.False(t, match)
+}
+
+func createCSR(t *testing.T, key *ecdsa.PrivateKey, template *x509.CertificateRequest) []byte {
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	require.NoError(t, err, "could not create certificate request")
+
+	csr, err := x509.ParseCertificateRequest(der)
+	require.NoError(t, err, "could not parse certificate request")
+
+	data, err := trust.PEMEncodeCSR(csr)
+	require.NoError(t, err, "could not encode certificate request")
+	return data
+}
+
+func createCert(t *testing.T, pub *ecdsa.PublicKey) *x509.Certificate {
+	ca, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err, "could not generate ca key")
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "trisa.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pub, ca)
+	require.NoError(t, err, "could not create certificate")
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err, "could not parse certificate")
+	return cert
+}
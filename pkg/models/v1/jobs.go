@@ -0,0 +1,71 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// JobMetadataKey is the gRPC header key used to return the ID of the job that processes
+// the side effects of a TRISA RegisterRequest, which does not have a field for the job,
+// so that clients can poll for the status of the job.
+const JobMetadataKey = "trisa-job-id"
+
+// Job types that are processed by the directory service.
+const (
+	JobRegistration = "registration"
+)
+
+// NewJob creates a queued job of the specified type for the VASP. The ID of the job is
+// assigned when it is created in the store.
+func NewJob(jobType, vaspID string) (*Job, error) {
+	if jobType == "" || vaspID == "" {
+		return nil, errors.New("must supply a job type and VASP ID for job creation")
+	}
+
+	return &Job{
+		Type:        jobType,
+		Vasp:        vaspID,
+		Status:      JobState_JOB_QUEUED,
+		NextAttempt: time.Now().Format(time.RFC3339),
+	}, nil
+}
+
+// StepCompleted returns true if the step has been completed by a previous attempt.
+func (j *Job) StepCompleted(step string) bool {
+	for _, completed := range j.CompletedSteps {
+		if completed == step {
+			return true
+		}
+	}
+	return false
+}
+
+// CompleteStep records that the step has been completed so that it is not repeated if
+// the job is retried.
+func (j *Job) CompleteStep(step string) {
+	if !j.StepCompleted(step) {
+		j.CompletedSteps = append(j.CompletedSteps, step)
+	}
+}
+
+// IsFinished returns true if the job has completed or failed and will not be attempted.
+func (j *Job) IsFinished() bool {
+	return j.Status == JobState_JOB_COMPLETED || j.Status == JobState_JOB_FAILED
+}
+
+// Due returns true if the job should be attempted at the specified time.
+func (j *Job) Due(now time.Time) bool {
+	if j.IsFinished() {
+		return false
+	}
+
+	if j.NextAttempt == "" {
+		return true
+	}
+
+	next, err := time.Parse(time.RFC3339, j.NextAttempt)
+	if err != nil {
+		return true
+	}
+	return !next.After(now)
+}
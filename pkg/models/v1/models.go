@@ -146,6 +146,12 @@ func GetAdminEmailLog(vasp *pb.VASP) (_ []*EmailLogEntry, err error) {
 
 // Create and add a new entry to the EmailLog on the extra data on the VASP record.
 func AppendAdminEmailLog(vasp *pb.VASP, reason string, subject string) (err error) {
+	return AppendAdminEmailLogMessage(vasp, reason, subject, "")
+}
+
+// AppendAdminEmailLogMessage adds a new entry to the EmailLog on the extra data on the
+// VASP record that is linked to the outbox email that delivers the message.
+func AppendAdminEmailLogMessage(vasp *pb.VASP, reason, subject, messageID string) (err error) {
 	// VASP must be non-nil.
 	if vasp == nil {
 		return errors.New("cannot append to nil VASP")
@@ -166,6 +172,7 @@ func AppendAdminEmailLog(vasp *pb.VASP, reason string, subject string) (err erro
 		Timestamp: time.Now().Format(time.RFC3339),
 		Reason:    reason,
 		Subject:   subject,
+		MessageId: messageID,
 	}
 	extra.EmailLog = append(extra.EmailLog, entry)
 
@@ -224,6 +231,12 @@ func GetVASPEmailLog(vasp *pb.VASP) (emails []*EmailLogEntry, err error) {
 
 // Create and add a new entry to the EmailLog on the extra data on the Contact record.
 func (c *Contact) AppendEmailLog(reason, subject string) {
+	c.AppendEmailLogMessage(reason, subject, "")
+}
+
+// AppendEmailLogMessage adds a new entry to the EmailLog of the Contact record that is
+// linked to the outbox email that delivers the message.
+func (c *Contact) AppendEmailLogMessage(reason, subject, messageID string) {
 	// Contact must be non-nil.
 	if c == nil {
 		return
@@ -240,6 +253,7 @@ func (c *Contact) AppendEmailLog(reason, subject string) {
 		Reason:    reason,
 		Subject:   subject,
 		Recipient: c.Email,
+		MessageId: messageID,
 	}
 	c.EmailLog = append(c.EmailLog, entry)
 }
@@ -432,6 +446,219 @@ func AppendCertID(vasp *pb.VASP, certID string) (err error) {
 	return nil
 }
 
+// GetRevokedOn returns the timestamp when the identity certificate of the VASP was
+// revoked or an empty string if the identity certificate has not been revoked.
+func GetRevokedOn(vasp *pb.VASP) (_ string, err error) {
+	// If the extra data is nil, return empty string with no error
+	if vasp.Extra == nil {
+		return "", nil
+	}
+
+	// Unmarshal the extra data field on the VASP
+	extra := &GDSExtraData{}
+	if err = vasp.Extra.UnmarshalTo(extra); err != nil {
+		return "", err
+	}
+	return extra.GetRevokedOn(), nil
+}
+
+// SetRevokedOn on the extra data on the VASP record; an empty timestamp clears the
+// revocation, e.g. when a new identity certificate is issued.
+func SetRevokedOn(vasp *pb.VASP, timestamp string) (err error) {
+	// Must unmarshal previous extra to ensure that other data is not overwritten.
+	extra := &GDSExtraData{}
+	if vasp.Extra != nil {
+		if err = vasp.Extra.UnmarshalTo(extra); err != nil {
+			return fmt.Errorf("could not deserialize previous extra: %s", err)
+		}
+	}
+
+	// Update the revocation timestamp
+	extra.RevokedOn = timestamp
+
+	// Serialize the extra back to the VASP.
+	if vasp.Extra, err = anypb.New(extra); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetHealthCheck returns the health check record from the extra data on the VASP or nil
+// if the TRISA endpoint of the VASP has not been health checked.
+func GetHealthCheck(vasp *pb.VASP) (_ *HealthCheckRecord, err error) {
+	// If the extra data is nil, return nil (no health checks).
+	if vasp.Extra == nil {
+		return nil, nil
+	}
+
+	// Unmarshal the extra data field on the VASP.
+	extra := &GDSExtraData{}
+	if err = vasp.Extra.UnmarshalTo(extra); err != nil {
+		return nil, err
+	}
+	return extra.GetHealthCheck(), nil
+}
+
+// SetHealthCheck on the extra data on the VASP record and update the service status of
+// the VASP to the status of the most recent health check.
+func SetHealthCheck(vasp *pb.VASP, record *HealthCheckRecord) (err error) {
+	// Record must be non-nil.
+	if record == nil {
+		return errors.New("cannot set nil health check record on extra")
+	}
+
+	// Must unmarshal previous extra to ensure that other data is not overwritten.
+	extra := &GDSExtraData{}
+	if vasp.Extra != nil {
+		if err = vasp.Extra.UnmarshalTo(extra); err != nil {
+			return fmt.Errorf("could not deserialize previous extra: %s", err)
+		}
+	}
+
+	// Update the health check record and the service status
+	extra.HealthCheck = record
+	vasp.ServiceStatus = record.Status
+
+	// Serialize the extra back to the VASP.
+	if vasp.Extra, err = anypb.New(extra); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDuplicates returns the likely duplicate registrations of the VASP that were
+// detected when it was registered from the extra data on the VASP.
+func GetDuplicates(vasp *pb.VASP) (_ []*DuplicateMatch, err error) {
+	// If the extra data is nil, return nil (no duplicates).
+	if vasp.Extra == nil {
+		return nil, nil
+	}
+
+	// Unmarshal the extra data field on the VASP.
+	extra := &GDSExtraData{}
+	if err = vasp.Extra.UnmarshalTo(extra); err != nil {
+		return nil, err
+	}
+	return extra.GetDuplicates(), nil
+}
+
+// SetDuplicates on the extra data on the VASP record, replacing any previous matches.
+func SetDuplicates(vasp *pb.VASP, matches []*DuplicateMatch) (err error) {
+	// Must unmarshal previous extra to ensure that other data is not overwritten.
+	extra := &GDSExtraData{}
+	if vasp.Extra != nil {
+		if err = vasp.Extra.UnmarshalTo(extra); err != nil {
+			return fmt.Errorf("could not deserialize previous extra: %s", err)
+		}
+	}
+
+	extra.Duplicates = matches
+
+	// Serialize the extra back to the VASP.
+	if vasp.Extra, err = anypb.New(extra); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReissuanceStageFired returns true if the stage of the certificate reissuance schedule
+// has already fired for the current identity certificate of the VASP.
+func ReissuanceStageFired(vasp *pb.VASP, stage string) (_ bool, err error) {
+	// If the extra data is nil, no stages have fired.
+	if vasp.Extra == nil || vasp.IdentityCertificate == nil {
+		return false, nil
+	}
+
+	// Unmarshal the extra data field on the VASP.
+	extra := &GDSExtraData{}
+	if err = vasp.Extra.UnmarshalTo(extra); err != nil {
+		return false, err
+	}
+
+	certID := GetCertID(vasp.IdentityCertificate)
+	for _, record := range extra.ReissuanceStages {
+		if record.Stage == stage && record.Certificate == certID && record.NotAfter == vasp.IdentityCertificate.NotAfter {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RecordReissuanceStage records on the extra data of the VASP that the stage of the
+// certificate reissuance schedule has fired for the current identity certificate of the
+// VASP. Records for previous identity certificates are discarded.
+func RecordReissuanceStage(vasp *pb.VASP, stage string) (err error) {
+	if vasp.IdentityCertificate == nil {
+		return fmt.Errorf("vasp %s does not have an identity certificate", vasp.Id)
+	}
+
+	// Must unmarshal previous extra to ensure that other data is not overwritten.
+	extra := &GDSExtraData{}
+	if vasp.Extra != nil {
+		if err = vasp.Extra.UnmarshalTo(extra); err != nil {
+			return fmt.Errorf("could not deserialize previous extra: %s", err)
+		}
+	}
+
+	certID := GetCertID(vasp.IdentityCertificate)
+	records := make([]*ReissuanceStageRecord, 0, len(extra.ReissuanceStages)+1)
+	for _, record := range extra.ReissuanceStages {
+		if record.Certificate == certID && record.NotAfter == vasp.IdentityCertificate.NotAfter {
+			records = append(records, record)
+		}
+	}
+
+	extra.ReissuanceStages = append(records, &ReissuanceStageRecord{
+		Certificate: certID,
+		NotAfter:    vasp.IdentityCertificate.NotAfter,
+		Stage:       stage,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	})
+
+	// Serialize the extra back to the VASP.
+	if vasp.Extra, err = anypb.New(extra); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetReissuanceCSR returns the PEM encoded certificate signing request submitted by the
+// VASP for its next certificate reissuance or an empty string if none was submitted.
+func GetReissuanceCSR(vasp *pb.VASP) (_ string, err error) {
+	// If the extra data is nil, return empty string with no error
+	if vasp.Extra == nil {
+		return "", nil
+	}
+
+	// Unmarshal the extra data field on the VASP
+	extra := &GDSExtraData{}
+	if err = vasp.Extra.UnmarshalTo(extra); err != nil {
+		return "", err
+	}
+	return extra.GetReissuanceCsr(), nil
+}
+
+// SetReissuanceCSR on the extra data on the VASP record; an empty CSR clears the
+// submitted CSR, e.g. once it has been used to reissue the certificates of the VASP.
+func SetReissuanceCSR(vasp *pb.VASP, csr string) (err error) {
+	// Must unmarshal previous extra to ensure that other data is not overwritten.
+	extra := &GDSExtraData{}
+	if vasp.Extra != nil {
+		if err = vasp.Extra.UnmarshalTo(extra); err != nil {
+			return fmt.Errorf("could not deserialize previous extra: %s", err)
+		}
+	}
+
+	// Update the reissuance CSR
+	extra.ReissuanceCsr = csr
+
+	// Serialize the extra back to the VASP.
+	if vasp.Extra, err = anypb.New(extra); err != nil {
+		return err
+	}
+	return nil
+}
+
 // NewCertificate creates and returns a certificate associated with a VASP.
 func NewCertificate(vasp *pb.VASP, certRequest *CertificateRequest, data *pb.Certificate) (cert *Certificate, err error) {
 	// VASP must be not nil.
@@ -540,6 +767,23 @@ func UpdateCertificateRequestStatus(request *CertificateRequest, state Certifica
 	return nil
 }
 
+// CertificateRequestArchivable returns true if the certificate request has finished,
+// e.g. it has been completed, rejected, or has errored, and was last modified before
+// the specified cutoff, meaning that it no longer needs to be handled by the certman.
+func CertificateRequestArchivable(request *CertificateRequest, cutoff time.Time) bool {
+	switch request.Status {
+	case CertificateRequestState_COMPLETED, CertificateRequestState_CR_REJECTED, CertificateRequestState_CR_ERRORED:
+	default:
+		return false
+	}
+
+	modified, err := time.Parse(time.RFC3339, request.Modified)
+	if err != nil {
+		return false
+	}
+	return modified.Before(cutoff)
+}
+
 // GetReviewNotes returns all of the review notes for a VASP as a map.
 func GetReviewNotes(vasp *pb.VASP) (_ map[string]*ReviewNote, err error) {
 	// If the extra data is nil, return an empty map (no review notes).
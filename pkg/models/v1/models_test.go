@@ -402,6 +402,165 @@ func TestCertIDs(t *testing.T) {
 	require.Len(t, ids, 2)
 }
 
+func TestRevokedOn(t *testing.T) {
+	vasp := &pb.VASP{}
+
+	// No extra, Get should return an empty string
+	revokedOn, err := GetRevokedOn(vasp)
+	require.NoError(t, err)
+	require.Empty(t, revokedOn)
+
+	// Set the revocation without overwriting other extra data
+	require.NoError(t, AppendCertID(vasp, "1df61840-7033-40fb-8ce9-538c87e242f5"))
+	require.NoError(t, SetRevokedOn(vasp, "2022-03-14T12:00:00Z"))
+	revokedOn, err = GetRevokedOn(vasp)
+	require.NoError(t, err)
+	require.Equal(t, "2022-03-14T12:00:00Z", revokedOn)
+
+	ids, err := GetCertIDs(vasp)
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+
+	// Clear the revocation
+	require.NoError(t, SetRevokedOn(vasp, ""))
+	revokedOn, err = GetRevokedOn(vasp)
+	require.NoError(t, err)
+	require.Empty(t, revokedOn)
+}
+
+func TestReissuanceCSR(t *testing.T) {
+	vasp := &pb.VASP{}
+
+	// No extra, Get should return an empty string
+	csr, err := GetReissuanceCSR(vasp)
+	require.NoError(t, err)
+	require.Empty(t, csr)
+
+	// Set the CSR without overwriting other extra data
+	require.NoError(t, AppendCertID(vasp, "1df61840-7033-40fb-8ce9-538c87e242f5"))
+	require.NoError(t, SetReissuanceCSR(vasp, "-----BEGIN CERTIFICATE REQUEST-----"))
+	csr, err = GetReissuanceCSR(vasp)
+	require.NoError(t, err)
+	require.Equal(t, "-----BEGIN CERTIFICATE REQUEST-----", csr)
+
+	ids, err := GetCertIDs(vasp)
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+
+	// Clear the CSR
+	require.NoError(t, SetReissuanceCSR(vasp, ""))
+	csr, err = GetReissuanceCSR(vasp)
+	require.NoError(t, err)
+	require.Empty(t, csr)
+}
+
+func TestHealthCheck(t *testing.T) {
+	vasp := &pb.VASP{}
+
+	// No extra, Get should return nil
+	record, err := GetHealthCheck(vasp)
+	require.NoError(t, err)
+	require.Nil(t, record)
+
+	// Cannot set a nil record
+	err = SetHealthCheck(vasp, nil)
+	require.EqualError(t, err, "cannot set nil health check record on extra")
+
+	// Set the record without overwriting other extra data
+	require.NoError(t, AppendCertID(vasp, "1df61840-7033-40fb-8ce9-538c87e242f5"))
+	expected := &HealthCheckRecord{
+		Status:        pb.ServiceState_HEALTHY,
+		LastCheckedAt: "2022-03-14T12:00:00Z",
+		LastHealthyAt: "2022-03-14T12:00:00Z",
+		History: []*HealthCheckEntry{
+			{Timestamp: "2022-03-14T12:00:00Z", Status: pb.ServiceState_HEALTHY, LatencyMs: 42},
+		},
+	}
+	require.NoError(t, SetHealthCheck(vasp, expected))
+	require.Equal(t, pb.ServiceState_HEALTHY, vasp.ServiceStatus)
+
+	record, err = GetHealthCheck(vasp)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(expected, record))
+
+	ids, err := GetCertIDs(vasp)
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+}
+
+func TestDuplicates(t *testing.T) {
+	vasp := &pb.VASP{}
+
+	// No extra, Get should return nil
+	matches, err := GetDuplicates(vasp)
+	require.NoError(t, err)
+	require.Nil(t, matches)
+
+	// Set the matches without overwriting other extra data
+	require.NoError(t, AppendCertID(vasp, "1df61840-7033-40fb-8ce9-538c87e242f5"))
+	expected := []*DuplicateMatch{
+		{VaspId: "b6b1b1a5-bf23-4d4d-9f3b-3d4b1b2cfc4e", CommonName: "trisa.example.com", Reasons: []string{"lei"}, Score: 1, DetectedOn: "2022-03-14T12:00:00Z"},
+	}
+	require.NoError(t, SetDuplicates(vasp, expected))
+
+	matches, err = GetDuplicates(vasp)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	require.True(t, proto.Equal(expected[0], matches[0]))
+
+	ids, err := GetCertIDs(vasp)
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+
+	// Clearing the matches should remove them
+	require.NoError(t, SetDuplicates(vasp, nil))
+	matches, err = GetDuplicates(vasp)
+	require.NoError(t, err)
+	require.Empty(t, matches)
+}
+
+func TestReissuanceStages(t *testing.T) {
+	vasp := &pb.VASP{}
+
+	// Cannot record a stage without an identity certificate
+	require.Error(t, RecordReissuanceStage(vasp, "remind:contacts:reissuance_reminder:720h0m0s"))
+
+	fired, err := ReissuanceStageFired(vasp, "remind:contacts:reissuance_reminder:720h0m0s")
+	require.NoError(t, err)
+	require.False(t, fired)
+
+	// Record the stage without overwriting other extra data
+	vasp.IdentityCertificate = &pb.Certificate{SerialNumber: []byte{0x0a, 0x1b}, NotAfter: "2023-03-14T12:00:00Z"}
+	require.NoError(t, AppendCertID(vasp, "1df61840-7033-40fb-8ce9-538c87e242f5"))
+	require.NoError(t, RecordReissuanceStage(vasp, "remind:contacts:reissuance_reminder:720h0m0s"))
+
+	fired, err = ReissuanceStageFired(vasp, "remind:contacts:reissuance_reminder:720h0m0s")
+	require.NoError(t, err)
+	require.True(t, fired)
+
+	fired, err = ReissuanceStageFired(vasp, "remind:contacts:reissuance_reminder:168h0m0s")
+	require.NoError(t, err)
+	require.False(t, fired)
+
+	ids, err := GetCertIDs(vasp)
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+
+	// Stages should fire again for a new identity certificate
+	vasp.IdentityCertificate = &pb.Certificate{SerialNumber: []byte{0x0c, 0x2d}, NotAfter: "2024-03-14T12:00:00Z"}
+	fired, err = ReissuanceStageFired(vasp, "remind:contacts:reissuance_reminder:720h0m0s")
+	require.NoError(t, err)
+	require.False(t, fired)
+
+	// Records for previous certificates should be discarded
+	require.NoError(t, RecordReissuanceStage(vasp, "reissue:admins:reissuance_admin_notification:240h0m0s"))
+	extra := &GDSExtraData{}
+	require.NoError(t, vasp.Extra.UnmarshalTo(extra))
+	require.Len(t, extra.ReissuanceStages, 1)
+	require.Equal(t, "0C2D", extra.ReissuanceStages[0].Certificate)
+	require.Equal(t, "reissue:admins:reissuance_admin_notification:240h0m0s", extra.ReissuanceStages[0].Stage)
+}
+
 func TestCertReqIDs(t *testing.T) {
 	vasp := &pb.VASP{}
 
@@ -748,6 +907,33 @@ func TestUpdateCertificateRequestStatus(t *testing.T) {
 	require.Equal(t, "automated", request.AuditLog[1].Source)
 }
 
+func TestCertificateRequestArchivable(t *testing.T) {
+	cutoff := time.Now().Add(-24 * time.Hour)
+	old := cutoff.Add(-time.Hour).Format(time.RFC3339)
+	recent := time.Now().Format(time.RFC3339)
+
+	testCases := []struct {
+		status   CertificateRequestState
+		modified string
+		expected bool
+	}{
+		{CertificateRequestState_INITIALIZED, old, false},
+		{CertificateRequestState_READY_TO_SUBMIT, old, false},
+		{CertificateRequestState_PROCESSING, old, false},
+		{CertificateRequestState_DOWNLOADED, old, false},
+		{CertificateRequestState_COMPLETED, old, true},
+		{CertificateRequestState_CR_REJECTED, old, true},
+		{CertificateRequestState_CR_ERRORED, old, true},
+		{CertificateRequestState_COMPLETED, recent, false},
+		{CertificateRequestState_COMPLETED, "", false},
+	}
+
+	for _, tc := range testCases {
+		req := &CertificateRequest{Status: tc.status, Modified: tc.modified}
+		require.Equal(t, tc.expected, CertificateRequestArchivable(req, cutoff), "unexpected archivable result for %s request modified %q", tc.status, tc.modified)
+	}
+}
+
 func TestIsTraveler(t *testing.T) {
 	vasp := &pb.VASP{CommonName: "trisa.example.com"}
 	require.False(t, IsTraveler(vasp))
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrEmailDelivered is returned when attempting to requeue an email that was delivered.
+var ErrEmailDelivered = errors.New("email has already been delivered")
+
+// NewEmail creates a queued outbox email that delivers the JSON encoded draft to the
+// recipient. The ID of the email is assigned when it is created in the store.
+func NewEmail(reason, subject, recipient, vaspID string, draft []byte) (*Email, error) {
+	if recipient == "" || len(draft) == 0 {
+		return nil, errors.New("must supply a recipient and draft for email creation")
+	}
+
+	return &Email{
+		Reason:      reason,
+		Subject:     subject,
+		Recipient:   recipient,
+		Vasp:        vaspID,
+		Draft:       draft,
+		Status:      EmailState_EMAIL_QUEUED,
+		NextAttempt: time.Now().Format(time.RFC3339),
+	}, nil
+}
+
+// IsFinished returns true if the email has been delivered or has been moved to the
+// dead letter state and will not be attempted unless it is retried by an admin.
+func (e *Email) IsFinished() bool {
+	return e.Status == EmailState_EMAIL_DELIVERED || e.Status == EmailState_EMAIL_DEAD_LETTER
+}
+
+// Due returns true if delivery of the email should be attempted at the specified time.
+func (e *Email) Due(now time.Time) bool {
+	if e.IsFinished() {
+		return false
+	}
+
+	if e.NextAttempt == "" {
+		return true
+	}
+
+	next, err := time.Parse(time.RFC3339, e.NextAttempt)
+	if err != nil {
+		return true
+	}
+	return !next.After(now)
+}
+
+// Requeue an email that has not been delivered so that delivery is attempted again
+// with a fresh set of attempts, e.g. when an admin retries a dead letter.
+func (e *Email) Requeue() error {
+	if e.Status == EmailState_EMAIL_DELIVERED {
+		return ErrEmailDelivered
+	}
+
+	e.Status = EmailState_EMAIL_QUEUED
+	e.Attempts = 0
+	e.NextAttempt = time.Now().Format(time.RFC3339)
+	return nil
+}
+
+// ParseEmailState parses an email state from a string that is case insensitive and
+// may omit the email prefix, e.g. "dead_letter" or "EMAIL_DEAD_LETTER".
+func ParseEmailState(s string) (EmailState, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if !strings.HasPrefix(s, "EMAIL_") {
+		s = "EMAIL_" + s
+	}
+
+	if state, ok := EmailState_value[s]; ok {
+		return EmailState(state), nil
+	}
+	return 0, fmt.Errorf("unknown email state %q", s)
+}
@@ -24,6 +24,11 @@ func GetCertificateRequestParams(request *CertificateRequest, profile string) (p
 		return nil, fmt.Errorf("unknown profile: %s", profile)
 	}
 
+	// Certificates issued from a CSR are not encrypted so no password is submitted
+	if request.Csr != "" {
+		required = withoutParam(required, sectigo.ParamPassword)
+	}
+
 	if request.Params == nil {
 		request.Params = make(map[string]string)
 	}
@@ -43,7 +48,7 @@ func GetCertificateRequestParams(request *CertificateRequest, profile string) (p
 	}
 
 	// Validate the parameters to ensure they can be submitted to Sectigo
-	if err = ValidateCertificateRequestParams(params, profile); err != nil {
+	if err = validateParams(params, required); err != nil {
 		return nil, err
 	}
 
@@ -69,7 +74,10 @@ func ValidateCertificateRequestParams(params map[string]string, profile string)
 	if !ok {
 		return fmt.Errorf("unknown profile: %s", profile)
 	}
+	return validateParams(params, required)
+}
 
+func validateParams(params map[string]string, required []string) (err error) {
 	// Check that all required parameters are in the params and that the params are
 	// populated with a non-empty value.
 	for _, key := range required {
@@ -89,3 +97,14 @@ func ValidateCertificateRequestParams(params map[string]string, profile string)
 	// TODO: Check to ensure the common name is in the dNSNAmes
 	return nil
 }
+
+// Returns a copy of the sorted required params without the specified param.
+func withoutParam(required []string, param string) []string {
+	params := make([]string, 0, len(required))
+	for _, key := range required {
+		if key != param {
+			params = append(params, key)
+		}
+	}
+	return params
+}
@@ -84,6 +84,26 @@ func TestGetCertificateRequestParams(t *testing.T) {
 	}
 }
 
+// Test that the pkcs12 password is not required or submitted for CSR requests.
+func TestGetCertificateRequestParamsCSR(t *testing.T) {
+	request := &models.CertificateRequest{
+		Csr: "-----BEGIN CERTIFICATE REQUEST-----",
+		Params: map[string]string{
+			"commonName":     "api.alice.vaspbot.net",
+			"dNSName":        "api.alice.vaspbot.net",
+			"pkcs12Password": "supersecretsquirrel",
+		},
+	}
+
+	params, err := models.GetCertificateRequestParams(request, sectigo.ProfileCipherTraceEE)
+	require.NoError(t, err, "expected params to be valid without a pkcs12 password")
+	require.NotContains(t, params, sectigo.ParamPassword, "expected pkcs12 password to be excluded from CSR params")
+	require.Equal(t, "api.alice.vaspbot.net", params[sectigo.ParamCommonName])
+
+	// The profile required params should not be modified
+	require.Contains(t, sectigo.Profiles[sectigo.ProfileCipherTraceEE], sectigo.ParamPassword)
+}
+
 // Test updating params on a certificate request model.
 func TestUpdateCertificateRequestParams(t *testing.T) {
 	// Test updating a nil map
@@ -0,0 +1,243 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Usages of the active certificates of a VASP.
+const (
+	CertificateUsageIdentity = "identity"
+	CertificateUsageSigning  = "signing"
+)
+
+// The Lookup reply only has fields for a single identity and signing certificate, so
+// the full set of active certificates and the rotation overlap are returned in the
+// gRPC response headers. Each active certificates header value is a protocol buffer
+// encoded members ActiveCertificate.
+const (
+	ActiveCertificatesMetadataKey = "trisa-active-certificates-bin"
+	RotationOverlapMetadataKey    = "trisa-rotation-overlap"
+)
+
+// GetActiveCertificates returns the identity and signing certificates of the VASP that
+// have not been revoked or expired at the specified time. Certificates that are not yet
+// valid are returned so that peers can pre-trust them. If no certificates of a usage
+// have been recorded on the VASP (e.g. for VASPs verified before rotation was tracked),
+// the certificates on the VASP record are returned, using the identity certificate and
+// the last signing certificate that has not been revoked as the primary certificates.
+func GetActiveCertificates(vasp *pb.VASP, now time.Time) (active []*ActiveCertificate, err error) {
+	var stored []*ActiveCertificate
+	if stored, err = getActiveCertificates(vasp); err != nil {
+		return nil, err
+	}
+
+	recorded := make(map[string]bool, 2)
+	for _, cert := range stored {
+		recorded[cert.Usage] = true
+	}
+
+	certs := make([]*ActiveCertificate, 0, len(stored)+len(vasp.SigningCertificates)+1)
+	if recorded[CertificateUsageIdentity] {
+		certs = append(certs, filterUsage(stored, CertificateUsageIdentity)...)
+	} else if vasp.IdentityCertificate != nil {
+		certs = append(certs, NewActiveCertificate(CertificateUsageIdentity, vasp.IdentityCertificate, true))
+	}
+
+	if recorded[CertificateUsageSigning] {
+		certs = append(certs, filterUsage(stored, CertificateUsageSigning)...)
+	} else {
+		primary := true
+		for i := len(vasp.SigningCertificates) - 1; i >= 0; i-- {
+			cert := NewActiveCertificate(CertificateUsageSigning, vasp.SigningCertificates[i], primary)
+			if cert.ActiveAt(now) {
+				certs = append(certs, cert)
+				primary = false
+			}
+		}
+	}
+
+	active = make([]*ActiveCertificate, 0, len(certs))
+	for _, cert := range certs {
+		if cert.ActiveAt(now) {
+			active = append(active, cert)
+		}
+	}
+	return active, nil
+}
+
+// ActiveAt returns true if the certificate has not been revoked and has not reached
+// the end of its trust window at the specified time.
+func (c *ActiveCertificate) ActiveAt(now time.Time) bool {
+	if c.Details == nil || c.Details.Revoked {
+		return false
+	}
+
+	if notAfter, err := time.Parse(time.RFC3339, c.NotAfter); err == nil && !notAfter.After(now) {
+		return false
+	}
+	return true
+}
+
+// RotateCertificate makes the certificate the primary certificate of the usage on the
+// VASP record. The previous primary certificate remains active until the end of the
+// overlap period (or until it expires if that is sooner) so that peers that have
+// cached it can continue to connect while they pre-trust the new certificate. Revoked
+// and expired certificates are removed from the active certificates.
+func RotateCertificate(vasp *pb.VASP, usage string, cert *pb.Certificate, overlap time.Duration) (err error) {
+	if usage != CertificateUsageIdentity && usage != CertificateUsageSigning {
+		return fmt.Errorf("unknown certificate usage %q", usage)
+	}
+
+	if cert == nil || len(cert.SerialNumber) == 0 {
+		return errors.New("cannot rotate to a certificate without a serial number")
+	}
+
+	now := time.Now()
+	var active []*ActiveCertificate
+	if active, err = GetActiveCertificates(vasp, now); err != nil {
+		return fmt.Errorf("could not deserialize previous extra: %s", err)
+	}
+
+	// Certificates of the other usage are only stored if they have been recorded so
+	// that the certificates on the VASP record continue to be used until rotated.
+	var recorded []*ActiveCertificate
+	if recorded, err = getActiveCertificates(vasp); err != nil {
+		return fmt.Errorf("could not deserialize previous extra: %s", err)
+	}
+
+	other := false
+	for _, prev := range recorded {
+		if prev.Usage != usage {
+			other = true
+			break
+		}
+	}
+
+	certID := GetCertID(cert)
+	retired := now.Add(overlap)
+	rotated := make([]*ActiveCertificate, 0, len(active)+1)
+	for _, prev := range active {
+		if prev.Usage != usage && !other {
+			continue
+		}
+
+		if prev.Usage == usage {
+			if prev.Id == certID {
+				continue
+			}
+
+			if prev.Primary {
+				prev.Primary = false
+				if notAfter, err := time.Parse(time.RFC3339, prev.NotAfter); err != nil || retired.Before(notAfter) {
+					prev.NotAfter = retired.Format(time.RFC3339)
+				}
+			}
+		}
+		rotated = append(rotated, prev)
+	}
+	rotated = append(rotated, NewActiveCertificate(usage, cert, true))
+
+	if err = setActiveCertificates(vasp, rotated); err != nil {
+		return err
+	}
+
+	// Update the certificate on the VASP record that is returned for new connections
+	switch usage {
+	case CertificateUsageIdentity:
+		vasp.IdentityCertificate = cert
+	case CertificateUsageSigning:
+		for _, signing := range vasp.SigningCertificates {
+			if GetCertID(signing) == certID {
+				return nil
+			}
+		}
+		vasp.SigningCertificates = append(vasp.SigningCertificates, cert)
+	}
+	return nil
+}
+
+// RevokeActiveCertificate marks the active certificates with the certificate ID as
+// revoked so that they are no longer returned as active certificates.
+func RevokeActiveCertificate(vasp *pb.VASP, certID string) (err error) {
+	// If the extra data is nil, there are no recorded active certificates.
+	if vasp.Extra == nil {
+		return nil
+	}
+
+	extra := &GDSExtraData{}
+	if err = vasp.Extra.UnmarshalTo(extra); err != nil {
+		return fmt.Errorf("could not deserialize previous extra: %s", err)
+	}
+
+	for _, cert := range extra.ActiveCertificates {
+		if cert.Id == certID && cert.Details != nil {
+			cert.Details.Revoked = true
+		}
+	}
+
+	// Serialize the extra back to the VASP.
+	if vasp.Extra, err = anypb.New(extra); err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewActiveCertificate creates an active certificate that is trusted for the validity
+// period of the certificate.
+func NewActiveCertificate(usage string, cert *pb.Certificate, primary bool) *ActiveCertificate {
+	return &ActiveCertificate{
+		Id:        GetCertID(cert),
+		Usage:     usage,
+		Primary:   primary,
+		NotBefore: cert.NotBefore,
+		NotAfter:  cert.NotAfter,
+		Details:   proto.Clone(cert).(*pb.Certificate),
+	}
+}
+
+func getActiveCertificates(vasp *pb.VASP) (_ []*ActiveCertificate, err error) {
+	// If the extra data is nil, there are no recorded active certificates.
+	if vasp.Extra == nil {
+		return nil, nil
+	}
+
+	extra := &GDSExtraData{}
+	if err = vasp.Extra.UnmarshalTo(extra); err != nil {
+		return nil, err
+	}
+	return extra.ActiveCertificates, nil
+}
+
+func setActiveCertificates(vasp *pb.VASP, certs []*ActiveCertificate) (err error) {
+	// Must unmarshal previous extra to ensure that other data is not overwritten.
+	extra := &GDSExtraData{}
+	if vasp.Extra != nil {
+		if err = vasp.Extra.UnmarshalTo(extra); err != nil {
+			return fmt.Errorf("could not deserialize previous extra: %s", err)
+		}
+	}
+
+	extra.ActiveCertificates = certs
+
+	// Serialize the extra back to the VASP.
+	if vasp.Extra, err = anypb.New(extra); err != nil {
+		return err
+	}
+	return nil
+}
+
+func filterUsage(certs []*ActiveCertificate, usage string) []*ActiveCertificate {
+	filtered := make([]*ActiveCertificate, 0, len(certs))
+	for _, cert := range certs {
+		if cert.Usage == usage {
+			filtered = append(filtered, cert)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,141 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	. "github.com/trisacrypto/directory/pkg/models/v1"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+func TestActiveCertificatesLegacy(t *testing.T) {
+	now := time.Now()
+	notAfter := now.AddDate(1, 0, 0).Format(time.RFC3339)
+
+	// The certificates on the VASP record are active if none have been recorded
+	vasp := &pb.VASP{
+		IdentityCertificate: &pb.Certificate{SerialNumber: []byte{0x0a}, NotAfter: notAfter},
+		SigningCertificates: []*pb.Certificate{
+			{SerialNumber: []byte{0x0b}, NotAfter: notAfter},
+			{SerialNumber: []byte{0x0c}, NotAfter: notAfter},
+			{SerialNumber: []byte{0x0d}, NotAfter: notAfter, Revoked: true},
+			{SerialNumber: []byte{0x0e}, NotAfter: now.AddDate(0, 0, -1).Format(time.RFC3339)},
+		},
+	}
+
+	active, err := GetActiveCertificates(vasp, now)
+	require.NoError(t, err)
+	require.Len(t, active, 3, "revoked and expired certificates should not be active")
+
+	expected := []struct {
+		id      string
+		usage   string
+		primary bool
+	}{
+		{"0A", CertificateUsageIdentity, true},
+		{"0C", CertificateUsageSigning, true},
+		{"0B", CertificateUsageSigning, false},
+	}
+	for i, tc := range expected {
+		require.Equal(t, tc.id, active[i].Id)
+		require.Equal(t, tc.usage, active[i].Usage)
+		require.Equal(t, tc.primary, active[i].Primary)
+		require.Equal(t, notAfter, active[i].NotAfter)
+	}
+
+	// A VASP with no certificates has no active certificates
+	active, err = GetActiveCertificates(&pb.VASP{}, now)
+	require.NoError(t, err)
+	require.Empty(t, active)
+}
+
+func TestRotateCertificate(t *testing.T) {
+	now := time.Now()
+	overlap := 30 * 24 * time.Hour
+
+	vasp := &pb.VASP{
+		IdentityCertificate: &pb.Certificate{
+			SerialNumber: []byte{0x01},
+			NotBefore:    now.AddDate(-1, 0, 0).Format(time.RFC3339),
+			NotAfter:     now.AddDate(0, 2, 0).Format(time.RFC3339),
+		},
+		SigningCertificates: []*pb.Certificate{
+			{SerialNumber: []byte{0x0b}, NotAfter: now.AddDate(1, 0, 0).Format(time.RFC3339)},
+		},
+	}
+
+	// Invalid rotations
+	require.EqualError(t, RotateCertificate(vasp, "unknown", &pb.Certificate{SerialNumber: []byte{0x02}}, overlap), `unknown certificate usage "unknown"`)
+	require.EqualError(t, RotateCertificate(vasp, CertificateUsageIdentity, &pb.Certificate{}, overlap), "cannot rotate to a certificate without a serial number")
+
+	// Rotating the identity certificate keeps the previous certificate for the overlap
+	next := &pb.Certificate{
+		SerialNumber: []byte{0x02},
+		NotBefore:    now.Format(time.RFC3339),
+		NotAfter:     now.AddDate(1, 0, 0).Format(time.RFC3339),
+	}
+	require.NoError(t, RotateCertificate(vasp, CertificateUsageIdentity, next, overlap))
+	require.Equal(t, next, vasp.IdentityCertificate, "expected the new certificate to be the identity certificate")
+
+	active, err := GetActiveCertificates(vasp, now)
+	require.NoError(t, err)
+	require.Len(t, active, 3)
+
+	require.Equal(t, "01", active[0].Id)
+	require.False(t, active[0].Primary)
+	retired, err := time.Parse(time.RFC3339, active[0].NotAfter)
+	require.NoError(t, err)
+	require.WithinDuration(t, now.Add(overlap), retired, time.Second, "expected the previous certificate to be retired after the overlap")
+
+	require.Equal(t, "02", active[1].Id)
+	require.True(t, active[1].Primary)
+	require.Equal(t, next.NotBefore, active[1].NotBefore)
+	require.Equal(t, next.NotAfter, active[1].NotAfter)
+
+	// Signing certificates are still read from the VASP record
+	require.Equal(t, "0B", active[2].Id)
+	require.Equal(t, CertificateUsageSigning, active[2].Usage)
+	require.True(t, active[2].Primary)
+
+	// The previous certificate is no longer active after the overlap
+	active, err = GetActiveCertificates(vasp, now.Add(overlap+time.Hour))
+	require.NoError(t, err)
+	require.Len(t, active, 2)
+	require.Equal(t, "02", active[0].Id)
+
+	// The overlap does not extend the validity of a certificate that expires sooner
+	final := &pb.Certificate{SerialNumber: []byte{0x03}, NotAfter: now.AddDate(2, 0, 0).Format(time.RFC3339)}
+	require.NoError(t, RotateCertificate(vasp, CertificateUsageIdentity, final, 365*24*time.Hour))
+	active, err = GetActiveCertificates(vasp, now)
+	require.NoError(t, err)
+	require.Len(t, active, 4)
+	require.Equal(t, "01", active[0].Id)
+	require.Equal(t, "02", active[1].Id)
+	require.False(t, active[1].Primary)
+	require.Equal(t, next.NotAfter, active[1].NotAfter)
+	require.True(t, active[2].Primary)
+
+	// Revoked certificates are no longer active
+	require.NoError(t, RevokeActiveCertificate(vasp, "02"))
+	active, err = GetActiveCertificates(vasp, now)
+	require.NoError(t, err)
+	require.Len(t, active, 3)
+	for _, cert := range active {
+		require.NotEqual(t, "02", cert.Id)
+	}
+
+	// Rotating the signing certificate appends it to the VASP record
+	signing := &pb.Certificate{SerialNumber: []byte{0x0c}, NotAfter: now.AddDate(1, 0, 0).Format(time.RFC3339)}
+	require.NoError(t, RotateCertificate(vasp, CertificateUsageSigning, signing, overlap))
+	require.Len(t, vasp.SigningCertificates, 2)
+	require.Equal(t, signing, vasp.SigningCertificates[1])
+
+	active, err = GetActiveCertificates(vasp, now)
+	require.NoError(t, err)
+	require.Len(t, active, 4)
+	require.Equal(t, "0B", active[2].Id)
+	require.False(t, active[2].Primary)
+	require.Equal(t, "0C", active[3].Id)
+	require.True(t, active[3].Primary)
+}
@@ -0,0 +1,138 @@
+package mock
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"mime/multipart"
+	"sync"
+	"time"
+
+	"github.com/trisacrypto/trisa/pkg/trust"
+)
+
+// issuer is a lightweight certificate authority that signs uploaded CSRs so that tests
+// can download certificates issued for a public key they control.
+type issuer struct {
+	sync.Mutex
+	cacrt  *x509.Certificate
+	cakey  *ecdsa.PrivateKey
+	issued *trust.Provider
+}
+
+// Issue a certificate for the PEM encoded CSR in the uploaded file.
+func (i *issuer) Issue(fh *multipart.FileHeader) (err error) {
+	var f multipart.File
+	if f, err = fh.Open(); err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var data []byte
+	if data, err = io.ReadAll(f); err != nil {
+		return err
+	}
+
+	var csr *x509.CertificateRequest
+	if csr, err = trust.PEMDecodeCSR(data); err != nil {
+		return fmt.Errorf("could not parse csr: %w", err)
+	}
+
+	if err = csr.CheckSignature(); err != nil {
+		return fmt.Errorf("invalid csr signature: %w", err)
+	}
+
+	i.Lock()
+	defer i.Unlock()
+	if i.cacrt == nil {
+		if err = i.init(); err != nil {
+			return err
+		}
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber(),
+		Subject:      csr.Subject,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().AddDate(1, 1, 0),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		DNSNames:     csr.DNSNames,
+	}
+
+	var signed []byte
+	if signed, err = x509.CreateCertificate(rand.Reader, template, i.cacrt, csr.PublicKey, i.cakey); err != nil {
+		return err
+	}
+
+	var chain []byte
+	for _, der := range [][]byte{signed, i.cacrt.Raw} {
+		var cert *x509.Certificate
+		if cert, err = x509.ParseCertificate(der); err != nil {
+			return err
+		}
+
+		var block []byte
+		if block, err = trust.PEMEncodeCertificate(cert); err != nil {
+			return err
+		}
+		chain = append(chain, block...)
+	}
+
+	if i.issued, err = trust.New(chain); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Download returns a zip archive with the public chain of the most recently issued
+// certificate.
+func (i *issuer) Download() (_ []byte, err error) {
+	i.Lock()
+	defer i.Unlock()
+	if i.issued == nil {
+		return nil, errors.New("no certificates have been issued from a csr")
+	}
+
+	var sz *trust.Serializer
+	if sz, err = trust.NewSerializer(false, "", trust.CompressionZIP); err != nil {
+		return nil, err
+	}
+	return sz.Compress(i.issued)
+}
+
+func (i *issuer) init() (err error) {
+	if i.cakey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+		return err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber(),
+		Subject:               pkix.Name{CommonName: "Mock Sectigo CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().AddDate(10, 0, 0),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	var signed []byte
+	if signed, err = x509.CreateCertificate(rand.Reader, template, template, &i.cakey.PublicKey, i.cakey); err != nil {
+		return err
+	}
+
+	i.cacrt, err = x509.ParseCertificate(signed)
+	return err
+}
+
+func serialNumber() *big.Int {
+	sn := make([]byte, 16)
+	rand.Read(sn)
+	return new(big.Int).SetBytes(sn)
+}
@@ -31,6 +31,13 @@ import (
 
 var mockServer *Server
 
+// Batch IDs returned by the mock server; certificates in the CSR batch are issued for
+// the public key of the uploaded CSR and are downloaded without a private key.
+const (
+	BatchID    = 42
+	CSRBatchID = 43
+)
+
 type Profile struct {
 	ID      string
 	Name    string
@@ -137,6 +144,7 @@ type Server struct {
 	calls           sync.Map
 	profileLookup   map[string]Profile
 	authorityLookup map[int]Profile
+	issuer          *issuer
 }
 
 // Get returns the current mock server.
@@ -158,6 +166,7 @@ func Start(profile string) error {
 		router:          gin.New(),
 		profileLookup:   make(map[string]Profile),
 		authorityLookup: make(map[int]Profile),
+		issuer:          &issuer{},
 	}
 
 	// TODO: We are only loading one authority so that we can get consistent behavior
@@ -397,7 +406,7 @@ func (s *Server) createSingleCertBatch(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, &sectigo.BatchResponse{
-		BatchID:      42,
+		BatchID:      BatchID,
 		CreationDate: time.Now().Format(time.RFC3339),
 		Status:       sectigo.BatchStatusReadyForDownload,
 		Active:       false,
@@ -431,10 +440,17 @@ func (s *Server) uploadCSR(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, fmt.Errorf("expected 1 file, got %d", len(files)))
 		return
 	}
+
+	// Issue a certificate for the uploaded CSR so that it can be downloaded
+	if err = s.issuer.Issue(files[0]); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, &sectigo.BatchResponse{
-		BatchID:      42,
+		BatchID:      CSRBatchID,
 		CreationDate: time.Now().Format(time.RFC3339),
-		Status:       "completed",
+		Status:       sectigo.BatchStatusReadyForDownload,
 		Active:       false,
 	})
 }
@@ -448,7 +464,7 @@ func (s *Server) batchDetail(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, &sectigo.BatchResponse{
-		BatchID:      42,
+		BatchID:      BatchID,
 		CreationDate: time.Now().Format(time.RFC3339),
 		Status:       sectigo.BatchStatusReadyForDownload,
 		Active:       false,
@@ -489,6 +505,19 @@ func (s *Server) download(c *gin.Context) {
 		return
 	}
 
+	// Certificates issued from an uploaded CSR only contain the public chain
+	if id == strconv.Itoa(CSRBatchID) {
+		data, err := s.issuer.Download()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, err)
+			return
+		}
+
+		c.Header("Content-Disposition", "attachment; filename=certificate.zip")
+		c.Data(http.StatusOK, "application/zip", data)
+		return
+	}
+
 	// Using runtime.Caller allows us to load the fixture using a path relative to this
 	// file, since the mock can be invoked from a few different packages.
 	_, thisFile, _, ok := runtime.Caller(0)
@@ -1,6 +1,12 @@
 package sectigo_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
 	"net/http"
 	"os"
@@ -130,9 +136,16 @@ func (s *SectigoTestSuite) createSingleCertBatch(t *testing.T) {
 }
 
 func (s *SectigoTestSuite) uploadCSRBatch(t *testing.T) {
-	rep, err := s.api.UploadCSRBatch(42, "foo", []byte("foo"), map[string]string{"foo": "bar"})
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "foo"}}, key)
+	require.NoError(t, err)
+	csr := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+
+	rep, err := s.api.UploadCSRBatch(42, "foo", csr, map[string]string{"foo": "bar"})
 	require.NoError(t, err)
 	require.NotNil(t, rep)
+	require.Equal(t, mock.CSRBatchID, rep.BatchID)
 }
 
 func (s *SectigoTestSuite) batchDetail(t *testing.T) {
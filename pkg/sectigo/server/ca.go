@@ -1,7 +1,11 @@
 package server
 
 import (
+	"crypto/x509"
+	"encoding/json"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"strconv"
 
@@ -76,7 +80,77 @@ func (s *Server) CreateSingleCertBatch(c *gin.Context) {
 }
 
 func (s *Server) UploadCSRBatch(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, Err("this endpoint has not been implemented yet"))
+	form, err := c.MultipartForm()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Err(err))
+		return
+	}
+
+	// The batch request is JSON serialized in a file part of the multipart form
+	info := &sectigo.UploadCSRBatchRequest{}
+	if parts := form.File["csrBatchRequest"]; len(parts) == 1 {
+		if err = readJSONPart(parts[0], info); err != nil {
+			c.JSON(http.StatusBadRequest, Err(err))
+			return
+		}
+	} else {
+		c.JSON(http.StatusBadRequest, Err("csr batch request required"))
+		return
+	}
+
+	// Determine the profile from the profile ID
+	var profile string
+	switch strconv.Itoa(info.ProfileID) {
+	case sectigo.ProfileIDCipherTraceEE:
+		profile = sectigo.ProfileCipherTraceEE
+	case sectigo.ProfileIDCipherTraceEndEntityCertificate:
+		profile = sectigo.ProfileCipherTraceEndEntityCertificate
+	default:
+		c.JSON(http.StatusBadRequest, Err("unknown profile"))
+		return
+	}
+
+	// TODO: handle bulk uploads of multiple CSRs
+	files := form.File["files"]
+	if len(files) != 1 {
+		c.JSON(http.StatusBadRequest, Err("exactly one csr file required"))
+		return
+	}
+
+	var csr *x509.CertificateRequest
+	if csr, err = readCSRPart(files[0]); err != nil {
+		c.JSON(http.StatusBadRequest, Err(err))
+		return
+	}
+
+	// Create a batch from the uploaded csr
+	batch, err := s.store.AddCSRBatch(profile, info)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Err(err))
+		return
+	}
+
+	rep := &sectigo.BatchResponse{
+		BatchID:         batch.BatchID,
+		OrderNumber:     batch.OrderNumber,
+		CreationDate:    batch.CreationDate,
+		Profile:         batch.Profile,
+		Size:            batch.Size,
+		Status:          batch.Status,
+		Active:          batch.Active > 0,
+		BatchName:       batch.BatchName,
+		RejectReason:    batch.RejectReason,
+		GeneratorValues: nil,
+		UserID:          UserID,
+		Downloadable:    batch.Status == sectigo.BatchStatusReadyForDownload,
+		Rejectable:      batch.Status == sectigo.BatchStatusProcessing,
+	}
+
+	// After a suitable delay to mimic the real service, issue certs
+	go s.CreateCSRCerts(csr, batch.BatchID)
+
+	// Return the batch response
+	c.JSON(http.StatusCreated, rep)
 }
 
 func (s *Server) BatchDetail(c *gin.Context) {
@@ -171,20 +245,28 @@ func (s *Server) Download(c *gin.Context) {
 		return
 	}
 
-	// Get the pkcs12 password
-	pkcs12password := batch.Params.Get(sectigo.ParamPassword, "")
-	if pkcs12password == "" {
-		c.JSON(http.StatusInternalServerError, Err("batch does not have pkcs12 password"))
-		return
-	}
+	// Certificates issued from an uploaded CSR only contain the public chain, otherwise
+	// the pkcs12 password is required to encrypt the private key.
+	var sz *trust.Serializer
+	if batch.Uploaded {
+		if sz, err = trust.NewSerializer(false, "", trust.CompressionZIP); err != nil {
+			c.JSON(http.StatusInternalServerError, Err(err))
+			return
+		}
+	} else {
+		pkcs12password := batch.Params.Get(sectigo.ParamPassword, "")
+		if pkcs12password == "" {
+			c.JSON(http.StatusInternalServerError, Err("batch does not have pkcs12 password"))
+			return
+		}
 
-	certData, err := s.store.GetCertData(batch.SerialNumbers[0])
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, Err(err))
-		return
+		if sz, err = trust.NewSerializer(true, pkcs12password, trust.CompressionZIP); err != nil {
+			c.JSON(http.StatusInternalServerError, Err(err))
+			return
+		}
 	}
 
-	sz, err := trust.NewSerializer(true, pkcs12password, trust.CompressionZIP)
+	certData, err := s.store.GetCertData(batch.SerialNumbers[0])
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, Err(err))
 		return
@@ -350,3 +432,34 @@ func ParseID(c *gin.Context) (id int, err error) {
 	}
 	return int(id64), nil
 }
+
+func readJSONPart(fh *multipart.FileHeader, v interface{}) (err error) {
+	var f multipart.File
+	if f, err = fh.Open(); err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewDecoder(f).Decode(v)
+}
+
+func readCSRPart(fh *multipart.FileHeader) (csr *x509.CertificateRequest, err error) {
+	var f multipart.File
+	if f, err = fh.Open(); err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var data []byte
+	if data, err = io.ReadAll(f); err != nil {
+		return nil, err
+	}
+
+	if csr, err = trust.PEMDecodeCSR(data); err != nil {
+		return nil, fmt.Errorf("could not parse csr: %w", err)
+	}
+
+	if err = csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("invalid csr signature: %w", err)
+	}
+	return csr, nil
+}
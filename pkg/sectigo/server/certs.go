@@ -47,6 +47,27 @@ func (s *Server) CreateCerts(params Params, profile string, batchID int) {
 	log.Info().Str("common_name", sub.CommonName).Msg("certificates issued")
 }
 
+func (s *Server) CreateCSRCerts(csr *x509.CertificateRequest, batchID int) {
+	// Wait 5 minutes to simulate real processing times
+	time.Sleep(5 * time.Minute)
+
+	certs, err := s.certs.IssueCSR(csr)
+	if err != nil {
+		sentry.Error(nil).Err(err).Msg("could not issue certs from csr")
+		if err = s.store.RejectBatch(batchID, err.Error()); err != nil {
+			sentry.Error(nil).Err(err).Msg("could not reject batch")
+		}
+		return
+	}
+
+	if err := s.store.AddCert(batchID, certs); err != nil {
+		sentry.Error(nil).Err(err).Msg("could not add certs to store")
+		return
+	}
+
+	log.Info().Str("common_name", csr.Subject.CommonName).Msg("certificates issued from csr")
+}
+
 type Certs struct {
 	cacrt *x509.Certificate
 	cakey crypto.PrivateKey
@@ -90,20 +111,62 @@ func (c *Certs) Issue(sub pkix.Name) (_ []byte, err error) {
 	}
 	cert.SubjectKeyId = []byte(keyID)
 
-	if signed, err = x509.CreateCertificate(rand.Reader, cert, c.cacrt, &key.PublicKey, c.cakey); err != nil {
+	if signed, err = c.sign(cert, &key.PublicKey); err != nil {
 		return nil, err
 	}
+	chain.Write(signed)
 
-	// Encode the certificate chain
-	if err = pem.Encode(&chain, &pem.Block{Type: "CERTIFICATE", Bytes: signed}); err != nil {
+	if err = pem.Encode(&chain, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}); err != nil {
 		return nil, err
 	}
 
-	if err = pem.Encode(&chain, &pem.Block{Type: "CERTIFICATE", Bytes: c.cacrt.Raw}); err != nil {
+	return chain.Bytes(), nil
+}
+
+// IssueCSR issues a certificate for the public key and names in the certificate signing
+// request; only the public certificate chain is returned since the private key is held
+// by the requester.
+func (c *Certs) IssueCSR(csr *x509.CertificateRequest) (_ []byte, err error) {
+	var keyID string
+	if keyID, err = signature.New(csr.PublicKey); err != nil {
 		return nil, err
 	}
 
-	if err = pem.Encode(&chain, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}); err != nil {
+	dnsNames := csr.DNSNames
+	if len(dnsNames) == 0 {
+		dnsNames = []string{csr.Subject.CommonName}
+	}
+
+	cert := &x509.Certificate{
+		SerialNumber: SerialNumber(),
+		Subject:      csr.Subject,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().AddDate(1, 1, 0),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		DNSNames:     dnsNames,
+		SubjectKeyId: []byte(keyID),
+	}
+
+	return c.sign(cert, csr.PublicKey)
+}
+
+// Signs the certificate with the CA and returns the PEM encoded certificate chain.
+func (c *Certs) sign(cert *x509.Certificate, pub crypto.PublicKey) (_ []byte, err error) {
+	var (
+		signed []byte
+		chain  bytes.Buffer
+	)
+
+	if signed, err = x509.CreateCertificate(rand.Reader, cert, c.cacrt, pub, c.cakey); err != nil {
+		return nil, err
+	}
+
+	if err = pem.Encode(&chain, &pem.Block{Type: "CERTIFICATE", Bytes: signed}); err != nil {
+		return nil, err
+	}
+
+	if err = pem.Encode(&chain, &pem.Block{Type: "CERTIFICATE", Bytes: c.cacrt.Raw}); err != nil {
 		return nil, err
 	}
 
@@ -1,12 +1,17 @@
 package server_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
 	"crypto/x509/pkix"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"github.com/trisacrypto/directory/pkg/sectigo/server"
+	"github.com/trisacrypto/trisa/pkg/trust"
 )
 
 func TestCertIssuer(t *testing.T) {
@@ -33,3 +38,33 @@ func TestSerialNumber(t *testing.T) {
 	sns2 := fmt.Sprintf("%32X", sn2)
 	require.NotEqual(t, sns, sns2)
 }
+
+func TestCertIssuerCSR(t *testing.T) {
+	certs, err := server.NewCerts(server.Config{})
+	require.NoError(t, err, "could not create cert issuer")
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err, "could not generate private key")
+
+	template := &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "test.example.com"},
+		DNSNames: []string{"test.example.com", "api.example.com"},
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	require.NoError(t, err, "could not create certificate request")
+	csr, err := x509.ParseCertificateRequest(der)
+	require.NoError(t, err, "could not parse certificate request")
+
+	data, err := certs.IssueCSR(csr)
+	require.NoError(t, err, "could not issue certs")
+	require.NotContains(t, string(data), "PRIVATE KEY", "expected only the public chain")
+
+	// The leaf certificate should be issued for the public key in the csr
+	provider, err := trust.New(data)
+	require.NoError(t, err, "could not parse issued chain")
+	leaf, err := provider.GetLeafCertificate()
+	require.NoError(t, err, "could not get leaf certificate")
+	require.True(t, key.PublicKey.Equal(leaf.PublicKey), "expected leaf to have csr public key")
+	require.Equal(t, template.DNSNames, leaf.DNSNames)
+	require.Equal(t, "test.example.com", leaf.Subject.CommonName)
+}
@@ -39,6 +39,7 @@ type Batch struct {
 	Params        Params
 	Expires       time.Time
 	Cleaned       bool
+	Uploaded      bool
 }
 
 type Certificate struct {
@@ -99,6 +100,33 @@ func (s *Store) AddBatch(profile string, info *sectigo.CreateSingleCertBatchRequ
 	return *batch, nil
 }
 
+func (s *Store) AddCSRBatch(profile string, info *sectigo.UploadCSRBatchRequest) (Batch, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	// NOTE: batch will expire in 2 days and will not be able to be downloaded after
+	s.batchseq++
+	batch := &Batch{
+		BatchID:       s.batchseq,
+		BatchName:     fmt.Sprintf("csr-batch-%d", s.batchseq),
+		OrderNumber:   0,
+		CreationDate:  time.Now().Format(time.RFC3339Nano),
+		Profile:       profile,
+		Size:          1,
+		Status:        sectigo.BatchStatusProcessing,
+		Expires:       time.Now().AddDate(0, 0, 2),
+		Active:        1,
+		Failed:        0,
+		SerialNumbers: nil,
+		Params:        info.ProfileParams,
+		Cleaned:       false,
+		Uploaded:      true,
+	}
+
+	s.batches[batch.BatchID] = batch
+	return *batch, nil
+}
+
 func (s *Store) GetBatch(id int) (Batch, error) {
 	s.RLock()
 	defer s.RUnlock()
@@ -0,0 +1,264 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	bff "github.com/trisacrypto/directory/pkg/bff/models/v1"
+	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+)
+
+// CopyNamespaces are the namespaces copied by the Copier in the order they are copied.
+var CopyNamespaces = []string{
+	wire.NamespaceVASPs,
+	wire.NamespaceCertReqs,
+	wire.NamespaceCertReqArchive,
+	wire.NamespaceCerts,
+	wire.NamespaceAnnouncements,
+	wire.NamespaceActivities,
+	wire.NamespaceOrganizations,
+	wire.NamespaceContacts,
+	wire.NamespaceJobs,
+	wire.NamespaceEmails,
+}
+
+// The number of records copied between checkpoints.
+const checkpointInterval = 100
+
+// Copier copies every object in every namespace from one store to another. Objects are
+// copied as the serialized records read from the source store and written verbatim to
+// the destination store by their IDs, so that the IDs, versions, and management
+// timestamps of the records are preserved and references between objects remain valid.
+// The destination store is reindexed once the VASP records have been copied.
+//
+// Objects are iterated in key order, which is true of all of the store backends, so the
+// copy records the last key copied in each namespace in its checkpoint. If a copy is
+// interrupted it can be resumed from the checkpoint, skipping the namespaces that were
+// completed and the objects that were already copied.
+type Copier struct {
+	Src          Store
+	Dst          Store
+	Since        time.Time                   // The first month to copy announcement and activity months from
+	Checkpoint   *CopyCheckpoint             // The progress of the copy, created if nil
+	OnCheckpoint func(*CopyCheckpoint) error // Called periodically to save the checkpoint
+}
+
+// CopyCheckpoint records the progress of a copy so that it can be resumed.
+type CopyCheckpoint struct {
+	Completed map[string]bool   `json:"completed"`
+	LastKey   map[string]string `json:"last_key"`
+	Copied    map[string]uint64 `json:"copied"`
+}
+
+// NewCopyCheckpoint creates an empty checkpoint for a copy that has not started.
+func NewCopyCheckpoint() *CopyCheckpoint {
+	return &CopyCheckpoint{
+		Completed: make(map[string]bool),
+		LastKey:   make(map[string]string),
+		Copied:    make(map[string]uint64),
+	}
+}
+
+// LoadCopyCheckpoint loads a checkpoint from a JSON file on disk. If the file does not
+// exist an empty checkpoint is returned.
+func LoadCopyCheckpoint(path string) (ckpt *CopyCheckpoint, err error) {
+	ckpt = NewCopyCheckpoint()
+
+	var data []byte
+	if data, err = os.ReadFile(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ckpt, nil
+		}
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, ckpt); err != nil {
+		return nil, fmt.Errorf("could not parse checkpoint: %w", err)
+	}
+	return ckpt, nil
+}
+
+// Save the checkpoint as a JSON file on disk.
+func (c *CopyCheckpoint) Save(path string) (err error) {
+	var data []byte
+	if data, err = json.MarshalIndent(c, "", "  "); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Copy every namespace from the source to the destination store, resuming from the
+// checkpoint if one is specified.
+func (c *Copier) Copy(ctx context.Context) (err error) {
+	if c.Checkpoint == nil {
+		c.Checkpoint = NewCopyCheckpoint()
+	}
+
+	for _, namespace := range CopyNamespaces {
+		if c.Checkpoint.Completed[namespace] {
+			log.Info().Str("namespace", namespace).Msg("namespace already copied, skipping")
+			continue
+		}
+
+		switch namespace {
+		case wire.NamespaceVASPs:
+			err = c.copyVASPs(ctx)
+		case wire.NamespaceAnnouncements, wire.NamespaceActivities:
+			err = c.copyMonths(ctx, namespace)
+		default:
+			err = c.copyRaw(ctx, namespace)
+		}
+
+		if err != nil {
+			// Save the checkpoint so that the copy can be resumed
+			if serr := c.save(); serr != nil {
+				log.Error().Err(serr).Msg("could not save copy checkpoint")
+			}
+			return fmt.Errorf("could not copy %s: %w", namespace, err)
+		}
+
+		c.Checkpoint.Completed[namespace] = true
+		if err = c.save(); err != nil {
+			return err
+		}
+		log.Info().Str("namespace", namespace).Uint64("copied", c.Checkpoint.Copied[namespace]).Msg("namespace copied")
+	}
+	return nil
+}
+
+// CopyCount compares the number of objects in a namespace of the source and destination
+// stores after a copy. If either store cannot count the namespace, Err is set.
+type CopyCount struct {
+	Namespace string
+	Src       uint64
+	Dst       uint64
+	Err       error
+}
+
+// Verified returns true if the namespace has the same number of objects in both stores.
+func (c *CopyCount) Verified() bool {
+	return c.Err == nil && c.Src == c.Dst
+}
+
+// Verify the copy by comparing the counts of every namespace in the source and
+// destination stores.
+func (c *Copier) Verify(ctx context.Context) (counts []*CopyCount) {
+	counters := map[string][2]func(context.Context) (uint64, error){
+		wire.NamespaceVASPs:          {c.Src.CountVASPs, c.Dst.CountVASPs},
+		wire.NamespaceCertReqs:       {c.Src.CountCertReqs, c.Dst.CountCertReqs},
+		wire.NamespaceCertReqArchive: {c.Src.CountArchivedCertReqs, c.Dst.CountArchivedCertReqs},
+		wire.NamespaceCerts:          {c.Src.CountCerts, c.Dst.CountCerts},
+		wire.NamespaceAnnouncements:  {c.Src.CountAnnouncementMonths, c.Dst.CountAnnouncementMonths},
+		wire.NamespaceActivities:     {c.Src.CountActivityMonth, c.Dst.CountActivityMonth},
+		wire.NamespaceOrganizations:  {c.Src.CountOrganizations, c.Dst.CountOrganizations},
+		wire.NamespaceContacts:       {c.Src.CountContacts, c.Dst.CountContacts},
+		wire.NamespaceJobs:           {c.Src.CountJobs, c.Dst.CountJobs},
+		wire.NamespaceEmails:         {c.Src.CountEmails, c.Dst.CountEmails},
+	}
+
+	counts = make([]*CopyCount, 0, len(CopyNamespaces))
+	for _, namespace := range CopyNamespaces {
+		count := &CopyCount{Namespace: namespace}
+		if count.Src, count.Err = counters[namespace][0](ctx); count.Err == nil {
+			count.Dst, count.Err = counters[namespace][1](ctx)
+		}
+		counts = append(counts, count)
+	}
+	return counts
+}
+
+// VASPs are copied without updating the indices of the destination store, which is
+// reindexed once all of the VASPs have been copied.
+func (c *Copier) copyVASPs(ctx context.Context) (err error) {
+	if err = c.copyRaw(ctx, wire.NamespaceVASPs); err != nil {
+		return err
+	}
+
+	if indexer, ok := c.Dst.(Indexer); ok {
+		if err = indexer.Reindex(); err != nil {
+			return fmt.Errorf("could not reindex destination: %w", err)
+		}
+	}
+	return nil
+}
+
+// Copy the serialized records of the namespace in key order, skipping the records that
+// were copied before the checkpoint.
+func (c *Copier) copyRaw(ctx context.Context, ns string) error {
+	last := c.Checkpoint.LastKey[ns]
+	return c.Src.ScanRaw(ctx, ns, func(id string, data []byte) (err error) {
+		if last != "" && id <= last {
+			return nil
+		}
+
+		if err = c.Dst.PutRaw(ctx, ns, id, data); err != nil {
+			return err
+		}
+		return c.copied(ns, id)
+	})
+}
+
+// Months cannot be listed in every store so every month since the start of the copy is
+// retrieved from the source store and copied if it exists.
+func (c *Copier) copyMonths(ctx context.Context, ns string) error {
+	return c.months(ns, func(date string) (err error) {
+		var data []byte
+		if data, err = c.Src.GetRaw(ctx, ns, date); err != nil {
+			if errors.Is(err, storeerrors.ErrEntityNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		if err = c.Dst.PutRaw(ctx, ns, date, data); err != nil {
+			return err
+		}
+		return c.copied(ns, date)
+	})
+}
+
+// months calls the copy function for every month from the start of the copy (or from
+// the last month copied if resuming) until the current month.
+func (c *Copier) months(ns string, fn func(date string) error) (err error) {
+	start := c.Since
+	if last := c.Checkpoint.LastKey[ns]; last != "" {
+		var ts time.Time
+		if ts, err = time.Parse(bff.MonthLayout, last); err != nil {
+			return fmt.Errorf("invalid checkpoint: %w", err)
+		}
+		start = ts.AddDate(0, 1, 0)
+	}
+
+	start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for month := start; !month.After(time.Now()); month = month.AddDate(0, 1, 0) {
+		if err = fn(month.Format(bff.MonthLayout)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copied records the key of the object that was copied, saving the checkpoint
+// periodically so that the copy can be resumed if it is interrupted.
+func (c *Copier) copied(ns, key string) error {
+	c.Checkpoint.LastKey[ns] = key
+	c.Checkpoint.Copied[ns]++
+
+	if c.Checkpoint.Copied[ns]%checkpointInterval == 0 {
+		return c.save()
+	}
+	return nil
+}
+
+func (c *Copier) save() error {
+	if c.OnCheckpoint != nil {
+		return c.OnCheckpoint(c.Checkpoint)
+	}
+	return nil
+}
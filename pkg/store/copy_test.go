@@ -0,0 +1,170 @@
+package store_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	bff "github.com/trisacrypto/directory/pkg/bff/models/v1"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/store"
+	"github.com/trisacrypto/directory/pkg/store/config"
+	"github.com/trisacrypto/directory/pkg/utils/logger"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCopy(t *testing.T) {
+	logger.Discard()
+	defer logger.ResetLogger()
+
+	ctx := context.Background()
+	src := openLevelDB(t)
+	dst := openLevelDB(t)
+
+	// Populate the source store
+	vaspIDs := make([]string, 0, 5)
+	for i := 0; i < 5; i++ {
+		id, err := src.CreateVASP(ctx, &pb.VASP{CommonName: fmt.Sprintf("trisa%d.example.com", i+1)})
+		require.NoError(t, err)
+		vaspIDs = append(vaspIDs, id)
+
+		_, err = src.CreateCertReq(ctx, &models.CertificateRequest{Vasp: id, CommonName: fmt.Sprintf("trisa%d.example.com", i+1)})
+		require.NoError(t, err)
+	}
+
+	// Records modified in the past should keep their management timestamps in the copy
+	archived := &models.CertificateRequest{Id: "b4a3bbd9-2c5a-4f4c-8f85-9a5e0ad8b0a1", Vasp: vaspIDs[0], Status: models.CertificateRequestState_COMPLETED, Created: "2020-01-01T00:00:00Z", Modified: "2020-01-02T00:00:00Z"}
+	data, err := proto.Marshal(archived)
+	require.NoError(t, err)
+	require.NoError(t, src.PutRaw(ctx, wire.NamespaceCertReqArchive, archived.Id, data))
+
+	certID, err := src.CreateCert(ctx, &models.Certificate{Vasp: vaspIDs[0], Status: models.CertificateState_ISSUED})
+	require.NoError(t, err)
+
+	orgID, err := src.CreateOrganization(ctx, &bff.Organization{Name: "Alice VASP"})
+	require.NoError(t, err)
+
+	_, err = src.CreateContact(ctx, &models.Contact{Email: "alice@example.com", Name: "Alice"})
+	require.NoError(t, err)
+
+	jobID, err := src.CreateJob(ctx, &models.Job{Type: "register", Vasp: vaspIDs[0], Attempts: 2})
+	require.NoError(t, err)
+
+	emailID, err := src.CreateEmail(ctx, &models.Email{Reason: "verify_contact", Recipient: "alice@example.com", Status: models.EmailState_EMAIL_DEAD_LETTER})
+	require.NoError(t, err)
+
+	lastMonth := time.Now().AddDate(0, -1, 0).Format(bff.MonthLayout)
+	require.NoError(t, src.UpdateAnnouncementMonth(ctx, &bff.AnnouncementMonth{Date: lastMonth, Announcements: []*bff.Announcement{{Title: "Hello"}}}))
+
+	// Simulate a copy that was interrupted after the first two VASPs were copied; VASPs
+	// are copied in key order so the IDs are sorted to find the first two.
+	sort.Strings(vaspIDs)
+	ckpt := store.NewCopyCheckpoint()
+	for _, vaspID := range vaspIDs[:2] {
+		vasp, err := src.RetrieveVASP(ctx, vaspID)
+		require.NoError(t, err)
+		_, err = dst.CreateVASP(ctx, vasp)
+		require.NoError(t, err)
+	}
+	ckpt.LastKey[wire.NamespaceVASPs] = vaspIDs[1]
+	ckpt.Copied[wire.NamespaceVASPs] = 2
+
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	require.NoError(t, ckpt.Save(path))
+
+	ckpt, err = store.LoadCopyCheckpoint(path)
+	require.NoError(t, err)
+
+	copier := &store.Copier{
+		Src:        src,
+		Dst:        dst,
+		Since:      time.Now().AddDate(0, -3, 0),
+		Checkpoint: ckpt,
+		OnCheckpoint: func(ckpt *store.CopyCheckpoint) error {
+			return ckpt.Save(path)
+		},
+	}
+	require.NoError(t, copier.Copy(ctx))
+
+	// All namespaces should be marked as completed in the saved checkpoint
+	ckpt, err = store.LoadCopyCheckpoint(path)
+	require.NoError(t, err)
+	for _, namespace := range store.CopyNamespaces {
+		require.True(t, ckpt.Completed[namespace], "expected %s to be completed", namespace)
+	}
+	require.Equal(t, uint64(5), ckpt.Copied[wire.NamespaceVASPs])
+
+	// IDs and versions should be preserved in the destination
+	for _, vaspID := range vaspIDs {
+		expected, err := src.RetrieveVASP(ctx, vaspID)
+		require.NoError(t, err)
+		actual, err := dst.RetrieveVASP(ctx, vaspID)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(expected, actual), "expected VASP to be copied verbatim")
+	}
+
+	// The destination should be reindexed after the VASPs are copied
+	vasps, err := dst.SearchVASPs(ctx, map[string]interface{}{"name": "trisa5.example.com"})
+	require.NoError(t, err)
+	require.Len(t, vasps, 1)
+
+	req, err := dst.RetrieveArchivedCertReq(ctx, archived.Id)
+	require.NoError(t, err)
+	require.Equal(t, "2020-01-02T00:00:00Z", req.Modified, "expected modified timestamp to be preserved")
+
+	_, err = dst.RetrieveCert(ctx, certID)
+	require.NoError(t, err)
+
+	org, err := dst.RetrieveOrganization(ctx, uuid.MustParse(orgID))
+	require.NoError(t, err)
+	require.Equal(t, "Alice VASP", org.Name)
+
+	_, err = dst.RetrieveContact(ctx, "alice@example.com")
+	require.NoError(t, err)
+
+	job, err := dst.RetrieveJob(ctx, jobID)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), job.Attempts)
+
+	email, err := dst.RetrieveEmail(ctx, emailID)
+	require.NoError(t, err)
+	require.Equal(t, models.EmailState_EMAIL_DEAD_LETTER, email.Status)
+
+	month, err := dst.RetrieveAnnouncementMonth(ctx, lastMonth)
+	require.NoError(t, err)
+	require.Len(t, month.Announcements, 1)
+
+	// Counts should match for every namespace leveldb can count
+	for _, count := range copier.Verify(ctx) {
+		switch count.Namespace {
+		case wire.NamespaceAnnouncements, wire.NamespaceActivities:
+			require.Error(t, count.Err, "leveldb cannot count months")
+		default:
+			require.True(t, count.Verified(), "expected %s counts to match", count.Namespace)
+		}
+	}
+
+	// A completed copy should not copy anything else when resumed
+	copier.Checkpoint = ckpt
+	require.NoError(t, copier.Copy(ctx))
+	require.Equal(t, uint64(5), copier.Checkpoint.Copied[wire.NamespaceVASPs])
+
+	// A missing checkpoint file should return an empty checkpoint
+	ckpt, err = store.LoadCopyCheckpoint(filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+	require.Empty(t, ckpt.Completed)
+}
+
+func openLevelDB(t *testing.T) store.Store {
+	db, err := store.Open(config.StoreConfig{URL: "leveldb:///" + t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
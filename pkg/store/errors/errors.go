@@ -10,4 +10,6 @@ var (
 	ErrIDAlreadySet      = errors.New("record must not have an ID (use update instead)")
 	ErrIncompleteRecord  = errors.New("record is missing required fields")
 	ErrProtocol          = errors.New("unexpected protocol error")
+	ErrTxnClosed         = errors.New("transaction has already been committed or rolled back")
+	ErrUnknownNamespace  = errors.New("unknown namespace")
 )
@@ -91,6 +91,19 @@ func (c Container) Reverse(value string, norm Normalizer) ([]string, bool) {
 	return results, len(results) > 0
 }
 
+// Purge removes the value from all keys in the index, e.g. to remove a record from the
+// index without knowing which keys it was indexed by. Returns true if the value was
+// removed from any key in the index.
+func (c Container) Purge(value string) bool {
+	removed := false
+	for key := range c {
+		if c.Remove(key, value, nil) {
+			removed = true
+		}
+	}
+	return removed
+}
+
 // Contains determines if the value is contained by the key index
 func (c Container) Contains(key string, value string, norm Normalizer) bool {
 	// normalize the key to enhance search
@@ -165,6 +178,10 @@ func (c *normalizedContainer) Reverse(value string) ([]string, bool) {
 	return c.index.Reverse(value, c.norm)
 }
 
+func (c *normalizedContainer) Purge(value string) bool {
+	return c.index.Purge(value)
+}
+
 func (c *normalizedContainer) Find(key string) ([]string, bool) {
 	return c.index.Find(key, c.norm)
 }
@@ -98,6 +98,13 @@ func TestContainerIndex(t *testing.T) {
 	require.False(t, ok)
 	require.Empty(t, vals)
 
+	// Purge a value from all keys
+	require.True(t, countries.Purge(id2))
+	vals, ok = countries.Reverse(id2, nil)
+	require.False(t, ok)
+	require.Empty(t, vals)
+	require.False(t, countries.Purge(id2))
+
 	// Test serialization and deserialization
 	data, err := countries.Dump()
 	require.NoError(t, err)
@@ -0,0 +1,404 @@
+package index
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Tokens shorter than this are not indexed and tokens in a query must be at least this
+// long to be prefix matched to longer tokens in the index.
+const (
+	tokenMinLength       = 2
+	fuzzyPrefixMinLength = 3
+)
+
+// Relevance of a token in the index relative to an exact match of a query token.
+const (
+	prefixSimilarity = 0.8
+	fuzzySimilarity  = 0.8
+)
+
+// Common words that are not indexed since they do not help distinguish records.
+var stopwords = map[string]struct{}{
+	"a": {}, "an": {}, "and": {}, "at": {}, "by": {}, "for": {}, "in": {}, "of": {},
+	"on": {}, "or": {}, "the": {}, "to": {}, "http": {}, "https": {}, "www": {},
+}
+
+// Tokenize splits text into lower case tokens of letters and digits for full text
+// indexing and search, discarding stop words and tokens that are too short.
+func Tokenize(s string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len([]rune(field)) < tokenMinLength {
+			continue
+		}
+		if _, ok := stopwords[field]; ok {
+			continue
+		}
+		tokens = append(tokens, field)
+	}
+	return tokens
+}
+
+// Ranker is implemented by indices that score the records that match a query by their
+// relevance so that search results can be ordered from most to least relevant.
+type Ranker interface {
+	Rank(query map[string]interface{}) map[string]float64
+}
+
+// FullText is an inverted index that maps the tokens of indexed text to the records
+// that contain them along with the number of times the token occurs in the record.
+type FullText map[string]map[string]int
+
+// Add the tokens of the text to the index for the specified record.
+func (c FullText) Add(text, record string) bool {
+	added := false
+	for _, token := range Tokenize(text) {
+		postings, ok := c[token]
+		if !ok {
+			postings = make(map[string]int)
+			c[token] = postings
+		}
+		postings[record]++
+		added = true
+	}
+	return added
+}
+
+// Remove the tokens of the text from the index for the specified record.
+func (c FullText) Remove(text, record string) bool {
+	removed := false
+	for _, token := range Tokenize(text) {
+		postings, ok := c[token]
+		if !ok {
+			continue
+		}
+
+		if count, ok := postings[record]; ok {
+			removed = true
+			if count > 1 {
+				postings[record] = count - 1
+			} else {
+				delete(postings, record)
+			}
+		}
+
+		if len(postings) == 0 {
+			delete(c, token)
+		}
+	}
+	return removed
+}
+
+// Purge the record from every token in the index.
+func (c FullText) Purge(record string) bool {
+	removed := false
+	for token, postings := range c {
+		if _, ok := postings[record]; ok {
+			delete(postings, record)
+			removed = true
+		}
+
+		if len(postings) == 0 {
+			delete(c, token)
+		}
+	}
+	return removed
+}
+
+// Find returns the records that contain the token in sorted order.
+func (c FullText) Find(token string) ([]string, bool) {
+	postings, ok := c[Normalize(token)]
+	if !ok {
+		return nil, false
+	}
+
+	records := make([]string, 0, len(postings))
+	for record := range postings {
+		records = append(records, record)
+	}
+	sort.Strings(records)
+	return records, true
+}
+
+// Contains returns true if every token of the text is indexed for the record.
+func (c FullText) Contains(text, record string) bool {
+	tokens := Tokenize(text)
+	if len(tokens) == 0 {
+		return false
+	}
+
+	for _, token := range tokens {
+		if _, ok := c[token][record]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Reverse returns the tokens that are indexed for the record in sorted order.
+func (c FullText) Reverse(record string) ([]string, bool) {
+	tokens := make([]string, 0)
+	for token, postings := range c {
+		if _, ok := postings[record]; ok {
+			tokens = append(tokens, token)
+		}
+	}
+	sort.Strings(tokens)
+	return tokens, len(tokens) > 0
+}
+
+// Rank the records that match the text. Every token in the text must match a token in
+// the record either exactly, as a prefix, or within a small edit distance for the
+// record to match. Records are scored by the sum of the best match for each token,
+// weighted by how rare the matched token is in the index (TF-IDF).
+func (c FullText) Rank(text string) map[string]float64 {
+	tokens := Tokenize(text)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	nrecords := c.records()
+	var scores map[string]float64
+	for i, token := range tokens {
+		matches := c.match(token, nrecords)
+		if i == 0 {
+			scores = matches
+			continue
+		}
+
+		for record, score := range scores {
+			if match, ok := matches[record]; ok {
+				scores[record] = score + match
+			} else {
+				delete(scores, record)
+			}
+		}
+	}
+	return scores
+}
+
+// match scores every record that has a token similar to the query token, keeping the
+// best score if the record has multiple similar tokens.
+func (c FullText) match(query string, nrecords int) map[string]float64 {
+	scores := make(map[string]float64)
+	for token, postings := range c {
+		sim := similarity(query, token)
+		if sim == 0 {
+			continue
+		}
+
+		idf := math.Log(1 + float64(nrecords)/float64(len(postings)))
+		for record, count := range postings {
+			score := sim * idf * (1 + math.Log(float64(count)))
+			if score > scores[record] {
+				scores[record] = score
+			}
+		}
+	}
+	return scores
+}
+
+// records returns the number of distinct records in the index.
+func (c FullText) records() int {
+	records := make(map[string]struct{})
+	for _, postings := range c {
+		for record := range postings {
+			records[record] = struct{}{}
+		}
+	}
+	return len(records)
+}
+
+// Dump a full text index to a byte representation for storage on disk.
+func (c FullText) Dump() (_ []byte, err error) {
+	// Create a compressed writer to encode JSON into
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+
+	// Marshal the JSON representation of the index
+	encoder := json.NewEncoder(gz)
+	if err = encoder.Encode(c); err != nil {
+		return nil, fmt.Errorf("could not encode index: %s", err)
+	}
+
+	if err = gz.Close(); err != nil {
+		return nil, fmt.Errorf("could not compress index: %s", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Load a full text index from a byte representation on disk.
+func (c FullText) Load(data []byte) (err error) {
+	// Create a compressed reader to decode the JSON from.
+	buf := bytes.NewBuffer(data)
+
+	var gz *gzip.Reader
+	if gz, err = gzip.NewReader(buf); err != nil {
+		return fmt.Errorf("could not decompress index: %s", err)
+	}
+
+	decoder := json.NewDecoder(gz)
+	if err = decoder.Decode(&c); err != nil {
+		return fmt.Errorf("could not decode index: %s", err)
+	}
+	return nil
+}
+
+// similarity returns the relevance of an indexed token to a query token between 0 (not
+// a match) and 1 (an exact match). Tokens that have the query as a prefix or that are
+// within the maximum edit distance for the length of the query are partial matches.
+func similarity(query, token string) float64 {
+	if query == token {
+		return 1
+	}
+
+	q, t := []rune(query), []rune(token)
+	if len(q) >= fuzzyPrefixMinLength && strings.HasPrefix(token, query) {
+		return prefixSimilarity * float64(len(q)) / float64(len(t))
+	}
+
+	edits := maxEdits(len(q))
+	if edits == 0 || abs(len(q)-len(t)) > edits {
+		return 0
+	}
+
+	if dist := levenshtein(q, t); dist <= edits {
+		return fuzzySimilarity * (1 - float64(dist)/float64(len(q)))
+	}
+	return 0
+}
+
+// maxEdits returns the number of typos that are tolerated for a token of the length.
+func maxEdits(n int) int {
+	switch {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// levenshtein computes the edit distance between two strings.
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Normalized full text maintains the query key and relevance weight with the index.
+type normalizedFullText struct {
+	index  FullText
+	query  string
+	weight float64
+}
+
+func (c *normalizedFullText) Add(text, record string) bool {
+	return c.index.Add(text, record)
+}
+
+func (c *normalizedFullText) Remove(text, record string) bool {
+	return c.index.Remove(text, record)
+}
+
+func (c *normalizedFullText) Purge(record string) bool {
+	return c.index.Purge(record)
+}
+
+func (c *normalizedFullText) Find(token string) ([]string, bool) {
+	return c.index.Find(token)
+}
+
+func (c *normalizedFullText) Contains(text, record string) bool {
+	return c.index.Contains(text, record)
+}
+
+func (c *normalizedFullText) Reverse(record string) ([]string, bool) {
+	return c.index.Reverse(record)
+}
+
+func (c *normalizedFullText) Load(data []byte) error {
+	return c.index.Load(data)
+}
+
+func (c *normalizedFullText) Dump() ([]byte, error) {
+	return c.index.Dump()
+}
+
+func (c *normalizedFullText) Len() int {
+	return len(c.index)
+}
+
+func (c *normalizedFullText) Empty() bool {
+	return len(c.index) == 0
+}
+
+// Rank the records that match any of the terms in the query, adding the scores of
+// records that match multiple terms and weighting the scores by the index weight.
+func (c *normalizedFullText) Rank(query map[string]interface{}) map[string]float64 {
+	terms, ok := ParseQuery(c.query, query, nil)
+	if !ok {
+		return nil
+	}
+
+	log.Debug().Str("index", c.query).Strs("terms", terms).Msg("full text search")
+	scores := make(map[string]float64)
+	for _, term := range terms {
+		for record, score := range c.index.Rank(term) {
+			scores[record] += score * c.weight
+		}
+	}
+	return scores
+}
+
+// Search returns the records that match the query in sorted order.
+func (c *normalizedFullText) Search(query map[string]interface{}) []string {
+	scores := c.Rank(query)
+	if scores == nil {
+		return nil
+	}
+
+	results := make([]string, 0, len(scores))
+	for record := range scores {
+		results = append(results, record)
+	}
+	sort.Strings(results)
+	return results
+}
@@ -0,0 +1,80 @@
+package index_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/store/index"
+)
+
+func TestTokenize(t *testing.T) {
+	tt := []struct {
+		in       string
+		expected []string
+	}{
+		{"", []string{}},
+		{"Alice VASP, LLC", []string{"alice", "vasp", "llc"}},
+		{"https://www.alice.io/about", []string{"alice", "io", "about"}},
+		{"The Bank of   Ümlaut-Straße 7", []string{"bank", "ümlaut", "straße"}},
+	}
+
+	for i, tc := range tt {
+		require.Equal(t, tc.expected, index.Tokenize(tc.in), "test case %d failed", i)
+	}
+}
+
+func TestFullText(t *testing.T) {
+	idx := make(index.FullText)
+	require.True(t, idx.Add("Alice VASP, LLC", "alice"))
+	require.True(t, idx.Add("Alice Trading", "alice"))
+	require.True(t, idx.Add("Bob Crypto Exchange", "bob"))
+	require.True(t, idx.Add("Alica Exchange", "alica"))
+	require.False(t, idx.Add("the of", "alice"), "stop words should not be indexed")
+
+	records, ok := idx.Find("Exchange")
+	require.True(t, ok)
+	require.Equal(t, []string{"alica", "bob"}, records)
+
+	tokens, ok := idx.Reverse("alice")
+	require.True(t, ok)
+	require.Equal(t, []string{"alice", "llc", "trading", "vasp"}, tokens)
+
+	require.True(t, idx.Contains("vasp alice", "alice"))
+	require.False(t, idx.Contains("vasp exchange", "alice"))
+
+	// Exact matches are more relevant than fuzzy matches
+	scores := idx.Rank("alice")
+	require.Len(t, scores, 2)
+	require.Greater(t, scores["alice"], scores["alica"])
+
+	// Every token in the text must match
+	scores = idx.Rank("alice exchange")
+	require.Len(t, scores, 1)
+	require.Contains(t, scores, "alica")
+
+	// Typos and prefixes are matched
+	require.Contains(t, idx.Rank("Alise VASP"), "alice")
+	require.Contains(t, idx.Rank("Bob Exchnage"), "bob")
+	require.Contains(t, idx.Rank("cryp"), "bob")
+	require.Empty(t, idx.Rank("cr"), "short prefixes should not be matched")
+	require.Empty(t, idx.Rank("bob"+"ali"), "unrelated tokens should not be matched")
+
+	// Removing text only removes a single occurrence of each token
+	require.True(t, idx.Remove("Alice Trading", "alice"))
+	require.Contains(t, idx.Rank("alice"), "alice")
+	require.Empty(t, idx.Rank("trading"))
+
+	// Purge removes all tokens for the record
+	require.True(t, idx.Purge("alice"))
+	_, ok = idx.Reverse("alice")
+	require.False(t, ok)
+	require.False(t, idx.Purge("alice"))
+
+	// Test dumping and loading the index
+	data, err := idx.Dump()
+	require.NoError(t, err)
+
+	loaded := make(index.FullText)
+	require.NoError(t, loaded.Load(data))
+	require.Equal(t, idx, loaded)
+}
@@ -13,6 +13,7 @@ type Index interface {
 	Search(query map[string]interface{}) []string
 	Add(key, value string) bool
 	Reverse(value string) ([]string, bool)
+	Purge(value string) bool
 }
 
 // SingleIndex is not the best term for this but we will refactor this during the Trtl
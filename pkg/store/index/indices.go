@@ -0,0 +1,227 @@
+package index
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/rs/zerolog"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+// Indices is the set of secondary indices created from the registered definitions that
+// a store maintains for VASP records. The store is responsible for inserting and
+// removing records as they are written and for serializing each index to disk. Indices
+// is not safe for concurrent use; stores must guard access with their own locks.
+type Indices struct {
+	defs    []*Definition
+	indices map[string]Index
+	missing map[string]struct{}
+}
+
+// NewIndices creates an empty index for every registered index definition.
+func NewIndices() *Indices {
+	defs := Definitions()
+	indices := &Indices{
+		defs:    defs,
+		indices: make(map[string]Index, len(defs)),
+		missing: make(map[string]struct{}, len(defs)),
+	}
+
+	for _, def := range defs {
+		indices.indices[def.Name] = def.New()
+		indices.missing[def.Name] = struct{}{}
+	}
+	return indices
+}
+
+// Definitions returns the definitions of the indices in the set.
+func (i *Indices) Definitions() []*Definition {
+	return i.defs
+}
+
+// Get the index with the specified name, returns nil if the index does not exist.
+func (i *Indices) Get(name string) Index {
+	return i.indices[name]
+}
+
+// Find the record ID for the key in the specified unique index.
+func (i *Indices) Find(name, key string) (string, bool) {
+	if idx, ok := i.indices[name].(SingleIndex); ok {
+		return idx.Find(key)
+	}
+	return "", false
+}
+
+// Insert the values extracted from the VASP record into every index.
+func (i *Indices) Insert(vasp *pb.VASP) {
+	for _, def := range i.defs {
+		idx := i.indices[def.Name]
+		for _, value := range def.Extract(vasp) {
+			idx.Add(value, vasp.Id)
+		}
+	}
+}
+
+// Remove the values extracted from the VASP record from every index. Values in unique
+// indices are only removed if they belong to the record, so that removing a record does
+// not remove a value that was indexed by another record.
+func (i *Indices) Remove(vasp *pb.VASP) {
+	for _, def := range i.defs {
+		switch idx := i.indices[def.Name].(type) {
+		case SingleIndex:
+			for _, value := range def.Extract(vasp) {
+				if id, ok := idx.Find(value); ok && id == vasp.Id {
+					idx.Remove(value)
+				}
+			}
+		case MultiIndex:
+			for _, value := range def.Extract(vasp) {
+				idx.Remove(value, vasp.Id)
+			}
+		}
+	}
+}
+
+// Purge the record ID from every index, e.g. when the previous version of the record
+// is not available to extract the indexed values from.
+func (i *Indices) Purge(id string) {
+	for _, idx := range i.indices {
+		idx.Purge(id)
+	}
+}
+
+// The relevance score of a record that is matched by an exact or prefix match index,
+// which ranks these matches above all but the strongest full text matches.
+const matchScore = 10.0
+
+// Search the indices for the record IDs that match the query. Records matched by any of
+// the exact, prefix, or full text match indices are included in the results, then the
+// results are reduced to the records that match every term of the filter indices in
+// the query. The record IDs are returned in order of relevance, records with the same
+// relevance are sorted by ID so that the order of the results is deterministic.
+func (i *Indices) Search(query map[string]interface{}) []string {
+	scores := make(map[string]float64)
+	for _, def := range i.defs {
+		if def.Mode == Filter {
+			continue
+		}
+
+		idx := i.indices[def.Name]
+		if ranker, ok := idx.(Ranker); ok {
+			for record, score := range ranker.Rank(query) {
+				scores[record] += score
+			}
+			continue
+		}
+
+		for _, result := range idx.Search(query) {
+			scores[result] += matchScore
+		}
+	}
+
+	// NOTE: if a filter term is not in the index, no records will be returned
+	for _, def := range i.defs {
+		if def.Mode != Filter {
+			continue
+		}
+
+		terms, ok := ParseQuery(def.Query, query, def.Normalize)
+		if !ok {
+			continue
+		}
+
+		idx := i.indices[def.Name]
+		for _, term := range terms {
+			for record := range scores {
+				if !contains(idx, term, record) {
+					delete(scores, record)
+				}
+			}
+		}
+	}
+
+	results := make([]string, 0, len(scores))
+	for record := range scores {
+		results = append(results, record)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if scores[results[i]] != scores[results[j]] {
+			return scores[results[i]] > scores[results[j]]
+		}
+		return results[i] < results[j]
+	})
+	return results
+}
+
+// Empty returns true if all of the indices are empty. Note that individual indices may
+// legitimately be empty, e.g. if no VASP has an LEI.
+func (i *Indices) Empty() bool {
+	for _, idx := range i.indices {
+		if !idx.Empty() {
+			return false
+		}
+	}
+	return true
+}
+
+// NeedsReindex returns true if the indices should be rebuilt from the records in the
+// database, either because all of the indices are empty or because one of the indices
+// was not loaded from the database, e.g. a newly registered index. This should be
+// called after the indices have been loaded when the store is opened.
+func (i *Indices) NeedsReindex() bool {
+	return len(i.missing) > 0 || i.Empty()
+}
+
+// Load the serialized data into the specified index, replacing its current contents.
+func (i *Indices) Load(name string, data []byte) (err error) {
+	def := i.definition(name)
+	if def == nil {
+		return fmt.Errorf("unknown index %q", name)
+	}
+
+	idx := def.New()
+	if err = idx.(Serializer).Load(data); err != nil {
+		return err
+	}
+	i.indices[name] = idx
+	delete(i.missing, name)
+	return nil
+}
+
+// Dump the specified index so that it can be stored on disk.
+func (i *Indices) Dump(name string) ([]byte, error) {
+	idx, ok := i.indices[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown index %q", name)
+	}
+	return idx.(Serializer).Dump()
+}
+
+// MarshalZerologObject logs the length of every index.
+func (i *Indices) MarshalZerologObject(e *zerolog.Event) {
+	for _, def := range i.defs {
+		e.Int(def.Name, i.indices[def.Name].Len())
+	}
+}
+
+func (i *Indices) definition(name string) *Definition {
+	for _, def := range i.defs {
+		if def.Name == name {
+			return def
+		}
+	}
+	return nil
+}
+
+// contains returns true if the index maps the key to the record.
+func contains(idx Index, key, record string) bool {
+	switch idx := idx.(type) {
+	case MultiIndex:
+		return idx.Contains(key, record)
+	case SingleIndex:
+		id, ok := idx.Find(key)
+		return ok && id == record
+	}
+	return false
+}
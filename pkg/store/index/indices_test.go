@@ -0,0 +1,140 @@
+package index_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/store/index"
+	"github.com/trisacrypto/trisa/pkg/ivms101"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+func TestIndices(t *testing.T) {
+	indices := index.NewIndices()
+	require.True(t, indices.Empty(), "new indices should be empty")
+	require.True(t, indices.NeedsReindex(), "new indices have not been loaded")
+	require.Len(t, indices.Definitions(), 12, "have the registered indices changed?")
+
+	alice := mkvasp("a8de7c3f-b3e5-4b6c-9fd2-2f1a3c9e1e5a", "trisa.alice.io", "Alice VASP", "https://alice.io", "US")
+	alice.Entity.NationalIdentification = &ivms101.NationalIdentification{
+		NationalIdentifier:     "5493001KJTIIGC8Y1R12",
+		NationalIdentifierType: ivms101.NationalIdentifierLEIX,
+	}
+	alice.IdentityCertificate = &pb.Certificate{SerialNumber: []byte{0xab, 0xcd, 0x01}}
+	alice.Entity.GeographicAddresses = []*ivms101.Address{
+		{AddressLine: []string{"1 Main Street", "Springfield"}, Country: "US"},
+	}
+	alice.Trixo = &pb.TRIXOQuestionnaire{PrimaryNationalJurisdiction: "US", PrimaryRegulator: "FinCEN"}
+
+	bob := mkvasp("0f3a4e61-3c73-4fa1-8a3b-8e1d0c7b2f4d", "trisa.bob.io", "Bob VASP", "https://bob.io", "GB")
+
+	indices.Insert(alice)
+	indices.Insert(bob)
+	require.False(t, indices.Empty(), "indices should not be empty once all indices have records")
+
+	// Test searching the indices
+	testCases := []struct {
+		query    map[string]interface{}
+		expected []string
+	}{
+		{map[string]interface{}{"name": "alice vasp"}, []string{alice.Id}},
+		{map[string]interface{}{"name": []string{"Alice VASP", "bob vasp"}}, []string{bob.Id, alice.Id}},
+		{map[string]interface{}{"name": []string{"Alice VASP", "bob vasp"}, "country": "United States"}, []string{alice.Id}},
+		{map[string]interface{}{"website": "https://bob.io/about"}, []string{bob.Id}},
+		{map[string]interface{}{"lei": "5493001kjtiigc8y1r12"}, []string{alice.Id}},
+		{map[string]interface{}{"common_name": "TRISA.BOB.IO"}, []string{bob.Id}},
+		{map[string]interface{}{"dns_name": "trisa.alice.io"}, []string{alice.Id}},
+		{map[string]interface{}{"serial_number": "AB:CD:01"}, []string{alice.Id}},
+		{map[string]interface{}{"serial_number": "AB:CD:01", "category": "ATM"}, []string{}},
+		{map[string]interface{}{"country": "US"}, []string{}},
+		{map[string]interface{}{"text": "Alise"}, []string{alice.Id}},
+		{map[string]interface{}{"text": "springfeild"}, []string{alice.Id}},
+		{map[string]interface{}{"text": "fincen"}, []string{alice.Id}},
+		{map[string]interface{}{"text": "vasp", "name": "bob vasp"}, []string{bob.Id, alice.Id}},
+	}
+
+	for i, tc := range testCases {
+		require.Equal(t, tc.expected, indices.Search(tc.query), "test case %d failed", i)
+	}
+
+	// Unique values should not be removed by records they do not belong to
+	eve := mkvasp("5a7d1b9e-0c1f-4e2a-b3d4-6f8e9a0b1c2d", "trisa.eve.io", "Alice VASP", "https://eve.io", "US")
+	indices.Remove(eve)
+	id, ok := indices.Find(index.Names, "Alice VASP")
+	require.True(t, ok, "removing eve should not remove alice's name")
+	require.Equal(t, alice.Id, id)
+
+	// Test updating a record by removing and inserting it
+	indices.Remove(alice)
+	alice.CommonName = "trisa.alice.com"
+	indices.Insert(alice)
+
+	require.Empty(t, indices.Search(map[string]interface{}{"common_name": "trisa.alice.io"}))
+	require.Equal(t, []string{alice.Id}, indices.Search(map[string]interface{}{"common_name": "trisa.alice.com"}))
+
+	// Test purging a record without the original record
+	indices.Purge(alice.Id)
+	require.Empty(t, indices.Search(map[string]interface{}{"name": "alice vasp", "lei": "5493001KJTIIGC8Y1R12"}))
+	require.Equal(t, []string{bob.Id}, indices.Search(map[string]interface{}{"name": "bob vasp"}))
+
+	// Test dumping and loading an index
+	data, err := indices.Dump(index.Names)
+	require.NoError(t, err, "could not dump names index")
+
+	loaded := index.NewIndices()
+	require.NoError(t, loaded.Load(index.Names, data), "could not load names index")
+	require.Equal(t, indices.Get(index.Names).Len(), loaded.Get(index.Names).Len())
+	require.Equal(t, []string{bob.Id}, loaded.Search(map[string]interface{}{"name": "bob"}))
+
+	// Indices should only be rebuilt if an index was not loaded or all are empty
+	require.True(t, loaded.NeedsReindex(), "expected reindex when indices were not loaded")
+	partial := index.NewIndices()
+	partial.Insert(bob)
+	for _, def := range partial.Definitions() {
+		data, err := partial.Dump(def.Name)
+		require.NoError(t, err, "could not dump %s index", def.Name)
+		require.NoError(t, loaded.Load(def.Name, data), "could not load %s index", def.Name)
+	}
+	require.True(t, loaded.Get(index.LEIs).Empty(), "expected the lei index to be empty")
+	require.False(t, loaded.Empty(), "indices should not be empty if any index has records")
+	require.False(t, loaded.NeedsReindex(), "a single empty index should not require a reindex")
+
+	empty := index.NewIndices()
+	for _, def := range empty.Definitions() {
+		data, err := empty.Dump(def.Name)
+		require.NoError(t, err, "could not dump empty %s index", def.Name)
+		require.NoError(t, empty.Load(def.Name, data), "could not load empty %s index", def.Name)
+	}
+	require.True(t, empty.NeedsReindex(), "expected reindex when all indices are empty")
+
+	require.Error(t, loaded.Load("foo", data), "expected error loading unknown index")
+	require.Error(t, loaded.Load(index.Countries, []byte("foo")), "expected error loading corrupted index")
+}
+
+func TestRegister(t *testing.T) {
+	// Cannot register an invalid index or an index that already exists
+	require.Panics(t, func() { index.Register(&index.Definition{Name: "foo"}) })
+	require.Panics(t, func() {
+		index.Register(&index.Definition{Name: index.Names, Query: "name", Extract: index.ExtractNames})
+	})
+	require.Panics(t, func() {
+		index.Register(&index.Definition{Name: "foo", Query: "foo", Mode: index.PrefixMatch, Extract: index.ExtractNames})
+	})
+}
+
+func mkvasp(id, commonName, name, website, country string) *pb.VASP {
+	return &pb.VASP{
+		Id:               id,
+		CommonName:       commonName,
+		Website:          website,
+		BusinessCategory: pb.BusinessCategory_PRIVATE_ORGANIZATION,
+		Entity: &ivms101.LegalPerson{
+			Name: &ivms101.LegalPersonName{
+				NameIdentifiers: []*ivms101.LegalPersonNameId{
+					{LegalPersonName: name, LegalPersonNameIdentifierType: ivms101.LegalPersonLegal},
+				},
+			},
+			CountryOfRegistration: country,
+		},
+	}
+}
@@ -0,0 +1,371 @@
+package index
+
+import (
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/trisacrypto/trisa/pkg/ivms101"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+// Names of the registered secondary indices, which are also used as the keys that the
+// serialized indices are stored under in the database.
+const (
+	Names              = "names"
+	Websites           = "websites"
+	Countries          = "countries"
+	Categories         = "categories"
+	LEIs               = "leis"
+	CommonNames        = "common_names"
+	DNSNames           = "dns_names"
+	CertificateSerials = "serials"
+	TextNames          = "text_names"
+	TextWebsites       = "text_websites"
+	TextAddresses      = "text_addresses"
+	TextTRIXO          = "text_trixo"
+)
+
+// Extractor returns the values from a VASP record that should be indexed; the values
+// are normalized by the index before they are stored so they may be returned as is.
+type Extractor func(vasp *pb.VASP) []string
+
+// SearchMode specifies how an index participates in a search query.
+type SearchMode uint8
+
+const (
+	// ExactMatch indices add records whose normalized value matches a query term.
+	ExactMatch SearchMode = iota
+
+	// PrefixMatch indices add records whose normalized value matches a query term or,
+	// if there is no exact match, has a prefix of the query term.
+	PrefixMatch
+
+	// Filter indices remove records matched by other indices that do not have the
+	// query term, e.g. to restrict results to a specific country.
+	Filter
+
+	// FullTextMatch indices tokenize the indexed values and add records that have
+	// tokens similar to every token of a query term, scored by relevance.
+	FullTextMatch
+)
+
+// Definition declares a secondary index over VASP records. Stores create, maintain,
+// serialize, and rebuild every registered index from its definition so that new
+// indices can be added without modifying the store implementations.
+type Definition struct {
+	Name      string     // The name of the index and its storage key
+	Query     string     // The search query key handled by the index
+	Unique    bool       // If true, each value maps to a single VASP record
+	Mode      SearchMode // How the index is used to search or filter records
+	Extract   Extractor  // Returns the values of a VASP record to index
+	Normalize Normalizer // Normalizes values before they are indexed or searched
+	Weight    float64    // Boosts the relevance of full text matches in the index
+}
+
+// New creates an empty index from the definition.
+func (d *Definition) New() Index {
+	if d.Mode == FullTextMatch {
+		return &normalizedFullText{index: make(FullText), query: d.Query, weight: d.Weight}
+	}
+
+	if d.Unique {
+		idx := &normalizedUnique{index: make(Unique), norm: d.Normalize}
+		switch d.Mode {
+		case PrefixMatch:
+			idx.search = idx.PrefixMatch(d.Query, searchPrefixMinLength)
+		default:
+			idx.search = idx.ExactMatch(d.Query)
+		}
+		return idx
+	}
+
+	idx := &normalizedContainer{index: make(Container), norm: d.Normalize}
+	idx.search = idx.ContainsRecord(d.Query)
+	return idx
+}
+
+// Validate that the definition can be used to create and maintain an index.
+func (d *Definition) Validate() error {
+	switch {
+	case d.Name == "":
+		return fmt.Errorf("index definition requires a name")
+	case d.Query == "":
+		return fmt.Errorf("index %q requires a query key", d.Name)
+	case d.Extract == nil:
+		return fmt.Errorf("index %q requires an extractor", d.Name)
+	case d.Mode == PrefixMatch && !d.Unique:
+		return fmt.Errorf("index %q: prefix matching is only supported by unique indices", d.Name)
+	case d.Mode == FullTextMatch && (d.Unique || d.Weight <= 0):
+		return fmt.Errorf("index %q: full text indices must not be unique and require a weight", d.Name)
+	}
+	return nil
+}
+
+var (
+	regmu    sync.RWMutex
+	registry []*Definition
+)
+
+// Register a secondary index so that it is maintained by stores that are opened after
+// the index is registered. Register panics if the definition is invalid or if an index
+// with the same name has already been registered.
+func Register(def *Definition) {
+	if err := def.Validate(); err != nil {
+		panic(err)
+	}
+
+	regmu.Lock()
+	defer regmu.Unlock()
+	for _, existing := range registry {
+		if existing.Name == def.Name {
+			panic(fmt.Errorf("index %q is already registered", def.Name))
+		}
+	}
+	registry = append(registry, def)
+}
+
+// Definitions returns the registered index definitions in registration order.
+func Definitions() []*Definition {
+	regmu.RLock()
+	defer regmu.RUnlock()
+	defs := make([]*Definition, len(registry))
+	copy(defs, registry)
+	return defs
+}
+
+func init() {
+	Register(&Definition{
+		Name:      Names,
+		Query:     "name",
+		Unique:    true,
+		Mode:      PrefixMatch,
+		Extract:   ExtractNames,
+		Normalize: Normalize,
+	})
+
+	Register(&Definition{
+		Name:      Websites,
+		Query:     "website",
+		Unique:    true,
+		Mode:      ExactMatch,
+		Extract:   ExtractWebsite,
+		Normalize: NormalizeURL,
+	})
+
+	Register(&Definition{
+		Name:      Countries,
+		Query:     "country",
+		Mode:      Filter,
+		Extract:   ExtractCountries,
+		Normalize: NormalizeCountry,
+	})
+
+	Register(&Definition{
+		Name:      Categories,
+		Query:     "category",
+		Mode:      Filter,
+		Extract:   ExtractCategories,
+		Normalize: Normalize,
+	})
+
+	Register(&Definition{
+		Name:      LEIs,
+		Query:     "lei",
+		Mode:      ExactMatch,
+		Extract:   ExtractLEIs,
+		Normalize: NormalizeIdentifier,
+	})
+
+	Register(&Definition{
+		Name:      CommonNames,
+		Query:     "common_name",
+		Unique:    true,
+		Mode:      ExactMatch,
+		Extract:   ExtractCommonName,
+		Normalize: Normalize,
+	})
+
+	Register(&Definition{
+		Name:      DNSNames,
+		Query:     "dns_name",
+		Mode:      ExactMatch,
+		Extract:   ExtractDNSNames,
+		Normalize: Normalize,
+	})
+
+	Register(&Definition{
+		Name:      CertificateSerials,
+		Query:     "serial_number",
+		Mode:      ExactMatch,
+		Extract:   ExtractCertificateSerials,
+		Normalize: NormalizeIdentifier,
+	})
+
+	// Full text indices are weighted so that matches in the names of a VASP are more
+	// relevant than matches in its website, addresses, or TRIXO questionnaire.
+	Register(&Definition{
+		Name:    TextNames,
+		Query:   "text",
+		Mode:    FullTextMatch,
+		Extract: ExtractNames,
+		Weight:  3,
+	})
+
+	Register(&Definition{
+		Name:    TextWebsites,
+		Query:   "text",
+		Mode:    FullTextMatch,
+		Extract: ExtractWebsite,
+		Weight:  2,
+	})
+
+	Register(&Definition{
+		Name:    TextAddresses,
+		Query:   "text",
+		Mode:    FullTextMatch,
+		Extract: ExtractAddresses,
+		Weight:  1,
+	})
+
+	Register(&Definition{
+		Name:    TextTRIXO,
+		Query:   "text",
+		Mode:    FullTextMatch,
+		Extract: ExtractTRIXO,
+		Weight:  1,
+	})
+}
+
+//===========================================================================
+// Extractors
+//===========================================================================
+
+// ExtractNames returns the common name and all of the legal entity names of the VASP.
+func ExtractNames(vasp *pb.VASP) []string {
+	names := []string{vasp.CommonName}
+	if vasp.Entity != nil {
+		names = append(names, vasp.Entity.Names()...)
+	}
+	return names
+}
+
+// ExtractWebsite returns the website of the VASP.
+func ExtractWebsite(vasp *pb.VASP) []string {
+	return []string{vasp.Website}
+}
+
+// ExtractCountries returns the country of registration and the countries of all of the
+// geographic addresses of the VASP.
+func ExtractCountries(vasp *pb.VASP) []string {
+	if vasp.Entity == nil {
+		return nil
+	}
+
+	countries := []string{vasp.Entity.CountryOfRegistration}
+	for _, addr := range vasp.Entity.GeographicAddresses {
+		countries = append(countries, addr.Country)
+	}
+	return countries
+}
+
+// ExtractCategories returns the business category and the VASP categories of the VASP.
+func ExtractCategories(vasp *pb.VASP) []string {
+	categories := []string{vasp.BusinessCategory.String()}
+	return append(categories, vasp.VaspCategories...)
+}
+
+// ExtractLEIs returns the legal entity identifier of the VASP if it has one.
+func ExtractLEIs(vasp *pb.VASP) []string {
+	if vasp.Entity == nil || vasp.Entity.NationalIdentification == nil {
+		return nil
+	}
+
+	if vasp.Entity.NationalIdentification.NationalIdentifierType != ivms101.NationalIdentifierLEIX {
+		return nil
+	}
+	return []string{vasp.Entity.NationalIdentification.NationalIdentifier}
+}
+
+// ExtractCommonName returns the common name of the VASP.
+func ExtractCommonName(vasp *pb.VASP) []string {
+	return []string{vasp.CommonName}
+}
+
+// ExtractDNSNames returns the subject alternative names of the identity certificate of
+// the VASP, including the common name which must be one of the DNS names.
+func ExtractDNSNames(vasp *pb.VASP) []string {
+	names := []string{vasp.CommonName}
+	if vasp.IdentityCertificate != nil && vasp.IdentityCertificate.Subject != nil {
+		names = append(names, vasp.IdentityCertificate.Subject.CommonName)
+	}
+
+	if vasp.IdentityCertificate != nil && len(vasp.IdentityCertificate.Data) > 0 {
+		// The certificate data is PEM encoded, if it cannot be parsed the SANs are
+		// simply not indexed since the certificate is validated on issuance.
+		if block, _ := pem.Decode(vasp.IdentityCertificate.Data); block != nil {
+			if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+				names = append(names, cert.DNSNames...)
+			}
+		}
+	}
+	return names
+}
+
+// ExtractCertificateSerials returns the hex encoded serial numbers of the identity and
+// signing certificates of the VASP.
+func ExtractCertificateSerials(vasp *pb.VASP) []string {
+	serials := make([]string, 0, len(vasp.SigningCertificates)+1)
+	if vasp.IdentityCertificate != nil && len(vasp.IdentityCertificate.SerialNumber) > 0 {
+		serials = append(serials, hex.EncodeToString(vasp.IdentityCertificate.SerialNumber))
+	}
+
+	for _, cert := range vasp.SigningCertificates {
+		if len(cert.SerialNumber) > 0 {
+			serials = append(serials, hex.EncodeToString(cert.SerialNumber))
+		}
+	}
+	return serials
+}
+
+// ExtractAddresses returns the text of the geographic addresses of the VASP.
+func ExtractAddresses(vasp *pb.VASP) []string {
+	if vasp.Entity == nil {
+		return nil
+	}
+
+	values := make([]string, 0, len(vasp.Entity.GeographicAddresses)*6)
+	for _, addr := range vasp.Entity.GeographicAddresses {
+		values = append(values, addr.AddressLine...)
+		values = append(values,
+			addr.Department, addr.SubDepartment, addr.StreetName, addr.BuildingName,
+			addr.PostCode, addr.TownName, addr.TownLocationName, addr.DistrictName,
+			addr.CountrySubDivision, addr.Country,
+		)
+	}
+	return values
+}
+
+// ExtractTRIXO returns the jurisdictions, regulators, and regulations in the TRIXO
+// questionnaire of the VASP.
+func ExtractTRIXO(vasp *pb.VASP) []string {
+	if vasp.Trixo == nil {
+		return nil
+	}
+
+	values := []string{vasp.Trixo.PrimaryNationalJurisdiction, vasp.Trixo.PrimaryRegulator}
+	for _, jurisdiction := range vasp.Trixo.OtherJurisdictions {
+		values = append(values, jurisdiction.Country, jurisdiction.RegulatorName, jurisdiction.LicenseNumber)
+	}
+	return append(values, vasp.Trixo.ApplicableRegulations...)
+}
+
+// NormalizeIdentifier normalizes identifiers such as LEIs and certificate serial
+// numbers so that they are matched regardless of case or separators.
+func NormalizeIdentifier(s string) string {
+	s = Normalize(s)
+	return strings.NewReplacer(":", "", "-", "", " ", "").Replace(s)
+}
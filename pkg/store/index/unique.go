@@ -80,6 +80,20 @@ func (c Unique) Reverse(value string, norm Normalizer) ([]string, bool) {
 	return results, len(results) > 0
 }
 
+// Purge removes all keys that map to the specified value, e.g. to remove a record from
+// the index without knowing which keys it was indexed by. Returns true if any keys were
+// removed from the index.
+func (c Unique) Purge(value string) bool {
+	removed := false
+	for key, val := range c {
+		if value == val {
+			delete(c, key)
+			removed = true
+		}
+	}
+	return removed
+}
+
 // Dump a unique index to a byte representation for storage on disk.
 func (c Unique) Dump() (_ []byte, err error) {
 	// Create a compressed writer to encode JSON into
@@ -131,6 +145,10 @@ func (c *normalizedUnique) Reverse(value string) ([]string, bool) {
 	return c.index.Reverse(value, c.norm)
 }
 
+func (c *normalizedUnique) Purge(value string) bool {
+	return c.index.Purge(value)
+}
+
 func (c *normalizedUnique) Find(key string) (string, bool) {
 	return c.index.Find(key, c.norm)
 }
@@ -66,6 +66,14 @@ func TestUniqueIndex(t *testing.T) {
 	require.False(t, ok)
 	require.Empty(t, vals)
 
+	// Purge all entries for a value
+	require.True(t, names.Add("purge me", id3, index.Normalize))
+	require.True(t, names.Add("purge me too", id3, index.Normalize))
+	require.Len(t, names, 5)
+	require.True(t, names.Purge(id3))
+	require.Len(t, names, 3)
+	require.False(t, names.Purge(id3))
+
 	// Test serialization and deserialization
 	data, err := names.Dump()
 	require.NoError(t, err)
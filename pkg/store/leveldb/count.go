@@ -29,6 +29,10 @@ func (s *Store) CountCertReqs(context.Context) (uint64, error) {
 	return s.countPrefix(preCertReqs)
 }
 
+func (s *Store) CountArchivedCertReqs(context.Context) (uint64, error) {
+	return s.countPrefix(preCertReqArchive)
+}
+
 func (s *Store) CountCerts(context.Context) (uint64, error) {
 	return s.countPrefix(preCerts)
 }
@@ -48,3 +52,11 @@ func (s *Store) CountOrganizations(context.Context) (uint64, error) {
 func (s *Store) CountContacts(context.Context) (uint64, error) {
 	return s.countPrefix(preContacts)
 }
+
+func (s *Store) CountJobs(context.Context) (uint64, error) {
+	return s.countPrefix(preJobs)
+}
+
+func (s *Store) CountEmails(context.Context) (uint64, error) {
+	return s.countPrefix(preEmails)
+}
@@ -22,7 +22,9 @@ import (
 	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
 	"github.com/trisacrypto/directory/pkg/store/index"
 	"github.com/trisacrypto/directory/pkg/store/iterator"
+	"github.com/trisacrypto/directory/pkg/utils/backups"
 	"github.com/trisacrypto/directory/pkg/utils/sentry"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 	"google.golang.org/protobuf/proto"
 )
@@ -39,11 +41,12 @@ func Open(path string) (*Store, error) {
 		return nil, err
 	}
 
-	// Perform a reindex if the local indices are null or empty. In the case where the
-	// store has no data, this won't be harmful - but in the case where the stored index
-	// has been corrupted, this should repair it.
-	if store.names.Empty() || store.websites.Empty() || store.countries.Empty() || store.categories.Empty() {
-		log.Info().Msg("reindexing to recover from empty indices")
+	// Perform a reindex if the local indices are empty or an index was not stored. In
+	// the case where the store has no data, this won't be harmful - but in the case
+	// where the stored index has been lost or a new index was registered, this should
+	// repair it.
+	if store.indices.NeedsReindex() {
+		log.Info().Msg("reindexing to recover from empty or missing indices")
 		if err = store.Reindex(); err != nil {
 			return nil, err
 		}
@@ -54,28 +57,25 @@ func Open(path string) (*Store, error) {
 
 // keys and prefixes for leveldb buckets and indices
 var (
-	keyAutoSequence  = []byte("sequence::pks")
-	keyNameIndex     = []byte("index::names")
-	keyWebsiteIndex  = []byte("index::websites")
-	keyCountryIndex  = []byte("index::countries")
-	keyCategoryIndex = []byte("index::categories")
-	preVASPs         = []byte("vasps::")
-	preCerts         = []byte("certs::")
-	preCertReqs      = []byte("certreqs::")
-	preOrganizations = []byte("organizations::")
-	preContacts      = []byte("contacts::")
+	keyAutoSequence   = []byte("sequence::pks")
+	preIndices        = []byte("index::")
+	preVASPs          = []byte("vasps::")
+	preCerts          = []byte("certs::")
+	preCertReqs       = []byte("certreqs::")
+	preCertReqArchive = []byte("certreqs_archive::")
+	preOrganizations  = []byte("organizations::")
+	preContacts       = []byte("contacts::")
+	preJobs           = []byte("jobs::")
+	preEmails         = []byte("emails::")
 )
 
 // Store implements store.Store for some basic LevelDB operations and simple protocol
 // buffer storage in a key/value database.
 type Store struct {
 	sync.RWMutex
-	db         *leveldb.DB
-	pkseq      index.Sequence    // autoincrement sequence for ID values
-	names      index.SingleIndex // case insensitive name index
-	websites   index.SingleIndex // website/url index
-	countries  index.MultiIndex  // lookup vasps in a specific country
-	categories index.MultiIndex  // lookup vasps based on specified categories
+	db      *leveldb.DB
+	pkseq   index.Sequence // autoincrement sequence for ID values
+	indices *index.Indices // registered secondary indices for vasp lookups
 }
 
 //===========================================================================
@@ -126,7 +126,7 @@ func (s *Store) CreateVASP(ctx context.Context, v *pb.VASP) (id string, err erro
 
 	// Check the uniqueness constraints
 	// NOTE: website removed as uniqueness constraint in SC-4483
-	if id, ok := s.names.Find(v.CommonName); ok && id != v.Id {
+	if id, ok := s.indices.Find(index.Names, v.CommonName); ok && id != v.Id {
 		return "", storeerrors.ErrDuplicateEntity
 	}
 
@@ -208,7 +208,7 @@ func (s *Store) UpdateVASP(ctx context.Context, v *pb.VASP) (err error) {
 
 	// Check the uniqueness constraints
 	// NOTE: website removed as uniqueness constraint in SC-4483
-	if id, ok := s.names.Find(v.CommonName); ok && id != v.Id {
+	if id, ok := s.indices.Find(index.Names, v.CommonName); ok && id != v.Id {
 		return storeerrors.ErrDuplicateEntity
 	}
 
@@ -270,64 +270,24 @@ func (s *Store) ListVASPs(ctx context.Context) iterator.DirectoryIterator {
 	}
 }
 
-// SearchVASPs uses the names and countries index to find VASPs that match the specified
+// SearchVASPs uses the registered indices to find VASPs that match the specified
 // query. This is a very simple search and is not intended for robust usage. To find a
 // VASP by name, a case insensitive search is performed if the query exists in
 // any of the VASP entity names. If there is not an exact match a prefix lookup is used
 // so long as the prefix > 3 characters. The search also looks up website matches by
-// parsing urls to match hostnames rather than scheme or path. Finally the query is
-// filtered by country and category.
+// parsing urls to match hostnames rather than scheme or path, as well as exact matches
+// of LEIs, common names, DNS names, and certificate serial numbers. Finally the query
+// is filtered by country and category.
 func (s *Store) SearchVASPs(ctx context.Context, query map[string]interface{}) (vasps []*pb.VASP, err error) {
-	// A set of records that match the query and need to be fetched
-	records := make(map[string]struct{})
-
+	// The records that match the query and need to be fetched
 	s.RLock()
-	// Search the name index
-	for _, result := range s.names.Search(query) {
-		records[result] = struct{}{}
-	}
-
-	// Lookup by website
-	for _, result := range s.websites.Search(query) {
-		records[result] = struct{}{}
-	}
-
-	// Filter by country
-	// NOTE: if country is not in the index, no records will be returned
-	countries, ok := index.ParseQuery("country", query, index.NormalizeCountry)
-	if ok {
-		for _, country := range countries {
-			for record := range records {
-				if !s.countries.Contains(country, record) {
-					// Remove the found VASP since it is not in the country index
-					// NOTE: safe to remove during map iteration: https://stackoverflow.com/questions/23229975/is-it-safe-to-remove-selected-keys-from-map-within-a-range-loop
-					delete(records, record)
-				}
-			}
-		}
-	}
-
-	// Filter by category
-	// NOTE: if category is not in the index, no records will be returned
-	categories, ok := index.ParseQuery("category", query, index.Normalize)
-	if ok {
-		for _, category := range categories {
-			for record := range records {
-				if !s.categories.Contains(category, record) {
-					// Remove the found VASP since it is not in the category index
-					// NOTE: safe to remove during map iteration: https://stackoverflow.com/questions/23229975/is-it-safe-to-remove-selected-keys-from-map-within-a-range-loop
-					delete(records, record)
-				}
-			}
-		}
-	}
-
+	records := s.indices.Search(query)
 	s.RUnlock()
 
 	// Perform the lookup of records if there are any
 	if len(records) > 0 {
 		vasps = make([]*pb.VASP, 0, len(records))
-		for id := range records {
+		for _, id := range records {
 			var vasp *pb.VASP
 			if vasp, err = s.RetrieveVASP(ctx, id); err != nil {
 				if err == storeerrors.ErrEntityNotFound {
@@ -472,7 +432,8 @@ func (s *Store) CreateCertReq(ctx context.Context, r *models.CertificateRequest)
 	return r.Id, nil
 }
 
-// RetrieveCertReq returns a certificate request by certificate request ID.
+// RetrieveCertReq returns a certificate request by certificate request ID. If the
+// request is not active it is retrieved from the archive.
 func (s *Store) RetrieveCertReq(ctx context.Context, id string) (r *models.CertificateRequest, err error) {
 	if id == "" {
 		return nil, storeerrors.ErrEntityNotFound
@@ -481,7 +442,7 @@ func (s *Store) RetrieveCertReq(ctx context.Context, id string) (r *models.Certi
 	var val []byte
 	if val, err = s.db.Get(careqKey(id), nil); err != nil {
 		if err == leveldb.ErrNotFound {
-			return nil, storeerrors.ErrEntityNotFound
+			return s.RetrieveArchivedCertReq(ctx, id)
 		}
 		return nil, err
 	}
@@ -530,6 +491,57 @@ func (s *Store) DeleteCertReq(ctx context.Context, id string) (err error) {
 	return nil
 }
 
+// ArchiveCertReq moves a certificate request from the active requests to the archive.
+func (s *Store) ArchiveCertReq(ctx context.Context, id string) (err error) {
+	if id == "" {
+		return storeerrors.ErrEntityNotFound
+	}
+
+	var val []byte
+	if val, err = s.db.Get(careqKey(id), nil); err != nil {
+		if err == leveldb.ErrNotFound {
+			return storeerrors.ErrEntityNotFound
+		}
+		return err
+	}
+
+	// Write the archived request and delete the active request atomically
+	batch := new(leveldb.Batch)
+	batch.Put(archivedCareqKey(id), val)
+	batch.Delete(careqKey(id))
+	return s.db.Write(batch, nil)
+}
+
+// ListArchivedCertReqs returns all certificate requests that have been archived.
+func (s *Store) ListArchivedCertReqs(ctx context.Context) iterator.CertificateRequestIterator {
+	return &certReqIterator{
+		iterWrapper{
+			iter: s.db.NewIterator(util.BytesPrefix(preCertReqArchive), nil),
+		},
+	}
+}
+
+// RetrieveArchivedCertReq returns an archived certificate request by ID.
+func (s *Store) RetrieveArchivedCertReq(ctx context.Context, id string) (r *models.CertificateRequest, err error) {
+	if id == "" {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+
+	var val []byte
+	if val, err = s.db.Get(archivedCareqKey(id), nil); err != nil {
+		if err == leveldb.ErrNotFound {
+			return nil, storeerrors.ErrEntityNotFound
+		}
+		return nil, err
+	}
+
+	r = new(models.CertificateRequest)
+	if err = proto.Unmarshal(val, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
 //===========================================================================
 // AnnouncementStore Implementation
 //===========================================================================
@@ -791,13 +803,30 @@ func (s *Store) DeleteOrganization(ctx context.Context, id uuid.UUID) (err error
 	return nil
 }
 
-// TODO: Complete the leveldb ContactStore implementation
 //===========================================================================
 // ContactStore Implementation
 //===========================================================================
 
+// ListContacts returns all of the contacts in the store ordered by email address.
 func (s *Store) ListContacts(ctx context.Context) []*models.Contact {
-	return nil
+	iter := s.db.NewIterator(util.BytesPrefix(preContacts), nil)
+	defer iter.Release()
+
+	contacts := make([]*models.Contact, 0)
+	for iter.Next() {
+		c := new(models.Contact)
+		if err := proto.Unmarshal(iter.Value(), c); err != nil {
+			sentry.Error(ctx).Err(err).Str("type", wire.NamespaceContacts).Str("key", string(iter.Key())).Msg("corrupted data encountered")
+			continue
+		}
+		contacts = append(contacts, c)
+	}
+
+	if err := iter.Error(); err != nil {
+		sentry.Error(ctx).Err(err).Msg("could not list contacts")
+		return nil
+	}
+	return contacts
 }
 
 // CreateContact creates a new Contact record in the store, using the contact's
@@ -876,6 +905,216 @@ func (s *Store) DeleteContact(ctx context.Context, email string) (err error) {
 	return nil
 }
 
+//===========================================================================
+// JobStore Implementation
+//===========================================================================
+
+// ListJobs returns all of the jobs in the store ordered by ID.
+func (s *Store) ListJobs(ctx context.Context) []*models.Job {
+	iter := s.db.NewIterator(util.BytesPrefix(preJobs), nil)
+	defer iter.Release()
+
+	jobs := make([]*models.Job, 0)
+	for iter.Next() {
+		j := new(models.Job)
+		if err := proto.Unmarshal(iter.Value(), j); err != nil {
+			sentry.Error(ctx).Err(err).Str("type", wire.NamespaceJobs).Str("key", string(iter.Key())).Msg("corrupted data encountered")
+			continue
+		}
+		jobs = append(jobs, j)
+	}
+
+	if err := iter.Error(); err != nil {
+		sentry.Error(ctx).Err(err).Msg("could not list jobs")
+		return nil
+	}
+	return jobs
+}
+
+// CreateJob creates a new job in the store and assigns it a unique ID.
+func (s *Store) CreateJob(ctx context.Context, j *models.Job) (_ string, err error) {
+	if j.Id != "" {
+		return "", storeerrors.ErrIDAlreadySet
+	}
+	j.Id = uuid.New().String()
+
+	// Update management timestamps and record metadata
+	j.Created = time.Now().Format(time.RFC3339)
+	j.Modified = j.Created
+
+	var data []byte
+	if data, err = proto.Marshal(j); err != nil {
+		return "", err
+	}
+
+	if err = s.db.Put(jobKey(j.Id), data, nil); err != nil {
+		return "", err
+	}
+	return j.Id, nil
+}
+
+// RetrieveJob returns a job by its ID.
+func (s *Store) RetrieveJob(ctx context.Context, id string) (j *models.Job, err error) {
+	if id == "" {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+
+	var data []byte
+	if data, err = s.db.Get(jobKey(id), nil); err != nil {
+		if err == leveldb.ErrNotFound {
+			return nil, storeerrors.ErrEntityNotFound
+		}
+		return nil, err
+	}
+
+	j = new(models.Job)
+	if err = proto.Unmarshal(data, j); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// UpdateJob can create or update a job. The job should be as complete as possible,
+// including an ID generated by the caller.
+func (s *Store) UpdateJob(ctx context.Context, j *models.Job) (err error) {
+	if j.Id == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	// Update management timestamps and record metadata
+	j.Modified = time.Now().Format(time.RFC3339)
+	if j.Created == "" {
+		j.Created = j.Modified
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(j); err != nil {
+		return err
+	}
+
+	if err = s.db.Put(jobKey(j.Id), data, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteJob deletes a job from the store by ID.
+func (s *Store) DeleteJob(ctx context.Context, id string) (err error) {
+	if id == "" {
+		return storeerrors.ErrEntityNotFound
+	}
+
+	if err = s.db.Delete(jobKey(id), nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+//===========================================================================
+// EmailStore Implementation
+//===========================================================================
+
+// ListEmails returns all of the outbox emails in the store ordered by ID.
+func (s *Store) ListEmails(ctx context.Context) []*models.Email {
+	iter := s.db.NewIterator(util.BytesPrefix(preEmails), nil)
+	defer iter.Release()
+
+	emails := make([]*models.Email, 0)
+	for iter.Next() {
+		e := new(models.Email)
+		if err := proto.Unmarshal(iter.Value(), e); err != nil {
+			sentry.Error(ctx).Err(err).Str("type", wire.NamespaceEmails).Str("key", string(iter.Key())).Msg("corrupted data encountered")
+			continue
+		}
+		emails = append(emails, e)
+	}
+
+	if err := iter.Error(); err != nil {
+		sentry.Error(ctx).Err(err).Msg("could not list emails")
+		return nil
+	}
+	return emails
+}
+
+// CreateEmail creates a new outbox email in the store and assigns it a unique ID.
+func (s *Store) CreateEmail(ctx context.Context, e *models.Email) (_ string, err error) {
+	if e.Id != "" {
+		return "", storeerrors.ErrIDAlreadySet
+	}
+	e.Id = uuid.New().String()
+
+	// Update management timestamps and record metadata
+	e.Created = time.Now().Format(time.RFC3339)
+	e.Modified = e.Created
+
+	var data []byte
+	if data, err = proto.Marshal(e); err != nil {
+		return "", err
+	}
+
+	if err = s.db.Put(emailKey(e.Id), data, nil); err != nil {
+		return "", err
+	}
+	return e.Id, nil
+}
+
+// RetrieveEmail returns an outbox email by its ID.
+func (s *Store) RetrieveEmail(ctx context.Context, id string) (e *models.Email, err error) {
+	if id == "" {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+
+	var data []byte
+	if data, err = s.db.Get(emailKey(id), nil); err != nil {
+		if err == leveldb.ErrNotFound {
+			return nil, storeerrors.ErrEntityNotFound
+		}
+		return nil, err
+	}
+
+	e = new(models.Email)
+	if err = proto.Unmarshal(data, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// UpdateEmail can create or update an outbox email. The email should be as complete as possible,
+// including an ID generated by the caller.
+func (s *Store) UpdateEmail(ctx context.Context, e *models.Email) (err error) {
+	if e.Id == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	// Update management timestamps and record metadata
+	e.Modified = time.Now().Format(time.RFC3339)
+	if e.Created == "" {
+		e.Created = e.Modified
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(e); err != nil {
+		return err
+	}
+
+	if err = s.db.Put(emailKey(e.Id), data, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteEmail deletes an outbox email from the store by ID.
+func (s *Store) DeleteEmail(ctx context.Context, id string) (err error) {
+	if id == "" {
+		return storeerrors.ErrEntityNotFound
+	}
+
+	if err = s.db.Delete(emailKey(id), nil); err != nil {
+		return err
+	}
+	return nil
+}
+
 //===========================================================================
 // Key Handlers
 //===========================================================================
@@ -904,6 +1143,11 @@ func careqKey(id string) []byte {
 	return makeKey(preCertReqs, id)
 }
 
+// creates a []byte key from the cert request id using the archive prefix
+func archivedCareqKey(id string) []byte {
+	return makeKey(preCertReqArchive, id)
+}
+
 // prefixes a key generated by the organization model to emulate buckets in leveldb.
 func orgKey(orgKey []byte) (key []byte) {
 	key = make([]byte, 0, len(preOrganizations)+len(orgKey))
@@ -912,22 +1156,34 @@ func orgKey(orgKey []byte) (key []byte) {
 	return key
 }
 
+// creates a []byte key for the index name using a prefix to act as a leveldb bucket
+func indexKey(name string) []byte {
+	return makeKey(preIndices, name)
+}
+
 func contactKey(email string) []byte {
 	email = models.NormalizeEmail(email)
 	return makeKey(preContacts, email)
 }
 
+// creates a []byte key from the job id using a prefix to act as a leveldb bucket
+func jobKey(id string) []byte {
+	return makeKey(preJobs, id)
+}
+
+// creates a []byte key from the email id using a prefix to act as a leveldb bucket
+func emailKey(id string) []byte {
+	return makeKey(preEmails, id)
+}
+
 //===========================================================================
 // Indexer
 //===========================================================================
 
-// Reindex rebuilds the name and country indices for the server and synchronizes them
+// Reindex rebuilds all of the registered indices for the server and synchronizes them
 // back to disk to ensure they're complete and accurate.
 func (s *Store) Reindex() (err error) {
-	names := index.NewNamesIndex()
-	websites := index.NewWebsiteIndex()
-	countries := index.NewCountryIndex()
-	categories := index.NewCategoryIndex()
+	indices := index.NewIndices()
 
 	iter := s.db.NewIterator(util.BytesPrefix(preVASPs), nil)
 	defer iter.Release()
@@ -937,27 +1193,7 @@ func (s *Store) Reindex() (err error) {
 		if err = proto.Unmarshal(iter.Value(), vasp); err != nil {
 			return err
 		}
-
-		// Update name index
-		names.Add(vasp.CommonName, vasp.Id)
-		for _, name := range vasp.Entity.Names() {
-			names.Add(name, vasp.Id)
-		}
-
-		// Update website index
-		websites.Add(vasp.Website, vasp.Id)
-
-		// Update country index
-		countries.Add(vasp.Entity.CountryOfRegistration, vasp.Id)
-		for _, addr := range vasp.Entity.GeographicAddresses {
-			countries.Add(addr.Country, vasp.Id)
-		}
-
-		// Update category index
-		categories.Add(vasp.BusinessCategory.String(), vasp.Id)
-		for _, vaspCategory := range vasp.VaspCategories {
-			categories.Add(vaspCategory, vasp.Id)
-		}
+		indices.Insert(vasp)
 	}
 
 	if err = iter.Error(); err != nil {
@@ -965,33 +1201,16 @@ func (s *Store) Reindex() (err error) {
 	}
 
 	s.Lock()
-	if !names.Empty() {
-		s.names = names
-	}
-
-	if !websites.Empty() {
-		s.websites = websites
-	}
-
-	if !countries.Empty() {
-		s.countries = countries
-	}
-
-	if !categories.Empty() {
-		s.categories = categories
-	}
+	s.indices = indices
 	s.Unlock()
 
 	if err = s.sync(); err != nil {
 		return err
 	}
 
-	log.Debug().
-		Int("names", s.names.Len()).
-		Int("websites", s.websites.Len()).
-		Int("countries", s.countries.Len()).
-		Int("categories", s.categories.Len()).
-		Msg("reindex complete")
+	s.RLock()
+	log.Debug().Object("indices", s.indices).Msg("reindex complete")
+	s.RUnlock()
 	return nil
 }
 
@@ -1008,7 +1227,7 @@ func (s *Store) Backup(path string) (err error) {
 	}
 
 	// Create the directory for the copied leveldb database
-	archive := filepath.Join(path, time.Now().UTC().Format("gdsdb-200601021504"))
+	archive := filepath.Join(path, backups.ArchiveName(backups.GDSPrefix, time.Now()))
 	if err = os.Mkdir(archive, 0744); err != nil {
 		return fmt.Errorf("could not create archive directory: %s", err)
 	}
@@ -1134,44 +1353,15 @@ func CopyDB(src *leveldb.DB, dst *leveldb.DB) (ncopied uint64, err error) {
 // Indices and Synchronization
 //===========================================================================
 
+// insertIndices adds the record to the registered indices; must be called under lock.
 func (s *Store) insertIndices(v *pb.VASP) (err error) {
-	s.names.Add(v.CommonName, v.Id)
-	for _, name := range v.Entity.Names() {
-		s.names.Add(name, v.Id)
-	}
-
-	s.websites.Add(v.Website, v.Id)
-
-	s.countries.Add(v.Entity.CountryOfRegistration, v.Id)
-	for _, addr := range v.Entity.GeographicAddresses {
-		s.countries.Add(addr.Country, v.Id)
-	}
-
-	s.categories.Add(v.BusinessCategory.String(), v.Id)
-	for _, vaspCategory := range v.VaspCategories {
-		s.categories.Add(vaspCategory, v.Id)
-	}
-
+	s.indices.Insert(v)
 	return nil
 }
 
+// removeIndices removes the record from the registered indices; must be called under lock.
 func (s *Store) removeIndices(v *pb.VASP) (err error) {
-	s.names.Remove(v.CommonName)
-	for _, name := range v.Entity.Names() {
-		s.names.Remove(name)
-	}
-
-	s.websites.Remove(v.Website)
-
-	s.countries.Remove(v.Entity.CountryOfRegistration, v.Id)
-	for _, addr := range v.Entity.GeographicAddresses {
-		s.countries.Remove(addr.Country, v.Id)
-	}
-
-	s.categories.Remove(v.BusinessCategory.String(), v.Id)
-	for _, vaspCategory := range v.VaspCategories {
-		s.categories.Remove(vaspCategory, v.Id)
-	}
+	s.indices.Remove(v)
 	return nil
 }
 
@@ -1181,28 +1371,23 @@ func (s *Store) sync() (err error) {
 		return err
 	}
 
-	if err = s.syncnames(); err != nil {
-		return err
-	}
-
-	if err = s.syncwebsites(); err != nil {
-		return err
-	}
+	// Critical section (optimizing for safety rather than speed)
+	s.Lock()
+	defer s.Unlock()
 
-	if err = s.synccountries(); err != nil {
-		return err
+	// Create the indices and load them from disk on the first sync
+	load := s.indices == nil
+	if load {
+		s.indices = index.NewIndices()
 	}
 
-	if err = s.synccategories(); err != nil {
-		return err
+	for _, def := range s.indices.Definitions() {
+		if err = s.syncindex(def.Name, load); err != nil {
+			return err
+		}
 	}
 
-	log.Debug().
-		Int("names", s.names.Len()).
-		Int("websites", s.websites.Len()).
-		Int("countries", s.countries.Len()).
-		Int("categories", s.categories.Len()).
-		Msg("indices synchronized")
+	log.Debug().Object("indices", s.indices).Msg("indices synchronized")
 	return nil
 }
 
@@ -1246,178 +1431,40 @@ func (s *Store) seqsync() (err error) {
 	return nil
 }
 
-// sync the names index with the leveldb names key
-func (s *Store) syncnames() (err error) {
-	var val []byte
-
-	// Critical section (optimizing for safety rather than speed)
-	s.Lock()
-	defer s.Unlock()
-
-	if s.names == nil {
-		// Create the index to load it from disk
-		s.names = index.NewNamesIndex()
-
-		// fetch the names from the database
-		if val, err = s.db.Get(keyNameIndex, nil); err != nil {
-			if err == leveldb.ErrNotFound {
-				return nil
-			}
-			log.Error().Err(err).Msg("could not fetch names index from database")
-			return err
-		}
-
-		if err = s.names.Load(val); err != nil {
-			log.Error().Err(err).Msg("could not unmarshal names index")
-			return storeerrors.ErrCorruptedIndex
-		}
-	}
-
-	// Put the current names back to the database
-	if !s.names.Empty() {
-		if val, err = s.names.Dump(); err != nil {
-			log.Error().Err(err).Msg("could not marshal names index")
-			return storeerrors.ErrCorruptedIndex
-		}
-
-		if err = s.db.Put(keyNameIndex, val, nil); err != nil {
-			log.Error().Err(err).Msg("could not put names index")
-			return storeerrors.ErrCorruptedIndex
-		}
-	}
-
-	log.Debug().Int("size", len(val)).Msg("names index checkpointed")
-	return nil
-}
-
-// sync the websites index with the leveldb websites key
-func (s *Store) syncwebsites() (err error) {
-	var val []byte
-
-	// Critical section (optimizing for safety rather than speed)
-	s.Lock()
-	defer s.Unlock()
-
-	if s.websites == nil {
-		// Create the index to load it from disk
-		s.websites = index.NewWebsiteIndex()
-
-		// fetch the websites from the database
-		if val, err = s.db.Get(keyWebsiteIndex, nil); err != nil {
-			if err == leveldb.ErrNotFound {
-				return nil
-			}
-			log.Error().Err(err).Msg("could not fetch websites index from database")
-			return err
-		}
-
-		if err = s.websites.Load(val); err != nil {
-			log.Error().Err(err).Msg("could not unmarshal websites index")
-			return storeerrors.ErrCorruptedIndex
-		}
-	}
-
-	// Put the current websites back to the database
-	if !s.websites.Empty() {
-		if val, err = s.websites.Dump(); err != nil {
-			log.Error().Err(err).Msg("could not marshal websites index")
-			return storeerrors.ErrCorruptedIndex
-		}
-
-		if err = s.db.Put(keyWebsiteIndex, val, nil); err != nil {
-			log.Error().Err(err).Msg("could not put websites index")
-			return storeerrors.ErrCorruptedIndex
-		}
-	}
-
-	log.Debug().Int("size", len(val)).Msg("websites index checkpointed")
-	return nil
-}
-
-// sync the countries index with the leveldb countries key
-func (s *Store) synccountries() (err error) {
+// sync an index with its leveldb index key, loading the index from disk if specified.
+// Must be called under lock.
+func (s *Store) syncindex(name string, load bool) (err error) {
 	var val []byte
+	key := indexKey(name)
 
-	// Critical section (optimizing for safety rather than speed)
-	s.Lock()
-	defer s.Unlock()
-
-	if s.countries == nil {
-		// Create the countries index and load from the database
-		s.countries = index.NewCountryIndex()
-
-		// fetch the countries from the database
-		if val, err = s.db.Get(keyCountryIndex, nil); err != nil {
+	if load {
+		// fetch the index from the database
+		if val, err = s.db.Get(key, nil); err != nil {
 			if err == leveldb.ErrNotFound {
 				return nil
 			}
-			log.Error().Err(err).Msg("could fetch country index from database")
+			log.Error().Err(err).Str("index", name).Msg("could not fetch index from database")
 			return err
 		}
 
-		if err = s.countries.Load(val); err != nil {
-			log.Error().Err(err).Msg("could not unmarshal country index")
+		if err = s.indices.Load(name, val); err != nil {
+			log.Error().Err(err).Str("index", name).Msg("could not unmarshal index")
 			return storeerrors.ErrCorruptedIndex
 		}
 	}
 
-	if !s.countries.Empty() {
-		// Put the current countries back to the database
-		if val, err = s.countries.Dump(); err != nil {
-			log.Error().Err(err).Msg("could not marshal country index")
-			return storeerrors.ErrCorruptedIndex
-		}
-
-		if err = s.db.Put(keyCountryIndex, val, nil); err != nil {
-			log.Error().Err(err).Msg("could not put country index")
-			return storeerrors.ErrCorruptedIndex
-		}
+	// Put the current index back to the database; empty indices are also stored so
+	// that they are not treated as missing when the store is opened.
+	if val, err = s.indices.Dump(name); err != nil {
+		log.Error().Err(err).Str("index", name).Msg("could not marshal index")
+		return storeerrors.ErrCorruptedIndex
 	}
 
-	log.Debug().Int("size", len(val)).Msg("country index checkpointed")
-	return nil
-}
-
-// sync the categories index with the leveldb categories key
-func (s *Store) synccategories() (err error) {
-	var val []byte
-
-	// Critical section (optimizing for safety rather than speed)
-	s.Lock()
-	defer s.Unlock()
-
-	if s.categories == nil {
-		// Create the categories index and load from the database
-		s.categories = index.NewCategoryIndex()
-
-		// fetch the categories from the database
-		if val, err = s.db.Get(keyCategoryIndex, nil); err != nil {
-			if err == leveldb.ErrNotFound {
-				return nil
-			}
-			log.Error().Err(err).Msg("could fetch categories index from database")
-			return err
-		}
-
-		if err = s.categories.Load(val); err != nil {
-			log.Error().Err(err).Msg("could not unmarshal categories index")
-			return storeerrors.ErrCorruptedIndex
-		}
-	}
-
-	if !s.categories.Empty() {
-		// Put the current categories back to the database
-		if val, err = s.categories.Dump(); err != nil {
-			log.Error().Err(err).Msg("could not marshal categories index")
-			return storeerrors.ErrCorruptedIndex
-		}
-
-		if err = s.db.Put(keyCategoryIndex, val, nil); err != nil {
-			log.Error().Err(err).Msg("could not put categories index")
-			return storeerrors.ErrCorruptedIndex
-		}
+	if err = s.db.Put(key, val, nil); err != nil {
+		log.Error().Err(err).Str("index", name).Msg("could not put index")
+		return storeerrors.ErrCorruptedIndex
 	}
 
-	log.Debug().Int("size", len(val)).Msg("categories index checkpointed")
+	log.Debug().Str("index", name).Int("size", len(val)).Msg("index checkpointed")
 	return nil
 }
@@ -327,6 +327,69 @@ func (s *leveldbTestSuite) TestCertificateRequestStore() {
 	s.Equal(10, niters)
 }
 
+func (s *leveldbTestSuite) TestCertificateRequestArchive() {
+	ctx := context.Background()
+
+	// Archiving a certificate request that does not exist should error
+	s.ErrorIs(s.db.ArchiveCertReq(ctx, uuid.New().String()), storeerrors.ErrEntityNotFound)
+
+	// Create a few certificate requests to archive
+	ids := make([]string, 0, 3)
+	for i := 0; i < 3; i++ {
+		crr := &models.CertificateRequest{
+			Vasp:       uuid.New().String(),
+			CommonName: fmt.Sprintf("archive%d.example.com", i+1),
+			Status:     models.CertificateRequestState_COMPLETED,
+		}
+		id, err := s.db.CreateCertReq(ctx, crr)
+		s.NoError(err)
+		ids = append(ids, id)
+	}
+
+	// Archive the first certificate request
+	s.NoError(s.db.ArchiveCertReq(ctx, ids[0]))
+
+	// The archived request should no longer be active but should still be retrievable
+	reqs, err := s.db.ListCertReqs(ctx).All()
+	s.NoError(err)
+	s.Len(reqs, 2)
+
+	count, err := s.db.CountCertReqs(ctx)
+	s.NoError(err)
+	s.Equal(uint64(2), count)
+
+	crr, err := s.db.RetrieveArchivedCertReq(ctx, ids[0])
+	s.NoError(err)
+	s.Equal(ids[0], crr.Id)
+	s.Equal(models.CertificateRequestState_COMPLETED, crr.Status)
+
+	crr, err = s.db.RetrieveCertReq(ctx, ids[0])
+	s.NoError(err, "archived certificate requests should be retrievable by id")
+	s.Equal(ids[0], crr.Id)
+
+	_, err = s.db.RetrieveArchivedCertReq(ctx, ids[1])
+	s.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	// A certificate request cannot be archived twice
+	s.ErrorIs(s.db.ArchiveCertReq(ctx, ids[0]), storeerrors.ErrEntityNotFound)
+
+	// Archive the remaining certificate requests
+	s.NoError(s.db.ArchiveCertReq(ctx, ids[1]))
+	s.NoError(s.db.ArchiveCertReq(ctx, ids[2]))
+
+	archived, err := s.db.ListArchivedCertReqs(ctx).All()
+	s.NoError(err)
+	s.Len(archived, 3)
+
+	count, err = s.db.CountArchivedCertReqs(ctx)
+	s.NoError(err)
+	s.Equal(uint64(3), count)
+
+	count, err = s.db.CountCertReqs(ctx)
+	s.NoError(err)
+	s.Equal(uint64(0), count)
+}
+
 func (s *leveldbTestSuite) TestAnnouncementStore() {
 	// Load the announcement month record from testdata
 	data, err := os.ReadFile("../testdata/announcements.json")
@@ -698,3 +761,241 @@ func (s *leveldbTestSuite) TestContactStore() {
 	s.Nil(con)
 	s.Equal(err, storeerrors.ErrEntityNotFound)
 }
+
+func (s *leveldbTestSuite) TestJobStore() {
+	ctx := context.Background()
+
+	// Make sure create errors if the ID is already set
+	job := &models.Job{Id: "b5841869-105f-411c-8722-4045aad72717"}
+	id, err := s.db.CreateJob(ctx, job)
+	s.Empty(id)
+	s.Equal(err, storeerrors.ErrIDAlreadySet)
+
+	// Create a valid job
+	job, err = models.NewJob(models.JobRegistration, "d9da630e-41aa-11ec-9d29-acde48001122")
+	s.NoError(err)
+	id, err = s.db.CreateJob(ctx, job)
+	s.NoError(err)
+	s.NotEmpty(id)
+	s.Equal(id, job.Id)
+
+	// Make sure retrieve throws the proper error when a job is not found
+	var j *models.Job
+	j, err = s.db.RetrieveJob(ctx, "")
+	s.Nil(j)
+	s.Equal(err, storeerrors.ErrEntityNotFound)
+
+	j, err = s.db.RetrieveJob(ctx, "b5841869-105f-411c-8722-4045aad72717")
+	s.Nil(j)
+	s.Equal(err, storeerrors.ErrEntityNotFound)
+
+	// Retrieve the created job
+	j, err = s.db.RetrieveJob(ctx, id)
+	s.NoError(err)
+	s.Equal(job.Type, j.Type)
+	s.Equal(job.Vasp, j.Vasp)
+	s.Equal(models.JobState_JOB_QUEUED, j.Status)
+	s.NotEmpty(j.Created)
+	s.NotEmpty(j.Modified)
+
+	// Make sure update errors with a job without an ID
+	err = s.db.UpdateJob(ctx, &models.Job{})
+	s.Equal(err, storeerrors.ErrIncompleteRecord)
+
+	// Properly update the job
+	j.Status = models.JobState_JOB_RETRYING
+	j.Attempts = 1
+	j.CompleteStep("review")
+	s.NoError(s.db.UpdateJob(ctx, j))
+
+	// The updated job should be listed and counted
+	jobs := s.db.ListJobs(ctx)
+	s.Len(jobs, 1)
+	s.Equal(models.JobState_JOB_RETRYING, jobs[0].Status)
+	s.Equal(uint32(1), jobs[0].Attempts)
+	s.Equal([]string{"review"}, jobs[0].CompletedSteps)
+
+	count, err := s.db.CountJobs(ctx)
+	s.NoError(err)
+	s.Equal(uint64(1), count)
+
+	// Make sure delete errors with an empty ID
+	err = s.db.DeleteJob(ctx, "")
+	s.Equal(err, storeerrors.ErrEntityNotFound)
+
+	// Delete the job
+	s.NoError(s.db.DeleteJob(ctx, id))
+	j, err = s.db.RetrieveJob(ctx, id)
+	s.Nil(j)
+	s.Equal(err, storeerrors.ErrEntityNotFound)
+	s.Empty(s.db.ListJobs(ctx))
+}
+
+func (s *leveldbTestSuite) TestEmailStore() {
+	ctx := context.Background()
+
+	// Make sure create errors if the ID is already set
+	email := &models.Email{Id: "b5841869-105f-411c-8722-4045aad72717"}
+	id, err := s.db.CreateEmail(ctx, email)
+	s.Empty(id)
+	s.Equal(err, storeerrors.ErrIDAlreadySet)
+
+	// Create a valid email
+	email, err = models.NewEmail("deliver_certs", "Welcome to TRISA", "admin@example.com", "d9da630e-41aa-11ec-9d29-acde48001122", []byte(`{"subject":"Welcome to TRISA"}`))
+	s.NoError(err)
+	id, err = s.db.CreateEmail(ctx, email)
+	s.NoError(err)
+	s.NotEmpty(id)
+	s.Equal(id, email.Id)
+
+	// Make sure retrieve throws the proper error when an email is not found
+	var e *models.Email
+	e, err = s.db.RetrieveEmail(ctx, "")
+	s.Nil(e)
+	s.Equal(err, storeerrors.ErrEntityNotFound)
+
+	e, err = s.db.RetrieveEmail(ctx, "b5841869-105f-411c-8722-4045aad72717")
+	s.Nil(e)
+	s.Equal(err, storeerrors.ErrEntityNotFound)
+
+	// Retrieve the created email
+	e, err = s.db.RetrieveEmail(ctx, id)
+	s.NoError(err)
+	s.Equal(email.Reason, e.Reason)
+	s.Equal(email.Recipient, e.Recipient)
+	s.Equal(email.Vasp, e.Vasp)
+	s.Equal(email.Draft, e.Draft)
+	s.Equal(models.EmailState_EMAIL_QUEUED, e.Status)
+	s.NotEmpty(e.Created)
+	s.NotEmpty(e.Modified)
+
+	// Make sure update errors with an email without an ID
+	err = s.db.UpdateEmail(ctx, &models.Email{})
+	s.Equal(err, storeerrors.ErrIncompleteRecord)
+
+	// Properly update the email
+	e.Status = models.EmailState_EMAIL_DEAD_LETTER
+	e.Attempts = 8
+	e.LastError = "could not connect to smtp server"
+	s.NoError(s.db.UpdateEmail(ctx, e))
+
+	// The updated email should be listed and counted
+	emails := s.db.ListEmails(ctx)
+	s.Len(emails, 1)
+	s.Equal(models.EmailState_EMAIL_DEAD_LETTER, emails[0].Status)
+	s.Equal(uint32(8), emails[0].Attempts)
+	s.Equal(e.LastError, emails[0].LastError)
+
+	count, err := s.db.CountEmails(ctx)
+	s.NoError(err)
+	s.Equal(uint64(1), count)
+
+	// Make sure delete errors with an empty ID
+	err = s.db.DeleteEmail(ctx, "")
+	s.Equal(err, storeerrors.ErrEntityNotFound)
+
+	// Delete the email
+	s.NoError(s.db.DeleteEmail(ctx, id))
+	e, err = s.db.RetrieveEmail(ctx, id)
+	s.Nil(e)
+	s.Equal(err, storeerrors.ErrEntityNotFound)
+	s.Empty(s.db.ListEmails(ctx))
+}
+
+func (s *leveldbTestSuite) TestTxn() {
+	// Use a separate database so that the VASPs of other tests do not conflict
+	db, err := Open(s.T().TempDir())
+	s.Require().NoError(err)
+	defer db.Close()
+
+	ctx := context.Background()
+	data, err := os.ReadFile("../testdata/vasp.json")
+	s.Require().NoError(err)
+
+	alice := &pb.VASP{}
+	s.Require().NoError(protojson.Unmarshal(data, alice))
+
+	// Create a VASP, certificate request, and contact in a single transaction
+	tx, err := db.Begin(ctx)
+	s.Require().NoError(err)
+
+	vaspID, err := tx.CreateVASP(alice)
+	s.Require().NoError(err)
+
+	certreq := &models.CertificateRequest{Vasp: vaspID, CommonName: alice.CommonName}
+	certreqID, err := tx.CreateCertReq(certreq)
+	s.Require().NoError(err)
+	s.NoError(models.AppendCertReqID(alice, certreqID))
+
+	_, err = tx.CreateContact(&models.Contact{Email: "Alice@example.com"})
+	s.NoError(err)
+
+	// Writes should not be visible until the transaction is committed
+	_, err = db.RetrieveVASP(ctx, vaspID)
+	s.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	s.Require().NoError(tx.Commit(ctx))
+	s.ErrorIs(tx.Commit(ctx), storeerrors.ErrTxnClosed)
+
+	vasp, err := db.RetrieveVASP(ctx, vaspID)
+	s.Require().NoError(err)
+	s.Equal(uint64(1), vasp.Version.Version)
+	ids, err := models.GetCertReqIDs(vasp)
+	s.NoError(err)
+	s.Equal([]string{certreqID}, ids)
+
+	certreq, err = db.RetrieveCertReq(ctx, certreqID)
+	s.Require().NoError(err)
+	s.Equal(vaspID, certreq.Vasp)
+
+	_, err = db.RetrieveContact(ctx, "alice@example.com")
+	s.NoError(err)
+
+	// The indices should be updated on commit
+	_, err = db.CreateVASP(ctx, &pb.VASP{CommonName: alice.CommonName})
+	s.ErrorIs(err, storeerrors.ErrDuplicateEntity)
+
+	// A failed commit should not write any of the records in the transaction
+	bob := &pb.VASP{CommonName: "trisa.bob.example.com"}
+	_, err = db.CreateVASP(ctx, bob)
+	s.Require().NoError(err)
+
+	tx, err = db.Begin(ctx)
+	s.Require().NoError(err)
+	defer tx.Rollback()
+
+	certreq.Status = models.CertificateRequestState_READY_TO_SUBMIT
+	s.NoError(tx.UpdateCertReq(certreq))
+	bob.CommonName = alice.CommonName
+	s.NoError(tx.UpdateVASP(bob))
+	s.ErrorIs(tx.Commit(ctx), storeerrors.ErrDuplicateEntity)
+
+	certreq, err = db.RetrieveCertReq(ctx, certreqID)
+	s.Require().NoError(err)
+	s.Equal(models.CertificateRequestState_INITIALIZED, certreq.Status)
+
+	// A rolled back transaction cannot be used
+	tx.Rollback()
+	s.ErrorIs(tx.UpdateCertReq(certreq), storeerrors.ErrTxnClosed)
+
+	// Update the VASP and delete the certificate request in a transaction
+	tx, err = db.Begin(ctx)
+	s.Require().NoError(err)
+	s.NoError(tx.DeleteCertReq(certreqID))
+	s.NoError(models.DeleteCertReqID(vasp, certreqID))
+	vasp.CommonName = "trisa.alice.example.com"
+	s.NoError(tx.UpdateVASP(vasp))
+	s.Require().NoError(tx.Commit(ctx))
+
+	_, err = db.RetrieveCertReq(ctx, certreqID)
+	s.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	vasp, err = db.RetrieveVASP(ctx, vaspID)
+	s.Require().NoError(err)
+	s.Equal(uint64(2), vasp.Version.Version)
+	s.Equal("trisa.alice.example.com", vasp.CommonName)
+
+	// The previous common name should have been removed from the indices
+	_, err = db.CreateVASP(ctx, &pb.VASP{CommonName: alice.CommonName})
+	s.NoError(err)
+}
@@ -0,0 +1,123 @@
+package leveldb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	bff "github.com/trisacrypto/directory/pkg/bff/models/v1"
+	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+)
+
+// ScanRaw calls fn with the ID and serialized record of every object in the namespace
+// in key order. Announcement and activity months are not prefixed in leveldb so they
+// cannot be scanned and must be retrieved by date with GetRaw instead.
+func (s *Store) ScanRaw(ctx context.Context, namespace string, fn func(id string, data []byte) error) (err error) {
+	var prefix []byte
+	if prefix, err = rawPrefix(namespace); err != nil {
+		return err
+	}
+
+	iter := s.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		id := iter.Key()[len(prefix):]
+		if namespace == wire.NamespaceOrganizations {
+			var orgID uuid.UUID
+			if orgID, err = uuid.FromBytes(id); err != nil {
+				return err
+			}
+			id = []byte(orgID.String())
+		}
+
+		// The iterator reuses the key and value slices so copy them for the callback
+		if err = fn(string(id), append([]byte(nil), iter.Value()...)); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+// GetRaw returns the serialized record with the specified ID in the namespace.
+func (s *Store) GetRaw(ctx context.Context, namespace, id string) (data []byte, err error) {
+	var key []byte
+	if key, err = rawKey(namespace, id); err != nil {
+		return nil, err
+	}
+
+	if data, err = s.db.Get(key, nil); err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return nil, storeerrors.ErrEntityNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+// PutRaw writes the serialized record with the specified ID to the namespace verbatim,
+// creating it or replacing the existing record.
+// NOTE: the indices are not updated when VASP records are written, call Reindex.
+func (s *Store) PutRaw(ctx context.Context, namespace, id string, data []byte) (err error) {
+	var key []byte
+	if key, err = rawKey(namespace, id); err != nil {
+		return err
+	}
+	return s.db.Put(key, data, nil)
+}
+
+// Returns the key prefix of the namespace to scan its records.
+func rawPrefix(namespace string) ([]byte, error) {
+	switch namespace {
+	case wire.NamespaceVASPs:
+		return preVASPs, nil
+	case wire.NamespaceCerts:
+		return preCerts, nil
+	case wire.NamespaceCertReqs:
+		return preCertReqs, nil
+	case wire.NamespaceCertReqArchive:
+		return preCertReqArchive, nil
+	case wire.NamespaceOrganizations:
+		return preOrganizations, nil
+	case wire.NamespaceContacts:
+		return preContacts, nil
+	case wire.NamespaceJobs:
+		return preJobs, nil
+	case wire.NamespaceEmails:
+		return preEmails, nil
+	case wire.NamespaceAnnouncements, wire.NamespaceActivities:
+		return nil, errors.New("cannot scan months in leveldb")
+	default:
+		return nil, storeerrors.ErrUnknownNamespace
+	}
+}
+
+// Returns the key of the record with the specified ID in the namespace.
+func rawKey(namespace, id string) (_ []byte, err error) {
+	switch namespace {
+	case wire.NamespaceAnnouncements, wire.NamespaceActivities:
+		// Months are stored by their date without a prefix
+		if _, err = time.Parse(bff.MonthLayout, id); err != nil {
+			return nil, err
+		}
+		return []byte(id), nil
+	case wire.NamespaceOrganizations:
+		var orgID uuid.UUID
+		if orgID, err = uuid.Parse(id); err != nil {
+			return nil, err
+		}
+		return orgKey(orgID[:]), nil
+	case wire.NamespaceContacts:
+		return contactKey(id), nil
+	}
+
+	var prefix []byte
+	if prefix, err = rawPrefix(namespace); err != nil {
+		return nil, err
+	}
+	return makeKey(prefix, id), nil
+}
@@ -0,0 +1,141 @@
+package leveldb
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"github.com/trisacrypto/directory/pkg/utils"
+)
+
+// ValidateArchive checks the integrity of a backup archive by extracting it to a
+// temporary directory and reading every record in the database with strict checksum
+// verification. The number of records in the archive is returned.
+func ValidateArchive(path string) (nrecords uint64, err error) {
+	var tmp string
+	if tmp, err = os.MkdirTemp("", "restore-*"); err != nil {
+		return 0, fmt.Errorf("could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	var db *leveldb.DB
+	if db, err = openArchive(path, tmp); err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	iter := db.NewIterator(nil, nil)
+	for iter.Next() {
+		nrecords++
+	}
+
+	iter.Release()
+	if err = iter.Error(); err != nil {
+		return nrecords, fmt.Errorf("archive is corrupted after %d records: %s", nrecords, err)
+	}
+	return nrecords, nil
+}
+
+// RestoreArchive restores a backup archive into a fresh leveldb database at dst. The
+// archive is extracted and validated in a temporary directory next to dst and its
+// records are copied into the new database, so a corrupted archive never leaves a
+// partially restored database behind. The destination must not exist or must be an
+// empty directory. The number of records restored is returned.
+//
+// The stored indices are removed from the restored database since they may have been
+// checkpointed before the most recent records in the archive were written; both the
+// GDS leveldb and trtl stores rebuild missing indices when they are opened.
+func RestoreArchive(path, dst string) (nrecords uint64, err error) {
+	// Refuse to overwrite an existing database
+	var entries []os.DirEntry
+	if entries, err = os.ReadDir(dst); err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	if len(entries) > 0 {
+		return 0, fmt.Errorf("restore destination %s is not empty", dst)
+	}
+
+	parent := filepath.Dir(filepath.Clean(dst))
+	if err = os.MkdirAll(parent, 0755); err != nil {
+		return 0, fmt.Errorf("could not create restore directory: %s", err)
+	}
+
+	var tmp string
+	if tmp, err = os.MkdirTemp(parent, ".restore-*"); err != nil {
+		return 0, fmt.Errorf("could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	var src *leveldb.DB
+	if src, err = openArchive(path, tmp); err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	var db *leveldb.DB
+	if db, err = leveldb.OpenFile(dst, &opt.Options{ErrorIfExist: true}); err != nil {
+		return 0, fmt.Errorf("could not create restore database: %s", err)
+	}
+
+	if nrecords, err = CopyDB(src, db); err != nil {
+		db.Close()
+		os.RemoveAll(dst)
+		return nrecords, fmt.Errorf("could not restore all records, restored %d records: %s", nrecords, err)
+	}
+
+	if err = dropIndices(db); err != nil {
+		db.Close()
+		os.RemoveAll(dst)
+		return nrecords, err
+	}
+
+	if err = db.Close(); err != nil {
+		return nrecords, fmt.Errorf("could not close restore database: %s", err)
+	}
+
+	log.Info().Str("archive", path).Str("db", dst).Uint64("records", nrecords).Msg("archive restored")
+	return nrecords, nil
+}
+
+// dropIndices deletes the stored indices from the database. Both the GDS leveldb store
+// and the honu engine used by trtl store the indices in the index namespace.
+func dropIndices(db *leveldb.DB) (err error) {
+	batch := new(leveldb.Batch)
+	iter := db.NewIterator(util.BytesPrefix(preIndices), nil)
+	for iter.Next() {
+		batch.Delete(iter.Key())
+	}
+
+	iter.Release()
+	if err = iter.Error(); err != nil {
+		return fmt.Errorf("could not iterate over restored indices: %s", err)
+	}
+
+	if err = db.Write(batch, &opt.WriteOptions{Sync: true}); err != nil {
+		return fmt.Errorf("could not delete restored indices: %s", err)
+	}
+	return nil
+}
+
+// openArchive extracts the archive into the directory and opens the extracted leveldb
+// database read-only with strict checksum verification.
+func openArchive(path, dir string) (db *leveldb.DB, err error) {
+	var root string
+	if root, err = utils.ExtractGzip(path, dir, false); err != nil {
+		return nil, fmt.Errorf("could not extract archive: %s", err)
+	}
+
+	if root == "" {
+		return nil, errors.New("archive does not contain a database directory")
+	}
+
+	if db, err = leveldb.OpenFile(root, &opt.Options{ReadOnly: true, ErrorIfMissing: true, Strict: opt.StrictAll}); err != nil {
+		return nil, fmt.Errorf("could not open archive database: %s", err)
+	}
+	return db, nil
+}
@@ -0,0 +1,106 @@
+package leveldb
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"github.com/trisacrypto/directory/pkg/utils"
+	"github.com/trisacrypto/directory/pkg/utils/backups"
+	"github.com/trisacrypto/directory/pkg/utils/logger"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+func TestRestore(t *testing.T) {
+	logger.Discard()
+	defer logger.ResetLogger()
+
+	db, err := Open(t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	id, err := db.CreateVASP(context.Background(), &pb.VASP{CommonName: "trisa.example.com"})
+	require.NoError(t, err)
+
+	// Create a backup archive of the database
+	dir := t.TempDir()
+	require.NoError(t, db.Backup(dir))
+
+	archives, err := backups.Staged(dir, backups.GDSPrefix)
+	require.NoError(t, err)
+	require.Len(t, archives, 1)
+	archive := archives[0]
+
+	nrecords, err := ValidateArchive(archive)
+	require.NoError(t, err)
+	require.NotZero(t, nrecords)
+
+	// Restore the archive into a fresh database
+	dst := filepath.Join(t.TempDir(), "restored")
+	nrestored, err := RestoreArchive(archive, dst)
+	require.NoError(t, err)
+	require.Equal(t, nrecords, nrestored)
+
+	// The indices should be removed so that they are rebuilt when the store is opened
+	ldb, err := leveldb.OpenFile(dst, nil)
+	require.NoError(t, err)
+	iter := ldb.NewIterator(util.BytesPrefix(preIndices), nil)
+	require.False(t, iter.Next(), "expected indices to be removed from the restored database")
+	iter.Release()
+	require.NoError(t, ldb.Close())
+
+	restored, err := Open(dst)
+	require.NoError(t, err)
+	vasp, err := restored.RetrieveVASP(context.Background(), id)
+	require.NoError(t, err)
+	require.Equal(t, "trisa.example.com", vasp.CommonName)
+	require.Equal(t, []string{id}, restored.indices.Search(map[string]interface{}{"common_name": "trisa.example.com"}))
+	require.NoError(t, restored.Close())
+
+	// Cannot restore over an existing database
+	_, err = RestoreArchive(archive, dst)
+	require.Error(t, err)
+
+	// A truncated archive should not validate or restore
+	data, err := os.ReadFile(archive)
+	require.NoError(t, err)
+	corrupt := filepath.Join(dir, "gdsdb-200001010000.tgz")
+	require.NoError(t, os.WriteFile(corrupt, data[:len(data)/2], 0644))
+
+	_, err = ValidateArchive(corrupt)
+	require.Error(t, err)
+
+	dst = filepath.Join(t.TempDir(), "corrupted")
+	_, err = RestoreArchive(corrupt, dst)
+	require.Error(t, err)
+	require.NoDirExists(t, dst)
+
+	// Archives written by the trtl backup manager do not have a root directory
+	trtldb := filepath.Join(t.TempDir(), "trtldb")
+	ldb, err = leveldb.OpenFile(trtldb, nil)
+	require.NoError(t, err)
+	require.NoError(t, ldb.Put([]byte("foo"), []byte("bar"), nil))
+	require.NoError(t, ldb.Put([]byte("index::names"), []byte("{}"), nil))
+	require.NoError(t, ldb.Close())
+	require.NoError(t, utils.WriteGzip(trtldb, filepath.Join(dir, "trtldb-202201021504.tgz")))
+
+	archive = filepath.Join(dir, "trtldb-202201021504.tgz")
+
+	dst = filepath.Join(t.TempDir(), "trtl")
+	nrestored, err = RestoreArchive(archive, dst)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), nrestored)
+
+	ldb, err = leveldb.OpenFile(dst, nil)
+	require.NoError(t, err)
+	defer ldb.Close()
+	_, err = ldb.Get([]byte("index::names"), nil)
+	require.ErrorIs(t, err, leveldb.ErrNotFound, "expected trtl index to be removed")
+	val, err := ldb.Get([]byte("foo"), nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("bar"), val)
+}
@@ -0,0 +1,108 @@
+package leveldb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
+	"github.com/trisacrypto/directory/pkg/store/index"
+	"github.com/trisacrypto/directory/pkg/store/txn"
+	"github.com/trisacrypto/directory/pkg/utils/sentry"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Begin a transaction that is committed to leveldb as a single write batch, so that
+// either all of the writes in the transaction are applied or none of them are.
+func (s *Store) Begin(ctx context.Context) (txn.Txn, error) {
+	return txn.New(s.commit), nil
+}
+
+// commit the operations of a transaction in a leveldb write batch.
+func (s *Store) commit(ctx context.Context, ops []*txn.Operation) (err error) {
+	batch := new(leveldb.Batch)
+
+	// Critical section (optimizing for safety rather than speed)
+	s.Lock()
+	defer s.Unlock()
+
+	// Keep track of the VASP records that are written and their previous versions so
+	// that the indices can be updated after the write batch is applied.
+	var prev, vasps []*pb.VASP
+	for _, op := range ops {
+		var key []byte
+		if key, err = txnKey(op); err != nil {
+			return err
+		}
+
+		if op.Delete() {
+			batch.Delete(key)
+			continue
+		}
+
+		if v, ok := op.VASP(); ok {
+			// The original record must be retrieved inside the lock so that the
+			// indices are correctly updated with what is on disk.
+			if !op.Create {
+				var o *pb.VASP
+				if o, err = s.RetrieveVASP(ctx, v.Id); err != nil {
+					return err
+				}
+				prev = append(prev, o)
+			}
+
+			// Check the uniqueness constraints
+			if id, ok := s.indices.Find(index.Names, v.CommonName); ok && id != v.Id {
+				return storeerrors.ErrDuplicateEntity
+			}
+			vasps = append(vasps, v)
+		}
+
+		var data []byte
+		if data, err = proto.Marshal(op.Record); err != nil {
+			return err
+		}
+		batch.Put(key, data)
+	}
+
+	// The write must be inside the lock so that the indices reflect the database.
+	if err = s.db.Write(batch, nil); err != nil {
+		return err
+	}
+
+	for _, o := range prev {
+		if err = s.removeIndices(o); err != nil {
+			// NOTE: if this error is triggered, admins may want to reindex the database
+			sentry.Error(ctx).Err(err).Msg("could not remove previous indices on commit: reindex required")
+		}
+	}
+
+	for _, v := range vasps {
+		if err = s.insertIndices(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// txnKey returns the leveldb key for the namespace and key of the operation.
+func txnKey(op *txn.Operation) ([]byte, error) {
+	switch op.Namespace {
+	case wire.NamespaceVASPs:
+		return vaspKey(op.Key), nil
+	case wire.NamespaceCerts:
+		return certKey(op.Key), nil
+	case wire.NamespaceCertReqs:
+		return careqKey(op.Key), nil
+	case wire.NamespaceContacts:
+		return contactKey(op.Key), nil
+	case wire.NamespaceJobs:
+		return jobKey(op.Key), nil
+	case wire.NamespaceEmails:
+		return emailKey(op.Key), nil
+	default:
+		return nil, fmt.Errorf("unhandled transaction namespace %q", op.Namespace)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/trisacrypto/directory/pkg/models/v1"
 	"github.com/trisacrypto/directory/pkg/store"
 	"github.com/trisacrypto/directory/pkg/store/iterator"
+	"github.com/trisacrypto/directory/pkg/store/txn"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 )
 
@@ -40,6 +41,10 @@ type MockState struct {
 	UpdateCertReqInvoked             bool
 	DeleteCertReqInvoked             bool
 	CountCertReqsInvoked             bool
+	ArchiveCertReqInvoked            bool
+	ListArchivedCertReqsInvoked      bool
+	RetrieveArchivedCertReqInvoked   bool
+	CountArchivedCertReqsInvoked     bool
 	ListCertInvoked                  bool
 	CreateCertInvoked                bool
 	RetrieveCertInvoked              bool
@@ -66,6 +71,22 @@ type MockState struct {
 	UpdateContactInvoked             bool
 	DeleteContactInvoked             bool
 	CountContactsInvoked             bool
+	ListJobsInvoked                  bool
+	CreateJobInvoked                 bool
+	RetrieveJobInvoked               bool
+	UpdateJobInvoked                 bool
+	DeleteJobInvoked                 bool
+	CountJobsInvoked                 bool
+	ListEmailsInvoked                bool
+	CreateEmailInvoked               bool
+	RetrieveEmailInvoked             bool
+	UpdateEmailInvoked               bool
+	DeleteEmailInvoked               bool
+	CountEmailsInvoked               bool
+	BeginInvoked                     bool
+	ScanRawInvoked                   bool
+	GetRawInvoked                    bool
+	PutRawInvoked                    bool
 	ReindexInvoked                   bool
 	BackupInvoked                    bool
 }
@@ -97,6 +118,10 @@ type MockDB struct {
 	OnUpdateCertReq             func(r *models.CertificateRequest) error
 	OnDeleteCertReq             func(id string) error
 	OnCountCertReqs             func(context.Context) (uint64, error)
+	OnArchiveCertReq            func(id string) error
+	OnListArchivedCertReqs      func() iterator.CertificateRequestIterator
+	OnRetrieveArchivedCertReq   func(id string) (*models.CertificateRequest, error)
+	OnCountArchivedCertReqs     func(context.Context) (uint64, error)
 	OnListCerts                 func() iterator.CertificateIterator
 	OnCreateCert                func(c *models.Certificate) (string, error)
 	OnRetrieveCert              func(id string) (*models.Certificate, error)
@@ -123,6 +148,22 @@ type MockDB struct {
 	OnUpdateContact             func(c *models.Contact) error
 	OnDeleteContact             func(email string) error
 	OnCountContacts             func(context.Context) (uint64, error)
+	OnListJobs                  func() []*models.Job
+	OnCreateJob                 func(j *models.Job) (string, error)
+	OnRetrieveJob               func(id string) (*models.Job, error)
+	OnUpdateJob                 func(j *models.Job) error
+	OnDeleteJob                 func(id string) error
+	OnCountJobs                 func(context.Context) (uint64, error)
+	OnListEmails                func() []*models.Email
+	OnCreateEmail               func(e *models.Email) (string, error)
+	OnRetrieveEmail             func(id string) (*models.Email, error)
+	OnUpdateEmail               func(e *models.Email) error
+	OnDeleteEmail               func(id string) error
+	OnCountEmails               func(context.Context) (uint64, error)
+	OnBegin                     func() (txn.Txn, error)
+	OnScanRaw                   func(namespace string, fn func(id string, data []byte) error) error
+	OnGetRaw                    func(namespace, id string) ([]byte, error)
+	OnPutRaw                    func(namespace, id string, data []byte) error
 	OnReindex                   func() error
 	OnBackup                    func(string) error
 }
@@ -217,6 +258,26 @@ func (m *MockDB) CountCertReqs(ctx context.Context) (uint64, error) {
 	return m.OnCountCertReqs(ctx)
 }
 
+func (m *MockDB) ArchiveCertReq(_ context.Context, id string) error {
+	state.ArchiveCertReqInvoked = true
+	return m.OnArchiveCertReq(id)
+}
+
+func (m *MockDB) ListArchivedCertReqs(_ context.Context) iterator.CertificateRequestIterator {
+	state.ListArchivedCertReqsInvoked = true
+	return m.OnListArchivedCertReqs()
+}
+
+func (m *MockDB) RetrieveArchivedCertReq(_ context.Context, id string) (*models.CertificateRequest, error) {
+	state.RetrieveArchivedCertReqInvoked = true
+	return m.OnRetrieveArchivedCertReq(id)
+}
+
+func (m *MockDB) CountArchivedCertReqs(ctx context.Context) (uint64, error) {
+	state.CountArchivedCertReqsInvoked = true
+	return m.OnCountArchivedCertReqs(ctx)
+}
+
 func (m *MockDB) ListCerts(_ context.Context) iterator.CertificateIterator {
 	state.ListCertInvoked = true
 	return m.OnListCerts()
@@ -347,6 +408,86 @@ func (m *MockDB) CountContacts(ctx context.Context) (uint64, error) {
 	return m.OnCountContacts(ctx)
 }
 
+func (m *MockDB) ListJobs(_ context.Context) []*models.Job {
+	state.ListJobsInvoked = true
+	return m.OnListJobs()
+}
+
+func (m *MockDB) CreateJob(_ context.Context, j *models.Job) (string, error) {
+	state.CreateJobInvoked = true
+	return m.OnCreateJob(j)
+}
+
+func (m *MockDB) RetrieveJob(_ context.Context, id string) (*models.Job, error) {
+	state.RetrieveJobInvoked = true
+	return m.OnRetrieveJob(id)
+}
+
+func (m *MockDB) UpdateJob(_ context.Context, j *models.Job) error {
+	state.UpdateJobInvoked = true
+	return m.OnUpdateJob(j)
+}
+
+func (m *MockDB) DeleteJob(_ context.Context, id string) error {
+	state.DeleteJobInvoked = true
+	return m.OnDeleteJob(id)
+}
+
+func (m *MockDB) CountJobs(ctx context.Context) (uint64, error) {
+	state.CountJobsInvoked = true
+	return m.OnCountJobs(ctx)
+}
+
+func (m *MockDB) ListEmails(_ context.Context) []*models.Email {
+	state.ListEmailsInvoked = true
+	return m.OnListEmails()
+}
+
+func (m *MockDB) CreateEmail(_ context.Context, e *models.Email) (string, error) {
+	state.CreateEmailInvoked = true
+	return m.OnCreateEmail(e)
+}
+
+func (m *MockDB) RetrieveEmail(_ context.Context, id string) (*models.Email, error) {
+	state.RetrieveEmailInvoked = true
+	return m.OnRetrieveEmail(id)
+}
+
+func (m *MockDB) UpdateEmail(_ context.Context, e *models.Email) error {
+	state.UpdateEmailInvoked = true
+	return m.OnUpdateEmail(e)
+}
+
+func (m *MockDB) DeleteEmail(_ context.Context, id string) error {
+	state.DeleteEmailInvoked = true
+	return m.OnDeleteEmail(id)
+}
+
+func (m *MockDB) CountEmails(ctx context.Context) (uint64, error) {
+	state.CountEmailsInvoked = true
+	return m.OnCountEmails(ctx)
+}
+
+func (m *MockDB) Begin(_ context.Context) (txn.Txn, error) {
+	state.BeginInvoked = true
+	return m.OnBegin()
+}
+
+func (m *MockDB) ScanRaw(_ context.Context, namespace string, fn func(id string, data []byte) error) error {
+	state.ScanRawInvoked = true
+	return m.OnScanRaw(namespace, fn)
+}
+
+func (m *MockDB) GetRaw(_ context.Context, namespace, id string) ([]byte, error) {
+	state.GetRawInvoked = true
+	return m.OnGetRaw(namespace, id)
+}
+
+func (m *MockDB) PutRaw(_ context.Context, namespace, id string, data []byte) error {
+	state.PutRawInvoked = true
+	return m.OnPutRaw(namespace, id, data)
+}
+
 func (m *MockDB) Reindex() error {
 	state.ReindexInvoked = true
 	return m.OnReindex()
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+func (s *Store) countTable(ctx context.Context, table string) (count uint64, err error) {
+	if err = s.db.QueryRowContext(ctx, fmt.Sprintf("SELECT COUNT(*) FROM %s", table)).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *Store) CountVASPs(ctx context.Context) (uint64, error) {
+	return s.countTable(ctx, tableVASPs)
+}
+
+func (s *Store) CountCertReqs(ctx context.Context) (uint64, error) {
+	return s.countTable(ctx, tableCertReqs)
+}
+
+func (s *Store) CountArchivedCertReqs(ctx context.Context) (uint64, error) {
+	return s.countTable(ctx, tableCertReqArchive)
+}
+
+func (s *Store) CountCerts(ctx context.Context) (uint64, error) {
+	return s.countTable(ctx, tableCerts)
+}
+
+func (s *Store) CountAnnouncementMonths(ctx context.Context) (uint64, error) {
+	return s.countTable(ctx, tableAnnouncements)
+}
+
+func (s *Store) CountActivityMonth(ctx context.Context) (uint64, error) {
+	return s.countTable(ctx, tableActivities)
+}
+
+func (s *Store) CountOrganizations(ctx context.Context) (uint64, error) {
+	return s.countTable(ctx, tableOrganizations)
+}
+
+func (s *Store) CountContacts(ctx context.Context) (uint64, error) {
+	return s.countTable(ctx, tableContacts)
+}
+
+func (s *Store) CountJobs(ctx context.Context) (uint64, error) {
+	return s.countTable(ctx, tableJobs)
+}
+
+func (s *Store) CountEmails(ctx context.Context) (uint64, error) {
+	return s.countTable(ctx, tableEmails)
+}
@@ -0,0 +1,270 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	bff "github.com/trisacrypto/directory/pkg/bff/models/v1"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/utils/sentry"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/protobuf/proto"
+)
+
+// The number of rows fetched from the database at a time by the row iterator.
+const iterPageSize = 100
+
+type vaspIterator struct {
+	*rowIterator
+}
+
+type certIterator struct {
+	*rowIterator
+}
+
+type certReqIterator struct {
+	*rowIterator
+}
+
+type organizationIterator struct {
+	*rowIterator
+}
+
+// rowIterator iterates over the records of a table in key order, loading the rows from
+// the database in pages using the last key of the previous page as the cursor so that
+// large tables are not held open in a single query. Loaded rows are retained so that
+// the iterator can move backwards with Prev; call Release to free them.
+type rowIterator struct {
+	ctx    context.Context
+	db     *sql.DB
+	table  string
+	keys   []string
+	values [][]byte
+	index  int
+	start  string
+	done   bool
+	err    error
+}
+
+func newRowIterator(ctx context.Context, db *sql.DB, table string) *rowIterator {
+	return &rowIterator{
+		ctx:   ctx,
+		db:    db,
+		table: table,
+		index: -1,
+	}
+}
+
+func (i *rowIterator) Next() bool {
+	if i.err != nil {
+		return false
+	}
+
+	i.index++
+	if i.index < len(i.keys) {
+		return true
+	}
+
+	if i.done {
+		i.index = len(i.keys)
+		return false
+	}
+
+	i.fetch()
+	return i.err == nil && i.index < len(i.keys)
+}
+
+func (i *rowIterator) Prev() bool {
+	i.index--
+	if i.index < 0 {
+		i.index = -1
+		return false
+	}
+	return i.index < len(i.keys)
+}
+
+// Seek moves the iterator to the first key that is greater than or equal to the key
+// and returns true if there is such a key. Seek must be called before Next.
+func (i *rowIterator) Seek(key string) bool {
+	if len(i.keys) > 0 || i.done {
+		i.err = errors.New("cannot call Seek() on a row iterator after Next() has been called")
+		return false
+	}
+
+	i.start = key
+	return i.Next()
+}
+
+// fetch the next page of rows from the database.
+func (i *rowIterator) fetch() {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	if len(i.keys) == 0 {
+		rows, err = i.db.QueryContext(i.ctx, fmt.Sprintf("SELECT id, data FROM %s WHERE id >= $1 ORDER BY id LIMIT $2", i.table), i.start, iterPageSize)
+	} else {
+		rows, err = i.db.QueryContext(i.ctx, fmt.Sprintf("SELECT id, data FROM %s WHERE id > $1 ORDER BY id LIMIT $2", i.table), i.keys[len(i.keys)-1], iterPageSize)
+	}
+
+	if err != nil {
+		i.err = err
+		return
+	}
+	defer rows.Close()
+
+	nrows := 0
+	for rows.Next() {
+		var (
+			key   string
+			value []byte
+		)
+
+		if err = rows.Scan(&key, &value); err != nil {
+			i.err = err
+			return
+		}
+
+		i.keys = append(i.keys, key)
+		i.values = append(i.values, value)
+		nrows++
+	}
+
+	if err = rows.Err(); err != nil {
+		i.err = err
+		return
+	}
+
+	// If a partial page is returned there are no more rows to fetch
+	i.done = nrows < iterPageSize
+}
+
+func (i *rowIterator) Key() string {
+	if i.index < 0 || i.index >= len(i.keys) {
+		return ""
+	}
+	return i.keys[i.index]
+}
+
+func (i *rowIterator) Value() []byte {
+	if i.index < 0 || i.index >= len(i.values) {
+		return nil
+	}
+	return i.values[i.index]
+}
+
+func (i *rowIterator) Error() error {
+	return i.err
+}
+
+func (i *rowIterator) Release() {
+	// Cleanup values stored in memory and mark for garbage collection
+	i.keys = nil
+	i.values = nil
+	i.done = true
+}
+
+func (i *vaspIterator) Id() string {
+	return i.Key()
+}
+
+func (i *vaspIterator) SeekId(vaspID string) bool {
+	return i.Seek(vaspID)
+}
+
+func (i *vaspIterator) VASP() (*pb.VASP, error) {
+	vasp := new(pb.VASP)
+	if err := proto.Unmarshal(i.Value(), vasp); err != nil {
+		sentry.Error(nil).Err(err).Str("type", wire.NamespaceVASPs).Str("key", i.Key()).Msg("corrupted data encountered")
+		return nil, err
+	}
+	return vasp, nil
+}
+
+func (i *vaspIterator) All() (vasps []*pb.VASP, err error) {
+	vasps = make([]*pb.VASP, 0)
+	defer i.Release()
+
+	for i.Next() {
+		vasp := new(pb.VASP)
+		if err = proto.Unmarshal(i.Value(), vasp); err != nil {
+			return nil, err
+		}
+		vasps = append(vasps, vasp)
+	}
+
+	if err = i.Error(); err != nil {
+		return nil, err
+	}
+	return vasps, nil
+}
+
+func (i *certIterator) Cert() (*models.Certificate, error) {
+	c := new(models.Certificate)
+	if err := proto.Unmarshal(i.Value(), c); err != nil {
+		sentry.Error(nil).Err(err).Str("type", wire.NamespaceCerts).Str("key", i.Key()).Msg("corrupted data encountered")
+		return nil, err
+	}
+	return c, nil
+}
+
+func (i *certIterator) All() (certs []*models.Certificate, err error) {
+	certs = make([]*models.Certificate, 0)
+	defer i.Release()
+
+	for i.Next() {
+		c := new(models.Certificate)
+		if err = proto.Unmarshal(i.Value(), c); err != nil {
+			return nil, err
+		}
+		certs = append(certs, c)
+	}
+
+	if err = i.Error(); err != nil {
+		return nil, err
+	}
+	return certs, nil
+}
+
+func (i *certReqIterator) CertReq() (*models.CertificateRequest, error) {
+	r := new(models.CertificateRequest)
+	if err := proto.Unmarshal(i.Value(), r); err != nil {
+		sentry.Error(nil).Err(err).Str("type", wire.NamespaceCertReqs).Str("key", i.Key()).Msg("corrupted data encountered")
+		return nil, err
+	}
+	return r, nil
+}
+
+func (i *certReqIterator) All() (reqs []*models.CertificateRequest, err error) {
+	reqs = make([]*models.CertificateRequest, 0)
+	defer i.Release()
+
+	for i.Next() {
+		r := new(models.CertificateRequest)
+		if err = proto.Unmarshal(i.Value(), r); err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, r)
+	}
+
+	if err = i.Error(); err != nil {
+		return nil, err
+	}
+	return reqs, nil
+}
+
+func (i *organizationIterator) ID() string {
+	return i.Key()
+}
+
+func (i *organizationIterator) Organization() (o *bff.Organization, err error) {
+	o = new(bff.Organization)
+	if err = proto.Unmarshal(i.Value(), o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
@@ -0,0 +1,296 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	bff "github.com/trisacrypto/directory/pkg/bff/models/v1"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	"github.com/trisacrypto/directory/pkg/store/config"
+	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
+	"github.com/trisacrypto/directory/pkg/utils/logger"
+	"github.com/trisacrypto/trisa/pkg/ivms101"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestSearchQuery(t *testing.T) {
+	// No match terms should not produce a query
+	stmt, args := searchQuery(map[string]interface{}{"country": "US"})
+	require.Empty(t, stmt)
+	require.Empty(t, args)
+
+	stmt, args = searchQuery(map[string]interface{}{
+		"name":    []string{"Alice VASP", "al"},
+		"country": "United States",
+		"text":    "alice springfield",
+	})
+	require.Contains(t, stmt, "SELECT v.data FROM")
+	require.Contains(t, stmt, "ORDER BY m.score DESC, v.id ASC")
+	require.Contains(t, args, "alice vasp", "expected normalized name term")
+	require.Contains(t, args, "alice vasp%", "expected prefix pattern for long term")
+	require.NotContains(t, args, "al%", "expected no prefix pattern for short term")
+	require.Contains(t, args, "US", "expected normalized country filter")
+	require.Contains(t, args, "alice:* & springfield:*", "expected full text query")
+
+	require.Equal(t, "alice:* & ab & springfield:*", tsquery("Alice, AB, the Springfield"))
+	require.Equal(t, `50\%\_off`, escapeLike("50%_off"))
+}
+
+type postgresTestSuite struct {
+	suite.Suite
+	db *Store
+}
+
+func TestPostgres(t *testing.T) {
+	// These tests require a disposable database, e.g. a local postgres container
+	if os.Getenv("GDS_TEST_POSTGRES_URL") == "" {
+		t.Skip("set $GDS_TEST_POSTGRES_URL to run postgres store tests")
+	}
+	suite.Run(t, new(postgresTestSuite))
+}
+
+func (s *postgresTestSuite) SetupSuite() {
+	// Discard logging from the application to focus on test logs
+	logger.Discard()
+
+	var err error
+	s.db, err = Open(config.StoreConfig{URL: os.Getenv("GDS_TEST_POSTGRES_URL")})
+	s.Require().NoError(err)
+}
+
+func (s *postgresTestSuite) SetupTest() {
+	// Start every test with empty tables
+	_, err := s.db.db.Exec("TRUNCATE vasps, vasp_index, vasp_documents, certs, certreqs, announcement_months, activity_months, organizations, contacts")
+	s.Require().NoError(err)
+}
+
+func (s *postgresTestSuite) TearDownSuite() {
+	s.NoError(s.db.Close())
+	logger.ResetLogger()
+}
+
+func (s *postgresTestSuite) TestDirectoryStore() {
+	ctx := context.Background()
+	data, err := os.ReadFile("../testdata/vasp.json")
+	s.NoError(err)
+
+	alice := &pb.VASP{}
+	s.NoError(protojson.Unmarshal(data, alice))
+
+	// Attempt to Create the VASP
+	id, err := s.db.CreateVASP(ctx, alice)
+	s.NoError(err)
+	s.NotEmpty(id)
+
+	alicer, err := s.db.RetrieveVASP(ctx, id)
+	s.NoError(err)
+	s.Equal(id, alicer.Id)
+	s.Equal(uint64(1), alicer.Version.Version)
+
+	// Cannot create a VASP with the same common name
+	_, err = s.db.CreateVASP(ctx, &pb.VASP{CommonName: alice.CommonName})
+	s.ErrorIs(err, storeerrors.ErrDuplicateEntity)
+
+	// Update the VASP
+	alicer.Entity.Name.NameIdentifiers[0].LegalPersonName = "AliceLiteCoin, LLC"
+	s.NoError(s.db.UpdateVASP(ctx, alicer))
+
+	alicer, err = s.db.RetrieveVASP(ctx, id)
+	s.NoError(err)
+	s.Equal(uint64(2), alicer.Version.Version)
+
+	// Cannot update a VASP that does not exist
+	s.ErrorIs(s.db.UpdateVASP(ctx, &pb.VASP{Id: uuid.NewString(), CommonName: "trisa.example.com"}), storeerrors.ErrEntityNotFound)
+
+	// Search uses the updated indices
+	vasps, err := s.db.SearchVASPs(ctx, map[string]interface{}{"name": "AliceLiteCoin, LLC"})
+	s.NoError(err)
+	s.Len(vasps, 1)
+	s.Equal(id, vasps[0].Id)
+
+	vasps, err = s.db.SearchVASPs(ctx, map[string]interface{}{"name": "alicelite"})
+	s.NoError(err)
+	s.Len(vasps, 1)
+
+	vasps, err = s.db.SearchVASPs(ctx, map[string]interface{}{"text": "litecoin"})
+	s.NoError(err)
+	s.Len(vasps, 1)
+
+	vasps, err = s.db.SearchVASPs(ctx, map[string]interface{}{"name": "AliceLiteCoin, LLC", "country": "GY"})
+	s.NoError(err)
+	s.Len(vasps, 0)
+
+	// Delete the VASP
+	s.NoError(s.db.DeleteVASP(ctx, id))
+	_, err = s.db.RetrieveVASP(ctx, id)
+	s.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	vasps, err = s.db.SearchVASPs(ctx, map[string]interface{}{"name": "AliceLiteCoin, LLC"})
+	s.NoError(err)
+	s.Len(vasps, 0)
+
+	// Add more VASPs than a single page of the iterator
+	for i := 0; i < iterPageSize+10; i++ {
+		vasp := &pb.VASP{
+			Entity: &ivms101.LegalPerson{
+				Name: &ivms101.LegalPersonName{
+					NameIdentifiers: []*ivms101.LegalPersonNameId{
+						{
+							LegalPersonName:               fmt.Sprintf("Test %d", i+1),
+							LegalPersonNameIdentifierType: ivms101.LegalPersonLegal,
+						},
+					},
+				},
+			},
+			CommonName: fmt.Sprintf("trisa%d.test.net", i+1),
+		}
+		_, err := s.db.CreateVASP(ctx, vasp)
+		s.NoError(err)
+	}
+
+	vasps, err = s.db.ListVASPs(ctx).All()
+	s.NoError(err)
+	s.Len(vasps, iterPageSize+10)
+
+	count, err := s.db.CountVASPs(ctx)
+	s.NoError(err)
+	s.Equal(uint64(iterPageSize+10), count)
+
+	// Seek to a VASP in the middle of the list
+	iter := s.db.ListVASPs(ctx)
+	s.True(iter.SeekId(vasps[50].Id))
+	s.Equal(vasps[50].Id, iter.Id())
+	s.True(iter.Next())
+	s.Equal(vasps[51].Id, iter.Id())
+	s.True(iter.Prev())
+	s.Equal(vasps[50].Id, iter.Id())
+	s.NoError(iter.Error())
+	iter.Release()
+
+	// Reindexing should not change search results
+	s.NoError(s.db.Reindex())
+	vasps, err = s.db.SearchVASPs(ctx, map[string]interface{}{"common_name": "trisa7.test.net"})
+	s.NoError(err)
+	s.Len(vasps, 1)
+}
+
+func (s *postgresTestSuite) TestCertificateStores() {
+	ctx := context.Background()
+
+	cert := &models.Certificate{Request: uuid.NewString(), Vasp: uuid.NewString(), Status: models.CertificateState_ISSUED}
+	id, err := s.db.CreateCert(ctx, cert)
+	s.NoError(err)
+
+	_, err = s.db.CreateCert(ctx, cert)
+	s.ErrorIs(err, storeerrors.ErrIDAlreadySet)
+
+	cert.Status = models.CertificateState_REVOKED
+	s.NoError(s.db.UpdateCert(ctx, cert))
+
+	certr, err := s.db.RetrieveCert(ctx, id)
+	s.NoError(err)
+	s.Equal(models.CertificateState_REVOKED, certr.Status)
+
+	s.NoError(s.db.DeleteCert(ctx, id))
+	_, err = s.db.RetrieveCert(ctx, id)
+	s.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	certreq := &models.CertificateRequest{Vasp: uuid.NewString(), CommonName: "trisa.example.com"}
+	id, err = s.db.CreateCertReq(ctx, certreq)
+	s.NoError(err)
+
+	reqs, err := s.db.ListCertReqs(ctx).All()
+	s.NoError(err)
+	s.Len(reqs, 1)
+	s.Equal(id, reqs[0].Id)
+	s.NotEmpty(reqs[0].Created)
+}
+
+func (s *postgresTestSuite) TestBFFStores() {
+	ctx := context.Background()
+
+	month := &bff.AnnouncementMonth{Date: "2022-01", Announcements: []*bff.Announcement{{Title: "Happy New Year!"}}}
+	s.NoError(s.db.UpdateAnnouncementMonth(ctx, month))
+
+	m, err := s.db.RetrieveAnnouncementMonth(ctx, "2022-01")
+	s.NoError(err)
+	s.Equal("Happy New Year!", m.Announcements[0].Title)
+
+	_, err = s.db.RetrieveAnnouncementMonth(ctx, "2022-02")
+	s.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	// Announcement and activity months with the same date do not conflict
+	s.NoError(s.db.UpdateActivityMonth(ctx, &bff.ActivityMonth{Date: "2022-01"}))
+	count, err := s.db.CountActivityMonth(ctx)
+	s.NoError(err)
+	s.Equal(uint64(1), count)
+
+	org := &bff.Organization{Name: "Alice VASP"}
+	id, err := s.db.CreateOrganization(ctx, org)
+	s.NoError(err)
+
+	orgr, err := s.db.RetrieveOrganization(ctx, uuid.MustParse(id))
+	s.NoError(err)
+	s.Equal("Alice VASP", orgr.Name)
+
+	iter := s.db.ListOrganizations(ctx)
+	s.True(iter.Next())
+	s.Equal(id, iter.ID())
+	iter.Release()
+
+	s.NoError(s.db.DeleteOrganization(ctx, uuid.MustParse(id)))
+	_, err = s.db.RetrieveOrganization(ctx, uuid.MustParse(id))
+	s.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	contact := &models.Contact{Email: "Alice@example.com", Name: "Alice"}
+	_, err = s.db.CreateContact(ctx, contact)
+	s.NoError(err)
+
+	contactr, err := s.db.RetrieveContact(ctx, "alice@example.com")
+	s.NoError(err)
+	s.Equal("Alice", contactr.Name)
+	s.Len(s.db.ListContacts(ctx), 1)
+}
+
+func (s *postgresTestSuite) TestTxn() {
+	ctx := context.Background()
+
+	// Create a VASP and certificate request in a single transaction
+	tx, err := s.db.Begin(ctx)
+	s.Require().NoError(err)
+
+	alice := &pb.VASP{CommonName: "trisa.alice.example.com"}
+	vaspID, err := tx.CreateVASP(alice)
+	s.Require().NoError(err)
+
+	certreq := &models.CertificateRequest{Vasp: vaspID, CommonName: alice.CommonName}
+	certreqID, err := tx.CreateCertReq(certreq)
+	s.Require().NoError(err)
+	s.Require().NoError(tx.Commit(ctx))
+
+	_, err = s.db.RetrieveVASP(ctx, vaspID)
+	s.NoError(err)
+	_, err = s.db.RetrieveCertReq(ctx, certreqID)
+	s.NoError(err)
+
+	// A failed commit should not write any of the records in the transaction
+	bob := &pb.VASP{CommonName: "trisa.bob.example.com"}
+	_, err = s.db.CreateVASP(ctx, bob)
+	s.Require().NoError(err)
+
+	tx, err = s.db.Begin(ctx)
+	s.Require().NoError(err)
+	s.NoError(tx.DeleteCertReq(certreqID))
+	bob.CommonName = alice.CommonName
+	s.NoError(tx.UpdateVASP(bob))
+	s.ErrorIs(tx.Commit(ctx), storeerrors.ErrDuplicateEntity)
+
+	_, err = s.db.RetrieveCertReq(ctx, certreqID)
+	s.NoError(err, "certificate request should not have been deleted")
+}
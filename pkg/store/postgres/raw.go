@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+)
+
+// ScanRaw calls fn with the ID and serialized record of every object in the namespace
+// in key order.
+func (s *Store) ScanRaw(ctx context.Context, namespace string, fn func(id string, data []byte) error) (err error) {
+	var table string
+	if table, err = rawTable(namespace); err != nil {
+		return err
+	}
+
+	iter := newRowIterator(ctx, s.db, table)
+	defer iter.Release()
+
+	for iter.Next() {
+		if err = fn(iter.Key(), iter.Value()); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+// GetRaw returns the serialized record with the specified ID in the namespace.
+func (s *Store) GetRaw(ctx context.Context, namespace, id string) (data []byte, err error) {
+	var table, key string
+	if table, key, err = rawKey(namespace, id); err != nil {
+		return nil, err
+	}
+
+	if err = s.db.QueryRowContext(ctx, fmt.Sprintf("SELECT data FROM %s WHERE id=$1", table), key).Scan(&data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storeerrors.ErrEntityNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+// PutRaw writes the serialized record with the specified ID to the namespace verbatim,
+// creating it or replacing the existing record.
+// NOTE: the indices are not updated when VASP records are written, call Reindex.
+func (s *Store) PutRaw(ctx context.Context, namespace, id string, data []byte) (err error) {
+	var table, key string
+	if table, key, err = rawKey(namespace, id); err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (id, data) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET data=EXCLUDED.data", table), key, data)
+	return err
+}
+
+// Returns the table and the key of the record with the specified ID in the namespace.
+func rawKey(namespace, id string) (table, key string, err error) {
+	if table, err = rawTable(namespace); err != nil {
+		return "", "", err
+	}
+
+	switch namespace {
+	case wire.NamespaceOrganizations:
+		var orgID uuid.UUID
+		if orgID, err = uuid.Parse(id); err != nil {
+			return "", "", err
+		}
+		return table, orgID.String(), nil
+	case wire.NamespaceContacts:
+		return table, models.NormalizeEmail(id), nil
+	default:
+		return table, id, nil
+	}
+}
+
+// Returns the table that stores the records of the namespace.
+func rawTable(namespace string) (string, error) {
+	switch namespace {
+	case wire.NamespaceVASPs:
+		return tableVASPs, nil
+	case wire.NamespaceCerts:
+		return tableCerts, nil
+	case wire.NamespaceCertReqs:
+		return tableCertReqs, nil
+	case wire.NamespaceCertReqArchive:
+		return tableCertReqArchive, nil
+	case wire.NamespaceAnnouncements:
+		return tableAnnouncements, nil
+	case wire.NamespaceActivities:
+		return tableActivities, nil
+	case wire.NamespaceOrganizations:
+		return tableOrganizations, nil
+	case wire.NamespaceContacts:
+		return tableContacts, nil
+	case wire.NamespaceJobs:
+		return tableJobs, nil
+	case wire.NamespaceEmails:
+		return tableEmails, nil
+	default:
+		return "", storeerrors.ErrUnknownNamespace
+	}
+}
@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Table names for each of the namespaces stored in the database. Records are stored as
+// serialized protocol buffers alongside the key used to look them up, VASP records are
+// additionally indexed in the vasp_index and vasp_documents tables for search.
+const (
+	tableVASPs          = "vasps"
+	tableVASPIndex      = "vasp_index"
+	tableVASPDocuments  = "vasp_documents"
+	tableCerts          = "certs"
+	tableCertReqs       = "certreqs"
+	tableAnnouncements  = "announcement_months"
+	tableActivities     = "activity_months"
+	tableOrganizations  = "organizations"
+	tableContacts       = "contacts"
+	tableJobs           = "jobs"
+	tableEmails         = "emails"
+	tableCertReqArchive = "certreqs_archive"
+)
+
+// Migrations are applied in order when the store is opened; every migration that has
+// been applied is recorded by its version in the schema_migrations table. Migrations
+// must never be modified once released, add a new migration to alter the schema.
+var migrations = []string{
+	// Version 1: initial schema
+	`CREATE TABLE IF NOT EXISTS vasps (
+		id TEXT PRIMARY KEY,
+		data BYTEA NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS vasp_index (
+		vasp_id TEXT NOT NULL REFERENCES vasps (id) ON DELETE CASCADE,
+		name TEXT NOT NULL,
+		value TEXT NOT NULL,
+		PRIMARY KEY (name, value, vasp_id)
+	);
+
+	CREATE INDEX IF NOT EXISTS vasp_index_vasp_id_idx ON vasp_index (vasp_id);
+	CREATE INDEX IF NOT EXISTS vasp_index_prefix_idx ON vasp_index (name, value text_pattern_ops);
+
+	CREATE TABLE IF NOT EXISTS vasp_documents (
+		vasp_id TEXT NOT NULL REFERENCES vasps (id) ON DELETE CASCADE,
+		query TEXT NOT NULL,
+		document TSVECTOR NOT NULL,
+		PRIMARY KEY (query, vasp_id)
+	);
+
+	CREATE INDEX IF NOT EXISTS vasp_documents_document_idx ON vasp_documents USING GIN (document);
+
+	CREATE TABLE IF NOT EXISTS certs (
+		id TEXT PRIMARY KEY,
+		data BYTEA NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS certreqs (
+		id TEXT PRIMARY KEY,
+		data BYTEA NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS announcement_months (
+		id TEXT PRIMARY KEY,
+		data BYTEA NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS activity_months (
+		id TEXT PRIMARY KEY,
+		data BYTEA NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS organizations (
+		id TEXT PRIMARY KEY,
+		data BYTEA NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS contacts (
+		id TEXT PRIMARY KEY,
+		data BYTEA NOT NULL
+	);`,
+
+	// Version 2: asynchronous jobs
+	`CREATE TABLE IF NOT EXISTS jobs (
+		id TEXT PRIMARY KEY,
+		data BYTEA NOT NULL
+	);`,
+
+	// Version 3: certificate request archive
+	`CREATE TABLE IF NOT EXISTS certreqs_archive (
+		id TEXT PRIMARY KEY,
+		data BYTEA NOT NULL
+	);`,
+
+	// Version 4: email outbox
+	`CREATE TABLE IF NOT EXISTS emails (
+		id TEXT PRIMARY KEY,
+		data BYTEA NOT NULL
+	);`,
+}
+
+// migrate applies any migrations that have not yet been applied to the database. The
+// migrations are applied in a single transaction under an advisory lock so that
+// multiple services opening the database at the same time do not conflict.
+func migrate(ctx context.Context, db *sql.DB) (err error) {
+	var tx *sql.Tx
+	if tx, err = db.BeginTx(ctx, nil); err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", lockMigrations); err != nil {
+		return fmt.Errorf("could not acquire migrations lock: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY, applied TIMESTAMPTZ NOT NULL DEFAULT now())"); err != nil {
+		return fmt.Errorf("could not create migrations table: %w", err)
+	}
+
+	var current int
+	if err = tx.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&current); err != nil {
+		return fmt.Errorf("could not determine schema version: %w", err)
+	}
+
+	for i := current; i < len(migrations); i++ {
+		if _, err = tx.ExecContext(ctx, migrations[i]); err != nil {
+			return fmt.Errorf("could not apply migration %d: %w", i+1, err)
+		}
+
+		if _, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", i+1); err != nil {
+			return fmt.Errorf("could not record migration %d: %w", i+1, err)
+		}
+		log.Info().Int("version", i+1).Msg("applied postgres schema migration")
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,239 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/store/index"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+// The relevance score of a record that is matched by an exact or prefix match index,
+// which ranks these matches above full text matches (ts_rank is at most 1).
+const matchScore = 10.0
+
+// Minimum length of a name query term before prefix matching is used; this matches the
+// behavior of the in-memory indices used by the embedded stores.
+const searchPrefixMinLength = 3
+
+// The text search configuration used for full text documents; the simple configuration
+// does not stem or remove stop words, which are removed by the index tokenizer instead.
+const tsconfig = "simple"
+
+// indexVASP writes the values extracted by the registered index definitions to the
+// vasp_index table and the full text documents to the vasp_documents table, replacing
+// any previously indexed values for the record. Must be called inside a transaction.
+func indexVASP(ctx context.Context, tx *sql.Tx, v *pb.VASP) (err error) {
+	if err = unindexVASP(ctx, tx, v.Id); err != nil {
+		return err
+	}
+
+	documents := make(map[string][]*document)
+	for _, def := range index.Definitions() {
+		if def.Mode == index.FullTextMatch {
+			documents[def.Query] = append(documents[def.Query], newDocument(def, v))
+			continue
+		}
+
+		for _, value := range def.Extract(v) {
+			if def.Normalize != nil {
+				value = def.Normalize(value)
+			}
+
+			if value == "" {
+				continue
+			}
+
+			if _, err = tx.ExecContext(ctx, "INSERT INTO vasp_index (vasp_id, name, value) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING", v.Id, def.Name, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	for query, parts := range documents {
+		// Each part is a weighted tsvector expression with its text as a parameter
+		args := []interface{}{v.Id, query}
+		exprs := make([]string, 0, len(parts))
+		for _, part := range parts {
+			args = append(args, part.text)
+			exprs = append(exprs, fmt.Sprintf("setweight(to_tsvector('%s', $%d), '%c')", tsconfig, len(args), part.label))
+		}
+
+		stmt := fmt.Sprintf("INSERT INTO vasp_documents (vasp_id, query, document) VALUES ($1, $2, %s)", strings.Join(exprs, " || "))
+		if _, err = tx.ExecContext(ctx, stmt, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unindexVASP removes all indexed values and documents for the record.
+func unindexVASP(ctx context.Context, tx *sql.Tx, id string) (err error) {
+	if _, err = tx.ExecContext(ctx, "DELETE FROM vasp_index WHERE vasp_id=$1", id); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM vasp_documents WHERE vasp_id=$1", id); err != nil {
+		return err
+	}
+	return nil
+}
+
+// document is the tokenized text extracted by a full text definition along with the
+// tsvector weight label that corresponds to the definition's weight.
+type document struct {
+	label byte
+	text  string
+}
+
+func newDocument(def *index.Definition, v *pb.VASP) *document {
+	tokens := make([]string, 0)
+	for _, value := range def.Extract(v) {
+		tokens = append(tokens, index.Tokenize(value)...)
+	}
+	return &document{label: weightLabel(def.Weight), text: strings.Join(tokens, " ")}
+}
+
+// weightLabel maps full text index weights onto the four tsvector weight labels, where
+// A is the most relevant and D is the least relevant.
+func weightLabel(weight float64) byte {
+	switch {
+	case weight >= 3:
+		return 'A'
+	case weight >= 2:
+		return 'B'
+	case weight >= 1:
+		return 'C'
+	default:
+		return 'D'
+	}
+}
+
+// tsquery converts a full text search term into a tsquery expression where every token
+// in the term must match, and tokens that are long enough may match as a prefix. Tokens
+// only contain letters and digits so they do not need to be escaped.
+func tsquery(term string) string {
+	tokens := index.Tokenize(term)
+	for i, token := range tokens {
+		if len([]rune(token)) >= searchPrefixMinLength {
+			tokens[i] = token + ":*"
+		}
+	}
+	return strings.Join(tokens, " & ")
+}
+
+// escapeLike escapes the wildcard characters in a LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// searchQuery builds the SQL query and arguments that select the data of the VASP
+// records matching the query, using the same semantics as the in-memory indices: the
+// records matched by any of the exact, prefix, or full text indices are included, then
+// reduced to the records that match every term of the filter indices. The records are
+// ordered by relevance, then by ID so that the order of the results is deterministic.
+// If the query does not have any match terms, an empty string is returned.
+func searchQuery(query map[string]interface{}) (_ string, args []interface{}) {
+	param := func(arg interface{}) string {
+		args = append(args, arg)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	matches := make([]string, 0)
+	filters := make([]string, 0)
+	fulltext := make(map[string]struct{})
+
+	for _, def := range index.Definitions() {
+		var norm index.Normalizer
+		if def.Mode != index.FullTextMatch {
+			norm = def.Normalize
+		}
+
+		terms, ok := index.ParseQuery(def.Query, query, norm)
+		if !ok {
+			continue
+		}
+
+		switch def.Mode {
+		case index.ExactMatch:
+			matches = append(matches, fmt.Sprintf(
+				"SELECT DISTINCT vasp_id, %.1f::float8 AS score FROM vasp_index WHERE name=%s AND value=ANY(%s)",
+				matchScore, param(def.Name), param(terms),
+			))
+
+		case index.PrefixMatch:
+			// Terms match exactly, or by prefix if they're long enough and there is no
+			// exact match for the term in the index.
+			name := param(def.Name)
+			conds := make([]string, 0, len(terms))
+			for _, term := range terms {
+				value := param(term)
+				if len(term) < searchPrefixMinLength {
+					conds = append(conds, fmt.Sprintf("i.value=%s", value))
+					continue
+				}
+
+				conds = append(conds, fmt.Sprintf(
+					"(i.value=%s OR (i.value LIKE %s AND NOT EXISTS (SELECT 1 FROM vasp_index e WHERE e.name=%s AND e.value=%s)))",
+					value, param(escapeLike(term)+"%"), name, value,
+				))
+			}
+
+			matches = append(matches, fmt.Sprintf(
+				"SELECT DISTINCT i.vasp_id, %.1f::float8 AS score FROM vasp_index i WHERE i.name=%s AND (%s)",
+				matchScore, name, strings.Join(conds, " OR "),
+			))
+
+		case index.Filter:
+			for _, term := range terms {
+				filters = append(filters, fmt.Sprintf(
+					"EXISTS (SELECT 1 FROM vasp_index f WHERE f.vasp_id=m.vasp_id AND f.name=%s AND f.value=%s)",
+					param(def.Name), param(term),
+				))
+			}
+
+		case index.FullTextMatch:
+			// Full text definitions with the same query share a single document
+			if _, ok := fulltext[def.Query]; ok {
+				continue
+			}
+			fulltext[def.Query] = struct{}{}
+
+			// Each term is ranked separately and the scores are added together
+			for _, term := range terms {
+				tsq := tsquery(term)
+				if tsq == "" {
+					continue
+				}
+
+				q := fmt.Sprintf("to_tsquery('%s', %s)", tsconfig, param(tsq))
+				matches = append(matches, fmt.Sprintf(
+					"SELECT vasp_id, ts_rank(document, %s)::float8 AS score FROM vasp_documents WHERE query=%s AND document @@ %s",
+					q, param(def.Query), q,
+				))
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return "", nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("SELECT v.data FROM (SELECT vasp_id, SUM(score) AS score FROM (")
+	sb.WriteString(strings.Join(matches, " UNION ALL "))
+	sb.WriteString(") AS matches GROUP BY vasp_id) AS m JOIN vasps v ON v.id=m.vasp_id")
+
+	// NOTE: if a filter term is not in the index, no records will be returned
+	if len(filters) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(filters, " AND "))
+	}
+	sb.WriteString(" ORDER BY m.score DESC, v.id ASC")
+
+	log.Debug().Int("matches", len(matches)).Int("filters", len(filters)).Msg("postgres search query")
+	return sb.String(), args
+}
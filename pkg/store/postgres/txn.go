@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
+	"github.com/trisacrypto/directory/pkg/store/txn"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+	"google.golang.org/protobuf/proto"
+)
+
+// Begin a transaction that is committed to the database in a single SQL transaction.
+func (s *Store) Begin(ctx context.Context) (txn.Txn, error) {
+	return txn.New(s.commit), nil
+}
+
+// commit the operations of a transaction in a single SQL transaction.
+func (s *Store) commit(ctx context.Context, ops []*txn.Operation) (err error) {
+	var tx *sql.Tx
+	if tx, err = s.db.BeginTx(ctx, nil); err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	for _, op := range ops {
+		var table string
+		if table, err = txnTable(op.Namespace); err != nil {
+			return err
+		}
+
+		if op.Delete() {
+			if _, err = tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id=$1", table), op.Key); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var data []byte
+		if data, err = proto.Marshal(op.Record); err != nil {
+			return err
+		}
+
+		v, ok := op.VASP()
+		if !ok {
+			if _, err = tx.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (id, data) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET data=EXCLUDED.data", table), op.Key, data); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Critical section (optimizing for safety rather than speed)
+		if !locked {
+			if _, err = tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", lockVASPs); err != nil {
+				return err
+			}
+			locked = true
+		}
+
+		if err = checkUniqueVASP(ctx, tx, v); err != nil {
+			return err
+		}
+
+		if op.Create {
+			if _, err = tx.ExecContext(ctx, "INSERT INTO vasps (id, data) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET data=EXCLUDED.data", v.Id, data); err != nil {
+				return err
+			}
+		} else {
+			var result sql.Result
+			if result, err = tx.ExecContext(ctx, "UPDATE vasps SET data=$2 WHERE id=$1", v.Id, data); err != nil {
+				return err
+			}
+
+			var nrows int64
+			if nrows, err = result.RowsAffected(); err != nil {
+				return err
+			}
+
+			if nrows == 0 {
+				return storeerrors.ErrEntityNotFound
+			}
+		}
+
+		if err = indexVASP(ctx, tx, v); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// txnTable returns the table that stores the records of the namespace.
+func txnTable(namespace string) (string, error) {
+	switch namespace {
+	case wire.NamespaceVASPs:
+		return tableVASPs, nil
+	case wire.NamespaceCerts:
+		return tableCerts, nil
+	case wire.NamespaceCertReqs:
+		return tableCertReqs, nil
+	case wire.NamespaceContacts:
+		return tableContacts, nil
+	case wire.NamespaceJobs:
+		return tableJobs, nil
+	case wire.NamespaceEmails:
+		return tableEmails, nil
+	default:
+		return "", fmt.Errorf("unhandled transaction namespace %q", namespace)
+	}
+}
@@ -23,6 +23,14 @@ func (s *Store) CountCertReqs(ctx context.Context) (_ uint64, err error) {
 	return reply.Objects, nil
 }
 
+func (s *Store) CountArchivedCertReqs(ctx context.Context) (_ uint64, err error) {
+	var reply *pb.CountReply
+	if reply, err = s.client.Count(ctx, &pb.CountRequest{Namespace: wire.NamespaceCertReqArchive}); err != nil {
+		return 0, err
+	}
+	return reply.Objects, nil
+}
+
 func (s *Store) CountCerts(ctx context.Context) (_ uint64, err error) {
 	var reply *pb.CountReply
 	if reply, err = s.client.Count(ctx, &pb.CountRequest{Namespace: wire.NamespaceCerts}); err != nil {
@@ -62,3 +70,19 @@ func (s *Store) CountContacts(ctx context.Context) (_ uint64, err error) {
 	}
 	return reply.Objects, nil
 }
+
+func (s *Store) CountJobs(ctx context.Context) (_ uint64, err error) {
+	var reply *pb.CountReply
+	if reply, err = s.client.Count(ctx, &pb.CountRequest{Namespace: wire.NamespaceJobs}); err != nil {
+		return 0, err
+	}
+	return reply.Objects, nil
+}
+
+func (s *Store) CountEmails(ctx context.Context) (_ uint64, err error) {
+	var reply *pb.CountReply
+	if reply, err = s.client.Count(ctx, &pb.CountRequest{Namespace: wire.NamespaceEmails}); err != nil {
+		return 0, err
+	}
+	return reply.Objects, nil
+}
@@ -3,6 +3,7 @@ package trtl_test
 import (
 	"context"
 
+	"github.com/trisacrypto/directory/pkg/store/index"
 	store "github.com/trisacrypto/directory/pkg/store/trtl"
 )
 
@@ -31,6 +32,10 @@ func (s *trtlStoreTestSuite) TestIndexSync() {
 	// Sync the indices to disk
 	// NOTE: this should also test any conflicts with reserved namespaces in trtl
 	require.NoError(db.Sync("all"), "could not sync indices to disk")
+	for _, def := range index.Definitions() {
+		require.NoError(db.Sync(def.Name), "could not sync %s index to disk", def.Name)
+	}
+	require.Error(db.Sync("foo"), "expected error syncing an unknown index")
 
 	// TODO: check that we can load the indices from disk
 
@@ -1,7 +1,6 @@
 package trtl
 
 import (
-	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
 	"google.golang.org/grpc"
 )
 
@@ -14,7 +13,7 @@ func NewMock(conn *grpc.ClientConn) (store *Store, err error) {
 	store = &Store{
 		conn: conn,
 	}
-	store.client = pb.NewTrtlClient(store.conn)
+	store.client = newSyncClient(store.conn)
 
 	if err = store.sync(); err != nil {
 		return nil, err
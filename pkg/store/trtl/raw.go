@@ -0,0 +1,119 @@
+package trtl
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
+	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
+	"github.com/trisacrypto/directory/pkg/utils"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ScanRaw calls fn with the ID and serialized record of every object in the namespace
+// in key order.
+func (s *Store) ScanRaw(ctx context.Context, namespace string, fn func(id string, data []byte) error) (err error) {
+	if !rawNamespace(namespace) {
+		return storeerrors.ErrUnknownNamespace
+	}
+
+	iter := NewTrtlStreamingIterator(s.client, namespace)
+	defer iter.Release()
+
+	for iter.Next() {
+		id := string(iter.Key())
+		if namespace == wire.NamespaceOrganizations {
+			var orgID uuid.UUID
+			if orgID, err = uuid.FromBytes(iter.Key()); err != nil {
+				return err
+			}
+			id = orgID.String()
+		}
+
+		if err = fn(id, iter.Value()); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+// GetRaw returns the serialized record with the specified ID in the namespace.
+func (s *Store) GetRaw(ctx context.Context, namespace, id string) (_ []byte, err error) {
+	var key []byte
+	if key, err = rawKey(namespace, id); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := utils.WithDeadline(ctx)
+	defer cancel()
+
+	var reply *pb.GetReply
+	if reply, err = s.client.Get(ctx, &pb.GetRequest{Key: key, Namespace: namespace}); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, storeerrors.ErrEntityNotFound
+		}
+		return nil, err
+	}
+	return reply.Value, nil
+}
+
+// PutRaw writes the serialized record with the specified ID to the namespace verbatim,
+// creating it or replacing the existing record.
+// NOTE: the indices are not updated when VASP records are written, call Reindex.
+func (s *Store) PutRaw(ctx context.Context, namespace, id string, data []byte) (err error) {
+	var key []byte
+	if key, err = rawKey(namespace, id); err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.WithDeadline(ctx)
+	defer cancel()
+
+	request := &pb.PutRequest{
+		Key:       key,
+		Value:     data,
+		Namespace: namespace,
+	}
+	if reply, err := s.client.Put(ctx, request); err != nil || !reply.Success {
+		if err == nil {
+			err = storeerrors.ErrProtocol
+		}
+		return err
+	}
+	return nil
+}
+
+// Returns the key of the record with the specified ID in the namespace.
+func rawKey(namespace, id string) (_ []byte, err error) {
+	if !rawNamespace(namespace) {
+		return nil, storeerrors.ErrUnknownNamespace
+	}
+
+	switch namespace {
+	case wire.NamespaceOrganizations:
+		var orgID uuid.UUID
+		if orgID, err = uuid.Parse(id); err != nil {
+			return nil, err
+		}
+		return orgID[:], nil
+	case wire.NamespaceContacts:
+		return []byte(models.NormalizeEmail(id)), nil
+	default:
+		return []byte(id), nil
+	}
+}
+
+// Returns true if the records of the namespace can be read and written verbatim.
+func rawNamespace(namespace string) bool {
+	switch namespace {
+	case wire.NamespaceVASPs, wire.NamespaceCerts, wire.NamespaceCertReqs, wire.NamespaceCertReqArchive,
+		wire.NamespaceAnnouncements, wire.NamespaceActivities, wire.NamespaceOrganizations,
+		wire.NamespaceContacts, wire.NamespaceJobs, wire.NamespaceEmails:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,269 @@
+package trtl
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrSyncClosed is returned when an operation is attempted on a closed sync client.
+var ErrSyncClosed = errors.New("trtl sync stream is closed")
+
+// syncClient implements the pb.TrtlClient interface but routes Get, Put, Delete, and
+// Iter requests over a single long-lived Sync stream rather than making a unary RPC
+// for each access, reducing the latency of the many sequential accesses the store
+// makes. All other RPCs (e.g. Cursor, Batch, Count) are passed through to the unary
+// client. Requests from concurrent callers are multiplexed onto the stream and
+// correlated with their replies by id, so the replies may arrive in any order.
+//
+// If the stream is broken all pending operations fail with the stream error and the
+// stream is reopened on the next operation. If the trtl server does not implement the
+// Sync RPC the client falls back to unary requests for the lifetime of the client.
+type syncClient struct {
+	pb.TrtlClient
+	mu       sync.Mutex
+	sendmu   sync.Mutex
+	stream   pb.Trtl_SyncClient
+	cancel   context.CancelFunc
+	pending  map[int64]chan *syncResult
+	seq      int64
+	unary    bool
+	closed   bool
+	streamWG sync.WaitGroup
+}
+
+// syncResult is delivered to a waiting operation when its reply arrives or the stream
+// that the operation was sent on fails.
+type syncResult struct {
+	reply *pb.SyncReply
+	err   error
+}
+
+// Compile-time check that the sync client can replace the unary client.
+var _ pb.TrtlClient = &syncClient{}
+
+func newSyncClient(conn grpc.ClientConnInterface) *syncClient {
+	return &syncClient{
+		TrtlClient: pb.NewTrtlClient(conn),
+		pending:    make(map[int64]chan *syncResult),
+	}
+}
+
+// Get a value for a key over the sync stream.
+func (c *syncClient) Get(ctx context.Context, in *pb.GetRequest, opts ...grpc.CallOption) (_ *pb.GetReply, err error) {
+	var reply *pb.SyncReply
+	if reply, err = c.do(ctx, &pb.SyncRequest{Request: &pb.SyncRequest_Get{Get: in}}); err != nil {
+		if err == errSyncUnimplemented {
+			return c.TrtlClient.Get(ctx, in, opts...)
+		}
+		return nil, err
+	}
+	return reply.GetGet(), nil
+}
+
+// Put a value for a key over the sync stream.
+func (c *syncClient) Put(ctx context.Context, in *pb.PutRequest, opts ...grpc.CallOption) (_ *pb.PutReply, err error) {
+	var reply *pb.SyncReply
+	if reply, err = c.do(ctx, &pb.SyncRequest{Request: &pb.SyncRequest_Put{Put: in}}); err != nil {
+		if err == errSyncUnimplemented {
+			return c.TrtlClient.Put(ctx, in, opts...)
+		}
+		return nil, err
+	}
+	return reply.GetPut(), nil
+}
+
+// Delete a key over the sync stream.
+func (c *syncClient) Delete(ctx context.Context, in *pb.DeleteRequest, opts ...grpc.CallOption) (_ *pb.DeleteReply, err error) {
+	var reply *pb.SyncReply
+	if reply, err = c.do(ctx, &pb.SyncRequest{Request: &pb.SyncRequest_Delete{Delete: in}}); err != nil {
+		if err == errSyncUnimplemented {
+			return c.TrtlClient.Delete(ctx, in, opts...)
+		}
+		return nil, err
+	}
+	return reply.GetDelete(), nil
+}
+
+// Iter fetches a page of key/value pairs over the sync stream.
+func (c *syncClient) Iter(ctx context.Context, in *pb.IterRequest, opts ...grpc.CallOption) (_ *pb.IterReply, err error) {
+	var reply *pb.SyncReply
+	if reply, err = c.do(ctx, &pb.SyncRequest{Request: &pb.SyncRequest_Iter{Iter: in}}); err != nil {
+		if err == errSyncUnimplemented {
+			return c.TrtlClient.Iter(ctx, in, opts...)
+		}
+		return nil, err
+	}
+	return reply.GetIter(), nil
+}
+
+// Close the sync stream; any pending operations will fail with ErrSyncClosed.
+func (c *syncClient) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	stream, cancel := c.stream, c.cancel
+	c.mu.Unlock()
+
+	if stream != nil {
+		c.sendmu.Lock()
+		stream.CloseSend()
+		c.sendmu.Unlock()
+		cancel()
+	}
+
+	c.streamWG.Wait()
+	return nil
+}
+
+// errSyncUnimplemented signals the caller to fall back to the unary RPC.
+var errSyncUnimplemented = errors.New("trtl sync rpc is not implemented by the server")
+
+// do sends the request on the sync stream and waits for the correlated reply. The
+// context only bounds how long the caller waits for the reply, it does not affect the
+// stream that is shared with other callers. If the reply is unsuccessful, the error is
+// converted back into a gRPC status error so that callers can handle it exactly like
+// the error from the equivalent unary RPC.
+func (c *syncClient) do(ctx context.Context, req *pb.SyncRequest) (_ *pb.SyncReply, err error) {
+	var (
+		stream pb.Trtl_SyncClient
+		result chan *syncResult
+	)
+
+	if stream, req.Id, result, err = c.register(); err != nil {
+		return nil, err
+	}
+
+	c.sendmu.Lock()
+	err = stream.Send(req)
+	c.sendmu.Unlock()
+
+	if err != nil {
+		// The actual error is returned by Recv, which will also fail pending requests.
+		if err == io.EOF {
+			c.unregister(req.Id)
+			return nil, status.Error(codes.Unavailable, "trtl sync stream closed by server")
+		}
+		c.unregister(req.Id)
+		return nil, err
+	}
+
+	select {
+	case rep := <-result:
+		if rep.err != nil {
+			return nil, rep.err
+		}
+
+		if !rep.reply.Success {
+			code := codes.Code(rep.reply.Code)
+			if code == codes.OK {
+				code = codes.Unknown
+			}
+			return nil, status.Error(code, rep.reply.Error)
+		}
+		return rep.reply, nil
+	case <-ctx.Done():
+		c.unregister(req.Id)
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// register a pending operation, opening the sync stream if it is not already open.
+func (c *syncClient) register() (stream pb.Trtl_SyncClient, id int64, result chan *syncResult, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil, 0, nil, ErrSyncClosed
+	}
+
+	if c.unary {
+		return nil, 0, nil, errSyncUnimplemented
+	}
+
+	if c.stream == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		if c.stream, err = c.TrtlClient.Sync(ctx); err != nil {
+			cancel()
+			return nil, 0, nil, err
+		}
+		c.cancel = cancel
+
+		c.streamWG.Add(1)
+		go c.recv(c.stream)
+	}
+
+	c.seq++
+	result = make(chan *syncResult, 1)
+	c.pending[c.seq] = result
+	return c.stream, c.seq, result, nil
+}
+
+// unregister a pending operation, e.g. if the caller is no longer waiting for it.
+func (c *syncClient) unregister(id int64) {
+	c.mu.Lock()
+	delete(c.pending, id)
+	c.mu.Unlock()
+}
+
+// recv delivers replies from the stream to the pending operations until the stream
+// fails or is closed, at which point all pending operations are failed.
+func (c *syncClient) recv(stream pb.Trtl_SyncClient) {
+	defer c.streamWG.Done()
+	for {
+		reply, err := stream.Recv()
+		if err != nil {
+			c.fail(stream, err)
+			return
+		}
+
+		c.mu.Lock()
+		result, ok := c.pending[reply.Id]
+		delete(c.pending, reply.Id)
+		c.mu.Unlock()
+
+		if ok {
+			result <- &syncResult{reply: reply}
+		}
+	}
+}
+
+// fail all pending operations on the stream and reset the stream so that it is
+// reopened by the next operation.
+func (c *syncClient) fail(stream pb.Trtl_SyncClient, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Ignore errors from an old stream that has already been replaced.
+	if c.stream != stream {
+		return
+	}
+
+	switch {
+	case c.closed:
+		err = ErrSyncClosed
+	case err == io.EOF:
+		err = status.Error(codes.Unavailable, "trtl sync stream closed by server")
+	case status.Code(err) == codes.Unimplemented:
+		log.Warn().Msg("trtl server does not implement sync, falling back to unary requests")
+		c.unary = true
+		err = errSyncUnimplemented
+	default:
+		log.Debug().Err(err).Msg("trtl sync stream failed")
+	}
+
+	for id, result := range c.pending {
+		result <- &syncResult{err: err}
+		delete(c.pending, id)
+	}
+
+	c.cancel()
+	c.stream = nil
+	c.cancel = nil
+}
@@ -2,6 +2,7 @@ package trtl
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/trisacrypto/directory/pkg/store/iterator"
 	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
 	"github.com/trisacrypto/directory/pkg/utils"
+	"github.com/trisacrypto/directory/pkg/utils/sentry"
 	"github.com/trisacrypto/directory/pkg/utils/wire"
 	gds "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 	"google.golang.org/grpc"
@@ -29,22 +31,28 @@ func Open(conf config.StoreConfig) (store *Store, err error) {
 	if store.conn, err = Connect(conf); err != nil {
 		return nil, err
 	}
-	store.client = pb.NewTrtlClient(store.conn)
+	store.client = newSyncClient(store.conn)
 
 	if err = store.sync(); err != nil {
 		return nil, err
 	}
 
-	// Perform a reindex if the local indices are null or empty. In the case where the
-	// store has no data, this won't be harmful - but in the case where the stored index
-	// has been corrupted, this should repair it.
-	if store.names.Empty() || store.websites.Empty() || store.countries.Empty() || store.categories.Empty() {
-		log.Info().Msg("reindexing to recover from empty indices")
+	// Perform a reindex if the local indices are empty or an index was not stored. In
+	// the case where the store has no data, this won't be harmful - but in the case
+	// where the stored index has been lost or a new index was registered, this should
+	// repair it.
+	if store.indices.NeedsReindex() {
+		log.Info().Msg("reindexing to recover from empty or missing indices")
 		if err = store.Reindex(); err != nil {
 			return nil, err
 		}
 	}
 
+	// Keep the indices up to date with changes made by other replicas.
+	var ctx context.Context
+	ctx, store.stopWatch = context.WithCancel(context.Background())
+	go store.Watch(ctx)
+
 	// Run background go routine to periodically checkpoint index to disk.
 	// NOTE: the leveldb store does this in the backup go routine.
 	// TODO: configure (enable/disable) this functionality and shutdown
@@ -64,12 +72,10 @@ func Open(conf config.StoreConfig) (store *Store, err error) {
 // Store implements the store.Store interface for the Trtl replicated database.
 type Store struct {
 	sync.RWMutex
-	conn       *grpc.ClientConn
-	client     pb.TrtlClient
-	names      index.SingleIndex // case insensitive name index
-	websites   index.SingleIndex // website/url index
-	countries  index.MultiIndex  // lookup vasps in a specific country
-	categories index.MultiIndex  // lookup vasps based on specified categories
+	conn      *grpc.ClientConn
+	client    *syncClient        // routes unary accesses over a long-lived Sync stream
+	indices   *index.Indices     // registered secondary indices for vasp lookups
+	stopWatch context.CancelFunc // stops watching for changes to the indices
 }
 
 //===========================================================================
@@ -79,6 +85,10 @@ type Store struct {
 // Close the connection to the database.
 func (s *Store) Close() error {
 	defer s.conn.Close()
+	defer s.client.Close()
+	if s.stopWatch != nil {
+		s.stopWatch()
+	}
 	if err := s.sync(); err != nil {
 		return err
 	}
@@ -99,58 +109,19 @@ func (s *Store) ListVASPs(ctx context.Context) iterator.DirectoryIterator {
 // SearchVASPs is intended to specifically identify a VASP (rather than as a browsing
 // functionality). As such it is primarily a filtering search rather than an inclusive
 // search. The query can contain a one or more name or website terms. Names are prefixed
-// matched to the index and websites are hostname matched. The query can contain one or
+// matched to the index and websites are hostname matched. LEIs, common names, DNS names,
+// and certificate serial numbers are exactly matched. The query can contain one or
 // more country and category filters as well, which reduce the number of search results.
 func (s *Store) SearchVASPs(ctx context.Context, query map[string]interface{}) (vasps []*gds.VASP, err error) {
-	// A set of records that match the query and need to be fetched
-	records := make(map[string]struct{})
-
+	// The records that match the query and need to be fetched
 	s.RLock()
-	// Search the name index
-	for _, result := range s.names.Search(query) {
-		records[result] = struct{}{}
-	}
-
-	// Lookup by website
-	for _, result := range s.websites.Search(query) {
-		records[result] = struct{}{}
-	}
-
-	// Filter by country
-	// NOTE: if country is not in the index, no records will be returned
-	countries, ok := index.ParseQuery("country", query, index.NormalizeCountry)
-	if ok {
-		for _, country := range countries {
-			for record := range records {
-				if !s.countries.Contains(country, record) {
-					// Remove the found VASP since it is not in the country index
-					// NOTE: safe to remove during map iteration
-					delete(records, record)
-				}
-			}
-		}
-	}
-
-	// Filter by category
-	// NOTE: if category is not in the index, no records will be returned
-	categories, ok := index.ParseQuery("category", query, index.Normalize)
-	if ok {
-		for _, category := range categories {
-			for record := range records {
-				if !s.categories.Contains(category, record) {
-					// Remove the found VASP since it is not in the category index
-					// NOTE: safe to remove during map iteration
-					delete(records, record)
-				}
-			}
-		}
-	}
+	records := s.indices.Search(query)
 	s.RUnlock()
 
 	// Perform the lookup of records if there are any
 	if len(records) > 0 {
 		vasps = make([]*gds.VASP, 0, len(records))
-		for id := range records {
+		for _, id := range records {
 			var vasp *gds.VASP
 			if vasp, err = s.RetrieveVASP(ctx, id); err != nil {
 				if err == storeerrors.ErrEntityNotFound {
@@ -197,7 +168,7 @@ func (s *Store) CreateVASP(ctx context.Context, v *gds.VASP) (id string, err err
 
 	// Check the uniqueness constraints
 	// NOTE: website removed as uniqueness constraint in SC-4483
-	if _, ok := s.names.Find(v.CommonName); ok {
+	if _, ok := s.indices.Find(index.Names, v.CommonName); ok {
 		return "", storeerrors.ErrDuplicateEntity
 	}
 
@@ -292,7 +263,7 @@ func (s *Store) UpdateVASP(ctx context.Context, v *gds.VASP) (err error) {
 
 	// Check the uniqueness constraints
 	// NOTE: website removed as uniqueness constraint in SC-4483
-	if id, ok := s.names.Find(v.CommonName); ok && id != v.Id {
+	if id, ok := s.indices.Find(index.Names, v.CommonName); ok && id != v.Id {
 		return storeerrors.ErrDuplicateEntity
 	}
 
@@ -535,8 +506,17 @@ func (s *Store) CreateCertReq(ctx context.Context, r *models.CertificateRequest)
 	return r.Id, nil
 }
 
-// RetrieveCertReq returns a certificate request by certificate request ID.
+// RetrieveCertReq returns a certificate request by certificate request ID. If the
+// request is not active it is retrieved from the archive.
 func (s *Store) RetrieveCertReq(ctx context.Context, id string) (r *models.CertificateRequest, err error) {
+	if r, err = s.retrieveCertReq(ctx, wire.NamespaceCertReqs, id); errors.Is(err, storeerrors.ErrEntityNotFound) {
+		return s.retrieveCertReq(ctx, wire.NamespaceCertReqArchive, id)
+	}
+	return r, err
+}
+
+// retrieves a certificate request by ID from the active or archived namespace.
+func (s *Store) retrieveCertReq(ctx context.Context, namespace, id string) (r *models.CertificateRequest, err error) {
 	if id == "" {
 		return nil, storeerrors.ErrEntityNotFound
 	}
@@ -545,7 +525,7 @@ func (s *Store) RetrieveCertReq(ctx context.Context, id string) (r *models.Certi
 	defer cancel()
 	request := &pb.GetRequest{
 		Key:       []byte(id),
-		Namespace: wire.NamespaceCertReqs,
+		Namespace: namespace,
 	}
 	var reply *pb.GetReply
 	if reply, err = s.client.Get(ctx, request); err != nil {
@@ -615,6 +595,48 @@ func (s *Store) DeleteCertReq(ctx context.Context, id string) (err error) {
 	return nil
 }
 
+// ArchiveCertReq moves a certificate request from the active requests to the archive.
+// The put to the archive and the delete of the active request are sent in a single trtl
+// Batch, which undoes the put if the delete fails; since the batch is not crash safe, a
+// request may still be left in both namespaces and the archive can be retried. The
+// request is archived without updating its Modified timestamp, which is the timestamp
+// that CertificateRequestArchivable compares to the retention cutoff.
+func (s *Store) ArchiveCertReq(ctx context.Context, id string) (err error) {
+	var r *models.CertificateRequest
+	if r, err = s.retrieveCertReq(ctx, wire.NamespaceCertReqs, id); err != nil {
+		return err
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(r); err != nil {
+		return err
+	}
+
+	// Write the archived request and delete the active request in a single batch
+	return s.batch(ctx, []*pb.BatchRequest{
+		{
+			Id:      0,
+			Request: &pb.BatchRequest_Put{Put: &pb.PutRequest{Key: []byte(id), Value: data, Namespace: wire.NamespaceCertReqArchive}},
+		},
+		{
+			Id:      1,
+			Request: &pb.BatchRequest_Delete{Delete: &pb.DeleteRequest{Key: []byte(id), Namespace: wire.NamespaceCertReqs}},
+		},
+	})
+}
+
+// ListArchivedCertReqs returns all certificate requests that have been archived.
+func (s *Store) ListArchivedCertReqs(ctx context.Context) iterator.CertificateRequestIterator {
+	return &certReqIterator{
+		NewTrtlStreamingIterator(s.client, wire.NamespaceCertReqArchive),
+	}
+}
+
+// RetrieveArchivedCertReq returns an archived certificate request by ID.
+func (s *Store) RetrieveArchivedCertReq(ctx context.Context, id string) (*models.CertificateRequest, error) {
+	return s.retrieveCertReq(ctx, wire.NamespaceCertReqArchive, id)
+}
+
 //===========================================================================
 // AnnouncementStore Implementation
 //===========================================================================
@@ -952,13 +974,30 @@ func (s *Store) DeleteOrganization(ctx context.Context, id uuid.UUID) (err error
 	return nil
 }
 
-// TODO: Complete the trtl ContactStore implementation
 //===========================================================================
 // ContactStore Implementation
 //===========================================================================
 
+// ListContacts returns all of the contacts in the store ordered by email address.
 func (s *Store) ListContacts(ctx context.Context) []*models.Contact {
-	return nil
+	iter := NewTrtlStreamingIterator(s.client, wire.NamespaceContacts)
+	defer iter.Release()
+
+	contacts := make([]*models.Contact, 0)
+	for iter.Next() {
+		c := new(models.Contact)
+		if err := proto.Unmarshal(iter.Value(), c); err != nil {
+			sentry.Error(ctx).Err(err).Str("type", wire.NamespaceContacts).Str("key", string(iter.Key())).Msg("corrupted data encountered")
+			continue
+		}
+		contacts = append(contacts, c)
+	}
+
+	if err := iter.Error(); err != nil {
+		sentry.Error(ctx).Err(err).Msg("could not list contacts")
+		return nil
+	}
+	return contacts
 }
 
 // CreateContact creates a new Contact record in the store, using the contact's
@@ -1082,3 +1121,267 @@ func (s *Store) DeleteContact(ctx context.Context, email string) error {
 	}
 	return nil
 }
+
+//===========================================================================
+// JobStore Implementation
+//===========================================================================
+
+// ListJobs returns all of the jobs in the store ordered by ID.
+func (s *Store) ListJobs(ctx context.Context) []*models.Job {
+	iter := NewTrtlStreamingIterator(s.client, wire.NamespaceJobs)
+	defer iter.Release()
+
+	jobs := make([]*models.Job, 0)
+	for iter.Next() {
+		j := new(models.Job)
+		if err := proto.Unmarshal(iter.Value(), j); err != nil {
+			sentry.Error(ctx).Err(err).Str("type", wire.NamespaceJobs).Str("key", string(iter.Key())).Msg("corrupted data encountered")
+			continue
+		}
+		jobs = append(jobs, j)
+	}
+
+	if err := iter.Error(); err != nil {
+		sentry.Error(ctx).Err(err).Msg("could not list jobs")
+		return nil
+	}
+	return jobs
+}
+
+// CreateJob creates a new job in the store and assigns it a unique ID.
+func (s *Store) CreateJob(ctx context.Context, j *models.Job) (_ string, err error) {
+	if j.Id != "" {
+		return "", storeerrors.ErrIDAlreadySet
+	}
+	j.Id = uuid.New().String()
+
+	// Update management timestamps and record metadata
+	j.Created = time.Now().Format(time.RFC3339)
+	j.Modified = j.Created
+
+	if err = s.putJob(ctx, j); err != nil {
+		return "", err
+	}
+	return j.Id, nil
+}
+
+// RetrieveJob returns a job by its ID.
+func (s *Store) RetrieveJob(ctx context.Context, id string) (j *models.Job, err error) {
+	if id == "" {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+
+	ctx, cancel := utils.WithDeadline(ctx)
+	defer cancel()
+
+	request := &pb.GetRequest{
+		Key:       []byte(id),
+		Namespace: wire.NamespaceJobs,
+	}
+	var reply *pb.GetReply
+	if reply, err = s.client.Get(ctx, request); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, storeerrors.ErrEntityNotFound
+		}
+		return nil, err
+	}
+
+	j = new(models.Job)
+	if err = proto.Unmarshal(reply.Value, j); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// UpdateJob can create or update a job. The job should be as complete as possible,
+// including an ID generated by the caller.
+func (s *Store) UpdateJob(ctx context.Context, j *models.Job) (err error) {
+	if j.Id == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	// Update management timestamps and record metadata
+	j.Modified = time.Now().Format(time.RFC3339)
+	if j.Created == "" {
+		j.Created = j.Modified
+	}
+	return s.putJob(ctx, j)
+}
+
+// DeleteJob deletes a job from the store by ID.
+func (s *Store) DeleteJob(ctx context.Context, id string) error {
+	if id == "" {
+		return storeerrors.ErrEntityNotFound
+	}
+
+	ctx, cancel := utils.WithDeadline(ctx)
+	defer cancel()
+
+	request := &pb.DeleteRequest{
+		Key:       []byte(id),
+		Namespace: wire.NamespaceJobs,
+	}
+	if reply, err := s.client.Delete(ctx, request); err != nil || !reply.Success {
+		if err == nil {
+			err = storeerrors.ErrProtocol
+		}
+		return err
+	}
+	return nil
+}
+
+// Helper to marshal a job and put it to the jobs namespace in trtl.
+func (s *Store) putJob(ctx context.Context, j *models.Job) (err error) {
+	var data []byte
+	if data, err = proto.Marshal(j); err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.WithDeadline(ctx)
+	defer cancel()
+
+	request := &pb.PutRequest{
+		Key:       []byte(j.Id),
+		Value:     data,
+		Namespace: wire.NamespaceJobs,
+	}
+	if reply, err := s.client.Put(ctx, request); err != nil || !reply.Success {
+		if err == nil {
+			err = storeerrors.ErrProtocol
+		}
+		return err
+	}
+	return nil
+}
+
+//===========================================================================
+// EmailStore Implementation
+//===========================================================================
+
+// ListEmails returns all of the outbox emails in the store ordered by ID.
+func (s *Store) ListEmails(ctx context.Context) []*models.Email {
+	iter := NewTrtlStreamingIterator(s.client, wire.NamespaceEmails)
+	defer iter.Release()
+
+	emails := make([]*models.Email, 0)
+	for iter.Next() {
+		e := new(models.Email)
+		if err := proto.Unmarshal(iter.Value(), e); err != nil {
+			sentry.Error(ctx).Err(err).Str("type", wire.NamespaceEmails).Str("key", string(iter.Key())).Msg("corrupted data encountered")
+			continue
+		}
+		emails = append(emails, e)
+	}
+
+	if err := iter.Error(); err != nil {
+		sentry.Error(ctx).Err(err).Msg("could not list emails")
+		return nil
+	}
+	return emails
+}
+
+// CreateEmail creates a new outbox email in the store and assigns it a unique ID.
+func (s *Store) CreateEmail(ctx context.Context, e *models.Email) (_ string, err error) {
+	if e.Id != "" {
+		return "", storeerrors.ErrIDAlreadySet
+	}
+	e.Id = uuid.New().String()
+
+	// Update management timestamps and record metadata
+	e.Created = time.Now().Format(time.RFC3339)
+	e.Modified = e.Created
+
+	if err = s.putEmail(ctx, e); err != nil {
+		return "", err
+	}
+	return e.Id, nil
+}
+
+// RetrieveEmail returns an outbox email by its ID.
+func (s *Store) RetrieveEmail(ctx context.Context, id string) (e *models.Email, err error) {
+	if id == "" {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+
+	ctx, cancel := utils.WithDeadline(ctx)
+	defer cancel()
+
+	request := &pb.GetRequest{
+		Key:       []byte(id),
+		Namespace: wire.NamespaceEmails,
+	}
+	var reply *pb.GetReply
+	if reply, err = s.client.Get(ctx, request); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, storeerrors.ErrEntityNotFound
+		}
+		return nil, err
+	}
+
+	e = new(models.Email)
+	if err = proto.Unmarshal(reply.Value, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// UpdateEmail can create or update an outbox email. The email should be as complete as possible,
+// including an ID generated by the caller.
+func (s *Store) UpdateEmail(ctx context.Context, e *models.Email) (err error) {
+	if e.Id == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	// Update management timestamps and record metadata
+	e.Modified = time.Now().Format(time.RFC3339)
+	if e.Created == "" {
+		e.Created = e.Modified
+	}
+	return s.putEmail(ctx, e)
+}
+
+// DeleteEmail deletes an outbox email from the store by ID.
+func (s *Store) DeleteEmail(ctx context.Context, id string) error {
+	if id == "" {
+		return storeerrors.ErrEntityNotFound
+	}
+
+	ctx, cancel := utils.WithDeadline(ctx)
+	defer cancel()
+
+	request := &pb.DeleteRequest{
+		Key:       []byte(id),
+		Namespace: wire.NamespaceEmails,
+	}
+	if reply, err := s.client.Delete(ctx, request); err != nil || !reply.Success {
+		if err == nil {
+			err = storeerrors.ErrProtocol
+		}
+		return err
+	}
+	return nil
+}
+
+// Helper to marshal an outbox email and put it to the emails namespace in trtl.
+func (s *Store) putEmail(ctx context.Context, e *models.Email) (err error) {
+	var data []byte
+	if data, err = proto.Marshal(e); err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.WithDeadline(ctx)
+	defer cancel()
+
+	request := &pb.PutRequest{
+		Key:       []byte(e.Id),
+		Value:     data,
+		Namespace: wire.NamespaceEmails,
+	}
+	if reply, err := s.client.Put(ctx, request); err != nil || !reply.Success {
+		if err == nil {
+			err = storeerrors.ErrProtocol
+		}
+		return err
+	}
+	return nil
+}
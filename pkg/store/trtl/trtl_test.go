@@ -9,20 +9,25 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	bff "github.com/trisacrypto/directory/pkg/bff/models/v1"
 	"github.com/trisacrypto/directory/pkg/models/v1"
 	store "github.com/trisacrypto/directory/pkg/store/trtl"
 	"github.com/trisacrypto/directory/pkg/trtl"
 	"github.com/trisacrypto/directory/pkg/trtl/config"
+	"github.com/trisacrypto/directory/pkg/trtl/mock"
 	"github.com/trisacrypto/directory/pkg/utils/bufconn"
 	"github.com/trisacrypto/directory/pkg/utils/logger"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
 	"github.com/trisacrypto/trisa/pkg/ivms101"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
 	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
+	"github.com/trisacrypto/directory/pkg/store/index"
 )
 
 const (
@@ -113,6 +118,27 @@ func TestTrtlStore(t *testing.T) {
 	suite.Run(t, new(trtlStoreTestSuite))
 }
 
+// Test that the store falls back to unary requests if trtl does not implement Sync.
+func TestSyncFallback(t *testing.T) {
+	remote := mock.New(nil)
+	defer remote.Shutdown()
+
+	require.NoError(t, remote.UseError(mock.SyncRPC, codes.Unimplemented, "not implemented"))
+	require.NoError(t, remote.UseError(mock.GetRPC, codes.NotFound, "not found"))
+
+	require.NoError(t, remote.Channel().Connect(context.Background()))
+	defer remote.Channel().Close()
+
+	db, err := store.NewMock(remote.Channel().Conn)
+	require.NoError(t, err, "could not create store without the sync rpc")
+
+	_, err = db.RetrieveVASP(context.Background(), "12345")
+	require.ErrorIs(t, err, storeerrors.ErrEntityNotFound)
+
+	require.Equal(t, 1, remote.Calls[mock.SyncRPC], "expected only one attempt to open a sync stream")
+	require.Equal(t, len(index.Definitions())+1, remote.Calls[mock.GetRPC], "expected index and vasp gets to fallback to unary")
+}
+
 // Tests all the directory store methods for interacting with VASPs on the Trtl DB.
 func (s *trtlStoreTestSuite) TestDirectoryStore() {
 	require := s.Require()
@@ -541,6 +567,74 @@ func (s *trtlStoreTestSuite) TestCertificateRequestStore() {
 	require.Len(reqs, 110)
 }
 
+func (s *trtlStoreTestSuite) TestCertificateRequestArchive() {
+	require := s.Require()
+	ctx := context.Background()
+
+	// Inject bufconn connection into the store
+	require.NoError(s.grpc.Connect(ctx))
+	defer s.grpc.Close()
+
+	// Connect a mock store
+	db, err := store.NewMock(s.grpc.Conn)
+	require.NoError(err)
+
+	// Archiving a certificate request that does not exist should error
+	require.ErrorIs(db.ArchiveCertReq(ctx, uuid.New().String()), storeerrors.ErrEntityNotFound)
+
+	// Create a few certificate requests to archive
+	ids := make([]string, 0, 3)
+	for i := 0; i < 3; i++ {
+		crr := &models.CertificateRequest{
+			Vasp:       uuid.New().String(),
+			CommonName: fmt.Sprintf("archive%d.example.com", i+1),
+			Status:     models.CertificateRequestState_COMPLETED,
+		}
+		id, err := db.CreateCertReq(ctx, crr)
+		require.NoError(err)
+		ids = append(ids, id)
+	}
+
+	// Archive the first certificate request
+	require.NoError(db.ArchiveCertReq(ctx, ids[0]))
+
+	// The archived request should no longer be active but should still be retrievable
+	reqs, err := db.ListCertReqs(ctx).All()
+	require.NoError(err)
+	require.Len(reqs, 2)
+
+	crr, err := db.RetrieveArchivedCertReq(ctx, ids[0])
+	require.NoError(err)
+	require.Equal(ids[0], crr.Id)
+	require.Equal(models.CertificateRequestState_COMPLETED, crr.Status)
+
+	crr, err = db.RetrieveCertReq(ctx, ids[0])
+	require.NoError(err, "archived certificate requests should be retrievable by id")
+	require.Equal(ids[0], crr.Id)
+
+	_, err = db.RetrieveArchivedCertReq(ctx, ids[1])
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	// A certificate request cannot be archived twice
+	require.ErrorIs(db.ArchiveCertReq(ctx, ids[0]), storeerrors.ErrEntityNotFound)
+
+	// Archive the remaining certificate requests
+	require.NoError(db.ArchiveCertReq(ctx, ids[1]))
+	require.NoError(db.ArchiveCertReq(ctx, ids[2]))
+
+	archived, err := db.ListArchivedCertReqs(ctx).All()
+	require.NoError(err)
+	require.Len(archived, 3)
+
+	count, err := db.CountArchivedCertReqs(ctx)
+	require.NoError(err)
+	require.Equal(uint64(3), count)
+
+	reqs, err = db.ListCertReqs(ctx).All()
+	require.NoError(err)
+	require.Empty(reqs)
+}
+
 func (s *trtlStoreTestSuite) TestAnnouncementStore() {
 	require := s.Require()
 
@@ -984,6 +1078,373 @@ func createVASPs(db *store.Store, num, startIndex int) error {
 	return nil
 }
 
+// Tests that watching the VASPs namespace keeps the indices of a store up to date with
+// changes made by another store (e.g. another GDS replica) connected to the same trtl.
+func (s *trtlStoreTestSuite) TestWatchIndices() {
+	require := s.Require()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Inject bufconn connection into the stores
+	require.NoError(s.grpc.Connect(context.Background()))
+	defer s.grpc.Close()
+
+	writer, err := store.NewMock(s.grpc.Conn)
+	require.NoError(err)
+
+	watcher, err := store.NewMock(s.grpc.Conn)
+	require.NoError(err)
+	go watcher.Watch(ctx)
+
+	query := map[string]interface{}{"name": "Watched VASP"}
+	vasps, err := watcher.SearchVASPs(ctx, query)
+	require.NoError(err)
+	require.Empty(vasps)
+
+	// Create a VASP with the writer, the watcher should eventually find it. The VASP
+	// is updated repeatedly since the watch stream may not have been open on create.
+	vasp := &pb.VASP{
+		Entity: &ivms101.LegalPerson{
+			Name: &ivms101.LegalPersonName{
+				NameIdentifiers: []*ivms101.LegalPersonNameId{
+					{
+						LegalPersonName:               "Watched VASP",
+						LegalPersonNameIdentifierType: ivms101.LegalPersonLegal,
+					},
+				},
+			},
+			CountryOfRegistration: "NZ",
+		},
+		Website:          "https://watched.example.com/",
+		CommonName:       "trisa.watched.example.com",
+		BusinessCategory: pb.BusinessCategoryPrivate,
+	}
+	id, err := writer.CreateVASP(ctx, vasp)
+	require.NoError(err)
+
+	require.Eventually(func() bool {
+		if err := writer.UpdateVASP(ctx, vasp); err != nil {
+			return false
+		}
+		vasps, err := watcher.SearchVASPs(ctx, map[string]interface{}{"name": "Watched VASP", "country": "NZ"})
+		return err == nil && len(vasps) == 1 && vasps[0].Id == id
+	}, 5*time.Second, 50*time.Millisecond, "watcher did not index the created vasp")
+
+	// Delete the VASP with the writer, the watcher should remove it from its indices
+	require.NoError(writer.DeleteVASP(ctx, id))
+	require.Eventually(func() bool {
+		vasps, err := watcher.SearchVASPs(ctx, query)
+		return err == nil && len(vasps) == 0
+	}, 5*time.Second, 50*time.Millisecond, "watcher did not remove the deleted vasp")
+}
+
+func (s *trtlStoreTestSuite) TestJobStore() {
+	require := s.Require()
+	ctx := context.Background()
+
+	// Inject bufconn connection into the store
+	require.NoError(s.grpc.Connect(ctx))
+	defer s.grpc.Close()
+
+	// Connect a mock store
+	db, err := store.NewMock(s.grpc.Conn)
+	require.NoError(err)
+
+	// Make sure create errors if the ID is already set
+	job := &models.Job{Id: "b5841869-105f-411c-8722-4045aad72717"}
+	id, err := db.CreateJob(ctx, job)
+	require.Empty(id)
+	require.Equal(err, storeerrors.ErrIDAlreadySet)
+
+	// Create a valid job
+	job, err = models.NewJob(models.JobRegistration, "d9da630e-41aa-11ec-9d29-acde48001122")
+	require.NoError(err)
+	id, err = db.CreateJob(ctx, job)
+	require.NoError(err)
+	require.NotEmpty(id)
+	require.Equal(id, job.Id)
+
+	// Make sure retrieve throws the proper error when a job is not found
+	var j *models.Job
+	j, err = db.RetrieveJob(ctx, "")
+	require.Nil(j)
+	require.Equal(err, storeerrors.ErrEntityNotFound)
+
+	j, err = db.RetrieveJob(ctx, "b5841869-105f-411c-8722-4045aad72717")
+	require.Nil(j)
+	require.Equal(err, storeerrors.ErrEntityNotFound)
+
+	// Retrieve the created job
+	j, err = db.RetrieveJob(ctx, id)
+	require.NoError(err)
+	require.Equal(job.Type, j.Type)
+	require.Equal(job.Vasp, j.Vasp)
+	require.Equal(models.JobState_JOB_QUEUED, j.Status)
+	require.NotEmpty(j.Created)
+	require.NotEmpty(j.Modified)
+
+	// Make sure update errors with a job without an ID
+	err = db.UpdateJob(ctx, &models.Job{})
+	require.Equal(err, storeerrors.ErrIncompleteRecord)
+
+	// Properly update the job
+	j.Status = models.JobState_JOB_RETRYING
+	j.Attempts = 1
+	j.CompleteStep("review")
+	require.NoError(db.UpdateJob(ctx, j))
+
+	// The updated job should be listed and counted
+	jobs := db.ListJobs(ctx)
+	require.Len(jobs, 1)
+	require.Equal(models.JobState_JOB_RETRYING, jobs[0].Status)
+	require.Equal(uint32(1), jobs[0].Attempts)
+	require.Equal([]string{"review"}, jobs[0].CompletedSteps)
+
+	count, err := db.CountJobs(ctx)
+	require.NoError(err)
+	require.Equal(uint64(1), count)
+
+	// Make sure delete errors with an empty ID
+	err = db.DeleteJob(ctx, "")
+	require.Equal(err, storeerrors.ErrEntityNotFound)
+
+	// Delete the job
+	require.NoError(db.DeleteJob(ctx, id))
+	j, err = db.RetrieveJob(ctx, id)
+	require.Nil(j)
+	require.Equal(err, storeerrors.ErrEntityNotFound)
+	require.Empty(db.ListJobs(ctx))
+}
+
+func (s *trtlStoreTestSuite) TestEmailStore() {
+	require := s.Require()
+	ctx := context.Background()
+
+	// Inject bufconn connection into the store
+	require.NoError(s.grpc.Connect(ctx))
+	defer s.grpc.Close()
+
+	// Connect a mock store
+	db, err := store.NewMock(s.grpc.Conn)
+	require.NoError(err)
+
+	// Make sure create errors if the ID is already set
+	email := &models.Email{Id: "b5841869-105f-411c-8722-4045aad72717"}
+	id, err := db.CreateEmail(ctx, email)
+	require.Empty(id)
+	require.Equal(err, storeerrors.ErrIDAlreadySet)
+
+	// Create a valid email
+	email, err = models.NewEmail("deliver_certs", "Welcome to TRISA", "admin@example.com", "d9da630e-41aa-11ec-9d29-acde48001122", []byte(`{"subject":"Welcome to TRISA"}`))
+	require.NoError(err)
+	id, err = db.CreateEmail(ctx, email)
+	require.NoError(err)
+	require.NotEmpty(id)
+	require.Equal(id, email.Id)
+
+	// Make sure retrieve throws the proper error when an email is not found
+	var e *models.Email
+	e, err = db.RetrieveEmail(ctx, "")
+	require.Nil(e)
+	require.Equal(err, storeerrors.ErrEntityNotFound)
+
+	e, err = db.RetrieveEmail(ctx, "b5841869-105f-411c-8722-4045aad72717")
+	require.Nil(e)
+	require.Equal(err, storeerrors.ErrEntityNotFound)
+
+	// Retrieve the created email
+	e, err = db.RetrieveEmail(ctx, id)
+	require.NoError(err)
+	require.Equal(email.Reason, e.Reason)
+	require.Equal(email.Recipient, e.Recipient)
+	require.Equal(email.Vasp, e.Vasp)
+	require.Equal(email.Draft, e.Draft)
+	require.Equal(models.EmailState_EMAIL_QUEUED, e.Status)
+	require.NotEmpty(e.Created)
+	require.NotEmpty(e.Modified)
+
+	// Make sure update errors with an email without an ID
+	err = db.UpdateEmail(ctx, &models.Email{})
+	require.Equal(err, storeerrors.ErrIncompleteRecord)
+
+	// Properly update the email
+	e.Status = models.EmailState_EMAIL_DEAD_LETTER
+	e.Attempts = 8
+	e.LastError = "could not connect to smtp server"
+	require.NoError(db.UpdateEmail(ctx, e))
+
+	// The updated email should be listed and counted
+	emails := db.ListEmails(ctx)
+	require.Len(emails, 1)
+	require.Equal(models.EmailState_EMAIL_DEAD_LETTER, emails[0].Status)
+	require.Equal(uint32(8), emails[0].Attempts)
+	require.Equal(e.LastError, emails[0].LastError)
+
+	count, err := db.CountEmails(ctx)
+	require.NoError(err)
+	require.Equal(uint64(1), count)
+
+	// Make sure delete errors with an empty ID
+	err = db.DeleteEmail(ctx, "")
+	require.Equal(err, storeerrors.ErrEntityNotFound)
+
+	// Delete the email
+	require.NoError(db.DeleteEmail(ctx, id))
+	e, err = db.RetrieveEmail(ctx, id)
+	require.Nil(e)
+	require.Equal(err, storeerrors.ErrEntityNotFound)
+	require.Empty(db.ListEmails(ctx))
+}
+
+func (s *trtlStoreTestSuite) TestRawStore() {
+	require := s.Require()
+	ctx := context.Background()
+
+	// Inject bufconn connection into the store
+	require.NoError(s.grpc.Connect(ctx))
+	defer s.grpc.Close()
+
+	// Connect a mock store
+	db, err := store.NewMock(s.grpc.Conn)
+	require.NoError(err)
+
+	// Organizations are keyed by their UUID bytes but scanned by their string ID
+	id, err := db.CreateOrganization(ctx, &bff.Organization{Name: "Alice VASP"})
+	require.NoError(err)
+	defer db.DeleteOrganization(ctx, uuid.MustParse(id))
+
+	ids := make([]string, 0)
+	require.NoError(db.ScanRaw(ctx, wire.NamespaceOrganizations, func(orgID string, data []byte) error {
+		ids = append(ids, orgID)
+		return nil
+	}))
+	require.Contains(ids, id)
+
+	data, err := db.GetRaw(ctx, wire.NamespaceOrganizations, id)
+	require.NoError(err)
+
+	// Records are written verbatim without updating their management timestamps
+	org := &bff.Organization{}
+	require.NoError(proto.Unmarshal(data, org))
+	org.Modified = "2020-01-01T00:00:00Z"
+	data, err = proto.Marshal(org)
+	require.NoError(err)
+	require.NoError(db.PutRaw(ctx, wire.NamespaceOrganizations, id, data))
+
+	org, err = db.RetrieveOrganization(ctx, uuid.MustParse(id))
+	require.NoError(err)
+	require.Equal("2020-01-01T00:00:00Z", org.Modified)
+
+	_, err = db.GetRaw(ctx, wire.NamespaceOrganizations, uuid.NewString())
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	err = db.PutRaw(ctx, wire.NamespaceIndices, "names", data)
+	require.ErrorIs(err, storeerrors.ErrUnknownNamespace)
+}
+
+func (s *trtlStoreTestSuite) TestTxn() {
+	require := s.Require()
+	ctx := context.Background()
+
+	// Inject bufconn connection into the store
+	require.NoError(s.grpc.Connect(ctx))
+	defer s.grpc.Close()
+
+	db, err := store.NewMock(s.grpc.Conn)
+	require.NoError(err)
+	defer deleteVASPs(db)
+
+	data, err := os.ReadFile("../testdata/vasp.json")
+	require.NoError(err)
+
+	alice := &pb.VASP{}
+	require.NoError(protojson.Unmarshal(data, alice))
+
+	// Create a VASP, certificate request, and contact in a single transaction
+	tx, err := db.Begin(ctx)
+	require.NoError(err)
+
+	vaspID, err := tx.CreateVASP(alice)
+	require.NoError(err)
+	require.NotEmpty(vaspID)
+
+	certreq := &models.CertificateRequest{Vasp: vaspID, CommonName: alice.CommonName}
+	certreqID, err := tx.CreateCertReq(certreq)
+	require.NoError(err)
+	require.NoError(models.AppendCertReqID(alice, certreqID))
+
+	contact := &models.Contact{Email: "Alice@example.com", Name: "Alice"}
+	_, err = tx.CreateContact(contact)
+	require.NoError(err)
+
+	// Writes should not be visible until the transaction is committed
+	_, err = db.RetrieveVASP(ctx, vaspID)
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	require.NoError(tx.Commit(ctx))
+	require.ErrorIs(tx.Commit(ctx), storeerrors.ErrTxnClosed)
+
+	vasp, err := db.RetrieveVASP(ctx, vaspID)
+	require.NoError(err)
+	require.Equal(uint64(1), vasp.Version.Version)
+	ids, err := models.GetCertReqIDs(vasp)
+	require.NoError(err)
+	require.Equal([]string{certreqID}, ids)
+
+	certreq, err = db.RetrieveCertReq(ctx, certreqID)
+	require.NoError(err)
+	require.Equal(vaspID, certreq.Vasp)
+
+	_, err = db.RetrieveContact(ctx, "alice@example.com")
+	require.NoError(err)
+
+	// The indices should be updated on commit
+	_, err = db.CreateVASP(ctx, &pb.VASP{CommonName: alice.CommonName})
+	require.ErrorIs(err, storeerrors.ErrDuplicateEntity)
+
+	// A failed commit should not write any of the records in the transaction
+	bob := &pb.VASP{CommonName: "trisa.bob.example.com"}
+	_, err = db.CreateVASP(ctx, bob)
+	require.NoError(err)
+
+	tx, err = db.Begin(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+
+	certreq.Status = models.CertificateRequestState_READY_TO_SUBMIT
+	require.NoError(tx.UpdateCertReq(certreq))
+	bob.CommonName = alice.CommonName
+	require.NoError(tx.UpdateVASP(bob))
+	require.ErrorIs(tx.Commit(ctx), storeerrors.ErrDuplicateEntity)
+
+	certreq, err = db.RetrieveCertReq(ctx, certreqID)
+	require.NoError(err)
+	require.Equal(models.CertificateRequestState_INITIALIZED, certreq.Status)
+
+	// Update the VASP and delete the certificate request in a transaction
+	tx, err = db.Begin(ctx)
+	require.NoError(err)
+	require.NoError(tx.DeleteCertReq(certreqID))
+	require.NoError(models.DeleteCertReqID(vasp, certreqID))
+	vasp.CommonName = "trisa.alice.example.com"
+	require.NoError(tx.UpdateVASP(vasp))
+	require.NoError(tx.Commit(ctx))
+
+	_, err = db.RetrieveCertReq(ctx, certreqID)
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	vasp, err = db.RetrieveVASP(ctx, vaspID)
+	require.NoError(err)
+	require.Equal(uint64(2), vasp.Version.Version)
+	require.Equal("trisa.alice.example.com", vasp.CommonName)
+
+	// The previous common name should have been removed from the indices
+	_, err = db.CreateVASP(ctx, &pb.VASP{CommonName: alice.CommonName})
+	require.NoError(err)
+
+	require.NoError(db.DeleteContact(ctx, "alice@example.com"))
+}
+
 // TODO: Add Announcements and Organization tests
 
 func deleteVASPs(db *store.Store) error {
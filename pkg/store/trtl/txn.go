@@ -0,0 +1,124 @@
+package trtl
+
+import (
+	"context"
+	"fmt"
+
+	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
+	"github.com/trisacrypto/directory/pkg/store/index"
+	"github.com/trisacrypto/directory/pkg/store/txn"
+	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
+	"github.com/trisacrypto/directory/pkg/utils"
+	"github.com/trisacrypto/directory/pkg/utils/sentry"
+	gds "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Begin a transaction that is committed to trtl with the Batch RPC, which undoes the
+// writes it already applied if any of the writes fail. The Batch RPC is best effort
+// rather than transactional: the writes are not isolated from other readers and writers
+// and a trtl crash during the commit can leave the transaction partially written.
+func (s *Store) Begin(ctx context.Context) (txn.Txn, error) {
+	return txn.New(s.commit), nil
+}
+
+// commit the operations of a transaction in a single trtl batch.
+func (s *Store) commit(ctx context.Context, ops []*txn.Operation) (err error) {
+	requests := make([]*pb.BatchRequest, 0, len(ops))
+
+	// Critical section (optimizing for safety rather than speed)
+	// NOTE: the lock doesn't prevent concurrent writes from multiple GDS instances.
+	s.Lock()
+	defer s.Unlock()
+
+	// Keep track of the VASP records that are written and their previous versions so
+	// that the indices can be updated after the batch is applied.
+	var prev, vasps []*gds.VASP
+	for i, op := range ops {
+		if op.Delete() {
+			requests = append(requests, &pb.BatchRequest{
+				Id:      int64(i),
+				Request: &pb.BatchRequest_Delete{Delete: &pb.DeleteRequest{Key: []byte(op.Key), Namespace: op.Namespace}},
+			})
+			continue
+		}
+
+		if v, ok := op.VASP(); ok {
+			// The original record must be retrieved inside the lock so that the
+			// indices are consistent with the database.
+			if !op.Create {
+				var o *gds.VASP
+				if o, err = s.RetrieveVASP(ctx, v.Id); err != nil {
+					return err
+				}
+				prev = append(prev, o)
+			}
+
+			// Check the uniqueness constraints
+			if id, ok := s.indices.Find(index.Names, v.CommonName); ok && id != v.Id {
+				return storeerrors.ErrDuplicateEntity
+			}
+			vasps = append(vasps, v)
+		}
+
+		var data []byte
+		if data, err = proto.Marshal(op.Record); err != nil {
+			return err
+		}
+
+		requests = append(requests, &pb.BatchRequest{
+			Id:      int64(i),
+			Request: &pb.BatchRequest_Put{Put: &pb.PutRequest{Key: []byte(op.Key), Value: data, Namespace: op.Namespace}},
+		})
+	}
+
+	if err = s.batch(ctx, requests); err != nil {
+		return err
+	}
+
+	for _, o := range prev {
+		if err = s.removeIndices(o); err != nil {
+			// NOTE: if this error is triggered, admins should reindex the database.
+			sentry.Error(ctx).Err(err).Msg("could not remove previous indices on commit: reindex required")
+		}
+	}
+
+	for _, v := range vasps {
+		if err = s.insertIndices(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// batch applies the put and delete requests with the trtl Batch RPC, which undoes the
+// requests that were applied if any request fails. See the trtl Batch RPC for the
+// limitations of the rollback.
+func (s *Store) batch(ctx context.Context, requests []*pb.BatchRequest) (err error) {
+	ctx, cancel := utils.WithDeadline(ctx)
+	defer cancel()
+
+	var stream pb.Trtl_BatchClient
+	if stream, err = s.client.Batch(ctx); err != nil {
+		return err
+	}
+
+	for _, request := range requests {
+		if err = stream.Send(request); err != nil {
+			return err
+		}
+	}
+
+	var reply *pb.BatchReply
+	if reply, err = stream.CloseAndRecv(); err != nil {
+		return err
+	}
+
+	if reply.Failed > 0 || reply.Successful != int64(len(requests)) {
+		if len(reply.Errors) > 0 {
+			return fmt.Errorf("%w: could not apply batch: %s", storeerrors.ErrProtocol, reply.Errors[0].Error)
+		}
+		return storeerrors.ErrProtocol
+	}
+	return nil
+}
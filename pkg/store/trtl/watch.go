@@ -0,0 +1,112 @@
+package trtl
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+	gds "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	watchMinBackoff = 1 * time.Second
+	watchMaxBackoff = 1 * time.Minute
+)
+
+// Watch subscribes to changes in the VASPs namespace so that the in-memory indices
+// reflect VASP records written by other GDS replicas or replicated from remote trtl
+// peers without requiring a periodic reindex. If the watch stream is interrupted, it
+// is reopened with exponential backoff and the indices are rebuilt to recover any
+// changes that were missed while disconnected. Watch blocks until the context is
+// canceled or the trtl server does not support the Watch RPC.
+func (s *Store) Watch(ctx context.Context) {
+	backoff := watchMinBackoff
+	reconnect := false
+
+	for {
+		// Rebuild the indices after the first connection since changes may have been
+		// missed between the stream being interrupted and being reopened.
+		if reconnect {
+			if err := s.Reindex(); err != nil {
+				log.Warn().Err(err).Msg("could not reindex after reconnecting vasp watch stream")
+			}
+		}
+
+		err := s.watchVASPs(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case status.Code(err) == codes.Unimplemented:
+			log.Warn().Msg("trtl server does not implement watch, indices will not reflect remote changes")
+			return
+		case status.Code(err) == codes.ResourceExhausted:
+			// Watcher fell behind; reindex and reconnect immediately
+			log.Warn().Err(err).Msg("vasp watch stream fell behind")
+			backoff = watchMinBackoff
+		default:
+			log.Warn().Err(err).Dur("backoff", backoff).Msg("vasp watch stream interrupted")
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+
+			if backoff *= 2; backoff > watchMaxBackoff {
+				backoff = watchMaxBackoff
+			}
+		}
+		reconnect = true
+	}
+}
+
+// watchVASPs applies VASP change events to the indices until the stream is closed.
+func (s *Store) watchVASPs(ctx context.Context) (err error) {
+	var stream pb.Trtl_WatchClient
+	if stream, err = s.client.Watch(ctx, &pb.WatchRequest{Namespace: wire.NamespaceVASPs}); err != nil {
+		return err
+	}
+
+	for {
+		var event *pb.WatchEvent
+		if event, err = stream.Recv(); err != nil {
+			return err
+		}
+
+		if err = s.applyVASPEvent(event); err != nil {
+			log.Warn().Err(err).Str("id", string(event.Key)).Msg("could not apply vasp watch event to indices")
+		}
+	}
+}
+
+// applyVASPEvent updates the indices to reflect a single change to a VASP record. The
+// record is purged from the indices by ID since the previous version of the record is
+// not available, then reinserted if the record was not deleted. Events for writes made
+// by this store are idempotent since the indices will already reflect the change.
+func (s *Store) applyVASPEvent(event *pb.WatchEvent) (err error) {
+	var vasp *gds.VASP
+	if event.Type == pb.WatchEvent_PUT {
+		vasp = &gds.VASP{}
+		if err = proto.Unmarshal(event.Value, vasp); err != nil {
+			return err
+		}
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	// Indices may be nil if the store was created without synchronizing them.
+	if s.indices == nil {
+		return nil
+	}
+
+	s.indices.Purge(string(event.Key))
+	if vasp != nil {
+		return s.insertIndices(vasp)
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+/*
+Package txn provides transactions that write multiple records to the store atomically,
+e.g. a VASP record along with its certificate requests and contacts, so that a failure
+partway through a multi-record operation does not leave the store inconsistent.
+Transactions buffer writes in memory; reads are not part of the transaction and should
+be performed against the store before the writes are added.
+*/
+package txn
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
+	"github.com/trisacrypto/directory/pkg/store/index"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Txn buffers writes to multiple records so that they are committed to the store
+// atomically: either all of the writes are applied or none of them are. Records are
+// prepared when they are added to the transaction (e.g. IDs are assigned and the
+// management timestamps are updated) so that the IDs of new records can be referenced
+// by other records in the same transaction before it is committed. Records are not
+// serialized until the commit, so later changes to a record that has already been added
+// to the transaction are also written.
+type Txn interface {
+	CreateVASP(v *pb.VASP) (string, error)
+	UpdateVASP(v *pb.VASP) error
+	CreateCertReq(r *models.CertificateRequest) (string, error)
+	UpdateCertReq(r *models.CertificateRequest) error
+	DeleteCertReq(id string) error
+	UpdateCert(c *models.Certificate) error
+	CreateContact(c *models.Contact) (string, error)
+	UpdateContact(c *models.Contact) error
+	CreateJob(j *models.Job) (string, error)
+	Commit(ctx context.Context) error
+	Rollback()
+}
+
+// Operation is a single buffered write to the store. If the record is nil then the
+// key is deleted from the namespace, otherwise the record is put to the key.
+type Operation struct {
+	Namespace string        // one of the wire namespaces, e.g. wire.NamespaceVASPs
+	Key       string        // the id of the record or the normalized email of a contact
+	Record    proto.Message // the record to write or nil to delete the key
+	Create    bool          // true if the record is new and there is no previous version
+}
+
+// Delete returns true if the operation deletes the key rather than writing a record.
+func (o *Operation) Delete() bool {
+	return o.Record == nil
+}
+
+// VASP returns the record if the operation writes a VASP, which requires the store to
+// check the uniqueness constraints and update the indices of the VASP.
+func (o *Operation) VASP() (*pb.VASP, bool) {
+	if o.Namespace != wire.NamespaceVASPs || o.Record == nil {
+		return nil, false
+	}
+	vasp, ok := o.Record.(*pb.VASP)
+	return vasp, ok
+}
+
+// CommitFunc writes all of the operations to the store atomically and in order.
+type CommitFunc func(ctx context.Context, ops []*Operation) error
+
+// Batch implements the Txn interface by buffering operations until the transaction is
+// committed, at which point the operations are passed to the commit function of the
+// store. Stores implement transactions by returning a Batch from their Begin method.
+type Batch struct {
+	ops    []*Operation
+	commit CommitFunc
+	closed bool
+}
+
+// Compile time interface implementation check.
+var _ Txn = &Batch{}
+
+// New creates a transaction that passes the buffered operations to the commit function.
+func New(commit CommitFunc) *Batch {
+	return &Batch{
+		ops:    make([]*Operation, 0, 4),
+		commit: commit,
+	}
+}
+
+// CreateVASP adds a new VASP to the transaction, assigning it an ID if it doesn't have
+// one. The uniqueness constraints are checked when the transaction is committed.
+func (b *Batch) CreateVASP(v *pb.VASP) (_ string, err error) {
+	if v.Id == "" {
+		v.Id = uuid.New().String()
+	}
+
+	// Ensure a common name exists for the uniqueness constraint
+	if name := index.Normalize(v.CommonName); name == "" {
+		return "", storeerrors.ErrIncompleteRecord
+	}
+
+	// Update management timestamps and record metadata
+	v.LastUpdated = time.Now().Format(time.RFC3339)
+	if v.FirstListed == "" {
+		v.FirstListed = v.LastUpdated
+	}
+	if v.Version == nil || v.Version.Version == 0 {
+		v.Version = &pb.Version{Version: 1}
+	}
+
+	if err = b.add(&Operation{Namespace: wire.NamespaceVASPs, Key: v.Id, Record: v, Create: true}); err != nil {
+		return "", err
+	}
+	return v.Id, nil
+}
+
+// UpdateVASP adds the VASP record to the transaction, overwriting the entire record.
+func (b *Batch) UpdateVASP(v *pb.VASP) error {
+	if v.Id == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	// Ensure a common name exists for the uniqueness constraint
+	if name := index.Normalize(v.CommonName); name == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	// Update management timestamps and record metadata
+	if v.Version == nil {
+		v.Version = &pb.Version{}
+	}
+	v.Version.Version++
+	v.LastUpdated = time.Now().Format(time.RFC3339)
+	if v.FirstListed == "" {
+		v.FirstListed = v.LastUpdated
+	}
+
+	return b.add(&Operation{Namespace: wire.NamespaceVASPs, Key: v.Id, Record: v})
+}
+
+// CreateCertReq adds a new certificate request to the transaction and assigns its ID.
+func (b *Batch) CreateCertReq(r *models.CertificateRequest) (_ string, err error) {
+	if r.Id != "" {
+		return "", storeerrors.ErrIDAlreadySet
+	}
+	r.Id = uuid.New().String()
+
+	// Update management timestamps and record metadata
+	r.Created = time.Now().Format(time.RFC3339)
+	if r.Modified == "" {
+		r.Modified = r.Created
+	}
+
+	if err = b.add(&Operation{Namespace: wire.NamespaceCertReqs, Key: r.Id, Record: r, Create: true}); err != nil {
+		return "", err
+	}
+	return r.Id, nil
+}
+
+// UpdateCertReq adds the certificate request to the transaction.
+func (b *Batch) UpdateCertReq(r *models.CertificateRequest) error {
+	if r.Id == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	// Update management timestamps and record metadata
+	r.Modified = time.Now().Format(time.RFC3339)
+	if r.Created == "" {
+		r.Created = r.Modified
+	}
+
+	return b.add(&Operation{Namespace: wire.NamespaceCertReqs, Key: r.Id, Record: r})
+}
+
+// DeleteCertReq adds the deletion of the certificate request to the transaction.
+func (b *Batch) DeleteCertReq(id string) error {
+	if id == "" {
+		return storeerrors.ErrEntityNotFound
+	}
+	return b.add(&Operation{Namespace: wire.NamespaceCertReqs, Key: id})
+}
+
+// UpdateCert adds the certificate to the transaction.
+func (b *Batch) UpdateCert(c *models.Certificate) error {
+	if c.Id == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+	return b.add(&Operation{Namespace: wire.NamespaceCerts, Key: c.Id, Record: c})
+}
+
+// CreateContact adds a new contact to the transaction, using the email as its ID.
+func (b *Batch) CreateContact(c *models.Contact) (_ string, err error) {
+	if c == nil || c.Email == "" {
+		return "", storeerrors.ErrIncompleteRecord
+	}
+
+	// Update management timestamps and record metadata
+	c.Created = time.Now().Format(time.RFC3339)
+	c.Modified = c.Created
+
+	if err = b.add(&Operation{Namespace: wire.NamespaceContacts, Key: models.NormalizeEmail(c.Email), Record: c, Create: true}); err != nil {
+		return "", err
+	}
+	return c.Email, nil
+}
+
+// UpdateContact adds the contact to the transaction.
+func (b *Batch) UpdateContact(c *models.Contact) error {
+	if c == nil || c.Email == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	c.Modified = time.Now().Format(time.RFC3339)
+	return b.add(&Operation{Namespace: wire.NamespaceContacts, Key: models.NormalizeEmail(c.Email), Record: c})
+}
+
+// CreateJob adds a new job to the transaction and assigns its ID, so that the job is
+// only processed if the records it depends on are also committed.
+func (b *Batch) CreateJob(j *models.Job) (_ string, err error) {
+	if j.Id != "" {
+		return "", storeerrors.ErrIDAlreadySet
+	}
+	j.Id = uuid.New().String()
+
+	// Update management timestamps and record metadata
+	j.Created = time.Now().Format(time.RFC3339)
+	j.Modified = j.Created
+
+	if err = b.add(&Operation{Namespace: wire.NamespaceJobs, Key: j.Id, Record: j, Create: true}); err != nil {
+		return "", err
+	}
+	return j.Id, nil
+}
+
+// Commit the buffered operations to the store. If the commit fails none of the
+// operations are applied. The transaction cannot be used after it is committed.
+func (b *Batch) Commit(ctx context.Context) (err error) {
+	if b.closed {
+		return storeerrors.ErrTxnClosed
+	}
+	b.closed = true
+
+	// Committing an empty transaction is a no-op
+	if len(b.ops) == 0 {
+		return nil
+	}
+	return b.commit(ctx, b.ops)
+}
+
+// Rollback discards the buffered operations; it is safe to call Rollback after Commit
+// so that callers can defer Rollback when the transaction is created.
+func (b *Batch) Rollback() {
+	b.closed = true
+	b.ops = nil
+}
+
+// Operations returns the buffered operations, primarily for testing.
+func (b *Batch) Operations() []*Operation {
+	return b.ops
+}
+
+// Adds the operation to the transaction. If the key has already been written to in the
+// transaction, the previous operation is replaced so that each key is written at most
+// once per transaction; a replaced create remains a create.
+func (b *Batch) add(op *Operation) error {
+	if b.closed {
+		return storeerrors.ErrTxnClosed
+	}
+
+	for i, prev := range b.ops {
+		if prev.Namespace == op.Namespace && prev.Key == op.Key {
+			op.Create = op.Create || (prev.Create && !op.Delete())
+			b.ops[i] = op
+			return nil
+		}
+	}
+
+	b.ops = append(b.ops, op)
+	return nil
+}
@@ -0,0 +1,76 @@
+package txn_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/models/v1"
+	storeerrors "github.com/trisacrypto/directory/pkg/store/errors"
+	"github.com/trisacrypto/directory/pkg/store/txn"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+func TestBatch(t *testing.T) {
+	var committed []*txn.Operation
+	tx := txn.New(func(_ context.Context, ops []*txn.Operation) error {
+		committed = ops
+		return nil
+	})
+
+	// Records should be prepared when they are added to the transaction
+	vasp := &pb.VASP{CommonName: "trisa.example.com"}
+	vaspID, err := tx.CreateVASP(vasp)
+	require.NoError(t, err)
+	require.NotEmpty(t, vaspID)
+	require.Equal(t, uint64(1), vasp.Version.Version)
+	require.NotEmpty(t, vasp.FirstListed)
+
+	certreq := &models.CertificateRequest{Vasp: vaspID}
+	certreqID, err := tx.CreateCertReq(certreq)
+	require.NoError(t, err)
+	require.NotEmpty(t, certreq.Created)
+
+	_, err = tx.CreateCertReq(certreq)
+	require.ErrorIs(t, err, storeerrors.ErrIDAlreadySet)
+	require.ErrorIs(t, tx.UpdateVASP(&pb.VASP{Id: vaspID}), storeerrors.ErrIncompleteRecord)
+
+	// Writing the same record twice should replace the original operation
+	require.NoError(t, models.AppendCertReqID(vasp, certreqID))
+	require.NoError(t, tx.UpdateVASP(vasp))
+	require.NoError(t, tx.UpdateContact(&models.Contact{Email: "Alice@Example.com"}))
+	require.NoError(t, tx.DeleteCertReq(certreqID))
+
+	ops := tx.Operations()
+	require.Len(t, ops, 3)
+	require.Equal(t, wire.NamespaceVASPs, ops[0].Namespace)
+	require.True(t, ops[0].Create, "an updated create should remain a create")
+	v, ok := ops[0].VASP()
+	require.True(t, ok)
+	require.Same(t, vasp, v)
+
+	require.Equal(t, wire.NamespaceCertReqs, ops[1].Namespace)
+	require.True(t, ops[1].Delete())
+	require.False(t, ops[1].Create)
+
+	require.Equal(t, wire.NamespaceContacts, ops[2].Namespace)
+	require.Equal(t, "alice@example.com", ops[2].Key)
+
+	// The operations should be passed to the commit function exactly once
+	require.NoError(t, tx.Commit(context.Background()))
+	require.Len(t, committed, 3)
+	require.ErrorIs(t, tx.Commit(context.Background()), storeerrors.ErrTxnClosed)
+	require.ErrorIs(t, tx.UpdateVASP(vasp), storeerrors.ErrTxnClosed)
+
+	// Commit errors should be returned and rolled back transactions cannot be committed
+	tx = txn.New(func(context.Context, []*txn.Operation) error { return errors.New("whoops") })
+	require.NoError(t, tx.UpdateCert(&models.Certificate{Id: "1234"}))
+	require.EqualError(t, tx.Commit(context.Background()), "whoops")
+
+	tx = txn.New(func(context.Context, []*txn.Operation) error { return errors.New("whoops") })
+	require.NoError(t, tx.UpdateCert(&models.Certificate{Id: "1234"}))
+	tx.Rollback()
+	require.ErrorIs(t, tx.Commit(context.Background()), storeerrors.ErrTxnClosed)
+}
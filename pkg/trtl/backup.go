@@ -1,11 +1,11 @@
 package trtl
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
 	"time"
 
 	"github.com/rotationalio/honu"
@@ -15,6 +15,7 @@ import (
 	ldbstore "github.com/trisacrypto/directory/pkg/store/leveldb"
 	"github.com/trisacrypto/directory/pkg/trtl/config"
 	"github.com/trisacrypto/directory/pkg/utils"
+	"github.com/trisacrypto/directory/pkg/utils/backups"
 	"github.com/trisacrypto/directory/pkg/utils/sentry"
 )
 
@@ -73,35 +74,72 @@ backups:
 		log.Debug().Msg("starting backup of trtl database")
 
 		// Perform the backup
-		if err = m.backup(backupDir); err != nil {
-			// Do not continue if there was a backup error; all code in the rest of the
-			// loop should expect that the backup was successful.
+		if err = m.Backup(backupDir); err != nil {
 			// NOTE: using WithLevel and Fatal does not Exit the program like log.Fatal()
 			// this ensures that we issue a CRITICAL severity without stopping the server.
 			sentry.Fatal(nil).Err(err).Msg("could not backup database")
 			continue backups
-		} else {
-			log.Info().Dur("duration", time.Since(start)).Msg("trtl backup complete")
 		}
+		log.Info().Dur("duration", time.Since(start)).Msg("trtl backup complete")
+	}
+}
 
-		// Remove any previous backups that may be in the directory
-		// NOTE: this requires the backup to write filenames as trtldb-200601021504.*
-		var archives []string
-		if archives, err = listArchives(backupDir); err != nil {
-			sentry.Error(nil).Err(err).Msg("could not list backup directory")
-		} else {
-			if len(archives) > m.conf.Keep {
-				var removed int
-				for _, archive := range archives[:len(archives)-m.conf.Keep] {
-					log.Debug().Str("archive", archive).Msg("deleting archive")
-					if err = os.Remove(archive); err == nil {
-						removed++
-					}
-				}
-				log.Debug().Int("kept", m.conf.Keep).Int("removed", removed).Msg("backup directory cleaned up")
-			}
+// Backup creates an archive of the trtl database staged in the backup directory and
+// uploads it (encrypted if a key is configured) to the backup sink, then removes any
+// previous archives from the sink that are not kept by the retention policy.
+func (m *BackupManager) Backup(path string) (err error) {
+	var manager *backups.Manager
+	if manager, err = m.manager(path); err != nil {
+		return fmt.Errorf("could not create backup sink: %s", err)
+	}
+
+	// Stage the archive in the backup directory before it is uploaded to the sink
+	var staging string
+	if staging, err = os.MkdirTemp(path, ".staging-"); err != nil {
+		return fmt.Errorf("could not create backup staging directory: %s", err)
+	}
+	defer os.RemoveAll(staging)
+
+	if err = m.backup(staging); err != nil {
+		return err
+	}
+
+	var archives []string
+	if archives, err = backups.Staged(staging, backups.TrtlPrefix); err != nil {
+		return fmt.Errorf("could not list backup staging directory: %s", err)
+	}
+
+	ctx := context.Background()
+	for _, archive := range archives {
+		if _, err = manager.Upload(ctx, archive); err != nil {
+			return fmt.Errorf("could not upload backup archive: %s", err)
 		}
 	}
+
+	// Remove any previous backups from the sink that are not retained; this is not a
+	// backup failure so the error is only logged.
+	var removed int
+	if removed, err = manager.Prune(ctx); err != nil {
+		sentry.Error(nil).Err(err).Msg("could not prune backup archives")
+		return nil
+	}
+	log.Debug().Int("kept", m.conf.Keep).Int("removed", removed).Msg("backup directory cleaned up")
+	return nil
+}
+
+// create the backup manager for the configured sink, using the backup directory as the
+// sink if no other sink is configured.
+func (m *BackupManager) manager(path string) (_ *backups.Manager, err error) {
+	var sink backups.Sink
+	if sink, err = backups.Open(m.conf.Sink, path); err != nil {
+		return nil, err
+	}
+
+	var key []byte
+	if key, err = backups.ParseKey(m.conf.EncryptionKey); err != nil {
+		return nil, err
+	}
+	return backups.New(backups.TrtlPrefix, sink, key, m.conf.Retention()), nil
 }
 
 func (m *BackupManager) Shutdown() error {
@@ -118,7 +156,7 @@ func (m *BackupManager) Shutdown() error {
 
 func (m *BackupManager) backup(path string) (err error) {
 	// Create the directory for the copied honu database
-	archive := filepath.Join(path, time.Now().UTC().Format("trtldb-200601021504"))
+	archive := filepath.Join(path, backups.ArchiveName(backups.TrtlPrefix, time.Now()))
 	if err = os.Mkdir(archive, 0755); err != nil {
 		return fmt.Errorf("could not create archive directory: %s", err)
 	}
@@ -192,15 +230,3 @@ func (m *BackupManager) getBackupStorage() (path string, err error) {
 	}
 	return path, nil
 }
-
-// list all backup archives ordered by date ascending using string sorting that depends
-// on the backup archive format trtldb-YYYYmmddHHMM.
-func listArchives(path string) (paths []string, err error) {
-	if paths, err = filepath.Glob(filepath.Join(path, "trtldb-[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9].*")); err != nil {
-		return nil, err
-	}
-
-	// Sort the paths by timestamp ascending
-	sort.Strings(paths)
-	return paths, nil
-}
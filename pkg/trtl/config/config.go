@@ -4,11 +4,13 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
 	honuconfig "github.com/rotationalio/honu/config"
 	"github.com/rs/zerolog"
+	"github.com/trisacrypto/directory/pkg/utils/backups"
 	"github.com/trisacrypto/directory/pkg/utils/logger"
 	"github.com/trisacrypto/directory/pkg/utils/sentry"
 	"github.com/trisacrypto/trisa/pkg/trust"
@@ -70,10 +72,15 @@ type MTLSConfig struct {
 }
 
 type BackupConfig struct {
-	Enabled  bool          `split_words:"true" default:"false"`
-	Interval time.Duration `split_words:"true" default:"24h"`
-	Storage  string        `split_words:"true" required:"false"`
-	Keep     int           `split_words:"true" default:"1"`
+	Enabled       bool               `split_words:"true" default:"false"`
+	Interval      time.Duration      `split_words:"true" default:"24h"`
+	Storage       string             `split_words:"true" required:"false"`
+	Keep          int                `split_words:"true" default:"1"`
+	KeepDaily     int                `split_words:"true" default:"0"`
+	KeepWeekly    int                `split_words:"true" default:"0"`
+	KeepMonthly   int                `split_words:"true" default:"0"`
+	EncryptionKey string             `split_words:"true" required:"false"`
+	Sink          backups.SinkConfig `split_words:"true"`
 }
 
 // New creates a new Config object, loading environment variables and defaults.
@@ -131,6 +138,9 @@ func (c Config) Validate() (err error) {
 	if err = c.MTLS.Validate(); err != nil {
 		return err
 	}
+	if err = c.Backup.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -260,3 +270,28 @@ func (c *MTLSConfig) load() (err error) {
 
 	return nil
 }
+
+func (c BackupConfig) Validate() (err error) {
+	if err = c.Sink.Validate(); err != nil {
+		return err
+	}
+
+	if _, err = backups.ParseKey(c.EncryptionKey); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if c.Keep < 0 || c.KeepDaily < 0 || c.KeepWeekly < 0 || c.KeepMonthly < 0 {
+		return errors.New("invalid configuration: backup retention cannot be negative")
+	}
+	return nil
+}
+
+// Retention returns the retention policy of the backup archives.
+func (c BackupConfig) Retention() backups.Retention {
+	return backups.Retention{
+		Keep:    c.Keep,
+		Daily:   c.KeepDaily,
+		Weekly:  c.KeepWeekly,
+		Monthly: c.KeepMonthly,
+	}
+}
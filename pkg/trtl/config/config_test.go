@@ -32,6 +32,10 @@ var testEnv = map[string]string{
 	"TRTL_BACKUP_INTERVAL":          "1h",
 	"TRTL_BACKUP_STORAGE":           "fixtures/backups",
 	"TRTL_BACKUP_KEEP":              "7",
+	"TRTL_BACKUP_KEEP_WEEKLY":       "4",
+	"TRTL_BACKUP_ENCRYPTION_KEY":    "3q2+796tvu/erb7v3q2+796tvu/erb7v3q2+796tvu8=",
+	"TRTL_BACKUP_SINK_URL":          "s3://backups/trtl",
+	"TRTL_BACKUP_SINK_ENDPOINT":     "localhost:9000",
 	"TRTL_SENTRY_DSN":               "https://something.ingest.sentry.io",
 	"TRTL_SENTRY_ENVIRONMENT":       "test",
 	"TRTL_SENTRY_RELEASE":           "1.4",
@@ -87,6 +91,10 @@ func TestConfig(t *testing.T) {
 	require.Equal(t, 1*time.Hour, conf.Backup.Interval)
 	require.Equal(t, testEnv["TRTL_BACKUP_STORAGE"], conf.Backup.Storage)
 	require.Equal(t, 7, conf.Backup.Keep)
+	require.Equal(t, 4, conf.Backup.KeepWeekly)
+	require.Equal(t, testEnv["TRTL_BACKUP_ENCRYPTION_KEY"], conf.Backup.EncryptionKey)
+	require.Equal(t, testEnv["TRTL_BACKUP_SINK_URL"], conf.Backup.Sink.URL)
+	require.Equal(t, testEnv["TRTL_BACKUP_SINK_ENDPOINT"], conf.Backup.Sink.Endpoint)
 	require.Equal(t, testEnv["TRTL_SENTRY_DSN"], conf.Sentry.DSN)
 	require.Equal(t, testEnv["TRTL_SENTRY_ENVIRONMENT"], conf.Sentry.Environment)
 	require.Equal(t, testEnv["TRTL_SENTRY_RELEASE"], conf.Sentry.Release)
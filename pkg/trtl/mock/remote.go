@@ -24,6 +24,7 @@ const (
 	BatchRPC    = "trtl.v1.Trtl/Batch"
 	CursorRPC   = "trtl.v1.Trtl/Cursor"
 	SyncRPC     = "trtl.v1.Trtl/Sync"
+	WatchRPC    = "trtl.v1.Trtl/Watch"
 	StatusRPC   = "trtl.v1.Trtl/Status"
 	GetPeersRPC = "trtl.peers.v1.PeerManagement/GetPeers"
 	AddPeersRPC = "trtl.peers.v1.PeerManagement/AddPeers"
@@ -74,6 +75,7 @@ type RemoteTrtl struct {
 	OnBatch    func(pb.Trtl_BatchServer) error
 	OnCursor   func(*pb.CursorRequest, pb.Trtl_CursorServer) error
 	OnSync     func(pb.Trtl_SyncServer) error
+	OnWatch    func(*pb.WatchRequest, pb.Trtl_WatchServer) error
 	OnStatus   func(context.Context, *pb.HealthCheck) (*pb.ServerStatus, error)
 	OnGetPeers func(context.Context, *peers.PeersFilter) (*peers.PeersList, error)
 	OnAddPeers func(context.Context, *peers.Peer) (*peers.PeersStatus, error)
@@ -142,6 +144,7 @@ func (s *RemoteTrtl) Reset() {
 	s.OnBatch = nil
 	s.OnCursor = nil
 	s.OnSync = nil
+	s.OnWatch = nil
 	s.OnStatus = nil
 	s.OnGetPeers = nil
 	s.OnAddPeers = nil
@@ -201,6 +204,8 @@ func (s *RemoteTrtl) UseFixture(rpc, path string) (err error) {
 		return fmt.Errorf("cannot use fixture for Cursor RPC, instead set OnCursorRPC directly")
 	case SyncRPC:
 		return fmt.Errorf("cannot use fixture for Sync RPC, instead set OnSyncRPC directly")
+	case WatchRPC:
+		return fmt.Errorf("cannot use fixture for Watch RPC, instead set OnWatchRPC directly")
 	case StatusRPC:
 		out := &pb.ServerStatus{}
 		if err = jsonpb.Unmarshal(data, out); err != nil {
@@ -273,6 +278,10 @@ func (s *RemoteTrtl) UseError(rpc string, code codes.Code, msg string) error {
 		s.OnSync = func(pb.Trtl_SyncServer) error {
 			return status.Error(code, msg)
 		}
+	case WatchRPC:
+		s.OnWatch = func(*pb.WatchRequest, pb.Trtl_WatchServer) error {
+			return status.Error(code, msg)
+		}
 	case StatusRPC:
 		s.OnStatus = func(ctx context.Context, in *pb.HealthCheck) (*pb.ServerStatus, error) {
 			return nil, status.Error(code, msg)
@@ -334,6 +343,11 @@ func (s *RemoteTrtl) Sync(stream pb.Trtl_SyncServer) error {
 	return s.OnSync(stream)
 }
 
+func (s *RemoteTrtl) Watch(in *pb.WatchRequest, stream pb.Trtl_WatchServer) error {
+	s.Calls[WatchRPC]++
+	return s.OnWatch(in, stream)
+}
+
 func (s *RemoteTrtl) Status(ctx context.Context, in *pb.HealthCheck) (*pb.ServerStatus, error) {
 	s.Calls[StatusRPC]++
 	return s.OnStatus(ctx, in)
@@ -4,17 +4,20 @@ import "github.com/trisacrypto/directory/pkg/utils/wire"
 
 // TODO: need functionality to actually extract namespaces from db
 const (
-	NamespacePeers         = wire.NamespaceReplicas
-	NamespaceIndex         = wire.NamespaceIndices
-	NamespaceDefault       = "default"
-	NamespaceUnknown       = "unknown"
-	NamespaceSequence      = wire.NamespaceSequence
-	NamespaceVASPs         = wire.NamespaceVASPs
-	NamespaceCertReqs      = wire.NamespaceCertReqs
-	NamespaceCerts         = wire.NamespaceCerts
-	NamespaceContacts      = wire.NamespaceContacts
-	NamespaceAnnouncements = wire.NamespaceAnnouncements
-	NamespaceOrganizations = wire.NamespaceOrganizations
+	NamespacePeers          = wire.NamespaceReplicas
+	NamespaceIndex          = wire.NamespaceIndices
+	NamespaceDefault        = "default"
+	NamespaceUnknown        = "unknown"
+	NamespaceSequence       = wire.NamespaceSequence
+	NamespaceVASPs          = wire.NamespaceVASPs
+	NamespaceCertReqs       = wire.NamespaceCertReqs
+	NamespaceCertReqArchive = wire.NamespaceCertReqArchive
+	NamespaceCerts          = wire.NamespaceCerts
+	NamespaceContacts       = wire.NamespaceContacts
+	NamespaceAnnouncements  = wire.NamespaceAnnouncements
+	NamespaceOrganizations  = wire.NamespaceOrganizations
+	NamespaceEmails         = wire.NamespaceEmails
+	NamespaceJobs           = wire.NamespaceJobs
 )
 
 // Reserved namespaces that cannot be used by the caller since they are in use by trtl.
@@ -30,9 +33,13 @@ var reservedNamespaces = map[string]struct{}{
 }
 
 // Replicated namespaces are the namespaces that are used in anti-entropy by default.
+// NOTE: the email outbox and the job queue are not replicated since every GDS instance
+// processes the queues of its own trtl node; replicating them would send each email and
+// process each job in every region.
 var replicatedNamespaces = []string{
 	NamespaceVASPs,
 	NamespaceCertReqs,
+	NamespaceCertReqArchive,
 	NamespaceCerts,
 	NamespaceAnnouncements,
 	NamespaceOrganizations,
@@ -48,7 +55,10 @@ var measuredNamespaces = []string{
 	NamespaceSequence,
 	NamespaceVASPs,
 	NamespaceCertReqs,
+	NamespaceCertReqArchive,
 	NamespaceCerts,
 	NamespaceAnnouncements,
 	NamespaceOrganizations,
+	NamespaceEmails,
+	NamespaceJobs,
 }
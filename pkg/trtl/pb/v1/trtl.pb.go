@@ -20,6 +20,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type WatchEvent_Type int32
+
+const (
+	WatchEvent_UNKNOWN WatchEvent_Type = 0
+	WatchEvent_PUT     WatchEvent_Type = 1
+	WatchEvent_DELETE  WatchEvent_Type = 2
+)
+
+// Enum value maps for WatchEvent_Type.
+var (
+	WatchEvent_Type_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "PUT",
+		2: "DELETE",
+	}
+	WatchEvent_Type_value = map[string]int32{
+		"UNKNOWN": 0,
+		"PUT":     1,
+		"DELETE":  2,
+	}
+)
+
+func (x WatchEvent_Type) Enum() *WatchEvent_Type {
+	p := new(WatchEvent_Type)
+	*p = x
+	return p
+}
+
+func (x WatchEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_trtl_v1_trtl_proto_enumTypes[0].Descriptor()
+}
+
+func (WatchEvent_Type) Type() protoreflect.EnumType {
+	return &file_trtl_v1_trtl_proto_enumTypes[0]
+}
+
+func (x WatchEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WatchEvent_Type.Descriptor instead.
+func (WatchEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{14, 0}
+}
+
 type GetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -853,9 +902,10 @@ type SyncReply struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id      int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id      int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` // the id of the SyncRequest this reply is correlated with
 	Success bool   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
-	Error   string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Error   string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"` // the error message if the operation was not successful
+	Code    uint32 `protobuf:"varint,8,opt,name=code,proto3" json:"code,omitempty"`  // the gRPC status code of the error if not successful
 	// Types that are assignable to Reply:
 	//
 	//	*SyncReply_Get
@@ -918,6 +968,13 @@ func (x *SyncReply) GetError() string {
 	return ""
 }
 
+func (x *SyncReply) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
 func (m *SyncReply) GetReply() isSyncReply_Reply {
 	if m != nil {
 		return m.Reply
@@ -981,6 +1038,150 @@ func (*SyncReply_Delete) isSyncReply_Reply() {}
 
 func (*SyncReply_Iter) isSyncReply_Reply() {}
 
+type WatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Namespace string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"` // the namespace to watch for changes
+	Prefix    []byte   `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`       // only watch keys with the specified prefix (optional)
+	Options   *Options `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`     // iter_no_values will omit values from put events
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_trtl_v1_trtl_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trtl_v1_trtl_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WatchRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetPrefix() []byte {
+	if x != nil {
+		return x.Prefix
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetOptions() *Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// A WatchEvent describes a change to a single key in the watched namespace. The meta
+// is always included so that subscribers can order and deduplicate events by version.
+type WatchEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type      WatchEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=trtl.v1.WatchEvent_Type" json:"type,omitempty"`
+	Key       []byte          `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value     []byte          `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"` // the value of the object, empty on delete
+	Namespace string          `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Meta      *Meta           `protobuf:"bytes,5,opt,name=meta,proto3" json:"meta,omitempty"`
+}
+
+func (x *WatchEvent) Reset() {
+	*x = WatchEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_trtl_v1_trtl_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEvent) ProtoMessage() {}
+
+func (x *WatchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_trtl_v1_trtl_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEvent.ProtoReflect.Descriptor instead.
+func (*WatchEvent) Descriptor() ([]byte, []int) {
+	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WatchEvent) GetType() WatchEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return WatchEvent_UNKNOWN
+}
+
+func (x *WatchEvent) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *WatchEvent) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *WatchEvent) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *WatchEvent) GetMeta() *Meta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
 type CountRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -994,7 +1195,7 @@ type CountRequest struct {
 func (x *CountRequest) Reset() {
 	*x = CountRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_trtl_v1_trtl_proto_msgTypes[13]
+		mi := &file_trtl_v1_trtl_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1007,7 +1208,7 @@ func (x *CountRequest) String() string {
 func (*CountRequest) ProtoMessage() {}
 
 func (x *CountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trtl_v1_trtl_proto_msgTypes[13]
+	mi := &file_trtl_v1_trtl_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1020,7 +1221,7 @@ func (x *CountRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CountRequest.ProtoReflect.Descriptor instead.
 func (*CountRequest) Descriptor() ([]byte, []int) {
-	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{13}
+	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *CountRequest) GetPrefix() []byte {
@@ -1057,7 +1258,7 @@ type CountReply struct {
 func (x *CountReply) Reset() {
 	*x = CountReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_trtl_v1_trtl_proto_msgTypes[14]
+		mi := &file_trtl_v1_trtl_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1070,7 +1271,7 @@ func (x *CountReply) String() string {
 func (*CountReply) ProtoMessage() {}
 
 func (x *CountReply) ProtoReflect() protoreflect.Message {
-	mi := &file_trtl_v1_trtl_proto_msgTypes[14]
+	mi := &file_trtl_v1_trtl_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1083,7 +1284,7 @@ func (x *CountReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CountReply.ProtoReflect.Descriptor instead.
 func (*CountReply) Descriptor() ([]byte, []int) {
-	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{14}
+	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *CountReply) GetObjects() uint64 {
@@ -1116,7 +1317,7 @@ type HealthCheck struct {
 func (x *HealthCheck) Reset() {
 	*x = HealthCheck{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_trtl_v1_trtl_proto_msgTypes[15]
+		mi := &file_trtl_v1_trtl_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1129,7 +1330,7 @@ func (x *HealthCheck) String() string {
 func (*HealthCheck) ProtoMessage() {}
 
 func (x *HealthCheck) ProtoReflect() protoreflect.Message {
-	mi := &file_trtl_v1_trtl_proto_msgTypes[15]
+	mi := &file_trtl_v1_trtl_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1142,7 +1343,7 @@ func (x *HealthCheck) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheck.ProtoReflect.Descriptor instead.
 func (*HealthCheck) Descriptor() ([]byte, []int) {
-	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{15}
+	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{17}
 }
 
 type ServerStatus struct {
@@ -1159,7 +1360,7 @@ type ServerStatus struct {
 func (x *ServerStatus) Reset() {
 	*x = ServerStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_trtl_v1_trtl_proto_msgTypes[16]
+		mi := &file_trtl_v1_trtl_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1172,7 +1373,7 @@ func (x *ServerStatus) String() string {
 func (*ServerStatus) ProtoMessage() {}
 
 func (x *ServerStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_trtl_v1_trtl_proto_msgTypes[16]
+	mi := &file_trtl_v1_trtl_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1185,7 +1386,7 @@ func (x *ServerStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerStatus.ProtoReflect.Descriptor instead.
 func (*ServerStatus) Descriptor() ([]byte, []int) {
-	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{16}
+	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ServerStatus) GetStatus() string {
@@ -1232,7 +1433,7 @@ type ReplicaStatus struct {
 func (x *ReplicaStatus) Reset() {
 	*x = ReplicaStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_trtl_v1_trtl_proto_msgTypes[17]
+		mi := &file_trtl_v1_trtl_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1245,7 +1446,7 @@ func (x *ReplicaStatus) String() string {
 func (*ReplicaStatus) ProtoMessage() {}
 
 func (x *ReplicaStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_trtl_v1_trtl_proto_msgTypes[17]
+	mi := &file_trtl_v1_trtl_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1258,7 +1459,7 @@ func (x *ReplicaStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReplicaStatus.ProtoReflect.Descriptor instead.
 func (*ReplicaStatus) Descriptor() ([]byte, []int) {
-	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{17}
+	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ReplicaStatus) GetEnabled() bool {
@@ -1320,7 +1521,7 @@ type Options struct {
 func (x *Options) Reset() {
 	*x = Options{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_trtl_v1_trtl_proto_msgTypes[18]
+		mi := &file_trtl_v1_trtl_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1333,7 +1534,7 @@ func (x *Options) String() string {
 func (*Options) ProtoMessage() {}
 
 func (x *Options) ProtoReflect() protoreflect.Message {
-	mi := &file_trtl_v1_trtl_proto_msgTypes[18]
+	mi := &file_trtl_v1_trtl_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1346,7 +1547,7 @@ func (x *Options) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Options.ProtoReflect.Descriptor instead.
 func (*Options) Descriptor() ([]byte, []int) {
-	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{18}
+	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *Options) GetReturnMeta() bool {
@@ -1399,7 +1600,7 @@ type KVPair struct {
 func (x *KVPair) Reset() {
 	*x = KVPair{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_trtl_v1_trtl_proto_msgTypes[19]
+		mi := &file_trtl_v1_trtl_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1412,7 +1613,7 @@ func (x *KVPair) String() string {
 func (*KVPair) ProtoMessage() {}
 
 func (x *KVPair) ProtoReflect() protoreflect.Message {
-	mi := &file_trtl_v1_trtl_proto_msgTypes[19]
+	mi := &file_trtl_v1_trtl_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1425,7 +1626,7 @@ func (x *KVPair) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KVPair.ProtoReflect.Descriptor instead.
 func (*KVPair) Descriptor() ([]byte, []int) {
-	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{19}
+	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *KVPair) GetKey() []byte {
@@ -1475,7 +1676,7 @@ type Meta struct {
 func (x *Meta) Reset() {
 	*x = Meta{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_trtl_v1_trtl_proto_msgTypes[20]
+		mi := &file_trtl_v1_trtl_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1488,7 +1689,7 @@ func (x *Meta) String() string {
 func (*Meta) ProtoMessage() {}
 
 func (x *Meta) ProtoReflect() protoreflect.Message {
-	mi := &file_trtl_v1_trtl_proto_msgTypes[20]
+	mi := &file_trtl_v1_trtl_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1501,7 +1702,7 @@ func (x *Meta) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Meta.ProtoReflect.Descriptor instead.
 func (*Meta) Descriptor() ([]byte, []int) {
-	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{20}
+	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *Meta) GetKey() []byte {
@@ -1559,7 +1760,7 @@ type Version struct {
 func (x *Version) Reset() {
 	*x = Version{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_trtl_v1_trtl_proto_msgTypes[21]
+		mi := &file_trtl_v1_trtl_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1572,7 +1773,7 @@ func (x *Version) String() string {
 func (*Version) ProtoMessage() {}
 
 func (x *Version) ProtoReflect() protoreflect.Message {
-	mi := &file_trtl_v1_trtl_proto_msgTypes[21]
+	mi := &file_trtl_v1_trtl_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1585,7 +1786,7 @@ func (x *Version) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Version.ProtoReflect.Descriptor instead.
 func (*Version) Descriptor() ([]byte, []int) {
-	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{21}
+	return file_trtl_v1_trtl_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *Version) GetPid() uint64 {
@@ -1621,7 +1822,7 @@ type BatchReply_Error struct {
 func (x *BatchReply_Error) Reset() {
 	*x = BatchReply_Error{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_trtl_v1_trtl_proto_msgTypes[22]
+		mi := &file_trtl_v1_trtl_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1634,7 +1835,7 @@ func (x *BatchReply_Error) String() string {
 func (*BatchReply_Error) ProtoMessage() {}
 
 func (x *BatchReply_Error) ProtoReflect() protoreflect.Message {
-	mi := &file_trtl_v1_trtl_proto_msgTypes[22]
+	mi := &file_trtl_v1_trtl_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1760,125 +1961,150 @@ var file_trtl_v1_trtl_proto_rawDesc = []byte{
 	0x12, 0x2a, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
 	0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x04, 0x69, 0x74, 0x65, 0x72, 0x42, 0x09, 0x0a, 0x07,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xfc, 0x01, 0x0a, 0x09, 0x53, 0x79, 0x6e, 0x63,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x90, 0x02, 0x0a, 0x09, 0x53, 0x79, 0x6e, 0x63,
 	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12,
 	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x25, 0x0a, 0x03, 0x67, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x03, 0x67, 0x65, 0x74, 0x12, 0x25, 0x0a, 0x03,
-	0x70, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x72, 0x74, 0x6c,
-	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x03,
-	0x70, 0x75, 0x74, 0x12, 0x2e, 0x0a, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x06, 0x64, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x12, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x74, 0x65, 0x72,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x04, 0x69, 0x74, 0x65, 0x72, 0x42, 0x07, 0x0a,
-	0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x5f, 0x0a, 0x0c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x19,
-	0x0a, 0x08, 0x73, 0x65, 0x65, 0x6b, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x07, 0x73, 0x65, 0x65, 0x6b, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d,
-	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61,
-	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x66, 0x0a, 0x0a, 0x43, 0x6f, 0x75, 0x6e, 0x74,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12,
-	0x1b, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c,
-	0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x0b, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22,
-	0x0d, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x22, 0x8a,
-	0x01, 0x0a, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x72, 0x65, 0x70,
-	0x6c, 0x69, 0x63, 0x61, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x72, 0x74,
-	0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x22, 0x99, 0x01, 0x0a, 0x0d,
-	0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a,
-	0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
-	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67,
-	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f,
-	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
-	0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
-	0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x67, 0x6d, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x73, 0x69, 0x67, 0x6d, 0x61, 0x22, 0xae, 0x01, 0x0a, 0x07, 0x4f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x6d, 0x65,
-	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e,
-	0x4d, 0x65, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0c, 0x69, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x6f, 0x5f,
-	0x6b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x74, 0x65, 0x72,
-	0x4e, 0x6f, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x69, 0x74, 0x65, 0x72, 0x5f, 0x6e,
-	0x6f, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c,
-	0x69, 0x74, 0x65, 0x72, 0x4e, 0x6f, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
-	0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x70,
-	0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
-	0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x71, 0x0a, 0x06, 0x4b, 0x56, 0x50, 0x61,
-	0x69, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61,
-	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e,
-	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31,
-	0x2e, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x22, 0xba, 0x01, 0x0a, 0x04,
-	0x4d, 0x65, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
-	0x70, 0x61, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05,
-	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e,
-	0x65, 0x72, 0x12, 0x2a, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28,
-	0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x22, 0x4d, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
-	0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x32, 0xee, 0x03, 0x0a, 0x04, 0x54, 0x72, 0x74, 0x6c,
-	0x12, 0x2f, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x13, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76,
-	0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x74,
-	0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x00, 0x12, 0x2f, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x12, 0x13, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e,
-	0x76, 0x31, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x25, 0x0a, 0x03, 0x67, 0x65, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x03, 0x67, 0x65, 0x74,
+	0x12, 0x25, 0x0a, 0x03, 0x70, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
 	0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x22, 0x00, 0x12, 0x38, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x16, 0x2e, 0x74,
-	0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x04,
-	0x49, 0x74, 0x65, 0x72, 0x12, 0x14, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x49,
-	0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x74, 0x72, 0x74,
-	0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
-	0x12, 0x37, 0x0a, 0x05, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x15, 0x2e, 0x74, 0x72, 0x74, 0x6c,
-	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x13, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x12, 0x35, 0x0a, 0x06, 0x43, 0x75, 0x72,
-	0x73, 0x6f, 0x72, 0x12, 0x16, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75,
-	0x72, 0x73, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x74, 0x72,
-	0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x56, 0x50, 0x61, 0x69, 0x72, 0x22, 0x00, 0x30, 0x01,
-	0x12, 0x36, 0x0a, 0x04, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x14, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e,
-	0x76, 0x31, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12,
-	0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x35, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x15, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e,
-	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
-	0x37, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x2e, 0x74, 0x72, 0x74, 0x6c,
-	0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x1a,
-	0x15, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x72, 0x69, 0x73, 0x61, 0x63, 0x72, 0x79, 0x70,
-	0x74, 0x6f, 0x2f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x2f, 0x70, 0x6b, 0x67,
-	0x2f, 0x74, 0x72, 0x74, 0x6c, 0x2f, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x62, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x48, 0x00, 0x52, 0x03, 0x70, 0x75, 0x74, 0x12, 0x2e, 0x0a, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52,
+	0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x72, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e,
+	0x49, 0x74, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x04, 0x69, 0x74, 0x65,
+	0x72, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x70, 0x0a, 0x0c, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x12, 0x2a, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xcd, 0x01, 0x0a,
+	0x0a, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x74, 0x72, 0x74, 0x6c,
+	0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12,
+	0x21, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x6d, 0x65,
+	0x74, 0x61, 0x22, 0x28, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e,
+	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x55, 0x54, 0x10, 0x01,
+	0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x22, 0x5f, 0x0a, 0x0c,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x65, 0x65, 0x6b, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x73, 0x65, 0x65, 0x6b, 0x4b, 0x65, 0x79, 0x12,
+	0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x66, 0x0a,
+	0x0a, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x6f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x5f, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x0d, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x22, 0x8a, 0x01, 0x0a, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x12,
+	0x30, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x22, 0x99, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x70, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x67, 0x6d, 0x61,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x69, 0x67, 0x6d, 0x61, 0x22, 0xae, 0x01,
+	0x0a, 0x07, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x74,
+	0x75, 0x72, 0x6e, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0c, 0x69, 0x74,
+	0x65, 0x72, 0x5f, 0x6e, 0x6f, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x69, 0x74, 0x65, 0x72, 0x4e, 0x6f, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x24, 0x0a, 0x0e,
+	0x69, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x6f, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x74, 0x65, 0x72, 0x4e, 0x6f, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x71,
+	0x0a, 0x06, 0x4b, 0x56, 0x50, 0x61, 0x69, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x21,
+	0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x74,
+	0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x6d, 0x65, 0x74,
+	0x61, 0x22, 0xba, 0x01, 0x0a, 0x04, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x67, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69,
+	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x72, 0x74, 0x6c,
+	0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x22, 0x4d,
+	0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x32, 0xa7, 0x04,
+	0x0a, 0x04, 0x54, 0x72, 0x74, 0x6c, 0x12, 0x2f, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x13, 0x2e,
+	0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x11, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x12, 0x13,
+	0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75,
+	0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x12, 0x16, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x74, 0x72, 0x74,
+	0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x32, 0x0a, 0x04, 0x49, 0x74, 0x65, 0x72, 0x12, 0x14, 0x2e, 0x74, 0x72, 0x74,
+	0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x12, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x05, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12,
+	0x15, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x12,
+	0x35, 0x0a, 0x06, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x16, 0x2e, 0x74, 0x72, 0x74, 0x6c,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x0f, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x56, 0x50, 0x61,
+	0x69, 0x72, 0x22, 0x00, 0x30, 0x01, 0x12, 0x36, 0x0a, 0x04, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x14,
+	0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x79, 0x6e, 0x63, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x37,
+	0x0a, 0x05, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x15, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76,
+	0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13,
+	0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x35, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x15, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x37,
+	0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e,
+	0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x1a, 0x15,
+	0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x72, 0x69, 0x73, 0x61, 0x63, 0x72, 0x79, 0x70, 0x74,
+	0x6f, 0x2f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
+	0x74, 0x72, 0x74, 0x6c, 0x2f, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1893,80 +2119,89 @@ func file_trtl_v1_trtl_proto_rawDescGZIP() []byte {
 	return file_trtl_v1_trtl_proto_rawDescData
 }
 
-var file_trtl_v1_trtl_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_trtl_v1_trtl_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_trtl_v1_trtl_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_trtl_v1_trtl_proto_goTypes = []any{
-	(*GetRequest)(nil),       // 0: trtl.v1.GetRequest
-	(*GetReply)(nil),         // 1: trtl.v1.GetReply
-	(*PutRequest)(nil),       // 2: trtl.v1.PutRequest
-	(*PutReply)(nil),         // 3: trtl.v1.PutReply
-	(*DeleteRequest)(nil),    // 4: trtl.v1.DeleteRequest
-	(*DeleteReply)(nil),      // 5: trtl.v1.DeleteReply
-	(*IterRequest)(nil),      // 6: trtl.v1.IterRequest
-	(*IterReply)(nil),        // 7: trtl.v1.IterReply
-	(*BatchRequest)(nil),     // 8: trtl.v1.BatchRequest
-	(*BatchReply)(nil),       // 9: trtl.v1.BatchReply
-	(*CursorRequest)(nil),    // 10: trtl.v1.CursorRequest
-	(*SyncRequest)(nil),      // 11: trtl.v1.SyncRequest
-	(*SyncReply)(nil),        // 12: trtl.v1.SyncReply
-	(*CountRequest)(nil),     // 13: trtl.v1.CountRequest
-	(*CountReply)(nil),       // 14: trtl.v1.CountReply
-	(*HealthCheck)(nil),      // 15: trtl.v1.HealthCheck
-	(*ServerStatus)(nil),     // 16: trtl.v1.ServerStatus
-	(*ReplicaStatus)(nil),    // 17: trtl.v1.ReplicaStatus
-	(*Options)(nil),          // 18: trtl.v1.Options
-	(*KVPair)(nil),           // 19: trtl.v1.KVPair
-	(*Meta)(nil),             // 20: trtl.v1.Meta
-	(*Version)(nil),          // 21: trtl.v1.Version
-	(*BatchReply_Error)(nil), // 22: trtl.v1.BatchReply.Error
+	(WatchEvent_Type)(0),     // 0: trtl.v1.WatchEvent.Type
+	(*GetRequest)(nil),       // 1: trtl.v1.GetRequest
+	(*GetReply)(nil),         // 2: trtl.v1.GetReply
+	(*PutRequest)(nil),       // 3: trtl.v1.PutRequest
+	(*PutReply)(nil),         // 4: trtl.v1.PutReply
+	(*DeleteRequest)(nil),    // 5: trtl.v1.DeleteRequest
+	(*DeleteReply)(nil),      // 6: trtl.v1.DeleteReply
+	(*IterRequest)(nil),      // 7: trtl.v1.IterRequest
+	(*IterReply)(nil),        // 8: trtl.v1.IterReply
+	(*BatchRequest)(nil),     // 9: trtl.v1.BatchRequest
+	(*BatchReply)(nil),       // 10: trtl.v1.BatchReply
+	(*CursorRequest)(nil),    // 11: trtl.v1.CursorRequest
+	(*SyncRequest)(nil),      // 12: trtl.v1.SyncRequest
+	(*SyncReply)(nil),        // 13: trtl.v1.SyncReply
+	(*WatchRequest)(nil),     // 14: trtl.v1.WatchRequest
+	(*WatchEvent)(nil),       // 15: trtl.v1.WatchEvent
+	(*CountRequest)(nil),     // 16: trtl.v1.CountRequest
+	(*CountReply)(nil),       // 17: trtl.v1.CountReply
+	(*HealthCheck)(nil),      // 18: trtl.v1.HealthCheck
+	(*ServerStatus)(nil),     // 19: trtl.v1.ServerStatus
+	(*ReplicaStatus)(nil),    // 20: trtl.v1.ReplicaStatus
+	(*Options)(nil),          // 21: trtl.v1.Options
+	(*KVPair)(nil),           // 22: trtl.v1.KVPair
+	(*Meta)(nil),             // 23: trtl.v1.Meta
+	(*Version)(nil),          // 24: trtl.v1.Version
+	(*BatchReply_Error)(nil), // 25: trtl.v1.BatchReply.Error
 }
 var file_trtl_v1_trtl_proto_depIdxs = []int32{
-	18, // 0: trtl.v1.GetRequest.options:type_name -> trtl.v1.Options
-	20, // 1: trtl.v1.GetReply.meta:type_name -> trtl.v1.Meta
-	18, // 2: trtl.v1.PutRequest.options:type_name -> trtl.v1.Options
-	20, // 3: trtl.v1.PutReply.meta:type_name -> trtl.v1.Meta
-	18, // 4: trtl.v1.DeleteRequest.options:type_name -> trtl.v1.Options
-	20, // 5: trtl.v1.DeleteReply.meta:type_name -> trtl.v1.Meta
-	18, // 6: trtl.v1.IterRequest.options:type_name -> trtl.v1.Options
-	19, // 7: trtl.v1.IterReply.values:type_name -> trtl.v1.KVPair
-	2,  // 8: trtl.v1.BatchRequest.put:type_name -> trtl.v1.PutRequest
-	4,  // 9: trtl.v1.BatchRequest.delete:type_name -> trtl.v1.DeleteRequest
-	22, // 10: trtl.v1.BatchReply.errors:type_name -> trtl.v1.BatchReply.Error
-	18, // 11: trtl.v1.CursorRequest.options:type_name -> trtl.v1.Options
-	0,  // 12: trtl.v1.SyncRequest.get:type_name -> trtl.v1.GetRequest
-	2,  // 13: trtl.v1.SyncRequest.put:type_name -> trtl.v1.PutRequest
-	4,  // 14: trtl.v1.SyncRequest.delete:type_name -> trtl.v1.DeleteRequest
-	6,  // 15: trtl.v1.SyncRequest.iter:type_name -> trtl.v1.IterRequest
-	1,  // 16: trtl.v1.SyncReply.get:type_name -> trtl.v1.GetReply
-	3,  // 17: trtl.v1.SyncReply.put:type_name -> trtl.v1.PutReply
-	5,  // 18: trtl.v1.SyncReply.delete:type_name -> trtl.v1.DeleteReply
-	7,  // 19: trtl.v1.SyncReply.iter:type_name -> trtl.v1.IterReply
-	17, // 20: trtl.v1.ServerStatus.replica:type_name -> trtl.v1.ReplicaStatus
-	20, // 21: trtl.v1.KVPair.meta:type_name -> trtl.v1.Meta
-	21, // 22: trtl.v1.Meta.version:type_name -> trtl.v1.Version
-	21, // 23: trtl.v1.Meta.parent:type_name -> trtl.v1.Version
-	0,  // 24: trtl.v1.Trtl.Get:input_type -> trtl.v1.GetRequest
-	2,  // 25: trtl.v1.Trtl.Put:input_type -> trtl.v1.PutRequest
-	4,  // 26: trtl.v1.Trtl.Delete:input_type -> trtl.v1.DeleteRequest
-	6,  // 27: trtl.v1.Trtl.Iter:input_type -> trtl.v1.IterRequest
-	8,  // 28: trtl.v1.Trtl.Batch:input_type -> trtl.v1.BatchRequest
-	10, // 29: trtl.v1.Trtl.Cursor:input_type -> trtl.v1.CursorRequest
-	11, // 30: trtl.v1.Trtl.Sync:input_type -> trtl.v1.SyncRequest
-	13, // 31: trtl.v1.Trtl.Count:input_type -> trtl.v1.CountRequest
-	15, // 32: trtl.v1.Trtl.Status:input_type -> trtl.v1.HealthCheck
-	1,  // 33: trtl.v1.Trtl.Get:output_type -> trtl.v1.GetReply
-	3,  // 34: trtl.v1.Trtl.Put:output_type -> trtl.v1.PutReply
-	5,  // 35: trtl.v1.Trtl.Delete:output_type -> trtl.v1.DeleteReply
-	7,  // 36: trtl.v1.Trtl.Iter:output_type -> trtl.v1.IterReply
-	9,  // 37: trtl.v1.Trtl.Batch:output_type -> trtl.v1.BatchReply
-	19, // 38: trtl.v1.Trtl.Cursor:output_type -> trtl.v1.KVPair
-	12, // 39: trtl.v1.Trtl.Sync:output_type -> trtl.v1.SyncReply
-	14, // 40: trtl.v1.Trtl.Count:output_type -> trtl.v1.CountReply
-	16, // 41: trtl.v1.Trtl.Status:output_type -> trtl.v1.ServerStatus
-	33, // [33:42] is the sub-list for method output_type
-	24, // [24:33] is the sub-list for method input_type
-	24, // [24:24] is the sub-list for extension type_name
-	24, // [24:24] is the sub-list for extension extendee
-	0,  // [0:24] is the sub-list for field type_name
+	21, // 0: trtl.v1.GetRequest.options:type_name -> trtl.v1.Options
+	23, // 1: trtl.v1.GetReply.meta:type_name -> trtl.v1.Meta
+	21, // 2: trtl.v1.PutRequest.options:type_name -> trtl.v1.Options
+	23, // 3: trtl.v1.PutReply.meta:type_name -> trtl.v1.Meta
+	21, // 4: trtl.v1.DeleteRequest.options:type_name -> trtl.v1.Options
+	23, // 5: trtl.v1.DeleteReply.meta:type_name -> trtl.v1.Meta
+	21, // 6: trtl.v1.IterRequest.options:type_name -> trtl.v1.Options
+	22, // 7: trtl.v1.IterReply.values:type_name -> trtl.v1.KVPair
+	3,  // 8: trtl.v1.BatchRequest.put:type_name -> trtl.v1.PutRequest
+	5,  // 9: trtl.v1.BatchRequest.delete:type_name -> trtl.v1.DeleteRequest
+	25, // 10: trtl.v1.BatchReply.errors:type_name -> trtl.v1.BatchReply.Error
+	21, // 11: trtl.v1.CursorRequest.options:type_name -> trtl.v1.Options
+	1,  // 12: trtl.v1.SyncRequest.get:type_name -> trtl.v1.GetRequest
+	3,  // 13: trtl.v1.SyncRequest.put:type_name -> trtl.v1.PutRequest
+	5,  // 14: trtl.v1.SyncRequest.delete:type_name -> trtl.v1.DeleteRequest
+	7,  // 15: trtl.v1.SyncRequest.iter:type_name -> trtl.v1.IterRequest
+	2,  // 16: trtl.v1.SyncReply.get:type_name -> trtl.v1.GetReply
+	4,  // 17: trtl.v1.SyncReply.put:type_name -> trtl.v1.PutReply
+	6,  // 18: trtl.v1.SyncReply.delete:type_name -> trtl.v1.DeleteReply
+	8,  // 19: trtl.v1.SyncReply.iter:type_name -> trtl.v1.IterReply
+	21, // 20: trtl.v1.WatchRequest.options:type_name -> trtl.v1.Options
+	0,  // 21: trtl.v1.WatchEvent.type:type_name -> trtl.v1.WatchEvent.Type
+	23, // 22: trtl.v1.WatchEvent.meta:type_name -> trtl.v1.Meta
+	20, // 23: trtl.v1.ServerStatus.replica:type_name -> trtl.v1.ReplicaStatus
+	23, // 24: trtl.v1.KVPair.meta:type_name -> trtl.v1.Meta
+	24, // 25: trtl.v1.Meta.version:type_name -> trtl.v1.Version
+	24, // 26: trtl.v1.Meta.parent:type_name -> trtl.v1.Version
+	1,  // 27: trtl.v1.Trtl.Get:input_type -> trtl.v1.GetRequest
+	3,  // 28: trtl.v1.Trtl.Put:input_type -> trtl.v1.PutRequest
+	5,  // 29: trtl.v1.Trtl.Delete:input_type -> trtl.v1.DeleteRequest
+	7,  // 30: trtl.v1.Trtl.Iter:input_type -> trtl.v1.IterRequest
+	9,  // 31: trtl.v1.Trtl.Batch:input_type -> trtl.v1.BatchRequest
+	11, // 32: trtl.v1.Trtl.Cursor:input_type -> trtl.v1.CursorRequest
+	12, // 33: trtl.v1.Trtl.Sync:input_type -> trtl.v1.SyncRequest
+	14, // 34: trtl.v1.Trtl.Watch:input_type -> trtl.v1.WatchRequest
+	16, // 35: trtl.v1.Trtl.Count:input_type -> trtl.v1.CountRequest
+	18, // 36: trtl.v1.Trtl.Status:input_type -> trtl.v1.HealthCheck
+	2,  // 37: trtl.v1.Trtl.Get:output_type -> trtl.v1.GetReply
+	4,  // 38: trtl.v1.Trtl.Put:output_type -> trtl.v1.PutReply
+	6,  // 39: trtl.v1.Trtl.Delete:output_type -> trtl.v1.DeleteReply
+	8,  // 40: trtl.v1.Trtl.Iter:output_type -> trtl.v1.IterReply
+	10, // 41: trtl.v1.Trtl.Batch:output_type -> trtl.v1.BatchReply
+	22, // 42: trtl.v1.Trtl.Cursor:output_type -> trtl.v1.KVPair
+	13, // 43: trtl.v1.Trtl.Sync:output_type -> trtl.v1.SyncReply
+	15, // 44: trtl.v1.Trtl.Watch:output_type -> trtl.v1.WatchEvent
+	17, // 45: trtl.v1.Trtl.Count:output_type -> trtl.v1.CountReply
+	19, // 46: trtl.v1.Trtl.Status:output_type -> trtl.v1.ServerStatus
+	37, // [37:47] is the sub-list for method output_type
+	27, // [27:37] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_trtl_v1_trtl_proto_init() }
@@ -2132,7 +2367,7 @@ func file_trtl_v1_trtl_proto_init() {
 			}
 		}
 		file_trtl_v1_trtl_proto_msgTypes[13].Exporter = func(v any, i int) any {
-			switch v := v.(*CountRequest); i {
+			switch v := v.(*WatchRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2144,7 +2379,7 @@ func file_trtl_v1_trtl_proto_init() {
 			}
 		}
 		file_trtl_v1_trtl_proto_msgTypes[14].Exporter = func(v any, i int) any {
-			switch v := v.(*CountReply); i {
+			switch v := v.(*WatchEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2156,7 +2391,7 @@ func file_trtl_v1_trtl_proto_init() {
 			}
 		}
 		file_trtl_v1_trtl_proto_msgTypes[15].Exporter = func(v any, i int) any {
-			switch v := v.(*HealthCheck); i {
+			switch v := v.(*CountRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2168,7 +2403,7 @@ func file_trtl_v1_trtl_proto_init() {
 			}
 		}
 		file_trtl_v1_trtl_proto_msgTypes[16].Exporter = func(v any, i int) any {
-			switch v := v.(*ServerStatus); i {
+			switch v := v.(*CountReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2180,7 +2415,7 @@ func file_trtl_v1_trtl_proto_init() {
 			}
 		}
 		file_trtl_v1_trtl_proto_msgTypes[17].Exporter = func(v any, i int) any {
-			switch v := v.(*ReplicaStatus); i {
+			switch v := v.(*HealthCheck); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2192,7 +2427,7 @@ func file_trtl_v1_trtl_proto_init() {
 			}
 		}
 		file_trtl_v1_trtl_proto_msgTypes[18].Exporter = func(v any, i int) any {
-			switch v := v.(*Options); i {
+			switch v := v.(*ServerStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2204,7 +2439,7 @@ func file_trtl_v1_trtl_proto_init() {
 			}
 		}
 		file_trtl_v1_trtl_proto_msgTypes[19].Exporter = func(v any, i int) any {
-			switch v := v.(*KVPair); i {
+			switch v := v.(*ReplicaStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2216,7 +2451,7 @@ func file_trtl_v1_trtl_proto_init() {
 			}
 		}
 		file_trtl_v1_trtl_proto_msgTypes[20].Exporter = func(v any, i int) any {
-			switch v := v.(*Meta); i {
+			switch v := v.(*Options); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2228,7 +2463,7 @@ func file_trtl_v1_trtl_proto_init() {
 			}
 		}
 		file_trtl_v1_trtl_proto_msgTypes[21].Exporter = func(v any, i int) any {
-			switch v := v.(*Version); i {
+			switch v := v.(*KVPair); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2240,6 +2475,30 @@ func file_trtl_v1_trtl_proto_init() {
 			}
 		}
 		file_trtl_v1_trtl_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*Meta); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_trtl_v1_trtl_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*Version); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_trtl_v1_trtl_proto_msgTypes[24].Exporter = func(v any, i int) any {
 			switch v := v.(*BatchReply_Error); i {
 			case 0:
 				return &v.state
@@ -2273,13 +2532,14 @@ func file_trtl_v1_trtl_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_trtl_v1_trtl_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   23,
+			NumEnums:      1,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_trtl_v1_trtl_proto_goTypes,
 		DependencyIndexes: file_trtl_v1_trtl_proto_depIdxs,
+		EnumInfos:         file_trtl_v1_trtl_proto_enumTypes,
 		MessageInfos:      file_trtl_v1_trtl_proto_msgTypes,
 	}.Build()
 	File_trtl_v1_trtl_proto = out.File
@@ -26,6 +26,7 @@ const (
 	Trtl_Batch_FullMethodName  = "/trtl.v1.Trtl/Batch"
 	Trtl_Cursor_FullMethodName = "/trtl.v1.Trtl/Cursor"
 	Trtl_Sync_FullMethodName   = "/trtl.v1.Trtl/Sync"
+	Trtl_Watch_FullMethodName  = "/trtl.v1.Trtl/Watch"
 	Trtl_Count_FullMethodName  = "/trtl.v1.Trtl/Count"
 	Trtl_Status_FullMethodName = "/trtl.v1.Trtl/Status"
 )
@@ -47,7 +48,13 @@ type TrtlClient interface {
 	// Cursor is a server-side streaming request to iterate in a memory safe fashion.
 	Cursor(ctx context.Context, in *CursorRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[KVPair], error)
 	// Sync is a bi-directional streaming mechanism to issue access requests synchronously.
+	// Requests are pipelined on a single long-lived stream and replies are returned as
+	// soon as each operation completes, possibly out of order, correlated by the id.
 	Sync(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SyncRequest, SyncReply], error)
+	// Watch is a server-side streaming request that pushes every Put and Delete in a
+	// namespace (optionally restricted to a key prefix) to the subscriber as it happens,
+	// including changes that are replicated from remote peers.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchEvent], error)
 	// Count the number of objects currently stored in the database
 	Count(ctx context.Context, in *CountRequest, opts ...grpc.CallOption) (*CountReply, error)
 	// This RPC servers as a health check for clients to make sure the server is online.
@@ -147,6 +154,25 @@ func (c *trtlClient) Sync(ctx context.Context, opts ...grpc.CallOption) (grpc.Bi
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Trtl_SyncClient = grpc.BidiStreamingClient[SyncRequest, SyncReply]
 
+func (c *trtlClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Trtl_ServiceDesc.Streams[3], Trtl_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, WatchEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Trtl_WatchClient = grpc.ServerStreamingClient[WatchEvent]
+
 func (c *trtlClient) Count(ctx context.Context, in *CountRequest, opts ...grpc.CallOption) (*CountReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CountReply)
@@ -184,7 +210,13 @@ type TrtlServer interface {
 	// Cursor is a server-side streaming request to iterate in a memory safe fashion.
 	Cursor(*CursorRequest, grpc.ServerStreamingServer[KVPair]) error
 	// Sync is a bi-directional streaming mechanism to issue access requests synchronously.
+	// Requests are pipelined on a single long-lived stream and replies are returned as
+	// soon as each operation completes, possibly out of order, correlated by the id.
 	Sync(grpc.BidiStreamingServer[SyncRequest, SyncReply]) error
+	// Watch is a server-side streaming request that pushes every Put and Delete in a
+	// namespace (optionally restricted to a key prefix) to the subscriber as it happens,
+	// including changes that are replicated from remote peers.
+	Watch(*WatchRequest, grpc.ServerStreamingServer[WatchEvent]) error
 	// Count the number of objects currently stored in the database
 	Count(context.Context, *CountRequest) (*CountReply, error)
 	// This RPC servers as a health check for clients to make sure the server is online.
@@ -220,6 +252,9 @@ func (UnimplementedTrtlServer) Cursor(*CursorRequest, grpc.ServerStreamingServer
 func (UnimplementedTrtlServer) Sync(grpc.BidiStreamingServer[SyncRequest, SyncReply]) error {
 	return status.Errorf(codes.Unimplemented, "method Sync not implemented")
 }
+func (UnimplementedTrtlServer) Watch(*WatchRequest, grpc.ServerStreamingServer[WatchEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
 func (UnimplementedTrtlServer) Count(context.Context, *CountRequest) (*CountReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Count not implemented")
 }
@@ -344,6 +379,17 @@ func _Trtl_Sync_Handler(srv interface{}, stream grpc.ServerStream) error {
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Trtl_SyncServer = grpc.BidiStreamingServer[SyncRequest, SyncReply]
 
+func _Trtl_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TrtlServer).Watch(m, &grpc.GenericServerStream[WatchRequest, WatchEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Trtl_WatchServer = grpc.ServerStreamingServer[WatchEvent]
+
 func _Trtl_Count_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CountRequest)
 	if err := dec(in); err != nil {
@@ -429,6 +475,11 @@ var Trtl_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Trtl_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "trtl/v1/trtl.proto",
 }
@@ -33,6 +33,7 @@ type Service struct {
 	aestop               chan struct{}
 	synchronized         time.Time
 	replicatedNamespaces []string
+	observer             func(*object.Object)
 }
 
 // New creates a new replica.Service that is completely decoupled from the trtl.Server.
@@ -52,6 +53,25 @@ func New(conf config.Config, db *honu.DB, replicatedNamespaces []string) (*Servi
 	}, nil
 }
 
+// Observe registers a callback that is invoked with every object that is written to
+// the database by replication, e.g. so that changes from remote peers can be published
+// to watchers. Observe must be called before anti-entropy or the server is started.
+func (r *Service) Observe(observer func(*object.Object)) {
+	r.observer = observer
+}
+
+// notify the observer if the update from a remote replica was written to disk.
+func (r *Service) notify(updateType honu.UpdateType, obj *object.Object) {
+	if r.observer == nil {
+		return
+	}
+
+	switch updateType {
+	case honu.UpdateForced, honu.UpdateLinear, honu.UpdateStomp:
+		r.observer(obj)
+	}
+}
+
 // Shutdown the replica server (stops the anti-entropy go-routine)
 func (r *Service) Shutdown() error {
 	// If anti-entropy is enabled, send a stop signal to it. Do not send the signal if
@@ -301,6 +321,7 @@ gossip:
 				continue gossip
 			}
 			atomic.AddUint64(&repairs, 1)
+			r.notify(updateType, sync.Object)
 
 			// Log update type in prometheus metrics.
 			switch updateType {
@@ -474,6 +474,8 @@ gossip:
 					Msg("could not update object from remote peer")
 				continue gossip
 			}
+			r.notify(updateType, sync.Object)
+
 			// Log update type in prometheus metrics.
 			switch updateType {
 			case honu.UpdateStomp:
@@ -140,6 +140,9 @@ func New(conf config.Config) (s *Server, err error) {
 	}
 	replication.RegisterReplicationServer(s.srv, s.replica)
 
+	// Publish changes that are replicated from remote peers to watchers
+	s.replica.Observe(s.trtl.watchers.Publish)
+
 	// Initialize Metrics service for Prometheus
 	if s.metrics, err = prom.New(conf.Metrics); err != nil {
 		return nil, err
@@ -217,6 +220,7 @@ func (t *Server) Run(sock net.Listener) {
 func (t *Server) Shutdown() (err error) {
 	errs := make([]error, 0)
 	log.Info().Msg("gracefully shutting down trtl server")
+	t.trtl.Shutdown()
 	t.srv.GracefulStop()
 
 	// Shutdown the backup manager
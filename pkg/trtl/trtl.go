@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/rotationalio/honu"
@@ -20,24 +22,41 @@ import (
 	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
 	"github.com/trisacrypto/directory/pkg/utils/sentry"
 	codes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	status "google.golang.org/grpc/status"
 )
 
 // A TrtlService implements the RPCs for interacting with a Honu database.
 type TrtlService struct {
 	pb.UnimplementedTrtlServer
-	parent *Server
-	db     *honu.DB
+	parent   *Server
+	db       *honu.DB
+	watchers *Watchers
+	done     chan struct{}
+	stop     sync.Once
 }
 
 func NewTrtlService(s *Server) (*TrtlService, error) {
-	return &TrtlService{parent: s, db: s.db}, nil
+	return &TrtlService{parent: s, db: s.db, watchers: NewWatchers(), done: make(chan struct{})}, nil
+}
+
+// Shutdown terminates the long-lived Sync and Watch streams so that the gRPC server
+// can be gracefully stopped without waiting for clients to close their streams.
+func (h *TrtlService) Shutdown() {
+	h.stop.Do(func() {
+		close(h.done)
+		h.watchers.Close()
+	})
 }
 
 const (
 	defaultPageSize = 100
+	maxSyncInflight = 64
 )
 
+// errShutdown is used internally to terminate long-lived streams on shutdown.
+var errShutdown = errors.New("trtl service is shutting down")
+
 // b64e encodes []byte keys and values as base64 encoded strings suitable for logging.
 var b64e = base64.RawURLEncoding.EncodeToString
 
@@ -144,6 +163,9 @@ func (h *TrtlService) Put(ctx context.Context, in *pb.PutRequest) (out *pb.PutRe
 	metrics.PmTrtlBytesWritten.WithLabelValues(in.Namespace).Add(float64(len(in.Value)))
 	metrics.PmObjectSize.WithLabelValues(in.Namespace).Observe(float64(len(in.Value)))
 
+	// Notify any watchers of the change
+	h.watchers.Publish(object)
+
 	out = &pb.PutReply{Success: true}
 	if in.Options != nil && in.Options.ReturnMeta {
 		out.Meta = returnMeta(object)
@@ -186,6 +208,9 @@ func (h *TrtlService) Delete(ctx context.Context, in *pb.DeleteRequest) (out *pb
 	// NOTE: the number of bytes written for the tombstone cannot be updated here since that data is in honu.
 	metrics.PmTrtlWrites.WithLabelValues(in.Namespace).Inc()
 
+	// Notify any watchers of the change
+	h.watchers.Publish(object)
+
 	out = &pb.DeleteReply{Success: true}
 	if in.Options != nil && in.Options.ReturnMeta {
 		out.Meta = returnMeta(object)
@@ -382,80 +407,179 @@ func (h *TrtlService) Iter(ctx context.Context, in *pb.IterRequest) (out *pb.Ite
 	return out, nil
 }
 
-// Batch is a client-side streaming request to issue multiple commands, usually Put and Delete.
-// TODO: should we track individual Put and Delete commands?
-// TODO: this method is not fully implemented yet.
-func (h *TrtlService) Batch(stream pb.Trtl_BatchServer) error {
-	msgs := 0
+// Batch is a client-side streaming request to issue multiple Put and Delete commands.
+// All of the requests are received and validated before any of them are applied, then
+// the requests are applied one at a time in order. Deleting a key that does not exist
+// is not an error so that the batch is not aborted. Watchers are only notified of the
+// changes once the entire batch has been applied.
+//
+// NOTE: the batch is not a transaction. If a request fails, the requests that were
+// already applied are undone with compensating writes that restore the previous values
+// of their keys; the compensating writes are new versions that are replicated like any
+// other write, and a failed compensating write is only logged. The batch is not
+// isolated: other readers see the partially applied batch, and concurrent writes to the
+// same keys may be overwritten by the rollback. If the process crashes while the batch
+// is being applied, the requests that were applied are not rolled back and the batch
+// is left partially written, so callers must be able to recover from a partial batch.
+func (h *TrtlService) Batch(stream pb.Trtl_BatchServer) (err error) {
+	ctx := stream.Context()
 	log.Debug().Msg("starting trtl Batch stream")
-	defer log.Debug().Int("msgs", msgs).Msg("trtl Batch stream closed")
 
+	// Read and validate all of the requests from the stream before applying them.
 	out := &pb.BatchReply{}
+	requests := make([]*pb.BatchRequest, 0)
 	for {
-		// Read the next request from the stream.
-		in, err := stream.Recv()
-		if err == io.EOF {
-			return stream.SendAndClose(out)
-		}
-		if err != nil {
+		var in *pb.BatchRequest
+		if in, err = stream.Recv(); err != nil {
+			if err == io.EOF {
+				break
+			}
 			return status.Error(codes.Internal, err.Error())
 		}
 
 		out.Operations++
-		if in.Request == nil {
-			out.Failed++
-			out.Errors = append(out.Errors, &pb.BatchReply_Error{
-				Id:    in.Id,
-				Error: "missing request field",
-			})
-			continue
+		requests = append(requests, in)
+		if msg := validateBatchRequest(in); msg != "" {
+			out.Errors = append(out.Errors, &pb.BatchReply_Error{Id: in.Id, Error: msg})
 		}
+	}
 
-		// Process the request.
-		switch in.Request.(type) {
-		case *pb.BatchRequest_Put:
-			var reply *pb.PutReply
-			if reply, err = h.Put(stream.Context(), in.GetPut()); err != nil || !reply.Success {
-				out.Failed++
-				var errMsg string
-				if err != nil {
-					errMsg = err.Error()
-				} else {
-					errMsg = "unexpected Put error"
-				}
-				out.Errors = append(out.Errors, &pb.BatchReply_Error{
-					Id:    in.Id,
-					Error: errMsg,
-				})
-				continue
-			}
-		case *pb.BatchRequest_Delete:
-			var reply *pb.DeleteReply
-			if reply, err = h.Delete(stream.Context(), in.GetDelete()); err != nil || !reply.Success {
-				out.Failed++
-				var errMsg string
-				if err != nil {
-					errMsg = err.Error()
-				} else {
-					errMsg = "unexpected Delete error"
-				}
-				out.Errors = append(out.Errors, &pb.BatchReply_Error{
-					Id:    in.Id,
-					Error: errMsg,
-				})
-				continue
-			}
-		default:
-			out.Failed++
-			out.Errors = append(out.Errors, &pb.BatchReply_Error{
-				Id:    in.Id,
-				Error: "unknown request type",
-			})
-			continue
+	// If any request is invalid, none of the requests are applied.
+	if len(out.Errors) > 0 {
+		out.Failed = out.Operations
+		log.Debug().Int64("operations", out.Operations).Int("invalid", len(out.Errors)).Msg("trtl Batch rejected")
+		return stream.SendAndClose(out)
+	}
+
+	// Apply the requests in order, keeping track of the previous values for rollback.
+	applied := make([]*batchUndo, 0, len(requests))
+	objects := make([]*object.Object, 0, len(requests))
+	for _, in := range requests {
+		var (
+			undo *batchUndo
+			obj  *object.Object
+		)
+
+		if undo, obj, err = h.applyBatchRequest(in); err != nil {
+			sentry.Error(ctx).Err(err).Int64("id", in.Id).Msg("could not apply batch request, rolling back")
+			h.rollbackBatch(ctx, applied)
+
+			out.Failed = out.Operations
+			out.Errors = append(out.Errors, &pb.BatchReply_Error{Id: in.Id, Error: err.Error()})
+			return stream.SendAndClose(out)
+		}
+
+		applied = append(applied, undo)
+		if obj != nil {
+			objects = append(objects, obj)
 		}
+	}
 
-		// Each case continues on failure so if we get here, the request was successful.
-		out.Successful++
+	// Notify any watchers of the changes once the batch is complete
+	for _, obj := range objects {
+		metrics.PmTrtlWrites.WithLabelValues(obj.Namespace).Inc()
+		h.watchers.Publish(obj)
+	}
+
+	out.Successful = out.Operations
+	log.Debug().Int64("operations", out.Operations).Msg("trtl Batch applied")
+	return stream.SendAndClose(out)
+}
+
+// batchUndo stores the previous value of a key that was modified by a batch request so
+// that the request can be rolled back. If the value is nil the key did not exist.
+type batchUndo struct {
+	key       []byte
+	namespace string
+	value     []byte
+}
+
+// Returns a message describing why the batch request is invalid or an empty string.
+func validateBatchRequest(in *pb.BatchRequest) string {
+	var (
+		namespace string
+		key       []byte
+	)
+
+	switch req := in.Request.(type) {
+	case *pb.BatchRequest_Put:
+		if len(req.Put.Value) == 0 {
+			return "value must be provided in Put request"
+		}
+		namespace, key = req.Put.Namespace, req.Put.Key
+	case *pb.BatchRequest_Delete:
+		namespace, key = req.Delete.Namespace, req.Delete.Key
+	case nil:
+		return "missing request field"
+	default:
+		return "unknown request type"
+	}
+
+	if _, found := reservedNamespaces[namespace]; found {
+		return "cannot use reserved namespace"
+	}
+	if len(key) == 0 {
+		return "key must be provided in batch request"
+	}
+	return ""
+}
+
+// Applies a validated batch request, returning the previous value of the key so that
+// the request can be undone and the object that was written, if any.
+func (h *TrtlService) applyBatchRequest(in *pb.BatchRequest) (undo *batchUndo, obj *object.Object, err error) {
+	undo = &batchUndo{}
+	switch req := in.Request.(type) {
+	case *pb.BatchRequest_Put:
+		undo.key, undo.namespace = req.Put.Key, req.Put.Namespace
+	case *pb.BatchRequest_Delete:
+		undo.key, undo.namespace = req.Delete.Key, req.Delete.Namespace
+	}
+
+	if undo.value, err = h.db.Get(undo.key, options.WithNamespace(undo.namespace)); err != nil {
+		if !errors.Is(err, engine.ErrNotFound) {
+			return nil, nil, err
+		}
+		undo.value = nil
+	}
+
+	switch req := in.Request.(type) {
+	case *pb.BatchRequest_Put:
+		if obj, err = h.db.Put(req.Put.Key, req.Put.Value, options.WithNamespace(req.Put.Namespace)); err != nil {
+			return nil, nil, err
+		}
+		metrics.PmTrtlBytesWritten.WithLabelValues(req.Put.Namespace).Add(float64(len(req.Put.Value)))
+		metrics.PmObjectSize.WithLabelValues(req.Put.Namespace).Observe(float64(len(req.Put.Value)))
+	case *pb.BatchRequest_Delete:
+		// Deleting a key that does not exist is a no-op
+		if undo.value == nil {
+			return undo, nil, nil
+		}
+
+		if obj, err = h.db.Delete(req.Delete.Key, options.WithNamespace(req.Delete.Namespace)); err != nil {
+			return nil, nil, err
+		}
+	}
+	return undo, obj, nil
+}
+
+// Restores the previous values of the applied batch requests in reverse order.
+func (h *TrtlService) rollbackBatch(ctx context.Context, applied []*batchUndo) {
+	for i := len(applied) - 1; i >= 0; i-- {
+		var (
+			err  error
+			undo = applied[i]
+		)
+
+		if undo.value != nil {
+			_, err = h.db.Put(undo.key, undo.value, options.WithNamespace(undo.namespace))
+		} else {
+			_, err = h.db.Delete(undo.key, options.WithNamespace(undo.namespace))
+		}
+
+		if err != nil && !errors.Is(err, engine.ErrNotFound) {
+			// NOTE: if this error is triggered the database may be inconsistent
+			sentry.Error(ctx).Err(err).Str("namespace", undo.namespace).Bytes("key", undo.key).Msg("could not roll back batch request")
+		}
 	}
 }
 
@@ -607,8 +731,251 @@ func (h *TrtlService) Cursor(in *pb.CursorRequest, stream pb.Trtl_CursorServer)
 	return nil
 }
 
+// Sync is a bidirectional streaming request that multiplexes Get, Put, Delete, and Iter
+// operations onto a single long-lived stream to avoid a connection round trip per
+// access. Requests are pipelined: each request is handled concurrently as soon as it is
+// received and its reply is sent as soon as the operation completes, which means that
+// replies may be returned out of order and must be correlated by the request id.
+// Clients that require ordering between operations must wait for the reply of the
+// first operation before sending the next.
+//
+// Errors are reported per operation in the reply rather than terminating the stream;
+// the error message and gRPC status code are the same as the equivalent unary RPC. The
+// number of in-flight operations is bounded; when the bound is reached the server stops
+// reading from the stream until an operation completes, applying backpressure to the
+// client via the underlying HTTP/2 flow control.
 func (h *TrtlService) Sync(stream pb.Trtl_SyncServer) (err error) {
-	return status.Error(codes.Unimplemented, "not implemented")
+	ctx := stream.Context()
+
+	// Individual operations may be in different namespaces, so remove the shared
+	// namespace from the context to prevent concurrent operations from racing to
+	// update it. The stream is monitored without a namespace.
+	opctx := context.WithValue(ctx, metrics.NamespaceKey, nil)
+
+	var nOps uint64
+	log.Debug().Msg("starting trtl Sync stream")
+	defer func() {
+		log.Debug().Uint64("operations", nOps).Msg("trtl Sync stream closed")
+	}()
+
+	// gRPC streams are not safe for concurrent sends, so all replies are serialized
+	// through a single sender go routine. If sending fails the sender continues to
+	// drain the replies channel so that no operation handler blocks forever.
+	replies := make(chan *pb.SyncReply, maxSyncInflight)
+	sendErr := make(chan error, 1)
+	go func() {
+		var failed error
+		for reply := range replies {
+			if failed != nil {
+				continue
+			}
+			failed = stream.Send(reply)
+		}
+		sendErr <- failed
+	}()
+
+	// Receive requests in a separate go routine so that the stream can be terminated
+	// when the server shuts down, even while waiting for the client to send.
+	requests := make(chan *pb.SyncRequest)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			in, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+
+			select {
+			case requests <- in:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// The inflight channel is a semaphore that bounds the number of concurrent
+	// operations being handled on the stream.
+	var wg sync.WaitGroup
+	inflight := make(chan struct{}, maxSyncInflight)
+
+recv:
+	for {
+		var in *pb.SyncRequest
+		select {
+		case in = <-requests:
+		case err = <-recvErr:
+			break recv
+		case <-h.done:
+			err = errShutdown
+			break recv
+		}
+
+		select {
+		case inflight <- struct{}{}:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break recv
+		case <-h.done:
+			err = errShutdown
+			break recv
+		}
+
+		nOps++
+		wg.Add(1)
+		go func(in *pb.SyncRequest) {
+			defer wg.Done()
+			defer func() { <-inflight }()
+			replies <- h.syncOperation(opctx, in)
+		}(in)
+	}
+
+	// Wait for all in-flight operations to complete and their replies to be sent.
+	wg.Wait()
+	close(replies)
+	serr := <-sendErr
+
+	if err == errShutdown {
+		log.Debug().Uint64("operations", nOps).Msg("sync stream terminated by server shutdown")
+		return status.Error(codes.Unavailable, "server is shutting down")
+	}
+
+	// The client closing the send side of the stream is a normal termination.
+	if err != nil && err != io.EOF {
+		// Downgrading to a debug message since this occurs relatively frequently
+		log.Debug().Err(err).Msg("sync stream terminated with error")
+		if status.Code(err) == codes.Canceled || err == context.Canceled {
+			return status.Errorf(codes.Canceled, "sync canceled by client: %s", err)
+		}
+		return status.Errorf(codes.Aborted, "sync stream aborted: %s", err)
+	}
+
+	if serr != nil {
+		log.Debug().Err(serr).Msg("could not send sync reply")
+		return status.Errorf(codes.Aborted, "send error occurred: %s", serr)
+	}
+
+	log.Info().Uint64("operations", nOps).Msg("sync request complete")
+	return nil
+}
+
+// syncOperation handles a single request received on a Sync stream by dispatching it
+// to the equivalent unary RPC handler and wrapping the result in a SyncReply. Errors are
+// converted into the reply so that a single failed operation does not end the stream.
+func (h *TrtlService) syncOperation(ctx context.Context, in *pb.SyncRequest) (out *pb.SyncReply) {
+	var err error
+	out = &pb.SyncReply{Id: in.Id}
+
+	switch req := in.Request.(type) {
+	case *pb.SyncRequest_Get:
+		var rep *pb.GetReply
+		if rep, err = h.Get(ctx, req.Get); err == nil {
+			out.Reply = &pb.SyncReply_Get{Get: rep}
+		}
+	case *pb.SyncRequest_Put:
+		var rep *pb.PutReply
+		if rep, err = h.Put(ctx, req.Put); err == nil {
+			out.Reply = &pb.SyncReply_Put{Put: rep}
+		}
+	case *pb.SyncRequest_Delete:
+		var rep *pb.DeleteReply
+		if rep, err = h.Delete(ctx, req.Delete); err == nil {
+			out.Reply = &pb.SyncReply_Delete{Delete: rep}
+		}
+	case *pb.SyncRequest_Iter:
+		var rep *pb.IterReply
+		if rep, err = h.Iter(ctx, req.Iter); err == nil {
+			out.Reply = &pb.SyncReply_Iter{Iter: rep}
+		}
+	case nil:
+		err = status.Error(codes.InvalidArgument, "missing request field")
+	default:
+		err = status.Error(codes.InvalidArgument, "unknown request type")
+	}
+
+	if err != nil {
+		serr := status.Convert(err)
+		out.Code = uint32(serr.Code())
+		out.Error = serr.Message()
+		return out
+	}
+
+	out.Success = true
+	return out
+}
+
+// Watch is a server-side streaming request that pushes a change event for every Put and
+// Delete of a key in the requested namespace (and with the requested prefix, if any) to
+// the client as the change is written, including changes that are replicated from
+// remote peers. Each event includes the version metadata of the object so that the
+// client can order events and detect duplicates. The stream runs until the client
+// cancels it or the server shuts down.
+//
+// Watch does not send the current state of the namespace; clients that need a complete
+// view should start watching and then use Cursor to load the current state, applying
+// events whose version is later than the version of the loaded object. If the client
+// cannot keep up with the rate of changes the stream is terminated with a
+// ResourceExhausted error and the client must resynchronize. The response headers are
+// sent once the subscription is active so clients can wait on them before writing.
+//
+// The iter_no_values option omits the value from put events to reduce data transfer.
+func (h *TrtlService) Watch(in *pb.WatchRequest, stream pb.Trtl_WatchServer) (err error) {
+	// Fetch the stream context
+	ctx := stream.Context()
+
+	// Update namespace for monitoring purposes
+	metrics.UpdateNamespace(ctx, in.Namespace)
+
+	// Ensure the namespace is not reserved
+	if _, found := reservedNamespaces[in.Namespace]; found {
+		sentry.Warn(ctx).Str("namespace", in.Namespace).Msg("cannot use reserved namespace")
+		return status.Error(codes.PermissionDenied, "cannot use reserved namespace")
+	}
+
+	// Objects written without a namespace are stored in the default namespace.
+	namespace := in.Namespace
+	if namespace == "" {
+		namespace = NamespaceDefault
+	}
+
+	noValues := in.Options != nil && in.Options.IterNoValues
+	id, events := h.watchers.Subscribe(namespace, in.Prefix, noValues)
+	defer h.watchers.Unsubscribe(id)
+	log.Debug().Str("namespace", namespace).Msg("trtl Watch")
+
+	// Send the headers so that the client knows the subscription is active and that
+	// all changes from this point forward will be sent on the stream.
+	if err = stream.SendHeader(metadata.MD{}); err != nil {
+		log.Debug().Err(err).Msg("could not send watch headers")
+		return status.Errorf(codes.Aborted, "send error occurred: %s", err)
+	}
+
+	var nEvents uint64
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().
+				Str("namespace", namespace).
+				Uint64("count", nEvents).
+				Msg("watch request canceled by client")
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				if h.watchers.Overflowed(id) {
+					log.Warn().Str("namespace", namespace).Uint64("count", nEvents).Msg("watcher fell behind")
+					return status.Error(codes.ResourceExhausted, "watcher could not keep up with changes, resynchronize and watch again")
+				}
+				return status.Error(codes.Unavailable, "server is shutting down")
+			}
+
+			if err = stream.Send(event); err != nil {
+				// Downgrading to a debug message since this occurs relatively frequently.
+				log.Debug().Err(err).Msg("could not send watch event")
+				return status.Errorf(codes.Aborted, "send error occurred: %s", err)
+			}
+			nEvents++
+		}
+	}
 }
 
 func (h *TrtlService) Count(ctx context.Context, in *pb.CountRequest) (out *pb.CountReply, err error) {
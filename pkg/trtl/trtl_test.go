@@ -358,6 +358,71 @@ func (s *trtlTestSuite) TestBatch() {
 	require.Len(reply.Errors, len(requests))
 	require.Contains(requests, reply.Errors[1].Id)
 	require.Equal(requests[reply.Errors[1].Id].Id, reply.Errors[1].Id)
+
+	// A valid batch should apply all of the requests
+	batch := []*pb.BatchRequest{
+		{Id: 1, Request: &pb.BatchRequest_Put{Put: &pb.PutRequest{Key: []byte("batch1"), Namespace: "people", Value: []byte("alpha")}}},
+		{Id: 2, Request: &pb.BatchRequest_Put{Put: &pb.PutRequest{Key: []byte("batch2"), Namespace: "places", Value: []byte("bravo")}}},
+		{Id: 3, Request: &pb.BatchRequest_Put{Put: &pb.PutRequest{Key: []byte("batch1"), Namespace: "people", Value: []byte("charlie")}}},
+		{Id: 4, Request: &pb.BatchRequest_Delete{Delete: &pb.DeleteRequest{Key: []byte("missing"), Namespace: "people"}}},
+	}
+	reply, err = s.sendBatch(client, batch)
+	require.NoError(err)
+	require.Equal(int64(4), reply.Operations)
+	require.Equal(int64(4), reply.Successful)
+	require.Zero(reply.Failed)
+	require.Empty(reply.Errors)
+
+	get, err := client.Get(ctx, &pb.GetRequest{Key: []byte("batch1"), Namespace: "people"})
+	require.NoError(err)
+	require.Equal([]byte("charlie"), get.Value)
+
+	get, err = client.Get(ctx, &pb.GetRequest{Key: []byte("batch2"), Namespace: "places"})
+	require.NoError(err)
+	require.Equal([]byte("bravo"), get.Value)
+
+	// If any request in the batch is invalid, none of the requests should be applied
+	batch = []*pb.BatchRequest{
+		{Id: 1, Request: &pb.BatchRequest_Put{Put: &pb.PutRequest{Key: []byte("batch1"), Namespace: "people", Value: []byte("delta")}}},
+		{Id: 2, Request: &pb.BatchRequest_Delete{Delete: &pb.DeleteRequest{Key: []byte("batch2"), Namespace: "places"}}},
+		{Id: 3, Request: &pb.BatchRequest_Put{Put: &pb.PutRequest{Key: []byte("batch3"), Namespace: "people"}}},
+	}
+	reply, err = s.sendBatch(client, batch)
+	require.NoError(err)
+	require.Equal(int64(3), reply.Operations)
+	require.Equal(int64(3), reply.Failed)
+	require.Zero(reply.Successful)
+	require.Len(reply.Errors, 1)
+	require.Equal(int64(3), reply.Errors[0].Id)
+	require.Equal("value must be provided in Put request", reply.Errors[0].Error)
+
+	get, err = client.Get(ctx, &pb.GetRequest{Key: []byte("batch1"), Namespace: "people"})
+	require.NoError(err)
+	require.Equal([]byte("charlie"), get.Value)
+
+	_, err = client.Get(ctx, &pb.GetRequest{Key: []byte("batch2"), Namespace: "places"})
+	require.NoError(err, "batch2 should not have been deleted")
+
+	// Clean up the keys written by the batch
+	for _, key := range []struct{ ns, key string }{{"people", "batch1"}, {"places", "batch2"}} {
+		_, err = client.Delete(ctx, &pb.DeleteRequest{Key: []byte(key.key), Namespace: key.ns})
+		require.NoError(err)
+	}
+}
+
+// sendBatch streams the requests to the Batch RPC and returns the reply.
+func (s *trtlTestSuite) sendBatch(client pb.TrtlClient, requests []*pb.BatchRequest) (_ *pb.BatchReply, err error) {
+	var stream pb.Trtl_BatchClient
+	if stream, err = client.Batch(context.Background()); err != nil {
+		return nil, err
+	}
+
+	for _, r := range requests {
+		if err = stream.Send(r); err != nil {
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
 }
 
 func (s *trtlTestSuite) TestIter() {
@@ -664,6 +729,146 @@ func (s *trtlTestSuite) TestCursor() {
 	require.Equal(2, i, "expected 3 results returned after seek, have fixtures changed?")
 }
 
+// Test that the Sync RPC pipelines operations and correlates replies by id.
+func (s *trtlTestSuite) TestSync() {
+	require := s.Require()
+	ctx := context.Background()
+
+	// Start the gRPC client.
+	require.NoError(s.grpc.Connect(ctx))
+	defer s.grpc.Close()
+	client := pb.NewTrtlClient(s.grpc.Conn)
+
+	stream, err := client.Sync(ctx)
+	require.NoError(err, "could not create sync stream")
+
+	// Put a value and wait for the reply since operations on the stream are not ordered.
+	require.NoError(stream.Send(&pb.SyncRequest{Id: 1, Request: &pb.SyncRequest_Put{Put: &pb.PutRequest{Namespace: "sync", Key: []byte("foo"), Value: []byte("bar")}}}))
+	rep, err := stream.Recv()
+	require.NoError(err)
+	require.Equal(int64(1), rep.Id)
+	require.True(rep.Success)
+	require.True(rep.GetPut().Success)
+
+	// Pipeline a series of requests, including requests that should fail.
+	requests := []*pb.SyncRequest{
+		{Id: 2, Request: &pb.SyncRequest_Get{Get: &pb.GetRequest{Namespace: "sync", Key: []byte("foo"), Options: &pb.Options{ReturnMeta: true}}}},
+		{Id: 3, Request: &pb.SyncRequest_Get{Get: &pb.GetRequest{Namespace: "sync", Key: []byte("foo")}}},
+		{Id: 4, Request: &pb.SyncRequest_Get{Get: &pb.GetRequest{Namespace: "sync", Key: []byte("missing")}}},
+		{Id: 5, Request: &pb.SyncRequest_Put{Put: &pb.PutRequest{Namespace: "default", Key: []byte("foo"), Value: []byte("bar")}}},
+		{Id: 6, Request: &pb.SyncRequest_Iter{Iter: &pb.IterRequest{Namespace: "sync"}}},
+		{Id: 7, Request: &pb.SyncRequest_Delete{Delete: &pb.DeleteRequest{Namespace: "sync", Key: []byte("missing")}}},
+		{Id: 8},
+	}
+	for _, req := range requests {
+		require.NoError(stream.Send(req))
+	}
+	require.NoError(stream.CloseSend())
+
+	replies := make(map[int64]*pb.SyncReply)
+	for {
+		rep, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		require.NotContains(replies, rep.Id, "received duplicate reply")
+		replies[rep.Id] = rep
+	}
+	require.Len(replies, len(requests))
+
+	require.True(replies[2].Success)
+	require.Equal([]byte("bar"), replies[2].GetGet().Value)
+	require.Equal([]byte("foo"), replies[2].GetGet().Meta.Key)
+
+	require.True(replies[3].Success)
+	require.Equal([]byte("bar"), replies[3].GetGet().Value)
+	require.Nil(replies[3].GetGet().Meta)
+
+	require.False(replies[4].Success)
+	require.Equal(uint32(codes.NotFound), replies[4].Code)
+	require.Nil(replies[4].Reply)
+
+	require.False(replies[5].Success)
+	require.Equal(uint32(codes.PermissionDenied), replies[5].Code)
+	require.Equal("cannot use reserved namespace", replies[5].Error)
+
+	require.True(replies[6].Success)
+	require.Len(replies[6].GetIter().Values, 1)
+	require.Equal([]byte("foo"), replies[6].GetIter().Values[0].Key)
+
+	require.False(replies[7].Success)
+	require.Equal(uint32(codes.NotFound), replies[7].Code)
+
+	require.False(replies[8].Success)
+	require.Equal(uint32(codes.InvalidArgument), replies[8].Code)
+	require.Equal("missing request field", replies[8].Error)
+}
+
+// Test that the Watch RPC streams puts and deletes in the watched namespace and prefix.
+func (s *trtlTestSuite) TestWatch() {
+	require := s.Require()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Start the gRPC client.
+	require.NoError(s.grpc.Connect(ctx))
+	defer s.grpc.Close()
+	client := pb.NewTrtlClient(s.grpc.Conn)
+
+	// Test cannot use reserved namespace
+	stream, err := client.Watch(ctx, &pb.WatchRequest{Namespace: "sequence"})
+	require.NoError(err, "could not create watch stream")
+	_, err = stream.Recv()
+	s.StatusError(err, codes.PermissionDenied, "cannot use reserved namespace")
+
+	// Watch a prefix in the namespace and wait for the subscription to be active
+	stream, err = client.Watch(ctx, &pb.WatchRequest{Namespace: "watch", Prefix: []byte("foo")})
+	require.NoError(err, "could not create watch stream")
+	_, err = stream.Header()
+	require.NoError(err, "could not receive watch headers")
+
+	// Make changes inside and outside of the watched namespace and prefix
+	_, err = client.Put(ctx, &pb.PutRequest{Namespace: "other", Key: []byte("foo1"), Value: []byte("a")})
+	require.NoError(err)
+	_, err = client.Put(ctx, &pb.PutRequest{Namespace: "watch", Key: []byte("bar1"), Value: []byte("b")})
+	require.NoError(err)
+	_, err = client.Put(ctx, &pb.PutRequest{Namespace: "watch", Key: []byte("foo1"), Value: []byte("c")})
+	require.NoError(err)
+	_, err = client.Put(ctx, &pb.PutRequest{Namespace: "watch", Key: []byte("foo1"), Value: []byte("d")})
+	require.NoError(err)
+	_, err = client.Delete(ctx, &pb.DeleteRequest{Namespace: "watch", Key: []byte("foo1")})
+	require.NoError(err)
+
+	// Only the changes to the watched prefix should be received in order
+	event, err := stream.Recv()
+	require.NoError(err)
+	require.Equal(pb.WatchEvent_PUT, event.Type)
+	require.Equal([]byte("foo1"), event.Key)
+	require.Equal([]byte("c"), event.Value)
+	require.Equal("watch", event.Namespace)
+	require.NotNil(event.Meta)
+	version := event.Meta.Version.Version
+
+	event, err = stream.Recv()
+	require.NoError(err)
+	require.Equal(pb.WatchEvent_PUT, event.Type)
+	require.Equal([]byte("d"), event.Value)
+	require.Greater(event.Meta.Version.Version, version)
+	require.Equal(version, event.Meta.Parent.Version)
+
+	event, err = stream.Recv()
+	require.NoError(err)
+	require.Equal(pb.WatchEvent_DELETE, event.Type)
+	require.Equal([]byte("foo1"), event.Key)
+	require.Empty(event.Value)
+
+	// Canceling the context should end the stream
+	cancel()
+	_, err = stream.Recv()
+	s.StatusError(err, codes.Canceled, "context canceled")
+}
+
 func (s *trtlTestSuite) TestCount() {
 	require := s.Require()
 	ctx := context.Background()
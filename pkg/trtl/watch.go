@@ -0,0 +1,142 @@
+package trtl
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/rotationalio/honu/object"
+	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
+)
+
+// The number of events buffered for each watcher; if a watcher falls further behind
+// than this it is closed so that it does not block writes to the database.
+const watchBufferSize = 512
+
+// Watchers manages the subscribers to the Watch RPC and fans out change events for
+// objects that are written to the database, either locally or by replication. Publish
+// must never block database accesses, so subscribers that are too slow to keep up are
+// closed and must reconnect (and resynchronize) to continue receiving events.
+type Watchers struct {
+	sync.RWMutex
+	subs map[uint64]*watcher
+	seq  uint64
+}
+
+// watcher is a single subscriber that receives the events for a namespace and prefix.
+type watcher struct {
+	namespace string
+	prefix    []byte
+	noValues  bool
+	events    chan *pb.WatchEvent
+	overflow  bool
+}
+
+func NewWatchers() *Watchers {
+	return &Watchers{subs: make(map[uint64]*watcher)}
+}
+
+// Subscribe to the events in the specified namespace whose keys have the prefix. The
+// events channel is closed when the subscriber is removed or falls too far behind.
+func (w *Watchers) Subscribe(namespace string, prefix []byte, noValues bool) (id uint64, events <-chan *pb.WatchEvent) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.seq++
+	sub := &watcher{
+		namespace: namespace,
+		prefix:    prefix,
+		noValues:  noValues,
+		events:    make(chan *pb.WatchEvent, watchBufferSize),
+	}
+	w.subs[w.seq] = sub
+	return w.seq, sub.events
+}
+
+// Unsubscribe removes the subscriber and closes its events channel. It is safe to
+// call Unsubscribe for a subscriber that has already been removed.
+func (w *Watchers) Unsubscribe(id uint64) {
+	w.Lock()
+	defer w.Unlock()
+	if sub, ok := w.subs[id]; ok {
+		if !sub.overflow {
+			close(sub.events)
+		}
+		delete(w.subs, id)
+	}
+}
+
+// Close removes all subscribers, closing their events channels, e.g. on shutdown.
+func (w *Watchers) Close() {
+	w.Lock()
+	defer w.Unlock()
+	for id, sub := range w.subs {
+		if !sub.overflow {
+			close(sub.events)
+		}
+		delete(w.subs, id)
+	}
+}
+
+// Overflowed returns true if the subscriber was removed because it fell behind.
+func (w *Watchers) Overflowed(id uint64) bool {
+	w.RLock()
+	defer w.RUnlock()
+	if sub, ok := w.subs[id]; ok {
+		return sub.overflow
+	}
+	return false
+}
+
+// Len returns the number of active subscribers.
+func (w *Watchers) Len() int {
+	w.RLock()
+	defer w.RUnlock()
+	return len(w.subs)
+}
+
+// Publish an object that was written to the database to all matching subscribers.
+// Tombstones are published as delete events, all other objects as put events.
+func (w *Watchers) Publish(obj *object.Object) {
+	if w == nil || obj == nil {
+		return
+	}
+
+	w.Lock()
+	defer w.Unlock()
+	if len(w.subs) == 0 {
+		return
+	}
+
+	event := &pb.WatchEvent{
+		Type:      pb.WatchEvent_PUT,
+		Key:       obj.Key,
+		Value:     obj.Data,
+		Namespace: obj.Namespace,
+		Meta:      returnMeta(obj),
+	}
+
+	if obj.Version != nil && obj.Version.Tombstone {
+		event.Type = pb.WatchEvent_DELETE
+		event.Value = nil
+	}
+
+	for _, sub := range w.subs {
+		if sub.overflow || sub.namespace != obj.Namespace || !bytes.HasPrefix(obj.Key, sub.prefix) {
+			continue
+		}
+
+		out := event
+		if sub.noValues && event.Value != nil {
+			out = &pb.WatchEvent{Type: event.Type, Key: event.Key, Namespace: event.Namespace, Meta: event.Meta}
+		}
+
+		select {
+		case sub.events <- out:
+		default:
+			// The subscriber is too slow, close the channel so the stream terminates;
+			// the subscriber remains in the map until it is unsubscribed.
+			sub.overflow = true
+			close(sub.events)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package trtl_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rotationalio/honu/object"
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/trtl"
+	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
+)
+
+func TestWatchers(t *testing.T) {
+	watchers := trtl.NewWatchers()
+	obj := func(namespace, key string, tombstone bool) *object.Object {
+		return &object.Object{
+			Key:       []byte(key),
+			Namespace: namespace,
+			Data:      []byte("value"),
+			Version:   &object.Version{Pid: 8, Version: 1, Tombstone: tombstone},
+		}
+	}
+
+	id, events := watchers.Subscribe("people", []byte("a"), false)
+	_, noValues := watchers.Subscribe("people", nil, true)
+	require.Equal(t, 2, watchers.Len())
+
+	watchers.Publish(obj("people", "alice", false))
+	watchers.Publish(obj("places", "amsterdam", false))
+	watchers.Publish(obj("people", "bob", true))
+
+	event := <-events
+	require.Equal(t, pb.WatchEvent_PUT, event.Type)
+	require.Equal(t, []byte("alice"), event.Key)
+	require.Equal(t, []byte("value"), event.Value)
+	require.Len(t, events, 0, "expected only events matching namespace and prefix")
+
+	event = <-noValues
+	require.Equal(t, []byte("alice"), event.Key)
+	require.Empty(t, event.Value)
+	event = <-noValues
+	require.Equal(t, pb.WatchEvent_DELETE, event.Type)
+	require.Equal(t, []byte("bob"), event.Key)
+
+	// A watcher that falls behind should be closed without blocking the publisher
+	for i := 0; i < 1024; i++ {
+		watchers.Publish(obj("people", fmt.Sprintf("a%04d", i), false))
+	}
+	require.True(t, watchers.Overflowed(id))
+	for range events {
+	}
+
+	watchers.Unsubscribe(id)
+	require.Equal(t, 1, watchers.Len())
+	require.False(t, watchers.Overflowed(id))
+
+	// Closing the watchers should close all remaining subscribers
+	watchers.Close()
+	require.Equal(t, 0, watchers.Len())
+	for range noValues {
+	}
+}
@@ -0,0 +1,298 @@
+/*
+Package backups stores the compressed database archives created by the GDS and trtl
+backup managers in a pluggable sink (a local directory, an S3 compatible object store,
+or a generic HTTP endpoint), optionally encrypting the archives with envelope
+encryption and pruning old archives according to a daily, weekly, and monthly
+retention policy.
+*/
+package backups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Archives are named <prefix>-YYYYmmddHHMM.tgz, with an additional .enc extension if
+// they have been encrypted before being stored in the sink.
+const (
+	GDSPrefix     = "gdsdb"
+	TrtlPrefix    = "trtldb"
+	ArchiveLayout = "200601021504"
+	ArchiveExt    = ".tgz"
+	EncryptedExt  = ".enc"
+)
+
+var ErrNotSupported = errors.New("operation not supported by backup sink")
+
+// Sink is a storage location for backup archives.
+type Sink interface {
+	// Put writes the object to the sink with the specified name; size is the number
+	// of bytes that will be read from r or -1 if unknown.
+	Put(ctx context.Context, name string, r io.Reader, size int64) error
+
+	// List returns the objects in the sink; sinks that cannot list objects return
+	// ErrNotSupported, in which case retention must be managed by the remote target.
+	List(ctx context.Context) ([]*Object, error)
+
+	// Get returns a reader for the object with the specified name in the sink; the
+	// caller must close the reader when done.
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+
+	// Delete removes the object with the specified name from the sink.
+	Delete(ctx context.Context, name string) error
+}
+
+// Object is an item stored in a backup sink.
+type Object struct {
+	Name string
+	Size int64
+}
+
+// Archive is a backup archive stored in a sink whose timestamp is parsed from its name.
+type Archive struct {
+	Name      string
+	Timestamp time.Time
+	Size      int64
+	Encrypted bool
+}
+
+// Manager uploads backup archives with the configured prefix to a sink, encrypting
+// them if an encryption key is specified, and prunes old archives from the sink.
+type Manager struct {
+	prefix    string
+	sink      Sink
+	key       []byte
+	retention Retention
+}
+
+// New creates a backup manager for the archives with the specified prefix. If key is
+// nil the archives are stored without encryption.
+func New(prefix string, sink Sink, key []byte, retention Retention) *Manager {
+	return &Manager{
+		prefix:    prefix,
+		sink:      sink,
+		key:       key,
+		retention: retention,
+	}
+}
+
+// Upload the archive at the local path to the sink, encrypting it if the manager has an
+// encryption key. The name of the object written to the sink is returned.
+func (m *Manager) Upload(ctx context.Context, path string) (name string, err error) {
+	var f *os.File
+	if f, err = os.Open(path); err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var stat os.FileInfo
+	if stat, err = f.Stat(); err != nil {
+		return "", err
+	}
+
+	name = filepath.Base(path)
+	if m.key == nil {
+		if err = m.sink.Put(ctx, name, f, stat.Size()); err != nil {
+			return "", fmt.Errorf("could not put archive to sink: %w", err)
+		}
+		return name, nil
+	}
+
+	// Stream the encrypted archive to the sink
+	name += EncryptedExt
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(Encrypt(m.key, pw, f))
+	}()
+
+	if err = m.sink.Put(ctx, name, pr, EncryptedSize(stat.Size())); err != nil {
+		pr.CloseWithError(err)
+		return "", fmt.Errorf("could not put encrypted archive to sink: %w", err)
+	}
+	return name, nil
+}
+
+// Prune deletes the archives in the sink that are not kept by the retention policy and
+// returns the number of archives that were removed. If the sink cannot list its
+// objects, no archives are pruned.
+func (m *Manager) Prune(ctx context.Context) (removed int, err error) {
+	var objects []*Object
+	if objects, err = m.sink.List(ctx); err != nil {
+		if errors.Is(err, ErrNotSupported) {
+			log.Debug().Msg("backup sink does not support listing, archives not pruned")
+			return 0, nil
+		}
+		return 0, fmt.Errorf("could not list backup sink: %w", err)
+	}
+
+	for _, archive := range m.retention.Expired(ParseArchives(m.prefix, objects)) {
+		log.Debug().Str("archive", archive.Name).Msg("deleting archive")
+		if err = m.sink.Delete(ctx, archive.Name); err != nil {
+			return removed, fmt.Errorf("could not delete archive %s: %w", archive.Name, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// List the archives in the sink ordered by timestamp descending so the most recent
+// archive is first. Returns ErrNotSupported if the sink cannot list its objects.
+func (m *Manager) List(ctx context.Context) (_ []*Archive, err error) {
+	var objects []*Object
+	if objects, err = m.sink.List(ctx); err != nil {
+		return nil, err
+	}
+	return ParseArchives(m.prefix, objects), nil
+}
+
+// Find the archive with the specified name in the sink, or the most recent archive if
+// name is empty. If the sink cannot list its objects, the archive must be named and it
+// is assumed to exist.
+func (m *Manager) Find(ctx context.Context, name string) (_ *Archive, err error) {
+	var archives []*Archive
+	if archives, err = m.List(ctx); err != nil {
+		if !errors.Is(err, ErrNotSupported) {
+			return nil, fmt.Errorf("could not list backup sink: %w", err)
+		}
+
+		if name == "" {
+			return nil, errors.New("backup sink cannot be listed, specify the name of the archive")
+		}
+
+		archive, ok := ParseArchive(m.prefix, name)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a %s archive", name, m.prefix)
+		}
+		return archive, nil
+	}
+
+	if len(archives) == 0 {
+		return nil, fmt.Errorf("no %s archives found in backup sink", m.prefix)
+	}
+
+	if name == "" {
+		return archives[0], nil
+	}
+
+	for _, archive := range archives {
+		if archive.Name == name {
+			return archive, nil
+		}
+	}
+	return nil, fmt.Errorf("archive %s not found in backup sink", name)
+}
+
+// Download the archive from the sink into the directory, decrypting it with the key of
+// the manager if it is encrypted. The path to the plaintext archive is returned.
+func (m *Manager) Download(ctx context.Context, archive *Archive, dir string) (path string, err error) {
+	if archive.Encrypted && m.key == nil {
+		return "", fmt.Errorf("%s is encrypted, an encryption key is required", archive.Name)
+	}
+
+	var rc io.ReadCloser
+	if rc, err = m.sink.Get(ctx, archive.Name); err != nil {
+		return "", fmt.Errorf("could not get %s from backup sink: %w", archive.Name, err)
+	}
+	defer rc.Close()
+
+	var f *os.File
+	path = filepath.Join(dir, strings.TrimSuffix(archive.Name, EncryptedExt))
+	if f, err = os.Create(path); err != nil {
+		return "", err
+	}
+
+	if archive.Encrypted {
+		err = Decrypt(m.key, f, rc)
+	} else {
+		_, err = io.Copy(f, rc)
+	}
+
+	if err != nil {
+		f.Close()
+		os.Remove(path)
+		return "", fmt.Errorf("could not download %s: %w", archive.Name, err)
+	}
+
+	if err = f.Close(); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+// ArchiveName returns the name of the archive with the specified prefix created at the
+// timestamp without the archive extension, e.g. the directory that is compressed.
+func ArchiveName(prefix string, ts time.Time) string {
+	return prefix + "-" + ts.UTC().Format(ArchiveLayout)
+}
+
+// ParseArchive parses the name of a backup archive with the specified prefix, returning
+// false if the name does not match the archive format.
+func ParseArchive(prefix, name string) (_ *Archive, ok bool) {
+	archive := &Archive{Name: name}
+	if strings.HasSuffix(name, EncryptedExt) {
+		archive.Encrypted = true
+		name = strings.TrimSuffix(name, EncryptedExt)
+	}
+
+	if !strings.HasPrefix(name, prefix+"-") || !strings.HasSuffix(name, ArchiveExt) {
+		return nil, false
+	}
+
+	var err error
+	ts := strings.TrimSuffix(strings.TrimPrefix(name, prefix+"-"), ArchiveExt)
+	if archive.Timestamp, err = time.Parse(ArchiveLayout, ts); err != nil {
+		return nil, false
+	}
+	return archive, true
+}
+
+// ParseArchives returns the objects that are backup archives with the specified prefix
+// ordered by timestamp descending so the most recent archive is first. Objects whose
+// names do not match the archive format are ignored.
+func ParseArchives(prefix string, objects []*Object) []*Archive {
+	archives := make([]*Archive, 0, len(objects))
+	for _, obj := range objects {
+		if archive, ok := ParseArchive(prefix, obj.Name); ok {
+			archive.Size = obj.Size
+			archives = append(archives, archive)
+		}
+	}
+
+	sort.Slice(archives, func(i, j int) bool {
+		return archives[i].Timestamp.After(archives[j].Timestamp)
+	})
+	return archives
+}
+
+// Staged returns the paths of the archives with the specified prefix in a local staging
+// directory ordered by timestamp ascending so that they are uploaded oldest first.
+func Staged(dir, prefix string) (paths []string, err error) {
+	var entries []os.DirEntry
+	if entries, err = os.ReadDir(dir); err != nil {
+		return nil, err
+	}
+
+	objects := make([]*Object, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			objects = append(objects, &Object{Name: entry.Name()})
+		}
+	}
+
+	archives := ParseArchives(prefix, objects)
+	paths = make([]string, len(archives))
+	for i, archive := range archives {
+		paths[len(archives)-1-i] = filepath.Join(dir, archive.Name)
+	}
+	return paths, nil
+}
@@ -0,0 +1,339 @@
+package backups_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/utils/backups"
+)
+
+const testKey = "3q2+796tvu/erb7v3q2+796tvu/erb7v3q2+796tvu8="
+
+func TestEnvelope(t *testing.T) {
+	key, err := backups.ParseKey(testKey)
+	require.NoError(t, err)
+
+	for _, size := range []int{0, 1, 1024, 64 * 1024, 2*64*1024 + 5} {
+		plaintext := make([]byte, size)
+		_, err = rand.Read(plaintext)
+		require.NoError(t, err)
+
+		encrypted := &bytes.Buffer{}
+		require.NoError(t, backups.Encrypt(key, encrypted, bytes.NewReader(plaintext)))
+		require.Equal(t, backups.EncryptedSize(int64(size)), int64(encrypted.Len()), "unexpected encrypted size for %d bytes", size)
+
+		decrypted := &bytes.Buffer{}
+		require.NoError(t, backups.Decrypt(key, decrypted, bytes.NewReader(encrypted.Bytes())))
+		require.True(t, bytes.Equal(plaintext, decrypted.Bytes()), "decrypted plaintext does not match for %d bytes", size)
+	}
+
+	plaintext := bytes.Repeat([]byte("gdsdb"), 30000)
+	encrypted := &bytes.Buffer{}
+	require.NoError(t, backups.Encrypt(key, encrypted, bytes.NewReader(plaintext)))
+	data := encrypted.Bytes()
+
+	// Cannot decrypt with a different key
+	other := make([]byte, 32)
+	require.ErrorIs(t, backups.Decrypt(other, io.Discard, bytes.NewReader(data)), backups.ErrWrongKey)
+
+	// Cannot decrypt an archive that is not encrypted
+	require.ErrorIs(t, backups.Decrypt(key, io.Discard, bytes.NewReader(plaintext)), backups.ErrNotEncrypted)
+
+	// Truncated archives are detected, including truncation at a chunk boundary
+	require.ErrorIs(t, backups.Decrypt(key, io.Discard, bytes.NewReader(data[:len(data)-10])), backups.ErrCorruptedBackup)
+	boundary := int(backups.EncryptedSize(64*1024)) - 4 - 16
+	require.ErrorIs(t, backups.Decrypt(key, io.Discard, bytes.NewReader(data[:boundary])), backups.ErrCorruptedBackup)
+
+	// Modified archives are detected
+	modified := bytes.Clone(data)
+	modified[len(modified)/2] ^= 0xff
+	require.ErrorIs(t, backups.Decrypt(key, io.Discard, bytes.NewReader(modified)), backups.ErrCorruptedBackup)
+
+	// Keys must be 32 bytes
+	_, err = backups.ParseKey("c2hvcnQ=")
+	require.ErrorIs(t, err, backups.ErrInvalidKey)
+
+	key, err = backups.ParseKey("")
+	require.NoError(t, err)
+	require.Nil(t, key)
+}
+
+func TestRetention(t *testing.T) {
+	// Create an archive every 12 hours for 90 days
+	objects := make([]*backups.Object, 0)
+	start := time.Date(2022, 1, 1, 6, 0, 0, 0, time.UTC)
+	for ts := start; ts.Before(start.AddDate(0, 0, 90)); ts = ts.Add(12 * time.Hour) {
+		objects = append(objects, &backups.Object{Name: "gdsdb-" + ts.Format(backups.ArchiveLayout) + ".tgz"})
+	}
+
+	// Objects that are not archives with the prefix are ignored
+	objects = append(objects, &backups.Object{Name: "trtldb-202201010600.tgz"}, &backups.Object{Name: "notes.txt"})
+
+	archives := backups.ParseArchives("gdsdb", objects)
+	require.Len(t, archives, 180)
+	require.True(t, archives[0].Timestamp.After(archives[1].Timestamp), "expected archives to be sorted newest first")
+
+	kept := func(r backups.Retention) []*backups.Archive {
+		expired := make(map[string]struct{})
+		for _, archive := range r.Expired(archives) {
+			expired[archive.Name] = struct{}{}
+		}
+
+		kept := make([]*backups.Archive, 0)
+		for _, archive := range archives {
+			if _, ok := expired[archive.Name]; !ok {
+				kept = append(kept, archive)
+			}
+		}
+		return kept
+	}
+
+	// The most recent archive is always kept
+	require.Len(t, kept(backups.Retention{}), 1)
+	require.Len(t, kept(backups.Retention{Keep: 3}), 3)
+
+	// Daily retention keeps the most recent archive of each day
+	daily := kept(backups.Retention{Daily: 7})
+	require.Len(t, daily, 7)
+	for i, archive := range daily {
+		require.Equal(t, 18, archive.Timestamp.Hour())
+		require.Equal(t, archives[0].Timestamp.AddDate(0, 0, -i), archive.Timestamp)
+	}
+
+	// Overlapping periods keep the union of the archives: 2 recent, 6 more daily, 2
+	// more weekly (the latest two weeks are already kept), and 2 more monthly.
+	require.Len(t, kept(backups.Retention{Keep: 2, Daily: 7, Weekly: 4, Monthly: 3}), 12)
+	require.Len(t, kept(backups.Retention{Monthly: 12}), 3)
+}
+
+func TestLocalManager(t *testing.T) {
+	ctx := context.Background()
+	key, err := backups.ParseKey(testKey)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	sink, err := backups.Open(backups.SinkConfig{}, dir)
+	require.NoError(t, err)
+
+	manager := backups.New("gdsdb", sink, key, backups.Retention{Keep: 2})
+
+	// Upload several archives from a staging directory
+	staging := t.TempDir()
+	for _, ts := range []string{"202201010000", "202201020000", "202201030000"} {
+		path := filepath.Join(staging, "gdsdb-"+ts+".tgz")
+		require.NoError(t, os.WriteFile(path, []byte("archive "+ts), 0644))
+
+		name, err := manager.Upload(ctx, path)
+		require.NoError(t, err)
+		require.Equal(t, "gdsdb-"+ts+".tgz.enc", name)
+	}
+
+	removed, err := manager.Prune(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, removed)
+
+	objects, err := sink.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+
+	// The archive in the sink can be decrypted with the key
+	decrypted := filepath.Join(t.TempDir(), "gdsdb-202201030000.tgz")
+	require.NoError(t, backups.DecryptFile(key, filepath.Join(dir, "gdsdb-202201030000.tgz.enc"), decrypted))
+	data, err := os.ReadFile(decrypted)
+	require.NoError(t, err)
+	require.Equal(t, []byte("archive 202201030000"), data)
+
+	// The archives in the sink can be listed, found, and downloaded
+	archives, err := manager.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, archives, 2)
+	require.Equal(t, "gdsdb-202201030000.tgz.enc", archives[0].Name)
+	require.True(t, archives[0].Encrypted)
+	require.NotZero(t, archives[0].Size)
+
+	archive, err := manager.Find(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, archives[0], archive, "expected the most recent archive")
+
+	archive, err = manager.Find(ctx, "gdsdb-202201020000.tgz.enc")
+	require.NoError(t, err)
+	require.Equal(t, archives[1], archive)
+
+	_, err = manager.Find(ctx, "gdsdb-202201010000.tgz.enc")
+	require.Error(t, err, "pruned archives should not be found")
+
+	path, err := manager.Download(ctx, archive, t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, "gdsdb-202201020000.tgz", filepath.Base(path))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, []byte("archive 202201020000"), data)
+
+	// Encrypted archives cannot be downloaded without the key
+	_, err = backups.New("gdsdb", sink, nil, backups.Retention{}).Download(ctx, archive, t.TempDir())
+	require.Error(t, err)
+
+	_, err = backups.New("trtldb", sink, nil, backups.Retention{}).Find(ctx, "")
+	require.Error(t, err, "expected no archives with a different prefix")
+
+	// Invalid sink configurations should error
+	_, err = backups.Open(backups.SinkConfig{URL: "ftp://example.com/backups"}, dir)
+	require.Error(t, err)
+	_, err = backups.Open(backups.SinkConfig{URL: "s3://backups"}, dir)
+	require.Error(t, err, "an endpoint is required for s3 sinks")
+}
+
+func TestStaged(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"gdsdb-202201020000.tgz", "gdsdb-202201010000.tgz.enc", "trtldb-202201030000.tgz", "gdsdb-latest.tgz"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, backups.ArchiveName(backups.GDSPrefix, time.Now())), 0755))
+
+	paths, err := backups.Staged(dir, backups.GDSPrefix)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(dir, "gdsdb-202201010000.tgz.enc"),
+		filepath.Join(dir, "gdsdb-202201020000.tgz"),
+	}, paths, "expected archives to be sorted oldest first")
+
+	archive, ok := backups.ParseArchive(backups.TrtlPrefix, "trtldb-202201030000.tgz")
+	require.True(t, ok)
+	require.Equal(t, time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC), archive.Timestamp)
+	require.False(t, archive.Encrypted)
+
+	_, ok = backups.ParseArchive(backups.GDSPrefix, "trtldb-202201030000.tgz")
+	require.False(t, ok)
+}
+
+func TestHTTPSink(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received = make(map[string][]byte)
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			received[r.URL.Path] = data
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			data, ok := received[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(data)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	sink, err := backups.Open(backups.SinkConfig{URL: srv.URL + "/backups/", Token: "secret"}, "")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "trtldb-202201010000.tgz")
+	require.NoError(t, os.WriteFile(path, []byte("archive"), 0644))
+
+	manager := backups.New("trtldb", sink, nil, backups.Retention{Keep: 1})
+	_, err = manager.Upload(ctx, path)
+	require.NoError(t, err)
+	require.Equal(t, []byte("archive"), received["/backups/trtldb-202201010000.tgz"])
+
+	// HTTP sinks cannot be listed so nothing is pruned
+	removed, err := manager.Prune(ctx)
+	require.NoError(t, err)
+	require.Zero(t, removed)
+
+	// Archives must be named to be found since the endpoint cannot be listed
+	_, err = manager.Find(ctx, "")
+	require.Error(t, err)
+	_, err = manager.Find(ctx, "notes.txt")
+	require.Error(t, err)
+
+	archive, err := manager.Find(ctx, "trtldb-202201010000.tgz")
+	require.NoError(t, err)
+	path, err = manager.Download(ctx, archive, t.TempDir())
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, []byte("archive"), data)
+
+	archive, err = manager.Find(ctx, "trtldb-202201020000.tgz")
+	require.NoError(t, err)
+	_, err = manager.Download(ctx, archive, t.TempDir())
+	require.Error(t, err, "expected error when the archive does not exist")
+
+	// Errors from the endpoint are returned
+	u, _ := url.Parse(srv.URL)
+	require.Error(t, backups.NewHTTP(u, "wrong").Put(ctx, "foo", bytes.NewReader(nil), 0))
+}
+
+func TestS3Sink(t *testing.T) {
+	// These tests require an S3 compatible object store with an existing bucket, e.g.
+	// docker run -p 9000:9000 minio/minio server /data and a bucket named backups.
+	endpoint := os.Getenv("GDS_TEST_MINIO_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("set $GDS_TEST_MINIO_ENDPOINT to run s3 backup sink tests")
+	}
+
+	ctx := context.Background()
+	sink, err := backups.Open(backups.SinkConfig{
+		URL:             "s3://backups/test",
+		Endpoint:        endpoint,
+		AccessKeyID:     os.Getenv("GDS_TEST_MINIO_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("GDS_TEST_MINIO_SECRET_ACCESS_KEY"),
+		Insecure:        true,
+	}, "")
+	require.NoError(t, err)
+
+	manager := backups.New("gdsdb", sink, nil, backups.Retention{Keep: 1})
+	staging := t.TempDir()
+	for _, ts := range []string{"202201010000", "202201020000"} {
+		path := filepath.Join(staging, "gdsdb-"+ts+".tgz")
+		require.NoError(t, os.WriteFile(path, []byte("archive "+ts), 0644))
+		_, err = manager.Upload(ctx, path)
+		require.NoError(t, err)
+	}
+
+	removed, err := manager.Prune(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, removed)
+
+	objects, err := sink.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	require.Equal(t, "gdsdb-202201020000.tgz", objects[0].Name)
+
+	archive, err := manager.Find(ctx, "")
+	require.NoError(t, err)
+	path, err := manager.Download(ctx, archive, t.TempDir())
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, []byte("archive 202201020000"), data)
+
+	require.NoError(t, sink.Delete(ctx, objects[0].Name))
+}
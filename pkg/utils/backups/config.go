@@ -0,0 +1,78 @@
+package backups
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// SinkConfig specifies where backup archives are stored. The URL scheme determines the
+// type of sink: file:///path/to/dir for a local directory, s3://bucket/prefix for an S3
+// compatible object store, and http(s)://host/path for an endpoint that accepts HTTP PUT
+// requests. If the URL is empty, archives are stored in the local backup storage.
+type SinkConfig struct {
+	URL             string `required:"false"`
+	Endpoint        string `required:"false"`                    // S3 endpoint, e.g. localhost:9000 for MinIO
+	Region          string `required:"false"`                    // S3 region of the bucket
+	AccessKeyID     string `split_words:"true" required:"false"` // S3 access key
+	SecretAccessKey string `split_words:"true" required:"false"` // S3 secret key
+	Insecure        bool   `default:"false"`                     // connect to the S3 endpoint without TLS
+	Token           string `required:"false"`                    // bearer token for HTTP PUT requests
+}
+
+func (c SinkConfig) Validate() (err error) {
+	if c.URL == "" {
+		return nil
+	}
+
+	var u *url.URL
+	if u, err = url.Parse(c.URL); err != nil {
+		return fmt.Errorf("invalid backup sink url: %w", err)
+	}
+
+	switch u.Scheme {
+	case "file":
+		if u.Path == "" {
+			return fmt.Errorf("invalid backup sink: file url must contain a path")
+		}
+	case "s3":
+		if u.Host == "" {
+			return fmt.Errorf("invalid backup sink: s3 url must contain a bucket")
+		}
+		if c.Endpoint == "" {
+			return fmt.Errorf("invalid backup sink: s3 endpoint is required")
+		}
+	case "http", "https":
+		if u.Host == "" {
+			return fmt.Errorf("invalid backup sink: http url must contain a host")
+		}
+	default:
+		return fmt.Errorf("unknown backup sink scheme %q", u.Scheme)
+	}
+	return nil
+}
+
+// Open the sink described by the configuration. If the URL is empty, a sink for the
+// local storage directory is returned.
+func Open(conf SinkConfig, storage string) (_ Sink, err error) {
+	if err = conf.Validate(); err != nil {
+		return nil, err
+	}
+
+	if conf.URL == "" {
+		return NewLocal(storage)
+	}
+
+	var u *url.URL
+	if u, err = url.Parse(conf.URL); err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "file":
+		return NewLocal(u.Path)
+	case "s3":
+		return NewS3(u, conf)
+	default:
+		return NewHTTP(u, conf.Token), nil
+	}
+}
@@ -0,0 +1,261 @@
+package backups
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Archives are encrypted with envelope encryption: a random data key is generated for
+// every archive and used to encrypt the archive with AES-256-GCM, then the data key is
+// encrypted (wrapped) with the configured key and stored in the header of the encrypted
+// archive. The archive is encrypted in chunks so that large archives can be streamed;
+// each chunk has a unique nonce made up of a random prefix, the chunk counter, and a
+// flag marking the final chunk so that reordered or truncated archives are detected.
+//
+// The encrypted archive format is:
+//
+//	magic (6) | version (1) | key id (8) | wrapped key (60) | nonce prefix (7) | chunks
+//
+// where each chunk is a 4 byte big endian length followed by the sealed chunk.
+const (
+	envelopeVersion = 1
+	keySize         = 32
+	keyIDSize       = 8
+	prefixSize      = 7
+	chunkSize       = 64 * 1024
+)
+
+var (
+	envelopeMagic  = []byte("GDSBAK")
+	wrappedKeySize = 12 + keySize + 16
+	headerSize     = int64(len(envelopeMagic) + 1 + keyIDSize + wrappedKeySize + prefixSize)
+)
+
+var (
+	ErrInvalidKey      = errors.New("backup encryption key must be 32 bytes")
+	ErrNotEncrypted    = errors.New("archive is not an encrypted backup")
+	ErrWrongKey        = errors.New("archive was encrypted with a different key")
+	ErrCorruptedBackup = errors.New("encrypted archive is corrupted or truncated")
+)
+
+// ParseKey decodes a base64 encoded 32 byte encryption key. An empty string returns a
+// nil key, meaning that archives should not be encrypted.
+func ParseKey(s string) (key []byte, err error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	if key, err = base64.StdEncoding.DecodeString(s); err != nil {
+		return nil, fmt.Errorf("could not decode backup encryption key: %w", err)
+	}
+
+	if len(key) != keySize {
+		return nil, ErrInvalidKey
+	}
+	return key, nil
+}
+
+// EncryptedSize returns the size of an encrypted archive whose plaintext is n bytes.
+func EncryptedSize(n int64) int64 {
+	// Every chunk is full except the last, which may be empty
+	chunks := n/chunkSize + 1
+	return headerSize + chunks*(4+16) + n
+}
+
+// Encrypt the plaintext read from src and write the encrypted archive to dst.
+func Encrypt(key []byte, dst io.Writer, src io.Reader) (err error) {
+	if len(key) != keySize {
+		return ErrInvalidKey
+	}
+
+	// Generate a new data key and wrap it with the key encryption key
+	dataKey := make([]byte, keySize)
+	if _, err = rand.Read(dataKey); err != nil {
+		return err
+	}
+
+	var kek cipher.AEAD
+	if kek, err = newGCM(key); err != nil {
+		return err
+	}
+
+	header := make([]byte, 0, headerSize)
+	header = append(header, envelopeMagic...)
+	header = append(header, envelopeVersion)
+	header = append(header, keyID(key)...)
+
+	nonce := make([]byte, kek.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return err
+	}
+	wrapped := kek.Seal(nonce, nonce, dataKey, header)
+	header = append(header, wrapped...)
+
+	prefix := make([]byte, prefixSize)
+	if _, err = rand.Read(prefix); err != nil {
+		return err
+	}
+	header = append(header, prefix...)
+
+	if _, err = dst.Write(header); err != nil {
+		return err
+	}
+
+	var aead cipher.AEAD
+	if aead, err = newGCM(dataKey); err != nil {
+		return err
+	}
+
+	// Seal each chunk of the plaintext; a short read marks the final chunk
+	buf := make([]byte, chunkSize)
+	out := make([]byte, 4, 4+chunkSize+aead.Overhead())
+	for counter := uint32(0); ; counter++ {
+		var n int
+		n, err = io.ReadFull(src, buf)
+		last := err == io.EOF || err == io.ErrUnexpectedEOF
+		if err != nil && !last {
+			return err
+		}
+
+		sealed := aead.Seal(out[:4], chunkNonce(prefix, counter, last), buf[:n], nil)
+		binary.BigEndian.PutUint32(sealed[:4], uint32(len(sealed)-4))
+		if _, err = dst.Write(sealed); err != nil {
+			return err
+		}
+
+		if last {
+			return nil
+		}
+	}
+}
+
+// Decrypt the encrypted archive read from src and write the plaintext to dst. If an
+// error is returned, any plaintext already written to dst must be discarded.
+func Decrypt(key []byte, dst io.Writer, src io.Reader) (err error) {
+	if len(key) != keySize {
+		return ErrInvalidKey
+	}
+
+	header := make([]byte, headerSize)
+	if _, err = io.ReadFull(src, header); err != nil {
+		return ErrNotEncrypted
+	}
+
+	if !bytes.Equal(header[:len(envelopeMagic)], envelopeMagic) || header[len(envelopeMagic)] != envelopeVersion {
+		return ErrNotEncrypted
+	}
+
+	idx := len(envelopeMagic) + 1
+	if !bytes.Equal(header[idx:idx+keyIDSize], keyID(key)) {
+		return ErrWrongKey
+	}
+	idx += keyIDSize
+
+	var kek cipher.AEAD
+	if kek, err = newGCM(key); err != nil {
+		return err
+	}
+
+	wrapped := header[idx : idx+wrappedKeySize]
+	var dataKey []byte
+	if dataKey, err = kek.Open(nil, wrapped[:kek.NonceSize()], wrapped[kek.NonceSize():], header[:idx]); err != nil {
+		return ErrCorruptedBackup
+	}
+	prefix := header[idx+wrappedKeySize:]
+
+	var aead cipher.AEAD
+	if aead, err = newGCM(dataKey); err != nil {
+		return err
+	}
+
+	r := bufio.NewReader(src)
+	buf := make([]byte, chunkSize+aead.Overhead())
+	length := make([]byte, 4)
+	for counter := uint32(0); ; counter++ {
+		if _, err = io.ReadFull(r, length); err != nil {
+			return ErrCorruptedBackup
+		}
+
+		n := binary.BigEndian.Uint32(length)
+		if n > uint32(len(buf)) {
+			return ErrCorruptedBackup
+		}
+
+		if _, err = io.ReadFull(r, buf[:n]); err != nil {
+			return ErrCorruptedBackup
+		}
+
+		// The final chunk is the one that is not followed by any more data
+		_, err = r.Peek(1)
+		last := err == io.EOF
+
+		var plaintext []byte
+		if plaintext, err = aead.Open(buf[:0], chunkNonce(prefix, counter, last), buf[:n], nil); err != nil {
+			return ErrCorruptedBackup
+		}
+
+		if _, err = dst.Write(plaintext); err != nil {
+			return err
+		}
+
+		if last {
+			return nil
+		}
+	}
+}
+
+// DecryptFile decrypts the encrypted archive at src and writes the plaintext archive to
+// dst; dst is removed if the archive cannot be decrypted.
+func DecryptFile(key []byte, src, dst string) (err error) {
+	var in, out *os.File
+	if in, err = os.Open(src); err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if out, err = os.Create(dst); err != nil {
+		return err
+	}
+
+	if err = Decrypt(key, out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
+
+func newGCM(key []byte) (_ cipher.AEAD, err error) {
+	var block cipher.Block
+	if block, err = aes.NewCipher(key); err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// keyID identifies the key encryption key so that decrypting with the wrong key can be
+// reported without revealing anything about the key.
+func keyID(key []byte) []byte {
+	sum := sha256.Sum256(key)
+	return sum[:keyIDSize]
+}
+
+func chunkNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, 12)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[prefixSize:], counter)
+	if last {
+		nonce[11] = 1
+	}
+	return nonce
+}
@@ -0,0 +1,103 @@
+package backups
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// HTTP stores archives by making a PUT request with the archive as the body to the
+// base url joined with the archive name and retrieves them with a GET request to the
+// same url. Endpoints cannot be listed, so retention must be managed by the remote
+// target.
+type HTTP struct {
+	base   *url.URL
+	token  string
+	client *http.Client
+}
+
+var _ Sink = &HTTP{}
+
+// NewHTTP returns a sink that puts archives to the base url, authenticating with the
+// bearer token if it is not empty.
+func NewHTTP(base *url.URL, token string) *HTTP {
+	return &HTTP{
+		base:  base,
+		token: token,
+		client: &http.Client{
+			Timeout: 30 * time.Minute,
+		},
+	}
+}
+
+func (s *HTTP) Put(ctx context.Context, name string, r io.Reader, size int64) (err error) {
+	var req *http.Request
+	if req, err = s.request(ctx, http.MethodPut, name, r); err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.ContentLength = size
+	return s.do(req)
+}
+
+func (s *HTTP) List(context.Context) ([]*Object, error) {
+	return nil, ErrNotSupported
+}
+
+func (s *HTTP) Get(ctx context.Context, name string) (_ io.ReadCloser, err error) {
+	var req *http.Request
+	if req, err = s.request(ctx, http.MethodGet, name, nil); err != nil {
+		return nil, err
+	}
+
+	var rep *http.Response
+	if rep, err = s.client.Do(req); err != nil {
+		return nil, err
+	}
+
+	if err = checkStatus(req, rep); err != nil {
+		rep.Body.Close()
+		return nil, err
+	}
+	return rep.Body, nil
+}
+
+func (s *HTTP) Delete(ctx context.Context, name string) (err error) {
+	var req *http.Request
+	if req, err = s.request(ctx, http.MethodDelete, name, nil); err != nil {
+		return err
+	}
+	return s.do(req)
+}
+
+func (s *HTTP) request(ctx context.Context, method, name string, body io.Reader) (req *http.Request, err error) {
+	endpoint := s.base.JoinPath(strings.TrimPrefix(name, "/"))
+	if req, err = http.NewRequestWithContext(ctx, method, endpoint.String(), body); err != nil {
+		return nil, err
+	}
+
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	return req, nil
+}
+
+func (s *HTTP) do(req *http.Request) (err error) {
+	var rep *http.Response
+	if rep, err = s.client.Do(req); err != nil {
+		return err
+	}
+	defer rep.Body.Close()
+	return checkStatus(req, rep)
+}
+
+func checkStatus(req *http.Request, rep *http.Response) error {
+	if rep.StatusCode < 200 || rep.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned %s", req.Method, req.URL.Redacted(), rep.Status)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package backups
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local stores archives in a directory on disk.
+type Local struct {
+	dir string
+}
+
+var _ Sink = &Local{}
+
+// NewLocal returns a sink for the directory, creating it if it does not exist.
+func NewLocal(dir string) (_ *Local, err error) {
+	if dir == "" {
+		return nil, fmt.Errorf("incorrectly configured: no local backup storage directory")
+	}
+
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("could not create backup storage directory: %w", err)
+	}
+	return &Local{dir: dir}, nil
+}
+
+// Put writes the object to a temporary file that is renamed once it is complete so that
+// a partially written archive never appears in the directory.
+func (s *Local) Put(_ context.Context, name string, r io.Reader, _ int64) (err error) {
+	var f *os.File
+	if f, err = os.CreateTemp(s.dir, "."+name+"-*"); err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err = io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(s.dir, name))
+}
+
+func (s *Local) List(context.Context) (objects []*Object, err error) {
+	var entries []os.DirEntry
+	if entries, err = os.ReadDir(s.dir); err != nil {
+		return nil, err
+	}
+
+	objects = make([]*Object, 0, len(entries))
+	for _, entry := range entries {
+		// Skip directories and in progress writes
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		var info os.FileInfo
+		if info, err = entry.Info(); err != nil {
+			return nil, err
+		}
+		objects = append(objects, &Object{Name: entry.Name(), Size: info.Size()})
+	}
+	return objects, nil
+}
+
+func (s *Local) Get(_ context.Context, name string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(s.dir, name))
+}
+
+func (s *Local) Delete(_ context.Context, name string) error {
+	return os.Remove(filepath.Join(s.dir, name))
+}
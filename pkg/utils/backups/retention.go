@@ -0,0 +1,59 @@
+package backups
+
+import "fmt"
+
+// Retention determines which archives are kept in a sink. The Keep most recent archives
+// are always kept, along with the most recent archive of each of the last Daily days,
+// Weekly ISO weeks, and Monthly months that have archives. The most recent archive is
+// always kept even if all the values are zero.
+type Retention struct {
+	Keep    int
+	Daily   int
+	Weekly  int
+	Monthly int
+}
+
+// Expired returns the archives that are not retained by the policy. Archives must be
+// ordered by timestamp descending as returned by ParseArchives.
+func (r Retention) Expired(archives []*Archive) (expired []*Archive) {
+	keep := r.Keep
+	if keep < 1 {
+		keep = 1
+	}
+
+	days := make(map[string]struct{}, r.Daily)
+	weeks := make(map[string]struct{}, r.Weekly)
+	months := make(map[string]struct{}, r.Monthly)
+
+	for i, archive := range archives {
+		retained := i < keep
+
+		// Only the first (e.g. most recent) archive in each period is retained
+		if day := archive.Timestamp.Format("2006-01-02"); len(days) < r.Daily {
+			if _, ok := days[day]; !ok {
+				days[day] = struct{}{}
+				retained = true
+			}
+		}
+
+		year, wk := archive.Timestamp.ISOWeek()
+		if week := fmt.Sprintf("%04d-W%02d", year, wk); len(weeks) < r.Weekly {
+			if _, ok := weeks[week]; !ok {
+				weeks[week] = struct{}{}
+				retained = true
+			}
+		}
+
+		if month := archive.Timestamp.Format("2006-01"); len(months) < r.Monthly {
+			if _, ok := months[month]; !ok {
+				months[month] = struct{}{}
+				retained = true
+			}
+		}
+
+		if !retained {
+			expired = append(expired, archive)
+		}
+	}
+	return expired
+}
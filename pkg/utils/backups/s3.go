@@ -0,0 +1,89 @@
+package backups
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3 stores archives in a bucket of an S3 compatible object store such as AWS S3,
+// Google Cloud Storage (with HMAC keys), or MinIO.
+type S3 struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+var _ Sink = &S3{}
+
+// NewS3 returns a sink for the bucket and optional key prefix in the s3://bucket/prefix
+// url using the endpoint and credentials in the configuration.
+func NewS3(u *url.URL, conf SinkConfig) (_ *S3, err error) {
+	sink := &S3{
+		bucket: u.Host,
+		prefix: strings.Trim(u.Path, "/"),
+	}
+
+	if sink.client, err = minio.New(conf.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(conf.AccessKeyID, conf.SecretAccessKey, ""),
+		Secure: !conf.Insecure,
+		Region: conf.Region,
+	}); err != nil {
+		return nil, err
+	}
+	return sink, nil
+}
+
+func (s *S3) Put(ctx context.Context, name string, r io.Reader, size int64) (err error) {
+	_, err = s.client.PutObject(ctx, s.bucket, s.key(name), r, size, minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	})
+	return err
+}
+
+func (s *S3) List(ctx context.Context) (objects []*Object, err error) {
+	opts := minio.ListObjectsOptions{}
+	if s.prefix != "" {
+		opts.Prefix = s.prefix + "/"
+	}
+
+	objects = make([]*Object, 0)
+	for info := range s.client.ListObjects(ctx, s.bucket, opts) {
+		if info.Err != nil {
+			return nil, info.Err
+		}
+		objects = append(objects, &Object{Name: path.Base(info.Key), Size: info.Size})
+	}
+	return objects, nil
+}
+
+func (s *S3) Get(ctx context.Context, name string) (_ io.ReadCloser, err error) {
+	var obj *minio.Object
+	if obj, err = s.client.GetObject(ctx, s.bucket, s.key(name), minio.GetObjectOptions{}); err != nil {
+		return nil, err
+	}
+
+	// GetObject does not make a request until the object is read, so stat the object
+	// to return an error if it does not exist.
+	if _, err = obj.Stat(); err != nil {
+		obj.Close()
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (s *S3) Delete(ctx context.Context, name string) error {
+	return s.client.RemoveObject(ctx, s.bucket, s.key(name), minio.RemoveObjectOptions{})
+}
+
+func (s *S3) key(name string) string {
+	if s.prefix == "" {
+		return name
+	}
+	return s.prefix + "/" + name
+}
@@ -5,7 +5,8 @@ import (
 	sgmail "github.com/sendgrid/sendgrid-go/helpers/mail"
 )
 
-// EmailClient is an interface that can be implemented by SendGrid email clients.
+// EmailClient is an interface that is implemented by the SendGrid email client and by
+// the SMTP and Maildir transports, which render SendGrid messages as RFC 5322 emails.
 type EmailClient interface {
 	Send(email *sgmail.SGMailV3) (*rest.Response, error)
 }
@@ -0,0 +1,72 @@
+package emails
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/sendgrid/rest"
+	sgmail "github.com/sendgrid/sendgrid-go/helpers/mail"
+)
+
+// Maildir spools rendered emails into a Maildir directory so that they can be delivered
+// by another process (e.g. an MTA that relays mail out of an air-gapped network). Emails
+// are written into the tmp directory and moved into the new directory when complete so
+// that readers of the Maildir never see a partially written email.
+type Maildir struct {
+	path     string
+	hostname string
+}
+
+var deliveries uint64
+
+// NewMaildir creates the Maildir at the specified path if it does not exist.
+func NewMaildir(path string) (_ *Maildir, err error) {
+	for _, dir := range []string{"tmp", "new", "cur"} {
+		if err = os.MkdirAll(filepath.Join(path, dir), 0700); err != nil {
+			return nil, fmt.Errorf("could not create maildir: %w", err)
+		}
+	}
+
+	m := &Maildir{path: path}
+	if m.hostname, err = os.Hostname(); err != nil {
+		m.hostname = "localhost"
+	}
+	return m, nil
+}
+
+// Send the email by spooling it into the new directory of the Maildir. The response
+// mirrors the SendGrid client so that the Maildir can be used by the email managers.
+func (m *Maildir) Send(msg *sgmail.SGMailV3) (_ *rest.Response, err error) {
+	var data []byte
+	if data, err = Render(msg); err != nil {
+		return &rest.Response{StatusCode: http.StatusBadRequest, Body: err.Error()}, err
+	}
+
+	var path string
+	if path, err = m.deliver(data); err != nil {
+		return &rest.Response{StatusCode: http.StatusInternalServerError, Body: err.Error()}, err
+	}
+	return &rest.Response{StatusCode: http.StatusOK, Body: path}, nil
+}
+
+func (m *Maildir) deliver(data []byte) (path string, err error) {
+	// Unique filenames are created using the Maildir time.pid_count.hostname convention
+	now := time.Now()
+	name := fmt.Sprintf("%d.M%dP%d_%d.%s", now.Unix(), now.Nanosecond()/1000, os.Getpid(), atomic.AddUint64(&deliveries, 1), m.hostname)
+
+	tmp := filepath.Join(m.path, "tmp", name)
+	if err = os.WriteFile(tmp, data, 0600); err != nil {
+		return "", fmt.Errorf("could not spool email: %w", err)
+	}
+
+	path = filepath.Join(m.path, "new", name)
+	if err = os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("could not deliver spooled email: %w", err)
+	}
+	return path, nil
+}
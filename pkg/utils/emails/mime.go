@@ -2,11 +2,19 @@ package emails
 
 import (
 	"bytes"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
 	"net/textproto"
 	"os"
+	"strings"
+	"time"
 
 	sgmail "github.com/sendgrid/sendgrid-go/helpers/mail"
 )
@@ -101,3 +109,148 @@ func GetRecipient(msg *sgmail.SGMailV3) (recipient string, err error) {
 	}
 	return "", errors.New("no recipient found for email")
 }
+
+// Recipients returns the addresses of all of the To, Cc, and Bcc recipients of the
+// email, which are the envelope recipients when the email is relayed over SMTP.
+func Recipients(msg *sgmail.SGMailV3) (recipients []string) {
+	for _, p := range msg.Personalizations {
+		for _, group := range [][]*sgmail.Email{p.To, p.CC, p.BCC} {
+			for _, r := range group {
+				if r != nil && r.Address != "" {
+					recipients = append(recipients, r.Address)
+				}
+			}
+		}
+	}
+	return recipients
+}
+
+// Render the email as an RFC 5322 message so that it can be sent by transports other
+// than SendGrid. The content of the email is sent as a multipart/alternative section
+// and attachments (which are base64 encoded by SendGrid helpers) are appended to the
+// multipart/mixed message. Bcc recipients are not included in the headers.
+func Render(msg *sgmail.SGMailV3) (_ []byte, err error) {
+	if msg.From == nil || msg.From.Address == "" {
+		return nil, errors.New("requires From address")
+	}
+
+	var to, cc []string
+	for _, p := range msg.Personalizations {
+		for _, r := range p.To {
+			if r != nil && r.Address != "" {
+				to = append(to, formatAddress(r))
+			}
+		}
+		for _, r := range p.CC {
+			if r != nil && r.Address != "" {
+				cc = append(cc, formatAddress(r))
+			}
+		}
+	}
+
+	if len(to) == 0 {
+		return nil, errors.New("requires To address")
+	}
+
+	// Write the message headers
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	fmt.Fprintf(body, "From: %s\r\n", formatAddress(msg.From))
+	fmt.Fprintf(body, "To: %s\r\n", strings.Join(to, ", "))
+	if len(cc) > 0 {
+		fmt.Fprintf(body, "Cc: %s\r\n", strings.Join(cc, ", "))
+	}
+	if msg.ReplyTo != nil && msg.ReplyTo.Address != "" {
+		fmt.Fprintf(body, "Reply-To: %s\r\n", formatAddress(msg.ReplyTo))
+	}
+	fmt.Fprintf(body, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(body, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(body, "Message-ID: %s\r\n", messageID(msg.From.Address))
+	fmt.Fprintf(body, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(body, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", writer.Boundary())
+
+	// Write the email content sections as alternatives of each other
+	if len(msg.Content) > 0 {
+		content := new(bytes.Buffer)
+		alternatives := multipart.NewWriter(content)
+		for _, c := range msg.Content {
+			header := textproto.MIMEHeader{}
+			header.Set("Content-Type", c.Type+"; charset=utf-8")
+			header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+			var part io.Writer
+			if part, err = alternatives.CreatePart(header); err != nil {
+				return nil, err
+			}
+
+			qp := quotedprintable.NewWriter(part)
+			if _, err = qp.Write([]byte(c.Value)); err != nil {
+				return nil, err
+			}
+			if err = qp.Close(); err != nil {
+				return nil, err
+			}
+		}
+
+		if err = alternatives.Close(); err != nil {
+			return nil, err
+		}
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", alternatives.Boundary()))
+
+		var part io.Writer
+		if part, err = writer.CreatePart(header); err != nil {
+			return nil, err
+		}
+		if _, err = content.WriteTo(part); err != nil {
+			return nil, err
+		}
+	}
+
+	// Write the attachment sections, the content is already base64 encoded
+	for _, a := range msg.Attachments {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", mime.FormatMediaType(a.Type, map[string]string{"name": a.Filename}))
+		header.Set("Content-Disposition", mime.FormatMediaType(a.Disposition, map[string]string{"filename": a.Filename}))
+		header.Set("Content-Transfer-Encoding", "base64")
+
+		var part io.Writer
+		if part, err = writer.CreatePart(header); err != nil {
+			return nil, err
+		}
+
+		for content := a.Content; len(content) > 0; {
+			n := len(content)
+			if n > 76 {
+				n = 76
+			}
+			if _, err = fmt.Fprintf(part, "%s\r\n", content[:n]); err != nil {
+				return nil, err
+			}
+			content = content[n:]
+		}
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	return body.Bytes(), nil
+}
+
+func formatAddress(email *sgmail.Email) string {
+	addr := &mail.Address{Name: email.Name, Address: email.Address}
+	return addr.String()
+}
+
+// Create a unique message ID in the domain of the sender.
+func messageID(from string) string {
+	domain := "localhost"
+	if idx := strings.LastIndex(from, "@"); idx >= 0 {
+		domain = from[idx+1:]
+	}
+
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+	return fmt.Sprintf("<%d.%x@%s>", time.Now().UnixNano(), nonce, domain)
+}
@@ -0,0 +1,138 @@
+package emails
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"time"
+
+	"github.com/sendgrid/rest"
+	sgmail "github.com/sendgrid/sendgrid-go/helpers/mail"
+)
+
+// DefaultSMTPPort is the submission port used if the SMTP URL does not specify a port.
+const DefaultSMTPPort = "587"
+
+// DefaultSMTPTimeout limits how long an email can take to send if no timeout is
+// configured so that an unresponsive server cannot block the sender indefinitely.
+const DefaultSMTPTimeout = 30 * time.Second
+
+// SMTP relays rendered emails through an SMTP server such as the mail relay of a
+// private TRISA network. Connections are upgraded with STARTTLS before authenticating
+// unless the transport is configured as insecure (e.g. for a local sink like MailHog).
+type SMTP struct {
+	addr     string
+	host     string
+	auth     smtp.Auth
+	insecure bool
+	timeout  time.Duration
+}
+
+// NewSMTP creates an SMTP transport for the smtp://[user:password@]host[:port] URL.
+func NewSMTP(u *url.URL, conf TransportConfig) *SMTP {
+	c := &SMTP{
+		host:     u.Hostname(),
+		insecure: conf.Insecure,
+		timeout:  conf.Timeout,
+	}
+
+	if c.timeout <= 0 {
+		c.timeout = DefaultSMTPTimeout
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = DefaultSMTPPort
+	}
+	c.addr = net.JoinHostPort(c.host, port)
+
+	username, password := conf.Username, conf.Password
+	if username == "" && u.User != nil {
+		username = u.User.Username()
+		password, _ = u.User.Password()
+	}
+
+	if username != "" {
+		c.auth = smtp.PlainAuth("", username, password, c.host)
+	}
+	return c
+}
+
+// Send the email through the SMTP server. The response mirrors the SendGrid client so
+// that the SMTP transport can be used by the email managers interchangeably.
+func (c *SMTP) Send(msg *sgmail.SGMailV3) (_ *rest.Response, err error) {
+	var data []byte
+	if data, err = Render(msg); err != nil {
+		return &rest.Response{StatusCode: http.StatusBadRequest, Body: err.Error()}, err
+	}
+
+	if err = c.send(msg.From.Address, Recipients(msg), data); err != nil {
+		return &rest.Response{StatusCode: http.StatusBadGateway, Body: err.Error()}, err
+	}
+	return &rest.Response{StatusCode: http.StatusOK}, nil
+}
+
+func (c *SMTP) send(from string, to []string, data []byte) (err error) {
+	var conn net.Conn
+	if conn, err = net.DialTimeout("tcp", c.addr, c.timeout); err != nil {
+		return fmt.Errorf("could not connect to smtp server: %w", err)
+	}
+
+	// The deadline applies to the entire conversation with the server
+	if err = conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		conn.Close()
+		return fmt.Errorf("could not set smtp connection deadline: %w", err)
+	}
+
+	var client *smtp.Client
+	if client, err = smtp.NewClient(conn, c.host); err != nil {
+		conn.Close()
+		return fmt.Errorf("could not connect to smtp server: %w", err)
+	}
+	defer client.Close()
+
+	if !c.insecure {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("smtp server does not support STARTTLS")
+		}
+
+		if err = client.StartTLS(&tls.Config{ServerName: c.host}); err != nil {
+			return fmt.Errorf("could not start tls: %w", err)
+		}
+	}
+
+	if c.auth != nil {
+		if err = client.Auth(c.auth); err != nil {
+			return fmt.Errorf("could not authenticate with smtp server: %w", err)
+		}
+	}
+
+	if err = client.Mail(from); err != nil {
+		return err
+	}
+
+	for _, rcpt := range to {
+		if err = client.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+
+	var w io.WriteCloser
+	if w, err = client.Data(); err != nil {
+		return err
+	}
+
+	if _, err = w.Write(data); err != nil {
+		return err
+	}
+
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
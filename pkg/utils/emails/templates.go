@@ -0,0 +1,135 @@
+package emails
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Template extensions that are loaded as email template overrides. Subject templates
+// allow operators to localize or rebrand the subject line of an email.
+const (
+	TextExt    = ".txt"
+	HTMLExt    = ".html"
+	SubjectExt = ".subject"
+)
+
+var localeRE = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// NormalizeLocale returns the lower case, hyphenated form of a locale such as ja or
+// es_MX (returned as es-mx) or an error if the locale is not a valid language tag.
+func NormalizeLocale(locale string) (string, error) {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if !localeRE.MatchString(normalized) {
+		return "", fmt.Errorf("invalid locale %q", locale)
+	}
+	return normalized, nil
+}
+
+// Locales returns the fallback chain of locales that is searched for localized email
+// templates: each of the preferred locales in order followed by its base language,
+// e.g. es-MX, es, then the next preferred locale. Empty and invalid locales are skipped
+// and the chain does not contain duplicates. Callers should fall back to the default
+// templates if no localized template is found for any locale in the chain.
+func Locales(preferred ...string) []string {
+	chain := make([]string, 0, 2*len(preferred))
+	seen := make(map[string]struct{}, 2*len(preferred))
+	add := func(locale string) {
+		if _, ok := seen[locale]; !ok {
+			seen[locale] = struct{}{}
+			chain = append(chain, locale)
+		}
+	}
+
+	for _, locale := range preferred {
+		var err error
+		if locale, err = NormalizeLocale(locale); err != nil {
+			continue
+		}
+
+		add(locale)
+		if base, _, ok := strings.Cut(locale, "-"); ok {
+			add(base)
+		}
+	}
+	return chain
+}
+
+// LocalizedName returns the name of the template for the specified locale, which is
+// the path of the template relative to the template directory, e.g. ja/verify_contact.txt.
+func LocalizedName(locale, name string) string {
+	if locale == "" {
+		return name
+	}
+	return locale + "/" + name
+}
+
+// ReadTemplates reads the email template overrides from the specified directory so
+// that operators can customize the branding of emails without recompiling. Templates in
+// the root of the directory replace the compiled-in templates with the same name, while
+// templates in a subdirectory are localized for the locale named by the subdirectory,
+// e.g. ja/verify_contact.html. The templates are returned by their localized name.
+func ReadTemplates(dir string) (templates map[string]string, err error) {
+	templates = make(map[string]string)
+	if dir == "" {
+		return templates, nil
+	}
+
+	var info os.FileInfo
+	if info, err = os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("could not read email templates: %w", err)
+	}
+
+	if !info.IsDir() {
+		return nil, fmt.Errorf("could not read email templates: %s is not a directory", dir)
+	}
+
+	err = filepath.WalkDir(dir, func(fpath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		switch filepath.Ext(fpath) {
+		case TextExt, HTMLExt, SubjectExt:
+		default:
+			return nil
+		}
+
+		var rel string
+		if rel, err = filepath.Rel(dir, fpath); err != nil {
+			return err
+		}
+
+		locale, name := path.Split(filepath.ToSlash(rel))
+		if locale != "" {
+			if strings.Count(locale, "/") > 1 {
+				return fmt.Errorf("email template %s must be in a locale directory", rel)
+			}
+
+			if locale, err = NormalizeLocale(strings.TrimSuffix(locale, "/")); err != nil {
+				return fmt.Errorf("email template %s: %w", rel, err)
+			}
+		}
+
+		var data []byte
+		if data, err = os.ReadFile(fpath); err != nil {
+			return err
+		}
+
+		templates[LocalizedName(locale, name)] = string(data)
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("could not read email templates: %w", err)
+	}
+	return templates, nil
+}
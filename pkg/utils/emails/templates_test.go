@@ -0,0 +1,81 @@
+package emails_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/utils/emails"
+)
+
+func TestLocales(t *testing.T) {
+	testCases := []struct {
+		preferred []string
+		expected  []string
+	}{
+		{nil, []string{}},
+		{[]string{"", "en"}, []string{"en"}},
+		{[]string{"ja"}, []string{"ja"}},
+		{[]string{"es_MX", "en"}, []string{"es-mx", "es", "en"}},
+		{[]string{"zh-Hant-TW", "zh", "en"}, []string{"zh-hant-tw", "zh", "en"}},
+		{[]string{"ko-KR", "ko", "ko-kr"}, []string{"ko-kr", "ko"}},
+		{[]string{"not a locale", "../ja", "ja"}, []string{"ja"}},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, emails.Locales(tc.preferred...), "unexpected fallback chain for %v", tc.preferred)
+	}
+
+	locale, err := emails.NormalizeLocale(" pt_BR ")
+	require.NoError(t, err)
+	require.Equal(t, "pt-br", locale)
+
+	_, err = emails.NormalizeLocale("english")
+	require.EqualError(t, err, `invalid locale "english"`)
+
+	require.Equal(t, "verify_contact.txt", emails.LocalizedName("", "verify_contact.txt"))
+	require.Equal(t, "ja/verify_contact.txt", emails.LocalizedName("ja", "verify_contact.txt"))
+}
+
+func TestReadTemplates(t *testing.T) {
+	// No templates are read if a directory is not specified
+	templates, err := emails.ReadTemplates("")
+	require.NoError(t, err)
+	require.Empty(t, templates)
+
+	_, err = emails.ReadTemplates(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+
+	dir := t.TempDir()
+	writeTemplate(t, dir, "verify_contact.html", "<p>Branded</p>")
+	writeTemplate(t, dir, "ja/verify_contact.txt", "こんにちは")
+	writeTemplate(t, dir, "ja/verify_contact.subject", "メールアドレスを確認してください")
+	writeTemplate(t, dir, "es_MX/verify_contact.txt", "Hola")
+	writeTemplate(t, dir, "ja/README.md", "ignored")
+
+	templates, err = emails.ReadTemplates(dir)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"verify_contact.html":       "<p>Branded</p>",
+		"ja/verify_contact.txt":     "こんにちは",
+		"ja/verify_contact.subject": "メールアドレスを確認してください",
+		"es-mx/verify_contact.txt":  "Hola",
+	}, templates)
+
+	// Templates must be in a valid locale directory
+	writeTemplate(t, dir, "japanese/verify_contact.txt", "こんにちは")
+	_, err = emails.ReadTemplates(dir)
+	require.EqualError(t, err, `could not read email templates: email template japanese/verify_contact.txt: invalid locale "japanese"`)
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "japanese")))
+
+	writeTemplate(t, dir, "ja/jp/verify_contact.txt", "こんにちは")
+	_, err = emails.ReadTemplates(dir)
+	require.EqualError(t, err, "could not read email templates: email template ja/jp/verify_contact.txt must be in a locale directory")
+}
+
+func writeTemplate(t *testing.T, dir, name, data string) {
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(data), 0644))
+}
@@ -0,0 +1,75 @@
+package emails
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/sendgrid/sendgrid-go"
+)
+
+// TransportConfig describes how rendered emails are delivered. If the URL is empty,
+// emails are sent with the SendGrid API; otherwise the URL scheme selects the transport:
+// smtp://host:port relays emails through an SMTP server using STARTTLS and maildir:///path
+// (or file:///path) spools emails into a Maildir directory, e.g. for air-gapped networks
+// where another process is responsible for delivering the spooled emails.
+type TransportConfig struct {
+	URL      string        `required:"false"`
+	Username string        `required:"false"` // SMTP username, overrides the user in the URL
+	Password string        `required:"false"` // SMTP password, overrides the password in the URL
+	Insecure bool          `default:"false"`  // send SMTP emails without STARTTLS, e.g. to a local sink
+	Timeout  time.Duration `default:"30s"`    // SMTP connection timeout, covers the entire send
+}
+
+func (c TransportConfig) Validate() (err error) {
+	if c.URL == "" {
+		return nil
+	}
+
+	var u *url.URL
+	if u, err = url.Parse(c.URL); err != nil {
+		return fmt.Errorf("invalid email transport url: %w", err)
+	}
+
+	switch u.Scheme {
+	case "smtp":
+		if u.Hostname() == "" {
+			return errors.New("invalid email transport: smtp url must contain a host")
+		}
+	case "maildir", "file":
+		if u.Path == "" {
+			return errors.New("invalid email transport: maildir url must contain a path")
+		}
+	default:
+		return fmt.Errorf("unknown email transport scheme %q", u.Scheme)
+	}
+	return nil
+}
+
+// Open the email client for the transport described by the configuration. If the URL
+// is empty, a SendGrid client is returned, which requires the SendGrid API key.
+func Open(conf TransportConfig, sendGridAPIKey string) (_ EmailClient, err error) {
+	if err = conf.Validate(); err != nil {
+		return nil, err
+	}
+
+	if conf.URL == "" {
+		if sendGridAPIKey == "" {
+			return nil, errors.New("cannot create sendgrid client without API key")
+		}
+		return sendgrid.NewSendClient(sendGridAPIKey), nil
+	}
+
+	var u *url.URL
+	if u, err = url.Parse(conf.URL); err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "smtp":
+		return NewSMTP(u, conf), nil
+	default:
+		return NewMaildir(u.Path)
+	}
+}
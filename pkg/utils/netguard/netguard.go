@@ -0,0 +1,94 @@
+/*
+Package netguard prevents outbound requests to user supplied URLs, such as webhooks and
+domain challenges, from reaching internal services of the directory, e.g. the loopback
+interface, private networks, or the cloud metadata endpoint.
+*/
+package netguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrNonPublicAddress is returned when a host resolves to an address that is not
+// reachable on the public internet.
+var ErrNonPublicAddress = errors.New("address is not a public internet address")
+
+// Shared address space for carrier-grade NAT (RFC 6598) is not covered by net.IP.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsPublic returns true if the IP address is a global unicast address that is not in a
+// private, shared, or loopback range. Link-local addresses, including the cloud
+// metadata endpoint at 169.254.169.254, are not global unicast addresses.
+func IsPublic(ip net.IP) bool {
+	if ip == nil || !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+
+	if ip4 := ip.To4(); ip4 != nil && (ip4[0] == 0 || sharedAddressSpace.Contains(ip4)) {
+		return false
+	}
+	return true
+}
+
+// CheckHost resolves the host and returns ErrNonPublicAddress if the host or any of the
+// addresses it resolves to are not public. The host may be an IP address and may
+// include a port.
+func CheckHost(ctx context.Context, host string) (err error) {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		if !IsPublic(ip) {
+			return fmt.Errorf("%s: %w", host, ErrNonPublicAddress)
+		}
+		return nil
+	}
+
+	var addrs []net.IPAddr
+	if addrs, err = net.DefaultResolver.LookupIPAddr(ctx, host); err != nil {
+		return fmt.Errorf("could not resolve %s: %w", host, err)
+	}
+
+	for _, addr := range addrs {
+		if !IsPublic(addr.IP) {
+			return fmt.Errorf("%s resolves to %s: %w", host, addr.IP, ErrNonPublicAddress)
+		}
+	}
+	return nil
+}
+
+// Control is a net.Dialer control function that refuses to connect to addresses that
+// are not public. It is called with the resolved address so it cannot be bypassed by a
+// host that resolves to a different address when it is dialed than when it was checked.
+func Control(network, address string, _ syscall.RawConn) (err error) {
+	var host string
+	if host, _, err = net.SplitHostPort(address); err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(host); !IsPublic(ip) {
+		return fmt.Errorf("%s: %w", host, ErrNonPublicAddress)
+	}
+	return nil
+}
+
+// NewTransport returns an HTTP transport that only connects to public addresses.
+// Proxies from the environment are not used since the proxy address would be checked
+// rather than the address of the requested host.
+func NewTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   Control,
+	}).DialContext
+	return transport
+}
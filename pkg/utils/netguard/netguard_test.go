@@ -0,0 +1,51 @@
+package netguard_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/utils/netguard"
+)
+
+func TestIsPublic(t *testing.T) {
+	for _, addr := range []string{
+		"127.0.0.1", "::1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254",
+		"fe80::1", "fc00::1", "0.0.0.0", "100.64.0.1", "224.0.0.1", "::ffff:127.0.0.1",
+	} {
+		require.False(t, netguard.IsPublic(net.ParseIP(addr)), "expected %s to not be public", addr)
+	}
+
+	for _, addr := range []string{"8.8.8.8", "1.1.1.1", "2606:4700:4700::1111"} {
+		require.True(t, netguard.IsPublic(net.ParseIP(addr)), "expected %s to be public", addr)
+	}
+	require.False(t, netguard.IsPublic(nil))
+}
+
+func TestCheckHost(t *testing.T) {
+	ctx := context.Background()
+	for _, host := range []string{"127.0.0.1", "127.0.0.1:8080", "[::1]:443", "169.254.169.254", "localhost"} {
+		require.ErrorIs(t, netguard.CheckHost(ctx, host), netguard.ErrNonPublicAddress, "expected %s to be rejected", host)
+	}
+	require.NoError(t, netguard.CheckHost(ctx, "8.8.8.8:443"))
+}
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	// The default transport can connect to the loopback server
+	rep, err := srv.Client().Get(srv.URL)
+	require.NoError(t, err)
+	rep.Body.Close()
+
+	// The guarded transport refuses to connect to the loopback server
+	client := &http.Client{Transport: netguard.NewTransport()}
+	_, err = client.Get(srv.URL)
+	require.ErrorIs(t, err, netguard.ErrNonPublicAddress)
+}
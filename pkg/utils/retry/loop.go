@@ -0,0 +1,108 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/utils/sentry"
+)
+
+// ProcessFunc performs one iteration through the work items of a queue.
+type ProcessFunc func(ctx context.Context) error
+
+// NewLoop creates a loop that calls the process function every interval once it is run.
+// The name is used to identify the loop in log messages.
+func NewLoop(name string, interval time.Duration, process ProcessFunc) *Loop {
+	return &Loop{
+		name:     name,
+		interval: interval,
+		process:  process,
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+// Loop runs a process function in a go routine when it is started, every interval, and
+// whenever it is notified that new work items are available, until it is stopped.
+type Loop struct {
+	mu       sync.Mutex
+	name     string
+	interval time.Duration
+	process  ProcessFunc
+	notify   chan struct{}
+	stop     chan struct{}
+}
+
+// Run starts the Loop as a go routine under the provided waitgroup. For graceful
+// shutdown, the caller must invoke the Stop method to signal the Loop routine to stop
+// and block on the waitgroup if provided.
+func (l *Loop) Run(wg *sync.WaitGroup) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.stop != nil {
+		return errors.New(l.name + " is already running")
+	}
+
+	if wg != nil {
+		wg.Add(1)
+	}
+
+	// The go routine only reads its own stop channel so that the field can be reset by
+	// Stop without racing the routine as it shuts down.
+	l.stop = make(chan struct{})
+	go func(stop <-chan struct{}) {
+		l.run(stop)
+		if wg != nil {
+			wg.Done()
+		}
+	}(l.stop)
+	return nil
+}
+
+// Stop signals the Loop routine to shutdown. Stop is safe to call more than once and
+// if the Loop is not running.
+// Note: This does not wait for the Loop to stop and the caller should block on the
+// waitgroup passed to the Run method in order to implement a graceful shutdown.
+func (l *Loop) Stop() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.stop != nil {
+		close(l.stop)
+		l.stop = nil
+	}
+}
+
+// Notify the Loop that new work items are available so that they are processed without
+// waiting for the next interval. Notify never blocks; if the Loop is not running, the
+// notification is handled when it starts.
+func (l *Loop) Notify() {
+	select {
+	case l.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (l *Loop) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+	log.Info().Dur("interval", l.interval).Msg(l.name + " started")
+
+	for {
+		// Process on startup to resume any work that was interrupted by a restart
+		if err := l.process(context.Background()); err != nil {
+			sentry.Error(nil).Err(err).Msg(l.name + " could not process")
+		}
+
+		select {
+		case <-stop:
+			log.Info().Msg(l.name + " received stop signal")
+			return
+		case <-ticker.C:
+		case <-l.notify:
+		}
+	}
+}
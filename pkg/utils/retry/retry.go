@@ -0,0 +1,72 @@
+/*
+Package retry implements the exponential backoff and the background processing loop
+shared by the durable queues of the directory service, such as the registration job
+queue, which persist their work items and periodically retry failed attempts until
+they succeed or a maximum number of attempts is reached.
+*/
+package retry
+
+import (
+	"time"
+)
+
+// Outcome of an attempt to process a work item.
+type Outcome uint8
+
+const (
+	Succeeded Outcome = iota // the attempt succeeded and the item is finished
+	Retrying                 // the attempt failed and the item should be retried
+	Failed                   // the attempt failed and no attempts remain
+)
+
+// Policy describes how failed attempts are retried: the delay before the first retry
+// is doubled for every subsequent attempt up to the maximum backoff until the maximum
+// number of attempts has been made.
+type Policy struct {
+	MaxAttempts uint32
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Attempt returns the outcome of an attempt that returned the specified error, where
+// attempts is the number of attempts made including this one. If the item should be
+// retried, the RFC3339 timestamp of the next attempt is also returned.
+func (p Policy) Attempt(attempts uint32, err error, now time.Time) (_ Outcome, nextAttempt string) {
+	switch {
+	case err == nil:
+		return Succeeded, ""
+	case attempts >= p.MaxAttempts:
+		return Failed, ""
+	default:
+		return Retrying, now.Add(p.Delay(attempts)).Format(time.RFC3339)
+	}
+}
+
+// Delay returns the delay before the next attempt, which doubles with every attempt up
+// to the maximum backoff.
+func (p Policy) Delay(attempts uint32) time.Duration {
+	delay := p.Backoff
+	for i := uint32(1); i < attempts && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return delay
+}
+
+// Expired returns true if the RFC3339 timestamp when an item finished is older than
+// the retention period. Items are never expired if the retention is zero or if the
+// timestamp cannot be parsed.
+func Expired(finished string, retention time.Duration, now time.Time) bool {
+	if retention <= 0 {
+		return false
+	}
+
+	ts, err := time.Parse(time.RFC3339, finished)
+	if err != nil {
+		return false
+	}
+	return now.Sub(ts) > retention
+}
@@ -0,0 +1,90 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/utils/retry"
+)
+
+func TestPolicy(t *testing.T) {
+	policy := retry.Policy{MaxAttempts: 4, Backoff: time.Minute, MaxBackoff: 3 * time.Minute}
+	now := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	failure := errors.New("connection refused")
+
+	// Successful attempts are never retried
+	outcome, next := policy.Attempt(1, nil, now)
+	require.Equal(t, retry.Succeeded, outcome)
+	require.Empty(t, next)
+
+	// The delay should double with every attempt up to the max backoff
+	testCases := []struct {
+		attempts uint32
+		delay    time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{3, 3 * time.Minute},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.delay, policy.Delay(tc.attempts), "unexpected delay for attempt %d", tc.attempts)
+		outcome, next = policy.Attempt(tc.attempts, failure, now)
+		require.Equal(t, retry.Retrying, outcome)
+		require.Equal(t, now.Add(tc.delay).Format(time.RFC3339), next)
+	}
+
+	// No attempts remain after the max attempts
+	outcome, next = policy.Attempt(4, failure, now)
+	require.Equal(t, retry.Failed, outcome)
+	require.Empty(t, next)
+}
+
+func TestExpired(t *testing.T) {
+	now := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	finished := now.Add(-2 * time.Hour).Format(time.RFC3339)
+
+	require.True(t, retry.Expired(finished, time.Hour, now))
+	require.False(t, retry.Expired(finished, 3*time.Hour, now))
+	require.False(t, retry.Expired(finished, 0, now), "items should not expire without a retention")
+	require.False(t, retry.Expired("", time.Hour, now), "unfinished items should not expire")
+}
+
+func TestLoop(t *testing.T) {
+	calls := make(chan struct{}, 4)
+	loop := retry.NewLoop("test loop", time.Hour, func(context.Context) error {
+		calls <- struct{}{}
+		return nil
+	})
+
+	wg := &sync.WaitGroup{}
+	require.NoError(t, loop.Run(wg))
+	require.Error(t, loop.Run(wg), "expected error when the loop is already running")
+
+	// The loop should process on startup and whenever it is notified
+	requireCalled(t, calls)
+	loop.Notify()
+	requireCalled(t, calls)
+
+	loop.Stop()
+	wg.Wait()
+
+	// Stopping the loop again should not panic and the loop should be able to restart
+	loop.Stop()
+	require.NoError(t, loop.Run(wg))
+	requireCalled(t, calls)
+	loop.Stop()
+	wg.Wait()
+}
+
+func requireCalled(t *testing.T, calls <-chan struct{}) {
+	select {
+	case <-calls:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "expected the loop to call the process function")
+	}
+}
@@ -29,16 +29,19 @@ var (
 
 // Namespace constants for all managed objects in GDS
 const (
-	NamespaceVASPs         = "vasps"
-	NamespaceCerts         = "certs"
-	NamespaceCertReqs      = "certreqs"
-	NamespaceReplicas      = "peers"
-	NamespaceIndices       = "index"
-	NamespaceSequence      = "sequence"
-	NamespaceAnnouncements = "announcements"
-	NamespaceActivities    = "activities"
-	NamespaceOrganizations = "organizations"
-	NamespaceContacts      = "contacts"
+	NamespaceVASPs          = "vasps"
+	NamespaceCerts          = "certs"
+	NamespaceCertReqs       = "certreqs"
+	NamespaceCertReqArchive = "certreqs_archive"
+	NamespaceReplicas       = "peers"
+	NamespaceIndices        = "index"
+	NamespaceSequence       = "sequence"
+	NamespaceAnnouncements  = "announcements"
+	NamespaceActivities     = "activities"
+	NamespaceOrganizations  = "organizations"
+	NamespaceContacts       = "contacts"
+	NamespaceJobs           = "jobs"
+	NamespaceEmails         = "emails"
 )
 
 // Namespaces defines all possible namespaces that GDS manages
@@ -62,7 +65,7 @@ func UnmarshalProto(namespace string, data []byte) (_ proto.Message, err error)
 			return nil, fmt.Errorf("could not unmarshal %s to %T: %s", namespace, cert, err)
 		}
 		return cert, nil
-	case NamespaceCertReqs:
+	case NamespaceCertReqs, NamespaceCertReqArchive:
 		certreq := &models.CertificateRequest{}
 		if err = proto.Unmarshal(data, certreq); err != nil {
 			return nil, fmt.Errorf("could not unmarshal %s to %T: %s", namespace, certreq, err)
@@ -135,7 +138,7 @@ func RemarshalJSON(namespace string, in []byte) (out []byte, err error) {
 			return nil, fmt.Errorf("could not unmarshal json %s into %T: %s", namespace, cert, err)
 		}
 		return proto.Marshal(cert)
-	case NamespaceCertReqs:
+	case NamespaceCertReqs, NamespaceCertReqArchive:
 		certreq := &models.CertificateRequest{}
 		if err = jsonpb.Unmarshal(in, certreq); err != nil {
 			return nil, fmt.Errorf("could not unmarshal json %s into %T: %s", namespace, certreq, err)